@@ -0,0 +1,202 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apikey authorizes requests to the admin API. Requests carry an API
+// key as a bearer token or present a TLS client certificate. Both grant a set
+// of scopes and may be restricted to the identities of an organization.
+package apikey
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	// ScopeAdmin grants access to all admin APIs.
+	ScopeAdmin = "admin"
+	// ScopeIdentitiesRead grants read access to identities and related
+	// resources, such as invitations and registration approvals.
+	ScopeIdentitiesRead = "identities:read"
+	// ScopeIdentitiesWrite grants write access to identities and related
+	// resources, including creating recovery links and codes.
+	ScopeIdentitiesWrite = "identities:write"
+	// ScopeSessionsRead grants read access to sessions and trusted devices.
+	ScopeSessionsRead = "sessions:read"
+	// ScopeSessionsWrite grants extending sessions and impersonating
+	// identities.
+	ScopeSessionsWrite = "sessions:write"
+	// ScopeSessionsRevoke grants revoking sessions and trusted devices.
+	ScopeSessionsRevoke = "sessions:revoke"
+	// ScopeCourierRead grants read access to courier messages.
+	ScopeCourierRead = "courier:read"
+
+	// TokenPrefix is prepended to all API keys.
+	TokenPrefix = "ory_ak_"
+
+	secretLength = 32
+)
+
+// Scopes lists all known scopes.
+var Scopes = []string{
+	ScopeAdmin,
+	ScopeIdentitiesRead,
+	ScopeIdentitiesWrite,
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+	ScopeSessionsRevoke,
+	ScopeCourierRead,
+}
+
+type (
+	// Key is an API key for the admin API. Only a hash of its secret is
+	// stored.
+	//
+	// swagger:model apiKey
+	Key struct {
+		// ID is the API key's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// Name describes the API key, for example the service using it.
+		//
+		// required: true
+		Name string `json:"name" db:"name"`
+
+		// Scopes are the admin API scopes granted to the API key.
+		//
+		// required: true
+		Scopes sqlxx.StringSliceJSONFormat `json:"scopes" faker:"-" db:"scopes"`
+
+		// OrganizationID restricts the API key to manage the identities of
+		// this organization.
+		OrganizationID uuid.NullUUID `json:"organization_id" faker:"-" db:"organization_id"`
+
+		// ExpiresAt is the time (UTC) after which the API key is no longer
+		// accepted. API keys without an expiry do not expire.
+		ExpiresAt *sqlxx.NullTime `json:"expires_at,omitempty" faker:"-" db:"expires_at"`
+
+		// LastUsedAt is the time (UTC) at which the API key was last used.
+		// It is updated at most once per minute.
+		LastUsedAt *sqlxx.NullTime `json:"last_used_at,omitempty" faker:"-" db:"last_used_at"`
+
+		// RevokedAt is the time (UTC) at which the API key was revoked.
+		RevokedAt *sqlxx.NullTime `json:"revoked_at,omitempty" faker:"-" db:"revoked_at"`
+
+		SecretHash string `json:"-" faker:"-" db:"secret_hash"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	// Principal is the authorized caller of the admin API.
+	Principal struct {
+		// KeyID is the ID of the API key used, if any.
+		KeyID uuid.NullUUID
+		// Subject is the common name of the TLS client certificate used, if
+		// any.
+		Subject        string
+		Scopes         []string
+		OrganizationID uuid.NullUUID
+	}
+
+	Persister interface {
+		CreateAPIKey(ctx context.Context, k *Key) error
+		GetAPIKey(ctx context.Context, id uuid.UUID) (*Key, error)
+		ListAPIKeys(ctx context.Context, opts []keysetpagination.Option) ([]Key, *keysetpagination.Paginator, error)
+		// RevokeAPIKey revokes the API key if it is not yet revoked and
+		// returns sqlcon.ErrNoRows otherwise.
+		RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+		// UpdateAPIKeyLastUsedAt records that the API key was used.
+		UpdateAPIKeyLastUsedAt(ctx context.Context, id uuid.UUID, at time.Time) error
+	}
+
+	PersistenceProvider interface {
+		APIKeyPersister() Persister
+	}
+
+	principalContextKey struct{}
+)
+
+func (Key) TableName() string { return "api_keys" }
+
+func (k Key) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: k.ID})
+}
+
+func (k Key) DefaultPageToken() keysetpagination.PageToken {
+	return Key{ID: uuid.Nil}.PageToken()
+}
+
+// IsActive returns true if the API key is neither revoked nor expired.
+func (k *Key) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(time.Time(*k.ExpiresAt))
+}
+
+// Principal returns the principal authorized by the API key.
+func (k *Key) Principal() *Principal {
+	return &Principal{
+		KeyID:          uuid.NullUUID{UUID: k.ID, Valid: true},
+		Scopes:         k.Scopes,
+		OrganizationID: k.OrganizationID,
+	}
+}
+
+// HasScope returns true if the principal was granted the scope or the admin
+// scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal authorized for the request, or
+// nil if admin API authorization is disabled.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return p
+}
+
+// NewToken returns a new API key token for the key with the given ID and the
+// secret contained in it.
+func NewToken(id uuid.UUID) (token, secret string) {
+	secret = randx.MustString(secretLength, randx.AlphaNum)
+	return TokenPrefix + id.String() + "_" + secret, secret
+}
+
+// ParseToken splits an API key token into the key's ID and the secret. It
+// returns false if the token is malformed.
+func ParseToken(token string) (uuid.UUID, string, bool) {
+	rest, ok := strings.CutPrefix(token, TokenPrefix)
+	if !ok {
+		return uuid.Nil, "", false
+	}
+	rawID, secret, ok := strings.Cut(rest, "_")
+	if !ok || secret == "" {
+		return uuid.Nil, "", false
+	}
+	id, err := uuid.FromString(rawID)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	return id, secret, true
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/ory/herodot"
+	courierv1 "github.com/ory/kratos/gen/courier/v1"
+	identityv1 "github.com/ory/kratos/gen/identity/v1"
+	sessionv1 "github.com/ory/kratos/gen/session/v1"
+)
+
+// grpcScopes maps the methods of the admin gRPC API to the scopes they
+// require. Methods without an entry require the admin scope.
+var grpcScopes = map[string]string{
+	identityv1.IdentityService_GetIdentity_FullMethodName:    ScopeIdentitiesRead,
+	identityv1.IdentityService_ListIdentities_FullMethodName: ScopeIdentitiesRead,
+	identityv1.IdentityService_CreateIdentity_FullMethodName: ScopeIdentitiesWrite,
+	identityv1.IdentityService_UpdateIdentity_FullMethodName: ScopeIdentitiesWrite,
+	identityv1.IdentityService_DeleteIdentity_FullMethodName: ScopeIdentitiesWrite,
+	sessionv1.SessionService_WhoAmI_FullMethodName:           ScopeSessionsRead,
+	sessionv1.SessionService_ListSessions_FullMethodName:     ScopeSessionsRead,
+	sessionv1.SessionService_RevokeSession_FullMethodName:    ScopeSessionsRevoke,
+	courierv1.CourierService_ListMessages_FullMethodName:     ScopeCourierRead,
+}
+
+// RequiredGRPCScope returns the scope required to call the gRPC method.
+func RequiredGRPCScope(fullMethod string) string {
+	if scope, ok := grpcScopes[fullMethod]; ok {
+		return scope
+	}
+	return ScopeAdmin
+}
+
+// UnaryServerInterceptor authorizes calls to the admin gRPC API like the
+// middleware authorizes requests to the admin API. Credentials restricted to
+// an organization can not be used for the gRPC API.
+func (m *Middleware) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !m.d.Config().AdminAuthorizationEnabled(ctx) {
+		return handler(ctx, req)
+	}
+
+	var authorization string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	var state *tls.ConnectionState
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &info.State
+		}
+	}
+
+	p, err := m.authenticate(ctx, authorization, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if scope := RequiredGRPCScope(info.FullMethod); !p.HasScope(scope) {
+		return nil, errors.WithStack(herodot.ErrForbidden().WithReasonf("The credentials used are missing the %q scope required for this request.", scope))
+	}
+
+	if p.OrganizationID.Valid {
+		return nil, errors.WithStack(herodot.ErrForbidden().WithReason("The credentials used are restricted to an organization and can not be used for the gRPC API."))
+	}
+
+	return handler(WithPrincipal(ctx, p), req)
+}
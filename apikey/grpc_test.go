@@ -0,0 +1,88 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/driver/config"
+	courierv1 "github.com/ory/kratos/gen/courier/v1"
+	identityv1 "github.com/ory/kratos/gen/identity/v1"
+	sessionv1 "github.com/ory/kratos/gen/session/v1"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+)
+
+func TestRequiredGRPCScope(t *testing.T) {
+	for _, tc := range []struct {
+		method, expected string
+	}{
+		{identityv1.IdentityService_ListIdentities_FullMethodName, apikey.ScopeIdentitiesRead},
+		{identityv1.IdentityService_DeleteIdentity_FullMethodName, apikey.ScopeIdentitiesWrite},
+		{sessionv1.SessionService_WhoAmI_FullMethodName, apikey.ScopeSessionsRead},
+		{sessionv1.SessionService_RevokeSession_FullMethodName, apikey.ScopeSessionsRevoke},
+		{courierv1.CourierService_ListMessages_FullMethodName, apikey.ScopeCourierRead},
+		{"/unknown.v1.UnknownService/Unknown", apikey.ScopeAdmin},
+	} {
+		t.Run("case="+tc.method, func(t *testing.T) {
+			assert.Equal(t, tc.expected, apikey.RequiredGRPCScope(tc.method))
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyAdminAuthorizationEnabled, true)
+
+	client := identityv1.NewIdentityServiceClient(testhelpers.NewAdminGRPCClient(t, reg))
+
+	newKey := func(t *testing.T, k *apikey.Key) string {
+		k.ID = uuid.Must(uuid.NewV4())
+		token, secret := apikey.NewToken(k.ID)
+		secretHash, err := reg.Hasher(ctx).Generate(ctx, []byte(secret))
+		require.NoError(t, err)
+		k.SecretHash = string(secretHash)
+		require.NoError(t, reg.APIKeyPersister().CreateAPIKey(ctx, k))
+		return token
+	}
+
+	call := func(t *testing.T, token string) codes.Code {
+		t.Helper()
+		ctx := ctx
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		_, err := client.ListIdentities(ctx, &identityv1.ListIdentitiesRequest{})
+		return status.Code(err)
+	}
+
+	t.Run("case=requires an API key", func(t *testing.T) {
+		assert.Equal(t, codes.Unauthenticated, call(t, ""))
+		assert.Equal(t, codes.Unauthenticated, call(t, "not-a-key"))
+	})
+
+	t.Run("case=enforces scopes", func(t *testing.T) {
+		assert.Equal(t, codes.OK, call(t, newKey(t, &apikey.Key{Name: "read", Scopes: []string{apikey.ScopeIdentitiesRead}})))
+		assert.Equal(t, codes.PermissionDenied, call(t, newKey(t, &apikey.Key{Name: "courier", Scopes: []string{apikey.ScopeCourierRead}})))
+	})
+
+	t.Run("case=rejects keys restricted to an organization", func(t *testing.T) {
+		token := newKey(t, &apikey.Key{
+			Name:           "org",
+			Scopes:         []string{apikey.ScopeAdmin},
+			OrganizationID: uuid.NullUUID{UUID: uuid.Must(uuid.NewV4()), Valid: true},
+		})
+		assert.Equal(t, codes.PermissionDenied, call(t, token))
+	})
+}
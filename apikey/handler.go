@@ -0,0 +1,391 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/logrusx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCollection = "/api-keys"
+	RouteItem       = RouteCollection + "/{id}"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		hash.HashProvider
+		PersistenceProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		APIKeyHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		RouteCollection,
+		RouteCollection+"/*",
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteCollection, h.create)
+	admin.GET(RouteItem, h.get)
+	admin.DELETE(RouteItem, h.revoke)
+}
+
+// Create API Key Request Body
+//
+// swagger:model createApiKeyBody
+type CreateAPIKeyBody struct {
+	// Name describes the API key, for example the service using it.
+	//
+	// required: true
+	Name string `json:"name"`
+
+	// Scopes are the admin API scopes granted to the API key. One of
+	// `admin`, `identities:read`, `identities:write`, `sessions:read`,
+	// `sessions:write`, `sessions:revoke` and `courier:read`.
+	//
+	// required: true
+	Scopes []string `json:"scopes"`
+
+	// OrganizationID restricts the API key to manage the identities of this
+	// organization.
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+
+	// ExpiresIn is the time after which the API key is no longer accepted.
+	// API keys without an expiry do not expire.
+	//
+	// pattern: ^[0-9]+(ns|us|ms|s|m|h)$
+	// example:
+	//	- 2160h
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
+
+// Created API Key
+//
+// swagger:model apiKeyWithToken
+type apiKeyWithToken struct {
+	Key
+
+	// Token is the API key to send as a bearer token in the Authorization
+	// header. It is only returned when the API key is created.
+	//
+	// required: true
+	Token string `json:"token"`
+}
+
+// Create API Key Parameters
+//
+// swagger:parameters createApiKey
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createAPIKey struct {
+	// in: body
+	Body CreateAPIKeyBody
+}
+
+// swagger:route POST /admin/api-keys apiKey createApiKey
+//
+// # Create an API Key
+//
+// Creates an API key for the admin API which grants the given scopes. The API key is only
+// returned in this response; only a hash of it is stored. API keys are only enforced if
+// `serve.admin.authorization.enabled` is set.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: apiKeyWithToken
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body CreateAPIKeyBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	if strings.TrimSpace(body.Name) == "" {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason(`The API key requires a "name".`)))
+		return
+	}
+	if len(body.Scopes) == 0 {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason(`The API key requires at least one scope in "scopes".`)))
+		return
+	}
+	for _, scope := range body.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The scope %q is unknown. Known scopes are: %s", scope, strings.Join(Scopes, ", "))))
+			return
+		}
+	}
+
+	k := &Key{
+		ID:             uuid.Must(uuid.NewV4()),
+		Name:           body.Name,
+		Scopes:         slices.Compact(slices.Sorted(slices.Values(body.Scopes))),
+		OrganizationID: body.OrganizationID,
+	}
+
+	if len(body.ExpiresIn) > 0 {
+		expiresIn, err := time.ParseDuration(body.ExpiresIn)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`Unable to parse "expires_in" whose format should match "[0-9]+(ns|us|ms|s|m|h)" but did not: %s`, body.ExpiresIn)))
+			return
+		}
+		if expiresIn <= 0 {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`Value from "expires_in" must be result to a future time: %s`, body.ExpiresIn)))
+			return
+		}
+		k.ExpiresAt = new(sqlxx.NullTime(time.Now().UTC().Add(expiresIn)))
+	}
+
+	token, secret := NewToken(k.ID)
+	secretHash, err := h.r.Hasher(ctx).Generate(ctx, []byte(secret))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	k.SecretHash = string(secretHash)
+
+	if err := h.r.APIKeyPersister().CreateAPIKey(ctx, k); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewAPIKeyCreated(ctx, k.ID))
+	h.r.Logger().
+		WithField("api_key_id", k.ID).
+		WithField("scopes", k.Scopes).
+		Info("An API key has been created.")
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "api-keys", k.ID.String()).String(),
+		&apiKeyWithToken{Key: *k, Token: token},
+	)
+}
+
+// Paginated API Key List Response
+//
+// swagger:response listApiKeys
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listAPIKeysResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of API keys
+	//
+	// in:body
+	Body []Key
+}
+
+// List API Keys Parameters
+//
+// swagger:parameters listApiKeys
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listAPIKeys struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/api-keys apiKey listApiKeys
+//
+// # List API Keys
+//
+// Lists all API keys, including expired and revoked ones. The API keys themselves are not returned.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listApiKeys
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	apiKeys, nextPage, err := h.r.APIKeyPersister().ListAPIKeys(r.Context(), opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, apiKeys)
+}
+
+// Get API Key Parameters
+//
+// swagger:parameters getApiKey
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getAPIKey struct {
+	// ID is the API key's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/api-keys/{id} apiKey getApiKey
+//
+// # Get an API Key
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: apiKey
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	k, err := h.r.APIKeyPersister().GetAPIKey(r.Context(), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, k)
+}
+
+// Revoke API Key Parameters
+//
+// swagger:parameters revokeApiKey
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeAPIKey struct {
+	// ID is the API key's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/api-keys/{id} apiKey revokeApiKey
+//
+// # Revoke an API Key
+//
+// Revokes an API key so that it is no longer accepted. Revoked API keys are kept to audit their use.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	k, err := h.r.APIKeyPersister().GetAPIKey(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.APIKeyPersister().RevokeAPIKey(ctx, k.ID); errors.Is(err, sqlcon.ErrNoRows()) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReason("The API key was already revoked.")))
+		return
+	} else if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewAPIKeyRevoked(ctx, k.ID))
+	w.WriteHeader(http.StatusNoContent)
+}
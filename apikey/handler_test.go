@@ -0,0 +1,129 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	do := func(t *testing.T, method, url string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var payload io.Reader
+		if body != nil {
+			raw, err := json.Marshal(body)
+			require.NoError(t, err)
+			payload = bytes.NewReader(raw)
+		}
+		req, err := http.NewRequest(method, url, payload)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	collection := adminTS.URL + "/admin" + apikey.RouteCollection
+
+	t.Run("case=creates an API key and stores only its hash", func(t *testing.T) {
+		orgID := uuid.Must(uuid.NewV4())
+		res := do(t, "POST", collection, map[string]any{
+			"name":            "support-tool",
+			"scopes":          []string{apikey.ScopeSessionsRevoke, apikey.ScopeIdentitiesRead, apikey.ScopeIdentitiesRead},
+			"organization_id": orgID,
+			"expires_in":      "24h",
+		}, http.StatusCreated)
+
+		assert.Equal(t, "support-tool", res.Get("name").String())
+		assert.Equal(t, `["identities:read","sessions:revoke"]`, res.Get("scopes").Raw)
+		assert.Equal(t, orgID.String(), res.Get("organization_id").String())
+		assert.True(t, res.Get("expires_at").Exists())
+		assert.False(t, res.Get("secret_hash").Exists())
+
+		id, secret, ok := apikey.ParseToken(res.Get("token").String())
+		require.True(t, ok, "%s", res.Raw)
+		assert.Equal(t, res.Get("id").String(), id.String())
+
+		k, err := reg.APIKeyPersister().GetAPIKey(ctx, id)
+		require.NoError(t, err)
+		assert.NotContains(t, k.SecretHash, secret)
+		assert.NoError(t, hash.Compare(ctx, []byte(secret), []byte(k.SecretHash)))
+
+		got := do(t, "GET", collection+"/"+id.String(), nil, http.StatusOK)
+		assert.Equal(t, id.String(), got.Get("id").String())
+		assert.False(t, got.Get("token").Exists())
+	})
+
+	t.Run("case=rejects invalid bodies", func(t *testing.T) {
+		for name, body := range map[string]map[string]any{
+			"missing name":       {"scopes": []string{apikey.ScopeAdmin}},
+			"missing scopes":     {"name": "ci"},
+			"unknown scope":      {"name": "ci", "scopes": []string{"identities:delete"}},
+			"invalid expiry":     {"name": "ci", "scopes": []string{apikey.ScopeAdmin}, "expires_in": "tomorrow"},
+			"negative expiry":    {"name": "ci", "scopes": []string{apikey.ScopeAdmin}, "expires_in": "-1h"},
+			"unknown properties": {"name": "ci", "scopes": []string{apikey.ScopeAdmin}, "secret": "foo"},
+		} {
+			t.Run("case="+name, func(t *testing.T) {
+				do(t, "POST", collection, body, http.StatusBadRequest)
+			})
+		}
+	})
+
+	t.Run("case=revokes an API key once", func(t *testing.T) {
+		res := do(t, "POST", collection, map[string]any{"name": "ci", "scopes": []string{apikey.ScopeAdmin}}, http.StatusCreated)
+		id := res.Get("id").String()
+
+		do(t, "DELETE", collection+"/"+id, nil, http.StatusNoContent)
+		assert.True(t, do(t, "GET", collection+"/"+id, nil, http.StatusOK).Get("revoked_at").Exists())
+		do(t, "DELETE", collection+"/"+id, nil, http.StatusConflict)
+		do(t, "DELETE", collection+"/"+uuid.Must(uuid.NewV4()).String(), nil, http.StatusNotFound)
+	})
+
+	t.Run("case=lists API keys", func(t *testing.T) {
+		for range 3 {
+			do(t, "POST", collection, map[string]any{"name": "listed", "scopes": []string{apikey.ScopeCourierRead}}, http.StatusCreated)
+		}
+
+		req, err := http.NewRequest("GET", collection+"?page_size=2", nil)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var keys []apikey.Key
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&keys))
+		assert.Len(t, keys, 2)
+		for _, k := range keys {
+			assert.Empty(t, k.SecretHash)
+		}
+
+		_, next, _ := keysetpagination.ParseHeader(res)
+		assert.NotEmpty(t, next)
+	})
+}
@@ -0,0 +1,378 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/sqlcon"
+)
+
+const (
+	// lastUsedResolution is the minimum time between two updates of an API
+	// key's last use.
+	lastUsedResolution = time.Minute
+
+	verifiedSecretTTL = 5 * time.Minute
+)
+
+type (
+	middlewareDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.WriterProvider
+		identity.PoolProvider
+		PersistenceProvider
+	}
+
+	// Middleware authorizes requests to the admin API if
+	// `serve.admin.authorization.enabled` is set.
+	Middleware struct {
+		d middlewareDependencies
+
+		// verified caches the secret hashes of recently verified API keys,
+		// as comparing them with the secret is deliberately slow.
+		verified *ristretto.Cache[string, string]
+	}
+
+	MiddlewareProvider interface {
+		APIKeyMiddleware() *Middleware
+	}
+
+	// scopeRule maps the admin routes starting with pattern, in which `*`
+	// matches a single path segment, to the scopes they require. Routes
+	// without a matching rule require the admin scope.
+	scopeRule struct {
+		pattern string
+		// read is required by GET and HEAD requests.
+		read string
+		// write is required by all other requests.
+		write string
+		// remove is required by DELETE requests. Defaults to write.
+		remove string
+	}
+)
+
+var (
+	// publicPaths do not require authorization. They serve health checks
+	// and metrics, or redirect to the public API.
+	publicPaths = []string{
+		"/admin/health",
+		"/admin/version",
+		"/admin/metrics",
+		"/admin/self-service",
+		"/admin/schemas",
+	}
+
+	// defaultNetworkPaths manage all networks and can only be called with
+	// credentials of the default network.
+	defaultNetworkPaths = []string{
+		"/admin/tenants",
+	}
+
+	scopeRules = []scopeRule{
+		{pattern: "/admin/identities/*/sessions", read: ScopeSessionsRead, remove: ScopeSessionsRevoke},
+		{pattern: "/admin/identities/*/trusted-devices", read: ScopeSessionsRead, remove: ScopeSessionsRevoke},
+		{pattern: "/admin/identities/*/impersonate", write: ScopeSessionsWrite},
+		{pattern: "/admin/identities", read: ScopeIdentitiesRead, write: ScopeIdentitiesWrite},
+		{pattern: "/admin/recovery", write: ScopeIdentitiesWrite},
+		{pattern: "/admin/invitations", read: ScopeIdentitiesRead, write: ScopeIdentitiesWrite},
+		{pattern: "/admin/registration-approvals", read: ScopeIdentitiesRead, write: ScopeIdentitiesWrite},
+		{pattern: "/admin/identity-jobs", read: ScopeIdentitiesRead, write: ScopeIdentitiesWrite},
+		{pattern: "/admin/legal-acceptances", read: ScopeIdentitiesRead},
+		{pattern: "/admin/sessions", read: ScopeSessionsRead, write: ScopeSessionsWrite, remove: ScopeSessionsRevoke},
+		{pattern: "/admin/courier", read: ScopeCourierRead},
+	}
+)
+
+func NewMiddleware(d middlewareDependencies) *Middleware {
+	cache, _ := ristretto.NewCache(&ristretto.Config[string, string]{
+		MaxCost:     10_000,
+		NumCounters: 100_000,
+		BufferItems: 64,
+	})
+	return &Middleware{d: d, verified: cache}
+}
+
+func (m *Middleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	ctx := r.Context()
+	if !m.d.Config().AdminAuthorizationEnabled(ctx) || isPublicPath(r.URL.Path) {
+		next(w, r)
+		return
+	}
+
+	p, err := m.authenticate(ctx, r.Header.Get("Authorization"), r.TLS)
+	if err != nil {
+		m.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if isDefaultNetworkPath(r.URL.Path) && tenant.FromContext(ctx) != nil {
+		m.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrForbidden().WithReason("Tenants can only be managed with credentials of the default network.")))
+		return
+	}
+
+	if scope := RequiredScope(r.Method, r.URL.Path); !p.HasScope(scope) {
+		m.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrForbidden().WithReasonf("The credentials used are missing the %q scope required for this request.", scope)))
+		return
+	}
+
+	if p.OrganizationID.Valid {
+		if err := m.restrictToOrganization(r, p.OrganizationID.UUID); err != nil {
+			m.d.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	next(w, r.WithContext(WithPrincipal(ctx, p)))
+}
+
+// RequiredScope returns the scope required to call the admin route.
+func RequiredScope(method, path string) string {
+	segments := splitPath(path)
+	for _, rule := range scopeRules {
+		if !matchesPattern(segments, splitPath(rule.pattern)) {
+			continue
+		}
+
+		var scope string
+		switch method {
+		case http.MethodGet, http.MethodHead:
+			scope = rule.read
+		case http.MethodDelete:
+			scope = rule.remove
+			if scope == "" {
+				scope = rule.write
+			}
+		default:
+			scope = rule.write
+		}
+
+		if scope == "" {
+			return ScopeAdmin
+		}
+		return scope
+	}
+	return ScopeAdmin
+}
+
+// authenticate returns the principal of the API key in the authorization
+// header or of the verified TLS client certificate.
+func (m *Middleware) authenticate(ctx context.Context, authorization string, state *tls.ConnectionState) (*Principal, error) {
+	if token, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+		return m.authenticateToken(ctx, strings.TrimSpace(token))
+	}
+
+	if state != nil && len(state.VerifiedChains) > 0 {
+		subject := state.VerifiedChains[0][0].Subject.CommonName
+		clients, err := m.d.Config().AdminClientCertificates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range clients {
+			if c.Subject != subject || !allowsTenant(ctx, c) {
+				continue
+			}
+
+			p := &Principal{Subject: subject, Scopes: c.Scopes}
+			if c.OrganizationID != "" {
+				id, err := uuid.FromString(c.OrganizationID)
+				if err != nil {
+					return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The organization ID of the admin API client %q is invalid: %s", subject, err))
+				}
+				p.OrganizationID = uuid.NullUUID{UUID: id, Valid: true}
+			}
+			return p, nil
+		}
+
+		return nil, errors.WithStack(herodot.ErrForbidden().WithReasonf("The client certificate %q is not allowed to access the admin API.", subject))
+	}
+
+	return nil, errors.WithStack(herodot.ErrUnauthorized().WithReason("The admin API requires an API key sent as a bearer token in the Authorization header."))
+}
+
+// allowsTenant returns true if the client certificate can be used for requests
+// served as the tenant of the context.
+func allowsTenant(ctx context.Context, c config.AdminClientCertificate) bool {
+	t := tenant.FromContext(ctx)
+	if t == nil {
+		return c.Tenant == ""
+	}
+	return c.Tenant == t.ID.String() || c.Tenant == t.Name
+}
+
+func (m *Middleware) authenticateToken(ctx context.Context, token string) (*Principal, error) {
+	errInvalid := errors.WithStack(herodot.ErrUnauthorized().WithReason("The API key is invalid, expired or was revoked."))
+
+	id, secret, ok := ParseToken(token)
+	if !ok {
+		return nil, errInvalid
+	}
+
+	k, err := m.d.APIKeyPersister().GetAPIKey(ctx, id)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return nil, errInvalid
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if !k.IsActive(now) {
+		return nil, errInvalid
+	}
+
+	digest := sha256.Sum256([]byte(secret))
+	cacheKey := k.ID.String() + ":" + hex.EncodeToString(digest[:])
+	if verified, ok := m.verified.Get(cacheKey); !ok || verified != k.SecretHash {
+		if err := hash.Compare(ctx, []byte(secret), []byte(k.SecretHash)); err != nil {
+			return nil, errInvalid
+		}
+		m.verified.SetWithTTL(cacheKey, k.SecretHash, 1, verifiedSecretTTL)
+	}
+
+	if k.LastUsedAt == nil || now.Sub(time.Time(*k.LastUsedAt)) >= lastUsedResolution {
+		if err := m.d.APIKeyPersister().UpdateAPIKeyLastUsedAt(ctx, k.ID, now); err != nil {
+			m.d.Logger().WithError(err).WithField("api_key_id", k.ID).Warn("Unable to record the last use of the API key.")
+		}
+	}
+
+	return k.Principal(), nil
+}
+
+// restrictToOrganization ensures that requests of principals restricted to an
+// organization only manage identities of that organization.
+func (m *Middleware) restrictToOrganization(r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+	errForbidden := errors.WithStack(herodot.ErrForbidden().WithReason("The credentials used are restricted to an organization and can only manage the identities of that organization."))
+
+	segments := splitPath(r.URL.Path)
+	if len(segments) < 2 || segments[1] != "identities" {
+		return errForbidden
+	}
+
+	if len(segments) == 2 {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			// Only list the identities of the organization.
+			query := r.URL.Query()
+			if requested, ok := query["organization_id"]; ok {
+				for _, id := range requested {
+					if id != organizationID.String() {
+						return errForbidden
+					}
+				}
+				return nil
+			}
+			query.Set("organization_id", organizationID.String())
+			r.URL.RawQuery = query.Encode()
+			return nil
+		case http.MethodPost:
+			return rewriteBody(r, func(body []byte) ([]byte, error) {
+				if requested := gjson.GetBytes(body, "organization_id"); requested.Exists() && requested.Type != gjson.Null {
+					if requested.String() != organizationID.String() {
+						return nil, errForbidden
+					}
+					return body, nil
+				}
+				return sjson.SetBytes(body, "organization_id", organizationID.String())
+			})
+		default:
+			return errForbidden
+		}
+	}
+
+	id, err := uuid.FromString(segments[2])
+	if err != nil {
+		return errForbidden
+	}
+
+	i, err := m.d.IdentityPool().GetIdentity(ctx, id, identity.ExpandNothing)
+	if errors.Is(err, sqlcon.ErrNoRows()) || (err == nil && (!i.OrganizationID.Valid || i.OrganizationID.UUID != organizationID)) {
+		return errors.WithStack(herodot.ErrNotFound().WithReason("Unable to locate the resource"))
+	} else if err != nil {
+		return err
+	}
+
+	if len(segments) == 3 && r.Method == http.MethodPatch {
+		// Identities must not be moved to another organization.
+		return rewriteBody(r, func(body []byte) ([]byte, error) {
+			for _, op := range gjson.ParseBytes(body).Array() {
+				if strings.HasPrefix(op.Get("path").String(), "/organization_id") {
+					return nil, errForbidden
+				}
+			}
+			return body, nil
+		})
+	}
+	return nil
+}
+
+func rewriteBody(r *http.Request, rewrite func([]byte) ([]byte, error)) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("Unable to read the request body: %s", err))
+	}
+	_ = r.Body.Close()
+
+	body, err = rewrite(body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return nil
+}
+
+func isPublicPath(path string) bool {
+	segments := splitPath(path)
+	return slices.ContainsFunc(publicPaths, func(prefix string) bool {
+		return matchesPattern(segments, splitPath(prefix))
+	})
+}
+
+func isDefaultNetworkPath(path string) bool {
+	segments := splitPath(path)
+	return slices.ContainsFunc(defaultNetworkPaths, func(prefix string) bool {
+		return matchesPattern(segments, splitPath(prefix))
+	})
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchesPattern(segments, pattern []string) bool {
+	if len(segments) < len(pattern) {
+		return false
+	}
+	for k, p := range pattern {
+		if p != "*" && p != segments[k] {
+			return false
+		}
+	}
+	return true
+}
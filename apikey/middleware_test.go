@@ -0,0 +1,288 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikey_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/urfave/negroni"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestRequiredScope(t *testing.T) {
+	for _, tc := range []struct {
+		method, path, expected string
+	}{
+		{"GET", "/admin/identities", apikey.ScopeIdentitiesRead},
+		{"GET", "/admin/identities/some-id", apikey.ScopeIdentitiesRead},
+		{"POST", "/admin/identities", apikey.ScopeIdentitiesWrite},
+		{"PATCH", "/admin/identities/some-id", apikey.ScopeIdentitiesWrite},
+		{"DELETE", "/admin/identities/some-id", apikey.ScopeIdentitiesWrite},
+		{"GET", "/admin/identities/some-id/sessions", apikey.ScopeSessionsRead},
+		{"DELETE", "/admin/identities/some-id/sessions", apikey.ScopeSessionsRevoke},
+		{"POST", "/admin/identities/some-id/impersonate", apikey.ScopeSessionsWrite},
+		{"GET", "/admin/identities/some-id/impersonate", apikey.ScopeAdmin},
+		{"POST", "/admin/recovery/link", apikey.ScopeIdentitiesWrite},
+		{"GET", "/admin/sessions/some-id", apikey.ScopeSessionsRead},
+		{"PATCH", "/admin/sessions/some-id/extend", apikey.ScopeSessionsWrite},
+		{"DELETE", "/admin/sessions/some-id", apikey.ScopeSessionsRevoke},
+		{"GET", "/admin/courier/messages", apikey.ScopeCourierRead},
+		{"GET", "/admin/identity-jobs/some-id/failures", apikey.ScopeIdentitiesRead},
+		{"DELETE", "/admin/identity-jobs/some-id", apikey.ScopeIdentitiesWrite},
+		{"POST", "/admin/courier/messages", apikey.ScopeAdmin},
+		{"GET", "/admin/identitiesfoo", apikey.ScopeAdmin},
+		{"GET", "/admin/api-keys", apikey.ScopeAdmin},
+		{"POST", "/admin/tenants", apikey.ScopeAdmin},
+	} {
+		t.Run("case="+tc.method+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, apikey.RequiredScope(tc.method, tc.path))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyAdminAuthorizationEnabled, true)
+
+	n := negroni.New(reg.APIKeyMiddleware())
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var scopes []string
+		if p := apikey.PrincipalFromContext(r.Context()); p != nil {
+			scopes = p.Scopes
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query":  r.URL.RawQuery,
+			"body":   string(body),
+			"scopes": scopes,
+		})
+	})
+	ts := httptest.NewServer(n)
+	t.Cleanup(ts.Close)
+
+	newKey := func(t *testing.T, k *apikey.Key) string {
+		k.ID = uuid.Must(uuid.NewV4())
+		token, secret := apikey.NewToken(k.ID)
+		secretHash, err := reg.Hasher(ctx).Generate(ctx, []byte(secret))
+		require.NoError(t, err)
+		k.SecretHash = string(secretHash)
+		require.NoError(t, reg.APIKeyPersister().CreateAPIKey(ctx, k))
+		return token
+	}
+
+	do := func(t *testing.T, token, method, path, body string, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	t.Run("case=passes through if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyAdminAuthorizationEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyAdminAuthorizationEnabled, true) })
+
+		do(t, "", "GET", "/admin/identities", "", http.StatusOK)
+	})
+
+	t.Run("case=does not protect health checks", func(t *testing.T) {
+		do(t, "", "GET", "/admin/health/ready", "", http.StatusOK)
+		do(t, "", "GET", "/admin/version", "", http.StatusOK)
+	})
+
+	t.Run("case=requires an API key", func(t *testing.T) {
+		do(t, "", "GET", "/admin/identities", "", http.StatusUnauthorized)
+		do(t, "not-a-key", "GET", "/admin/identities", "", http.StatusUnauthorized)
+		do(t, apikey.TokenPrefix+uuid.Must(uuid.NewV4()).String()+"_secret", "GET", "/admin/identities", "", http.StatusUnauthorized)
+	})
+
+	t.Run("case=rejects a wrong secret", func(t *testing.T) {
+		token := newKey(t, &apikey.Key{Name: "wrong", Scopes: []string{apikey.ScopeAdmin}})
+		do(t, token+"x", "GET", "/admin/identities", "", http.StatusUnauthorized)
+	})
+
+	t.Run("case=enforces scopes", func(t *testing.T) {
+		token := newKey(t, &apikey.Key{Name: "read", Scopes: []string{apikey.ScopeIdentitiesRead}})
+
+		res := do(t, token, "GET", "/admin/identities", "", http.StatusOK)
+		assert.Equal(t, `["identities:read"]`, res.Get("scopes").Raw)
+		do(t, token, "POST", "/admin/identities", "{}", http.StatusForbidden)
+		do(t, token, "DELETE", "/admin/sessions/"+uuid.Must(uuid.NewV4()).String(), "", http.StatusForbidden)
+		do(t, token, "GET", "/admin/api-keys", "", http.StatusForbidden)
+
+		admin := newKey(t, &apikey.Key{Name: "admin", Scopes: []string{apikey.ScopeAdmin}})
+		do(t, admin, "GET", "/admin/api-keys", "", http.StatusOK)
+	})
+
+	t.Run("case=rejects expired and revoked API keys", func(t *testing.T) {
+		expired := newKey(t, &apikey.Key{
+			Name:      "expired",
+			Scopes:    []string{apikey.ScopeAdmin},
+			ExpiresAt: new(sqlxx.NullTime(time.Now().Add(-time.Minute))),
+		})
+		do(t, expired, "GET", "/admin/identities", "", http.StatusUnauthorized)
+
+		revoked := newKey(t, &apikey.Key{Name: "revoked", Scopes: []string{apikey.ScopeAdmin}})
+		do(t, revoked, "GET", "/admin/identities", "", http.StatusOK)
+
+		id, _, _ := apikey.ParseToken(revoked)
+		require.NoError(t, reg.APIKeyPersister().RevokeAPIKey(ctx, id))
+		do(t, revoked, "GET", "/admin/identities", "", http.StatusUnauthorized)
+	})
+
+	t.Run("case=records the last use", func(t *testing.T) {
+		token := newKey(t, &apikey.Key{Name: "used", Scopes: []string{apikey.ScopeAdmin}})
+		id, _, _ := apikey.ParseToken(token)
+
+		k, err := reg.APIKeyPersister().GetAPIKey(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, k.LastUsedAt)
+
+		do(t, token, "GET", "/admin/identities", "", http.StatusOK)
+
+		k, err = reg.APIKeyPersister().GetAPIKey(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, k.LastUsedAt)
+		assert.WithinDuration(t, time.Now(), time.Time(*k.LastUsedAt), time.Minute)
+	})
+
+	t.Run("case=restricts to the organization", func(t *testing.T) {
+		orgID := uuid.Must(uuid.NewV4())
+		token := newKey(t, &apikey.Key{
+			Name:           "org",
+			Scopes:         []string{apikey.ScopeIdentitiesRead, apikey.ScopeIdentitiesWrite, apikey.ScopeSessionsRead},
+			OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true},
+		})
+
+		member := &identity.Identity{
+			Traits:         identity.Traits(`{"email":"` + uuid.Must(uuid.NewV4()).String() + `@ory.sh"}`),
+			OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true},
+		}
+		require.NoError(t, reg.IdentityManager().Create(ctx, member))
+		other := &identity.Identity{
+			Traits: identity.Traits(`{"email":"` + uuid.Must(uuid.NewV4()).String() + `@ory.sh"}`),
+		}
+		require.NoError(t, reg.IdentityManager().Create(ctx, other))
+
+		t.Run("case=forces the organization when listing", func(t *testing.T) {
+			res := do(t, token, "GET", "/admin/identities", "", http.StatusOK)
+			assert.Equal(t, "organization_id="+orgID.String(), res.Get("query").String())
+
+			res = do(t, token, "GET", "/admin/identities?organization_id="+orgID.String(), "", http.StatusOK)
+			assert.Equal(t, "organization_id="+orgID.String(), res.Get("query").String())
+
+			do(t, token, "GET", "/admin/identities?organization_id="+uuid.Must(uuid.NewV4()).String(), "", http.StatusForbidden)
+			do(t, token, "GET", "/admin/identities?organization_id=", "", http.StatusForbidden)
+		})
+
+		t.Run("case=sets the organization when creating", func(t *testing.T) {
+			res := do(t, token, "POST", "/admin/identities", `{"schema_id":"default"}`, http.StatusOK)
+			assert.Equal(t, orgID.String(), gjson.Get(res.Get("body").String(), "organization_id").String())
+
+			do(t, token, "POST", "/admin/identities", `{"organization_id":"`+uuid.Must(uuid.NewV4()).String()+`"}`, http.StatusForbidden)
+		})
+
+		t.Run("case=only allows identities of the organization", func(t *testing.T) {
+			do(t, token, "GET", "/admin/identities/"+member.ID.String(), "", http.StatusOK)
+			do(t, token, "GET", "/admin/identities/"+member.ID.String()+"/sessions", "", http.StatusOK)
+			do(t, token, "GET", "/admin/identities/"+other.ID.String(), "", http.StatusNotFound)
+			do(t, token, "DELETE", "/admin/identities/"+other.ID.String(), "", http.StatusNotFound)
+		})
+
+		t.Run("case=does not allow moving identities", func(t *testing.T) {
+			do(t, token, "PATCH", "/admin/identities/"+member.ID.String(), `[{"op":"replace","path":"/traits/email","value":"foo@ory.sh"}]`, http.StatusOK)
+			do(t, token, "PATCH", "/admin/identities/"+member.ID.String(), `[{"op":"remove","path":"/organization_id"}]`, http.StatusForbidden)
+			do(t, token, "PATCH", "/admin/identities", `{"identities":[]}`, http.StatusForbidden)
+		})
+
+		t.Run("case=does not allow other resources", func(t *testing.T) {
+			do(t, token, "GET", "/admin/sessions", "", http.StatusForbidden)
+		})
+	})
+
+	t.Run("case=binds client certificates to a tenant", func(t *testing.T) {
+		acme := &tenant.Tenant{ID: uuid.Must(uuid.NewV4()), Name: "acme"}
+		other := &tenant.Tenant{ID: uuid.Must(uuid.NewV4()), Name: "other"}
+
+		conf.MustSet(ctx, config.ViperKeyAdminAuthorizationClientCertificates, []map[string]any{
+			{"subject": "default-sync", "scopes": []string{apikey.ScopeAdmin}},
+			{"subject": "acme-sync", "scopes": []string{apikey.ScopeAdmin}, "tenant": "acme"},
+			{"subject": "acme-id-sync", "scopes": []string{apikey.ScopeAdmin}, "tenant": acme.ID.String()},
+		})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyAdminAuthorizationClientCertificates, []map[string]any{}) })
+
+		serve := func(t *testing.T, subject string, tn *tenant.Tenant) int {
+			req := httptest.NewRequest("GET", "/admin/identities", nil)
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: subject}}}}}
+			if tn != nil {
+				req = req.WithContext(tenant.WithTenant(req.Context(), tn))
+			}
+			w := httptest.NewRecorder()
+			n.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusOK, serve(t, "default-sync", nil))
+		assert.Equal(t, http.StatusForbidden, serve(t, "default-sync", acme))
+
+		assert.Equal(t, http.StatusOK, serve(t, "acme-sync", acme))
+		assert.Equal(t, http.StatusForbidden, serve(t, "acme-sync", other))
+		assert.Equal(t, http.StatusForbidden, serve(t, "acme-sync", nil))
+
+		assert.Equal(t, http.StatusOK, serve(t, "acme-id-sync", acme), "the tenant is matched by its ID")
+		assert.Equal(t, http.StatusForbidden, serve(t, "acme-id-sync", other))
+	})
+
+	t.Run("case=only allows credentials of the default network to manage tenants", func(t *testing.T) {
+		acme := &tenant.Tenant{ID: uuid.Must(uuid.NewV4()), Name: "acme"}
+		token := newKey(t, &apikey.Key{Name: "tenants", Scopes: []string{apikey.ScopeAdmin}})
+
+		serve := func(t *testing.T, method, path string, tn *tenant.Tenant) int {
+			req := httptest.NewRequest(method, path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tn != nil {
+				req = req.WithContext(tenant.WithTenant(req.Context(), tn))
+			}
+			w := httptest.NewRecorder()
+			n.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusOK, serve(t, "GET", "/admin/tenants", nil))
+		assert.Equal(t, http.StatusForbidden, serve(t, "GET", "/admin/tenants", acme))
+		assert.Equal(t, http.StatusForbidden, serve(t, "POST", "/admin/tenants", acme))
+		assert.Equal(t, http.StatusForbidden, serve(t, "DELETE", "/admin/tenants/"+acme.ID.String(), acme))
+		assert.Equal(t, http.StatusOK, serve(t, "GET", "/admin/identities", acme), "tenant credentials can still manage their own network")
+	})
+}
@@ -0,0 +1,358 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package approval lets administrators review identities which registered
+// while `selfservice.flows.registration.require_approval` is enabled. Such
+// identities are in the `pending_approval` state and can not sign in until
+// they are approved.
+package approval
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/pagination/keysetpagination"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	RouteCollection = "/registration-approvals"
+	RouteApprove    = RouteCollection + "/{id}/approve"
+	RouteReject     = RouteCollection + "/{id}/reject"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.WriterProvider
+		httpx.ClientProvider
+		nosurfx.CSRFProvider
+		courier.Provider
+		courier.ConfigProvider
+		identity.PrivilegedPoolProvider
+		identity.TraitsClassifierProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		RegistrationApprovalHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteApprove, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteReject, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteApprove, h.approve)
+	admin.POST(RouteReject, h.reject)
+}
+
+// Paginated Registration Approval List Response
+//
+// swagger:response listRegistrationApprovals
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listRegistrationApprovalsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of identities pending approval
+	//
+	// in:body
+	Body []identity.Identity
+}
+
+// List Registration Approvals Parameters
+//
+// swagger:parameters listRegistrationApprovals
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listRegistrationApprovals struct {
+	keysetpagination.RequestParameters
+
+	// Include PII in Response
+	//
+	// Traits which are classified as PII are redacted unless this is set to true.
+	//
+	// required: false
+	// in: query
+	DeclassifyPII bool `json:"include_pii"`
+}
+
+// swagger:route GET /admin/registration-approvals identity listRegistrationApprovals
+//
+// # List Registrations Pending Approval
+//
+// Lists the identities which registered while registration approval is required and which have
+// not been approved or rejected yet.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listRegistrationApprovals
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts, err := keysetpagination.Parse(r.URL.Query(), keysetpagination.NewStringPageToken)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason(err.Error())))
+		return
+	}
+
+	var declassifyPII bool
+	if v := r.URL.Query().Get("include_pii"); v != "" {
+		if declassifyPII, err = strconv.ParseBool(v); err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The include_pii parameter must be a boolean.")))
+			return
+		}
+	}
+
+	is, nextPage, err := h.r.PrivilegedIdentityPool().ListIdentities(ctx, identity.ListIdentityParameters{
+		Expand:           identity.ExpandDefault,
+		State:            identity.StatePendingApproval,
+		KeySetPagination: opts,
+	})
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if !declassifyPII {
+		for k := range is {
+			if err := h.r.IdentityTraitsClassifier().RedactTraits(ctx, &is[k]); err != nil {
+				h.r.Writer().WriteError(w, r, err)
+				return
+			}
+		}
+	}
+
+	if nextPage != nil {
+		u := *r.URL
+		keysetpagination.Header(w, &u, nextPage)
+	}
+	h.r.Writer().Write(w, r, is)
+}
+
+// Approve Registration Parameters
+//
+// swagger:parameters approveRegistration
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type approveRegistration struct {
+	// ID is the ID of the identity pending approval.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route POST /admin/registration-approvals/{id}/approve identity approveRegistration
+//
+// # Approve a Registration
+//
+// Activates an identity which is pending approval so that it can sign in, and notifies the
+// identity by email.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identity
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) approve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	i, err := h.decide(ctx, x.ParseUUID(r.PathValue("id")), identity.StateActive)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	loginURL := h.r.Config().SelfPublicURL(ctx).JoinPath(login.RouteInitBrowserFlow).String()
+	h.notify(r, i, func(to string, model map[string]interface{}) courier.EmailTemplate {
+		return email.NewRegistrationApproved(h.r, &email.RegistrationApprovedModel{To: to, LoginURL: loginURL, Identity: model})
+	})
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewRegistrationApproved(ctx, i.ID))
+	h.r.Logger().WithField("identity_id", i.ID).Info("A registration has been approved.")
+	h.r.Writer().Write(w, r, i)
+}
+
+// Reject Registration Parameters
+//
+// swagger:parameters rejectRegistration
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type rejectRegistration struct {
+	// ID is the ID of the identity pending approval.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route POST /admin/registration-approvals/{id}/reject identity rejectRegistration
+//
+// # Reject a Registration
+//
+// Deactivates an identity which is pending approval and notifies the identity by email. The
+// identity can not sign in and can be deleted using the identity APIs.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identity
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	i, err := h.decide(ctx, x.ParseUUID(r.PathValue("id")), identity.StateInactive)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.notify(r, i, func(to string, model map[string]interface{}) courier.EmailTemplate {
+		return email.NewRegistrationRejected(h.r, &email.RegistrationRejectedModel{To: to, Identity: model})
+	})
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewRegistrationRejected(ctx, i.ID))
+	h.r.Logger().WithField("identity_id", i.ID).Info("A registration has been rejected.")
+	h.r.Writer().Write(w, r, i)
+}
+
+// decide moves the identity out of the pending approval state.
+func (h *Handler) decide(ctx context.Context, id uuid.UUID, state identity.State) (*identity.Identity, error) {
+	i, err := h.r.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.State != identity.StatePendingApproval {
+		return nil, errors.WithStack(herodot.ErrConflict().WithReasonf("The identity is not pending approval because it is %s.", i.State))
+	}
+
+	i.State = state
+	i.StateChangedAt = new(sqlxx.NullTime(time.Now().UTC()))
+	if err := h.r.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "state", "state_changed_at"); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// notify emails the decision to the identity. Failing to do so does not undo
+// the decision.
+func (h *Handler) notify(r *http.Request, i *identity.Identity, newTemplate func(to string, model map[string]interface{}) courier.EmailTemplate) {
+	if err := h.queue(r.Context(), i, newTemplate); err != nil {
+		h.r.Logger().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			WithError(err).
+			Warn("Unable to send the registration approval notification.")
+	}
+}
+
+func (h *Handler) queue(ctx context.Context, i *identity.Identity, newTemplate func(to string, model map[string]interface{}) courier.EmailTemplate) error {
+	to, ok := emailAddress(i)
+	if !ok {
+		return nil
+	}
+
+	model, err := x.StructToMap(i.CopyWithoutCredentials())
+	if err != nil {
+		return err
+	}
+
+	c, err := h.r.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.QueueEmail(ctx, newTemplate(to, model))
+	return err
+}
+
+// emailAddress returns the identity's first verifiable email address, or its
+// first recovery email address if it has no verifiable one.
+func emailAddress(i *identity.Identity) (string, bool) {
+	for _, a := range i.VerifiableAddresses {
+		if a.Via == identity.AddressTypeEmail {
+			return a.Value, true
+		}
+	}
+	for _, a := range i.RecoveryAddresses {
+		if a.Via == identity.AddressTypeEmail {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
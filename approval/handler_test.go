@@ -0,0 +1,135 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package approval_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/approval"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/configx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, true)),
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceRegistrationEnableLegacyOneStep: true,
+			config.ViperKeySelfServiceRegistrationRequireApproval:     true,
+		}),
+	)
+	publicTS, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewRegistrationUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	register := func(t *testing.T, email, password string) gjson.Result {
+		body := testhelpers.SubmitRegistrationForm(t, true, testhelpers.NewDebugClient(t), publicTS, func(v url.Values) {
+			v.Set("traits.email", email)
+			v.Set("password", password)
+			v.Set("method", identity.CredentialsTypePassword.String())
+		}, false, http.StatusOK, publicTS.URL+registration.RouteSubmitFlow)
+		return gjson.Parse(body)
+	}
+
+	loginError := func(t *testing.T, email, password string) gjson.Result {
+		body := testhelpers.SubmitLoginForm(t, true, testhelpers.NewDebugClient(t), publicTS, func(v url.Values) {
+			v.Set("identifier", email)
+			v.Set("password", password)
+			v.Set("method", identity.CredentialsTypePassword.String())
+		}, false, false, http.StatusBadRequest, publicTS.URL+login.RouteSubmitFlow)
+		return gjson.Parse(body)
+	}
+
+	decide := func(t *testing.T, route, id string, expectCode int) gjson.Result {
+		res, err := adminTS.Client().Post(adminTS.URL+"/admin"+strings.Replace(route, "{id}", id, 1), "application/json", nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body := x.MustReadAll(res.Body)
+		require.Equal(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	messages := func(t *testing.T, email string) []courier.Message {
+		messages, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{Recipient: email}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		return messages
+	}
+
+	t.Run("case=approved registrations can sign in", func(t *testing.T) {
+		email, password := "approve@ory.sh", x.NewUUID().String()
+
+		res := register(t, email, password)
+		assert.Equal(t, string(identity.StatePendingApproval), res.Get("identity.state").String(), "%s", res.Raw)
+		assert.False(t, res.Get("session_token").Exists(), "%s", res.Raw)
+		id := res.Get("identity.id").String()
+
+		res = loginError(t, email, password)
+		assert.EqualValues(t, text.ErrorValidationIdentityPendingApproval, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+
+		list, body := testhelpers.EasyGetJSON(t, adminTS.Client(), adminTS.URL+"/admin"+approval.RouteCollection)
+		require.Equal(t, http.StatusOK, list.StatusCode, "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "#.id").String(), id)
+
+		res = decide(t, approval.RouteApprove, id, http.StatusOK)
+		assert.Equal(t, string(identity.StateActive), res.Get("state").String(), "%s", res.Raw)
+
+		sent := messages(t, email)
+		require.Len(t, sent, 1)
+		assert.Equal(t, template.TypeRegistrationApproved, sent[0].TemplateType)
+
+		_, body = testhelpers.EasyGetJSON(t, adminTS.Client(), adminTS.URL+"/admin"+approval.RouteCollection)
+		assert.NotContains(t, gjson.GetBytes(body, "#.id").String(), id)
+
+		_ = testhelpers.SubmitLoginForm(t, true, testhelpers.NewDebugClient(t), publicTS, func(v url.Values) {
+			v.Set("identifier", email)
+			v.Set("password", password)
+			v.Set("method", identity.CredentialsTypePassword.String())
+		}, false, false, http.StatusOK, publicTS.URL+login.RouteSubmitFlow)
+
+		t.Run("case=decisions can not be changed", func(t *testing.T) {
+			decide(t, approval.RouteApprove, id, http.StatusConflict)
+			decide(t, approval.RouteReject, id, http.StatusConflict)
+		})
+	})
+
+	t.Run("case=rejected registrations can not sign in", func(t *testing.T) {
+		email, password := "reject@ory.sh", x.NewUUID().String()
+
+		id := register(t, email, password).Get("identity.id").String()
+
+		res := decide(t, approval.RouteReject, id, http.StatusOK)
+		assert.Equal(t, string(identity.StateInactive), res.Get("state").String(), "%s", res.Raw)
+
+		sent := messages(t, email)
+		require.Len(t, sent, 1)
+		assert.Equal(t, template.TypeRegistrationRejected, sent[0].TemplateType)
+
+		res = loginError(t, email, password)
+		assert.EqualValues(t, text.ErrorValidationIdentityDisabled, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+	})
+
+	t.Run("case=unknown identities are not found", func(t *testing.T) {
+		decide(t, approval.RouteApprove, x.NewUUID().String(), http.StatusNotFound)
+	})
+}
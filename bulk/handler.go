@@ -0,0 +1,489 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/logrusx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCollection = "/identity-jobs"
+	RouteItem       = RouteCollection + "/{id}"
+	RouteFailures   = RouteItem + "/failures"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		PersistenceProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		IdentityJobHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		RouteCollection,
+		RouteCollection+"/*",
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteFailures, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteCollection, h.create)
+	admin.GET(RouteItem, h.get)
+	admin.DELETE(RouteItem, h.cancel)
+	admin.GET(RouteFailures, h.listFailures)
+}
+
+// Create Identity Job Request Body
+//
+// swagger:model createIdentityJobBody
+type CreateJobBody struct {
+	// Action is the action to apply to the identities. One of `delete`,
+	// `deactivate`, `revoke_sessions` and `set_metadata`. Deactivating an
+	// identity also revokes its sessions.
+	//
+	// required: true
+	Action string `json:"action"`
+
+	// Selector selects the identities to apply the action to. At least one
+	// condition is required.
+	//
+	// required: true
+	Selector Selector `json:"selector"`
+
+	// MetadataPublic replaces the identities' public metadata. Only used by
+	// the `set_metadata` action.
+	MetadataPublic json.RawMessage `json:"metadata_public,omitempty"`
+
+	// MetadataAdmin replaces the identities' admin metadata. Only used by the
+	// `set_metadata` action.
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
+}
+
+// Create Identity Job Parameters
+//
+// swagger:parameters createIdentityJob
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createIdentityJob struct {
+	// in: body
+	Body CreateJobBody
+}
+
+// swagger:route POST /admin/identity-jobs identity createIdentityJob
+//
+// # Create an Identity Job
+//
+// Creates a job which deletes or deactivates the selected identities, revokes their sessions, or
+// replaces their metadata. The job runs in the background in batches of `identity.jobs.batch_size`
+// identities and reports its progress. Jobs are run by the `identity_jobs` maintenance job and
+// therefore can only be created if `maintenance.enabled` is set and the job is enabled.
+//
+// Identities are selected by organization, identity schema and credentials identifier pattern, or by
+// a list of up to 100,000 identity IDs.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: identityJob
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body CreateJobBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	// Identity jobs are run by the maintenance scheduler.
+	if !h.r.Config().MaintenanceEnabled(ctx) || !h.r.Config().MaintenanceJobEnabled(ctx, MaintenanceJobName) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrMisconfiguration().WithReason(
+			`Identity jobs are run by the "identity_jobs" maintenance job, which is disabled. Set "maintenance.enabled" to true and enable the job to create identity jobs.`)))
+		return
+	}
+
+	j, ids, err := newJob(&body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityJobPersister().CreateIdentityJob(ctx, j, ids); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewIdentityJobCreated(ctx, j.ID, j.Action))
+	h.r.Logger().
+		WithField("identity_job_id", j.ID).
+		WithField("identity_job_action", j.Action).
+		Info("An identity job has been created.")
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "identity-jobs", j.ID.String()).String(),
+		j,
+	)
+}
+
+// newJob validates the request body and returns the job and, if the job
+// selects a list of identity IDs, the sorted and deduplicated IDs.
+func newJob(body *CreateJobBody) (*Job, []uuid.UUID, error) {
+	if !slices.Contains(Actions, body.Action) {
+		return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The action %q is unknown. Known actions are: %s", body.Action, strings.Join(Actions, ", ")))
+	}
+
+	s := body.Selector
+	if s.Empty() {
+		return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReason(`The "selector" requires at least one condition.`))
+	}
+
+	j := &Job{
+		ID:     uuid.Must(uuid.NewV4()),
+		Action: body.Action,
+		Status: StatusPending,
+	}
+
+	var ids []uuid.UUID
+	if len(s.IdentityIDs) > 0 {
+		if s.OrganizationID.Valid || s.SchemaID != "" || s.CredentialsIdentifier != "" {
+			return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReason(`The selector's "identity_ids" can not be combined with other conditions.`))
+		}
+		if len(s.IdentityIDs) > MaxIdentityIDs {
+			return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`The selector's "identity_ids" must not contain more than %d IDs.`, MaxIdentityIDs))
+		}
+		ids = slices.Compact(slices.SortedFunc(slices.Values(s.IdentityIDs), func(a, b uuid.UUID) int {
+			return bytes.Compare(a.Bytes(), b.Bytes())
+		}))
+		s.IdentityIDs = nil
+		j.IdentityIDList = true
+		j.Total = len(ids)
+	}
+	j.Selector = s
+
+	if body.Action == ActionSetMetadata {
+		j.MetadataPublic = nullJSON(body.MetadataPublic)
+		j.MetadataAdmin = nullJSON(body.MetadataAdmin)
+		if len(j.MetadataPublic) == 0 && len(j.MetadataAdmin) == 0 {
+			return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReason(`The action "set_metadata" requires "metadata_public" or "metadata_admin".`))
+		}
+	} else if len(nullJSON(body.MetadataPublic)) > 0 || len(nullJSON(body.MetadataAdmin)) > 0 {
+		return nil, nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`The action %q does not accept metadata.`, body.Action))
+	}
+
+	return j, ids, nil
+}
+
+func nullJSON(raw json.RawMessage) sqlxx.NullJSONRawMessage {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return sqlxx.NullJSONRawMessage(raw)
+}
+
+// Paginated Identity Job List Response
+//
+// swagger:response listIdentityJobs
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityJobsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of identity jobs
+	//
+	// in:body
+	Body []Job
+}
+
+// List Identity Jobs Parameters
+//
+// swagger:parameters listIdentityJobs
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityJobs struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/identity-jobs identity listIdentityJobs
+//
+// # List Identity Jobs
+//
+// Lists all identity jobs, including completed, canceled and failed ones.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listIdentityJobs
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	jobs, nextPage, err := h.r.IdentityJobPersister().ListIdentityJobs(r.Context(), opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, jobs)
+}
+
+// Get Identity Job Parameters
+//
+// swagger:parameters getIdentityJob
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getIdentityJob struct {
+	// ID is the identity job's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/identity-jobs/{id} identity getIdentityJob
+//
+// # Get an Identity Job
+//
+// Returns the identity job including its status and progress.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identityJob
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	j, err := h.r.IdentityJobPersister().GetIdentityJob(r.Context(), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, j)
+}
+
+// Paginated Identity Job Failure List Response
+//
+// swagger:response listIdentityJobFailures
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityJobFailuresResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of identity job failures
+	//
+	// in:body
+	Body []Failure
+}
+
+// List Identity Job Failures Parameters
+//
+// swagger:parameters listIdentityJobFailures
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityJobFailures struct {
+	keysetpagination.RequestParameters
+
+	// ID is the identity job's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/identity-jobs/{id}/failures identity listIdentityJobFailures
+//
+// # List Identity Job Failures
+//
+// Lists the identities the job's action failed for and why.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listIdentityJobFailures
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) listFailures(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	j, err := h.r.IdentityJobPersister().GetIdentityJob(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	keys := h.r.Config().SecretsPagination(ctx)
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	failures, nextPage, err := h.r.IdentityJobPersister().ListIdentityJobFailures(ctx, j.ID, opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, failures)
+}
+
+// Cancel Identity Job Parameters
+//
+// swagger:parameters cancelIdentityJob
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type cancelIdentityJob struct {
+	// ID is the identity job's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/identity-jobs/{id} identity cancelIdentityJob
+//
+// # Cancel an Identity Job
+//
+// Cancels a pending or running identity job. A running job stops after its current batch; identities
+// the action was already applied to are not restored. Canceled jobs are kept to report their progress.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	j, err := h.r.IdentityJobPersister().GetIdentityJob(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityJobPersister().CancelIdentityJob(ctx, j.ID); errors.Is(err, sqlcon.ErrNoRows()) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReason("The identity job already finished.")))
+		return
+	} else if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewIdentityJobCanceled(ctx, j.ID, j.Action))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package bulk_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/bulk"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg/testhelpers"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := newRegistry(t)
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	do := func(t *testing.T, method, url string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var payload io.Reader
+		if body != nil {
+			raw, err := json.Marshal(body)
+			require.NoError(t, err)
+			payload = bytes.NewReader(raw)
+		}
+		req, err := http.NewRequest(method, url, payload)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	collection := adminTS.URL + "/admin" + bulk.RouteCollection
+
+	t.Run("case=rejects invalid jobs", func(t *testing.T) {
+		id := uuid.Must(uuid.NewV4())
+		for _, tc := range []struct {
+			name   string
+			body   map[string]any
+			reason string
+		}{
+			{"unknown action", map[string]any{"action": "anonymize", "selector": map[string]any{"schema_id": "default"}}, "is unknown"},
+			{"empty selector", map[string]any{"action": bulk.ActionDelete, "selector": map[string]any{}}, "at least one condition"},
+			{"combined selector", map[string]any{"action": bulk.ActionDelete, "selector": map[string]any{"schema_id": "default", "identity_ids": []uuid.UUID{id}}}, "can not be combined"},
+			{"missing metadata", map[string]any{"action": bulk.ActionSetMetadata, "selector": map[string]any{"schema_id": "default"}, "metadata_public": nil}, "requires"},
+			{"unexpected metadata", map[string]any{"action": bulk.ActionDelete, "selector": map[string]any{"schema_id": "default"}, "metadata_admin": map[string]any{"a": "b"}}, "does not accept metadata"},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				res := do(t, "POST", collection, tc.body, http.StatusBadRequest)
+				assert.Contains(t, res.Get("error.reason").String(), tc.reason)
+			})
+		}
+	})
+
+	t.Run("case=creates, lists and cancels a job", func(t *testing.T) {
+		a, b := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+		res := do(t, "POST", collection, map[string]any{
+			"action":   bulk.ActionRevokeSessions,
+			"selector": map[string]any{"identity_ids": []uuid.UUID{a, b, a}},
+		}, http.StatusCreated)
+
+		id := res.Get("id").String()
+		assert.Equal(t, bulk.ActionRevokeSessions, res.Get("action").String())
+		assert.Equal(t, bulk.StatusPending, res.Get("status").String())
+		assert.True(t, res.Get("identity_id_list").Bool())
+		assert.EqualValues(t, 2, res.Get("total").Int())
+		assert.False(t, res.Get("selector.identity_ids").Exists())
+
+		assert.Equal(t, id, do(t, "GET", collection+"/"+id, nil, http.StatusOK).Get("id").String())
+		assert.Contains(t, do(t, "GET", collection, nil, http.StatusOK).Get("#.id").Value(), id)
+		assert.Empty(t, do(t, "GET", collection+"/"+id+"/failures", nil, http.StatusOK).Array())
+
+		do(t, "DELETE", collection+"/"+id, nil, http.StatusNoContent)
+		do(t, "DELETE", collection+"/"+id, nil, http.StatusConflict)
+		assert.Equal(t, bulk.StatusCanceled, do(t, "GET", collection+"/"+id, nil, http.StatusOK).Get("status").String())
+
+		j, err := reg.IdentityJobPersister().GetIdentityJob(ctx, uuid.FromStringOrNil(id))
+		require.NoError(t, err)
+		ids, err := reg.IdentityJobPersister().FindIdentityJobTargets(ctx, j, 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids, "the items of finished jobs are removed")
+	})
+
+	t.Run("case=rejects jobs if they would never run", func(t *testing.T) {
+		body := map[string]any{"action": bulk.ActionDelete, "selector": map[string]any{"schema_id": "default"}}
+
+		conf.MustSet(ctx, config.ViperKeyMaintenanceEnabled, false)
+		res := do(t, "POST", collection, body, http.StatusInternalServerError)
+		assert.Contains(t, res.Get("error.reason").String(), "maintenance.enabled")
+		conf.MustSet(ctx, config.ViperKeyMaintenanceEnabled, true)
+
+		conf.MustSet(ctx, config.ViperKeyMaintenanceJobs+"."+bulk.MaintenanceJobName+".enabled", false)
+		do(t, "POST", collection, body, http.StatusInternalServerError)
+		conf.MustSet(ctx, config.ViperKeyMaintenanceJobs+"."+bulk.MaintenanceJobName+".enabled", true)
+	})
+
+	t.Run("case=returns 404 for unknown jobs", func(t *testing.T) {
+		do(t, "GET", collection+"/"+uuid.Must(uuid.NewV4()).String(), nil, http.StatusNotFound)
+		do(t, "DELETE", collection+"/"+uuid.Must(uuid.NewV4()).String(), nil, http.StatusNotFound)
+		do(t, "GET", collection+"/"+uuid.Must(uuid.NewV4()).String()+"/failures", nil, http.StatusNotFound)
+	})
+}
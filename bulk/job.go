@@ -0,0 +1,292 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bulk applies an action, such as deleting or deactivating, to many
+// identities at once. Jobs are created through the admin API and run in the
+// background by the "identity_jobs" maintenance job, in batches which can be
+// resumed after a restart.
+package bulk
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/x"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	// ActionDelete deletes the identities.
+	ActionDelete = "delete"
+	// ActionDeactivate sets the identities' state to inactive and revokes
+	// their sessions.
+	ActionDeactivate = "deactivate"
+	// ActionRevokeSessions revokes the identities' sessions.
+	ActionRevokeSessions = "revoke_sessions"
+	// ActionSetMetadata replaces the identities' public and admin metadata.
+	ActionSetMetadata = "set_metadata"
+
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusCanceled  = "canceled"
+	StatusFailed    = "failed"
+
+	// MaxIdentityIDs is the maximum number of identity IDs a job can select.
+	MaxIdentityIDs = 100_000
+)
+
+// Actions lists all known actions.
+var Actions = []string{
+	ActionDelete,
+	ActionDeactivate,
+	ActionRevokeSessions,
+	ActionSetMetadata,
+}
+
+type (
+	// Identity Job Selector
+	//
+	// Selects the identities an identity job applies to. The identities must
+	// match all given conditions.
+	//
+	// swagger:model identityJobSelector
+	Selector struct {
+		// OrganizationID selects the identities of this organization.
+		OrganizationID uuid.NullUUID `json:"organization_id"`
+
+		// SchemaID selects the identities using this identity schema.
+		SchemaID string `json:"schema_id,omitempty"`
+
+		// CredentialsIdentifier selects the identities with a credentials
+		// identifier, such as an email address or username, matching this
+		// pattern. The pattern may contain `*` as a wildcard, for example
+		// `*@example.org`.
+		CredentialsIdentifier string `json:"credentials_identifier,omitempty"`
+
+		// IdentityIDs selects the identities with these IDs. It can not be
+		// combined with other conditions. The IDs are not returned.
+		IdentityIDs []uuid.UUID `json:"identity_ids,omitempty"`
+	}
+
+	// Identity Job
+	//
+	// An identity job applies an action to the selected identities in the
+	// background.
+	//
+	// swagger:model identityJob
+	Job struct {
+		// ID is the job's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// Action is the action applied to the identities. One of `delete`,
+		// `deactivate`, `revoke_sessions` and `set_metadata`.
+		//
+		// required: true
+		Action string `json:"action" db:"action"`
+
+		// Selector selects the identities the job applies to.
+		//
+		// required: true
+		Selector Selector `json:"selector" faker:"-" db:"selector"`
+
+		// IdentityIDList is true if the job applies to a list of identity IDs.
+		//
+		// required: true
+		IdentityIDList bool `json:"identity_id_list" db:"identity_id_list"`
+
+		// MetadataPublic replaces the identities' public metadata if the
+		// action is `set_metadata`.
+		MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public,omitempty" faker:"-" db:"metadata_public"`
+
+		// MetadataAdmin replaces the identities' admin metadata if the action
+		// is `set_metadata`.
+		MetadataAdmin sqlxx.NullJSONRawMessage `json:"metadata_admin,omitempty" faker:"-" db:"metadata_admin"`
+
+		// Status is one of `pending`, `running`, `completed`, `canceled` and
+		// `failed`.
+		//
+		// required: true
+		Status string `json:"status" db:"status"`
+
+		// Total is the number of identities the job applies to. It is
+		// determined when the job starts running.
+		//
+		// required: true
+		Total int `json:"total" db:"total"`
+
+		// Processed is the number of identities the job was applied to so
+		// far, including failures.
+		//
+		// required: true
+		Processed int `json:"processed" db:"processed"`
+
+		// Failed is the number of identities the action failed for.
+		//
+		// required: true
+		Failed int `json:"failed" db:"failed"`
+
+		// Cursor is the ID of the last processed identity.
+		Cursor uuid.NullUUID `json:"-" faker:"-" db:"last_identity_id"`
+
+		// StartedAt is the time (UTC) at which the job started running.
+		StartedAt *sqlxx.NullTime `json:"started_at,omitempty" faker:"-" db:"started_at"`
+
+		// Error describes why the job failed.
+		Error sqlxx.NullString `json:"error,omitempty" faker:"-" db:"error"`
+
+		// FinishedAt is the time (UTC) at which the job completed, was
+		// canceled or failed.
+		FinishedAt *sqlxx.NullTime `json:"finished_at,omitempty" faker:"-" db:"finished_at"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	// Identity Job Failure
+	//
+	// An identity the job's action failed for.
+	//
+	// swagger:model identityJobFailure
+	Failure struct {
+		// ID is the failure's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// JobID is the ID of the job.
+		//
+		// required: true
+		JobID uuid.UUID `json:"job_id" faker:"-" db:"job_id"`
+
+		// IdentityID is the ID of the identity.
+		//
+		// required: true
+		IdentityID uuid.UUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+		// Error describes why the action failed.
+		//
+		// required: true
+		Error string `json:"error" db:"error"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	// Item is an identity ID of a job selecting a list of identity IDs.
+	Item struct {
+		ID         uuid.UUID `db:"id"`
+		NID        uuid.UUID `db:"nid"`
+		JobID      uuid.UUID `db:"job_id"`
+		IdentityID uuid.UUID `db:"identity_id"`
+	}
+
+	Persister interface {
+		// CreateIdentityJob creates the job and, for jobs selecting a list of
+		// identity IDs, its items.
+		CreateIdentityJob(ctx context.Context, j *Job, identityIDs []uuid.UUID) error
+		GetIdentityJob(ctx context.Context, id uuid.UUID) (*Job, error)
+		ListIdentityJobs(ctx context.Context, opts []keysetpagination.Option) ([]Job, *keysetpagination.Paginator, error)
+		ListIdentityJobFailures(ctx context.Context, jobID uuid.UUID, opts []keysetpagination.Option) ([]Failure, *keysetpagination.Paginator, error)
+
+		// ListUnfinishedIdentityJobs returns the pending and running jobs,
+		// oldest first.
+		ListUnfinishedIdentityJobs(ctx context.Context) ([]Job, error)
+
+		// CountIdentityJobTargets returns the number of identities the job
+		// applies to.
+		CountIdentityJobTargets(ctx context.Context, j *Job) (int, error)
+
+		// FindIdentityJobTargets returns, in ascending order, the IDs of up
+		// to limit identities the job applies to after its cursor.
+		FindIdentityJobTargets(ctx context.Context, j *Job, limit int) ([]uuid.UUID, error)
+
+		// StartIdentityJob marks the pending job as running and returns
+		// sqlcon.ErrNoRows if it is not pending.
+		StartIdentityJob(ctx context.Context, id uuid.UUID, total int) error
+
+		// RecordIdentityJobBatch advances the running job's cursor and records
+		// the batch's failures. It returns sqlcon.ErrNoRows if the job is not
+		// running.
+		RecordIdentityJobBatch(ctx context.Context, id, cursor uuid.UUID, processed int, failures []Failure) error
+
+		// FinishIdentityJob marks the running job as completed and returns
+		// sqlcon.ErrNoRows if it is not running.
+		FinishIdentityJob(ctx context.Context, id uuid.UUID) error
+
+		// CancelIdentityJob marks the pending or running job as canceled and
+		// returns sqlcon.ErrNoRows if it already finished.
+		CancelIdentityJob(ctx context.Context, id uuid.UUID) error
+
+		// FailIdentityJob marks the pending or running job as failed and
+		// returns sqlcon.ErrNoRows if it already finished.
+		FailIdentityJob(ctx context.Context, id uuid.UUID, reason string) error
+	}
+	PersistenceProvider interface {
+		IdentityJobPersister() Persister
+	}
+)
+
+func (Job) TableName() string     { return "identity_jobs" }
+func (Failure) TableName() string { return "identity_job_failures" }
+func (Item) TableName() string    { return "identity_job_items" }
+
+func (j Job) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: j.ID})
+}
+
+func (j Job) DefaultPageToken() keysetpagination.PageToken {
+	return Job{ID: uuid.Nil}.PageToken()
+}
+
+func (f Failure) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: f.ID})
+}
+
+func (f Failure) DefaultPageToken() keysetpagination.PageToken {
+	return Failure{ID: uuid.Nil}.PageToken()
+}
+
+// Finished returns true if the job completed, was canceled or failed.
+func (j *Job) Finished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusCanceled || j.Status == StatusFailed
+}
+
+func (s *Selector) Scan(value any) error {
+	return sqlxx.JSONScan(s, value)
+}
+
+func (s Selector) Value() (driver.Value, error) {
+	v, err := json.Marshal(s)
+	return string(v), err
+}
+
+// Empty returns true if the selector has no conditions and would select all
+// identities.
+func (s *Selector) Empty() bool {
+	return !s.OrganizationID.Valid && s.SchemaID == "" && s.CredentialsIdentifier == "" && len(s.IdentityIDs) == 0
+}
+
+// CredentialsIdentifierLikePattern returns the credentials identifier
+// pattern as an SQL LIKE pattern.
+func (s *Selector) CredentialsIdentifierLikePattern() string {
+	return strings.ReplaceAll(x.EscapeLikePattern(s.CredentialsIdentifier), "*", "%")
+}
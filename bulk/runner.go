@@ -0,0 +1,226 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package bulk
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+// MaintenanceJobName is the name of the maintenance job running the identity
+// jobs.
+const MaintenanceJobName = "identity_jobs"
+
+type (
+	runnerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		identity.PrivilegedPoolProvider
+		session.PersistenceProvider
+		tenant.ContextsProvider
+		PersistenceProvider
+	}
+
+	// Runner runs the pending and running identity jobs. It is run as the
+	// "identity_jobs" maintenance job.
+	Runner struct {
+		d runnerDependencies
+	}
+	RunnerProvider interface {
+		IdentityJobRunner() *Runner
+	}
+)
+
+func NewRunner(d runnerDependencies) *Runner {
+	return &Runner{d: d}
+}
+
+func (r *Runner) Name() string {
+	return MaintenanceJobName
+}
+
+// Run runs the unfinished identity jobs to completion, in the default network
+// and, in multi-tenant mode, the network of every tenant. Progress is saved
+// after every batch, so that jobs interrupted by a shutdown or a lost lease
+// resume where they left off in the next run.
+//
+// A job which fails is marked as failed, and the remaining jobs are run
+// regardless.
+func (r *Runner) Run(ctx context.Context) error {
+	ctxs, err := r.d.TenantContexts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ctx := range ctxs {
+		jobs, err := r.d.IdentityJobPersister().ListUnfinishedIdentityJobs(ctx)
+		if err != nil {
+			r.d.Logger().WithError(err).Error("Unable to list the unfinished identity jobs.")
+			continue
+		}
+		for k := range jobs {
+			err := r.RunJob(ctx, &jobs[k])
+			if ctx.Err() != nil {
+				// Interrupted jobs resume in the next run.
+				return errors.WithStack(ctx.Err())
+			} else if err == nil {
+				continue
+			}
+
+			l := r.d.Logger().WithError(err).WithField("identity_job_id", jobs[k].ID)
+			l.Error("The identity job failed.")
+			if err := r.d.IdentityJobPersister().FailIdentityJob(ctx, jobs[k].ID, failureReason(err)); err != nil && !errors.Is(err, sqlcon.ErrNoRows()) {
+				l.WithError(err).Error("Unable to mark the identity job as failed.")
+			}
+		}
+	}
+	return nil
+}
+
+// RunJob starts the job if it is pending and processes its batches until it
+// is completed or canceled.
+func (r *Runner) RunJob(ctx context.Context, j *Job) error {
+	l := r.d.Logger().
+		WithField("identity_job_id", j.ID).
+		WithField("identity_job_action", j.Action)
+
+	if j.Status == StatusPending {
+		total := j.Total
+		if !j.IdentityIDList {
+			var err error
+			if total, err = r.d.IdentityJobPersister().CountIdentityJobTargets(ctx, j); err != nil {
+				return err
+			}
+		}
+		if err := r.d.IdentityJobPersister().StartIdentityJob(ctx, j.ID, total); errors.Is(err, sqlcon.ErrNoRows()) {
+			// The job was canceled in the meantime.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		l.WithField("identity_job_total", total).Info("Identity job started.")
+	}
+
+	batchSize := r.d.Config().IdentityJobsBatchSize(ctx)
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// The job is loaded again for every batch to pick up its cursor and
+		// to stop once it was canceled.
+		var err error
+		if j, err = r.d.IdentityJobPersister().GetIdentityJob(ctx, j.ID); err != nil {
+			return err
+		}
+		if j.Status != StatusRunning {
+			l.WithField("identity_job_status", j.Status).Info("Identity job stopped.")
+			return nil
+		}
+
+		ids, err := r.d.IdentityJobPersister().FindIdentityJobTargets(ctx, j, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			if err := r.d.IdentityJobPersister().FinishIdentityJob(ctx, j.ID); err != nil && !errors.Is(err, sqlcon.ErrNoRows()) {
+				return err
+			}
+			l.WithField("identity_job_processed", j.Processed).
+				WithField("identity_job_failed", j.Failed).
+				Info("Identity job completed.")
+			return nil
+		}
+
+		var failures []Failure
+		for _, id := range ids {
+			if err := r.apply(ctx, j, id); err != nil {
+				if ctx.Err() != nil {
+					return errors.WithStack(ctx.Err())
+				}
+				failures = append(failures, Failure{JobID: j.ID, IdentityID: id, Error: failureReason(err)})
+			}
+		}
+
+		if err := r.d.IdentityJobPersister().RecordIdentityJobBatch(ctx, j.ID, ids[len(ids)-1], len(ids), failures); errors.Is(err, sqlcon.ErrNoRows()) {
+			// The job was canceled while the batch was processed.
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Runner) apply(ctx context.Context, j *Job, id uuid.UUID) error {
+	switch j.Action {
+	case ActionDelete:
+		// Deleting the identity cascades to its sessions.
+		return r.d.PrivilegedIdentityPool().DeleteIdentity(ctx, id)
+	case ActionDeactivate:
+		i, err := r.d.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandNothing)
+		if err != nil {
+			return err
+		}
+		if i.State != identity.StateInactive {
+			stateChangedAt := sqlxx.NullTime(time.Now().UTC())
+			i.State = identity.StateInactive
+			i.StateChangedAt = &stateChangedAt
+			if err := r.d.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "state", "state_changed_at"); err != nil {
+				return err
+			}
+		}
+		return r.revokeSessions(ctx, id)
+	case ActionRevokeSessions:
+		if _, err := r.d.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandNothing); err != nil {
+			return err
+		}
+		return r.revokeSessions(ctx, id)
+	case ActionSetMetadata:
+		i, err := r.d.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandNothing)
+		if err != nil {
+			return err
+		}
+		var columns []string
+		if len(j.MetadataPublic) > 0 {
+			i.MetadataPublic = j.MetadataPublic
+			columns = append(columns, "metadata_public")
+		}
+		if len(j.MetadataAdmin) > 0 {
+			i.MetadataAdmin = j.MetadataAdmin
+			columns = append(columns, "metadata_admin")
+		}
+		return r.d.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, columns...)
+	default:
+		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Identity job %s has unknown action %q.", j.ID, j.Action))
+	}
+}
+
+func (r *Runner) revokeSessions(ctx context.Context, id uuid.UUID) error {
+	if err := r.d.SessionPersister().DeleteSessionsByIdentity(ctx, id); err != nil && !errors.Is(err, sqlcon.ErrNoRows()) {
+		return err
+	}
+	return nil
+}
+
+func failureReason(err error) string {
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return "The identity does not exist."
+	}
+	var herodotErr *herodot.DefaultError
+	if errors.As(err, &herodotErr) && herodotErr.Reason() != "" {
+		return herodotErr.Reason()
+	}
+	return err.Error()
+}
@@ -0,0 +1,241 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package bulk_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/bulk"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+func newRegistry(t *testing.T) (*config.Config, *driver.RegistryDefault) {
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(context.Background(), config.ViperKeyMaintenanceEnabled, true)
+	return conf, reg
+}
+
+// createIdentity creates an identity with a password and an active session.
+func createIdentity(t *testing.T, reg *driver.RegistryDefault, email string) *identity.Identity {
+	ctx := context.Background()
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"` + email + `"}`)
+	i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+		Identifiers: []string{email},
+		Config:      []byte(`{"hashed_password":"$2a$04$zvZz1zV"}`),
+	})
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	s, err := testhelpers.NewActiveSession(httptest.NewRequest("GET", "/sessions/whoami", nil), reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+	return i
+}
+
+func createJob(t *testing.T, reg *driver.RegistryDefault, j *bulk.Job, ids ...uuid.UUID) *bulk.Job {
+	j.ID = uuid.Must(uuid.NewV4())
+	j.Status = bulk.StatusPending
+	if len(ids) > 0 {
+		j.IdentityIDList = true
+		j.Total = len(ids)
+	}
+	require.NoError(t, reg.IdentityJobPersister().CreateIdentityJob(context.Background(), j, ids))
+	return j
+}
+
+func getJob(t *testing.T, reg *driver.RegistryDefault, id uuid.UUID) *bulk.Job {
+	j, err := reg.IdentityJobPersister().GetIdentityJob(context.Background(), id)
+	require.NoError(t, err)
+	return j
+}
+
+func getIdentity(t *testing.T, reg *driver.RegistryDefault, id uuid.UUID) *identity.Identity {
+	i, err := reg.PrivilegedIdentityPool().GetIdentity(context.Background(), id, identity.ExpandNothing)
+	require.NoError(t, err)
+	return i
+}
+
+func countSessions(t *testing.T, reg *driver.RegistryDefault, id uuid.UUID) int64 {
+	_, total, err := reg.SessionPersister().ListSessionsByIdentity(context.Background(), id, nil, 1, 10, uuid.Nil, nil)
+	require.NoError(t, err)
+	return total
+}
+
+// failingJobPersister fails to count the targets of one job.
+type failingJobPersister struct {
+	bulk.Persister
+	failing uuid.UUID
+}
+
+func (p *failingJobPersister) CountIdentityJobTargets(ctx context.Context, j *bulk.Job) (int, error) {
+	if j.ID == p.failing {
+		return 0, errors.New("unable to count the targets")
+	}
+	return p.Persister.CountIdentityJobTargets(ctx, j)
+}
+
+type failingJobRegistry struct {
+	*driver.RegistryDefault
+	p bulk.Persister
+}
+
+func (r *failingJobRegistry) IdentityJobPersister() bulk.Persister {
+	return r.p
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=deactivates identities matching an identifier pattern", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeyIdentityJobsBatchSize, 1)
+
+		first := createIdentity(t, reg, "first@customer.example")
+		second := createIdentity(t, reg, "second@customer.example")
+		other := createIdentity(t, reg, "other@example.org")
+
+		j := createJob(t, reg, &bulk.Job{
+			Action:   bulk.ActionDeactivate,
+			Selector: bulk.Selector{CredentialsIdentifier: "*@customer.example"},
+		})
+		require.NoError(t, reg.IdentityJobRunner().Run(ctx))
+
+		actual := getJob(t, reg, j.ID)
+		assert.Equal(t, bulk.StatusCompleted, actual.Status)
+		assert.Equal(t, 2, actual.Total)
+		assert.Equal(t, 2, actual.Processed)
+		assert.Zero(t, actual.Failed)
+		assert.NotNil(t, actual.StartedAt)
+		assert.NotNil(t, actual.FinishedAt)
+
+		for _, i := range []*identity.Identity{first, second} {
+			assert.Equal(t, identity.StateInactive, getIdentity(t, reg, i.ID).State)
+			assert.EqualValues(t, 0, countSessions(t, reg, i.ID))
+		}
+		assert.Equal(t, identity.StateActive, getIdentity(t, reg, other.ID).State)
+		assert.EqualValues(t, 1, countSessions(t, reg, other.ID))
+	})
+
+	t.Run("case=deletes a list of identities and records failures", func(t *testing.T) {
+		_, reg := newRegistry(t)
+
+		deleted := createIdentity(t, reg, "deleted@example.org")
+		kept := createIdentity(t, reg, "kept@example.org")
+		missing := uuid.Must(uuid.NewV4())
+
+		j := createJob(t, reg, &bulk.Job{Action: bulk.ActionDelete}, deleted.ID, missing)
+		require.NoError(t, reg.IdentityJobRunner().Run(ctx))
+
+		actual := getJob(t, reg, j.ID)
+		assert.Equal(t, bulk.StatusCompleted, actual.Status)
+		assert.Equal(t, 2, actual.Total)
+		assert.Equal(t, 2, actual.Processed)
+		assert.Equal(t, 1, actual.Failed)
+
+		_, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, deleted.ID, identity.ExpandNothing)
+		assert.ErrorIs(t, err, sqlcon.ErrNoRows())
+		getIdentity(t, reg, kept.ID)
+
+		failures, _, err := reg.IdentityJobPersister().ListIdentityJobFailures(ctx, j.ID, []keysetpagination.Option{})
+		require.NoError(t, err)
+		require.Len(t, failures, 1)
+		assert.Equal(t, missing, failures[0].IdentityID)
+		assert.Equal(t, "The identity does not exist.", failures[0].Error)
+	})
+
+	t.Run("case=sets metadata of identities using a schema", func(t *testing.T) {
+		_, reg := newRegistry(t)
+
+		i := createIdentity(t, reg, "metadata@example.org")
+		i.MetadataAdmin = []byte(`{"keep":true}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "metadata_admin"))
+
+		j := createJob(t, reg, &bulk.Job{
+			Action:         bulk.ActionSetMetadata,
+			Selector:       bulk.Selector{SchemaID: config.DefaultIdentityTraitsSchemaID},
+			MetadataPublic: []byte(`{"offboarded":true}`),
+		})
+		require.NoError(t, reg.IdentityJobRunner().Run(ctx))
+		assert.Equal(t, bulk.StatusCompleted, getJob(t, reg, j.ID).Status)
+
+		actual := getIdentity(t, reg, i.ID)
+		assert.JSONEq(t, `{"offboarded":true}`, string(actual.MetadataPublic))
+		assert.JSONEq(t, `{"keep":true}`, string(actual.MetadataAdmin))
+	})
+
+	t.Run("case=resumes after the last recorded batch", func(t *testing.T) {
+		_, reg := newRegistry(t)
+
+		a := createIdentity(t, reg, "a@example.org")
+		b := createIdentity(t, reg, "b@example.org")
+		ids, err := reg.IdentityJobPersister().FindIdentityJobTargets(ctx, &bulk.Job{Selector: bulk.Selector{SchemaID: config.DefaultIdentityTraitsSchemaID}}, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []uuid.UUID{a.ID, b.ID}, ids)
+
+		j := createJob(t, reg, &bulk.Job{Action: bulk.ActionRevokeSessions}, ids...)
+		require.NoError(t, reg.IdentityJobPersister().StartIdentityJob(ctx, j.ID, j.Total))
+		require.NoError(t, reg.IdentityJobPersister().RecordIdentityJobBatch(ctx, j.ID, ids[0], 1, nil))
+
+		require.NoError(t, reg.IdentityJobRunner().Run(ctx))
+
+		actual := getJob(t, reg, j.ID)
+		assert.Equal(t, bulk.StatusCompleted, actual.Status)
+		assert.Equal(t, 2, actual.Processed)
+		assert.EqualValues(t, 1, countSessions(t, reg, ids[0]), "the first identity was already processed")
+		assert.EqualValues(t, 0, countSessions(t, reg, ids[1]))
+	})
+
+	t.Run("case=marks failed jobs and runs the remaining jobs", func(t *testing.T) {
+		_, reg := newRegistry(t)
+
+		i := createIdentity(t, reg, "remaining@example.org")
+		failing := createJob(t, reg, &bulk.Job{Action: bulk.ActionRevokeSessions, Selector: bulk.Selector{SchemaID: config.DefaultIdentityTraitsSchemaID}})
+		remaining := createJob(t, reg, &bulk.Job{Action: bulk.ActionRevokeSessions}, i.ID)
+
+		runner := bulk.NewRunner(&failingJobRegistry{
+			RegistryDefault: reg,
+			p:               &failingJobPersister{Persister: reg.IdentityJobPersister(), failing: failing.ID},
+		})
+		require.NoError(t, runner.Run(ctx))
+
+		actual := getJob(t, reg, failing.ID)
+		assert.Equal(t, bulk.StatusFailed, actual.Status)
+		assert.EqualValues(t, "unable to count the targets", actual.Error)
+		assert.NotNil(t, actual.FinishedAt)
+
+		assert.Equal(t, bulk.StatusCompleted, getJob(t, reg, remaining.ID).Status)
+		assert.EqualValues(t, 0, countSessions(t, reg, i.ID))
+	})
+
+	t.Run("case=does not run canceled jobs", func(t *testing.T) {
+		_, reg := newRegistry(t)
+
+		i := createIdentity(t, reg, "canceled@example.org")
+		j := createJob(t, reg, &bulk.Job{Action: bulk.ActionDelete}, i.ID)
+		require.NoError(t, reg.IdentityJobPersister().CancelIdentityJob(ctx, j.ID))
+		assert.ErrorIs(t, reg.IdentityJobPersister().CancelIdentityJob(ctx, j.ID), sqlcon.ErrNoRows())
+
+		require.NoError(t, reg.IdentityJobRunner().Run(ctx))
+
+		actual := getJob(t, reg, j.ID)
+		assert.Equal(t, bulk.StatusCanceled, actual.Status)
+		assert.Zero(t, actual.Processed)
+		getIdentity(t, reg, i.ID)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	FlagScope          = "scope"
+	FlagOrganizationID = "organization-id"
+	FlagExpiresIn      = "expires-in"
+)
+
+func NewCreateAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-key <name>",
+		Short: "Create an API key for the admin API",
+		Long: fmt.Sprintf(`Creates an API key for the admin API which grants the given scopes. The API key is only printed
+once and cannot be retrieved afterwards. API keys are only enforced if serve.admin.authorization.enabled is set.
+
+Known scopes are: %s`, strings.Join(apikey.Scopes, ", ")),
+		Example: `{{ .CommandPath }} support-tool --scope identities:read --scope sessions:revoke --expires-in 2160h`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := apikey.CreateAPIKeyBody{
+				Name:      args[0],
+				Scopes:    flagx.MustGetStringSlice(cmd, FlagScope),
+				ExpiresIn: flagx.MustGetString(cmd, FlagExpiresIn),
+			}
+
+			if raw := flagx.MustGetString(cmd, FlagOrganizationID); raw != "" {
+				id, err := uuid.FromString(raw)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse the organization ID: %s\n", err)
+					return cmdx.FailSilently(cmd)
+				}
+				body.OrganizationID = uuid.NullUUID{UUID: id, Valid: true}
+			}
+
+			var k outputAPIKeyWithToken
+			if _, err := do(cmd, http.MethodPost, nil, &body, &k); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not create the API key: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			cmdx.PrintRow(cmd, &k)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice(FlagScope, nil, "A scope granted to the API key, e.g. identities:read. Can be repeated.")
+	cmd.Flags().String(FlagOrganizationID, "", "Restricts the API key to manage the identities of this organization.")
+	cmd.Flags().String(FlagExpiresIn, "", "The time after which the API key expires, e.g. 2160h. API keys without an expiry do not expire.")
+	_ = cmd.MarkFlagRequired(FlagScope)
+
+	return cmd
+}
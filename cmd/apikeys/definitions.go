@@ -0,0 +1,91 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	outputAPIKey          apikey.Key
+	outputAPIKeyWithToken struct {
+		apikey.Key
+		Token string `json:"token"`
+	}
+	outputAPIKeyCollection struct {
+		APIKeys       []apikey.Key `json:"api_keys"`
+		NextPageToken string       `json:"next_page_token"`
+	}
+)
+
+func (outputAPIKey) Header() []string {
+	return []string{"ID", "NAME", "SCOPES", "ORGANIZATION", "EXPIRES", "LAST USED", "REVOKED"}
+}
+
+func (k outputAPIKey) Columns() []string {
+	organization := cmdx.None
+	if k.OrganizationID.Valid {
+		organization = k.OrganizationID.UUID.String()
+	}
+	return []string{
+		k.ID.String(),
+		k.Name,
+		strings.Join(k.Scopes, ", "),
+		organization,
+		formatTime(k.ExpiresAt),
+		formatTime(k.LastUsedAt),
+		formatTime(k.RevokedAt),
+	}
+}
+
+func (k outputAPIKey) Interface() interface{} {
+	return apikey.Key(k)
+}
+
+func (outputAPIKeyWithToken) Header() []string {
+	return append(outputAPIKey{}.Header(), "TOKEN")
+}
+
+func (k outputAPIKeyWithToken) Columns() []string {
+	return append(outputAPIKey(k.Key).Columns(), k.Token)
+}
+
+func (k outputAPIKeyWithToken) Interface() interface{} {
+	return k
+}
+
+func (outputAPIKeyCollection) Header() []string {
+	return outputAPIKey{}.Header()
+}
+
+func (c outputAPIKeyCollection) Table() [][]string {
+	rows := make([][]string, len(c.APIKeys))
+	for i, k := range c.APIKeys {
+		rows[i] = outputAPIKey(k).Columns()
+	}
+	return append(rows,
+		[]string{""},
+		[]string{"NEXT PAGE TOKEN", c.NextPageToken},
+	)
+}
+
+func (c outputAPIKeyCollection) Interface() interface{} {
+	return c
+}
+
+func (c outputAPIKeyCollection) Len() int {
+	return len(c.APIKeys)
+}
+
+func formatTime(t *sqlxx.NullTime) string {
+	if t == nil {
+		return cmdx.None
+	}
+	return time.Time(*t).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+)
+
+func NewDeleteAPIKeyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "api-key id-0 [id-1] [id-2] [id-n]",
+		Short: "Revoke one or more API keys by their ID(s)",
+		Long: `This command revokes one or more API keys by ID.
+
+Revoked API keys are no longer accepted, but are kept and still listed to audit their use.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				revoked = make([]cmdx.OutputIder, 0, len(args))
+				failed  = make(map[string]error)
+			)
+
+			for _, a := range args {
+				if _, err := do(cmd, http.MethodDelete, nil, nil, nil, a); err != nil {
+					failed[a] = err
+					continue
+				}
+				revoked = append(revoked, cmdx.OutputIder(a))
+			}
+
+			if len(revoked) == 1 {
+				cmdx.PrintRow(cmd, &revoked[0])
+			} else if len(revoked) > 1 {
+				cmdx.PrintTable(cmd, &cmdx.OutputIderCollection{Items: revoked})
+			}
+
+			cmdx.PrintErrors(cmd, failed)
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/x/cmdx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func NewListAPIKeysCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "api-keys",
+		Short:   "List API keys",
+		Long:    "Return a list of the API keys for the admin API, including expired and revoked ones.",
+		Example: "{{ .CommandPath }} --page-size 100",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			page, perPage, err := cmdx.ParseTokenPaginationArgs(cmd)
+			if err != nil {
+				return err
+			}
+
+			query := url.Values{"page_size": {strconv.Itoa(perPage)}}
+			if page != "" {
+				query.Set("page_token", page)
+			}
+
+			var keys []apikey.Key
+			res, err := do(cmd, http.MethodGet, query, nil, &keys)
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not list the API keys: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			_, next, _ := keysetpagination.ParseHeader(res)
+			cmdx.PrintTable(cmd, &outputAPIKeyCollection{
+				APIKeys:       keys,
+				NextPageToken: next,
+			})
+			return nil
+		},
+	}
+	cmdx.RegisterTokenPaginationFlags(c)
+	return c
+}
@@ -22,6 +22,7 @@ import (
 
 const (
 	envKeyEndpoint = "KRATOS_ADMIN_URL"
+	envKeyAPIKey   = "KRATOS_ADMIN_API_KEY"
 	FlagEndpoint   = "endpoint"
 )
 
@@ -73,10 +74,30 @@ func NewClient(cmd *cobra.Command) (*kratos.APIClient, error) {
 	conf.HTTPClient = httpx.NewResilientClient(
 		httpx.ResilientClientWithConnectionTimeout(10 * time.Second),
 	).StandardClient()
+	if apiKey := os.Getenv(envKeyAPIKey); apiKey != "" {
+		conf.HTTPClient.Transport = &apiKeyTransport{apiKey: apiKey, next: conf.HTTPClient.Transport}
+	}
 	conf.Servers = kratos.ServerConfigurations{{URL: u.String()}}
 	return kratos.NewAPIClient(conf), nil
 }
 
+// apiKeyTransport authorizes all requests with the API key.
+type apiKeyTransport struct {
+	apiKey string
+	next   http.RoundTripper
+}
+
+func (t *apiKeyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.apiKey)
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(r)
+}
+
 func RegisterClientFlags(flags *pflag.FlagSet) {
-	flags.StringP(FlagEndpoint, FlagEndpoint[:1], "", fmt.Sprintf("The URL of Ory Kratos' Admin API. Alternatively set using the %s environmental variable.", envKeyEndpoint))
+	flags.StringP(FlagEndpoint, FlagEndpoint[:1], "", fmt.Sprintf("The URL of Ory Kratos' Admin API. Alternatively set using the %s environmental variable. If the admin API requires authorization, set the API key using the %s environmental variable.", envKeyEndpoint, envKeyAPIKey))
 }
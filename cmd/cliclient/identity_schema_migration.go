@@ -0,0 +1,102 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cliclient
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/flagx"
+)
+
+type IdentitySchemaMigrationHandler struct{}
+
+func NewIdentitySchemaMigrationHandler() *IdentitySchemaMigrationHandler {
+	return &IdentitySchemaMigrationHandler{}
+}
+
+// MigrateIdentitySchema runs an identity schema migration in batches until all
+// identities using the migration's source schema were processed.
+func (h *IdentitySchemaMigrationHandler) MigrateIdentitySchema(cmd *cobra.Command, args []string, opts ...driver.RegistryOption) error {
+	d, err := getPersister(cmd, args, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if err := scopeToNetwork(ctx, d); err != nil {
+		return err
+	}
+
+	migrationID := flagx.MustGetString(cmd, "migration")
+	batchSize := flagx.MustGetInt(cmd, "batch-size")
+	dryRun := flagx.MustGetBool(cmd, "dry-run")
+	batchDelay := flagx.MustGetDuration(cmd, "batch-delay")
+	pageToken := flagx.MustGetString(cmd, "start-after")
+
+	if dryRun {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Dry run mode enabled. No changes will be written.")
+	}
+	if pageToken != "" {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Resuming after identity ID %s\n", pageToken)
+	}
+
+	var (
+		processed int
+		succeeded int
+		failures  []identity.SchemaMigrationResult
+	)
+	for {
+		report, err := d.IdentitySchemaMigrator().RunBatch(ctx, migrationID, identity.SchemaMigrationBatchOptions{
+			DryRun:    dryRun,
+			BatchSize: batchSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "running identity schema migration %s", migrationID)
+		}
+
+		processed += len(report.Results)
+		succeeded += report.Succeeded
+		for _, result := range report.Results {
+			if result.Error != nil {
+				failures = append(failures, result)
+			}
+		}
+
+		if report.NextPageToken == "" {
+			break
+		}
+		pageToken = report.NextPageToken
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "processed %d identities so far (--start-after %s)\n", processed, pageToken)
+
+		if batchDelay > 0 {
+			time.Sleep(batchDelay)
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintln(out)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if len(failures) > 0 {
+		_, _ = fmt.Fprintln(tw, "IDENTITY\tSTATUS\tERROR")
+		for _, f := range failures {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", f.IdentityID, f.Status, f.Error.Reason())
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+
+	_, _ = fmt.Fprintln(tw, "PROCESSED\tSUCCEEDED\tFAILED")
+	_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\n", processed, succeeded, len(failures))
+	_ = tw.Flush()
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package cliclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -76,6 +77,18 @@ func getPersister(cmd *cobra.Command, args []string, opts []driver.RegistryOptio
 	return d, nil
 }
 
+// scopeToNetwork scopes the registry's persister to the network stored in the
+// database, which getPersister skips. Commands working with identities must
+// call this before using the persister.
+func scopeToNetwork(ctx context.Context, d driver.Registry) error {
+	network, err := d.Persister().DetermineNetwork(ctx)
+	if err != nil {
+		return errors.Wrap(err, "determining network")
+	}
+	d.SetPersister(d.Persister().WithNetworkID(network.ID))
+	return nil
+}
+
 func (h *MigrateHandler) MigrateSQLDown(cmd *cobra.Command, args []string, opts ...driver.RegistryOption) error {
 	p, err := h.getPersister(cmd, args, opts)
 	if err != nil {
@@ -0,0 +1,151 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cliclient
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/flagx"
+	"github.com/ory/x/pagination/keysetpagination"
+)
+
+type UniqueTraitsHandler struct{}
+
+func NewUniqueTraitsHandler() *UniqueTraitsHandler {
+	return &UniqueTraitsHandler{}
+}
+
+type uniqueTraitDuplicate struct {
+	identityID  uuid.UUID
+	conflicting uuid.UUID
+	scope       string
+	instancePtr string
+}
+
+// IndexUniqueTraits backfills the unique traits index for all identities and
+// reports values which are used by more than one identity.
+func (h *UniqueTraitsHandler) IndexUniqueTraits(cmd *cobra.Command, args []string, opts ...driver.RegistryOption) error {
+	d, err := getPersister(cmd, args, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if err := scopeToNetwork(ctx, d); err != nil {
+		return err
+	}
+	p := d.Persister()
+
+	batchSize := flagx.MustGetInt(cmd, "batch-size")
+	dryRun := flagx.MustGetBool(cmd, "dry-run")
+	batchDelay := flagx.MustGetDuration(cmd, "batch-delay")
+
+	if dryRun {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Dry run mode enabled. No changes will be written.")
+	}
+
+	var (
+		scanned    int
+		indexed    int
+		duplicates []uniqueTraitDuplicate
+		// In dry-run mode the index is not written, so we detect duplicates
+		// among the scanned identities in memory.
+		owners = make(map[string]uuid.UUID)
+	)
+
+	pageOpts := []keysetpagination.Option{keysetpagination.WithSize(batchSize)}
+	if startAfter := flagx.MustGetString(cmd, "start-after"); startAfter != "" {
+		if _, err := uuid.FromString(startAfter); err != nil {
+			return errors.Wrapf(err, "invalid UUID in --start-after %q", startAfter)
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Resuming after identity ID %s\n", startAfter)
+		pageOpts = append(pageOpts, keysetpagination.WithToken(keysetpagination.StringPageToken(startAfter)))
+	}
+
+	for {
+		identities, page, err := p.ListIdentities(ctx, identity.ListIdentityParameters{
+			Expand:           identity.ExpandNothing,
+			KeySetPagination: pageOpts,
+		})
+		if err != nil {
+			return errors.Wrap(err, "listing identities")
+		}
+
+		var lastID uuid.UUID
+		for k := range identities {
+			i := &identities[k]
+			lastID = i.ID
+			scanned++
+
+			traits, err := d.IdentityTraitsClassifier().UniqueTraits(ctx, i)
+			if err != nil {
+				return errors.Wrapf(err, "computing unique traits of identity %s", i.ID)
+			}
+
+			if dryRun {
+				for _, trait := range traits {
+					if owner, ok := owners[trait.Key()]; ok {
+						duplicates = append(duplicates, uniqueTraitDuplicate{identityID: i.ID, conflicting: owner, scope: trait.Scope, instancePtr: trait.InstancePtr})
+						continue
+					}
+					owners[trait.Key()] = i.ID
+					indexed++
+				}
+				continue
+			}
+
+			conflicts, err := p.IndexUniqueTraits(ctx, i.ID, traits)
+			if err != nil {
+				return errors.Wrapf(err, "indexing unique traits of identity %s", i.ID)
+			}
+			for _, conflict := range conflicts {
+				duplicates = append(duplicates, uniqueTraitDuplicate{identityID: i.ID, conflicting: conflict.IdentityID, scope: conflict.Scope, instancePtr: conflict.InstancePtr})
+			}
+			indexed += len(traits) - len(conflicts)
+		}
+
+		if len(identities) > 0 {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "processed %d identities so far (--start-after %s)\n", scanned, lastID)
+		}
+
+		if page.IsLast() || len(identities) == 0 {
+			break
+		}
+		pageOpts = page.ToOptions()
+
+		if batchDelay > 0 {
+			time.Sleep(batchDelay)
+		}
+	}
+
+	printUniqueTraitsSummary(cmd, scanned, indexed, duplicates)
+	return nil
+}
+
+func printUniqueTraitsSummary(cmd *cobra.Command, scanned, indexed int, duplicates []uniqueTraitDuplicate) {
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintln(out)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if len(duplicates) > 0 {
+		_, _ = fmt.Fprintln(tw, "IDENTITY\tTRAIT\tSCOPE\tALREADY USED BY")
+		for _, d := range duplicates {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.identityID, d.instancePtr, d.scope, d.conflicting)
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+
+	_, _ = fmt.Fprintln(tw, "SCANNED\tINDEXED\tDUPLICATES")
+	_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\n", scanned, indexed, len(duplicates))
+	_ = tw.Flush()
+}
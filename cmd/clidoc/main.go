@@ -74,6 +74,11 @@ func init() {
 		"NewInfoSelfServiceRegisterWebAuthnDisplayName":           text.NewInfoSelfServiceRegisterWebAuthnDisplayName(),
 		"NewInfoSelfServiceRemoveWebAuthn":                        text.NewInfoSelfServiceRemoveWebAuthn("{display_name}", aSecondAgo),
 		"NewInfoSelfServiceRemovePasskey":                         text.NewInfoSelfServiceRemovePasskey("{display_name}", aSecondAgo),
+		"NewInfoSelfServiceRemoveTOTP":                            text.NewInfoSelfServiceRemoveTOTP("{display_name}", aSecondAgo, &aSecondAgo),
+		"NewInfoSelfServiceSettingsTOTPDeviceName":                text.NewInfoSelfServiceSettingsTOTPDeviceName(),
+		"NewInfoSelfServiceRevokeTrustedDevice":                   text.NewInfoSelfServiceRevokeTrustedDevice("{user_agent}", aSecondAgo, &aSecondAgo),
+		"NewInfoSelfServiceSettingsLegalDocumentsRequired":        text.NewInfoSelfServiceSettingsLegalDocumentsRequired(),
+		"NewInfoSelfServiceSettingsProfileIncomplete":             text.NewInfoSelfServiceSettingsProfileIncomplete([]string{"traits.name"}),
 		"NewErrorValidationVerificationFlowExpired":               text.NewErrorValidationVerificationFlowExpired(aSecondAgo),
 		"NewInfoSelfServiceVerificationSuccessful":                text.NewInfoSelfServiceVerificationSuccessful(),
 		"NewVerificationEmailSent":                                text.NewVerificationEmailSent(),
@@ -84,6 +89,12 @@ func init() {
 		"NewErrorValidationVerificationRetrySuccess":              text.NewErrorValidationVerificationRetrySuccess(),
 		"NewErrorValidationVerificationStateFailure":              text.NewErrorValidationVerificationStateFailure(),
 		"NewErrorValidationVerificationCodeInvalidOrAlreadyUsed":  text.NewErrorValidationVerificationCodeInvalidOrAlreadyUsed(),
+		"NewInfoSelfServiceDevice":                                text.NewInfoSelfServiceDevice(),
+		"NewInfoSelfServiceDeviceApproved":                        text.NewInfoSelfServiceDeviceApproved(),
+		"NewInfoSelfServiceDeviceDenied":                          text.NewInfoSelfServiceDeviceDenied(),
+		"NewErrorValidationDeviceUserCodeInvalid":                 text.NewErrorValidationDeviceUserCodeInvalid(),
+		"NewInfoSelfServiceRevokeSessions":                        text.NewInfoSelfServiceRevokeSessions(),
+		"NewInfoSelfServiceRevokeSessionsSucceeded":               text.NewInfoSelfServiceRevokeSessionsSucceeded(),
 		"NewErrorSystemGeneric":                                   text.NewErrorSystemGeneric("{reason}"),
 		"NewErrorSystemNoAuthenticationMethodsAvailable":          text.NewErrorSystemNoAuthenticationMethodsAvailable(),
 		"NewErrorSystemOrganizationNoSSOProvidersAvailable":       text.NewErrorSystemOrganizationNoSSOProvidersAvailable(),
@@ -144,6 +155,8 @@ func init() {
 		"NewErrorValidationVerificationNoStrategyFound":           text.NewErrorValidationVerificationNoStrategyFound(),
 		"NewInfoSelfServiceLoginWebAuthn":                         text.NewInfoSelfServiceLoginWebAuthn(),
 		"NewInfoSelfServiceLoginDeviceAuthn":                      text.NewInfoSelfServiceLoginDeviceAuthn(),
+		"NewInfoSelfServiceLoginCrossDevice":                      text.NewInfoSelfServiceLoginCrossDevice(),
+		"NewInfoSelfServiceLoginCrossDeviceURL":                   text.NewInfoSelfServiceLoginCrossDeviceURL("{url}"),
 		"NewInfoRegistration":                                     text.NewInfoRegistration(),
 		"NewInfoRegistrationWith":                                 text.NewInfoRegistrationWith("{provider}", "{providerID}"),
 		"NewInfoRegistrationContinue":                             text.NewInfoRegistrationContinue(),
@@ -167,6 +180,12 @@ func init() {
 		"NewInfoNodeInputEmailOrPhone":                            text.NewInfoNodeInputEmailOrPhone(),
 		"NewInfoNodeResendOTP":                                    text.NewInfoNodeResendOTP(),
 		"NewInfoNodeLoginAndLinkCredential":                       text.NewInfoNodeLoginAndLinkCredential(),
+		"NewInfoNodeLabelRememberDevice":                          text.NewInfoNodeLabelRememberDevice(),
+		"NewInfoNodeLabelDeviceUserCode":                          text.NewInfoNodeLabelDeviceUserCode(),
+		"NewInfoNodeLabelDeviceApprove":                           text.NewInfoNodeLabelDeviceApprove(),
+		"NewInfoNodeLabelDeviceDeny":                              text.NewInfoNodeLabelDeviceDeny(),
+		"NewInfoNodeLabelLegalDocument":                           text.NewInfoNodeLabelLegalDocument("{title}", "{version}", "{url}"),
+		"NewInfoNodeLabelRevokeSessions":                          text.NewInfoNodeLabelRevokeSessions(),
 		"NewInfoNodeLabelContinue":                                text.NewInfoNodeLabelContinue(),
 		"NewInfoSelfServiceSettingsRegisterWebAuthn":              text.NewInfoSelfServiceSettingsRegisterWebAuthn(),
 		"NewInfoSelfServiceSettingsRegisterPasskey":               text.NewInfoSelfServiceSettingsRegisterPasskey(),
@@ -189,6 +208,7 @@ func init() {
 		"NewInfoSelfServiceLoginCode":                             text.NewInfoSelfServiceLoginCode(),
 		"NewErrorValidationRegistrationRetrySuccessful":           text.NewErrorValidationRegistrationRetrySuccessful(),
 		"NewInfoSelfServiceRegistrationRegisterCode":              text.NewInfoSelfServiceRegistrationRegisterCode(),
+		"NewInfoSelfServiceRegistrationPendingApproval":           text.NewInfoSelfServiceRegistrationPendingApproval(),
 		"NewErrorValidationLoginLinkedCredentialsDoNotMatch":      text.NewErrorValidationLoginLinkedCredentialsDoNotMatch(),
 		"NewErrorValidationAddressUnknown":                        text.NewErrorValidationAddressUnknown(),
 		"NewInfoSelfServiceLoginCodeMFA":                          text.NewInfoSelfServiceLoginCodeMFA(),
@@ -200,6 +220,11 @@ func init() {
 		"NewErrorValidationEmail":                                 text.NewErrorValidationEmail("{value}"),
 		"NewErrorValidationPhone":                                 text.NewErrorValidationPhone("{value}"),
 		"NewErrorValidationIdentityDisabled":                      text.NewErrorValidationIdentityDisabled(),
+		"NewErrorValidationLoginBlocked":                          text.NewErrorValidationLoginBlocked(),
+		"NewErrorValidationLoginCrossDevicePending":               text.NewErrorValidationLoginCrossDevicePending(),
+		"NewErrorValidationLoginCrossDeviceDenied":                text.NewErrorValidationLoginCrossDeviceDenied(),
+		"NewErrorValidationLoginCrossDeviceExpired":               text.NewErrorValidationLoginCrossDeviceExpired(),
+		"NewErrorValidationIdentityPendingApproval":               text.NewErrorValidationIdentityPendingApproval(),
 		"NewErrorValidationSettingsTooManyAddressChanges":         text.NewErrorValidationSettingsTooManyAddressChanges(),
 	}
 }
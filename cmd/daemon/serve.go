@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +15,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/urfave/negroni"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ory/analytics-go/v5"
@@ -33,6 +36,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/selfservice/strategy/oidc"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
 	"github.com/ory/x/healthx"
@@ -66,6 +70,7 @@ func servePublic(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comm
 		httpMetrics,
 	)
 
+	n.Use(tenant.NewMiddleware(r, r.TenantResolver(), false))
 	for _, mw := range r.HTTPMiddlewares() {
 		n.Use(mw)
 	}
@@ -167,9 +172,11 @@ func serveAdmin(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comma
 		httpMetrics,
 	)
 
+	n.Use(tenant.NewMiddleware(r, r.TenantResolver(), true))
 	for _, mw := range r.HTTPMiddlewares() {
 		n.Use(mw)
 	}
+	n.Use(serveGRPC(r))
 
 	adminLogger := reqlog.NewMiddlewareFromLogger(l, "admin#"+cfg.BaseURL.String())
 
@@ -185,6 +192,7 @@ func serveAdmin(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comma
 	n.UseFunc(httprouterx.AddAdminPrefixIfNotPresentNegroni)
 	n.UseFunc(httprouterx.NoCacheNegroni)
 	n.Use(x.HTTPLoaderContextMiddleware(r))
+	n.Use(r.APIKeyMiddleware())
 	n.Use(sqa(ctx, cmd, r))
 
 	r.RegisterAdminRoutes(ctx, router)
@@ -201,16 +209,28 @@ func serveAdmin(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comma
 	}
 
 	handler = http.MaxBytesHandler(handler, 5*1024*1024 /* 5 MB */) // Important: this must be the outermost handler or our tracing breaks
+	// gRPC clients connecting without TLS require HTTP/2 cleartext (h2c).
+	handler = h2c.NewHandler(handler, &http2.Server{})
 
 	certFunc, err := cfg.TLS.GetCertFunc(ctx, l, "admin")
 	if err != nil {
 		return nil, err
 	}
 
+	tlsConfig := &tls.Config{GetCertificate: certFunc, MinVersion: tls.VersionTLS12}
+	clientCAs, err := r.Config().AdminClientCAs(ctx)
+	if err != nil {
+		return nil, err
+	} else if clientCAs != nil {
+		// Client certificates are optional, as API keys may be used instead.
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		tlsConfig.ClientCAs = clientCAs
+	}
+
 	//#nosec G112 -- the correct settings are set by graceful.WithDefaults
 	server := graceful.WithDefaults(&http.Server{
 		Handler:           handler,
-		TLSConfig:         &tls.Config{GetCertificate: certFunc, MinVersion: tls.VersionTLS12},
+		TLSConfig:         tlsConfig,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       30 * time.Second,
 		WriteTimeout:      120 * time.Second,
@@ -242,6 +262,19 @@ func serveAdmin(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comma
 	}, nil
 }
 
+// serveGRPC dispatches gRPC requests to the admin gRPC server. They are
+// authorized by its interceptors instead of the API key middleware.
+func serveGRPC(r *driver.RegistryDefault) negroni.HandlerFunc {
+	loader := x.HTTPLoaderContextMiddleware(r)
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if req.ProtoMajor != 2 || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc") {
+			next(w, req)
+			return
+		}
+		loader(w, req, r.AdminGRPCServer().ServeHTTP)
+	}
+}
+
 func sqa(ctx context.Context, cmd *cobra.Command, d driver.Registry) *metricsx.Service {
 	urls := []string{
 		d.Config().ServePublic(ctx).BaseURL.Host,
@@ -342,6 +375,15 @@ func courierTask(ctx context.Context, d driver.Registry) func() error {
 	}
 }
 
+func maintenanceTask(ctx context.Context, d driver.Registry) func() error {
+	return func() error {
+		if d.Config().MaintenanceEnabled(ctx) {
+			return d.MaintenanceScheduler().Run(ctx)
+		}
+		return nil
+	}
+}
+
 func ServeAll(d *driver.RegistryDefault) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
@@ -361,6 +403,7 @@ func ServeAll(d *driver.RegistryDefault) func(cmd *cobra.Command, args []string)
 			publicSrv,
 			adminSrv,
 			courierTask(ctx, d),
+			maintenanceTask(ctx, d),
 		}
 		for _, task := range tasks {
 			g.Go(task)
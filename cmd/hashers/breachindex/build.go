@@ -0,0 +1,146 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package breachindex
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const FlagMinCount = "min-count"
+
+var rangeFileName = regexp.MustCompile(`^(?i)([0-9a-f]{5})\.txt$`)
+
+func NewBuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build <index-file> <corpus> [<corpus> ...]",
+		Short: "Build an offline password breach index",
+		Long: `Builds a compact breach index which Ory Kratos queries in-process instead of the Have I Been Pwned API.
+
+A corpus is either a directory of Have I Been Pwned SHA-1 range files (e.g. 21BD1.txt containing "SUFFIX:COUNT"
+lines), as downloaded by the PwnedPasswordsDownloader, or a file containing "HASH:COUNT" lines ordered by hash.
+All hashes must be in ascending order across the given corpora.
+
+Configure the index with:
+
+	selfservice.methods.password.config.breach_corpus.path: <index-file>`,
+		Example: `kratos hashers breach-index build breaches.idx ./pwnedpasswords
+kratos hashers breach-index build --min-count 10 breaches.idx pwned-passwords-sha1-ordered-by-hash.txt`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minCount := flagx.MustGetInt(cmd, FlagMinCount)
+
+			out, err := os.Create(args[0])
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not create the index file: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+			defer func() { _ = out.Close() }()
+
+			b, err := password.NewBreachIndexBuilder(out, int64(minCount))
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not write the index file: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			for _, corpus := range args[1:] {
+				if err := addCorpus(b, corpus); err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not read corpus %s: %s\n", corpus, err)
+					return cmdx.FailSilently(cmd)
+				}
+			}
+
+			if err := b.Finish(); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not write the index file: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+			if err := out.Close(); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not write the index file: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d entries to %s\n", b.Entries(), args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(FlagMinCount, 1, "Omit hashes found in fewer breaches. Reduces the index size, but passwords breached less often are accepted regardless of max_breaches.")
+
+	return cmd
+}
+
+func addCorpus(b *password.BreachIndexBuilder, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !info.IsDir() {
+		return addCorpusFile(b, path, "")
+	}
+
+	// os.ReadDir returns the entries sorted by name, which is the order of the
+	// hash prefixes.
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, entry := range entries {
+		m := rangeFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		if err := addCorpusFile(b, filepath.Join(path, entry.Name()), strings.ToUpper(m[1])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addCorpusFile(b *password.BreachIndexBuilder, path, prefix string) error {
+	f, err := os.Open(path) //#nosec G304 -- the path is given by the user
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		row := strings.TrimSpace(sc.Text())
+		if row == "" {
+			continue
+		}
+
+		hashed, countText, hasCount := strings.Cut(row, ":")
+		sum, err := hex.DecodeString(prefix + hashed)
+		if err != nil || len(sum) != 20 {
+			return errors.Errorf("%s:%d: expected a SHA-1 hash but got %q", path, line, prefix+hashed)
+		}
+
+		count := int64(1)
+		if hasCount {
+			count, err = strconv.ParseInt(strings.ReplaceAll(countText, ",", ""), 10, 64)
+			if err != nil || count < 0 {
+				return errors.Errorf("%s:%d: expected a breach count but got %q", path, line, countText)
+			}
+		}
+
+		if err := b.Add(sum, count); err != nil {
+			return errors.Wrapf(err, "%s:%d", path, line)
+		}
+	}
+	return errors.WithStack(sc.Err())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ory/kratos/cmd/hashers/argon2"
+	"github.com/ory/kratos/cmd/hashers/breachindex"
 )
 
 func NewRootCmd() *cobra.Command {
@@ -22,4 +23,5 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	parent.AddCommand(rootCmd)
 
 	argon2.RegisterCommandRecursive(rootCmd)
+	breachindex.RegisterCommandRecursive(rootCmd)
 }
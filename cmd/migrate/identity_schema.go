@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewIdentitySchemaCmd(opts ...driver.RegistryOption) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "identity-schema [database-url]",
+		Short: "Migrate identities to another identity schema",
+		Long: `Runs an identity schema migration configured in "identity.schema_migrations".
+
+All identities using the migration's source schema are transformed using the
+migration's Jsonnet transform, validated against the target schema, and updated.
+Identities which fail to transform or validate are left untouched and reported.
+
+This command uses keyset pagination to iterate over the identities in batches.
+It can be interrupted and resumed using the --start-after flag with the last ID
+printed in the progress output. Use --dry-run to validate the migration without
+updating identities.
+
+You can read in the database URL using the -e flag, for example:
+	export DSN=...
+	kratos migrate identity-schema -e --migration customer-v1-to-v2 --config kratos.yml
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cliclient.NewIdentitySchemaMigrationHandler().MigrateIdentitySchema(cmd, args, opts...)
+			if err != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), err)
+				return err
+			}
+			return nil
+		},
+	}
+
+	configx.RegisterFlags(c.PersistentFlags())
+	c.Flags().BoolP("read-from-env", "e", false, "If set, reads the database connection string from the environment variable DSN or config file key dsn.")
+	c.Flags().String("migration", "", "The ID of the identity schema migration to run")
+	c.Flags().IntP("batch-size", "b", 100, "Number of identities to process per batch")
+	c.Flags().Bool("dry-run", false, "If set, only transform and validate identities without writing")
+	c.Flags().Duration("batch-delay", time.Second, "Delay between batches to reduce database load (e.g. 100ms, 1s)")
+	c.Flags().String("start-after", "", "Resume after the given identity ID")
+	_ = c.MarkFlagRequired("migration")
+
+	return c
+}
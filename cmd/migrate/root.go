@@ -25,6 +25,8 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	configx.RegisterFlags(c.PersistentFlags())
 	c.AddCommand(NewMigrateSQLCmd())
 	c.AddCommand(NewNormalizePhoneCmd())
+	c.AddCommand(NewUniqueTraitsCmd())
+	c.AddCommand(NewIdentitySchemaCmd())
 
 	parent.AddCommand(c)
 }
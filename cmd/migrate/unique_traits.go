@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewUniqueTraitsCmd(opts ...driver.RegistryOption) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "unique-traits [database-url]",
+		Short: "Backfill the unique traits index and report duplicate trait values",
+		Long: `Indexes the values of all traits which the identity schema marks as unique
+using the "ory.sh/kratos" keyword "unique", and reports identities whose values
+are already used by another identity.
+
+Duplicate values are not indexed. The first identity (ordered by ID) owning a
+value keeps it; resolve the reported duplicates and run this command again.
+
+This command uses keyset pagination to iterate over the identities in batches.
+It is safe to run multiple times (idempotent) and can be interrupted and resumed
+using the --start-after flag with the last ID printed in the progress output.
+
+Run this command AFTER deploying an identity schema which adds the "unique"
+keyword to existing traits.
+
+Trait values are indexed using an HMAC keyed with "secrets.default". After
+rotating the secret, run this command again to re-index all values with the new
+secret before removing the previous secret from the configuration.
+
+You can read in the database URL using the -e flag, for example:
+	export DSN=...
+	kratos migrate unique-traits -e
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cliclient.NewUniqueTraitsHandler().IndexUniqueTraits(cmd, args, opts...)
+			if err != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), err)
+				return err
+			}
+			return nil
+		},
+	}
+
+	configx.RegisterFlags(c.PersistentFlags())
+	c.Flags().BoolP("read-from-env", "e", false, "If set, reads the database connection string from the environment variable DSN or config file key dsn.")
+	c.Flags().IntP("batch-size", "b", 1000, "Number of identities to process per batch")
+	c.Flags().Bool("dry-run", false, "If set, only report duplicates among the scanned identities without writing the index")
+	c.Flags().Duration("batch-delay", time.Second, "Delay between batches to reduce database load (e.g. 100ms, 1s)")
+	c.Flags().String("start-after", "", "Resume after the given identity ID")
+
+	return c
+}
@@ -0,0 +1,125 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/cmd/migrate"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/dbal"
+)
+
+func TestUniqueTraitsCmd(t *testing.T) {
+	const secret = "a-secret-which-is-at-least-32-characters-long"
+
+	dsn := dbal.NewSQLiteTestDatabase(t)
+	conf, reg := pkg.NewRegistryDefaultWithDSN(t, dsn,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stubs/identity.schema.json")),
+		configx.WithValue(config.ViperKeySecretsDefault, []string{secret}),
+	)
+
+	// The identities are created before the schema marks the username as
+	// unique, so that the index is empty and the values may be duplicated.
+	createIdentity := func(t *testing.T, username string) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"username":"` + username + `"}`)
+		require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+		return i
+	}
+	alice, bob, otherAlice := createIdentity(t, "alice"), createIdentity(t, "bob"), createIdentity(t, "alice")
+
+	configFile := filepath.Join(t.TempDir(), "kratos.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`identity:
+  default_schema_id: default
+  schemas:
+    - id: default
+      url: file://./stubs/unique.schema.json
+secrets:
+  default:
+    - `+secret+`
+`), 0600))
+
+	cmd := &cmdx.CommandExecuter{
+		New: func() *cobra.Command {
+			return migrate.NewUniqueTraitsCmd()
+		},
+		PersistentArgs: []string{"--config", configFile, "--batch-size", "2", "--batch-delay", "0s"},
+	}
+
+	summary := func(t *testing.T, stdOut string) []string {
+		lines := strings.Split(strings.TrimSpace(stdOut), "\n")
+		require.GreaterOrEqual(t, len(lines), 2, "%s", stdOut)
+		assert.Equal(t, []string{"SCANNED", "INDEXED", "DUPLICATES"}, strings.Fields(lines[len(lines)-2]))
+		return strings.Fields(lines[len(lines)-1])
+	}
+
+	t.Run("case=fails without database url", func(t *testing.T) {
+		_, _, err := cmd.Exec(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("case=reports duplicates without writing the index in dry-run mode", func(t *testing.T) {
+		stdOut, stdErr, err := cmd.Exec(nil, dsn, "--dry-run")
+		require.NoError(t, err, stdErr)
+
+		assert.Contains(t, stdErr, "Dry run mode enabled")
+		assert.Equal(t, []string{"3", "2", "1"}, summary(t, stdOut))
+		assert.Contains(t, stdOut, alice.ID.String())
+		assert.Contains(t, stdOut, otherAlice.ID.String())
+
+		// The username of bob was not indexed, so it can still be used.
+		t.Cleanup(testhelpers.SetDefaultIdentitySchema(conf, "file://./stubs/unique.schema.json"))
+		i := createIdentity(t, "bob")
+		require.NoError(t, reg.Persister().DeleteIdentity(context.Background(), i.ID))
+	})
+
+	t.Run("case=indexes unique traits and reports duplicates", func(t *testing.T) {
+		stdOut, stdErr, err := cmd.Exec(nil, dsn)
+		require.NoError(t, err, stdErr)
+
+		assert.Contains(t, stdErr, "processed 3 identities so far")
+		assert.Equal(t, []string{"3", "2", "1"}, summary(t, stdOut))
+		assert.Contains(t, stdOut, "/username")
+		assert.Contains(t, stdOut, alice.ID.String())
+		assert.Contains(t, stdOut, otherAlice.ID.String())
+
+		t.Cleanup(testhelpers.SetDefaultIdentitySchema(conf, "file://./stubs/unique.schema.json"))
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"username":"bob"}`)
+		assert.Error(t, reg.Persister().CreateIdentity(context.Background(), i), "the username of %s is indexed", bob.ID)
+	})
+
+	t.Run("case=is idempotent", func(t *testing.T) {
+		stdOut, stdErr, err := cmd.Exec(nil, dsn)
+		require.NoError(t, err, stdErr)
+		assert.Equal(t, []string{"3", "2", "1"}, summary(t, stdOut))
+	})
+
+	t.Run("case=resumes after the given identity", func(t *testing.T) {
+		stdOut, stdErr, err := cmd.Exec(nil, dsn, "--start-after", "ffffffff-ffff-ffff-ffff-ffffffffffff")
+		require.NoError(t, err, stdErr)
+		assert.Contains(t, stdErr, "Resuming after identity ID ffffffff-ffff-ffff-ffff-ffffffffffff")
+		assert.Equal(t, []string{"0", "0", "0"}, summary(t, stdOut))
+	})
+
+	t.Run("case=rejects an invalid resume ID", func(t *testing.T) {
+		_, stdErr, err := cmd.Exec(nil, dsn, "--start-after", "not-a-uuid")
+		require.Error(t, err)
+		assert.Contains(t, stdErr, "invalid UUID in --start-after")
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ory/kratos/cmd/apikeys"
 	"github.com/ory/kratos/cmd/cleanup"
 	"github.com/ory/kratos/cmd/courier"
 	"github.com/ory/kratos/cmd/hashers"
@@ -18,6 +19,7 @@ import (
 	"github.com/ory/kratos/cmd/migrate"
 	"github.com/ory/kratos/cmd/remote"
 	"github.com/ory/kratos/cmd/serve"
+	"github.com/ory/kratos/cmd/tenants"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/x/cmdx"
@@ -33,12 +35,21 @@ func NewRootCmd(driverOpts ...driver.RegistryOption) (cmd *cobra.Command) {
 
 	courier.RegisterCommandRecursive(cmd, driverOpts)
 	cmd.AddCommand(identities.NewGetCmd())
-	cmd.AddCommand(identities.NewDeleteCmd())
+	deleteCmd := identities.NewDeleteCmd()
+	deleteCmd.AddCommand(tenants.NewDeleteTenantCmd())
+	deleteCmd.AddCommand(apikeys.NewDeleteAPIKeyCmd())
+	cmd.AddCommand(deleteCmd)
+	createCmd := tenants.NewCreateCmd()
+	createCmd.AddCommand(apikeys.NewCreateAPIKeyCmd())
+	cmd.AddCommand(createCmd)
 	cmd.AddCommand(jsonnet.NewFormatCmd())
 	hashers.RegisterCommandRecursive(cmd)
 	cmd.AddCommand(identities.NewImportCmd())
 	cmd.AddCommand(jsonnet.NewLintCmd())
-	cmd.AddCommand(identities.NewListCmd())
+	listCmd := identities.NewListCmd()
+	listCmd.AddCommand(tenants.NewListTenantsCmd())
+	listCmd.AddCommand(apikeys.NewListAPIKeysCmd())
+	cmd.AddCommand(listCmd)
 	migrate.RegisterCommandRecursive(cmd)
 	serve.RegisterCommandRecursive(cmd, driverOpts)
 	cleanup.RegisterCommandRecursive(cmd)
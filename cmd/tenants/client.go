@@ -0,0 +1,86 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/urlx"
+)
+
+// do sends a request to the tenants admin API, which is not yet part of the
+// generated SDK, using the SDK client's endpoint and HTTP client.
+func do(cmd *cobra.Command, method string, query url.Values, body, out any, paths ...string) (*http.Response, error) {
+	c, err := cliclient.NewClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	conf := c.GetConfig()
+
+	endpoint, err := url.Parse(conf.Servers[0].URL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	u := urlx.AppendPaths(endpoint, append([]string{"admin", "tenants"}, paths...)...)
+	u.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, u.String(), reqBody)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hc := conf.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 400 {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+				Reason  string `json:"reason"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&e); err != nil || e.Error.Message == "" {
+			return nil, errors.Errorf("the server responded with status %s", res.Status)
+		}
+		if e.Error.Reason != "" {
+			return nil, errors.Errorf("%s: %s", e.Error.Message, e.Error.Reason)
+		}
+		return nil, errors.New(e.Error.Message)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return res, nil
+}
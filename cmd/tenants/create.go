@@ -0,0 +1,81 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	FlagHost    = "host"
+	FlagOverlay = "config-overlay"
+)
+
+func NewCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create resources",
+	}
+	cmd.AddCommand(NewCreateTenantCmd())
+	cliclient.RegisterClientFlags(cmd.PersistentFlags())
+	cmdx.RegisterFormatFlags(cmd.PersistentFlags())
+	return cmd
+}
+
+func NewCreateTenantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant <name>",
+		Short: "Create a tenant",
+		Long: `Creates a tenant served on the given hosts. Requires multitenancy.enabled to be set.
+
+The optional configuration overlay is a JSON or YAML file which may overlay the identity, selfservice, courier,
+session and cookies configuration for this tenant.`,
+		Example: `{{ .CommandPath }} acme --host auth.acme.com --config-overlay acme.yml`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := tenant.CreateTenantBody{
+				Name:  args[0],
+				Hosts: flagx.MustGetStringSlice(cmd, FlagHost),
+			}
+
+			if path := flagx.MustGetString(cmd, FlagOverlay); path != "" {
+				raw, err := os.ReadFile(path) //#nosec G304 -- the path is given by the user
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not read the configuration overlay: %s\n", err)
+					return cmdx.FailSilently(cmd)
+				}
+				body.Config, err = yaml.YAMLToJSON(raw)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse the configuration overlay: %s\n", err)
+					return cmdx.FailSilently(cmd)
+				}
+			}
+
+			var t tenant.Tenant
+			if _, err := do(cmd, http.MethodPost, nil, &body, &t); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not create the tenant: %s\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			cmdx.PrintRow(cmd, (*outputTenant)(&t))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice(FlagHost, nil, "A host served as this tenant, e.g. auth.acme.com. Can be repeated.")
+	cmd.Flags().String(FlagOverlay, "", "Path to a JSON or YAML file overlaying the configuration for this tenant.")
+	_ = cmd.MarkFlagRequired(FlagHost)
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"strings"
+
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/cmdx"
+)
+
+type (
+	outputTenant           tenant.Tenant
+	outputTenantCollection struct {
+		Tenants       []tenant.Tenant `json:"tenants"`
+		NextPageToken string          `json:"next_page_token"`
+	}
+)
+
+func (outputTenant) Header() []string {
+	return []string{"ID", "NAME", "HOSTS", "CONFIG OVERLAY"}
+}
+
+func (t outputTenant) Columns() []string {
+	overlay := cmdx.None
+	if len(t.Config) > 0 && string(t.Config) != "null" && string(t.Config) != "{}" {
+		overlay = "yes"
+	}
+	return []string{t.ID.String(), t.Name, strings.Join(t.Hosts, ", "), overlay}
+}
+
+func (t outputTenant) Interface() interface{} {
+	return tenant.Tenant(t)
+}
+
+func (outputTenantCollection) Header() []string {
+	return outputTenant{}.Header()
+}
+
+func (c outputTenantCollection) Table() [][]string {
+	rows := make([][]string, len(c.Tenants))
+	for i, t := range c.Tenants {
+		rows[i] = outputTenant(t).Columns()
+	}
+	return append(rows,
+		[]string{""},
+		[]string{"NEXT PAGE TOKEN", c.NextPageToken},
+	)
+}
+
+func (c outputTenantCollection) Interface() interface{} {
+	return c
+}
+
+func (c outputTenantCollection) Len() int {
+	return len(c.Tenants)
+}
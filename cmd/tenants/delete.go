@@ -0,0 +1,50 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenants
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+)
+
+func NewDeleteTenantCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tenant id-0 [id-1] [id-2] [id-n]",
+		Short: "Delete one or more tenants by their ID(s)",
+		Long: `This command deletes one or more tenants by ID.
+
+Deleting a tenant irrecoverably deletes all of its identities, sessions, flows and courier messages.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				deleted = make([]cmdx.OutputIder, 0, len(args))
+				failed  = make(map[string]error)
+			)
+
+			for _, a := range args {
+				if _, err := do(cmd, http.MethodDelete, nil, nil, nil, a); err != nil {
+					failed[a] = err
+					continue
+				}
+				deleted = append(deleted, cmdx.OutputIder(a))
+			}
+
+			if len(deleted) == 1 {
+				cmdx.PrintRow(cmd, &deleted[0])
+			} else if len(deleted) > 1 {
+				cmdx.PrintTable(cmd, &cmdx.OutputIderCollection{Items: deleted})
+			}
+
+			cmdx.PrintErrors(cmd, failed)
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+
+			return nil
+		},
+	}
+}
@@ -15,9 +15,11 @@ import (
 	"github.com/ory/x/jsonnetsecure"
 	"github.com/ory/x/logrusx"
 	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/tenant"
 )
 
 type (
@@ -28,14 +30,16 @@ type (
 		ConfigProvider
 		httpx.ClientProvider
 		jsonnetsecure.VMProvider
+		tenant.ContextsProvider
 	}
 
 	Courier interface {
 		Work(ctx context.Context) error
-		QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, error)
-		QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error)
+		QueueEmail(ctx context.Context, t EmailTemplate, opts ...QueueOption) (uuid.UUID, error)
+		QueueSMS(ctx context.Context, t SMSTemplate, opts ...QueueOption) (uuid.UUID, error)
 		DispatchQueue(ctx context.Context) error
 		DispatchMessage(ctx context.Context, msg Message) error
+		DispatchNow(ctx context.Context, id uuid.UUID) error
 		UseBackoff(b backoff.BackOff)
 		FailOnDispatchError()
 	}
@@ -48,6 +52,14 @@ type (
 		CourierConfig() config.CourierConfigs
 	}
 
+	// QueueOption configures how a message is queued.
+	QueueOption func(*queueOptions)
+
+	queueOptions struct {
+		channel       string
+		withoutWorker bool
+	}
+
 	courier struct {
 		deps                        Dependencies
 		failOnDispatchError         bool
@@ -68,6 +80,44 @@ func NewCourierWithCustomTemplates(_ context.Context, deps Dependencies, newEmai
 	}, nil
 }
 
+// WithChannel queues the message for the courier channel with the given ID
+// instead of the default channel for the message type.
+func WithChannel(id string) QueueOption {
+	return func(o *queueOptions) {
+		o.channel = id
+	}
+}
+
+// WithoutWorker marks the message as processing, so that the courier worker
+// does not pick it up. The caller has to dispatch the message using
+// DispatchNow.
+func WithoutWorker() QueueOption {
+	return func(o *queueOptions) {
+		o.withoutWorker = true
+	}
+}
+
+func (c *courier) addMessage(ctx context.Context, m *Message, opts []QueueOption) error {
+	var o queueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.channel != "" {
+		m.Channel = sqlxx.NullString(o.channel)
+	}
+
+	if err := c.deps.CourierPersister().AddMessage(ctx, m); err != nil {
+		return err
+	}
+	if o.withoutWorker {
+		if err := c.deps.CourierPersister().SetMessageStatus(ctx, m.ID, MessageStatusProcessing); err != nil {
+			return err
+		}
+		m.Status = MessageStatusProcessing
+	}
+	return nil
+}
+
 func (c *courier) FailOnDispatchError() {
 	c.failOnDispatchError = true
 }
@@ -98,7 +148,7 @@ func (c *courier) watchMessages(ctx context.Context, errChan chan error) {
 	c.backoff.Reset()
 	for {
 		if err := backoff.Retry(func() error {
-			return c.DispatchQueue(ctx)
+			return c.dispatchQueues(ctx)
 		}, c.backoff); err != nil {
 			errChan <- errors.WithStack(err)
 			return
@@ -106,3 +156,18 @@ func (c *courier) watchMessages(ctx context.Context, errChan chan error) {
 		time.Sleep(wait)
 	}
 }
+
+// dispatchQueues dispatches the queue of the default network and, in
+// multi-tenant mode, the queue of every tenant.
+func (c *courier) dispatchQueues(ctx context.Context) error {
+	ctxs, err := c.deps.TenantContexts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ctx := range ctxs {
+		if err := c.DispatchQueue(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
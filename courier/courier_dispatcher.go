@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -168,3 +169,36 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// DispatchNow dispatches a message which was queued using WithoutWorker and
+// records the result. If the dispatch fails, the message is abandoned so that
+// the caller can fall back to another channel.
+func (c *courier) DispatchNow(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := c.deps.Tracer(ctx).Tracer().Start(ctx, "courier.DispatchNow")
+	defer otelx.End(span, &err)
+
+	msg, err := c.deps.CourierPersister().FetchMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if dispatchErr := c.DispatchMessage(ctx, *msg); dispatchErr != nil {
+		if err := c.deps.CourierPersister().RecordDispatch(ctx, msg.ID, CourierMessageDispatchStatusFailed, dispatchErr); err != nil {
+			return err
+		}
+		if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+			return err
+		}
+		span.AddEvent(events.NewCourierMessageAbandoned(ctx, msg.ID, msg.Channel.String(), string(msg.TemplateType)))
+		return dispatchErr
+	}
+
+	if err := c.deps.CourierPersister().RecordDispatch(ctx, msg.ID, CourierMessageDispatchStatusSuccess, nil); err != nil {
+		c.deps.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error(`Unable to record success log entry.`)
+		// continue with execution, as the message was successfully dispatched
+	}
+	return nil
+}
@@ -113,3 +113,34 @@ func TestDispatchQueue(t *testing.T) {
 	require.Contains(t, gjson.GetBytes(message.Dispatches[0].Error, "reason").String(), "failed to send email via smtp")
 	require.Contains(t, gjson.GetBytes(message.Dispatches[1].Error, "reason").String(), "failed to send email via smtp")
 }
+
+func TestDispatchNow(t *testing.T) {
+	_, reg := pkg.NewRegistryDefaultWithDSN(t, "", configx.WithValues(map[string]any{
+		config.ViperKeyCourierMessageRetries: 5,
+		config.ViperKeyCourierSMTPURL:        "http://foo.url",
+	}))
+
+	c, err := reg.Courier(t.Context())
+	require.NoError(t, err)
+
+	id, err := c.QueueEmail(t.Context(), templates.NewTestStub(&templates.TestStubModel{
+		To:      "test-recipient-1@example.org",
+		Subject: "test-subject-1",
+		Body:    "test-body-1",
+	}), courier.WithChannel("email"), courier.WithoutWorker())
+	require.NoError(t, err)
+
+	// The worker does not pick up the message.
+	_, err = reg.CourierPersister().NextMessages(t.Context(), 10)
+	require.ErrorIs(t, err, courier.ErrQueueEmpty)
+
+	// sending the email fails, because there is no SMTP server at foo.url
+	require.Error(t, c.DispatchNow(t.Context(), id))
+
+	message, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusAbandoned, message.Status)
+	assert.EqualValues(t, "email", message.Channel)
+	require.Len(t, message.Dispatches, 1)
+	assert.Equal(t, courier.CourierMessageDispatchStatusFailed, message.Dispatches[0].Status)
+}
@@ -102,6 +102,47 @@ func NewEmailTemplateFromMessage(d template.Dependencies, msg Message) (EmailTem
 			return nil, err
 		}
 		return email.NewRegistrationCodeValid(d, &t), nil
+	case template.TypeRetentionNotice:
+		var t email.RetentionNoticeModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewRetentionNotice(d, &t), nil
+	case template.TypeLoginRiskNotice:
+		var t email.LoginRiskNoticeModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLoginRiskNotice(d, &t), nil
+	case template.TypeInvitationValid:
+		var t email.InvitationValidModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewInvitationValid(d, &t), nil
+	case template.TypeRegistrationApproved:
+		var t email.RegistrationApprovedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewRegistrationApproved(d, &t), nil
+	case template.TypeRegistrationRejected:
+		var t email.RegistrationRejectedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewRegistrationRejected(d, &t), nil
+	case template.TypeSecurityPasswordChanged,
+		template.TypeSecurityMFAEnrolled,
+		template.TypeSecurityMFARemoved,
+		template.TypeSecurityRecoveryUsed,
+		template.TypeSecurityNewDeviceLogin,
+		template.TypeSecurityEmailChanged:
+		var t email.SecurityNotificationModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewSecurityNotification(d, msg.TemplateType, &t)
 	default:
 		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
 	}
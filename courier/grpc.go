@@ -0,0 +1,93 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ory/herodot"
+	courierv1 "github.com/ory/kratos/gen/courier/v1"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+var _ courierv1.CourierServiceServer = (*GRPCServer)(nil)
+
+type (
+	// GRPCServer serves the courier gRPC API. It shares the request handling
+	// of the courier admin API.
+	GRPCServer struct {
+		courierv1.UnimplementedCourierServiceServer
+		h *Handler
+	}
+
+	GRPCServerProvider interface {
+		CourierGRPCServer() *GRPCServer
+	}
+)
+
+func NewGRPCServer(h *Handler) *GRPCServer { return &GRPCServer{h: h} }
+
+func (s *GRPCServer) ListMessages(ctx context.Context, req *courierv1.ListMessagesRequest) (*courierv1.ListMessagesResponse, error) {
+	keys := s.h.r.Config().SecretsPagination(ctx)
+
+	query := url.Values{}
+	if req.GetPageSize() > 0 {
+		query.Set("page_size", strconv.Itoa(int(req.GetPageSize())))
+	}
+	if req.GetPageToken() != "" {
+		query.Set("page_token", req.GetPageToken())
+	}
+	paginator, err := keysetpagination.ParseQueryParams(keys, query)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithWrap(err).WithReasonf("Unable to parse the page token: %s", err))
+	}
+
+	filter := ListCourierMessagesParameters{Recipient: req.GetRecipient()}
+	if req.GetStatus() != courierv1.MessageStatus_MESSAGE_STATUS_UNSPECIFIED {
+		status := MessageStatus(req.GetStatus())
+		filter.Status = &status
+	}
+
+	messages, nextPage, err := s.h.r.CourierPersister().ListMessages(ctx, filter, paginator)
+	if err != nil {
+		return nil, err
+	}
+
+	redact := !s.h.r.Config().IsInsecureDevMode(ctx)
+	res := &courierv1.ListMessagesResponse{Messages: make([]*courierv1.Message, len(messages))}
+	for k, m := range messages {
+		if redact {
+			m.Body = "<redacted-unless-dev-mode>"
+			m.Subject = "<redacted-unless-dev-mode>"
+		}
+		res.Messages[k] = ToProto(&m)
+	}
+	if nextPage != nil && !nextPage.IsLast() {
+		res.NextPageToken = nextPage.PageToken().Encrypt(keys)
+	}
+
+	return res, nil
+}
+
+// ToProto converts the message to its gRPC representation.
+func ToProto(m *Message) *courierv1.Message {
+	return &courierv1.Message{
+		Id:           m.ID.String(),
+		Status:       courierv1.MessageStatus(m.Status),
+		Type:         courierv1.MessageType(m.Type),
+		Recipient:    m.Recipient,
+		Subject:      m.Subject,
+		Body:         m.Body,
+		TemplateType: string(m.TemplateType),
+		Channel:      string(m.Channel),
+		SendCount:    int64(m.SendCount),
+		CreatedAt:    timestamppb.New(m.CreatedAt),
+		UpdatedAt:    timestamppb.New(m.UpdatedAt),
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error) {
+func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate, opts ...QueueOption) (uuid.UUID, error) {
 	recipient, err := t.PhoneNumber()
 	if err != nil {
 		return uuid.Nil, err
@@ -44,7 +44,7 @@ func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error
 		RequestHeaders: requestHeaders,
 		Body:           body,
 	}
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	if err := c.addMessage(ctx, message, opts); err != nil {
 		return uuid.Nil, err
 	}
 
@@ -96,7 +96,7 @@ func NewSMTPClient(deps Dependencies, cfg *config.SMTPConfig) (*SMTPClient, erro
 	}, nil
 }
 
-func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, error) {
+func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate, opts ...QueueOption) (uuid.UUID, error) {
 	recipient, err := t.EmailRecipient()
 	if err != nil {
 		return uuid.Nil, errors.WithStack(err)
@@ -140,7 +140,7 @@ func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, e
 		RequestHeaders: requestHeaders,
 	}
 
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	if err := c.addMessage(ctx, message, opts); err != nil {
 		return uuid.Nil, errors.WithStack(err)
 	}
 
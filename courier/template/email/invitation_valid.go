@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	InvitationValid struct {
+		d template.Dependencies
+		m *InvitationValidModel
+	}
+	InvitationValidModel struct {
+		To               string                 `json:"to"`
+		InvitationURL    string                 `json:"invitation_url"`
+		Identity         map[string]interface{} `json:"identity"`
+		ExpiresInMinutes int                    `json:"expires_in_minutes"`
+	}
+)
+
+func NewInvitationValid(d template.Dependencies, m *InvitationValidModel) *InvitationValid {
+	return &InvitationValid{d: d, m: m}
+}
+
+func (t *InvitationValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *InvitationValid) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "invitation/valid/email.subject.gotmpl", "invitation/valid/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesInvitationValid(ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *InvitationValid) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "invitation/valid/email.body.gotmpl", "invitation/valid/email.body*", t.m, t.d.CourierConfig().CourierTemplatesInvitationValid(ctx).Body.HTML)
+}
+
+func (t *InvitationValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "invitation/valid/email.body.plaintext.gotmpl", "invitation/valid/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesInvitationValid(ctx).Body.PlainText)
+}
+
+func (t *InvitationValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *InvitationValid) TemplateType() template.TemplateType {
+	return template.TypeInvitationValid
+}
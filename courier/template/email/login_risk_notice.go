@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginRiskNotice struct {
+		deps  template.Dependencies
+		model *LoginRiskNoticeModel
+	}
+	LoginRiskNoticeModel struct {
+		To         string                 `json:"to"`
+		Identity   map[string]interface{} `json:"identity"`
+		Blocked    bool                   `json:"blocked"`
+		Score      int                    `json:"score"`
+		Signals    []string               `json:"signals"`
+		IPAddress  string                 `json:"ip_address"`
+		UserAgent  string                 `json:"user_agent"`
+		Location   string                 `json:"location"`
+		SignedInAt string                 `json:"signed_in_at"`
+	}
+)
+
+func NewLoginRiskNotice(d template.Dependencies, m *LoginRiskNoticeModel) *LoginRiskNotice {
+	return &LoginRiskNotice{deps: d, model: m}
+}
+
+func (t *LoginRiskNotice) EmailRecipient() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *LoginRiskNotice) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_risk/notice/email.subject.gotmpl", "login_risk/notice/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesLoginRiskNotice(ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LoginRiskNotice) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_risk/notice/email.body.gotmpl", "login_risk/notice/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesLoginRiskNotice(ctx).Body.HTML)
+}
+
+func (t *LoginRiskNotice) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_risk/notice/email.body.plaintext.gotmpl", "login_risk/notice/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesLoginRiskNotice(ctx).Body.PlainText)
+}
+
+func (t *LoginRiskNotice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *LoginRiskNotice) TemplateType() template.TemplateType {
+	return template.TypeLoginRiskNotice
+}
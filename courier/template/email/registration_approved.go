@@ -0,0 +1,55 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	RegistrationApproved struct {
+		d template.Dependencies
+		m *RegistrationApprovedModel
+	}
+	RegistrationApprovedModel struct {
+		To       string                 `json:"to"`
+		LoginURL string                 `json:"login_url"`
+		Identity map[string]interface{} `json:"identity"`
+	}
+)
+
+func NewRegistrationApproved(d template.Dependencies, m *RegistrationApprovedModel) *RegistrationApproved {
+	return &RegistrationApproved{d: d, m: m}
+}
+
+func (t *RegistrationApproved) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RegistrationApproved) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/approved/email.subject.gotmpl", "registration_approval/approved/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationApproved(ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *RegistrationApproved) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/approved/email.body.gotmpl", "registration_approval/approved/email.body*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationApproved(ctx).Body.HTML)
+}
+
+func (t *RegistrationApproved) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/approved/email.body.plaintext.gotmpl", "registration_approval/approved/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationApproved(ctx).Body.PlainText)
+}
+
+func (t *RegistrationApproved) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *RegistrationApproved) TemplateType() template.TemplateType {
+	return template.TypeRegistrationApproved
+}
@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	RegistrationRejected struct {
+		d template.Dependencies
+		m *RegistrationRejectedModel
+	}
+	RegistrationRejectedModel struct {
+		To       string                 `json:"to"`
+		Identity map[string]interface{} `json:"identity"`
+	}
+)
+
+func NewRegistrationRejected(d template.Dependencies, m *RegistrationRejectedModel) *RegistrationRejected {
+	return &RegistrationRejected{d: d, m: m}
+}
+
+func (t *RegistrationRejected) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RegistrationRejected) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/rejected/email.subject.gotmpl", "registration_approval/rejected/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationRejected(ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *RegistrationRejected) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/rejected/email.body.gotmpl", "registration_approval/rejected/email.body*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationRejected(ctx).Body.HTML)
+}
+
+func (t *RegistrationRejected) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "registration_approval/rejected/email.body.plaintext.gotmpl", "registration_approval/rejected/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesRegistrationRejected(ctx).Body.PlainText)
+}
+
+func (t *RegistrationRejected) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *RegistrationRejected) TemplateType() template.TemplateType {
+	return template.TypeRegistrationRejected
+}
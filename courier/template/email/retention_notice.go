@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	RetentionNotice struct {
+		deps  template.Dependencies
+		model *RetentionNoticeModel
+	}
+	RetentionNoticeModel struct {
+		To            string                 `json:"to"`
+		Identity      map[string]interface{} `json:"identity"`
+		PolicyID      string                 `json:"policy_id"`
+		InactiveSince string                 `json:"inactive_since"`
+	}
+)
+
+func NewRetentionNotice(d template.Dependencies, m *RetentionNoticeModel) *RetentionNotice {
+	return &RetentionNotice{deps: d, model: m}
+}
+
+func (t *RetentionNotice) EmailRecipient() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *RetentionNotice) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "retention/notice/email.subject.gotmpl", "retention/notice/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesRetentionNotice(ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *RetentionNotice) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "retention/notice/email.body.gotmpl", "retention/notice/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesRetentionNotice(ctx).Body.HTML)
+}
+
+func (t *RetentionNotice) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "retention/notice/email.body.plaintext.gotmpl", "retention/notice/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesRetentionNotice(ctx).Body.PlainText)
+}
+
+func (t *RetentionNotice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *RetentionNotice) TemplateType() template.TemplateType {
+	return template.TypeRetentionNotice
+}
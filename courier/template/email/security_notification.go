@@ -0,0 +1,99 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+)
+
+type (
+	// SecurityNotification tells an identity about a security relevant change
+	// of their account. All security notifications share one model and differ
+	// in their templates.
+	SecurityNotification struct {
+		deps         template.Dependencies
+		templateType template.TemplateType
+		model        *SecurityNotificationModel
+	}
+	SecurityNotificationModel struct {
+		To         string                 `json:"to"`
+		Identity   map[string]interface{} `json:"identity"`
+		Method     string                 `json:"method,omitempty"`
+		NewAddress string                 `json:"new_address,omitempty"`
+		IPAddress  string                 `json:"ip_address"`
+		UserAgent  string                 `json:"user_agent"`
+		Location   string                 `json:"location"`
+		OccurredAt string                 `json:"occurred_at"`
+
+		// RevokeSessionsURL signs the identity out everywhere if the change
+		// was not made by them.
+		RevokeSessionsURL string `json:"revoke_sessions_url,omitempty"`
+	}
+
+	securityNotificationTemplate struct {
+		dir    string
+		config func(config.CourierConfigs, context.Context) *config.CourierEmailTemplate
+	}
+)
+
+var securityNotificationTemplates = map[template.TemplateType]securityNotificationTemplate{
+	template.TypeSecurityPasswordChanged: {"security_notification/password_changed", config.CourierConfigs.CourierTemplatesSecurityPasswordChanged},
+	template.TypeSecurityMFAEnrolled:     {"security_notification/mfa_enrolled", config.CourierConfigs.CourierTemplatesSecurityMFAEnrolled},
+	template.TypeSecurityMFARemoved:      {"security_notification/mfa_removed", config.CourierConfigs.CourierTemplatesSecurityMFARemoved},
+	template.TypeSecurityRecoveryUsed:    {"security_notification/recovery_used", config.CourierConfigs.CourierTemplatesSecurityRecoveryUsed},
+	template.TypeSecurityNewDeviceLogin:  {"security_notification/new_device_login", config.CourierConfigs.CourierTemplatesSecurityNewDeviceLogin},
+	template.TypeSecurityEmailChanged:    {"security_notification/email_changed", config.CourierConfigs.CourierTemplatesSecurityEmailChanged},
+}
+
+// IsSecurityNotification returns true if the template type is one of the
+// security notifications.
+func IsSecurityNotification(t template.TemplateType) bool {
+	_, ok := securityNotificationTemplates[t]
+	return ok
+}
+
+func NewSecurityNotification(d template.Dependencies, t template.TemplateType, m *SecurityNotificationModel) (*SecurityNotification, error) {
+	if !IsSecurityNotification(t) {
+		return nil, errors.Errorf("%s is not a security notification template type", t)
+	}
+	return &SecurityNotification{deps: d, templateType: t, model: m}, nil
+}
+
+func (t *SecurityNotification) EmailRecipient() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *SecurityNotification) EmailSubject(ctx context.Context) (string, error) {
+	tpl := securityNotificationTemplates[t.templateType]
+	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), path.Join(tpl.dir, "email.subject.gotmpl"), path.Join(tpl.dir, "email.subject*"), t.model, tpl.config(t.deps.CourierConfig(), ctx).Subject)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *SecurityNotification) EmailBody(ctx context.Context) (string, error) {
+	tpl := securityNotificationTemplates[t.templateType]
+	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), path.Join(tpl.dir, "email.body.gotmpl"), path.Join(tpl.dir, "email.body*"), t.model, tpl.config(t.deps.CourierConfig(), ctx).Body.HTML)
+}
+
+func (t *SecurityNotification) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	tpl := securityNotificationTemplates[t.templateType]
+	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), path.Join(tpl.dir, "email.body.plaintext.gotmpl"), path.Join(tpl.dir, "email.body.plaintext*"), t.model, tpl.config(t.deps.CourierConfig(), ctx).Body.PlainText)
+}
+
+func (t *SecurityNotification) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *SecurityNotification) TemplateType() template.TemplateType {
+	return t.templateType
+}
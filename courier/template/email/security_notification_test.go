@@ -0,0 +1,68 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/testhelpers"
+	"github.com/ory/kratos/pkg"
+)
+
+func TestSecurityNotification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, tmpl := range []template.TemplateType{
+		template.TypeSecurityPasswordChanged,
+		template.TypeSecurityMFAEnrolled,
+		template.TypeSecurityMFARemoved,
+		template.TypeSecurityRecoveryUsed,
+		template.TypeSecurityNewDeviceLogin,
+		template.TypeSecurityEmailChanged,
+	} {
+		t.Run("type="+string(tmpl), func(t *testing.T) {
+			t.Run("test=with courier templates directory", func(t *testing.T) {
+				_, reg := pkg.NewFastRegistryWithMocks(t)
+				tpl, err := email.NewSecurityNotification(reg, tmpl, &email.SecurityNotificationModel{})
+				require.NoError(t, err)
+
+				testhelpers.TestRendered(t, ctx, tpl)
+				assert.Equal(t, tmpl, tpl.TemplateType())
+			})
+
+			t.Run("test=with remote resources", func(t *testing.T) {
+				testhelpers.TestRemoteTemplates(t, "../courier/builtin/templates/security_notification/"+strings.TrimPrefix(string(tmpl), "security_"), tmpl)
+			})
+		})
+	}
+
+	t.Run("case=email changed contains the revoke sessions link", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t)
+		tpl, err := email.NewSecurityNotification(reg, template.TypeSecurityEmailChanged, &email.SecurityNotificationModel{
+			To:                "old@example.org",
+			NewAddress:        "new@example.org",
+			RevokeSessionsURL: "https://www.ory.sh/self-service/revoke-sessions/browser?token=abc",
+		})
+		require.NoError(t, err)
+
+		body, err := tpl.EmailBodyPlaintext(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, body, "new@example.org")
+		assert.Contains(t, body, "https://www.ory.sh/self-service/revoke-sessions/browser?token=abc")
+	})
+
+	t.Run("case=rejects other template types", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t)
+		_, err := email.NewSecurityNotification(reg, template.TypeLoginRiskNotice, &email.SecurityNotificationModel{})
+		require.Error(t, err)
+	})
+}
@@ -86,7 +86,21 @@ func TestRemoteTemplates(t *testing.T, basePath string, tmplType template.Templa
 			return email.NewLoginCodeValid(d, &email.LoginCodeValidModel{})
 		case template.TypeRegistrationCodeValid:
 			return email.NewRegistrationCodeValid(d, &email.RegistrationCodeValidModel{})
+		case template.TypeRetentionNotice:
+			return email.NewRetentionNotice(d, &email.RetentionNoticeModel{})
+		case template.TypeLoginRiskNotice:
+			return email.NewLoginRiskNotice(d, &email.LoginRiskNoticeModel{})
+		case template.TypeInvitationValid:
+			return email.NewInvitationValid(d, &email.InvitationValidModel{})
+		case template.TypeRegistrationApproved:
+			return email.NewRegistrationApproved(d, &email.RegistrationApprovedModel{})
+		case template.TypeRegistrationRejected:
+			return email.NewRegistrationRejected(d, &email.RegistrationRejectedModel{})
 		default:
+			if email.IsSecurityNotification(tmpl) {
+				tpl, _ := email.NewSecurityNotification(d, tmpl, &email.SecurityNotificationModel{})
+				return tpl
+			}
 			return nil
 		}
 	}
@@ -20,4 +20,15 @@ const (
 	TypeTestStub                TemplateType = "stub"
 	TypeLoginCodeValid          TemplateType = "login_code_valid"
 	TypeRegistrationCodeValid   TemplateType = "registration_code_valid"
+	TypeRetentionNotice         TemplateType = "retention_notice"
+	TypeLoginRiskNotice         TemplateType = "login_risk_notice"
+	TypeSecurityPasswordChanged TemplateType = "security_password_changed"
+	TypeSecurityMFAEnrolled     TemplateType = "security_mfa_enrolled"
+	TypeSecurityMFARemoved      TemplateType = "security_mfa_removed"
+	TypeSecurityRecoveryUsed    TemplateType = "security_recovery_used"
+	TypeSecurityNewDeviceLogin  TemplateType = "security_new_device_login"
+	TypeSecurityEmailChanged    TemplateType = "security_email_changed"
+	TypeInvitationValid         TemplateType = "invitation_valid"
+	TypeRegistrationApproved    TemplateType = "registration_approved"
+	TypeRegistrationRejected    TemplateType = "registration_rejected"
 )
@@ -8,6 +8,8 @@ import (
 	"cmp"
 	"context"
 	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +17,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -73,6 +76,17 @@ const (
 	ViperKeyCourierHTTPRequestConfig                         = "courier.http.request_config"
 	ViperKeyCourierTemplatesLoginCodeValidEmail              = "courier.templates.login_code.valid.email"
 	ViperKeyCourierTemplatesRegistrationCodeValidEmail       = "courier.templates.registration_code.valid.email"
+	ViperKeyCourierTemplatesRetentionNoticeEmail             = "courier.templates.retention.notice.email"
+	ViperKeyCourierTemplatesLoginRiskNoticeEmail             = "courier.templates.login_risk.notice.email"
+	ViperKeyCourierTemplatesSecurityPasswordChangedEmail     = "courier.templates.security_notification.password_changed.email"
+	ViperKeyCourierTemplatesSecurityMFAEnrolledEmail         = "courier.templates.security_notification.mfa_enrolled.email"
+	ViperKeyCourierTemplatesSecurityMFARemovedEmail          = "courier.templates.security_notification.mfa_removed.email"
+	ViperKeyCourierTemplatesSecurityRecoveryUsedEmail        = "courier.templates.security_notification.recovery_used.email"
+	ViperKeyCourierTemplatesSecurityNewDeviceLoginEmail      = "courier.templates.security_notification.new_device_login.email"
+	ViperKeyCourierTemplatesSecurityEmailChangedEmail        = "courier.templates.security_notification.email_changed.email"
+	ViperKeyCourierTemplatesInvitationValidEmail             = "courier.templates.invitation.valid.email"
+	ViperKeyCourierTemplatesRegistrationApprovedEmail        = "courier.templates.registration_approval.approved.email"
+	ViperKeyCourierTemplatesRegistrationRejectedEmail        = "courier.templates.registration_approval.rejected.email"
 	ViperKeyCourierSMTP                                      = "courier.smtp"
 	ViperKeyCourierSMTPFrom                                  = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
@@ -88,6 +102,9 @@ const (
 	ViperKeySecretsPagination                                = "secrets.pagination"
 	ViperKeyPublicBaseURL                                    = "serve.public.base_url"
 	ViperKeyAdminBaseURL                                     = "serve.admin.base_url"
+	ViperKeyAdminAuthorizationEnabled                        = "serve.admin.authorization.enabled"
+	ViperKeyAdminAuthorizationClientCA                       = "serve.admin.authorization.mtls.client_ca"
+	ViperKeyAdminAuthorizationClientCertificates             = "serve.admin.authorization.mtls.clients"
 	ViperKeySessionLifespan                                  = "session.lifespan"
 	ViperKeySessionSameSite                                  = "session.cookie.same_site"
 	ViperKeySessionSecure                                    = "session.cookie.secure"
@@ -95,6 +112,8 @@ const (
 	ViperKeySessionName                                      = "session.cookie.name"
 	ViperKeySessionPath                                      = "session.cookie.path"
 	ViperKeySessionPersistentCookie                          = "session.cookie.persistent"
+	ViperKeySessionImpersonationMaxLifespan                  = "session.impersonation.max_lifespan"
+	ViperKeySessionRevokeLinkLifespan                        = "session.revoke_link.lifespan"
 	ViperKeySessionTokenizerTemplates                        = "session.whoami.tokenizer.templates"
 	ViperKeySessionWhoAmIAAL                                 = "session.whoami.required_aal"
 	ViperKeySessionWhoAmICaching                             = "feature_flags.cacheable_sessions"
@@ -121,6 +140,7 @@ const (
 	ViperKeySelfServiceRegistrationRequestLifespan           = "selfservice.flows.registration.lifespan"
 	ViperKeySelfServiceRegistrationAfter                     = "selfservice.flows.registration.after"
 	ViperKeySelfServiceRegistrationBeforeHooks               = "selfservice.flows.registration.before.hooks"
+	ViperKeySelfServiceRegistrationRequireApproval           = "selfservice.flows.registration.require_approval"
 	ViperKeySelfServiceLoginUI                               = "selfservice.flows.login.ui_url"
 	ViperKeySelfServiceLoginFlowStyle                        = "selfservice.flows.login.style"
 	ViperKeySecurityAccountEnumerationMitigate               = "security.account_enumeration.mitigate"
@@ -128,6 +148,9 @@ const (
 	ViperKeySelfServiceLoginRequestLifespan                  = "selfservice.flows.login.lifespan"
 	ViperKeySelfServiceLoginAfter                            = "selfservice.flows.login.after"
 	ViperKeySelfServiceLoginBeforeHooks                      = "selfservice.flows.login.before.hooks"
+	ViperKeySelfServiceLoginRisk                             = "selfservice.flows.login.risk"
+	ViperKeySelfServiceLoginRiskEnabled                      = "selfservice.flows.login.risk.enabled"
+	ViperKeySelfServiceLoginProfileCompletionEnabled         = "selfservice.flows.login.profile_completion.enabled"
 	ViperKeySelfServiceErrorUI                               = "selfservice.flows.error.ui_url"
 	ViperKeySelfServiceLogoutBrowserDefaultReturnTo          = "selfservice.flows.logout.after." + DefaultBrowserReturnURL
 	ViperKeySelfServiceSettingsURL                           = "selfservice.flows.settings.ui_url"
@@ -152,8 +175,19 @@ const (
 	ViperKeySelfServiceVerificationBeforeHooks               = "selfservice.flows.verification.before.hooks"
 	ViperKeySelfServiceVerificationUse                       = "selfservice.flows.verification.use"
 	ViperKeySelfServiceVerificationNotifyUnknownRecipients   = "selfservice.flows.verification.notify_unknown_recipients"
+	ViperKeySelfServiceDeviceEnabled                         = "selfservice.flows.device.enabled"
+	ViperKeySelfServiceDeviceUI                              = "selfservice.flows.device.ui_url"
+	ViperKeySelfServiceDeviceLifespan                        = "selfservice.flows.device.lifespan"
+	ViperKeySelfServiceDeviceInterval                        = "selfservice.flows.device.interval"
+	ViperKeySelfServiceInvitationLifespan                    = "selfservice.flows.invitation.lifespan"
+	ViperKeySelfServiceRevokeSessionsUI                      = "selfservice.flows.revoke_sessions.ui_url"
 	ViperKeyDefaultIdentitySchemaID                          = "identity.default_schema_id"
 	ViperKeyIdentitySchemas                                  = "identity.schemas"
+	ViperKeyIdentitySchemaMigrations                         = "identity.schema_migrations"
+	ViperKeyIdentityRetentionDryRun                          = "identity.retention.dry_run"
+	ViperKeyIdentityRetentionBatchSize                       = "identity.retention.batch_size"
+	ViperKeyIdentityRetentionPolicies                        = "identity.retention.policies"
+	ViperKeyIdentityJobsBatchSize                            = "identity.jobs.batch_size"
 	ViperKeyHasherAlgorithm                                  = "hashers.algorithm"
 	ViperKeyHasherArgon2ConfigMemory                         = "hashers.argon2.memory"
 	ViperKeyHasherArgon2ConfigIterations                     = "hashers.argon2.iterations"
@@ -167,18 +201,27 @@ const (
 	ViperKeyCipherAlgorithm                                  = "ciphers.algorithm"
 	ViperKeyDatabaseCleanupSleepTables                       = "database.cleanup.sleep.tables"
 	ViperKeyDatabaseCleanupBatchSize                         = "database.cleanup.batch_size"
+	ViperKeyDatabaseCleanupOlderThan                         = "database.cleanup.older_than"
 	ViperKeyLinkLifespan                                     = "selfservice.methods.link.config.lifespan"
 	ViperKeyCodeLifespan                                     = "selfservice.methods.code.config.lifespan"
 	ViperKeyCodeMaxSubmissions                               = "selfservice.methods.code.config.max_submissions"
 	ViperKeyCodeConfigMissingCredentialFallbackEnabled       = "selfservice.methods.code.config.missing_credential_fallback_enabled"
+	ViperKeyCodeChannels                                     = "selfservice.methods.code.config.channels"
 	ViperKeyPasswordHaveIBeenPwnedHost                       = "selfservice.methods.password.config.haveibeenpwned_host"
 	ViperKeyPasswordHaveIBeenPwnedEnabled                    = "selfservice.methods.password.config.haveibeenpwned_enabled"
 	ViperKeyPasswordMaxBreaches                              = "selfservice.methods.password.config.max_breaches"
 	ViperKeyPasswordMinLength                                = "selfservice.methods.password.config.min_password_length"
 	ViperKeyPasswordIdentifierSimilarityCheckEnabled         = "selfservice.methods.password.config.identifier_similarity_check_enabled"
 	ViperKeyIgnoreNetworkErrors                              = "selfservice.methods.password.config.ignore_network_errors"
+	ViperKeyPasswordBreachCorpusFormat                       = "selfservice.methods.password.config.breach_corpus.format"
+	ViperKeyPasswordBreachCorpusPath                         = "selfservice.methods.password.config.breach_corpus.path"
 	ViperKeyPasswordRegistrationProfileGroup                 = "selfservice.methods.password.config.password_profile_registration_node_group"
+	ViperKeyPasswordPolicyRules                              = "selfservice.methods.password.config.policy"
+	ViperKeyPasswordSchemaPolicies                           = "selfservice.methods.password.config.schema_policies"
 	ViperKeyTOTPIssuer                                       = "selfservice.methods.totp.config.issuer"
+	ViperKeyTrustedDeviceLifespan                            = "selfservice.methods.trusted_device.config.lifespan"
+	ViperKeyCrossDeviceLifespan                              = "selfservice.methods.cross_device.config.lifespan"
+	ViperKeyLegalDocuments                                   = "selfservice.methods.legal.config.documents"
 	ViperKeyOIDCBaseRedirectURL                              = "selfservice.methods.oidc.config.base_redirect_uri"
 	ViperKeySAMLBaseRedirectURL                              = "selfservice.methods.saml.config.base_redirect_uri"
 	ViperKeyWebAuthnRPDisplayName                            = "selfservice.methods.webauthn.config.rp.display_name"
@@ -199,6 +242,14 @@ const (
 	ViperKeyPreviewDefaultReadConsistencyLevel               = "preview.default_read_consistency_level"
 	ViperKeyVersion                                          = "version"
 	ViperKeyPasswordMigrationHook                            = "selfservice.methods.password.config.migrate_hook"
+	ViperKeyMultiTenancyEnabled                              = "multitenancy.enabled"
+	ViperKeyMultiTenancyHeader                               = "multitenancy.header"
+	ViperKeyMultiTenancyRequireTenant                        = "multitenancy.require_tenant"
+	ViperKeyMultiTenancyCacheTTL                             = "multitenancy.cache_ttl"
+	ViperKeyMaintenanceEnabled                               = "maintenance.enabled"
+	ViperKeyMaintenancePollInterval                          = "maintenance.poll_interval"
+	ViperKeyMaintenanceLease                                 = "maintenance.lease"
+	ViperKeyMaintenanceJobs                                  = "maintenance.jobs"
 )
 
 const (
@@ -213,6 +264,16 @@ const (
 	BcryptDefaultCost            uint32 = 12
 )
 
+const (
+	// PasswordBreachCorpusFormatIndex is a breach index built with
+	// `kratos hashers breach-index build`.
+	PasswordBreachCorpusFormatIndex = "index"
+
+	// PasswordBreachCorpusFormatRangeFiles is a directory of Have I Been Pwned
+	// SHA-1 range files named after their five character hash prefix.
+	PasswordBreachCorpusFormatRangeFiles = "range_files"
+)
+
 // DefaultSessionCookieName returns the default cookie name for the kratos session.
 const DefaultSessionCookieName = "ory_kratos_session"
 
@@ -248,6 +309,77 @@ type (
 		URL                   string `json:"url" koanf:"url"`
 		SelfserviceSelectable bool   `json:"selfservice_selectable" koanf:"selfservice_selectable"`
 	}
+	IdentitySchemaMigration struct {
+		ID           string `json:"id" koanf:"id"`
+		From         string `json:"from" koanf:"from"`
+		To           string `json:"to" koanf:"to"`
+		TransformURL string `json:"transform" koanf:"transform"`
+		Lazy         bool   `json:"lazy" koanf:"lazy"`
+	}
+	// LegalDocument is a document, such as the terms of service, which users
+	// must accept. Changing the version requires users to accept it again.
+	LegalDocument struct {
+		ID      string `json:"id" koanf:"id"`
+		Version string `json:"version" koanf:"version"`
+		Title   string `json:"title" koanf:"title"`
+		URL     string `json:"url" koanf:"url"`
+	}
+	// AdminClientCertificate grants access to the admin API to clients which
+	// present a TLS client certificate, issued by the configured client CA,
+	// with the given subject common name. Without a tenant, the client can
+	// only access the admin API of the default network.
+	AdminClientCertificate struct {
+		Subject        string   `json:"subject" koanf:"subject"`
+		Scopes         []string `json:"scopes" koanf:"scopes"`
+		OrganizationID string   `json:"organization_id" koanf:"organization_id"`
+		Tenant         string   `json:"tenant" koanf:"tenant"`
+	}
+	IdentityRetentionPolicy struct {
+		ID          string        `json:"id" koanf:"id"`
+		Action      string        `json:"action" koanf:"action"`
+		InactiveFor time.Duration `json:"inactive_for" koanf:"inactive_for"`
+		OlderThan   time.Duration `json:"older_than" koanf:"older_than"`
+		State       string        `json:"state" koanf:"state"`
+		Verified    *bool         `json:"verified" koanf:"verified"`
+	}
+	LoginRisk struct {
+		Enabled    bool                `json:"enabled" koanf:"enabled"`
+		Thresholds LoginRiskThresholds `json:"thresholds" koanf:"thresholds"`
+		Weights    LoginRiskWeights    `json:"weights" koanf:"weights"`
+
+		// ImpossibleTravelSpeed is the speed in km/h above which traveling
+		// between two sign-ins is considered impossible.
+		ImpossibleTravelSpeed float64 `json:"impossible_travel_speed" koanf:"impossible_travel_speed"`
+
+		// FailureWindow is how long failed sign-ins count towards the score.
+		FailureWindow time.Duration `json:"failure_window" koanf:"failure_window"`
+
+		// IPBlocklist contains IP addresses and CIDR ranges with a bad
+		// reputation.
+		IPBlocklist []string `json:"ip_blocklist" koanf:"ip_blocklist"`
+
+		// IPBlocklistFile is the path to a file with one IP address or CIDR
+		// range with a bad reputation per line.
+		IPBlocklistFile string `json:"ip_blocklist_file" koanf:"ip_blocklist_file"`
+
+		Scorer LoginRiskScorer `json:"scorer" koanf:"scorer"`
+	}
+	LoginRiskThresholds struct {
+		Notify int `json:"notify" koanf:"notify"`
+		StepUp int `json:"step_up" koanf:"step_up"`
+		Block  int `json:"block" koanf:"block"`
+	}
+	LoginRiskWeights struct {
+		NewDevice        int `json:"new_device" koanf:"new_device"`
+		NewCountry       int `json:"new_country" koanf:"new_country"`
+		ImpossibleTravel int `json:"impossible_travel" koanf:"impossible_travel"`
+		IPReputation     int `json:"ip_reputation" koanf:"ip_reputation"`
+		RecentFailure    int `json:"recent_failure" koanf:"recent_failure"`
+	}
+	LoginRiskScorer struct {
+		Type   string         `json:"type" koanf:"type"`
+		Config map[string]any `json:"config" koanf:"config"`
+	}
 	PasswordPolicy struct {
 		HaveIBeenPwnedHost               string `json:"haveibeenpwned_host"`
 		HaveIBeenPwnedEnabled            bool   `json:"haveibeenpwned_enabled"`
@@ -255,6 +387,22 @@ type (
 		IgnoreNetworkErrors              bool   `json:"ignore_network_errors"`
 		MinPasswordLength                uint   `json:"min_password_length"`
 		IdentifierSimilarityCheckEnabled bool   `json:"identifier_similarity_check_enabled"`
+		BreachCorpusFormat               string `json:"breach_corpus_format"`
+		BreachCorpusPath                 string `json:"breach_corpus_path"`
+	}
+	PasswordPolicyRules struct {
+		MinLowercase   uint          `json:"min_lowercase" koanf:"min_lowercase"`
+		MinUppercase   uint          `json:"min_uppercase" koanf:"min_uppercase"`
+		MinDigits      uint          `json:"min_digits" koanf:"min_digits"`
+		MinSymbols     uint          `json:"min_symbols" koanf:"min_symbols"`
+		BannedWords    []string      `json:"banned_words" koanf:"banned_words"`
+		BannedWordsURL string        `json:"banned_words_url" koanf:"banned_words_url"`
+		HistorySize    uint          `json:"history_size" koanf:"history_size"`
+		MaxAge         time.Duration `json:"max_age" koanf:"max_age"`
+	}
+	PasswordSchemaPolicy struct {
+		SchemaID string              `json:"schema_id" koanf:"schema_id"`
+		Policy   PasswordPolicyRules `json:"policy" koanf:"policy"`
 	}
 	Schemas                  []Schema
 	CourierEmailBodyTemplate struct {
@@ -271,6 +419,17 @@ type (
 	CourierSMSTemplateBody struct {
 		PlainText string `json:"plaintext"`
 	}
+	// CodeChannel is a courier channel codes can be delivered over.
+	CodeChannel struct {
+		// ID is the ID of the courier channel.
+		ID string `json:"id" koanf:"id"`
+
+		// Via is the address type the channel delivers to, `email` or `sms`.
+		Via string `json:"via" koanf:"via"`
+
+		// Fallback lists the channels to try if dispatching fails.
+		Fallback []string `json:"fallback" koanf:"fallback"`
+	}
 	CourierChannel struct {
 		ID            string         `json:"id" koanf:"id"`
 		Type          string         `json:"type" koanf:"type"`
@@ -312,6 +471,17 @@ type (
 		CourierTemplatesVerificationCodeValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesLoginCodeValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesRegistrationCodeValid(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesRetentionNotice(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesLoginRiskNotice(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityPasswordChanged(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityMFAEnrolled(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityMFARemoved(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityRecoveryUsed(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityNewDeviceLogin(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesSecurityEmailChanged(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesInvitationValid(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesRegistrationApproved(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesRegistrationRejected(ctx context.Context) *CourierEmailTemplate
 		CourierSMSTemplatesVerificationCodeValid(ctx context.Context) *CourierSMSTemplate
 		CourierSMSTemplatesRecoveryCodeValid(ctx context.Context) *CourierSMSTemplate
 		CourierSMSTemplatesLoginCodeValid(ctx context.Context) *CourierSMSTemplate
@@ -508,6 +678,47 @@ func (p *Config) ServeAdmin(ctx context.Context) *configx.Serve {
 	})
 }
 
+// AdminAuthorizationEnabled returns true if requests to the admin API must be
+// authorized using an API key or a TLS client certificate.
+func (p *Config) AdminAuthorizationEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyAdminAuthorizationEnabled)
+}
+
+func (p *Config) AdminClientCertificates(ctx context.Context) ([]AdminClientCertificate, error) {
+	var clients []AdminClientCertificate
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeyAdminAuthorizationClientCertificates, &clients); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the admin API client certificates: %s", err))
+	}
+	return clients, nil
+}
+
+// AdminClientCAs returns the certificate authorities which issue TLS client
+// certificates for the admin API, or nil if none are configured.
+func (p *Config) AdminClientCAs(ctx context.Context) (*x509.CertPool, error) {
+	var pem []byte
+	if encoded := p.GetProvider(ctx).String(ViperKeyAdminAuthorizationClientCA + ".base64"); encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the admin API client CA: %s", err))
+		}
+		pem = decoded
+	} else if path := p.GetProvider(ctx).String(ViperKeyAdminAuthorizationClientCA + ".path"); path != "" {
+		contents, err := os.ReadFile(path) //#nosec G304 -- the path is set by the operator
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to read the admin API client CA: %s", err))
+		}
+		pem = contents
+	} else {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReason("The admin API client CA does not contain any PEM-encoded certificates."))
+	}
+	return pool, nil
+}
+
 func (p *Config) CORSPublic(ctx context.Context) (cors.Options, bool) {
 	return p.GetProvider(ctx).CORS("serve.public", cors.Options{
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
@@ -603,6 +814,37 @@ func (p *Config) TOTPIssuer(ctx context.Context) string {
 	return p.GetProvider(ctx).StringF(ViperKeyTOTPIssuer, p.SelfPublicURL(ctx).Hostname())
 }
 
+// SelfServiceTrustedDeviceEnabled returns whether users can trust their
+// browser to skip the second factor on later sign-ins.
+func (p *Config) SelfServiceTrustedDeviceEnabled(ctx context.Context) bool {
+	return p.SelfServiceStrategy(ctx, "trusted_device").Enabled
+}
+
+// SelfServiceTrustedDeviceLifespan returns how long a browser stays trusted.
+func (p *Config) SelfServiceTrustedDeviceLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyTrustedDeviceLifespan, 30*24*time.Hour)
+}
+
+// SelfServiceCrossDeviceLifespan returns how long the QR code shown for
+// cross-device sign ins can be approved.
+func (p *Config) SelfServiceCrossDeviceLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyCrossDeviceLifespan, 5*time.Minute)
+}
+
+// SelfServiceLegalDocuments returns the legal documents users must accept, or
+// nil if the legal method is disabled.
+func (p *Config) SelfServiceLegalDocuments(ctx context.Context) ([]LegalDocument, error) {
+	if !p.SelfServiceStrategy(ctx, "legal").Enabled {
+		return nil, nil
+	}
+
+	var docs []LegalDocument
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeyLegalDocuments, &docs); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode legal documents: %s", err))
+	}
+	return docs, nil
+}
+
 func (p *Config) OIDCRedirectURIBase(ctx context.Context) *url.URL {
 	return p.GetProvider(ctx).URIF(ViperKeyOIDCBaseRedirectURL, p.SelfPublicURL(ctx))
 }
@@ -619,6 +861,51 @@ func (p *Config) IdentityTraitsSchemas(ctx context.Context) (ss Schemas, err err
 	return ss, nil
 }
 
+func (p *Config) IdentitySchemaMigrations(ctx context.Context) (ms []IdentitySchemaMigration, err error) {
+	if err = p.GetProvider(ctx).Unmarshal(ViperKeyIdentitySchemaMigrations, &ms); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode identity schema migrations: %s", err))
+	}
+
+	return ms, nil
+}
+
+func (p *Config) IdentityRetentionPolicies(ctx context.Context) (ps []IdentityRetentionPolicy, err error) {
+	if err = p.GetProvider(ctx).Unmarshal(ViperKeyIdentityRetentionPolicies, &ps); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode identity retention policies: %s", err))
+	}
+
+	return ps, nil
+}
+
+func (p *Config) IdentityRetentionDryRun(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyIdentityRetentionDryRun)
+}
+
+func (p *Config) IdentityRetentionBatchSize(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyIdentityRetentionBatchSize, 100)
+}
+
+// IdentityJobsBatchSize returns the number of identities an identity job
+// processes before saving its progress.
+func (p *Config) IdentityJobsBatchSize(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyIdentityJobsBatchSize, 100)
+}
+
+func (p *Config) IdentitySchemaMigration(ctx context.Context, id string) (*IdentitySchemaMigration, error) {
+	ms, err := p.IdentitySchemaMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range ms {
+		if m.ID == id {
+			return &m, nil
+		}
+	}
+
+	return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf("Unable to find identity schema migration \"%s\".", id))
+}
+
 func (p *Config) DSN(ctx context.Context) string {
 	pp := p.GetProvider(ctx)
 	dsn := pp.String(ViperKeyDSN)
@@ -656,6 +943,10 @@ func (p *Config) SelfServiceFlowRegistrationEnabled(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySelfServiceRegistrationEnabled)
 }
 
+func (p *Config) SelfServiceFlowRegistrationRequireApproval(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySelfServiceRegistrationRequireApproval)
+}
+
 func (p *Config) SelfServiceFlowRegistrationLoginHints(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySelfServiceRegistrationLoginHints)
 }
@@ -1001,6 +1292,14 @@ func (p *Config) SessionLifespan(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySessionLifespan, time.Hour*24)
 }
 
+func (p *Config) SessionImpersonationMaxLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionImpersonationMaxLifespan, time.Hour)
+}
+
+func (p *Config) SessionRevokeLinkLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionRevokeLinkLifespan, 7*24*time.Hour)
+}
+
 func (p *Config) SessionPersistentCookie(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySessionPersistentCookie)
 }
@@ -1040,6 +1339,32 @@ func (p *Config) SelfServiceFlowLoginRequestLifespan(ctx context.Context) time.D
 	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceLoginRequestLifespan, time.Hour)
 }
 
+func (p *Config) SelfServiceFlowLoginRiskEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySelfServiceLoginRiskEnabled)
+}
+
+func (p *Config) SelfServiceFlowLoginProfileCompletionEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySelfServiceLoginProfileCompletionEnabled)
+}
+
+func (p *Config) SelfServiceFlowLoginRisk(ctx context.Context) (*LoginRisk, error) {
+	var r LoginRisk
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeySelfServiceLoginRisk, &r); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the login risk configuration: %s", err))
+	}
+
+	if r.ImpossibleTravelSpeed <= 0 {
+		r.ImpossibleTravelSpeed = 1000
+	}
+	if r.FailureWindow <= 0 {
+		r.FailureWindow = time.Hour
+	}
+	if r.Scorer.Type == "" {
+		r.Scorer.Type = "builtin"
+	}
+	return &r, nil
+}
+
 func (p *Config) SelfServiceFlowSettingsFlowLifespan(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceSettingsRequestLifespan, time.Hour)
 }
@@ -1180,6 +1505,50 @@ func (p *Config) CourierTemplatesRegistrationCodeValid(ctx context.Context) *Cou
 	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesRegistrationCodeValidEmail)
 }
 
+func (p *Config) CourierTemplatesRetentionNotice(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesRetentionNoticeEmail)
+}
+
+func (p *Config) CourierTemplatesLoginRiskNotice(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginRiskNoticeEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityPasswordChanged(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityPasswordChangedEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityMFAEnrolled(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityMFAEnrolledEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityMFARemoved(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityMFARemovedEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityRecoveryUsed(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityRecoveryUsedEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityNewDeviceLogin(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityNewDeviceLoginEmail)
+}
+
+func (p *Config) CourierTemplatesSecurityEmailChanged(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSecurityEmailChangedEmail)
+}
+
+func (p *Config) CourierTemplatesInvitationValid(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesInvitationValidEmail)
+}
+
+func (p *Config) CourierTemplatesRegistrationApproved(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesRegistrationApprovedEmail)
+}
+
+func (p *Config) CourierTemplatesRegistrationRejected(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesRegistrationRejectedEmail)
+}
+
 func (p *Config) CourierMessageRetries(ctx context.Context) int {
 	return p.GetProvider(ctx).IntF(ViperKeyCourierMessageRetries, 5)
 }
@@ -1302,6 +1671,30 @@ func (p *Config) SelfServiceFlowVerificationAfterHooks(ctx context.Context, stra
 	return p.selfServiceHooks(ctx, HookStrategyKey(ViperKeySelfServiceVerificationAfter, strategy))
 }
 
+func (p *Config) SelfServiceFlowDeviceEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeySelfServiceDeviceEnabled)
+}
+
+func (p *Config) SelfServiceFlowDeviceUI(ctx context.Context) *url.URL {
+	return p.ParseAbsoluteOrRelativeURIOrFail(ctx, ViperKeySelfServiceDeviceUI)
+}
+
+func (p *Config) SelfServiceFlowDeviceLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceDeviceLifespan, 15*time.Minute)
+}
+
+func (p *Config) SelfServiceFlowDeviceInterval(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceDeviceInterval, 5*time.Second)
+}
+
+func (p *Config) SelfServiceFlowInvitationLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySelfServiceInvitationLifespan, 7*24*time.Hour)
+}
+
+func (p *Config) SelfServiceFlowRevokeSessionsUI(ctx context.Context) *url.URL {
+	return p.ParseAbsoluteOrRelativeURIOrFail(ctx, ViperKeySelfServiceRevokeSessionsUI)
+}
+
 func (p *Config) SelfServiceFlowRecoveryReturnTo(ctx context.Context, defaultReturnTo *url.URL) *url.URL {
 	return p.GetProvider(ctx).RequestURIF(ViperKeySelfServiceRecoveryBrowserDefaultReturnTo, defaultReturnTo)
 }
@@ -1334,6 +1727,23 @@ func (p *Config) SelfServiceCodeMethodMissingCredentialFallbackEnabled(ctx conte
 	return p.GetProvider(ctx).Bool(ViperKeyCodeConfigMissingCredentialFallbackEnabled)
 }
 
+// SelfServiceCodeMethodChannels returns the channels codes can be delivered
+// over. The built-in `email` and `sms` channels are appended unless they are
+// configured explicitly.
+func (p *Config) SelfServiceCodeMethodChannels(ctx context.Context) ([]CodeChannel, error) {
+	var channels []CodeChannel
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeyCodeChannels, &channels); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the code delivery channels: %s", err))
+	}
+
+	for _, builtin := range []string{"email", "sms"} {
+		if !slices.ContainsFunc(channels, func(c CodeChannel) bool { return c.ID == builtin }) {
+			channels = append(channels, CodeChannel{ID: builtin, Via: builtin})
+		}
+	}
+	return channels, nil
+}
+
 func (p *Config) DatabaseCleanupSleepTables(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).Duration(ViperKeyDatabaseCleanupSleepTables)
 }
@@ -1342,6 +1752,10 @@ func (p *Config) DatabaseCleanupBatchSize(ctx context.Context) int {
 	return p.GetProvider(ctx).Int(ViperKeyDatabaseCleanupBatchSize)
 }
 
+func (p *Config) DatabaseCleanupOlderThan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).Duration(ViperKeyDatabaseCleanupOlderThan)
+}
+
 func (p *Config) SelfServiceFlowRecoveryAfterHooks(ctx context.Context, strategy string) []SelfServiceHook {
 	return p.selfServiceHooks(ctx, HookStrategyKey(ViperKeySelfServiceRecoveryAfter, strategy))
 }
@@ -1484,7 +1898,107 @@ func (p *Config) PasswordPolicyConfig(ctx context.Context) *PasswordPolicy {
 		IgnoreNetworkErrors:              p.GetProvider(ctx).BoolF(ViperKeyIgnoreNetworkErrors, true),
 		MinPasswordLength:                uint(p.GetProvider(ctx).IntF(ViperKeyPasswordMinLength, 8)), // #nosec G115 -- negative values are prevented by the schema validation
 		IdentifierSimilarityCheckEnabled: p.GetProvider(ctx).BoolF(ViperKeyPasswordIdentifierSimilarityCheckEnabled, true),
+		BreachCorpusFormat:               p.GetProvider(ctx).StringF(ViperKeyPasswordBreachCorpusFormat, PasswordBreachCorpusFormatIndex),
+		BreachCorpusPath:                 p.GetProvider(ctx).String(ViperKeyPasswordBreachCorpusPath),
+	}
+}
+
+// PasswordPolicyRules returns the password policy rules which apply to identities
+// using the given identity schema. Rules configured for the schema in
+// `schema_policies` replace the default rules.
+func (p *Config) PasswordPolicyRules(ctx context.Context, schemaID string) (*PasswordPolicyRules, error) {
+	policies, err := p.passwordSchemaPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.SchemaID == schemaID {
+			return &policy.Policy, nil
+		}
+	}
+
+	var rules PasswordPolicyRules
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeyPasswordPolicyRules, &rules); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode password policy: %s", err))
+	}
+	return &rules, nil
+}
+
+// PasswordPolicyMaxAgeEnabled returns true if any password policy limits the
+// age of passwords.
+func (p *Config) PasswordPolicyMaxAgeEnabled(ctx context.Context) bool {
+	if p.GetProvider(ctx).DurationF(ViperKeyPasswordPolicyRules+".max_age", 0) > 0 {
+		return true
+	}
+
+	policies, err := p.passwordSchemaPolicies(ctx)
+	if err != nil {
+		return false
+	}
+	for _, policy := range policies {
+		if policy.Policy.MaxAge > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Config) passwordSchemaPolicies(ctx context.Context) (policies []PasswordSchemaPolicy, err error) {
+	if err := p.GetProvider(ctx).Unmarshal(ViperKeyPasswordSchemaPolicies, &policies); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode password schema policies: %s", err))
+	}
+	return policies, nil
+}
+
+func (p *Config) MultiTenancyEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyMultiTenancyEnabled)
+}
+
+// MultiTenancyHeader returns the header naming the tenant of an admin request,
+// or an empty string if tenants are only resolved using the request host.
+func (p *Config) MultiTenancyHeader(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyMultiTenancyHeader)
+}
+
+func (p *Config) MultiTenancyRequireTenant(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyMultiTenancyRequireTenant)
+}
+
+func (p *Config) MultiTenancyCacheTTL(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyMultiTenancyCacheTTL, time.Minute)
+}
+
+func (p *Config) MaintenanceEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyMaintenanceEnabled)
+}
+
+func (p *Config) MaintenancePollInterval(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyMaintenancePollInterval, time.Minute)
+}
+
+func (p *Config) MaintenanceLease(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyMaintenanceLease, 5*time.Minute)
+}
+
+func (p *Config) MaintenanceJobEnabled(ctx context.Context, job string) bool {
+	return p.GetProvider(ctx).BoolF(fmt.Sprintf("%s.%s.enabled", ViperKeyMaintenanceJobs, job), true)
+}
+
+// maintenanceJobDefaultIntervals lists the jobs which run more often than
+// hourly by default.
+var maintenanceJobDefaultIntervals = map[string]time.Duration{
+	// Identity jobs are created through the admin API and should start soon.
+	"identity_jobs": time.Minute,
+}
+
+// MaintenanceJobInterval returns how long to wait after the job finished
+// before running it again.
+func (p *Config) MaintenanceJobInterval(ctx context.Context, job string) time.Duration {
+	fallback, ok := maintenanceJobDefaultIntervals[job]
+	if !ok {
+		fallback = time.Hour
 	}
+	return p.GetProvider(ctx).DurationF(fmt.Sprintf("%s.%s.interval", ViperKeyMaintenanceJobs, job), fallback)
 }
 
 func (p *Config) WebAuthnForPasswordless(ctx context.Context) bool {
@@ -588,6 +588,8 @@ func TestViperProvider_Defaults(t *testing.T) {
 		assert.Equal(t, "https://www.ory.com/kratos/docs/fallback/registration", p.SelfServiceFlowRegistrationUI(ctx).String())
 		assert.Equal(t, "https://www.ory.com/kratos/docs/fallback/recovery", p.SelfServiceFlowRecoveryUI(ctx).String())
 		assert.Equal(t, "https://www.ory.com/kratos/docs/fallback/verification", p.SelfServiceFlowVerificationUI(ctx).String())
+		assert.Equal(t, "https://www.ory.com/kratos/docs/fallback/device", p.SelfServiceFlowDeviceUI(ctx).String())
+		assert.Equal(t, "https://www.ory.com/kratos/docs/fallback/revoke_sessions", p.SelfServiceFlowRevokeSessionsUI(ctx).String())
 	})
 }
 
@@ -1129,6 +1131,39 @@ func TestPasswordlessCode(t *testing.T) {
 	assert.True(t, conf.SelfServiceCodeStrategy(ctx).PasswordlessEnabled)
 }
 
+func TestCodeChannels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("case=defaults", func(t *testing.T) {
+		conf, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.SkipValidation())
+		require.NoError(t, err)
+
+		channels, err := conf.SelfServiceCodeMethodChannels(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []config.CodeChannel{{ID: "email", Via: "email"}, {ID: "sms", Via: "sms"}}, channels)
+	})
+
+	t.Run("case=configured", func(t *testing.T) {
+		conf, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.SkipValidation(),
+			configx.WithValue(config.ViperKeyCodeChannels, []map[string]any{
+				{"id": "whatsapp", "via": "sms", "fallback": []string{"sms", "email"}},
+				{"id": "sms", "via": "sms", "fallback": []string{"email"}},
+			}))
+		require.NoError(t, err)
+
+		channels, err := conf.SelfServiceCodeMethodChannels(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []config.CodeChannel{
+			{ID: "whatsapp", Via: "sms", Fallback: []string{"sms", "email"}},
+			{ID: "sms", Via: "sms", Fallback: []string{"email"}},
+			{ID: "email", Via: "email"},
+		}, channels)
+	})
+}
+
 func TestChangeMinPasswordLength(t *testing.T) {
 	t.Parallel()
 	t.Run("case=must fail on minimum password length below enforced minimum", func(t *testing.T) {
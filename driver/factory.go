@@ -10,6 +10,7 @@ import (
 	"github.com/ory/x/servicelocatorx"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/tenant"
 	"github.com/ory/x/logrusx"
 )
 
@@ -37,10 +38,13 @@ func NewWithoutInit(ctx context.Context, stdOutOrErr io.Writer, dOpts ...Registr
 		l = logrusx.New("Ory Kratos", config.Version)
 	}
 
+	// Contexts served as a tenant use the tenant's network and configuration.
+	ctxter := tenant.NewContextualizer(sl.Contextualizer(), l)
+
 	c := opts.config
 	if c == nil {
 		var err error
-		c, err = config.New(ctx, l, stdOutOrErr, sl.Contextualizer(), opts.configOptions...)
+		c, err = config.New(ctx, l, stdOutOrErr, ctxter, opts.configOptions...)
 		if err != nil {
 			l.WithError(err).Error("Unable to instantiate configuration.")
 			return nil, err
@@ -53,7 +57,7 @@ func NewWithoutInit(ctx context.Context, stdOutOrErr io.Writer, dOpts ...Registr
 		return nil, err
 	}
 	r.slOptions = sl
-	r.SetContextualizer(sl.Contextualizer())
+	r.SetContextualizer(ctxter)
 
 	return r, nil
 }
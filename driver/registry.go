@@ -11,19 +11,30 @@ import (
 
 	"github.com/ory/x/httpx"
 
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/approval"
+	"github.com/ory/kratos/bulk"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/invitation"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/organization"
 	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/retention"
+	"github.com/ory/kratos/risk"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/revokesessions"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
@@ -31,6 +42,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/link"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
 	"github.com/ory/nosurf"
@@ -91,23 +103,68 @@ type Registry interface {
 	hash.HashProvider
 
 	identity.HandlerProvider
+	identity.GRPCServerProvider
 	identity.ValidationProvider
+	identity.TraitsClassifierProvider
+	identity.SchemaMigratorProvider
 	identity.PoolProvider
 	identity.PrivilegedPoolProvider
 	identity.ManagementProvider
 	identity.ActiveCredentialsCounterStrategyProvider
 
 	courier.HandlerProvider
+	courier.GRPCServerProvider
 	courier.PersistenceProvider
 
+	device.HandlerProvider
+	device.PersistenceProvider
+
+	revokesessions.HandlerProvider
+	revokesessions.PersistenceProvider
+
+	organization.HandlerProvider
+	organization.PersistenceProvider
+
+	invitation.HandlerProvider
+	invitation.PersistenceProvider
+	approval.HandlerProvider
+
+	legal.HandlerProvider
+	legal.PersistenceProvider
+
+	apikey.HandlerProvider
+	apikey.MiddlewareProvider
+	apikey.PersistenceProvider
+
+	tenant.HandlerProvider
+	tenant.PersistenceProvider
+	tenant.ResolverProvider
+
+	maintenance.HandlerProvider
+	maintenance.PersistenceProvider
+	maintenance.SchedulerProvider
+
+	retention.EnforcerProvider
+	retention.HandlerProvider
+	retention.PersistenceProvider
+
+	bulk.HandlerProvider
+	bulk.PersistenceProvider
+	bulk.RunnerProvider
+
+	risk.AssessorProvider
+	risk.PersistenceProvider
+
 	schema.HandlerProvider
 	schema.IdentitySchemaProvider
 
 	password2.ValidationProvider
 
 	session.HandlerProvider
+	session.GRPCServerProvider
 	session.ManagementProvider
 	session.PersistenceProvider
+	session.TrustedDevicePersistenceProvider
 	session.TokenizerProvider
 
 	settings.HandlerProvider
@@ -180,6 +237,7 @@ type options struct {
 	replacementStrategies         []NewStrategy
 	extraHooks                    map[string]func(config.SelfServiceHook) any
 	extraHandlers                 []NewHandler
+	passwordPolicyRules           []password2.PolicyRule
 	disableMigrationLogging       bool
 	jsonnetPool                   jsonnetsecure.Pool
 	serviceLocatorOptions         []servicelocatorx.Option
@@ -247,6 +305,14 @@ func WithExtraHooks(hooks map[string]func(config.SelfServiceHook) any) RegistryO
 	}
 }
 
+// WithPasswordPolicyRules adds rules to the password policy. The rules are
+// checked after the built-in rules whenever a user sets a new password.
+func WithPasswordPolicyRules(rules ...password2.PolicyRule) RegistryOption {
+	return func(o *options) {
+		o.passwordPolicyRules = append(o.passwordPolicyRules, rules...)
+	}
+}
+
 type NewHandler func(deps any) x.Handler
 
 func WithExtraHandlers(handlers ...NewHandler) RegistryOption {
@@ -21,29 +21,46 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/negroni"
+	"google.golang.org/grpc"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/approval"
+	"github.com/ory/kratos/bulk"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	courierv1 "github.com/ory/kratos/gen/courier/v1"
+	identityv1 "github.com/ory/kratos/gen/identity/v1"
+	sessionv1 "github.com/ory/kratos/gen/session/v1"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/invitation"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/organization"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/persistence/sql"
+	"github.com/ory/kratos/retention"
+	"github.com/ory/kratos/risk"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/revokesessions"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/hook"
 	"github.com/ory/kratos/selfservice/strategy/code"
+	"github.com/ory/kratos/selfservice/strategy/crossdevice"
 	"github.com/ory/kratos/selfservice/strategy/idfirst"
+	legalstrategy "github.com/ory/kratos/selfservice/strategy/legal"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/selfservice/strategy/lookup"
 	"github.com/ory/kratos/selfservice/strategy/oidc"
@@ -51,8 +68,10 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/selfservice/strategy/profile"
 	"github.com/ory/kratos/selfservice/strategy/totp"
+	"github.com/ory/kratos/selfservice/strategy/trusteddevice"
 	"github.com/ory/kratos/selfservice/strategy/webauthn"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
 	"github.com/ory/kratos/x/webauthnx"
@@ -99,14 +118,53 @@ type RegistryDefault struct {
 	hookAddressVerifier    *hook.AddressVerifier
 	hookShowVerificationUI *hook.ShowVerificationUIHook
 	hookVerifyNewAddress   *hook.VerifyNewAddress
+	hookPasswordExpiry     *hook.PasswordExpiry
+	hookLegalDocuments     *hook.LegalDocuments
+	hookProfileCompletion  *hook.ProfileCompletion
+	hookSecurityNotifier   *hook.SecurityNotifier
 
 	identityHandler        *identity.Handler
 	identityValidator      *identity.Validator
+	identityClassifier     initOnce[*identity.TraitsClassifier]
+	identitySchemaMigrator initOnce[*identity.SchemaMigrator]
 	identityManager        *identity.Manager
 	identitySchemaProvider schema.IdentitySchemaProvider
 
 	courierHandler *courier.Handler
 
+	deviceFlowHandler *device.Handler
+
+	revokeSessionsFlowHandler *revokesessions.Handler
+
+	organizationHandler *organization.Handler
+
+	invitationHandler *invitation.Handler
+	approvalHandler   *approval.Handler
+
+	legalHandler *legal.Handler
+
+	apiKeyHandler    *apikey.Handler
+	apiKeyMiddleware initOnce[*apikey.Middleware]
+
+	identityGRPCServer initOnce[*identity.GRPCServer]
+	sessionGRPCServer  initOnce[*session.GRPCServer]
+	courierGRPCServer  initOnce[*courier.GRPCServer]
+	adminGRPCServer    initOnce[*grpc.Server]
+
+	tenantHandler  *tenant.Handler
+	tenantResolver initOnce[*tenant.Resolver]
+
+	maintenanceHandler   *maintenance.Handler
+	maintenanceScheduler initOnce[*maintenance.Scheduler]
+
+	retentionHandler  *retention.Handler
+	retentionEnforcer initOnce[*retention.Enforcer]
+
+	identityJobHandler *bulk.Handler
+	identityJobRunner  initOnce[*bulk.Runner]
+
+	loginRiskAssessor initOnce[*risk.Assessor]
+
 	continuityManager *continuity.Manager
 
 	schemaHandler *schema.Handler
@@ -115,8 +173,10 @@ type RegistryDefault struct {
 	sessionManager   session.Manager
 	sessionTokenizer initOnce[*session.Tokenizer]
 
-	passwordHasher    initOnce[hash.Hasher]
-	passwordValidator initOnce[password.Validator]
+	passwordHasher       initOnce[hash.Hasher]
+	passwordValidator    initOnce[password.Validator]
+	passwordPolicyEngine initOnce[*password.PolicyEngine]
+	passwordPolicyRules  []password.PolicyRule
 
 	crypter initOnce[cipher.Cipher]
 
@@ -182,8 +242,19 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *http
 	m.SettingsHandler().RegisterPublicRoutes(router)
 	m.IdentityHandler().RegisterPublicRoutes(router)
 	m.CourierHandler().RegisterPublicRoutes(router)
+	m.OrganizationHandler().RegisterPublicRoutes(router)
+	m.InvitationHandler().RegisterPublicRoutes(router)
+	m.RegistrationApprovalHandler().RegisterPublicRoutes(router)
+	m.LegalHandler().RegisterPublicRoutes(router)
+	m.APIKeyHandler().RegisterPublicRoutes(router)
+	m.TenantHandler().RegisterPublicRoutes(router)
+	m.MaintenanceHandler().RegisterPublicRoutes(router)
+	m.RetentionHandler().RegisterPublicRoutes(router)
+	m.IdentityJobHandler().RegisterPublicRoutes(router)
 	m.SessionHandler().RegisterPublicRoutes(router)
 	m.SelfServiceErrorHandler().RegisterPublicRoutes(router)
+	m.DeviceFlowHandler().RegisterPublicRoutes(router)
+	m.RevokeSessionsFlowHandler().RegisterPublicRoutes(router)
 	m.SchemaHandler().RegisterPublicRoutes(router)
 
 	m.RecoveryHandler().RegisterPublicRoutes(router)
@@ -212,7 +283,18 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *httpr
 	m.SettingsHandler().RegisterAdminRoutes(router)
 	m.IdentityHandler().RegisterAdminRoutes(router)
 	m.CourierHandler().RegisterAdminRoutes(router)
+	m.OrganizationHandler().RegisterAdminRoutes(router)
+	m.InvitationHandler().RegisterAdminRoutes(router)
+	m.RegistrationApprovalHandler().RegisterAdminRoutes(router)
+	m.LegalHandler().RegisterAdminRoutes(router)
+	m.APIKeyHandler().RegisterAdminRoutes(router)
+	m.TenantHandler().RegisterAdminRoutes(router)
+	m.MaintenanceHandler().RegisterAdminRoutes(router)
+	m.RetentionHandler().RegisterAdminRoutes(router)
+	m.IdentityJobHandler().RegisterAdminRoutes(router)
 	m.SelfServiceErrorHandler().RegisterAdminRoutes(router)
+	m.DeviceFlowHandler().RegisterAdminRoutes(router)
+	m.RevokeSessionsFlowHandler().RegisterAdminRoutes(router)
 
 	m.RecoveryHandler().RegisterAdminRoutes(router)
 	m.SessionHandler().RegisterAdminRoutes(router)
@@ -236,6 +318,26 @@ func (m *RegistryDefault) HTTPMiddlewares() []negroni.Handler {
 	return m.slOptions.HTTPMiddlewares()
 }
 
+// AdminGRPCServer returns the gRPC server which is served on the admin port
+// alongside the admin API.
+func (m *RegistryDefault) AdminGRPCServer() *grpc.Server {
+	return m.adminGRPCServer.Get(func() *grpc.Server {
+		s := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+				herodot.UnaryErrorUnwrapInterceptor,
+				m.APIKeyMiddleware().UnaryServerInterceptor,
+			}, m.slOptions.GRPCUnaryInterceptors()...)...),
+			grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+				herodot.StreamErrorUnwrapInterceptor,
+			}, m.slOptions.GRPCStreamInterceptors()...)...),
+		)
+		identityv1.RegisterIdentityServiceServer(s, m.IdentityGRPCServer())
+		sessionv1.RegisterSessionServiceServer(s, m.SessionGRPCServer())
+		courierv1.RegisterCourierServiceServer(s, m.CourierGRPCServer())
+		return s
+	})
+}
+
 func NewRegistryDefault() *RegistryDefault {
 	r := &RegistryDefault{
 		trc:       otelx.NewNoop(),
@@ -332,6 +434,9 @@ func (m *RegistryDefault) selfServiceStrategies() []any {
 				passkey.NewStrategy(m),
 				webauthn.NewStrategy(m),
 				lookup.NewStrategy(m),
+				trusteddevice.NewStrategy(m),
+				crossdevice.NewStrategy(m),
+				legalstrategy.NewStrategy(m),
 				idfirst.NewStrategy(m),
 			}
 		}
@@ -429,6 +534,14 @@ func (m *RegistryDefault) IdentityValidator() *identity.Validator {
 	return m.identityValidator
 }
 
+func (m *RegistryDefault) IdentityTraitsClassifier() *identity.TraitsClassifier {
+	return m.identityClassifier.Get(func() *identity.TraitsClassifier { return identity.NewTraitsClassifier(m) })
+}
+
+func (m *RegistryDefault) IdentitySchemaMigrator() *identity.SchemaMigrator {
+	return m.identitySchemaMigrator.Get(func() *identity.SchemaMigrator { return identity.NewSchemaMigrator(m) })
+}
+
 func (m *RegistryDefault) SetConfig(c *config.Config) {
 	m.c = c
 }
@@ -465,6 +578,133 @@ func (m *RegistryDefault) IdentityHandler() *identity.Handler {
 	return m.identityHandler
 }
 
+func (m *RegistryDefault) IdentityGRPCServer() *identity.GRPCServer {
+	return m.identityGRPCServer.Get(func() *identity.GRPCServer { return identity.NewGRPCServer(m.IdentityHandler()) })
+}
+
+func (m *RegistryDefault) OrganizationHandler() *organization.Handler {
+	if m.organizationHandler == nil {
+		m.organizationHandler = organization.NewHandler(m)
+	}
+	return m.organizationHandler
+}
+
+func (m *RegistryDefault) RegistrationInvitationAcceptor() registration.InvitationAcceptor {
+	return m.InvitationHandler()
+}
+
+func (m *RegistryDefault) InvitationHandler() *invitation.Handler {
+	if m.invitationHandler == nil {
+		m.invitationHandler = invitation.NewHandler(m)
+	}
+	return m.invitationHandler
+}
+
+func (m *RegistryDefault) APIKeyHandler() *apikey.Handler {
+	if m.apiKeyHandler == nil {
+		m.apiKeyHandler = apikey.NewHandler(m)
+	}
+	return m.apiKeyHandler
+}
+
+func (m *RegistryDefault) APIKeyMiddleware() *apikey.Middleware {
+	return m.apiKeyMiddleware.Get(func() *apikey.Middleware { return apikey.NewMiddleware(m) })
+}
+
+func (m *RegistryDefault) RegistrationApprovalHandler() *approval.Handler {
+	if m.approvalHandler == nil {
+		m.approvalHandler = approval.NewHandler(m)
+	}
+	return m.approvalHandler
+}
+
+func (m *RegistryDefault) LegalHandler() *legal.Handler {
+	if m.legalHandler == nil {
+		m.legalHandler = legal.NewHandler(m)
+	}
+	return m.legalHandler
+}
+
+func (m *RegistryDefault) DeviceFlowHandler() *device.Handler {
+	if m.deviceFlowHandler == nil {
+		m.deviceFlowHandler = device.NewHandler(m)
+	}
+	return m.deviceFlowHandler
+}
+
+func (m *RegistryDefault) RevokeSessionsFlowHandler() *revokesessions.Handler {
+	if m.revokeSessionsFlowHandler == nil {
+		m.revokeSessionsFlowHandler = revokesessions.NewHandler(m)
+	}
+	return m.revokeSessionsFlowHandler
+}
+
+func (m *RegistryDefault) TenantHandler() *tenant.Handler {
+	if m.tenantHandler == nil {
+		m.tenantHandler = tenant.NewHandler(m)
+	}
+	return m.tenantHandler
+}
+
+func (m *RegistryDefault) TenantContexts(ctx context.Context) ([]context.Context, error) {
+	return tenant.Contexts(ctx, m)
+}
+
+func (m *RegistryDefault) TenantResolver() *tenant.Resolver {
+	return m.tenantResolver.Get(func() *tenant.Resolver {
+		return tenant.NewResolver(m)
+	})
+}
+
+func (m *RegistryDefault) MaintenanceHandler() *maintenance.Handler {
+	if m.maintenanceHandler == nil {
+		m.maintenanceHandler = maintenance.NewHandler(m)
+	}
+	return m.maintenanceHandler
+}
+
+func (m *RegistryDefault) MaintenanceScheduler() *maintenance.Scheduler {
+	return m.maintenanceScheduler.Get(func() *maintenance.Scheduler {
+		return maintenance.NewScheduler(m,
+			maintenance.NewCleanupJob(m),
+			m.RetentionEnforcer(),
+			m.IdentityJobRunner(),
+		)
+	})
+}
+
+func (m *RegistryDefault) RetentionHandler() *retention.Handler {
+	if m.retentionHandler == nil {
+		m.retentionHandler = retention.NewHandler(m)
+	}
+	return m.retentionHandler
+}
+
+func (m *RegistryDefault) RetentionEnforcer() *retention.Enforcer {
+	return m.retentionEnforcer.Get(func() *retention.Enforcer {
+		return retention.NewEnforcer(m)
+	})
+}
+
+func (m *RegistryDefault) IdentityJobHandler() *bulk.Handler {
+	if m.identityJobHandler == nil {
+		m.identityJobHandler = bulk.NewHandler(m)
+	}
+	return m.identityJobHandler
+}
+
+func (m *RegistryDefault) IdentityJobRunner() *bulk.Runner {
+	return m.identityJobRunner.Get(func() *bulk.Runner {
+		return bulk.NewRunner(m)
+	})
+}
+
+func (m *RegistryDefault) LoginRiskAssessor() *risk.Assessor {
+	return m.loginRiskAssessor.Get(func() *risk.Assessor {
+		return risk.NewAssessor(m)
+	})
+}
+
 func (m *RegistryDefault) CourierHandler() *courier.Handler {
 	if m.courierHandler == nil {
 		m.courierHandler = courier.NewHandler(m)
@@ -472,6 +712,10 @@ func (m *RegistryDefault) CourierHandler() *courier.Handler {
 	return m.courierHandler
 }
 
+func (m *RegistryDefault) CourierGRPCServer() *courier.GRPCServer {
+	return m.courierGRPCServer.Get(func() *courier.GRPCServer { return courier.NewGRPCServer(m.CourierHandler()) })
+}
+
 func (m *RegistryDefault) SchemaHandler() *schema.Handler {
 	if m.schemaHandler == nil {
 		m.schemaHandler = schema.NewHandler(m)
@@ -486,6 +730,10 @@ func (m *RegistryDefault) SessionHandler() *session.Handler {
 	return m.sessionHandler
 }
 
+func (m *RegistryDefault) SessionGRPCServer() *session.GRPCServer {
+	return m.sessionGRPCServer.Get(func() *session.GRPCServer { return session.NewGRPCServer(m.SessionHandler()) })
+}
+
 func (m *RegistryDefault) Cipher(ctx context.Context) cipher.Cipher {
 	return m.crypter.Get(func() cipher.Cipher {
 		switch m.c.CipherAlgorithm(ctx) {
@@ -520,6 +768,12 @@ func (m *RegistryDefault) PasswordValidator() password.Validator {
 	return m.passwordValidator.value
 }
 
+func (m *RegistryDefault) PasswordPolicyEngine() *password.PolicyEngine {
+	return m.passwordPolicyEngine.Get(func() *password.PolicyEngine {
+		return password.NewPolicyEngine(m, m.passwordPolicyRules...)
+	})
+}
+
 func (m *RegistryDefault) SelfServiceErrorHandler() *errorx.Handler {
 	if m.errorHandler == nil {
 		m.errorHandler = errorx.NewHandler(m)
@@ -620,6 +874,9 @@ func (m *RegistryDefault) Init(ctx context.Context, ctxer contextx.Contextualize
 	if o.extraHandlers != nil {
 		m.WithExtraHandlers(o.extraHandlers)
 	}
+	if o.passwordPolicyRules != nil {
+		m.passwordPolicyRules = o.passwordPolicyRules
+	}
 
 	if o.replaceIdentitySchemaProvider != nil {
 		m.identitySchemaProvider = o.replaceIdentitySchemaProvider(m)
@@ -766,12 +1023,31 @@ func (m *RegistryDefault) SettingsFlowPersister() settings.FlowPersister
 func (m *RegistryDefault) SelfServiceErrorPersister() errorx.Persister           { return m.persister }
 func (m *RegistryDefault) SessionPersister() session.Persister                   { return m.persister }
 func (m *RegistryDefault) CourierPersister() courier.Persister                   { return m.persister }
+func (m *RegistryDefault) TenantPersister() tenant.Persister                     { return m.persister }
+func (m *RegistryDefault) OrganizationPersister() organization.Persister         { return m.persister }
+func (m *RegistryDefault) InvitationPersister() invitation.Persister             { return m.persister }
+func (m *RegistryDefault) LegalPersister() legal.Persister                       { return m.persister }
+func (m *RegistryDefault) APIKeyPersister() apikey.Persister                     { return m.persister }
+func (m *RegistryDefault) DeviceFlowPersister() device.Persister                 { return m.persister }
+func (m *RegistryDefault) RevokeSessionsFlowPersister() revokesessions.Persister { return m.persister }
+func (m *RegistryDefault) MaintenancePersister() maintenance.Persister           { return m.persister }
+func (m *RegistryDefault) RetentionPersister() retention.Persister               { return m.persister }
+func (m *RegistryDefault) IdentityJobPersister() bulk.Persister                  { return m.persister }
 func (m *RegistryDefault) RecoveryTokenPersister() link.RecoveryTokenPersister   { return m.persister }
 func (m *RegistryDefault) RecoveryCodePersister() code.RecoveryCodePersister     { return m.persister }
 func (m *RegistryDefault) LoginCodePersister() code.LoginCodePersister           { return m.persister }
 func (m *RegistryDefault) VerificationTokenPersister() link.VerificationTokenPersister {
 	return m.persister
 }
+func (m *RegistryDefault) LoginRiskPersister() risk.Persister {
+	return m.persister
+}
+func (m *RegistryDefault) TrustedDevicePersister() session.TrustedDevicePersister {
+	return m.persister
+}
+func (m *RegistryDefault) ProfilePromptPersister() identity.ProfilePromptPersister {
+	return m.persister
+}
 func (m *RegistryDefault) VerificationCodePersister() code.VerificationCodePersister {
 	return m.persister
 }
@@ -781,6 +1057,9 @@ func (m *RegistryDefault) RegistrationCodePersister() code.RegistrationCodePersi
 func (m *RegistryDefault) PendingTraitsChangePersister() identity.PendingTraitsChangePersister {
 	return m.Persister()
 }
+func (m *RegistryDefault) UniqueTraitPersister() identity.UniqueTraitPersister {
+	return m.Persister()
+}
 func (m *RegistryDefault) TransactionalPersisterProvider() x.TransactionalPersister {
 	return m.persister
 }
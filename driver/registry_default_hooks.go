@@ -56,6 +56,34 @@ func (m *RegistryDefault) HookVerifyNewAddress() *hook.VerifyNewAddress {
 	return m.hookVerifyNewAddress
 }
 
+func (m *RegistryDefault) HookPasswordExpiry() *hook.PasswordExpiry {
+	if m.hookPasswordExpiry == nil {
+		m.hookPasswordExpiry = hook.NewPasswordExpiry(m)
+	}
+	return m.hookPasswordExpiry
+}
+
+func (m *RegistryDefault) HookLegalDocuments() *hook.LegalDocuments {
+	if m.hookLegalDocuments == nil {
+		m.hookLegalDocuments = hook.NewLegalDocuments(m)
+	}
+	return m.hookLegalDocuments
+}
+
+func (m *RegistryDefault) HookProfileCompletion() *hook.ProfileCompletion {
+	if m.hookProfileCompletion == nil {
+		m.hookProfileCompletion = hook.NewProfileCompletion(m)
+	}
+	return m.hookProfileCompletion
+}
+
+func (m *RegistryDefault) HookSecurityNotifier() *hook.SecurityNotifier {
+	if m.hookSecurityNotifier == nil {
+		m.hookSecurityNotifier = hook.NewSecurityNotifier(m)
+	}
+	return m.hookSecurityNotifier
+}
+
 func (m *RegistryDefault) WithHooks(hooks map[string]func(config.SelfServiceHook) interface{}) {
 	m.injectedSelfserviceHooks = hooks
 }
@@ -102,6 +130,10 @@ allHooksLoop:
 			if h, ok := any(m.HookVerifyNewAddress()).(T); ok {
 				hooks = append(hooks, h)
 			}
+		case hook.KeySecurityNotifier:
+			if h, ok := any(m.HookSecurityNotifier()).(T); ok {
+				hooks = append(hooks, h)
+			}
 		default:
 			for name, m := range m.injectedSelfserviceHooks {
 				if name == h.Name {
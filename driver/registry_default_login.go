@@ -27,13 +27,31 @@ func (m *RegistryDefault) PostLoginHooks(ctx context.Context, credentialsType id
 	if err != nil {
 		return nil, err
 	}
-	if len(hooks) > 0 {
-		return hooks, nil
+	if len(hooks) == 0 {
+		// since we don't want merging hooks defined in a specific strategy and global hooks
+		// global hooks are added only if no strategy specific hooks are defined
+		hooks, err = getHooks[login.PostHookExecutor](m, config.HookGlobal, m.Config().SelfServiceFlowLoginAfterHooks(ctx, config.HookGlobal))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// since we don't want merging hooks defined in a specific strategy and global hooks
-	// global hooks are added only if no strategy specific hooks are defined
-	return getHooks[login.PostHookExecutor](m, config.HookGlobal, m.Config().SelfServiceFlowLoginAfterHooks(ctx, config.HookGlobal))
+	// Expired passwords must be changed regardless of the configured hooks.
+	if credentialsType == identity.CredentialsTypePassword && m.Config().PasswordPolicyMaxAgeEnabled(ctx) {
+		hooks = append(hooks, m.HookPasswordExpiry())
+	}
+
+	// Changed legal documents must be accepted regardless of the configured hooks.
+	if m.Config().SelfServiceStrategy(ctx, "legal").Enabled {
+		hooks = append(hooks, m.HookLegalDocuments())
+	}
+
+	// Identities which do not satisfy their current identity schema must
+	// complete their profile regardless of the configured hooks.
+	if m.Config().SelfServiceFlowLoginProfileCompletionEnabled(ctx) {
+		hooks = append(hooks, m.HookProfileCompletion())
+	}
+	return hooks, nil
 }
 
 func (m *RegistryDefault) LoginHandler() *login.Handler {
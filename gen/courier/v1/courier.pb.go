@@ -0,0 +1,486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: courier/v1/courier.proto
+
+package courierv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MessageStatus int32
+
+const (
+	MessageStatus_MESSAGE_STATUS_UNSPECIFIED MessageStatus = 0
+	MessageStatus_MESSAGE_STATUS_QUEUED      MessageStatus = 1
+	MessageStatus_MESSAGE_STATUS_SENT        MessageStatus = 2
+	MessageStatus_MESSAGE_STATUS_PROCESSING  MessageStatus = 3
+	MessageStatus_MESSAGE_STATUS_ABANDONED   MessageStatus = 4
+)
+
+// Enum value maps for MessageStatus.
+var (
+	MessageStatus_name = map[int32]string{
+		0: "MESSAGE_STATUS_UNSPECIFIED",
+		1: "MESSAGE_STATUS_QUEUED",
+		2: "MESSAGE_STATUS_SENT",
+		3: "MESSAGE_STATUS_PROCESSING",
+		4: "MESSAGE_STATUS_ABANDONED",
+	}
+	MessageStatus_value = map[string]int32{
+		"MESSAGE_STATUS_UNSPECIFIED": 0,
+		"MESSAGE_STATUS_QUEUED":      1,
+		"MESSAGE_STATUS_SENT":        2,
+		"MESSAGE_STATUS_PROCESSING":  3,
+		"MESSAGE_STATUS_ABANDONED":   4,
+	}
+)
+
+func (x MessageStatus) Enum() *MessageStatus {
+	p := new(MessageStatus)
+	*p = x
+	return p
+}
+
+func (x MessageStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_courier_v1_courier_proto_enumTypes[0].Descriptor()
+}
+
+func (MessageStatus) Type() protoreflect.EnumType {
+	return &file_courier_v1_courier_proto_enumTypes[0]
+}
+
+func (x MessageStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageStatus.Descriptor instead.
+func (MessageStatus) EnumDescriptor() ([]byte, []int) {
+	return file_courier_v1_courier_proto_rawDescGZIP(), []int{0}
+}
+
+type MessageType int32
+
+const (
+	MessageType_MESSAGE_TYPE_UNSPECIFIED MessageType = 0
+	MessageType_MESSAGE_TYPE_EMAIL       MessageType = 1
+	MessageType_MESSAGE_TYPE_SMS         MessageType = 2
+)
+
+// Enum value maps for MessageType.
+var (
+	MessageType_name = map[int32]string{
+		0: "MESSAGE_TYPE_UNSPECIFIED",
+		1: "MESSAGE_TYPE_EMAIL",
+		2: "MESSAGE_TYPE_SMS",
+	}
+	MessageType_value = map[string]int32{
+		"MESSAGE_TYPE_UNSPECIFIED": 0,
+		"MESSAGE_TYPE_EMAIL":       1,
+		"MESSAGE_TYPE_SMS":         2,
+	}
+)
+
+func (x MessageType) Enum() *MessageType {
+	p := new(MessageType)
+	*p = x
+	return p
+}
+
+func (x MessageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_courier_v1_courier_proto_enumTypes[1].Descriptor()
+}
+
+func (MessageType) Type() protoreflect.EnumType {
+	return &file_courier_v1_courier_proto_enumTypes[1]
+}
+
+func (x MessageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageType.Descriptor instead.
+func (MessageType) EnumDescriptor() ([]byte, []int) {
+	return file_courier_v1_courier_proto_rawDescGZIP(), []int{1}
+}
+
+type Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        MessageStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=courier.v1.MessageStatus" json:"status,omitempty"`
+	Type          MessageType            `protobuf:"varint,3,opt,name=type,proto3,enum=courier.v1.MessageType" json:"type,omitempty"`
+	Recipient     string                 `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body          string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	TemplateType  string                 `protobuf:"bytes,7,opt,name=template_type,json=templateType,proto3" json:"template_type,omitempty"`
+	Channel       string                 `protobuf:"bytes,8,opt,name=channel,proto3" json:"channel,omitempty"`
+	SendCount     int64                  `protobuf:"varint,9,opt,name=send_count,json=sendCount,proto3" json:"send_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_courier_v1_courier_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_v1_courier_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_courier_v1_courier_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Message) GetStatus() MessageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MessageStatus_MESSAGE_STATUS_UNSPECIFIED
+}
+
+func (x *Message) GetType() MessageType {
+	if x != nil {
+		return x.Type
+	}
+	return MessageType_MESSAGE_TYPE_UNSPECIFIED
+}
+
+func (x *Message) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *Message) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Message) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Message) GetTemplateType() string {
+	if x != nil {
+		return x.TemplateType
+	}
+	return ""
+}
+
+func (x *Message) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Message) GetSendCount() int64 {
+	if x != nil {
+		return x.SendCount
+	}
+	return 0
+}
+
+func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Message) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListMessagesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Status only lists messages with this status.
+	Status MessageStatus `protobuf:"varint,3,opt,name=status,proto3,enum=courier.v1.MessageStatus" json:"status,omitempty"`
+	// Recipient only lists messages sent to this recipient.
+	Recipient     string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_courier_v1_courier_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_v1_courier_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_courier_v1_courier_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetStatus() MessageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MessageStatus_MESSAGE_STATUS_UNSPECIFIED
+}
+
+func (x *ListMessagesRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type ListMessagesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Messages []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_courier_v1_courier_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_v1_courier_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_courier_v1_courier_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListMessagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_courier_v1_courier_proto protoreflect.FileDescriptor
+
+const file_courier_v1_courier_proto_rawDesc = "" +
+	"\n" +
+	"\x18courier/v1/courier.proto\x12\n" +
+	"courier.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x03\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.courier.v1.MessageStatusR\x06status\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.courier.v1.MessageTypeR\x04type\x12\x1c\n" +
+	"\trecipient\x18\x04 \x01(\tR\trecipient\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12#\n" +
+	"\rtemplate_type\x18\a \x01(\tR\ftemplateType\x12\x18\n" +
+	"\achannel\x18\b \x01(\tR\achannel\x12\x1d\n" +
+	"\n" +
+	"send_count\x18\t \x01(\x03R\tsendCount\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa2\x01\n" +
+	"\x13ListMessagesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.courier.v1.MessageStatusR\x06status\x12\x1c\n" +
+	"\trecipient\x18\x04 \x01(\tR\trecipient\"o\n" +
+	"\x14ListMessagesResponse\x12/\n" +
+	"\bmessages\x18\x01 \x03(\v2\x13.courier.v1.MessageR\bmessages\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xa0\x01\n" +
+	"\rMessageStatus\x12\x1e\n" +
+	"\x1aMESSAGE_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15MESSAGE_STATUS_QUEUED\x10\x01\x12\x17\n" +
+	"\x13MESSAGE_STATUS_SENT\x10\x02\x12\x1d\n" +
+	"\x19MESSAGE_STATUS_PROCESSING\x10\x03\x12\x1c\n" +
+	"\x18MESSAGE_STATUS_ABANDONED\x10\x04*Y\n" +
+	"\vMessageType\x12\x1c\n" +
+	"\x18MESSAGE_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12MESSAGE_TYPE_EMAIL\x10\x01\x12\x14\n" +
+	"\x10MESSAGE_TYPE_SMS\x10\x022\x84\x01\n" +
+	"\x0eCourierService\x12r\n" +
+	"\fListMessages\x12\x1f.courier.v1.ListMessagesRequest\x1a .courier.v1.ListMessagesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/admin/courier/messagesB\x97\x01\n" +
+	"\x0ecom.courier.v1B\fCourierProtoP\x01Z.github.com/ory/kratos/gen/courier/v1;courierv1\xa2\x02\x03CXX\xaa\x02\n" +
+	"Courier.V1\xca\x02\n" +
+	"Courier\\V1\xe2\x02\x16Courier\\V1\\GPBMetadata\xea\x02\vCourier::V1b\x06proto3"
+
+var (
+	file_courier_v1_courier_proto_rawDescOnce sync.Once
+	file_courier_v1_courier_proto_rawDescData []byte
+)
+
+func file_courier_v1_courier_proto_rawDescGZIP() []byte {
+	file_courier_v1_courier_proto_rawDescOnce.Do(func() {
+		file_courier_v1_courier_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_courier_v1_courier_proto_rawDesc), len(file_courier_v1_courier_proto_rawDesc)))
+	})
+	return file_courier_v1_courier_proto_rawDescData
+}
+
+var file_courier_v1_courier_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_courier_v1_courier_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_courier_v1_courier_proto_goTypes = []any{
+	(MessageStatus)(0),            // 0: courier.v1.MessageStatus
+	(MessageType)(0),              // 1: courier.v1.MessageType
+	(*Message)(nil),               // 2: courier.v1.Message
+	(*ListMessagesRequest)(nil),   // 3: courier.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),  // 4: courier.v1.ListMessagesResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_courier_v1_courier_proto_depIdxs = []int32{
+	0, // 0: courier.v1.Message.status:type_name -> courier.v1.MessageStatus
+	1, // 1: courier.v1.Message.type:type_name -> courier.v1.MessageType
+	5, // 2: courier.v1.Message.created_at:type_name -> google.protobuf.Timestamp
+	5, // 3: courier.v1.Message.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: courier.v1.ListMessagesRequest.status:type_name -> courier.v1.MessageStatus
+	2, // 5: courier.v1.ListMessagesResponse.messages:type_name -> courier.v1.Message
+	3, // 6: courier.v1.CourierService.ListMessages:input_type -> courier.v1.ListMessagesRequest
+	4, // 7: courier.v1.CourierService.ListMessages:output_type -> courier.v1.ListMessagesResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_courier_v1_courier_proto_init() }
+func file_courier_v1_courier_proto_init() {
+	if File_courier_v1_courier_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_courier_v1_courier_proto_rawDesc), len(file_courier_v1_courier_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_courier_v1_courier_proto_goTypes,
+		DependencyIndexes: file_courier_v1_courier_proto_depIdxs,
+		EnumInfos:         file_courier_v1_courier_proto_enumTypes,
+		MessageInfos:      file_courier_v1_courier_proto_msgTypes,
+	}.Build()
+	File_courier_v1_courier_proto = out.File
+	file_courier_v1_courier_proto_goTypes = nil
+	file_courier_v1_courier_proto_depIdxs = nil
+}
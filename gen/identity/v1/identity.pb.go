@@ -0,0 +1,1373 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: identity/v1/identity.proto
+
+package identityv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IdentityState int32
+
+const (
+	IdentityState_IDENTITY_STATE_UNSPECIFIED      IdentityState = 0
+	IdentityState_IDENTITY_STATE_ACTIVE           IdentityState = 1
+	IdentityState_IDENTITY_STATE_INACTIVE         IdentityState = 2
+	IdentityState_IDENTITY_STATE_PENDING_APPROVAL IdentityState = 3
+)
+
+// Enum value maps for IdentityState.
+var (
+	IdentityState_name = map[int32]string{
+		0: "IDENTITY_STATE_UNSPECIFIED",
+		1: "IDENTITY_STATE_ACTIVE",
+		2: "IDENTITY_STATE_INACTIVE",
+		3: "IDENTITY_STATE_PENDING_APPROVAL",
+	}
+	IdentityState_value = map[string]int32{
+		"IDENTITY_STATE_UNSPECIFIED":      0,
+		"IDENTITY_STATE_ACTIVE":           1,
+		"IDENTITY_STATE_INACTIVE":         2,
+		"IDENTITY_STATE_PENDING_APPROVAL": 3,
+	}
+)
+
+func (x IdentityState) Enum() *IdentityState {
+	p := new(IdentityState)
+	*p = x
+	return p
+}
+
+func (x IdentityState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IdentityState) Descriptor() protoreflect.EnumDescriptor {
+	return file_identity_v1_identity_proto_enumTypes[0].Descriptor()
+}
+
+func (IdentityState) Type() protoreflect.EnumType {
+	return &file_identity_v1_identity_proto_enumTypes[0]
+}
+
+func (x IdentityState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IdentityState.Descriptor instead.
+func (IdentityState) EnumDescriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{0}
+}
+
+type Identity struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SchemaId            string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	SchemaUrl           string                 `protobuf:"bytes,3,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
+	State               IdentityState          `protobuf:"varint,4,opt,name=state,proto3,enum=identity.v1.IdentityState" json:"state,omitempty"`
+	StateChangedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=state_changed_at,json=stateChangedAt,proto3" json:"state_changed_at,omitempty"`
+	Traits              *structpb.Struct       `protobuf:"bytes,6,opt,name=traits,proto3" json:"traits,omitempty"`
+	VerifiableAddresses []*VerifiableAddress   `protobuf:"bytes,7,rep,name=verifiable_addresses,json=verifiableAddresses,proto3" json:"verifiable_addresses,omitempty"`
+	RecoveryAddresses   []*RecoveryAddress     `protobuf:"bytes,8,rep,name=recovery_addresses,json=recoveryAddresses,proto3" json:"recovery_addresses,omitempty"`
+	MetadataPublic      *structpb.Value        `protobuf:"bytes,9,opt,name=metadata_public,json=metadataPublic,proto3" json:"metadata_public,omitempty"`
+	MetadataAdmin       *structpb.Value        `protobuf:"bytes,10,opt,name=metadata_admin,json=metadataAdmin,proto3" json:"metadata_admin,omitempty"`
+	OrganizationId      *string                `protobuf:"bytes,11,opt,name=organization_id,json=organizationId,proto3,oneof" json:"organization_id,omitempty"`
+	ExternalId          *string                `protobuf:"bytes,12,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	// Credentials lists the identity's credentials without their configuration.
+	Credentials   []*Credentials         `protobuf:"bytes,13,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_identity_v1_identity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Identity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Identity) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *Identity) GetSchemaUrl() string {
+	if x != nil {
+		return x.SchemaUrl
+	}
+	return ""
+}
+
+func (x *Identity) GetState() IdentityState {
+	if x != nil {
+		return x.State
+	}
+	return IdentityState_IDENTITY_STATE_UNSPECIFIED
+}
+
+func (x *Identity) GetStateChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StateChangedAt
+	}
+	return nil
+}
+
+func (x *Identity) GetTraits() *structpb.Struct {
+	if x != nil {
+		return x.Traits
+	}
+	return nil
+}
+
+func (x *Identity) GetVerifiableAddresses() []*VerifiableAddress {
+	if x != nil {
+		return x.VerifiableAddresses
+	}
+	return nil
+}
+
+func (x *Identity) GetRecoveryAddresses() []*RecoveryAddress {
+	if x != nil {
+		return x.RecoveryAddresses
+	}
+	return nil
+}
+
+func (x *Identity) GetMetadataPublic() *structpb.Value {
+	if x != nil {
+		return x.MetadataPublic
+	}
+	return nil
+}
+
+func (x *Identity) GetMetadataAdmin() *structpb.Value {
+	if x != nil {
+		return x.MetadataAdmin
+	}
+	return nil
+}
+
+func (x *Identity) GetOrganizationId() string {
+	if x != nil && x.OrganizationId != nil {
+		return *x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Identity) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *Identity) GetCredentials() []*Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *Identity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Identity) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type VerifiableAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Verified      bool                   `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+	Via           string                 `protobuf:"bytes,4,opt,name=via,proto3" json:"via,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	VerifiedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifiableAddress) Reset() {
+	*x = VerifiableAddress{}
+	mi := &file_identity_v1_identity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifiableAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableAddress) ProtoMessage() {}
+
+func (x *VerifiableAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableAddress.ProtoReflect.Descriptor instead.
+func (*VerifiableAddress) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VerifiableAddress) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VerifiableAddress) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *VerifiableAddress) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifiableAddress) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
+}
+
+func (x *VerifiableAddress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VerifiableAddress) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *VerifiableAddress) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *VerifiableAddress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RecoveryAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Via           string                 `protobuf:"bytes,3,opt,name=via,proto3" json:"via,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryAddress) Reset() {
+	*x = RecoveryAddress{}
+	mi := &file_identity_v1_identity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryAddress) ProtoMessage() {}
+
+func (x *RecoveryAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryAddress.ProtoReflect.Descriptor instead.
+func (*RecoveryAddress) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecoveryAddress) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecoveryAddress) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RecoveryAddress) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
+}
+
+func (x *RecoveryAddress) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RecoveryAddress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Credentials struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type is the credentials type, for example `password` or `oidc`.
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Identifiers   []string               `protobuf:"bytes,2,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	mi := &file_identity_v1_identity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Credentials) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Credentials) GetIdentifiers() []string {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+func (x *Credentials) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Credentials) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Credentials) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentityRequest) Reset() {
+	*x = GetIdentityRequest{}
+	mi := &file_identity_v1_identity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityRequest) ProtoMessage() {}
+
+func (x *GetIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityRequest.ProtoReflect.Descriptor instead.
+func (*GetIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetIdentityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *Identity              `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentityResponse) Reset() {
+	*x = GetIdentityResponse{}
+	mi := &file_identity_v1_identity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityResponse) ProtoMessage() {}
+
+func (x *GetIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityResponse.ProtoReflect.Descriptor instead.
+func (*GetIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetIdentityResponse) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+type ListIdentitiesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// IDs only lists the identities with these IDs.
+	Ids []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	// OrganizationID only lists the identities of this organization.
+	OrganizationId string `protobuf:"bytes,4,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// CredentialsIdentifier only lists the identity with this credentials
+	// identifier, for example an email address.
+	CredentialsIdentifier string `protobuf:"bytes,5,opt,name=credentials_identifier,json=credentialsIdentifier,proto3" json:"credentials_identifier,omitempty"`
+	// IncludePII includes the traits classified as personally identifiable
+	// information. They are redacted otherwise.
+	IncludePii    bool `protobuf:"varint,6,opt,name=include_pii,json=includePii,proto3" json:"include_pii,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesRequest) Reset() {
+	*x = ListIdentitiesRequest{}
+	mi := &file_identity_v1_identity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesRequest) ProtoMessage() {}
+
+func (x *ListIdentitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListIdentitiesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListIdentitiesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListIdentitiesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListIdentitiesRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ListIdentitiesRequest) GetCredentialsIdentifier() string {
+	if x != nil {
+		return x.CredentialsIdentifier
+	}
+	return ""
+}
+
+func (x *ListIdentitiesRequest) GetIncludePii() bool {
+	if x != nil {
+		return x.IncludePii
+	}
+	return false
+}
+
+type ListIdentitiesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identities []*Identity            `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesResponse) Reset() {
+	*x = ListIdentitiesResponse{}
+	mi := &file_identity_v1_identity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesResponse) ProtoMessage() {}
+
+func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListIdentitiesResponse) GetIdentities() []*Identity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+func (x *ListIdentitiesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PasswordCredentials struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Password is the password in plain text. It is hashed before it is stored.
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// HashedPassword is a password hash in PHC format.
+	HashedPassword string `protobuf:"bytes,2,opt,name=hashed_password,json=hashedPassword,proto3" json:"hashed_password,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PasswordCredentials) Reset() {
+	*x = PasswordCredentials{}
+	mi := &file_identity_v1_identity_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordCredentials) ProtoMessage() {}
+
+func (x *PasswordCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordCredentials.ProtoReflect.Descriptor instead.
+func (*PasswordCredentials) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PasswordCredentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *PasswordCredentials) GetHashedPassword() string {
+	if x != nil {
+		return x.HashedPassword
+	}
+	return ""
+}
+
+type CreateIdentityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SchemaId       string                 `protobuf:"bytes,1,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	Traits         *structpb.Struct       `protobuf:"bytes,2,opt,name=traits,proto3" json:"traits,omitempty"`
+	State          IdentityState          `protobuf:"varint,3,opt,name=state,proto3,enum=identity.v1.IdentityState" json:"state,omitempty"`
+	MetadataPublic *structpb.Value        `protobuf:"bytes,4,opt,name=metadata_public,json=metadataPublic,proto3" json:"metadata_public,omitempty"`
+	MetadataAdmin  *structpb.Value        `protobuf:"bytes,5,opt,name=metadata_admin,json=metadataAdmin,proto3" json:"metadata_admin,omitempty"`
+	OrganizationId *string                `protobuf:"bytes,6,opt,name=organization_id,json=organizationId,proto3,oneof" json:"organization_id,omitempty"`
+	ExternalId     *string                `protobuf:"bytes,7,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	Password       *PasswordCredentials   `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateIdentityRequest) Reset() {
+	*x = CreateIdentityRequest{}
+	mi := &file_identity_v1_identity_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIdentityRequest) ProtoMessage() {}
+
+func (x *CreateIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIdentityRequest.ProtoReflect.Descriptor instead.
+func (*CreateIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateIdentityRequest) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *CreateIdentityRequest) GetTraits() *structpb.Struct {
+	if x != nil {
+		return x.Traits
+	}
+	return nil
+}
+
+func (x *CreateIdentityRequest) GetState() IdentityState {
+	if x != nil {
+		return x.State
+	}
+	return IdentityState_IDENTITY_STATE_UNSPECIFIED
+}
+
+func (x *CreateIdentityRequest) GetMetadataPublic() *structpb.Value {
+	if x != nil {
+		return x.MetadataPublic
+	}
+	return nil
+}
+
+func (x *CreateIdentityRequest) GetMetadataAdmin() *structpb.Value {
+	if x != nil {
+		return x.MetadataAdmin
+	}
+	return nil
+}
+
+func (x *CreateIdentityRequest) GetOrganizationId() string {
+	if x != nil && x.OrganizationId != nil {
+		return *x.OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateIdentityRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateIdentityRequest) GetPassword() *PasswordCredentials {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+type CreateIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *Identity              `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIdentityResponse) Reset() {
+	*x = CreateIdentityResponse{}
+	mi := &file_identity_v1_identity_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIdentityResponse) ProtoMessage() {}
+
+func (x *CreateIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIdentityResponse.ProtoReflect.Descriptor instead.
+func (*CreateIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateIdentityResponse) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+type UpdateIdentityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SchemaId       string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	Traits         *structpb.Struct       `protobuf:"bytes,3,opt,name=traits,proto3" json:"traits,omitempty"`
+	State          IdentityState          `protobuf:"varint,4,opt,name=state,proto3,enum=identity.v1.IdentityState" json:"state,omitempty"`
+	MetadataPublic *structpb.Value        `protobuf:"bytes,5,opt,name=metadata_public,json=metadataPublic,proto3" json:"metadata_public,omitempty"`
+	MetadataAdmin  *structpb.Value        `protobuf:"bytes,6,opt,name=metadata_admin,json=metadataAdmin,proto3" json:"metadata_admin,omitempty"`
+	ExternalId     *string                `protobuf:"bytes,7,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	Password       *PasswordCredentials   `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateIdentityRequest) Reset() {
+	*x = UpdateIdentityRequest{}
+	mi := &file_identity_v1_identity_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIdentityRequest) ProtoMessage() {}
+
+func (x *UpdateIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateIdentityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateIdentityRequest) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *UpdateIdentityRequest) GetTraits() *structpb.Struct {
+	if x != nil {
+		return x.Traits
+	}
+	return nil
+}
+
+func (x *UpdateIdentityRequest) GetState() IdentityState {
+	if x != nil {
+		return x.State
+	}
+	return IdentityState_IDENTITY_STATE_UNSPECIFIED
+}
+
+func (x *UpdateIdentityRequest) GetMetadataPublic() *structpb.Value {
+	if x != nil {
+		return x.MetadataPublic
+	}
+	return nil
+}
+
+func (x *UpdateIdentityRequest) GetMetadataAdmin() *structpb.Value {
+	if x != nil {
+		return x.MetadataAdmin
+	}
+	return nil
+}
+
+func (x *UpdateIdentityRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *UpdateIdentityRequest) GetPassword() *PasswordCredentials {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+type UpdateIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *Identity              `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIdentityResponse) Reset() {
+	*x = UpdateIdentityResponse{}
+	mi := &file_identity_v1_identity_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIdentityResponse) ProtoMessage() {}
+
+func (x *UpdateIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateIdentityResponse) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+type DeleteIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIdentityRequest) Reset() {
+	*x = DeleteIdentityRequest{}
+	mi := &file_identity_v1_identity_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIdentityRequest) ProtoMessage() {}
+
+func (x *DeleteIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIdentityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteIdentityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIdentityResponse) Reset() {
+	*x = DeleteIdentityResponse{}
+	mi := &file_identity_v1_identity_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIdentityResponse) ProtoMessage() {}
+
+func (x *DeleteIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_identity_v1_identity_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIdentityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_identity_v1_identity_proto_rawDescGZIP(), []int{14}
+}
+
+var File_identity_v1_identity_proto protoreflect.FileDescriptor
+
+const file_identity_v1_identity_proto_rawDesc = "" +
+	"\n" +
+	"\x1aidentity/v1/identity.proto\x12\videntity.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x06\n" +
+	"\bIdentity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\x12\x1d\n" +
+	"\n" +
+	"schema_url\x18\x03 \x01(\tR\tschemaUrl\x120\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1a.identity.v1.IdentityStateR\x05state\x12D\n" +
+	"\x10state_changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0estateChangedAt\x12/\n" +
+	"\x06traits\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06traits\x12Q\n" +
+	"\x14verifiable_addresses\x18\a \x03(\v2\x1e.identity.v1.VerifiableAddressR\x13verifiableAddresses\x12K\n" +
+	"\x12recovery_addresses\x18\b \x03(\v2\x1c.identity.v1.RecoveryAddressR\x11recoveryAddresses\x12?\n" +
+	"\x0fmetadata_public\x18\t \x01(\v2\x16.google.protobuf.ValueR\x0emetadataPublic\x12=\n" +
+	"\x0emetadata_admin\x18\n" +
+	" \x01(\v2\x16.google.protobuf.ValueR\rmetadataAdmin\x12,\n" +
+	"\x0forganization_id\x18\v \x01(\tH\x00R\x0eorganizationId\x88\x01\x01\x12$\n" +
+	"\vexternal_id\x18\f \x01(\tH\x01R\n" +
+	"externalId\x88\x01\x01\x12:\n" +
+	"\vcredentials\x18\r \x03(\v2\x18.identity.v1.CredentialsR\vcredentials\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x12\n" +
+	"\x10_organization_idB\x0e\n" +
+	"\f_external_id\"\xb2\x02\n" +
+	"\x11VerifiableAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x1a\n" +
+	"\bverified\x18\x03 \x01(\bR\bverified\x12\x10\n" +
+	"\x03via\x18\x04 \x01(\tR\x03via\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12;\n" +
+	"\vverified_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbf\x01\n" +
+	"\x0fRecoveryAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x10\n" +
+	"\x03via\x18\x03 \x01(\tR\x03via\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd3\x01\n" +
+	"\vCredentials\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\videntifiers\x18\x02 \x03(\tR\videntifiers\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"$\n" +
+	"\x12GetIdentityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x13GetIdentityResponse\x121\n" +
+	"\bidentity\x18\x01 \x01(\v2\x15.identity.v1.IdentityR\bidentity\"\xe6\x01\n" +
+	"\x15ListIdentitiesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\x12'\n" +
+	"\x0forganization_id\x18\x04 \x01(\tR\x0eorganizationId\x125\n" +
+	"\x16credentials_identifier\x18\x05 \x01(\tR\x15credentialsIdentifier\x12\x1f\n" +
+	"\vinclude_pii\x18\x06 \x01(\bR\n" +
+	"includePii\"w\n" +
+	"\x16ListIdentitiesResponse\x125\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x15.identity.v1.IdentityR\n" +
+	"identities\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"Z\n" +
+	"\x13PasswordCredentials\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\x12'\n" +
+	"\x0fhashed_password\x18\x02 \x01(\tR\x0ehashedPassword\"\xcd\x03\n" +
+	"\x15CreateIdentityRequest\x12\x1b\n" +
+	"\tschema_id\x18\x01 \x01(\tR\bschemaId\x12/\n" +
+	"\x06traits\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06traits\x120\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1a.identity.v1.IdentityStateR\x05state\x12?\n" +
+	"\x0fmetadata_public\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x0emetadataPublic\x12=\n" +
+	"\x0emetadata_admin\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\rmetadataAdmin\x12,\n" +
+	"\x0forganization_id\x18\x06 \x01(\tH\x00R\x0eorganizationId\x88\x01\x01\x12$\n" +
+	"\vexternal_id\x18\a \x01(\tH\x01R\n" +
+	"externalId\x88\x01\x01\x12<\n" +
+	"\bpassword\x18\b \x01(\v2 .identity.v1.PasswordCredentialsR\bpasswordB\x12\n" +
+	"\x10_organization_idB\x0e\n" +
+	"\f_external_id\"K\n" +
+	"\x16CreateIdentityResponse\x121\n" +
+	"\bidentity\x18\x01 \x01(\v2\x15.identity.v1.IdentityR\bidentity\"\x9b\x03\n" +
+	"\x15UpdateIdentityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\x12/\n" +
+	"\x06traits\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06traits\x120\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1a.identity.v1.IdentityStateR\x05state\x12?\n" +
+	"\x0fmetadata_public\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\x0emetadataPublic\x12=\n" +
+	"\x0emetadata_admin\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\rmetadataAdmin\x12$\n" +
+	"\vexternal_id\x18\a \x01(\tH\x00R\n" +
+	"externalId\x88\x01\x01\x12<\n" +
+	"\bpassword\x18\b \x01(\v2 .identity.v1.PasswordCredentialsR\bpasswordB\x0e\n" +
+	"\f_external_id\"K\n" +
+	"\x16UpdateIdentityResponse\x121\n" +
+	"\bidentity\x18\x01 \x01(\v2\x15.identity.v1.IdentityR\bidentity\"'\n" +
+	"\x15DeleteIdentityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteIdentityResponse*\x8c\x01\n" +
+	"\rIdentityState\x12\x1e\n" +
+	"\x1aIDENTITY_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IDENTITY_STATE_ACTIVE\x10\x01\x12\x1b\n" +
+	"\x17IDENTITY_STATE_INACTIVE\x10\x02\x12#\n" +
+	"\x1fIDENTITY_STATE_PENDING_APPROVAL\x10\x032\xeb\x04\n" +
+	"\x0fIdentityService\x12p\n" +
+	"\vGetIdentity\x12\x1f.identity.v1.GetIdentityRequest\x1a .identity.v1.GetIdentityResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/admin/identities/{id}\x12t\n" +
+	"\x0eListIdentities\x12\".identity.v1.ListIdentitiesRequest\x1a#.identity.v1.ListIdentitiesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/admin/identities\x12w\n" +
+	"\x0eCreateIdentity\x12\".identity.v1.CreateIdentityRequest\x1a#.identity.v1.CreateIdentityResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/admin/identities\x12|\n" +
+	"\x0eUpdateIdentity\x12\".identity.v1.UpdateIdentityRequest\x1a#.identity.v1.UpdateIdentityResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/admin/identities/{id}\x12y\n" +
+	"\x0eDeleteIdentity\x12\".identity.v1.DeleteIdentityRequest\x1a#.identity.v1.DeleteIdentityResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/admin/identities/{id}B\x9f\x01\n" +
+	"\x0fcom.identity.v1B\rIdentityProtoP\x01Z0github.com/ory/kratos/gen/identity/v1;identityv1\xa2\x02\x03IXX\xaa\x02\vIdentity.V1\xca\x02\vIdentity\\V1\xe2\x02\x17Identity\\V1\\GPBMetadata\xea\x02\fIdentity::V1b\x06proto3"
+
+var (
+	file_identity_v1_identity_proto_rawDescOnce sync.Once
+	file_identity_v1_identity_proto_rawDescData []byte
+)
+
+func file_identity_v1_identity_proto_rawDescGZIP() []byte {
+	file_identity_v1_identity_proto_rawDescOnce.Do(func() {
+		file_identity_v1_identity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_identity_v1_identity_proto_rawDesc), len(file_identity_v1_identity_proto_rawDesc)))
+	})
+	return file_identity_v1_identity_proto_rawDescData
+}
+
+var file_identity_v1_identity_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_identity_v1_identity_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_identity_v1_identity_proto_goTypes = []any{
+	(IdentityState)(0),             // 0: identity.v1.IdentityState
+	(*Identity)(nil),               // 1: identity.v1.Identity
+	(*VerifiableAddress)(nil),      // 2: identity.v1.VerifiableAddress
+	(*RecoveryAddress)(nil),        // 3: identity.v1.RecoveryAddress
+	(*Credentials)(nil),            // 4: identity.v1.Credentials
+	(*GetIdentityRequest)(nil),     // 5: identity.v1.GetIdentityRequest
+	(*GetIdentityResponse)(nil),    // 6: identity.v1.GetIdentityResponse
+	(*ListIdentitiesRequest)(nil),  // 7: identity.v1.ListIdentitiesRequest
+	(*ListIdentitiesResponse)(nil), // 8: identity.v1.ListIdentitiesResponse
+	(*PasswordCredentials)(nil),    // 9: identity.v1.PasswordCredentials
+	(*CreateIdentityRequest)(nil),  // 10: identity.v1.CreateIdentityRequest
+	(*CreateIdentityResponse)(nil), // 11: identity.v1.CreateIdentityResponse
+	(*UpdateIdentityRequest)(nil),  // 12: identity.v1.UpdateIdentityRequest
+	(*UpdateIdentityResponse)(nil), // 13: identity.v1.UpdateIdentityResponse
+	(*DeleteIdentityRequest)(nil),  // 14: identity.v1.DeleteIdentityRequest
+	(*DeleteIdentityResponse)(nil), // 15: identity.v1.DeleteIdentityResponse
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),        // 17: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 18: google.protobuf.Value
+}
+var file_identity_v1_identity_proto_depIdxs = []int32{
+	0,  // 0: identity.v1.Identity.state:type_name -> identity.v1.IdentityState
+	16, // 1: identity.v1.Identity.state_changed_at:type_name -> google.protobuf.Timestamp
+	17, // 2: identity.v1.Identity.traits:type_name -> google.protobuf.Struct
+	2,  // 3: identity.v1.Identity.verifiable_addresses:type_name -> identity.v1.VerifiableAddress
+	3,  // 4: identity.v1.Identity.recovery_addresses:type_name -> identity.v1.RecoveryAddress
+	18, // 5: identity.v1.Identity.metadata_public:type_name -> google.protobuf.Value
+	18, // 6: identity.v1.Identity.metadata_admin:type_name -> google.protobuf.Value
+	4,  // 7: identity.v1.Identity.credentials:type_name -> identity.v1.Credentials
+	16, // 8: identity.v1.Identity.created_at:type_name -> google.protobuf.Timestamp
+	16, // 9: identity.v1.Identity.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 10: identity.v1.VerifiableAddress.verified_at:type_name -> google.protobuf.Timestamp
+	16, // 11: identity.v1.VerifiableAddress.created_at:type_name -> google.protobuf.Timestamp
+	16, // 12: identity.v1.VerifiableAddress.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 13: identity.v1.RecoveryAddress.created_at:type_name -> google.protobuf.Timestamp
+	16, // 14: identity.v1.RecoveryAddress.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 15: identity.v1.Credentials.created_at:type_name -> google.protobuf.Timestamp
+	16, // 16: identity.v1.Credentials.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 17: identity.v1.GetIdentityResponse.identity:type_name -> identity.v1.Identity
+	1,  // 18: identity.v1.ListIdentitiesResponse.identities:type_name -> identity.v1.Identity
+	17, // 19: identity.v1.CreateIdentityRequest.traits:type_name -> google.protobuf.Struct
+	0,  // 20: identity.v1.CreateIdentityRequest.state:type_name -> identity.v1.IdentityState
+	18, // 21: identity.v1.CreateIdentityRequest.metadata_public:type_name -> google.protobuf.Value
+	18, // 22: identity.v1.CreateIdentityRequest.metadata_admin:type_name -> google.protobuf.Value
+	9,  // 23: identity.v1.CreateIdentityRequest.password:type_name -> identity.v1.PasswordCredentials
+	1,  // 24: identity.v1.CreateIdentityResponse.identity:type_name -> identity.v1.Identity
+	17, // 25: identity.v1.UpdateIdentityRequest.traits:type_name -> google.protobuf.Struct
+	0,  // 26: identity.v1.UpdateIdentityRequest.state:type_name -> identity.v1.IdentityState
+	18, // 27: identity.v1.UpdateIdentityRequest.metadata_public:type_name -> google.protobuf.Value
+	18, // 28: identity.v1.UpdateIdentityRequest.metadata_admin:type_name -> google.protobuf.Value
+	9,  // 29: identity.v1.UpdateIdentityRequest.password:type_name -> identity.v1.PasswordCredentials
+	1,  // 30: identity.v1.UpdateIdentityResponse.identity:type_name -> identity.v1.Identity
+	5,  // 31: identity.v1.IdentityService.GetIdentity:input_type -> identity.v1.GetIdentityRequest
+	7,  // 32: identity.v1.IdentityService.ListIdentities:input_type -> identity.v1.ListIdentitiesRequest
+	10, // 33: identity.v1.IdentityService.CreateIdentity:input_type -> identity.v1.CreateIdentityRequest
+	12, // 34: identity.v1.IdentityService.UpdateIdentity:input_type -> identity.v1.UpdateIdentityRequest
+	14, // 35: identity.v1.IdentityService.DeleteIdentity:input_type -> identity.v1.DeleteIdentityRequest
+	6,  // 36: identity.v1.IdentityService.GetIdentity:output_type -> identity.v1.GetIdentityResponse
+	8,  // 37: identity.v1.IdentityService.ListIdentities:output_type -> identity.v1.ListIdentitiesResponse
+	11, // 38: identity.v1.IdentityService.CreateIdentity:output_type -> identity.v1.CreateIdentityResponse
+	13, // 39: identity.v1.IdentityService.UpdateIdentity:output_type -> identity.v1.UpdateIdentityResponse
+	15, // 40: identity.v1.IdentityService.DeleteIdentity:output_type -> identity.v1.DeleteIdentityResponse
+	36, // [36:41] is the sub-list for method output_type
+	31, // [31:36] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
+}
+
+func init() { file_identity_v1_identity_proto_init() }
+func file_identity_v1_identity_proto_init() {
+	if File_identity_v1_identity_proto != nil {
+		return
+	}
+	file_identity_v1_identity_proto_msgTypes[0].OneofWrappers = []any{}
+	file_identity_v1_identity_proto_msgTypes[9].OneofWrappers = []any{}
+	file_identity_v1_identity_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_identity_v1_identity_proto_rawDesc), len(file_identity_v1_identity_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_identity_v1_identity_proto_goTypes,
+		DependencyIndexes: file_identity_v1_identity_proto_depIdxs,
+		EnumInfos:         file_identity_v1_identity_proto_enumTypes,
+		MessageInfos:      file_identity_v1_identity_proto_msgTypes,
+	}.Build()
+	File_identity_v1_identity_proto = out.File
+	file_identity_v1_identity_proto_goTypes = nil
+	file_identity_v1_identity_proto_depIdxs = nil
+}
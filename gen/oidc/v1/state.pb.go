@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.10
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: oidc/v1/state.proto
 
@@ -166,9 +166,9 @@ const file_oidc_v1_state_proto_rawDesc = "" +
 	"\x15FLOW_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fFLOW_KIND_LOGIN\x10\x01\x12\x1a\n" +
 	"\x16FLOW_KIND_REGISTRATION\x10\x02\x12\x16\n" +
-	"\x12FLOW_KIND_SETTINGS\x10\x03B|\n" +
+	"\x12FLOW_KIND_SETTINGS\x10\x03B\x80\x01\n" +
 	"\vcom.oidc.v1B\n" +
-	"StateProtoP\x01Z$github.com/ory/kratos/oidc/v1;oidcv1\xa2\x02\x03OXX\xaa\x02\aOidc.V1\xca\x02\aOidc\\V1\xe2\x02\x13Oidc\\V1\\GPBMetadata\xea\x02\bOidc::V1b\x06proto3"
+	"StateProtoP\x01Z(github.com/ory/kratos/gen/oidc/v1;oidcv1\xa2\x02\x03OXX\xaa\x02\aOidc.V1\xca\x02\aOidc\\V1\xe2\x02\x13Oidc\\V1\\GPBMetadata\xea\x02\bOidc::V1b\x06proto3"
 
 var (
 	file_oidc_v1_state_proto_rawDescOnce sync.Once
@@ -0,0 +1,772 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: session/v1/session.proto
+
+package sessionv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	v1 "github.com/ory/kratos/gen/identity/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuthenticatorAssuranceLevel int32
+
+const (
+	AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED AuthenticatorAssuranceLevel = 0
+	AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL0        AuthenticatorAssuranceLevel = 1
+	AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL1        AuthenticatorAssuranceLevel = 2
+	AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL2        AuthenticatorAssuranceLevel = 3
+)
+
+// Enum value maps for AuthenticatorAssuranceLevel.
+var (
+	AuthenticatorAssuranceLevel_name = map[int32]string{
+		0: "AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED",
+		1: "AUTHENTICATOR_ASSURANCE_LEVEL_AAL0",
+		2: "AUTHENTICATOR_ASSURANCE_LEVEL_AAL1",
+		3: "AUTHENTICATOR_ASSURANCE_LEVEL_AAL2",
+	}
+	AuthenticatorAssuranceLevel_value = map[string]int32{
+		"AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED": 0,
+		"AUTHENTICATOR_ASSURANCE_LEVEL_AAL0":        1,
+		"AUTHENTICATOR_ASSURANCE_LEVEL_AAL1":        2,
+		"AUTHENTICATOR_ASSURANCE_LEVEL_AAL2":        3,
+	}
+)
+
+func (x AuthenticatorAssuranceLevel) Enum() *AuthenticatorAssuranceLevel {
+	p := new(AuthenticatorAssuranceLevel)
+	*p = x
+	return p
+}
+
+func (x AuthenticatorAssuranceLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthenticatorAssuranceLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_session_v1_session_proto_enumTypes[0].Descriptor()
+}
+
+func (AuthenticatorAssuranceLevel) Type() protoreflect.EnumType {
+	return &file_session_v1_session_proto_enumTypes[0]
+}
+
+func (x AuthenticatorAssuranceLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthenticatorAssuranceLevel.Descriptor instead.
+func (AuthenticatorAssuranceLevel) EnumDescriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{0}
+}
+
+type Session struct {
+	state                       protoimpl.MessageState      `protogen:"open.v1"`
+	Id                          string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active                      bool                        `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	ExpiresAt                   *timestamppb.Timestamp      `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	AuthenticatedAt             *timestamppb.Timestamp      `protobuf:"bytes,4,opt,name=authenticated_at,json=authenticatedAt,proto3" json:"authenticated_at,omitempty"`
+	IssuedAt                    *timestamppb.Timestamp      `protobuf:"bytes,5,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	AuthenticatorAssuranceLevel AuthenticatorAssuranceLevel `protobuf:"varint,6,opt,name=authenticator_assurance_level,json=authenticatorAssuranceLevel,proto3,enum=session.v1.AuthenticatorAssuranceLevel" json:"authenticator_assurance_level,omitempty"`
+	AuthenticationMethods       []*AuthenticationMethod     `protobuf:"bytes,7,rep,name=authentication_methods,json=authenticationMethods,proto3" json:"authentication_methods,omitempty"`
+	Identity                    *v1.Identity                `protobuf:"bytes,8,opt,name=identity,proto3" json:"identity,omitempty"`
+	Devices                     []*Device                   `protobuf:"bytes,9,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_session_v1_session_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetAuthenticatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthenticatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Session) GetAuthenticatorAssuranceLevel() AuthenticatorAssuranceLevel {
+	if x != nil {
+		return x.AuthenticatorAssuranceLevel
+	}
+	return AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED
+}
+
+func (x *Session) GetAuthenticationMethods() []*AuthenticationMethod {
+	if x != nil {
+		return x.AuthenticationMethods
+	}
+	return nil
+}
+
+func (x *Session) GetIdentity() *v1.Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *Session) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type AuthenticationMethod struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Method        string                      `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Aal           AuthenticatorAssuranceLevel `protobuf:"varint,2,opt,name=aal,proto3,enum=session.v1.AuthenticatorAssuranceLevel" json:"aal,omitempty"`
+	CompletedAt   *timestamppb.Timestamp      `protobuf:"bytes,3,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Provider      string                      `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Organization  string                      `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticationMethod) Reset() {
+	*x = AuthenticationMethod{}
+	mi := &file_session_v1_session_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticationMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticationMethod) ProtoMessage() {}
+
+func (x *AuthenticationMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticationMethod.ProtoReflect.Descriptor instead.
+func (*AuthenticationMethod) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthenticationMethod) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuthenticationMethod) GetAal() AuthenticatorAssuranceLevel {
+	if x != nil {
+		return x.Aal
+	}
+	return AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED
+}
+
+func (x *AuthenticationMethod) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *AuthenticationMethod) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *AuthenticationMethod) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_session_v1_session_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Device) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Device) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type WhoAmIRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SessionToken is the session token of a native app.
+	SessionToken string `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	// Cookie is the cookie header of a browser request.
+	Cookie        string `protobuf:"bytes,2,opt,name=cookie,proto3" json:"cookie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIRequest) Reset() {
+	*x = WhoAmIRequest{}
+	mi := &file_session_v1_session_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIRequest) ProtoMessage() {}
+
+func (x *WhoAmIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoAmIRequest.ProtoReflect.Descriptor instead.
+func (*WhoAmIRequest) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WhoAmIRequest) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *WhoAmIRequest) GetCookie() string {
+	if x != nil {
+		return x.Cookie
+	}
+	return ""
+}
+
+type WhoAmIResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIResponse) Reset() {
+	*x = WhoAmIResponse{}
+	mi := &file_session_v1_session_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIResponse) ProtoMessage() {}
+
+func (x *WhoAmIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoAmIResponse.ProtoReflect.Descriptor instead.
+func (*WhoAmIResponse) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WhoAmIResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type ListSessionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Active only lists active or inactive sessions.
+	Active *bool `protobuf:"varint,3,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	// Expand includes related objects. Supported values are `identity` and
+	// `devices`.
+	Expand        []string `protobuf:"bytes,4,rep,name=expand,proto3" json:"expand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_session_v1_session_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSessionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSessionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSessionsRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+func (x *ListSessionsRequest) GetExpand() []string {
+	if x != nil {
+		return x.Expand
+	}
+	return nil
+}
+
+type ListSessionsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sessions []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_session_v1_session_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListSessionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_session_v1_session_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_session_v1_session_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_session_v1_session_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_session_v1_session_proto_rawDescGZIP(), []int{8}
+}
+
+var File_session_v1_session_proto protoreflect.FileDescriptor
+
+const file_session_v1_session_proto_rawDesc = "" +
+	"\n" +
+	"\x18session/v1/session.proto\x12\n" +
+	"session.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1aidentity/v1/identity.proto\"\x93\x04\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12E\n" +
+	"\x10authenticated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fauthenticatedAt\x127\n" +
+	"\tissued_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x12k\n" +
+	"\x1dauthenticator_assurance_level\x18\x06 \x01(\x0e2'.session.v1.AuthenticatorAssuranceLevelR\x1bauthenticatorAssuranceLevel\x12W\n" +
+	"\x16authentication_methods\x18\a \x03(\v2 .session.v1.AuthenticationMethodR\x15authenticationMethods\x121\n" +
+	"\bidentity\x18\b \x01(\v2\x15.identity.v1.IdentityR\bidentity\x12,\n" +
+	"\adevices\x18\t \x03(\v2\x12.session.v1.DeviceR\adevices\"\xe8\x01\n" +
+	"\x14AuthenticationMethod\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x129\n" +
+	"\x03aal\x18\x02 \x01(\x0e2'.session.v1.AuthenticatorAssuranceLevelR\x03aal\x12=\n" +
+	"\fcompleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\"r\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\"L\n" +
+	"\rWhoAmIRequest\x12#\n" +
+	"\rsession_token\x18\x01 \x01(\tR\fsessionToken\x12\x16\n" +
+	"\x06cookie\x18\x02 \x01(\tR\x06cookie\"?\n" +
+	"\x0eWhoAmIResponse\x12-\n" +
+	"\asession\x18\x01 \x01(\v2\x13.session.v1.SessionR\asession\"\x91\x01\n" +
+	"\x13ListSessionsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\x06active\x18\x03 \x01(\bH\x00R\x06active\x88\x01\x01\x12\x16\n" +
+	"\x06expand\x18\x04 \x03(\tR\x06expandB\t\n" +
+	"\a_active\"o\n" +
+	"\x14ListSessionsResponse\x12/\n" +
+	"\bsessions\x18\x01 \x03(\v2\x13.session.v1.SessionR\bsessions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"&\n" +
+	"\x14RevokeSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15RevokeSessionResponse*\xc4\x01\n" +
+	"\x1bAuthenticatorAssuranceLevel\x12-\n" +
+	")AUTHENTICATOR_ASSURANCE_LEVEL_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"AUTHENTICATOR_ASSURANCE_LEVEL_AAL0\x10\x01\x12&\n" +
+	"\"AUTHENTICATOR_ASSURANCE_LEVEL_AAL1\x10\x02\x12&\n" +
+	"\"AUTHENTICATOR_ASSURANCE_LEVEL_AAL2\x10\x032\xd4\x02\n" +
+	"\x0eSessionService\x12b\n" +
+	"\x06WhoAmI\x12\x19.session.v1.WhoAmIRequest\x1a\x1a.session.v1.WhoAmIResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/admin/sessions/whoami\x12j\n" +
+	"\fListSessions\x12\x1f.session.v1.ListSessionsRequest\x1a .session.v1.ListSessionsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/admin/sessions\x12r\n" +
+	"\rRevokeSession\x12 .session.v1.RevokeSessionRequest\x1a!.session.v1.RevokeSessionResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/admin/sessions/{id}B\x97\x01\n" +
+	"\x0ecom.session.v1B\fSessionProtoP\x01Z.github.com/ory/kratos/gen/session/v1;sessionv1\xa2\x02\x03SXX\xaa\x02\n" +
+	"Session.V1\xca\x02\n" +
+	"Session\\V1\xe2\x02\x16Session\\V1\\GPBMetadata\xea\x02\vSession::V1b\x06proto3"
+
+var (
+	file_session_v1_session_proto_rawDescOnce sync.Once
+	file_session_v1_session_proto_rawDescData []byte
+)
+
+func file_session_v1_session_proto_rawDescGZIP() []byte {
+	file_session_v1_session_proto_rawDescOnce.Do(func() {
+		file_session_v1_session_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_session_v1_session_proto_rawDesc), len(file_session_v1_session_proto_rawDesc)))
+	})
+	return file_session_v1_session_proto_rawDescData
+}
+
+var file_session_v1_session_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_session_v1_session_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_session_v1_session_proto_goTypes = []any{
+	(AuthenticatorAssuranceLevel)(0), // 0: session.v1.AuthenticatorAssuranceLevel
+	(*Session)(nil),                  // 1: session.v1.Session
+	(*AuthenticationMethod)(nil),     // 2: session.v1.AuthenticationMethod
+	(*Device)(nil),                   // 3: session.v1.Device
+	(*WhoAmIRequest)(nil),            // 4: session.v1.WhoAmIRequest
+	(*WhoAmIResponse)(nil),           // 5: session.v1.WhoAmIResponse
+	(*ListSessionsRequest)(nil),      // 6: session.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),     // 7: session.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),     // 8: session.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),    // 9: session.v1.RevokeSessionResponse
+	(*timestamppb.Timestamp)(nil),    // 10: google.protobuf.Timestamp
+	(*v1.Identity)(nil),              // 11: identity.v1.Identity
+}
+var file_session_v1_session_proto_depIdxs = []int32{
+	10, // 0: session.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	10, // 1: session.v1.Session.authenticated_at:type_name -> google.protobuf.Timestamp
+	10, // 2: session.v1.Session.issued_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: session.v1.Session.authenticator_assurance_level:type_name -> session.v1.AuthenticatorAssuranceLevel
+	2,  // 4: session.v1.Session.authentication_methods:type_name -> session.v1.AuthenticationMethod
+	11, // 5: session.v1.Session.identity:type_name -> identity.v1.Identity
+	3,  // 6: session.v1.Session.devices:type_name -> session.v1.Device
+	0,  // 7: session.v1.AuthenticationMethod.aal:type_name -> session.v1.AuthenticatorAssuranceLevel
+	10, // 8: session.v1.AuthenticationMethod.completed_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: session.v1.WhoAmIResponse.session:type_name -> session.v1.Session
+	1,  // 10: session.v1.ListSessionsResponse.sessions:type_name -> session.v1.Session
+	4,  // 11: session.v1.SessionService.WhoAmI:input_type -> session.v1.WhoAmIRequest
+	6,  // 12: session.v1.SessionService.ListSessions:input_type -> session.v1.ListSessionsRequest
+	8,  // 13: session.v1.SessionService.RevokeSession:input_type -> session.v1.RevokeSessionRequest
+	5,  // 14: session.v1.SessionService.WhoAmI:output_type -> session.v1.WhoAmIResponse
+	7,  // 15: session.v1.SessionService.ListSessions:output_type -> session.v1.ListSessionsResponse
+	9,  // 16: session.v1.SessionService.RevokeSession:output_type -> session.v1.RevokeSessionResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_session_v1_session_proto_init() }
+func file_session_v1_session_proto_init() {
+	if File_session_v1_session_proto != nil {
+		return
+	}
+	file_session_v1_session_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_session_v1_session_proto_rawDesc), len(file_session_v1_session_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_session_v1_session_proto_goTypes,
+		DependencyIndexes: file_session_v1_session_proto_depIdxs,
+		EnumInfos:         file_session_v1_session_proto_enumTypes,
+		MessageInfos:      file_session_v1_session_proto_msgTypes,
+	}.Build()
+	File_session_v1_session_proto = out.File
+	file_session_v1_session_proto_goTypes = nil
+	file_session_v1_session_proto_depIdxs = nil
+}
@@ -0,0 +1,403 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/x/jsonschemax"
+)
+
+const (
+	// EncryptedTraitPrefix is prepended to the ciphertext of encrypted trait
+	// values. Values without this prefix are treated as plaintext, which allows
+	// enabling encryption for existing identities without a data migration.
+	EncryptedTraitPrefix = "kratos:enc:v1:"
+
+	// RedactedTraitValue replaces the value of traits which are classified as
+	// personally identifiable information (PII) when they are redacted.
+	RedactedTraitValue = "[redacted]"
+
+	classificationCacheTTL = 5 * time.Minute
+)
+
+type (
+	// TraitsClassification lists the traits which the identity schema marks as
+	// encrypted at rest or as personally identifiable information (PII) using
+	// the `ory.sh/kratos` extension keyword `privacy`, the traits which must be
+	// unique using the keyword `unique`, and the optional traits users are
+	// asked for once after signing in using the keyword `profile`.
+	//
+	// Paths are relative to the identity's traits. Array elements are denoted
+	// by `#`.
+	TraitsClassification struct {
+		Encrypted [][]string
+		PII       [][]string
+		Unique    []UniqueTraitPath
+		AskOnce   [][]string
+	}
+
+	traitsClassifierDependencies interface {
+		schema.IdentitySchemaProvider
+		config.Provider
+		cipher.Provider
+	}
+	TraitsClassifier struct {
+		d     traitsClassifierDependencies
+		cache *ristretto.Cache[string, *TraitsClassification]
+	}
+	TraitsClassifierProvider interface {
+		IdentityTraitsClassifier() *TraitsClassifier
+	}
+)
+
+func NewTraitsClassifier(d traitsClassifierDependencies) *TraitsClassifier {
+	cache, _ := ristretto.NewCache(&ristretto.Config[string, *TraitsClassification]{
+		MaxCost:     1000,
+		NumCounters: 10_000,
+		BufferItems: 64,
+	})
+	return &TraitsClassifier{d: d, cache: cache}
+}
+
+// ClassifyTraitsSchema lists the classified traits of the identity schema
+// located at schemaURL.
+func ClassifyTraitsSchema(ctx context.Context, schemaURL string, disallowRefs bool) (*TraitsClassification, error) {
+	runner, err := schema.NewExtensionRunner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, err := schema.NewCompilerWithURL(ctx, schemaURL, disallowRefs)
+	if err != nil {
+		return nil, err
+	}
+	c.ExtractAnnotations = true
+	runner.Register(c)
+
+	paths, err := jsonschemax.ListPathsWithArraysIncluded(ctx, schemaURL, c)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to parse the identity schema: %s", err))
+	}
+
+	var result TraitsClassification
+	for _, path := range paths {
+		config, ok := path.CustomProperties[schema.ExtensionName].(*schema.ExtensionConfig)
+		if !ok {
+			continue
+		}
+
+		segments, ok := strings.CutPrefix(path.Name, "traits.")
+		if !ok {
+			continue
+		}
+
+		if config.Privacy.Encrypt {
+			result.Encrypted = append(result.Encrypted, strings.Split(segments, "."))
+		}
+		if config.Privacy.PII {
+			result.PII = append(result.PII, strings.Split(segments, "."))
+		}
+		if config.Unique.Scope != "" {
+			result.Unique = append(result.Unique, UniqueTraitPath{
+				Path:  strings.Split(segments, "."),
+				Scope: config.Unique.Scope,
+			})
+		}
+		if config.Profile.AskOnce {
+			result.AskOnce = append(result.AskOnce, strings.Split(segments, "."))
+		}
+	}
+
+	return &result, nil
+}
+
+// Classify returns the classification of the identity schema with the given ID.
+func (c *TraitsClassifier) Classify(ctx context.Context, schemaID string) (*TraitsClassification, error) {
+	ss, err := c.d.IdentityTraitsSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ss.GetByID(schemaID)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf(
+			`The JSON Schema "%s" for this identity's traits could not be found.`, schemaID))
+	}
+
+	key := s.URL.String()
+	if result, ok := c.cache.Get(key); ok {
+		return result, nil
+	}
+
+	result, err := ClassifyTraitsSchema(ctx, key, c.d.Config().SecurityDisallowRefInIdentitySchemas(ctx))
+	if err != nil {
+		return nil, err
+	}
+	c.cache.SetWithTTL(key, result, 1, classificationCacheTTL)
+	return result, nil
+}
+
+// EncryptTraits replaces the values of all traits which are marked for
+// encryption with their ciphertext. The traits must be plaintext, so values
+// which look encrypted are encrypted as well. It returns an error if no cipher
+// is configured.
+func (c *TraitsClassifier) EncryptTraits(ctx context.Context, i *Identity) error {
+	if !hasTraits(i.Traits) {
+		return nil
+	}
+
+	classification, err := c.Classify(ctx, i.SchemaID)
+	if err != nil || len(classification.Encrypted) == 0 {
+		return err
+	}
+
+	if _, ok := c.d.Cipher(ctx).(*cipher.Noop); ok {
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf(
+			`The JSON Schema "%s" marks traits for encryption, but no cipher is configured. Set "ciphers.algorithm" and "secrets.cipher".`, i.SchemaID))
+	}
+
+	traits, err := transformTraits(i.Traits, classification.Encrypted, func(value gjson.Result) (any, bool, error) {
+		if value.Type == gjson.Null {
+			return nil, false, nil
+		}
+		ciphertext, err := c.d.Cipher(ctx).Encrypt(ctx, []byte(value.Raw))
+		if err != nil {
+			return nil, false, err
+		}
+		return EncryptedTraitPrefix + ciphertext, true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.Traits = traits
+	return nil
+}
+
+// DecryptTraits reverses EncryptTraits. Values which are not encrypted are
+// left untouched.
+func (c *TraitsClassifier) DecryptTraits(ctx context.Context, i *Identity) error {
+	if !strings.Contains(string(i.Traits), EncryptedTraitPrefix) {
+		return nil
+	}
+
+	classification, err := c.Classify(ctx, i.SchemaID)
+	if err != nil || len(classification.Encrypted) == 0 {
+		return err
+	}
+
+	traits, err := transformTraits(i.Traits, classification.Encrypted, func(value gjson.Result) (any, bool, error) {
+		ciphertext, ok := strings.CutPrefix(value.Str, EncryptedTraitPrefix)
+		if value.Type != gjson.String || !ok {
+			return nil, false, nil
+		}
+		plaintext, err := c.d.Cipher(ctx).Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, false, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to decrypt identity traits: %s", err))
+		}
+		return rawTraitValue(plaintext), true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.Traits = traits
+	return nil
+}
+
+// RedactTraits replaces the values of all traits which are classified as PII
+// with RedactedTraitValue.
+func (c *TraitsClassifier) RedactTraits(ctx context.Context, i *Identity) error {
+	if !hasTraits(i.Traits) {
+		return nil
+	}
+
+	classification, err := c.Classify(ctx, i.SchemaID)
+	if err != nil || len(classification.PII) == 0 {
+		return err
+	}
+
+	traits, err := classification.Redact(i.Traits)
+	if err != nil {
+		return err
+	}
+
+	i.Traits = traits
+	return nil
+}
+
+// WithRedactedTraits returns a copy of the identity with all PII traits
+// redacted. The original identity is not modified.
+func (c *TraitsClassifier) WithRedactedTraits(ctx context.Context, i *Identity) (*Identity, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	redacted := *i
+	if err := c.RedactTraits(ctx, &redacted); err != nil {
+		return nil, err
+	}
+	return &redacted, nil
+}
+
+// Redact replaces the values of all traits which are classified as PII with
+// RedactedTraitValue.
+func (c *TraitsClassification) Redact(traits Traits) (Traits, error) {
+	if c == nil {
+		return traits, nil
+	}
+	return transformTraits(traits, c.PII, func(value gjson.Result) (any, bool, error) {
+		return RedactedTraitValue, value.Type != gjson.Null, nil
+	})
+}
+
+// IsPII returns true if the trait with the given name (e.g. `traits.emails.0`)
+// or one of its parents is classified as PII.
+func (c *TraitsClassification) IsPII(name string) bool {
+	if c == nil || !strings.HasPrefix(name, "traits.") {
+		return false
+	}
+
+	segments := strings.Split(strings.TrimPrefix(name, "traits."), ".")
+	for _, path := range c.PII {
+		if matchesTraitsPath(segments, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnsetAskOnce returns the paths (e.g. `traits.website`) of all "ask once"
+// traits which have no value. Traits within arrays are not supported and
+// ignored.
+func (c *TraitsClassification) UnsetAskOnce(traits Traits) []string {
+	if c == nil {
+		return nil
+	}
+
+	var result []string
+	for _, path := range c.AskOnce {
+		if slices.Contains(path, "#") {
+			continue
+		}
+
+		value := gjson.GetBytes(traits, expandTraitsPath(traits, path, "")[0])
+		if value.Exists() && value.Type != gjson.Null && value.String() != "" {
+			continue
+		}
+		result = append(result, "traits."+strings.Join(path, "."))
+	}
+	return result
+}
+
+type rawTraitValue []byte
+
+// matchesTraitsPath returns true if path is a prefix of segments. A `#` in path
+// matches any array index.
+func matchesTraitsPath(segments, path []string) bool {
+	if len(segments) < len(path) {
+		return false
+	}
+	for k, segment := range path {
+		if segment == "#" {
+			if _, err := strconv.Atoi(segments[k]); err != nil {
+				return false
+			}
+			continue
+		}
+		if segment != segments[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTraits(traits Traits) bool {
+	value := gjson.ParseBytes(traits)
+	return value.IsObject() && len(value.Map()) > 0
+}
+
+// transformTraits calls fn for every value found at the given paths and
+// replaces the value if fn returns true.
+func transformTraits(traits Traits, paths [][]string, fn func(gjson.Result) (any, bool, error)) (Traits, error) {
+	result := []byte(traits)
+	for _, path := range paths {
+		for _, concrete := range expandTraitsPath(result, path, "") {
+			value := gjson.GetBytes(result, concrete)
+			if !value.Exists() {
+				continue
+			}
+
+			replacement, ok, err := fn(value)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+
+			if raw, isRaw := replacement.(rawTraitValue); isRaw {
+				result, err = sjson.SetRawBytes(result, concrete, raw)
+			} else {
+				result, err = sjson.SetBytes(result, concrete, replacement)
+			}
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// expandTraitsPath resolves array wildcards (`#`) in path to the concrete
+// indices present in traits and returns escaped gjson/sjson paths.
+func expandTraitsPath(traits []byte, path []string, prefix string) []string {
+	if len(path) == 0 {
+		return []string{prefix}
+	}
+
+	join := func(segment string) string {
+		if prefix == "" {
+			return segment
+		}
+		return prefix + "." + segment
+	}
+
+	if path[0] != "#" {
+		return expandTraitsPath(traits, path[1:], join(escapeTraitsPathSegment(path[0])))
+	}
+
+	var result []string
+	elements := gjson.GetBytes(traits, prefix)
+	if !elements.IsArray() {
+		return nil
+	}
+	for k := range elements.Array() {
+		result = append(result, expandTraitsPath(traits, path[1:], join(strconv.Itoa(k)))...)
+	}
+	return result
+}
+
+func escapeTraitsPathSegment(segment string) string {
+	var b strings.Builder
+	for _, r := range segment {
+		switch r {
+		case '.', '*', '?', '#', '|', '@', '!', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+)
+
+func TestEncryptedTraits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=encrypts values which look encrypted", func(t *testing.T) {
+		conf, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCipherAlgorithm: "xchacha20-poly1305",
+			config.ViperKeySecretsCipher:   []string{"secret-thirty-two-character-long"},
+		}))
+		testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/extension/privacy/schema.json")
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"foo@ory.sh","national_id":"` + identity.EncryptedTraitPrefix + `00"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		assert.Equal(t, identity.EncryptedTraitPrefix+"00", gjson.GetBytes(i.Traits, "national_id").String())
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, i.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.EncryptedTraitPrefix+"00", gjson.GetBytes(actual.Traits, "national_id").String())
+	})
+
+	t.Run("case=requires a cipher", func(t *testing.T) {
+		conf, reg := pkg.NewFastRegistryWithMocks(t)
+		testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/extension/privacy/schema.json")
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"foo@ory.sh","national_id":"123-45"}`)
+		err := reg.PrivilegedIdentityPool().CreateIdentity(ctx, i)
+		require.ErrorIs(t, err, herodot.ErrMisconfiguration())
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cipher"
+)
+
+func TestClassifyTraitsSchema(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := ClassifyTraitsSchema(ctx, "file://./stub/extension/privacy/schema.json", false)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, [][]string{{"national_id"}, {"birthdate"}}, c.Encrypted)
+	assert.ElementsMatch(t, [][]string{{"email"}, {"national_id"}, {"phones", "#"}}, c.PII)
+	assert.Equal(t, [][]string{{"nickname"}}, c.AskOnce)
+
+	t.Run("case=lists unset ask once traits", func(t *testing.T) {
+		assert.Equal(t, []string{"traits.nickname"}, c.UnsetAskOnce(Traits(`{"email":"foo@ory.sh"}`)))
+		assert.Equal(t, []string{"traits.nickname"}, c.UnsetAskOnce(Traits(`{"nickname":""}`)))
+		assert.Empty(t, c.UnsetAskOnce(Traits(`{"nickname":"foo"}`)))
+	})
+
+	t.Run("case=redacts pii", func(t *testing.T) {
+		redacted, err := c.Redact(Traits(`{"email":"foo@ory.sh","nickname":"foo","phones":["+1","+2"],"national_id":null}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"email":"[redacted]","nickname":"foo","phones":["[redacted]","[redacted]"],"national_id":null}`, string(redacted))
+	})
+
+	t.Run("case=matches pii node names", func(t *testing.T) {
+		assert.True(t, c.IsPII("traits.email"))
+		assert.True(t, c.IsPII("traits.phones.1"))
+		assert.False(t, c.IsPII("traits.phones.foo"))
+		assert.False(t, c.IsPII("traits.nickname"))
+		assert.False(t, c.IsPII("email"))
+		assert.False(t, (*TraitsClassification)(nil).IsPII("traits.email"))
+	})
+
+	t.Run("case=encrypts and decrypts values", func(t *testing.T) {
+		noop := cipher.NewNoop()
+		original := Traits(`{"email":"foo@ory.sh","national_id":"123-45","birthdate":{"year":1970}}`)
+
+		encrypted, err := transformTraits(original, c.Encrypted, func(value gjson.Result) (any, bool, error) {
+			ciphertext, err := noop.Encrypt(ctx, []byte(value.Raw))
+			return EncryptedTraitPrefix + ciphertext, err == nil, err
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, string(encrypted), "123-45")
+		assert.Contains(t, string(encrypted), `"email":"foo@ory.sh"`)
+
+		decrypted, err := transformTraits(encrypted, c.Encrypted, func(value gjson.Result) (any, bool, error) {
+			plaintext, err := noop.Decrypt(ctx, value.Str[len(EncryptedTraitPrefix):])
+			return rawTraitValue(plaintext), err == nil, err
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, string(original), string(decrypted))
+	})
+
+	t.Run("case=ignores missing values", func(t *testing.T) {
+		result, err := c.Redact(Traits(`{"nickname":"foo"}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"nickname":"foo"}`, string(result))
+	})
+}
@@ -141,6 +141,24 @@ const (
 	// It is not used within the credentials object itself.
 	CredentialsTypeRecoveryLink CredentialsType = "link_recovery"
 	CredentialsTypeRecoveryCode CredentialsType = "code_recovery"
+
+	// CredentialsTypeTrustedDevice is a special credential type used in a session's authentication methods if a
+	// trusted device satisfied the second factor. It is not used within the credentials object itself.
+	CredentialsTypeTrustedDevice CredentialsType = "trusted_device"
+
+	// CredentialsTypeImpersonation is a special credential type used in a session's authentication methods if an
+	// administrator issued the session to impersonate the identity. It is not used within the credentials object itself.
+	CredentialsTypeImpersonation CredentialsType = "impersonation"
+
+	// CredentialsTypeDeviceAuthorization is a special credential type used in a session's authentication methods if
+	// the session was issued to a device after a signed in user approved its user code. It is not used within the
+	// credentials object itself.
+	CredentialsTypeDeviceAuthorization CredentialsType = "device_authorization"
+
+	// CredentialsTypeCrossDevice is a special credential type used in a session's authentication methods if the
+	// sign in was approved on another device on which the user was already signed in. It is not used within the
+	// credentials object itself.
+	CredentialsTypeCrossDevice CredentialsType = "cross_device"
 )
 
 // ParseCredentialsType parses a string into a CredentialsType or returns false as the second argument.
@@ -3,6 +3,13 @@
 
 package identity
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
 // CredentialsPassword is contains the configuration for credentials of the type password.
 //
 // swagger:model identityCredentialsPassword
@@ -14,6 +21,69 @@ type CredentialsPassword struct {
 	// using the password migration hook. If set, and the HashedPassword is empty, a
 	// webhook will be called during login to migrate the password.
 	UsePasswordMigrationHook bool `json:"use_password_migration_hook,omitempty"`
+
+	// PreviousHashedPasswords contains the hashes of previously used passwords,
+	// the most recent one first. They are kept to enforce the password history
+	// of the password policy.
+	PreviousHashedPasswords []string `json:"previous_hashed_passwords,omitempty"`
+
+	// ChangedAt is the time the password was last set. It is unknown for
+	// passwords which were set by older versions or imported without it.
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+}
+
+// Expired returns true if the password was changed longer than maxAge ago.
+// Passwords without a change date are expired, as their age is unknown.
+func (cp *CredentialsPassword) Expired(maxAge time.Duration, now time.Time) bool {
+	if cp == nil || maxAge <= 0 {
+		return false
+	}
+	if cp.ChangedAt == nil {
+		return true
+	}
+	return cp.ChangedAt.Add(maxAge).Before(now)
+}
+
+// PasswordExpired returns true if the identity has a password which was
+// changed longer than maxAge ago or whose change date is unknown.
+func (i *Identity) PasswordExpired(maxAge time.Duration, now time.Time) (bool, error) {
+	c, ok := i.GetCredentials(CredentialsTypePassword)
+	if !ok || len(c.Config) == 0 || maxAge <= 0 {
+		return false, nil
+	}
+
+	var cp CredentialsPassword
+	if err := json.Unmarshal(c.Config, &cp); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if cp.HashedPassword == "" && !cp.UsePasswordMigrationHook {
+		return false, nil
+	}
+	return cp.Expired(maxAge, now), nil
+}
+
+// WithNewHashedPassword returns the credentials for a newly set password. The
+// current password is added to the password history, which keeps at most
+// historySize-1 previous passwords so that together with the current password
+// the last historySize passwords are known.
+func (cp *CredentialsPassword) WithNewHashedPassword(hashedPassword string, historySize uint, now time.Time) *CredentialsPassword {
+	changedAt := now.UTC()
+	next := &CredentialsPassword{HashedPassword: hashedPassword, ChangedAt: &changedAt}
+	if cp == nil || historySize <= 1 {
+		return next
+	}
+
+	var previous []string
+	if cp.HashedPassword != "" {
+		previous = append(previous, cp.HashedPassword)
+	}
+	previous = append(previous, cp.PreviousHashedPasswords...)
+	if uint(len(previous)) > historySize-1 {
+		previous = previous[:historySize-1]
+	}
+	next.PreviousHashedPasswords = previous
+	return next
 }
 
 func (cp *CredentialsPassword) ShouldUsePasswordMigrationHook() bool {
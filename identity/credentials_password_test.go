@@ -5,8 +5,10 @@ package identity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCredentialsPassword_ShouldUsePasswordMigrationHook(t *testing.T) {
@@ -44,3 +46,77 @@ func TestCredentialsPassword_ShouldUsePasswordMigrationHook(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialsPassword_WithNewHashedPassword(t *testing.T) {
+	now := time.Now()
+
+	t.Run("case=no previous password", func(t *testing.T) {
+		var cp *CredentialsPassword
+		next := cp.WithNewHashedPassword("new", 3, now)
+		assert.Equal(t, "new", next.HashedPassword)
+		assert.Empty(t, next.PreviousHashedPasswords)
+		require.NotNil(t, next.ChangedAt)
+		assert.True(t, now.Equal(*next.ChangedAt))
+	})
+
+	t.Run("case=history disabled", func(t *testing.T) {
+		cp := &CredentialsPassword{HashedPassword: "current", PreviousHashedPasswords: []string{"old"}}
+		assert.Empty(t, cp.WithNewHashedPassword("new", 1, now).PreviousHashedPasswords)
+	})
+
+	t.Run("case=history is truncated", func(t *testing.T) {
+		cp := &CredentialsPassword{HashedPassword: "current", PreviousHashedPasswords: []string{"old-1", "old-2", "old-3"}}
+		assert.Equal(t, []string{"current", "old-1"}, cp.WithNewHashedPassword("new", 3, now).PreviousHashedPasswords)
+	})
+}
+
+func TestCredentialsPassword_Expired(t *testing.T) {
+	now := time.Now()
+	changedAt := now.Add(-48 * time.Hour)
+
+	assert.False(t, (*CredentialsPassword)(nil).Expired(time.Hour, now))
+	assert.False(t, (&CredentialsPassword{ChangedAt: &changedAt}).Expired(0, now))
+	assert.False(t, (&CredentialsPassword{ChangedAt: &changedAt}).Expired(72*time.Hour, now))
+	assert.True(t, (&CredentialsPassword{ChangedAt: &changedAt}).Expired(24*time.Hour, now))
+
+	t.Run("case=expired without change date", func(t *testing.T) {
+		assert.True(t, (&CredentialsPassword{}).Expired(72*time.Hour, now))
+		assert.False(t, (&CredentialsPassword{}).Expired(0, now))
+	})
+}
+
+func TestIdentity_PasswordExpired(t *testing.T) {
+	now := time.Now()
+	changedAt := now.Add(-48 * time.Hour)
+
+	i := NewIdentity("")
+	expired, err := i.PasswordExpired(time.Hour, now)
+	require.NoError(t, err)
+	assert.False(t, expired, "identities without a password")
+
+	i.SetCredentials(CredentialsTypePassword, Credentials{
+		Type:      CredentialsTypePassword,
+		Config:    []byte(`{"hashed_password":"$2a$04$zvZz1zV","changed_at":"` + changedAt.UTC().Format(time.RFC3339Nano) + `"}`),
+		UpdatedAt: now,
+	})
+	expired, err = i.PasswordExpired(72*time.Hour, now)
+	require.NoError(t, err)
+	assert.False(t, expired)
+
+	expired, err = i.PasswordExpired(24*time.Hour, now)
+	require.NoError(t, err)
+	assert.True(t, expired)
+
+	i.SetCredentials(CredentialsTypePassword, Credentials{
+		Type:      CredentialsTypePassword,
+		Config:    []byte(`{"hashed_password":"$2a$04$zvZz1zV"}`),
+		UpdatedAt: now,
+	})
+	expired, err = i.PasswordExpired(72*time.Hour, now)
+	require.NoError(t, err)
+	assert.True(t, expired, "does not use the time the credentials were updated without a change date")
+
+	expired, err = i.PasswordExpired(0, now)
+	require.NoError(t, err)
+	assert.False(t, expired, "without a maximum password age")
+}
@@ -3,10 +3,79 @@
 
 package identity
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
 // CredentialsConfig is the struct that is being used as part of the identity credentials.
 type CredentialsTOTPConfig struct {
+	// TOTPURL is the TOTP URL
+	//
+	// Deprecated: TOTP credentials are stored as a list of devices. This field
+	// is only set on credentials which were stored before multiple devices were
+	// supported.
+	//
+	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+	TOTPURL string `json:"totp_url,omitempty"`
+
+	// Devices lists the TOTP authenticators of the identity.
+	Devices []CredentialTOTPDevice `json:"devices,omitempty"`
+}
+
+// CredentialTOTPDevice is a single TOTP authenticator, for example an
+// authenticator app on a phone.
+type CredentialTOTPDevice struct {
+	// ID identifies the device.
+	ID uuid.UUID `json:"id"`
+
+	// DisplayName is the name the user gave the device.
+	DisplayName string `json:"display_name"`
+
 	// TOTPURL is the TOTP URL
 	//
 	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
 	TOTPURL string `json:"totp_url"`
+
+	// AddedAt is the time the device was added.
+	AddedAt time.Time `json:"added_at"`
+
+	// LastUsedAt is the time a code of the device was last used to sign in.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// NewCredentialsTOTPConfig decodes the TOTP configuration of the credentials.
+// A device stored in the legacy single-URL format is converted to a device
+// with the credentials' ID and creation time.
+func NewCredentialsTOTPConfig(c Credentials) (*CredentialsTOTPConfig, error) {
+	var conf CredentialsTOTPConfig
+	if len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &conf); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	if conf.TOTPURL != "" {
+		conf.Devices = append([]CredentialTOTPDevice{{
+			ID:      c.ID,
+			TOTPURL: conf.TOTPURL,
+			AddedAt: c.CreatedAt,
+		}}, conf.Devices...)
+		conf.TOTPURL = ""
+	}
+
+	return &conf, nil
+}
+
+// FindDevice returns the device with the given ID.
+func (c *CredentialsTOTPConfig) FindDevice(id uuid.UUID) (*CredentialTOTPDevice, bool) {
+	for k := range c.Devices {
+		if c.Devices[k].ID == id {
+			return &c.Devices[k], true
+		}
+	}
+	return nil, false
 }
@@ -0,0 +1,379 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ory/herodot"
+	identityv1 "github.com/ory/kratos/gen/identity/v1"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var (
+	_ identityv1.IdentityServiceServer = (*GRPCServer)(nil)
+
+	statesToProto = map[State]identityv1.IdentityState{
+		StateActive:          identityv1.IdentityState_IDENTITY_STATE_ACTIVE,
+		StateInactive:        identityv1.IdentityState_IDENTITY_STATE_INACTIVE,
+		StatePendingApproval: identityv1.IdentityState_IDENTITY_STATE_PENDING_APPROVAL,
+	}
+)
+
+type (
+	// GRPCServer serves the identity gRPC API. It shares the request
+	// handling of the identity admin API.
+	GRPCServer struct {
+		identityv1.UnimplementedIdentityServiceServer
+		h *Handler
+	}
+
+	GRPCServerProvider interface {
+		IdentityGRPCServer() *GRPCServer
+	}
+)
+
+func NewGRPCServer(h *Handler) *GRPCServer { return &GRPCServer{h: h} }
+
+func (s *GRPCServer) GetIdentity(ctx context.Context, req *identityv1.GetIdentityRequest) (*identityv1.GetIdentityResponse, error) {
+	id, err := parseGRPCUUID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pi, err := ToProto(i)
+	if err != nil {
+		return nil, err
+	}
+	return &identityv1.GetIdentityResponse{Identity: pi}, nil
+}
+
+func (s *GRPCServer) ListIdentities(ctx context.Context, req *identityv1.ListIdentitiesRequest) (*identityv1.ListIdentitiesResponse, error) {
+	query := url.Values{}
+	if req.GetPageSize() > 0 {
+		query.Set("page_size", strconv.Itoa(int(req.GetPageSize())))
+	}
+	if req.GetPageToken() != "" {
+		query.Set("page_token", req.GetPageToken())
+	}
+	if len(req.GetIds()) > 0 {
+		query["ids"] = req.GetIds()
+	}
+	if req.GetOrganizationId() != "" {
+		query.Set("organization_id", req.GetOrganizationId())
+	}
+	if req.GetCredentialsIdentifier() != "" {
+		query.Set("credentials_identifier", req.GetCredentialsIdentifier())
+	}
+	query.Set("include_pii", strconv.FormatBool(req.GetIncludePii()))
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "/?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	params, err := parseListIdentitiesParameters(r)
+	if err != nil {
+		return nil, err
+	}
+
+	is, nextPage, err := s.h.r.PrivilegedIdentityPool().ListIdentities(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &identityv1.ListIdentitiesResponse{Identities: make([]*identityv1.Identity, len(is))}
+	for k := range is {
+		if !params.DeclassifyPII {
+			if err := s.h.r.IdentityTraitsClassifier().RedactTraits(ctx, &is[k]); err != nil {
+				return nil, err
+			}
+		}
+		if res.Identities[k], err = ToProto(&is[k]); err != nil {
+			return nil, err
+		}
+	}
+	if nextPage != nil && !nextPage.IsLast() {
+		res.NextPageToken = nextPage.Token().Encode()
+	}
+
+	return res, nil
+}
+
+func (s *GRPCServer) CreateIdentity(ctx context.Context, req *identityv1.CreateIdentityRequest) (*identityv1.CreateIdentityResponse, error) {
+	cr := CreateIdentityBody{
+		SchemaID:    req.GetSchemaId(),
+		State:       stateFromProto(req.GetState()),
+		Credentials: credentialsFromProto(req.GetPassword()),
+		ExternalID:  req.GetExternalId(),
+	}
+
+	var err error
+	if cr.Traits, err = traitsFromProto(req.GetTraits()); err != nil {
+		return nil, err
+	}
+	if cr.MetadataPublic, err = valueFromProto(req.GetMetadataPublic()); err != nil {
+		return nil, err
+	}
+	if cr.MetadataAdmin, err = valueFromProto(req.GetMetadataAdmin()); err != nil {
+		return nil, err
+	}
+	if req.OrganizationId != nil {
+		id, err := parseGRPCUUID("organization_id", req.GetOrganizationId())
+		if err != nil {
+			return nil, err
+		}
+		cr.OrganizationID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	i, err := s.h.identityFromCreateIdentityBody(ctx, &cr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.h.r.IdentityManager().Create(ctx, i); err != nil {
+		if errors.Is(err, sqlcon.ErrUniqueViolation()) {
+			return nil, errors.WithStack(herodot.ErrConflict().WithReason("This identity conflicts with another identity that already exists."))
+		}
+		return nil, err
+	}
+
+	pi, err := ToProto(i)
+	if err != nil {
+		return nil, err
+	}
+	return &identityv1.CreateIdentityResponse{Identity: pi}, nil
+}
+
+func (s *GRPCServer) UpdateIdentity(ctx context.Context, req *identityv1.UpdateIdentityRequest) (*identityv1.UpdateIdentityResponse, error) {
+	id, err := parseGRPCUUID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetSchemaId() != "" {
+		i.SchemaID = req.GetSchemaId()
+	}
+
+	if state := stateFromProto(req.GetState()); state != "" && i.State != state {
+		if err := state.IsValid(); err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err).WithWrap(err))
+		}
+
+		stateChangedAt := sqlxx.NullTime(time.Now())
+		i.State = state
+		i.StateChangedAt = &stateChangedAt
+	}
+
+	traits, err := traitsFromProto(req.GetTraits())
+	if err != nil {
+		return nil, err
+	}
+	metadataPublic, err := valueFromProto(req.GetMetadataPublic())
+	if err != nil {
+		return nil, err
+	}
+	metadataAdmin, err := valueFromProto(req.GetMetadataAdmin())
+	if err != nil {
+		return nil, err
+	}
+	i.Traits = Traits(traits)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(metadataPublic)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(metadataAdmin)
+	i.ExternalID = sqlxx.NullString(req.GetExternalId())
+
+	// As with the admin API, credentials are only changed if set.
+	if creds := credentialsFromProto(req.GetPassword()); creds != nil {
+		if err := s.h.importCredentials(ctx, i, creds); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.h.r.IdentityManager().Update(ctx, i, ManagerAllowWriteProtectedTraits); err != nil {
+		return nil, err
+	}
+
+	pi, err := ToProto(i)
+	if err != nil {
+		return nil, err
+	}
+	return &identityv1.UpdateIdentityResponse{Identity: pi}, nil
+}
+
+func (s *GRPCServer) DeleteIdentity(ctx context.Context, req *identityv1.DeleteIdentityRequest) (*identityv1.DeleteIdentityResponse, error) {
+	id, err := parseGRPCUUID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.h.r.PrivilegedIdentityPool().DeleteIdentity(ctx, id); err != nil {
+		return nil, err
+	}
+	return &identityv1.DeleteIdentityResponse{}, nil
+}
+
+// ToProto converts the identity to its gRPC representation. Credentials are
+// included without their configuration.
+func ToProto(i *Identity) (*identityv1.Identity, error) {
+	pi := &identityv1.Identity{
+		Id:             i.ID.String(),
+		SchemaId:       i.SchemaID,
+		SchemaUrl:      i.SchemaURL,
+		State:          statesToProto[i.State],
+		StateChangedAt: nullTimeToProto(i.StateChangedAt),
+		CreatedAt:      timestamppb.New(i.CreatedAt),
+		UpdatedAt:      timestamppb.New(i.UpdatedAt),
+	}
+
+	if len(i.Traits) > 0 {
+		pi.Traits = new(structpb.Struct)
+		if err := protojson.Unmarshal(i.Traits, pi.Traits); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode the identity traits: %s", err))
+		}
+	}
+
+	var err error
+	if pi.MetadataPublic, err = valueToProto(i.MetadataPublic); err != nil {
+		return nil, err
+	}
+	if pi.MetadataAdmin, err = valueToProto(i.MetadataAdmin); err != nil {
+		return nil, err
+	}
+
+	if i.OrganizationID.Valid {
+		pi.OrganizationId = proto.String(i.OrganizationID.UUID.String())
+	}
+	if i.ExternalID != "" {
+		pi.ExternalId = proto.String(string(i.ExternalID))
+	}
+
+	for _, a := range i.VerifiableAddresses {
+		pi.VerifiableAddresses = append(pi.VerifiableAddresses, &identityv1.VerifiableAddress{
+			Id:         a.ID.String(),
+			Value:      a.Value,
+			Verified:   a.Verified,
+			Via:        a.Via,
+			Status:     string(a.Status),
+			VerifiedAt: nullTimeToProto(a.VerifiedAt),
+			CreatedAt:  timestamppb.New(a.CreatedAt),
+			UpdatedAt:  timestamppb.New(a.UpdatedAt),
+		})
+	}
+	for _, a := range i.RecoveryAddresses {
+		pi.RecoveryAddresses = append(pi.RecoveryAddresses, &identityv1.RecoveryAddress{
+			Id:        a.ID.String(),
+			Value:     a.Value,
+			Via:       a.Via,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+			UpdatedAt: timestamppb.New(a.UpdatedAt),
+		})
+	}
+	for _, t := range slices.Sorted(maps.Keys(i.Credentials)) {
+		c := i.Credentials[t]
+		pi.Credentials = append(pi.Credentials, &identityv1.Credentials{
+			Type:        string(t),
+			Identifiers: c.Identifiers,
+			Version:     int64(c.Version),
+			CreatedAt:   timestamppb.New(c.CreatedAt),
+			UpdatedAt:   timestamppb.New(c.UpdatedAt),
+		})
+	}
+
+	return pi, nil
+}
+
+func stateFromProto(s identityv1.IdentityState) State {
+	for state, ps := range statesToProto {
+		if ps == s {
+			return state
+		}
+	}
+	return ""
+}
+
+func credentialsFromProto(p *identityv1.PasswordCredentials) *IdentityWithCredentials {
+	if p == nil {
+		return nil
+	}
+	return &IdentityWithCredentials{
+		Password: &AdminIdentityImportCredentialsPassword{
+			Config: AdminIdentityImportCredentialsPasswordConfig{
+				Password:       p.GetPassword(),
+				HashedPassword: p.GetHashedPassword(),
+			},
+		},
+	}
+}
+
+func traitsFromProto(s *structpb.Struct) (json.RawMessage, error) {
+	if s == nil {
+		return json.RawMessage("{}"), nil
+	}
+	raw, err := protojson.Marshal(s)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Unable to decode the identity traits: %s", err))
+	}
+	return raw, nil
+}
+
+func valueFromProto(v *structpb.Value) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := protojson.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Unable to decode the identity metadata: %s", err))
+	}
+	return raw, nil
+}
+
+func valueToProto(raw sqlxx.NullJSONRawMessage) (*structpb.Value, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	v := new(structpb.Value)
+	if err := protojson.Unmarshal(raw, v); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode the identity metadata: %s", err))
+	}
+	return v, nil
+}
+
+func nullTimeToProto(t *sqlxx.NullTime) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(time.Time(*t))
+}
+
+func parseGRPCUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.FromString(value)
+	if err != nil {
+		return uuid.Nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Invalid UUID value `%s` for field `%s`.", value, field))
+	}
+	return id, nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	identityv1 "github.com/ory/kratos/gen/identity/v1"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+)
+
+func TestGRPCServer(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+	)
+	client := identityv1.NewIdentityServiceClient(testhelpers.NewAdminGRPCClient(t, reg))
+	ctx := context.Background()
+
+	traits := func(t *testing.T, email string) *structpb.Struct {
+		s, err := structpb.NewStruct(map[string]any{"email": email, "bar": "baz"})
+		require.NoError(t, err)
+		return s
+	}
+
+	created, err := client.CreateIdentity(ctx, &identityv1.CreateIdentityRequest{
+		Traits:   traits(t, "grpc@ory.sh"),
+		Password: &identityv1.PasswordCredentials{Password: "d8a6b1ab-8cfd-4b97-9fa9-b0c1f8e9f8b1"},
+	})
+	require.NoError(t, err)
+	id := created.GetIdentity().GetId()
+	assert.Equal(t, "grpc@ory.sh", created.GetIdentity().GetTraits().AsMap()["email"])
+	assert.Equal(t, identityv1.IdentityState_IDENTITY_STATE_ACTIVE, created.GetIdentity().GetState())
+	require.Len(t, created.GetIdentity().GetCredentials(), 1)
+	assert.Equal(t, []string{"grpc@ory.sh"}, created.GetIdentity().GetCredentials()[0].GetIdentifiers())
+
+	t.Run("method=GetIdentity", func(t *testing.T) {
+		res, err := client.GetIdentity(ctx, &identityv1.GetIdentityRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, id, res.GetIdentity().GetId())
+		assert.Equal(t, "default", res.GetIdentity().GetSchemaId())
+
+		_, err = client.GetIdentity(ctx, &identityv1.GetIdentityRequest{Id: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("method=ListIdentities", func(t *testing.T) {
+		res, err := client.ListIdentities(ctx, &identityv1.ListIdentitiesRequest{Ids: []string{id}})
+		require.NoError(t, err)
+		require.Len(t, res.GetIdentities(), 1)
+		assert.Equal(t, id, res.GetIdentities()[0].GetId())
+		assert.Empty(t, res.GetNextPageToken())
+
+		_, err = client.ListIdentities(ctx, &identityv1.ListIdentitiesRequest{Ids: []string{id}, OrganizationId: id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "filters can not be combined")
+	})
+
+	t.Run("method=UpdateIdentity", func(t *testing.T) {
+		res, err := client.UpdateIdentity(ctx, &identityv1.UpdateIdentityRequest{
+			Id:     id,
+			Traits: traits(t, "grpc-updated@ory.sh"),
+			State:  identityv1.IdentityState_IDENTITY_STATE_INACTIVE,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "grpc-updated@ory.sh", res.GetIdentity().GetTraits().AsMap()["email"])
+		assert.Equal(t, identityv1.IdentityState_IDENTITY_STATE_INACTIVE, res.GetIdentity().GetState())
+	})
+
+	t.Run("method=DeleteIdentity", func(t *testing.T) {
+		_, err := client.DeleteIdentity(ctx, &identityv1.DeleteIdentityRequest{Id: id})
+		require.NoError(t, err)
+
+		_, err = client.GetIdentity(ctx, &identityv1.GetIdentityRequest{Id: id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,6 +58,8 @@ type (
 		PoolProvider
 		PrivilegedPoolProvider
 		ManagementProvider
+		TraitsClassifierProvider
+		SchemaMigratorProvider
 		httpx.WriterProvider
 		config.Provider
 		nosurfx.CSRFProvider
@@ -76,9 +79,13 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 		RouteCollection,
 		RouteCollection+"/*",
 		RouteCollection+"/*/credentials/*",
+		RouteCollection+"/*/credentials/*/providers",
+		RouteCollection+"/*/credentials/*/providers/*/*",
 		httprouterx.AdminPrefix+RouteCollection,
 		httprouterx.AdminPrefix+RouteCollection+"/*",
 		httprouterx.AdminPrefix+RouteCollection+"/*/credentials/*",
+		httprouterx.AdminPrefix+RouteCollection+"/*/credentials/*/providers",
+		httprouterx.AdminPrefix+RouteCollection+"/*/credentials/*/providers/*/*",
 	)
 
 	public.GET(RouteCollection, redir.RedirectToAdminRoute(h.r))
@@ -89,6 +96,9 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 	public.PUT(RouteItem, redir.RedirectToAdminRoute(h.r))
 	public.PATCH(RouteItem, redir.RedirectToAdminRoute(h.r))
 	public.DELETE(RouteCredentialItem, redir.RedirectToAdminRoute(h.r))
+	public.GET(RouteCredentialProviderCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(RouteCredentialProviderCollection, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(RouteCredentialProviderItem, redir.RedirectToAdminRoute(h.r))
 
 	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
 	public.GET(httprouterx.AdminPrefix+RouteCollection+"/by/external/{externalID}", redir.RedirectToAdminRoute(h.r))
@@ -98,6 +108,9 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 	public.PUT(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
 	public.PATCH(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
 	public.DELETE(httprouterx.AdminPrefix+RouteCredentialItem, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteCredentialProviderCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCredentialProviderCollection, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteCredentialProviderItem, redir.RedirectToAdminRoute(h.r))
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
@@ -112,6 +125,12 @@ func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
 	admin.PUT(RouteItem, h.update)
 
 	admin.DELETE(RouteCredentialItem, h.deleteIdentityCredentials)
+	admin.GET(RouteCredentialProviderCollection, h.listProviderLinks)
+	admin.POST(RouteCredentialProviderCollection, h.addProviderLink)
+	admin.DELETE(RouteCredentialProviderItem, h.removeProviderLink)
+
+	admin.GET(RouteSchemaMigrationCollection, h.listSchemaMigrations)
+	admin.POST(RouteSchemaMigrationBatches, h.runSchemaMigrationBatch)
 }
 
 // Paginated Identity List Response
@@ -172,6 +191,15 @@ type _ struct {
 	// in: query
 	DeclassifyCredentials []string `json:"include_credential"`
 
+	// Include PII Traits in Response
+	//
+	// Traits which the identity schema classifies as personally identifiable information (PII) using
+	// `"ory.sh/kratos": {"privacy": {"pii": true}}` are redacted unless this parameter is set to `true`.
+	//
+	// required: false
+	// in: query
+	DeclassifyPII bool `json:"include_pii"`
+
 	// List identities that belong to a specific organization.
 	//
 	// required: false
@@ -230,6 +258,13 @@ func parseListIdentitiesParameters(r *http.Request) (params ListIdentityParamete
 		params.DeclassifyCredentials = append(params.DeclassifyCredentials, tc)
 	}
 
+	if includePII := query.Get("include_pii"); includePII != "" {
+		params.DeclassifyPII, err = strconv.ParseBool(includePII)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Invalid boolean value `%s` for parameter `include_pii`.", includePII))
+		}
+	}
+
 	if requestedFilters > 1 {
 		return params, errors.WithStack(herodot.ErrBadRequest().WithReason("You cannot combine multiple filters in this API"))
 	}
@@ -249,6 +284,9 @@ func parseListIdentitiesParameters(r *http.Request) (params ListIdentityParamete
 //
 // Lists all [identities](https://www.ory.com/docs/kratos/concepts/identity-user-model) in the system. Note: filters cannot be combined.
 //
+// Traits classified as personally identifiable information (PII) by the identity schema are redacted unless
+// `include_pii=true` is set.
+//
 //	Produces:
 //	- application/json
 //
@@ -301,6 +339,13 @@ func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if !params.DeclassifyPII {
+			if err := h.r.IdentityTraitsClassifier().RedactTraits(r.Context(), emit); err != nil {
+				h.r.Writer().WriteError(w, r, err)
+				return
+			}
+		}
+
 		isam[i] = WithCredentialsAndAdminMetadataInJSON(*emit)
 	}
 
@@ -571,9 +616,30 @@ type AdminIdentityImportCredentialsTOTP struct {
 //
 // swagger:model identityWithCredentialsTotpConfig
 type AdminIdentityImportCredentialsTOTPConfig struct {
+	// TOTPURL is the TOTP URL
+	//
+	// Imports a single, unnamed TOTP device. Use `devices` to import several
+	// devices.
+	//
+	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+	TOTPURL string `json:"totp_url"`
+
+	// Devices lists the TOTP devices to import.
+	Devices []AdminIdentityImportCredentialsTOTPDevice `json:"devices"`
+}
+
+// Create Identity and Import a TOTP Device
+//
+// swagger:model identityWithCredentialsTotpDevice
+type AdminIdentityImportCredentialsTOTPDevice struct {
+	// DisplayName is the name of the device shown to the user.
+	DisplayName string `json:"display_name"`
+
 	// TOTPURL is the TOTP URL
 	//
 	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+	//
+	// required: true
 	TOTPURL string `json:"totp_url"`
 }
 
@@ -651,6 +717,10 @@ type AdminIdentityImportCredentialsPasswordConfig struct {
 
 	// If set to true, the password will be migrated using the password migration hook.
 	UsePasswordMigrationHook bool `json:"use_password_migration_hook,omitempty"`
+
+	// The time the password was last changed. If unset, the password counts as expired
+	// if the password policy limits the password age.
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
 }
 
 // Create Identity and Import Social Sign In Credentials
@@ -1219,7 +1289,7 @@ func (h *Handler) patch(w http.ResponseWriter, r *http.Request) {
 		if err := patchedIdentity.State.IsValid(); err != nil {
 			h.r.Writer().WriteError(w, r, errors.WithStack(
 				herodot.ErrBadRequest().
-					WithReasonf("The supplied state ('%s') was not valid. Valid states are ('%s', '%s').", string(patchedIdentity.State), StateActive, StateInactive).
+					WithReasonf("The supplied state ('%s') was not valid. Valid states are ('%s', '%s', '%s').", string(patchedIdentity.State), StateActive, StateInactive, StatePendingApproval).
 					WithErrorf("%v", err).
 					WithWrap(err),
 			))
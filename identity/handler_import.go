@@ -8,11 +8,13 @@ import (
 	"context"
 	"encoding/json"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/x"
 )
 
 func (h *Handler) importCredentials(ctx context.Context, i *Identity, creds *IdentityWithCredentials) error {
@@ -181,12 +183,31 @@ func (h *Handler) importLookupSecretCredentials(_ context.Context, i *Identity,
 }
 
 func (h *Handler) importTOTPCredentials(_ context.Context, i *Identity, creds *AdminIdentityImportCredentialsTOTP) error {
-	return i.SetCredentialsWithConfig(CredentialsTypeTOTP, Credentials{}, CredentialsTOTPConfig{TOTPURL: creds.Config.TOTPURL})
+	devices := creds.Config.Devices
+	if creds.Config.TOTPURL != "" {
+		devices = append([]AdminIdentityImportCredentialsTOTPDevice{{TOTPURL: creds.Config.TOTPURL}}, devices...)
+	}
+
+	now := time.Now().UTC().Round(time.Second)
+	config := CredentialsTOTPConfig{Devices: make([]CredentialTOTPDevice, len(devices))}
+	for k, d := range devices {
+		if d.TOTPURL == "" {
+			return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The TOTP device at index %d is missing the TOTP URL.", k))
+		}
+		config.Devices[k] = CredentialTOTPDevice{
+			ID:          x.NewUUID(),
+			DisplayName: d.DisplayName,
+			TOTPURL:     d.TOTPURL,
+			AddedAt:     now,
+		}
+	}
+
+	return i.SetCredentialsWithConfig(CredentialsTypeTOTP, Credentials{}, config)
 }
 
 func (h *Handler) ImportPasswordCredentials(ctx context.Context, i *Identity, creds *AdminIdentityImportCredentialsPassword) (err error) {
 	if creds.Config.UsePasswordMigrationHook {
-		return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{UsePasswordMigrationHook: true})
+		return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{UsePasswordMigrationHook: true, ChangedAt: creds.Config.ChangedAt})
 	}
 
 	// In here we deliberately ignore any password policies as the point here is to import passwords, even if they
@@ -206,7 +227,7 @@ func (h *Handler) ImportPasswordCredentials(ctx context.Context, i *Identity, cr
 		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The imported password does not match any known hash format. For more information see https://www.ory.sh/dr/2"))
 	}
 
-	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{HashedPassword: string(hashed)})
+	return i.SetCredentialsWithConfig(CredentialsTypePassword, Credentials{}, CredentialsPassword{HashedPassword: string(hashed), ChangedAt: creds.Config.ChangedAt})
 }
 
 func (h *Handler) importOIDCCredentials(_ context.Context, i *Identity, creds *AdminIdentityImportCredentialsOIDC) error {
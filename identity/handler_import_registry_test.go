@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,6 +73,28 @@ func TestImportCredentialsPassword(t *testing.T) {
 				assert.False(t, config.UsePasswordMigrationHook)
 			},
 		},
+		{
+			name: "import hashed password with change date",
+			setupIdentity: func() *identity.Identity {
+				return identity.NewIdentity(conf.DefaultIdentityTraitsSchemaID(ctx))
+			},
+			credentials: &identity.AdminIdentityImportCredentialsPassword{
+				Config: identity.AdminIdentityImportCredentialsPasswordConfig{
+					HashedPassword: "$2a$10$JCU0ELjU1TCnFbV2jLi7huEQjVWZG0HzXQq/BWZyO30XR6DJxwN72",
+					ChangedAt:      new(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+				},
+			},
+			verify: func(t *testing.T, i *identity.Identity) {
+				creds, ok := i.GetCredentials(identity.CredentialsTypePassword)
+				require.True(t, ok, "password credentials should be set")
+
+				var config identity.CredentialsPassword
+				require.NoError(t, json.Unmarshal(creds.Config, &config))
+
+				require.NotNil(t, config.ChangedAt)
+				assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), *config.ChangedAt)
+			},
+		},
 		{
 			name: "import with password migration hook",
 			setupIdentity: func() *identity.Identity {
@@ -738,7 +738,37 @@ func TestImportTOTPCredentials(t *testing.T) {
 				var config CredentialsTOTPConfig
 				require.NoError(t, json.Unmarshal(creds.Config, &config))
 
-				assert.Equal(t, "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example", config.TOTPURL)
+				assert.Empty(t, config.TOTPURL)
+				require.Len(t, config.Devices, 1)
+				assert.Equal(t, "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example", config.Devices[0].TOTPURL)
+				assert.NotEqual(t, uuid.Nil, config.Devices[0].ID)
+			},
+		},
+		{
+			name: "multiple totp devices",
+			setupIdentity: func() *Identity {
+				return &Identity{}
+			},
+			credentials: &AdminIdentityImportCredentialsTOTP{
+				Config: AdminIdentityImportCredentialsTOTPConfig{
+					Devices: []AdminIdentityImportCredentialsTOTPDevice{
+						{DisplayName: "Phone", TOTPURL: "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"},
+						{DisplayName: "Tablet", TOTPURL: "otpauth://totp/Example:alice@example.com?secret=NBSWY3DPEHPK3PXQ&issuer=Example"},
+					},
+				},
+			},
+			verify: func(t *testing.T, i *Identity) {
+				creds, ok := i.GetCredentials(CredentialsTypeTOTP)
+				require.True(t, ok)
+
+				var config CredentialsTOTPConfig
+				require.NoError(t, json.Unmarshal(creds.Config, &config))
+
+				require.Len(t, config.Devices, 2)
+				assert.Equal(t, "Phone", config.Devices[0].DisplayName)
+				assert.Equal(t, "Tablet", config.Devices[1].DisplayName)
+				assert.Contains(t, config.Devices[1].TOTPURL, "NBSWY3DPEHPK3PXQ")
+				assert.NotEqual(t, config.Devices[0].ID, config.Devices[1].ID)
 			},
 		},
 		{
@@ -768,7 +798,8 @@ func TestImportTOTPCredentials(t *testing.T) {
 				require.NoError(t, json.Unmarshal(creds.Config, &config))
 
 				// Should have the new TOTP URL
-				assert.Equal(t, "otpauth://totp/Example:alice@example.com?secret=NEWSECRET&issuer=Example", config.TOTPURL)
+				require.Len(t, config.Devices, 1)
+				assert.Equal(t, "otpauth://totp/Example:alice@example.com?secret=NEWSECRET&issuer=Example", config.Devices[0].TOTPURL)
 			},
 		},
 	}
@@ -779,7 +810,9 @@ func TestImportTOTPCredentials(t *testing.T) {
 			err := h.importTOTPCredentials(ctx, i, tc.credentials)
 			require.NoError(t, err)
 			tc.verify(t, i)
-			snapshotx.SnapshotT(t, i.Credentials)
+			snapshotx.SnapshotT(t, i.Credentials, snapshotx.ExceptPaths(
+				"totp.config.devices.0.id", "totp.config.devices.0.added_at",
+				"totp.config.devices.1.id", "totp.config.devices.1.added_at"))
 		})
 	}
 }
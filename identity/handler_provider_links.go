@@ -0,0 +1,434 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCredentialProviderCollection = RouteCredentialItem + "/providers"
+	RouteCredentialProviderItem       = RouteCredentialProviderCollection + "/{provider}/{subject}"
+)
+
+// Identity Credentials Provider Link
+//
+// A link between the identity and an OpenID Connect or SAML provider. Tokens are not included.
+//
+// swagger:model identityCredentialsProviderLink
+type CredentialsProviderLink struct {
+	// The identifier of the link, which is `<provider>:<subject>`.
+	//
+	// required: true
+	Identifier string `json:"identifier"`
+
+	// The ID of the provider.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// The subject of the identity at the provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// The organization of the provider.
+	Organization string `json:"organization,omitempty"`
+
+	// If set, the user can sign in with the provider without the subject being set first.
+	UseAutoLink bool `json:"use_auto_link,omitempty"`
+}
+
+func newCredentialsProviderLink(p CredentialsOIDCProvider) CredentialsProviderLink {
+	return CredentialsProviderLink{
+		Identifier:   OIDCUniqueID(p.Provider, p.Subject),
+		Provider:     p.Provider,
+		Subject:      p.Subject,
+		Organization: p.Organization,
+		UseAutoLink:  p.UseAutoLink,
+	}
+}
+
+// List Identity Credentials Provider Links Parameters
+//
+// swagger:parameters listIdentityCredentialsProviderLinks
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityCredentialsProviderLinks struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the type of credentials, either `oidc` or `saml`.
+	//
+	// required: true
+	// in: path
+	Type CredentialsType `json:"type"`
+}
+
+// List Identity Credentials Provider Links Response
+//
+// swagger:response listIdentityCredentialsProviderLinks
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityCredentialsProviderLinksResponse struct {
+	// in: body
+	Body []CredentialsProviderLink
+}
+
+// swagger:route GET /admin/identities/{id}/credentials/{type}/providers identity listIdentityCredentialsProviderLinks
+//
+// # List the Provider Links of an Identity
+//
+// Lists the OpenID Connect or SAML providers which are linked to the identity.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listIdentityCredentialsProviderLinks
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) listProviderLinks(w http.ResponseWriter, r *http.Request) {
+	ct, err := providerLinkCredentialsType(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	providers, _, err := i.providerLinks(ct)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	links := make([]CredentialsProviderLink, len(providers))
+	for k, p := range providers {
+		links[k] = newCredentialsProviderLink(p)
+	}
+	h.r.Writer().Write(w, r, links)
+}
+
+// Add Identity Credentials Provider Link Parameters
+//
+// swagger:parameters addIdentityCredentialsProviderLink
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type addIdentityCredentialsProviderLink struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the type of credentials, either `oidc` or `saml`.
+	//
+	// required: true
+	// in: path
+	Type CredentialsType `json:"type"`
+
+	// in: body
+	Body AddIdentityCredentialsProviderLinkBody
+}
+
+// Add Identity Credentials Provider Link Body
+//
+// swagger:model addIdentityCredentialsProviderLinkBody
+type AddIdentityCredentialsProviderLinkBody struct {
+	// The ID of the provider, for example `google`.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// The subject of the identity at the provider. Usually the `sub` claim of the ID token.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// The organization of the provider.
+	Organization uuid.NullUUID `json:"organization,omitempty"`
+
+	// If set, the user can sign in with the provider without the subject being set first.
+	UseAutoLink bool `json:"use_auto_link,omitempty"`
+}
+
+// Identity Credentials Provider Link Response
+//
+// swagger:response identityCredentialsProviderLink
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type identityCredentialsProviderLinkResponse struct {
+	// in: body
+	Body CredentialsProviderLink
+}
+
+// swagger:route POST /admin/identities/{id}/credentials/{type}/providers identity addIdentityCredentialsProviderLink
+//
+// # Link a Provider to an Identity
+//
+// Links an OpenID Connect or SAML provider to the identity, so that the user can sign in with it. The link does
+// not contain any tokens until the user signs in with the provider.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: identityCredentialsProviderLink
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) addProviderLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ct, err := providerLinkCredentialsType(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var body AddIdentityCredentialsProviderLinkBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+	if body.Provider == "" || body.Subject == "" {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The provider and subject must be set.")))
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	link := CredentialsOIDCProvider{
+		Provider:    body.Provider,
+		Subject:     body.Subject,
+		UseAutoLink: body.UseAutoLink,
+	}
+	if body.Organization.Valid {
+		link.Organization = body.Organization.UUID.String()
+	}
+	if err := i.addProviderLink(ct, link); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(ctx, i, ManagerAllowWriteProtectedTraits); err != nil {
+		if errors.Is(err, sqlcon.ErrUniqueViolation()) {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReason("The provider and subject are already linked to another identity.")))
+			return
+		}
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewIdentityCredentialsLinked(ctx, i.ID, ct.String(), link.Provider, events.CredentialsLinkSourceAdminAPI))
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(
+			h.r.Config().SelfAdminURL(ctx),
+			"identities", i.ID.String(), "credentials", ct.String(), "providers",
+		).String(),
+		newCredentialsProviderLink(link),
+	)
+}
+
+// Remove Identity Credentials Provider Link Parameters
+//
+// swagger:parameters removeIdentityCredentialsProviderLink
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type removeIdentityCredentialsProviderLink struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the type of credentials, either `oidc` or `saml`.
+	//
+	// required: true
+	// in: path
+	Type CredentialsType `json:"type"`
+
+	// The ID of the provider.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+
+	// The subject of the identity at the provider.
+	//
+	// required: true
+	// in: path
+	Subject string `json:"subject"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/credentials/{type}/providers/{provider}/{subject} identity removeIdentityCredentialsProviderLink
+//
+// # Remove a Provider Link from an Identity
+//
+// Removes a single OpenID Connect or SAML provider link from the identity. The last link can only be removed if
+// the identity has another first factor credential.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) removeProviderLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ct, err := providerLinkCredentialsType(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	providers, _, err := i.providerLinks(ct)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	provider, identifier := r.PathValue("provider"), OIDCUniqueID(r.PathValue("provider"), r.PathValue("subject"))
+	if !slices.ContainsFunc(providers, func(p CredentialsOIDCProvider) bool { return OIDCUniqueID(p.Provider, p.Subject) == identifier }) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReasonf("The identifier `%s` is not linked to this identity.", identifier)))
+		return
+	}
+
+	if len(providers) < 2 {
+		firstFactor, err := h.r.IdentityManager().CountActiveFirstFactorCredentials(ctx, i)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+		if firstFactor < 2 {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("You cannot remove the last first factor credential.")))
+			return
+		}
+	}
+
+	if err := i.deleteCredentialOIDCSAMLFromIdentity(ct, identifier); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(ctx, i, ManagerAllowWriteProtectedTraits); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewIdentityCredentialsUnlinked(ctx, i.ID, ct.String(), provider, events.CredentialsLinkSourceAdminAPI))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func providerLinkCredentialsType(r *http.Request) (CredentialsType, error) {
+	ct := CredentialsType(r.PathValue("type"))
+	if ct != CredentialsTypeOIDC && ct != CredentialsTypeSAML {
+		return "", errors.WithStack(herodot.ErrBadRequest().WithReasonf("Provider links are only supported for %s and %s credentials.", CredentialsTypeOIDC, CredentialsTypeSAML))
+	}
+	return ct, nil
+}
+
+// providerLinks returns the providers linked by the OIDC or SAML credentials.
+// The credentials are nil if the identity has none of the type.
+func (i *Identity) providerLinks(ct CredentialsType) ([]CredentialsOIDCProvider, *Credentials, error) {
+	if _, ok := i.GetCredentials(ct); !ok {
+		return []CredentialsOIDCProvider{}, nil, nil
+	}
+
+	var conf CredentialsOIDC
+	creds, err := i.ParseCredentials(ct, &conf)
+	if err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+	}
+	if conf.Providers == nil {
+		conf.Providers = []CredentialsOIDCProvider{}
+	}
+	return conf.Providers, creds, nil
+}
+
+// addProviderLink adds the provider to the OIDC or SAML credentials.
+func (i *Identity) addProviderLink(ct CredentialsType, link CredentialsOIDCProvider) error {
+	providers, creds, err := i.providerLinks(ct)
+	if err != nil {
+		return err
+	}
+	if creds == nil {
+		creds = &Credentials{}
+	}
+
+	identifier := OIDCUniqueID(link.Provider, link.Subject)
+	if slices.Contains(creds.Identifiers, identifier) {
+		return errors.WithStack(herodot.ErrConflict().WithReasonf("The identifier `%s` is already linked to this identity.", identifier))
+	}
+
+	creds.Identifiers = append(creds.Identifiers, identifier)
+	return i.SetCredentialsWithConfig(ct, *creds, CredentialsOIDC{Providers: append(providers, link)})
+}
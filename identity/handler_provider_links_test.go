@@ -0,0 +1,148 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+)
+
+func TestHandlerProviderLinks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+	)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	do := func(t *testing.T, method, href string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var b bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&b).Encode(body))
+		}
+		req, err := http.NewRequest(method, adminTS.URL+href, &b)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	createIdentity := func(t *testing.T, creds map[identity.CredentialsType]identity.Credentials) *identity.Identity {
+		i := identity.NewIdentity("")
+		for ct, c := range creds {
+			i.SetCredentials(ct, c)
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	withPassword := func() map[identity.CredentialsType]identity.Credentials {
+		return map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Identifiers: []string{uuid.Must(uuid.NewV4()).String()},
+				Config:      []byte(`{"hashed_password":"some_valid_hash"}`),
+			},
+		}
+	}
+
+	t.Run("case=adds, lists, and removes provider links", func(t *testing.T) {
+		for _, ct := range []identity.CredentialsType{identity.CredentialsTypeOIDC, identity.CredentialsTypeSAML} {
+			t.Run("type="+ct.String(), func(t *testing.T) {
+				i := createIdentity(t, map[identity.CredentialsType]identity.Credentials{})
+				href := "/identities/" + i.ID.String() + "/credentials/" + ct.String() + "/providers"
+				subject := uuid.Must(uuid.NewV4()).String()
+				orgID := uuid.Must(uuid.NewV4())
+
+				assert.Empty(t, do(t, "GET", href, nil, http.StatusOK).Array())
+
+				res := do(t, "POST", href, identity.AddIdentityCredentialsProviderLinkBody{Provider: "google", Subject: subject}, http.StatusCreated)
+				assert.Equal(t, identity.OIDCUniqueID("google", subject), res.Get("identifier").String(), "%s", res.Raw)
+				do(t, "POST", href, identity.AddIdentityCredentialsProviderLinkBody{
+					Provider:     "github",
+					Subject:      subject,
+					Organization: uuid.NullUUID{UUID: orgID, Valid: true},
+				}, http.StatusCreated)
+
+				res = do(t, "GET", href, nil, http.StatusOK)
+				require.Len(t, res.Array(), 2, "%s", res.Raw)
+				assert.Equal(t, "google", res.Get("0.provider").String(), "%s", res.Raw)
+				assert.Equal(t, "github", res.Get("1.provider").String(), "%s", res.Raw)
+				assert.Equal(t, orgID.String(), res.Get("1.organization").String(), "%s", res.Raw)
+
+				actual, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, ct, identity.OIDCUniqueID("github", subject))
+				require.NoError(t, err)
+				assert.Equal(t, i.ID, actual.ID)
+
+				do(t, "DELETE", href+"/google/"+subject, nil, http.StatusNoContent)
+
+				res = do(t, "GET", href, nil, http.StatusOK)
+				require.Len(t, res.Array(), 1, "%s", res.Raw)
+				assert.Equal(t, "github", res.Get("0.provider").String(), "%s", res.Raw)
+
+				_, _, err = reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, ct, identity.OIDCUniqueID("google", subject))
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("case=rejects duplicate links", func(t *testing.T) {
+		subject := uuid.Must(uuid.NewV4()).String()
+		body := identity.AddIdentityCredentialsProviderLinkBody{Provider: "google", Subject: subject}
+
+		first := createIdentity(t, map[identity.CredentialsType]identity.Credentials{})
+		do(t, "POST", "/identities/"+first.ID.String()+"/credentials/oidc/providers", body, http.StatusCreated)
+		do(t, "POST", "/identities/"+first.ID.String()+"/credentials/oidc/providers", body, http.StatusConflict)
+
+		second := createIdentity(t, map[identity.CredentialsType]identity.Credentials{})
+		do(t, "POST", "/identities/"+second.ID.String()+"/credentials/oidc/providers", body, http.StatusConflict)
+	})
+
+	t.Run("case=does not remove the last first factor", func(t *testing.T) {
+		subject := uuid.Must(uuid.NewV4()).String()
+		i := createIdentity(t, map[identity.CredentialsType]identity.Credentials{})
+		href := "/identities/" + i.ID.String() + "/credentials/oidc/providers"
+		do(t, "POST", href, identity.AddIdentityCredentialsProviderLinkBody{Provider: "google", Subject: subject}, http.StatusCreated)
+
+		do(t, "DELETE", href+"/google/"+subject, nil, http.StatusBadRequest)
+
+		withOtherFactor := createIdentity(t, withPassword())
+		href = "/identities/" + withOtherFactor.ID.String() + "/credentials/oidc/providers"
+		do(t, "POST", href, identity.AddIdentityCredentialsProviderLinkBody{Provider: "google", Subject: uuid.Must(uuid.NewV4()).String()}, http.StatusCreated)
+		res := do(t, "GET", href, nil, http.StatusOK)
+		do(t, "DELETE", href+"/google/"+res.Get("0.subject").String(), nil, http.StatusNoContent)
+	})
+
+	t.Run("case=returns errors for invalid requests", func(t *testing.T) {
+		i := createIdentity(t, withPassword())
+		href := "/identities/" + i.ID.String() + "/credentials/"
+
+		do(t, "GET", href+"password/providers", nil, http.StatusBadRequest)
+		do(t, "POST", href+"oidc/providers", identity.AddIdentityCredentialsProviderLinkBody{Provider: "google"}, http.StatusBadRequest)
+		do(t, "GET", "/identities/"+uuid.Must(uuid.NewV4()).String()+"/credentials/oidc/providers", nil, http.StatusNotFound)
+		do(t, "DELETE", href+"oidc/providers/google/unknown", nil, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+)
+
+const (
+	RouteSchemaMigrationCollection = RouteCollection + "/schema-migrations"
+	RouteSchemaMigrationBatches    = RouteSchemaMigrationCollection + "/{id}/batches"
+)
+
+// List Identity Schema Migrations Response
+//
+// swagger:response listIdentitySchemaMigrations
+type _ struct {
+	// in: body
+	Body []SchemaMigration
+}
+
+// swagger:route GET /admin/identities/schema-migrations identity listIdentitySchemaMigrations
+//
+// # List Identity Schema Migrations
+//
+// Lists all identity schema migrations configured in `identity.schema_migrations`.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listIdentitySchemaMigrations
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) listSchemaMigrations(w http.ResponseWriter, r *http.Request) {
+	ms, err := h.r.Config().IdentitySchemaMigrations(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	result := make([]SchemaMigration, len(ms))
+	for k, m := range ms {
+		result[k] = NewSchemaMigration(m)
+	}
+	h.r.Writer().Write(w, r, result)
+}
+
+// Run Identity Schema Migration Batch Parameters
+//
+// swagger:parameters runIdentitySchemaMigrationBatch
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type runIdentitySchemaMigrationBatch struct {
+	// ID is the ID of the identity schema migration.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body RunIdentitySchemaMigrationBatchBody
+}
+
+// Run Identity Schema Migration Batch Body
+//
+// swagger:model runIdentitySchemaMigrationBatchBody
+type RunIdentitySchemaMigrationBatchBody struct {
+	// If set to true, identities are transformed and validated but not updated.
+	DryRun bool `json:"dry_run"`
+
+	// The number of identities to process. Defaults to 100, at most 1000.
+	BatchSize int `json:"batch_size"`
+
+	// The `next_page_token` of the previous batch's report. Omit to start with
+	// the first identity.
+	PageToken string `json:"page_token"`
+}
+
+// swagger:route POST /admin/identities/schema-migrations/{id}/batches identity runIdentitySchemaMigrationBatch
+//
+// # Run an Identity Schema Migration Batch
+//
+// Migrates the next batch of identities which use the migration's source schema to the target schema by applying
+// the migration's Jsonnet transform to their traits and metadata. Every migrated identity is validated against the
+// target schema and the report contains the outcome for each identity.
+//
+// Use `dry_run` to validate the migration without updating identities. To process all identities, repeat the request
+// with `page_token` set to the `next_page_token` of the previous report until it is empty. Batches can be resumed
+// at any time.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identitySchemaMigrationReport
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) runSchemaMigrationBatch(w http.ResponseWriter, r *http.Request) {
+	var body RunIdentitySchemaMigrationBatchBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	report, err := h.r.IdentitySchemaMigrator().RunBatch(r.Context(), r.PathValue("id"), SchemaMigrationBatchOptions{
+		DryRun:    body.DryRun,
+		BatchSize: body.BatchSize,
+		PageToken: body.PageToken,
+	})
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, report)
+}
@@ -1010,7 +1010,7 @@ func TestHandler(t *testing.T) {
 
 			// Verify TOTP credentials were created
 			require.Contains(t, actual.Credentials, identity.CredentialsTypeTOTP)
-			totpConfig := gjson.GetBytes(actual.Credentials[identity.CredentialsTypeTOTP].Config, "totp_url")
+			totpConfig := gjson.GetBytes(actual.Credentials[identity.CredentialsTypeTOTP].Config, "devices.0.totp_url")
 			assert.Equal(t, "totp://example.com?secret=JBSWY3DPEHPK3PXP&issuer=ORY", totpConfig.String(), "TOTP secret should be stored correctly")
 
 			// Now update the identity with new TOTP credentials
@@ -1031,7 +1031,7 @@ func TestHandler(t *testing.T) {
 
 			// Check if TOTP credentials were updated correctly
 			require.Contains(t, actual.Credentials, identity.CredentialsTypeTOTP)
-			totpConfig = gjson.GetBytes(actual.Credentials[identity.CredentialsTypeTOTP].Config, "totp_url")
+			totpConfig = gjson.GetBytes(actual.Credentials[identity.CredentialsTypeTOTP].Config, "devices.0.totp_url")
 			assert.Equal(t, "totp://example.com?secret=NBSWY3DPEHPK3PXQ&issuer=ORY", totpConfig.String(), "TOTP secret should be updated correctly")
 
 			// Verify that the traits were also updated
@@ -1041,6 +1041,7 @@ func TestHandler(t *testing.T) {
 			snapshotx.SnapshotT(t, identity.WithCredentialsAndAdminMetadataInJSON(*actual),
 				snapshotx.ExceptPaths("id", "schema_url", "state_changed_at", "created_at", "updated_at",
 					"credentials.totp.created_at", "credentials.totp.updated_at",
+					"credentials.totp.config.devices.0.id", "credentials.totp.config.devices.0.added_at",
 					"credentials.password.created_at", "credentials.password.updated_at"))
 		})
 
@@ -2006,7 +2007,7 @@ func TestHandler(t *testing.T) {
 				}
 
 				res := send(t, ts, "PATCH", "/identities/"+i.ID.String(), http.StatusBadRequest, &patch)
-				assert.EqualValues(t, "The supplied state ('invalid-value') was not valid. Valid states are ('active', 'inactive').", res.Get("error.reason").String(), "%s", res.Raw)
+				assert.EqualValues(t, "The supplied state ('invalid-value') was not valid. Valid states are ('active', 'inactive', 'pending_approval').", res.Get("error.reason").String(), "%s", res.Raw)
 
 				res = get(t, ts, "/identities/"+i.ID.String(), http.StatusOK)
 				// Assert that the schema ID is unchanged
@@ -26,7 +26,7 @@ import (
 
 // An Identity's State
 //
-// The state can either be `active` or `inactive`.
+// The state can either be `active`, `inactive`, or `pending_approval`.
 //
 // swagger:enum State
 type State string
@@ -34,11 +34,15 @@ type State string
 const (
 	StateActive   State = "active"
 	StateInactive State = "inactive"
+	// StatePendingApproval is the state of identities which registered while
+	// registration approval is required and which an administrator has not
+	// yet approved or rejected.
+	StatePendingApproval State = "pending_approval"
 )
 
 func (lt State) IsValid() error {
 	switch lt {
-	case StateActive, StateInactive:
+	case StateActive, StateInactive, StatePendingApproval:
 		return nil
 	}
 	return errors.New("identity state is not valid")
@@ -102,6 +106,10 @@ type Identity struct {
 	// StateChangedAt contains the last time when the identity's state changed.
 	StateChangedAt *sqlxx.NullTime `json:"state_changed_at,omitempty" faker:"-" db:"state_changed_at"`
 
+	// LastAuthenticatedAt contains the last time the identity signed in, signed up
+	// or recovered their account.
+	LastAuthenticatedAt *sqlxx.NullTime `json:"last_authenticated_at,omitempty" faker:"-" db:"last_authenticated_at" rw:"r"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_url`.
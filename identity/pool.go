@@ -5,6 +5,7 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/crdbx"
@@ -45,8 +46,11 @@ type (
 		CredentialsIdentifier        string
 		CredentialsIdentifierSimilar string
 		DeclassifyCredentials        []CredentialsType
+		DeclassifyPII                bool
 		KeySetPagination             []keysetpagination.Option
 		OrganizationID               uuid.UUID
+		SchemaID                     string
+		State                        State
 		ConsistencyLevel             crdbx.ConsistencyLevel
 		StatementTransformer         func(string) string
 
@@ -119,6 +123,10 @@ type (
 		// UpdateIdentityColumns updates targeted columns of an identity.
 		UpdateIdentityColumns(ctx context.Context, i *Identity, columns ...string) error
 
+		// UpdateIdentityLastAuthenticatedAt records when the identity last
+		// authenticated. It does not fail if the identity does not exist.
+		UpdateIdentityLastAuthenticatedAt(ctx context.Context, id uuid.UUID, at time.Time) error
+
 		// GetIdentityConfidential returns the identity including it's raw credentials.
 		//
 		// This should only be used internally. Please be aware that this method uses HydrateIdentityAssociations
@@ -0,0 +1,47 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ProfilePrompt records that the identity was asked for an optional trait
+// which the identity schema marks as "ask once". Such traits are not asked
+// for again, regardless of whether the user filled them in.
+//
+// swagger:ignore
+type ProfilePrompt struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	IdentityID uuid.UUID `json:"identity_id" db:"identity_id"`
+
+	// Trait is the dot-separated path of the trait, e.g. `traits.website`.
+	Trait string `json:"trait" db:"trait"`
+
+	PromptedAt time.Time `json:"prompted_at" db:"prompted_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	NID        uuid.UUID `json:"-" db:"nid"`
+}
+
+func (ProfilePrompt) TableName() string { return "identity_profile_prompts" }
+
+type (
+	ProfilePromptPersister interface {
+		// ListProfilePrompts returns the traits the identity was already
+		// prompted for.
+		ListProfilePrompts(ctx context.Context, identityID uuid.UUID) ([]string, error)
+
+		// CreateProfilePrompts records that the identity was prompted for the
+		// given traits. Traits which were already recorded are ignored.
+		CreateProfilePrompts(ctx context.Context, identityID uuid.UUID, traits []string) error
+	}
+
+	ProfilePromptPersistenceProvider interface {
+		ProfilePromptPersister() ProfilePromptPersister
+	}
+)
@@ -0,0 +1,411 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/gofrs/uuid"
+	"github.com/mohae/deepcopy"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/pagination/keysetpagination"
+	"github.com/ory/x/sqlxx"
+)
+
+const (
+	// SchemaMigrationStatusMigrated indicates that the identity was migrated.
+	SchemaMigrationStatusMigrated SchemaMigrationStatus = "migrated"
+
+	// SchemaMigrationStatusValid indicates that the migrated identity would be
+	// valid. It is only reported for dry runs.
+	SchemaMigrationStatusValid SchemaMigrationStatus = "valid"
+
+	// SchemaMigrationStatusInvalid indicates that the migrated identity does not
+	// validate against the target schema.
+	SchemaMigrationStatusInvalid SchemaMigrationStatus = "invalid"
+
+	// SchemaMigrationStatusError indicates that the transform or the update
+	// failed.
+	SchemaMigrationStatusError SchemaMigrationStatus = "error"
+
+	DefaultSchemaMigrationBatchSize = 100
+	MaxSchemaMigrationBatchSize     = 1000
+)
+
+var schemaMigrationTransformCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     10 << 20, // 10MB
+	NumCounters: 100_000,
+	BufferItems: 64,
+})
+
+type (
+	// Identity Schema Migration Status
+	//
+	// swagger:enum identitySchemaMigrationStatus
+	SchemaMigrationStatus string
+
+	// Identity Schema Migration
+	//
+	// swagger:model identitySchemaMigration
+	SchemaMigration struct {
+		// The migration's ID.
+		//
+		// required: true
+		ID string `json:"id"`
+
+		// The ID of the identity schema identities are migrated from.
+		//
+		// required: true
+		From string `json:"from"`
+
+		// The ID of the identity schema identities are migrated to.
+		//
+		// required: true
+		To string `json:"to"`
+
+		// Whether identities are migrated when they sign in.
+		//
+		// required: true
+		Lazy bool `json:"lazy"`
+	}
+
+	// Identity Schema Migration Result
+	//
+	// swagger:model identitySchemaMigrationResult
+	SchemaMigrationResult struct {
+		// The ID of the identity.
+		//
+		// required: true
+		IdentityID uuid.UUID `json:"identity_id"`
+
+		// The outcome of the migration for this identity.
+		//
+		// required: true
+		Status SchemaMigrationStatus `json:"status"`
+
+		// The error, if the status is `invalid` or `error`.
+		Error *herodot.DefaultError `json:"error,omitempty"`
+	}
+
+	// Identity Schema Migration Report
+	//
+	// swagger:model identitySchemaMigrationReport
+	SchemaMigrationReport struct {
+		// The migration's ID.
+		//
+		// required: true
+		MigrationID string `json:"migration_id"`
+
+		// Whether this was a dry run. Dry runs do not modify identities.
+		//
+		// required: true
+		DryRun bool `json:"dry_run"`
+
+		// The results for the identities of this batch.
+		//
+		// required: true
+		Results []SchemaMigrationResult `json:"results"`
+
+		// The number of identities which were migrated, or would be migrated in
+		// a dry run.
+		//
+		// required: true
+		Succeeded int `json:"succeeded"`
+
+		// The number of identities which could not be migrated.
+		//
+		// required: true
+		Failed int `json:"failed"`
+
+		// The token to pass as `page_token` to process the next batch. Empty
+		// if this was the last batch.
+		NextPageToken string `json:"next_page_token,omitempty"`
+	}
+
+	SchemaMigrationBatchOptions struct {
+		DryRun    bool
+		BatchSize int
+		PageToken string
+	}
+
+	schemaMigratorDependencies interface {
+		config.Provider
+		PrivilegedPoolProvider
+		ValidationProvider
+		jsonnetsecure.VMProvider
+		httpx.ClientProvider
+		logrusx.Provider
+		otelx.Provider
+	}
+	SchemaMigratorProvider interface {
+		IdentitySchemaMigrator() *SchemaMigrator
+	}
+	SchemaMigrator struct {
+		r schemaMigratorDependencies
+	}
+)
+
+func NewSchemaMigrator(r schemaMigratorDependencies) *SchemaMigrator {
+	return &SchemaMigrator{r: r}
+}
+
+// NewSchemaMigration converts the configured migration to its API
+// representation.
+func NewSchemaMigration(m config.IdentitySchemaMigration) SchemaMigration {
+	return SchemaMigration{ID: m.ID, From: m.From, To: m.To, Lazy: m.Lazy}
+}
+
+// Transform runs the migration's Jsonnet transform and returns a copy of the
+// identity using the target schema. The result is not validated.
+func (m *SchemaMigrator) Transform(ctx context.Context, migration *config.IdentitySchemaMigration, i *Identity) (_ *Identity, err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.SchemaMigrator.Transform", trace.WithAttributes(
+		attribute.String("migration.id", migration.ID),
+		attribute.Stringer("identity.id", i.ID)))
+	defer otelx.End(span, &err)
+
+	if i.SchemaID != migration.From {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(
+			"The identity uses identity schema \"%s\" but migration \"%s\" migrates identities from identity schema \"%s\".",
+			i.SchemaID, migration.ID, migration.From))
+	}
+
+	snippet, err := fetcher.NewFetcher(
+		fetcher.WithClient(m.r.HTTPClient(ctx)),
+		fetcher.WithCache(schemaMigrationTransformCache, 60*time.Minute),
+	).FetchContext(ctx, migration.TransformURL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf(
+			"Unable to fetch the Jsonnet transform of identity schema migration \"%s\": %s", migration.ID, err))
+	}
+
+	coalesce := func(b []byte) json.RawMessage {
+		if len(b) == 0 || string(b) == "null" {
+			return json.RawMessage("{}")
+		}
+		return b
+	}
+	input, err := json.Marshal(map[string]any{
+		"id":              i.ID,
+		"schema_id":       i.SchemaID,
+		"traits":          coalesce(i.Traits),
+		"metadata_public": coalesce(i.MetadataPublic),
+		"metadata_admin":  coalesce(i.MetadataAdmin),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	vm, err := m.r.JsonnetVM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vm.ExtCode("identity", string(input))
+
+	evaluated, err := vm.EvaluateAnonymousSnippet(migration.TransformURL, snippet.String())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(
+			"The Jsonnet transform of identity schema migration \"%s\" failed: %s", migration.ID, err))
+	}
+
+	traits := gjson.Get(evaluated, "identity.traits")
+	if !traits.IsObject() {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(
+			"The Jsonnet transform of identity schema migration \"%s\" did not return an object for key identity.traits. Please check your Jsonnet code!", migration.ID))
+	}
+
+	migrated := deepcopy.Copy(i).(*Identity)
+	migrated.SchemaID = migration.To
+	migrated.Traits = Traits(traits.Raw)
+	for key, target := range map[string]*sqlxx.NullJSONRawMessage{
+		"identity.metadata_public": &migrated.MetadataPublic,
+		"identity.metadata_admin":  &migrated.MetadataAdmin,
+	} {
+		// Metadata is left untouched unless the transform returns it.
+		if value := gjson.Get(evaluated, key); value.Exists() {
+			if value.Type == gjson.Null {
+				*target = nil
+			} else {
+				*target = sqlxx.NullJSONRawMessage(value.Raw)
+			}
+		}
+	}
+
+	return migrated, nil
+}
+
+// MigrateIdentity migrates a single identity. In dry runs, the identity is
+// transformed and validated but not updated.
+func (m *SchemaMigrator) MigrateIdentity(ctx context.Context, migration *config.IdentitySchemaMigration, id uuid.UUID, dryRun bool) SchemaMigrationResult {
+	_, result := m.migrateIdentity(ctx, migration, id, dryRun)
+	return result
+}
+
+func (m *SchemaMigrator) migrateIdentity(ctx context.Context, migration *config.IdentitySchemaMigration, id uuid.UUID, dryRun bool) (*Identity, SchemaMigrationResult) {
+	result := SchemaMigrationResult{IdentityID: id, Status: SchemaMigrationStatusError}
+
+	original, err := m.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		result.Error = herodot.ToDefaultError(err, "")
+		return nil, result
+	}
+
+	migrated, err := m.Transform(ctx, migration, original)
+	if err != nil {
+		result.Error = herodot.ToDefaultError(err, "")
+		return nil, result
+	}
+
+	if err := m.r.IdentityValidator().Validate(ctx, migrated); err != nil {
+		result.Status = SchemaMigrationStatusInvalid
+		if validationErr := new(schema.ValidationError); errors.As(err, &validationErr) {
+			result.Error = herodot.ErrBadRequest().
+				WithReasonf("The migrated identity is invalid: %s", validationErr.Message).
+				WithDetail("messages", validationErr.Messages)
+		} else {
+			result.Error = herodot.ToDefaultError(err, "")
+		}
+		return nil, result
+	}
+
+	if dryRun {
+		result.Status = SchemaMigrationStatusValid
+		return migrated, result
+	}
+
+	if err := m.r.PrivilegedIdentityPool().UpdateIdentity(ctx, migrated); err != nil {
+		result.Error = herodot.ToDefaultError(err, "")
+		return nil, result
+	}
+
+	result.Status = SchemaMigrationStatusMigrated
+	return migrated, result
+}
+
+// RunBatch migrates the next batch of identities using the migration's source
+// schema. Pass the report's NextPageToken as PageToken to process the
+// following batch.
+func (m *SchemaMigrator) RunBatch(ctx context.Context, migrationID string, opts SchemaMigrationBatchOptions) (_ *SchemaMigrationReport, err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.SchemaMigrator.RunBatch", trace.WithAttributes(
+		attribute.String("migration.id", migrationID),
+		attribute.Bool("dry_run", opts.DryRun)))
+	defer otelx.End(span, &err)
+
+	migration, err := m.r.Config().IdentitySchemaMigration(ctx, migrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	size := opts.BatchSize
+	if size <= 0 {
+		size = DefaultSchemaMigrationBatchSize
+	} else if size > MaxSchemaMigrationBatchSize {
+		size = MaxSchemaMigrationBatchSize
+	}
+
+	pageOpts := []keysetpagination.Option{keysetpagination.WithSize(size)}
+	if opts.PageToken != "" {
+		if _, err := uuid.FromString(opts.PageToken); err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The page token \"%s\" is invalid.", opts.PageToken))
+		}
+		pageOpts = append(pageOpts, keysetpagination.WithToken(keysetpagination.StringPageToken(opts.PageToken)))
+	}
+
+	identities, page, err := m.r.PrivilegedIdentityPool().ListIdentities(ctx, ListIdentityParameters{
+		Expand:           ExpandNothing,
+		SchemaID:         migration.From,
+		KeySetPagination: pageOpts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SchemaMigrationReport{
+		MigrationID: migration.ID,
+		DryRun:      opts.DryRun,
+		Results:     make([]SchemaMigrationResult, 0, len(identities)),
+	}
+	for _, i := range identities {
+		result := m.MigrateIdentity(ctx, migration, i.ID, opts.DryRun)
+		if result.Error != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	if len(identities) > 0 && !page.IsLast() {
+		report.NextPageToken = identities[len(identities)-1].ID.String()
+	}
+
+	span.SetAttributes(
+		attribute.Int("succeeded", report.Succeeded),
+		attribute.Int("failed", report.Failed))
+	return report, nil
+}
+
+// MigrateOnLogin applies all lazy migrations matching the identity's schema
+// and updates the identity in place. Migrations are chained, so an identity
+// can be moved through several schema versions at once.
+func (m *SchemaMigrator) MigrateOnLogin(ctx context.Context, i *Identity) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.SchemaMigrator.MigrateOnLogin")
+	defer otelx.End(span, &err)
+
+	migrations, err := m.r.Config().IdentitySchemaMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every migration is applied at most once to prevent cycles.
+	applied := make(map[string]struct{}, len(migrations))
+	for {
+		var migration *config.IdentitySchemaMigration
+		for k := range migrations {
+			if _, ok := applied[migrations[k].ID]; !ok && migrations[k].Lazy && migrations[k].From == i.SchemaID {
+				migration = &migrations[k]
+				break
+			}
+		}
+		if migration == nil {
+			return nil
+		}
+		applied[migration.ID] = struct{}{}
+
+		migrated, result := m.migrateIdentity(ctx, migration, i.ID, false)
+		if result.Error != nil {
+			return errors.WithStack(result.Error)
+		}
+
+		m.r.Logger().
+			WithField("identity_id", i.ID).
+			WithField("migration_id", migration.ID).
+			Info("Migrated identity to a new identity schema on login.")
+
+		i.SchemaID = migrated.SchemaID
+		i.Traits = migrated.Traits
+		i.MetadataPublic = migrated.MetadataPublic
+		i.MetadataAdmin = migrated.MetadataAdmin
+		i.VerifiableAddresses = migrated.VerifiableAddresses
+		i.RecoveryAddresses = migrated.RecoveryAddresses
+		i.UpdatedAt = migrated.UpdatedAt
+		if err := m.r.PrivilegedIdentityPool().InjectTraitsSchemaURL(ctx, i); err != nil {
+			return err
+		}
+	}
+}
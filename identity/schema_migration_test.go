@@ -0,0 +1,127 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestSchemaMigrator(t *testing.T) {
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(map[string]interface{}{
+			config.ViperKeyDefaultIdentitySchemaID: "customer",
+			config.ViperKeyIdentitySchemaMigrations: []map[string]interface{}{
+				{
+					"id":        "customer-v1-to-v2",
+					"from":      "customer",
+					"to":        "customer.v2",
+					"transform": "file://./stub/schema-migration/transform.jsonnet",
+					"lazy":      true,
+				},
+			},
+		}),
+		configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+			"customer":    "file://./stub/schema-migration/v1.schema.json",
+			"customer.v2": "file://./stub/schema-migration/v2.schema.json",
+		})),
+	)
+	ctx := t.Context()
+
+	createIdentity := func(t *testing.T, traits string) *identity.Identity {
+		i := identity.NewIdentity("customer")
+		i.Traits = identity.Traits(traits)
+		i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"tier":"gold"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	t.Run("case=transforms traits and metadata", func(t *testing.T) {
+		i := createIdentity(t, `{"email":"transform@ory.sh","name":"Ada Lovelace"}`)
+		migration, err := reg.Config().IdentitySchemaMigration(ctx, "customer-v1-to-v2")
+		require.NoError(t, err)
+
+		migrated, err := reg.IdentitySchemaMigrator().Transform(ctx, migration, i)
+		require.NoError(t, err)
+
+		assert.Equal(t, "customer.v2", migrated.SchemaID)
+		assert.JSONEq(t, `{"email":"transform@ory.sh","name":{"first":"Ada","last":"Lovelace"}}`, string(migrated.Traits))
+		assert.JSONEq(t, `{"tier":"gold","migrated_from":"customer"}`, string(migrated.MetadataAdmin))
+		assert.Equal(t, "customer", i.SchemaID, "the original identity must not be modified")
+	})
+
+	t.Run("case=runs batches", func(t *testing.T) {
+		valid := createIdentity(t, `{"email":"batch@ory.sh","name":"Grace Hopper"}`)
+		invalid := createIdentity(t, `{"email":"invalid@ory.sh","name":"Prince"}`)
+
+		run := func(t *testing.T, dryRun bool) map[string]identity.SchemaMigrationStatus {
+			statuses := map[string]identity.SchemaMigrationStatus{}
+			var pageToken string
+			for {
+				report, err := reg.IdentitySchemaMigrator().RunBatch(ctx, "customer-v1-to-v2", identity.SchemaMigrationBatchOptions{
+					DryRun:    dryRun,
+					BatchSize: 1,
+					PageToken: pageToken,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, dryRun, report.DryRun)
+				for _, result := range report.Results {
+					statuses[result.IdentityID.String()] = result.Status
+				}
+				if report.NextPageToken == "" {
+					return statuses
+				}
+				pageToken = report.NextPageToken
+			}
+		}
+
+		statuses := run(t, true)
+		assert.Equal(t, identity.SchemaMigrationStatusValid, statuses[valid.ID.String()])
+		assert.Equal(t, identity.SchemaMigrationStatusInvalid, statuses[invalid.ID.String()])
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, valid.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, "customer", actual.SchemaID, "dry runs must not update identities")
+
+		statuses = run(t, false)
+		assert.Equal(t, identity.SchemaMigrationStatusMigrated, statuses[valid.ID.String()])
+		assert.Equal(t, identity.SchemaMigrationStatusInvalid, statuses[invalid.ID.String()])
+
+		actual, err = reg.PrivilegedIdentityPool().GetIdentity(ctx, valid.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, "customer.v2", actual.SchemaID)
+		assert.Equal(t, "Grace", gjson.GetBytes(actual.Traits, "name.first").String())
+
+		actual, err = reg.PrivilegedIdentityPool().GetIdentity(ctx, invalid.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, "customer", actual.SchemaID, "invalid identities must not be updated")
+	})
+
+	t.Run("case=migrates on login", func(t *testing.T) {
+		i := createIdentity(t, `{"email":"lazy@ory.sh","name":"Alan Turing"}`)
+
+		require.NoError(t, reg.IdentitySchemaMigrator().MigrateOnLogin(ctx, i))
+		assert.Equal(t, "customer.v2", i.SchemaID)
+		assert.Equal(t, "Turing", gjson.GetBytes(i.Traits, "name.last").String())
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, i.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, "customer.v2", actual.SchemaID)
+	})
+
+	t.Run("case=unknown migration", func(t *testing.T) {
+		_, err := reg.IdentitySchemaMigrator().RunBatch(ctx, "does-not-exist", identity.SchemaMigrationBatchOptions{})
+		require.Error(t, err)
+	})
+}
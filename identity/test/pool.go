@@ -27,7 +27,9 @@ import (
 	idpersistence "github.com/ory/kratos/persistence/sql/identity"
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
+	"github.com/ory/pop/v6"
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/contextx"
 	"github.com/ory/x/crdbx"
@@ -79,6 +81,11 @@ func TestPool(ctx context.Context, p persistence.Persister, m *identity.Manager,
 			URL:    urlx.ParseOrPanic("file://./stub/phone.schema.json"),
 			RawURL: "file://./stub/phone.schema.json",
 		}
+		uniqueSchema := schema.Schema{
+			ID:     "unique",
+			URL:    urlx.ParseOrPanic("file://./stub/unique.schema.json"),
+			RawURL: "file://./stub/unique.schema.json",
+		}
 		ctx := contextx.WithConfigValues(ctx, map[string]any{
 			config.ViperKeyPublicBaseURL: exampleServerURL.String(),
 			config.ViperKeyIdentitySchemas: []config.Schema{
@@ -102,6 +109,10 @@ func TestPool(ctx context.Context, p persistence.Persister, m *identity.Manager,
 					ID:  phoneEmailSchema.ID,
 					URL: phoneEmailSchema.RawURL,
 				},
+				{
+					ID:  uniqueSchema.ID,
+					URL: uniqueSchema.RawURL,
+				},
 			},
 		})
 
@@ -775,6 +786,107 @@ func TestPool(ctx context.Context, p persistence.Persister, m *identity.Manager,
 			}
 		})
 
+		t.Run("case=fail on duplicate unique traits", func(t *testing.T) {
+			uniqueIdentity := func(username string) *identity.Identity {
+				i := identity.NewIdentity(uniqueSchema.ID)
+				i.Traits = identity.Traits(`{"username":"` + username + `"}`)
+				return i
+			}
+			assertDuplicateTrait := func(t *testing.T, err error) {
+				t.Helper()
+				require.NotErrorIs(t, err, sqlcon.ErrUniqueViolation())
+				var validationErr *schema.ValidationError
+				require.ErrorAs(t, err, &validationErr, "%+v", err)
+				assert.Equal(t, "/traits/username", validationErr.InstancePtr)
+				require.Len(t, validationErr.Messages, 1)
+				assert.Equal(t, text.ErrorValidationDuplicateTrait, validationErr.Messages[0].ID)
+			}
+
+			username := randx.MustString(16, randx.AlphaLowerNum)
+			initial := uniqueIdentity(username)
+			require.NoError(t, p.CreateIdentity(ctx, initial))
+			createdIDs = append(createdIDs, initial.ID)
+
+			t.Run("case=create", func(t *testing.T) {
+				duplicate := uniqueIdentity(username)
+				assertDuplicateTrait(t, p.CreateIdentity(ctx, duplicate))
+
+				_, err := p.GetIdentity(ctx, duplicate.ID, identity.ExpandNothing)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			})
+
+			t.Run("case=update", func(t *testing.T) {
+				other := uniqueIdentity(randx.MustString(16, randx.AlphaLowerNum))
+				require.NoError(t, p.CreateIdentity(ctx, other))
+				createdIDs = append(createdIDs, other.ID)
+
+				other.Traits = identity.Traits(`{"username":"` + username + `"}`)
+				assertDuplicateTrait(t, p.UpdateIdentity(ctx, other))
+
+				actual, err := p.GetIdentity(ctx, other.ID, identity.ExpandNothing)
+				require.NoError(t, err)
+				assert.NotEqual(t, username, gjson.GetBytes(actual.Traits, "username").String())
+			})
+
+			t.Run("case=update own value", func(t *testing.T) {
+				require.NoError(t, p.UpdateIdentity(ctx, initial))
+			})
+
+			t.Run("succeeds on different network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.NoError(t, p.CreateIdentity(ctx, uniqueIdentity(username)))
+			})
+
+			t.Run("case=create batch with a value indexed concurrently", func(t *testing.T) {
+				if dbname != "postgres" {
+					t.Skip("the violated index aborts the transaction only on PostgreSQL")
+				}
+
+				// The raced identity is created but not committed while the
+				// batch is checked. Inserting the duplicate then blocks on the
+				// unique index and fails once the other transaction commits.
+				raced := uniqueIdentity(randx.MustString(16, randx.AlphaLowerNum))
+				started, release, done := make(chan struct{}), make(chan struct{}), make(chan error, 1)
+				go func() {
+					done <- p.Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+						if err := p.CreateIdentity(ctx, raced); err != nil {
+							close(started)
+							return err
+						}
+						close(started)
+						<-release
+						return nil
+					})
+				}()
+				<-started
+				go func() {
+					time.Sleep(500 * time.Millisecond)
+					close(release)
+				}()
+
+				first := uniqueIdentity(randx.MustString(16, randx.AlphaLowerNum))
+				duplicate := uniqueIdentity(gjson.GetBytes(raced.Traits, "username").String())
+				last := uniqueIdentity(randx.MustString(16, randx.AlphaLowerNum))
+				err := p.CreateIdentities(ctx, first, duplicate, last)
+				require.NoError(t, <-done)
+				createdIDs = append(createdIDs, raced.ID)
+
+				errWithCtx := new(identity.CreateIdentitiesError)
+				require.ErrorAsf(t, err, &errWithCtx, "%#v", err)
+				assert.NotNil(t, errWithCtx.Find(duplicate))
+				assert.Nil(t, errWithCtx.Find(first))
+				assert.Nil(t, errWithCtx.Find(last))
+
+				for _, id := range []*identity.Identity{first, last} {
+					_, err := p.GetIdentity(ctx, id.ID, identity.ExpandNothing)
+					require.NoError(t, err)
+					createdIDs = append(createdIDs, id.ID)
+				}
+				_, err = p.GetIdentity(ctx, duplicate.ID, identity.ExpandNothing)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			})
+		})
+
 		t.Run("case=fail on duplicate credential identifiers if type is oidc", func(t *testing.T) {
 			oidcID := randx.MustString(16, randx.AlphaLowerNum)
 			initial := oidcIdentity("", oidcID)
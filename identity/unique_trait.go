@@ -0,0 +1,219 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// UniqueTraitScopeGlobal enforces uniqueness of a trait across all
+	// identities of a network.
+	UniqueTraitScopeGlobal = "global"
+
+	// UniqueTraitScopeOrganization enforces uniqueness of a trait across all
+	// identities of the same organization. Identities without an organization
+	// share one scope.
+	UniqueTraitScopeOrganization = "organization"
+)
+
+type (
+	// UniqueTraitPath is a trait which the identity schema marks as unique.
+	UniqueTraitPath struct {
+		Path  []string
+		Scope string
+	}
+
+	// UniqueTrait is an entry of the index which enforces the uniqueness of
+	// traits marked with the `ory.sh/kratos` extension keyword `unique`.
+	//
+	// swagger:ignore
+	UniqueTrait struct {
+		ID uuid.UUID `json:"id" db:"id"`
+
+		// IdentityID is the identity owning the trait value.
+		IdentityID uuid.UUID `json:"identity_id" db:"identity_id"`
+
+		// NID is the network ID (multi-tenant discriminator).
+		NID uuid.UUID `json:"-" db:"nid"`
+
+		// Path is the trait's path as declared in the identity schema, e.g.
+		// `employee_number` or `usernames.#`.
+		Path string `json:"path" db:"trait_path"`
+
+		// Scope is either `global` or the ID of the organization the trait is
+		// unique in.
+		Scope string `json:"scope" db:"scope"`
+
+		// ValueHash is the HMAC of the trait's value, keyed with the current
+		// default secret.
+		ValueHash string `json:"-" db:"value_hash"`
+
+		// ValueHashes are the HMACs of the trait's value keyed with every
+		// configured default secret, so that values indexed before the secrets
+		// were rotated are still found.
+		ValueHashes []string `json:"-" db:"-"`
+
+		// InstancePtr is the JSON pointer to the concrete trait value, used for
+		// reporting conflicts.
+		InstancePtr string `json:"-" db:"-"`
+
+		CreatedAt time.Time `json:"created_at" db:"created_at"`
+		UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	}
+
+	// UniqueTraitPersister maintains the unique traits index.
+	UniqueTraitPersister interface {
+		// IndexUniqueTraits replaces the index entries of the identity with the
+		// given traits. Traits which conflict with another identity's entries
+		// are skipped and the conflicting entries are returned.
+		IndexUniqueTraits(ctx context.Context, identityID uuid.UUID, traits []UniqueTrait) ([]UniqueTrait, error)
+	}
+
+	// UniqueTraitPersistenceProvider provides access to the persister.
+	UniqueTraitPersistenceProvider interface {
+		UniqueTraitPersister() UniqueTraitPersister
+	}
+)
+
+func (UniqueTrait) TableName() string {
+	return "identity_unique_traits"
+}
+
+// Key identifies the index entry independently of the identity owning it.
+func (t UniqueTrait) Key() string {
+	return t.Path + "|" + t.Scope + "|" + t.ValueHash
+}
+
+// Keys returns the keys of the index entry for all of its value hashes.
+func (t UniqueTrait) Keys() []string {
+	keys := []string{t.Key()}
+	for _, hash := range t.ValueHashes {
+		keys = append(keys, t.Path+"|"+t.Scope+"|"+hash)
+	}
+	return keys
+}
+
+// UniqueTraits returns the index entries for all unique traits of the
+// identity. The identity's traits must not be encrypted.
+func (c *TraitsClassifier) UniqueTraits(ctx context.Context, i *Identity) ([]UniqueTrait, error) {
+	if !hasTraits(i.Traits) {
+		return nil, nil
+	}
+
+	classification, err := c.Classify(ctx, i.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+	return classification.UniqueTraits(i, c.d.Config().SecretsDefault(ctx)), nil
+}
+
+// UniqueTraits returns the index entries for all unique traits of the
+// identity. Values are hashed with an HMAC keyed with the given secrets, the
+// first of which is the current one. Null values are not indexed and duplicate
+// values within the same identity are only indexed once.
+func (c *TraitsClassification) UniqueTraits(i *Identity, secrets [][]byte) []UniqueTrait {
+	if c == nil || len(c.Unique) == 0 {
+		return nil
+	}
+
+	var result []UniqueTrait
+	seen := make(map[string]struct{})
+	for _, unique := range c.Unique {
+		scope := UniqueTraitScopeGlobal
+		if unique.Scope == UniqueTraitScopeOrganization {
+			scope = i.OrganizationID.UUID.String()
+		}
+
+		for _, segments := range expandTraitsSegments(i.Traits, unique.Path, nil) {
+			value := gjson.GetBytes(i.Traits, joinTraitsPath(segments))
+			if !value.Exists() || value.Type == gjson.Null {
+				continue
+			}
+
+			raw := value.Raw
+			if value.Type == gjson.String {
+				raw = value.Str
+			}
+			hashes := make([]string, len(secrets))
+			for k, secret := range secrets {
+				hashes[k] = hashUniqueTraitValue(raw, secret)
+			}
+
+			trait := UniqueTrait{
+				IdentityID:  i.ID,
+				NID:         i.NID,
+				Path:        strings.Join(unique.Path, "."),
+				Scope:       scope,
+				ValueHash:   hashes[0],
+				ValueHashes: hashes,
+				InstancePtr: traitsPointer(segments),
+			}
+			if _, ok := seen[trait.Key()]; ok {
+				continue
+			}
+			seen[trait.Key()] = struct{}{}
+			result = append(result, trait)
+		}
+	}
+	return result
+}
+
+func hashUniqueTraitValue(value string, secret []byte) string {
+	h := hmac.New(sha512.New512_256, secret)
+	_, _ = h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// expandTraitsSegments resolves array wildcards (`#`) in path to the concrete
+// indices present in traits.
+func expandTraitsSegments(traits []byte, path []string, prefix []string) [][]string {
+	if len(path) == 0 {
+		return [][]string{prefix}
+	}
+
+	next := func(segment string) []string {
+		return append(append(make([]string, 0, len(prefix)+1), prefix...), segment)
+	}
+
+	if path[0] != "#" {
+		return expandTraitsSegments(traits, path[1:], next(path[0]))
+	}
+
+	elements := gjson.GetBytes(traits, joinTraitsPath(prefix))
+	if !elements.IsArray() {
+		return nil
+	}
+
+	var result [][]string
+	for k := range elements.Array() {
+		result = append(result, expandTraitsSegments(traits, path[1:], next(strconv.Itoa(k)))...)
+	}
+	return result
+}
+
+func joinTraitsPath(segments []string) string {
+	escaped := make([]string, len(segments))
+	for k, segment := range segments {
+		escaped[k] = escapeTraitsPathSegment(segment)
+	}
+	return strings.Join(escaped, ".")
+}
+
+func traitsPointer(segments []string) string {
+	escaped := make([]string, len(segments))
+	for k, segment := range segments {
+		escaped[k] = strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+	}
+	return "#/traits/" + strings.Join(escaped, "/")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueTraits(t *testing.T) {
+	c, err := ClassifyTraitsSchema(context.Background(), "file://./stub/extension/unique/schema.json", false)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []UniqueTraitPath{
+		{Path: []string{"employee_number"}, Scope: UniqueTraitScopeOrganization},
+		{Path: []string{"usernames", "#"}, Scope: UniqueTraitScopeGlobal},
+	}, c.Unique)
+
+	secrets := [][]byte{[]byte("current-secret-current-secret"), []byte("previous-secret-previous-secret")}
+	orgID := uuid.Must(uuid.NewV4())
+	i := &Identity{
+		ID:             uuid.Must(uuid.NewV4()),
+		Traits:         Traits(`{"employee_number":"E-1","usernames":["foo","bar","foo"],"nickname":"foo"}`),
+		OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true},
+	}
+
+	t.Run("case=lists unique values", func(t *testing.T) {
+		traits := c.UniqueTraits(i, secrets)
+		require.Len(t, traits, 3, "%+v", traits)
+
+		pointers := make([]string, len(traits))
+		for k, trait := range traits {
+			pointers[k] = trait.InstancePtr
+			assert.Equal(t, i.ID, trait.IdentityID)
+			if trait.Path == "employee_number" {
+				assert.Equal(t, orgID.String(), trait.Scope)
+			} else {
+				assert.Equal(t, "usernames.#", trait.Path)
+				assert.Equal(t, UniqueTraitScopeGlobal, trait.Scope)
+			}
+		}
+		assert.ElementsMatch(t, []string{"#/traits/employee_number", "#/traits/usernames/0", "#/traits/usernames/1"}, pointers)
+	})
+
+	t.Run("case=same value yields same hash", func(t *testing.T) {
+		other := *i
+		other.ID = uuid.Must(uuid.NewV4())
+		other.Traits = Traits(`{"usernames":["bar"]}`)
+
+		traits := c.UniqueTraits(&other, secrets)
+		require.Len(t, traits, 1)
+		assert.Contains(t, keys(c.UniqueTraits(i, secrets)), traits[0].Key())
+	})
+
+	t.Run("case=hashes are keyed with the secrets", func(t *testing.T) {
+		current := c.UniqueTraits(i, secrets[:1])
+		rotated := c.UniqueTraits(i, [][]byte{[]byte("rotated-secret-rotated-secret"), secrets[0]})
+		require.Len(t, rotated, len(current))
+
+		for k := range current {
+			assert.Len(t, current[k].ValueHash, 64)
+			assert.NotEqual(t, current[k].ValueHash, rotated[k].ValueHash, "the hash depends on the secret")
+			assert.Contains(t, rotated[k].Keys(), current[k].Key(), "values indexed with the previous secret are found")
+		}
+	})
+
+	t.Run("case=organization scope without organization", func(t *testing.T) {
+		other := *i
+		other.OrganizationID = uuid.NullUUID{}
+		other.Traits = Traits(`{"employee_number":"E-1","usernames":null}`)
+
+		traits := c.UniqueTraits(&other, secrets)
+		require.Len(t, traits, 1)
+		assert.Equal(t, uuid.Nil.String(), traits[0].Scope)
+	})
+}
+
+func keys(traits []UniqueTrait) []string {
+	result := make([]string, len(traits))
+	for k, trait := range traits {
+		result[k] = trait.Key()
+	}
+	return result
+}
@@ -5,13 +5,17 @@ package identity
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tidwall/sjson"
 
 	"github.com/ory/herodot"
+	"github.com/ory/jsonschema/v3"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/x/jsonschemax"
 	"github.com/ory/x/otelx"
 )
 
@@ -73,3 +77,56 @@ func (v *Validator) Validate(ctx context.Context, i *Identity) error {
 		)
 	})
 }
+
+// MissingTraits validates the identity's traits against its current identity
+// schema and returns the dot-separated paths (e.g. `traits.name.first`) of all
+// traits which are missing or invalid. The identity is not modified.
+//
+// An empty result means that the traits are valid. Errors other than schema
+// validation errors are returned as-is.
+func (v *Validator) MissingTraits(ctx context.Context, i *Identity) ([]string, error) {
+	c := *i
+	err := v.ValidateWithRunner(ctx, &c)
+	if err == nil {
+		return nil, nil
+	}
+
+	e := new(jsonschema.ValidationError)
+	if !errors.As(err, &e) {
+		return nil, err
+	}
+
+	traits := collectInvalidTraits(e, nil)
+	if len(traits) == 0 {
+		// The traits are invalid as a whole, for example because they are
+		// not an object.
+		traits = append(traits, "traits")
+	}
+	slices.Sort(traits)
+	return slices.Compact(traits), nil
+}
+
+func collectInvalidTraits(e *jsonschema.ValidationError, result []string) []string {
+	add := func(pointer string) {
+		if name, err := jsonschemax.JSONPointerToDotNotation(pointer); err == nil && strings.HasPrefix(name, "traits.") {
+			result = append(result, name)
+		}
+	}
+
+	if ctx, ok := e.Context.(*jsonschema.ValidationErrorContextRequired); ok {
+		for _, pointer := range ctx.Missing {
+			add(pointer)
+		}
+		return result
+	}
+
+	if len(e.Causes) == 0 {
+		add(e.InstancePtr)
+		return result
+	}
+
+	for _, cause := range e.Causes {
+		result = collectInvalidTraits(cause, result)
+	}
+	return result
+}
@@ -168,3 +168,64 @@ func TestSchemaValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestMissingTraits(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+			"default": "base64://" + base64.StdEncoding.EncodeToString([]byte(`{
+  "type": "object",
+  "properties": {
+    "traits": {
+      "type": "object",
+      "properties": {
+        "email": { "type": "string", "format": "email" },
+        "name": {
+          "type": "object",
+          "properties": {
+            "first": { "type": "string" },
+            "last": { "type": "string" }
+          },
+          "required": ["first", "last"]
+        },
+        "age": { "type": "integer", "minimum": 1 }
+      },
+      "required": ["email", "name"],
+      "additionalProperties": false
+    }
+  }
+}`)),
+		})),
+	)
+	v := NewValidator(reg)
+
+	for k, tc := range []struct {
+		traits   string
+		expected []string
+	}{
+		{
+			traits: `{"email":"foo@ory.sh","name":{"first":"Foo","last":"Bar"}}`,
+		},
+		{
+			traits:   `{}`,
+			expected: []string{"traits.email", "traits.name"},
+		},
+		{
+			traits:   `{"email":"foo@ory.sh","name":{"first":"Foo"},"age":0}`,
+			expected: []string{"traits.age", "traits.name.last"},
+		},
+		{
+			traits:   `{"email":"foo@ory.sh","name":{"first":"Foo","last":"Bar"},"unknown":true}`,
+			expected: []string{"traits"},
+		},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			i := &Identity{SchemaID: "default", Traits: Traits(tc.traits)}
+			actual, err := v.MissingTraits(t.Context(), i)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.JSONEq(t, tc.traits, string(i.Traits))
+		})
+	}
+}
@@ -0,0 +1,632 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/organization"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/logrusx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCollection = "/invitations"
+	RouteItem       = RouteCollection + "/{id}"
+
+	RouteAccept = "/self-service/invitation"
+
+	tokenLength = 32
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.WriterProvider
+		httpx.ClientProvider
+		nosurfx.CSRFProvider
+		x.TransactionPersistenceProvider
+		courier.Provider
+		courier.ConfigProvider
+		errorx.ManagementProvider
+		identity.ManagementProvider
+		identity.PrivilegedPoolProvider
+		organization.PersistenceProvider
+		registration.HandlerProvider
+		registration.FlowPersistenceProvider
+		PersistenceProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		InvitationHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		RouteCollection,
+		RouteCollection+"/*",
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+
+	public.GET(RouteAccept, h.accept)
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteCollection, h.create)
+	admin.GET(RouteItem, h.get)
+	admin.DELETE(RouteItem, h.revoke)
+
+	admin.GET(RouteAccept, redir.RedirectToPublicRoute(h.r))
+}
+
+// Create Invitation Request Body
+//
+// swagger:model createInvitationBody
+type CreateInvitationBody struct {
+	// SchemaID is the ID of the JSON Schema to be used for validating the
+	// invited identity's traits. The schema must mark an email trait as
+	// verifiable, as the invitation is sent to that address.
+	//
+	// required: true
+	SchemaID string `json:"schema_id"`
+
+	// Traits are the invited identity's preset traits.
+	//
+	// required: true
+	Traits json.RawMessage `json:"traits"`
+
+	// MetadataPublic is the invited identity's public metadata, for example
+	// their role.
+	MetadataPublic json.RawMessage `json:"metadata_public,omitempty"`
+
+	// MetadataAdmin is the invited identity's admin metadata.
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
+
+	// OrganizationID is the ID of the organization the invited identity
+	// belongs to.
+	OrganizationID uuid.NullUUID `json:"organization_id"`
+
+	// ExpiresIn is the time after which the invitation can no longer be
+	// accepted. Defaults to the configuration value of
+	// `selfservice.flows.invitation.lifespan`.
+	//
+	// pattern: ^[0-9]+(ns|us|ms|s|m|h)$
+	// example:
+	//	- 72h
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
+
+// Created Invitation
+//
+// swagger:model invitationWithLink
+type invitationWithLink struct {
+	Invitation
+
+	// InvitationLink is the link sent to the invited user. It is only
+	// returned when the invitation is created.
+	//
+	// required: true
+	// format: uri
+	InvitationLink string `json:"invitation_link"`
+}
+
+// Create Invitation Parameters
+//
+// swagger:parameters createInvitation
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createInvitation struct {
+	// in: body
+	Body CreateInvitationBody
+}
+
+// swagger:route POST /admin/invitations identity createInvitation
+//
+// # Invite a User
+//
+// Creates an inactive identity with the given traits and metadata and emails an invitation to
+// the identity's verifiable email address. The invited user accepts the invitation by opening the
+// link and completing the registration flow it starts, which activates the identity and verifies
+// the address.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: invitationWithLink
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body CreateInvitationBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	expiresIn := h.r.Config().SelfServiceFlowInvitationLifespan(ctx)
+	if len(body.ExpiresIn) > 0 {
+		var err error
+		expiresIn, err = time.ParseDuration(body.ExpiresIn)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`Unable to parse "expires_in" whose format should match "[0-9]+(ns|us|ms|s|m|h)" but did not: %s`, body.ExpiresIn)))
+			return
+		}
+	}
+	if expiresIn <= 0 {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`Value from "expires_in" must be result to a future time: %s`, body.ExpiresIn)))
+		return
+	}
+
+	if body.OrganizationID.Valid {
+		if _, err := h.r.OrganizationPersister().GetOrganization(ctx, body.OrganizationID.UUID); errors.Is(err, sqlcon.ErrNoRows()) {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Organization %s does not exist.", body.OrganizationID.UUID)))
+			return
+		} else if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	i := identity.NewIdentity(body.SchemaID)
+	i.Traits = identity.Traits(body.Traits)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(body.MetadataPublic)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(body.MetadataAdmin)
+	i.OrganizationID = body.OrganizationID
+	i.State = identity.StateInactive
+	i.StateChangedAt = new(sqlxx.NullTime(now))
+
+	token := randx.MustString(tokenLength, randx.AlphaNum)
+	inv := &Invitation{
+		ID:        uuid.Must(uuid.NewV4()),
+		State:     StatePending,
+		ExpiresAt: now.Add(expiresIn),
+	}
+	link := urlx.CopyWithQuery(urlx.AppendPaths(h.r.Config().SelfPublicURL(ctx), RouteAccept), url.Values{"token": {token}}).String()
+
+	if err := h.r.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if err := h.r.IdentityManager().Create(ctx, i); err != nil {
+			if errors.Is(err, sqlcon.ErrUniqueViolation()) {
+				return errors.WithStack(herodot.ErrConflict().WithReason("This identity conflicts with another identity that already exists."))
+			}
+			return err
+		}
+
+		address, err := invitationAddress(i)
+		if err != nil {
+			return err
+		}
+
+		inv.IdentityID = i.ID
+		inv.Address = address
+		if err := h.r.InvitationPersister().CreateInvitation(ctx, inv, token); err != nil {
+			return err
+		}
+
+		return h.send(ctx, i, inv, link)
+	}); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewInvitationCreated(ctx, inv.ID, i.ID))
+	h.r.Logger().
+		WithField("identity_id", i.ID).
+		WithField("invitation_id", inv.ID).
+		WithSensitiveField("invitation_link", link).
+		Info("An invitation has been created.")
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "invitations", inv.ID.String()).String(),
+		&invitationWithLink{Invitation: inv.withReportedState(), InvitationLink: link},
+	)
+}
+
+// invitationAddress returns the identity's first verifiable email address.
+func invitationAddress(i *identity.Identity) (string, error) {
+	for _, a := range i.VerifiableAddresses {
+		if a.Via == identity.AddressTypeEmail {
+			return a.Value, nil
+		}
+	}
+	return "", errors.WithStack(herodot.ErrBadRequest().WithReason("The identity has no verifiable email address to send the invitation to. Mark an email trait as verifiable in the identity schema."))
+}
+
+func (h *Handler) send(ctx context.Context, i *identity.Identity, inv *Invitation, link string) error {
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	c, err := h.r.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.QueueEmail(ctx, email.NewInvitationValid(h.r, &email.InvitationValidModel{
+		To:               inv.Address,
+		InvitationURL:    link,
+		Identity:         model,
+		ExpiresInMinutes: int(time.Until(inv.ExpiresAt).Minutes()),
+	}))
+	return err
+}
+
+// Paginated Invitation List Response
+//
+// swagger:response listInvitations
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listInvitationsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of invitations
+	//
+	// in:body
+	Body []Invitation
+}
+
+// List Invitations Parameters
+//
+// swagger:parameters listInvitations
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listInvitations struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/invitations identity listInvitations
+//
+// # List Invitations
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listInvitations
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	invitations, nextPage, err := h.r.InvitationPersister().ListInvitations(r.Context(), opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for k := range invitations {
+		invitations[k] = invitations[k].withReportedState()
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, invitations)
+}
+
+// Get Invitation Parameters
+//
+// swagger:parameters getInvitation
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getInvitation struct {
+	// ID is the invitation's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/invitations/{id} identity getInvitation
+//
+// # Get an Invitation
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: invitation
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	inv, err := h.r.InvitationPersister().GetInvitation(r.Context(), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, inv.withReportedState())
+}
+
+// Revoke Invitation Parameters
+//
+// swagger:parameters revokeInvitation
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeInvitation struct {
+	// ID is the invitation's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/invitations/{id} identity revokeInvitation
+//
+// # Revoke an Invitation
+//
+// Revokes a pending invitation so that its link can no longer be used. The invited identity
+// remains inactive and can be deleted using the identity APIs.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	inv, err := h.r.InvitationPersister().GetInvitation(ctx, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.InvitationPersister().RevokeInvitation(ctx, inv.ID); errors.Is(err, sqlcon.ErrNoRows()) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReasonf("The invitation can not be revoked because it is %s.", inv.State)))
+		return
+	} else if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewInvitationRevoked(ctx, inv.ID, inv.IdentityID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Accept Invitation Parameters
+//
+// swagger:parameters acceptInvitation
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type acceptInvitation struct {
+	// Token is the token contained in the invitation link.
+	//
+	// required: true
+	// in: query
+	Token string `json:"token"`
+}
+
+// swagger:route GET /self-service/invitation frontend acceptInvitation
+//
+// # Accept an Invitation
+//
+// This endpoint is the link sent in invitation emails and is opened in the invited user's browser.
+// It initializes a browser registration flow for the invited identity, pre-filled with the identity's
+// traits, and redirects the user to the registration UI (`selfservice.flows.registration.ui_url`).
+// Opening the link does not accept the invitation.
+//
+// Completing the registration flow accepts the invitation. Instead of creating a new identity, the
+// chosen credentials are added to the invited identity, which keeps the traits and metadata set by
+// the administrator. The invited identity is activated, the address the invitation was sent to is
+// verified and the registration hooks run.
+//
+// If the invitation is invalid, expired, revoked or was already accepted, the user is redirected to
+// the error UI.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: registrationFlow
+//	  303: emptyResponse
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) accept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	inv, err := h.r.InvitationPersister().FindPendingInvitation(ctx, r.URL.Query().Get("token"))
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(ErrInvitationInvalid()))
+		return
+	} else if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	invited, err := h.r.PrivilegedIdentityPool().GetIdentity(ctx, inv.IdentityID, identity.ExpandNothing)
+	if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f, err := h.r.RegistrationHandler().NewRegistrationFlow(w, r, flow.TypeBrowser,
+		registration.WithFlowInvitation(inv.ID, invited.SchemaID),
+		withoutToken,
+	)
+	if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f.UI.UpdateNodeValuesFromJSON(json.RawMessage(invited.Traits), "traits", node.DefaultGroup)
+	if err := h.r.RegistrationFlowPersister().UpdateRegistrationFlow(ctx, f); err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowCompletedAsRedirectOrJSON(w, r, h.r.Writer(), f, f.AppendTo(h.r.Config().SelfServiceFlowRegistrationUI(ctx)).String())
+}
+
+// withoutToken removes the invitation token from the flow's request URL, so
+// that it is not exposed by the flow.
+func withoutToken(f *registration.Flow) {
+	u, err := url.Parse(f.RequestURL)
+	if err != nil {
+		return
+	}
+	q := u.Query()
+	q.Del("token")
+	u.RawQuery = q.Encode()
+	f.RequestURL = u.String()
+}
+
+// AcceptRegistrationInvitation accepts the invitation the registration flow
+// was created for. The credentials of i are added to the invited identity,
+// which keeps the traits and metadata set by the administrator, and the
+// invited identity is activated. Afterwards, i is the invited identity.
+func (h *Handler) AcceptRegistrationInvitation(ctx context.Context, f *registration.Flow, i *identity.Identity) error {
+	inv, err := h.r.InvitationPersister().AcceptInvitation(ctx, f.InvitationID.UUID)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return errors.WithStack(ErrInvitationInvalid())
+	} else if err != nil {
+		return err
+	}
+
+	invited, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, inv.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	for ct, c := range i.Credentials {
+		invited.SetCredentials(ct, c)
+	}
+	if err := h.r.IdentityManager().Update(ctx, invited, identity.ManagerAllowWriteProtectedTraits); err != nil {
+		return err
+	}
+	if err := h.activate(ctx, invited, inv); err != nil {
+		return err
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewInvitationAccepted(ctx, inv.ID, invited.ID))
+	*i = *invited
+	return nil
+}
+
+// activate activates the invited identity and verifies the address the
+// invitation was sent to.
+func (h *Handler) activate(ctx context.Context, i *identity.Identity, inv *Invitation) error {
+	now := time.Now().UTC()
+
+	i.State = identity.StateActive
+	i.StateChangedAt = new(sqlxx.NullTime(now))
+	if err := h.r.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "state", "state_changed_at"); err != nil {
+		return err
+	}
+
+	for k, a := range i.VerifiableAddresses {
+		if a.Via != identity.AddressTypeEmail || a.Value != inv.Address || a.Verified {
+			continue
+		}
+		i.VerifiableAddresses[k].Verified = true
+		i.VerifiableAddresses[k].VerifiedAt = new(sqlxx.NullTime(now))
+		i.VerifiableAddresses[k].Status = identity.VerifiableAddressStatusCompleted
+		if err := h.r.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, &i.VerifiableAddresses[k], "verified", "verified_at", "status"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ErrInvitationInvalid() *herodot.DefaultError {
+	return nosurfx.ErrGone().WithReason("The invitation is invalid, has expired, was revoked or was already accepted. Please ask for a new invitation.")
+}
@@ -0,0 +1,241 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package invitation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/invitation"
+	"github.com/ory/kratos/pkg"
+	kratos "github.com/ory/kratos/pkg/httpclient"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValue(config.ViperKeyDefaultIdentitySchemaID, "default"),
+		configx.WithValue(config.ViperKeyIdentitySchemas, config.Schemas{
+			{ID: "default", URL: "file://./stub/identity.schema.json"},
+			{ID: "unverifiable", URL: "file://./stub/unverifiable.schema.json"},
+		}),
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, true)),
+		configx.WithValue(config.ViperKeyPasswordHaveIBeenPwnedEnabled, false),
+		// Invited users can register even if the registration is disabled.
+		configx.WithValue(config.ViperKeySelfServiceRegistrationEnabled, false),
+		configx.WithValue(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypePassword.String()), []config.SelfServiceHook{{Name: "session"}}),
+	)
+	publicTS, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	_ = testhelpers.NewRegistrationUIFlowEchoServer(t, reg)
+
+	do := func(t *testing.T, method, url string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var payload io.Reader
+		if body != nil {
+			raw, err := json.Marshal(body)
+			require.NoError(t, err)
+			payload = bytes.NewReader(raw)
+		}
+		req, err := http.NewRequest(method, url, payload)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	invite := func(t *testing.T, email string) gjson.Result {
+		return do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id":       "default",
+			"traits":          map[string]any{"email": email},
+			"metadata_public": map[string]any{"role": "editor"},
+		}, http.StatusCreated)
+	}
+
+	getIdentity := func(t *testing.T, id string) *identity.Identity {
+		i, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, uuid.Must(uuid.FromString(id)), identity.ExpandEverything)
+		require.NoError(t, err)
+		return i
+	}
+
+	// Opening the invitation link starts a registration flow, which the
+	// registration UI echo server returns.
+	open := func(t *testing.T, client *http.Client, link string) *kratos.RegistrationFlow {
+		res, body := testhelpers.EasyGet(t, client, link)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.True(t, strings.HasPrefix(res.Request.URL.String(), conf.SelfServiceFlowRegistrationUI(ctx).String()), res.Request.URL.String())
+		return testhelpers.GetRegistrationFlow(t, client, publicTS, res.Request.URL.Query().Get("flow"))
+	}
+
+	register := func(t *testing.T, client *http.Client, f *kratos.RegistrationFlow, password string) (string, *http.Response) {
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", "password")
+		values.Set("password", password)
+		return testhelpers.RegistrationMakeRequest(t, false, true, f, client, values.Encode())
+	}
+
+	t.Run("case=invites a user who accepts the invitation", func(t *testing.T) {
+		inv := invite(t, "invited@ory.sh")
+		assert.Equal(t, "pending", inv.Get("state").String(), "%s", inv.Raw)
+		assert.Equal(t, "invited@ory.sh", inv.Get("address").String(), "%s", inv.Raw)
+		link := inv.Get("invitation_link").String()
+		assert.True(t, strings.HasPrefix(link, publicTS.URL+invitation.RouteAccept+"?token="), link)
+
+		i := getIdentity(t, inv.Get("identity_id").String())
+		assert.Equal(t, identity.StateInactive, i.State)
+		assert.JSONEq(t, `{"role":"editor"}`, string(i.MetadataPublic))
+		require.Len(t, i.VerifiableAddresses, 1)
+		assert.False(t, i.VerifiableAddresses[0].Verified)
+
+		messages, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{Recipient: "invited@ory.sh"}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeInvitationValid, messages[0].TemplateType)
+		assert.Contains(t, messages[0].Body, link)
+
+		got := do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusOK)
+		assert.Equal(t, "pending", got.Get("state").String(), "%s", got.Raw)
+		assert.False(t, got.Get("invitation_link").Exists(), "%s", got.Raw)
+
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		token := u.Query().Get("token")
+
+		client := testhelpers.NewClientWithCookies(t)
+		f := open(t, client, link)
+		assert.Equal(t, "invited@ory.sh", testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes).Get("traits.email"), "the traits are pre-filled")
+		assert.NotContains(t, f.RequestUrl, token)
+
+		i = getIdentity(t, inv.Get("identity_id").String())
+		assert.Equal(t, identity.StateInactive, i.State, "opening the link does not accept the invitation")
+
+		// Opened before the invitation is accepted, completed afterwards.
+		otherClient := testhelpers.NewClientWithCookies(t)
+		otherFlow := open(t, otherClient, link)
+
+		t.Run("case=requires a valid password", func(t *testing.T) {
+			body, res := register(t, client, f, "short")
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+
+			i := getIdentity(t, inv.Get("identity_id").String())
+			assert.Equal(t, identity.StateInactive, i.State)
+			assert.False(t, i.VerifiableAddresses[0].Verified)
+			_, ok := i.GetCredentials(identity.CredentialsTypePassword)
+			assert.False(t, ok)
+
+			got := do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusOK)
+			assert.Equal(t, "pending", got.Get("state").String(), "%s", got.Raw)
+		})
+
+		body, res := register(t, client, f, "a-very-secure-password-4e8b")
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, inv.Get("identity_id").String(), gjson.Get(body, "identity.id").String(), "the invited identity is used: %s", body)
+		assert.Equal(t, inv.Get("identity_id").String(), gjson.Get(body, "session.identity.id").String(), "the session hook signs the user in: %s", body)
+
+		i = getIdentity(t, inv.Get("identity_id").String())
+		assert.Equal(t, identity.StateActive, i.State)
+		assert.JSONEq(t, `{"role":"editor"}`, string(i.MetadataPublic))
+		assert.True(t, i.VerifiableAddresses[0].Verified)
+		assert.Equal(t, identity.VerifiableAddressStatusCompleted, i.VerifiableAddresses[0].Status)
+		c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok)
+		assert.Equal(t, []string{"invited@ory.sh"}, c.Identifiers)
+
+		got = do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusOK)
+		assert.Equal(t, "accepted", got.Get("state").String(), "%s", got.Raw)
+		assert.True(t, got.Get("accepted_at").Exists(), "%s", got.Raw)
+
+		t.Run("case=an invitation can only be accepted once", func(t *testing.T) {
+			res, body := testhelpers.EasyGetJSON(t, publicTS.Client(), link)
+			assert.Equal(t, http.StatusGone, res.StatusCode, "%s", body)
+
+			otherBody, res := register(t, otherClient, otherFlow, "another-secure-password-91c3")
+			assert.Equal(t, http.StatusGone, res.StatusCode, "%s", otherBody)
+		})
+
+		t.Run("case=an accepted invitation can not be revoked", func(t *testing.T) {
+			do(t, "DELETE", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusConflict)
+		})
+	})
+
+	t.Run("case=revoked invitations can not be accepted", func(t *testing.T) {
+		inv := invite(t, "revoked@ory.sh")
+		do(t, "DELETE", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusNoContent)
+
+		res, body := testhelpers.EasyGetJSON(t, publicTS.Client(), inv.Get("invitation_link").String())
+		assert.Equal(t, http.StatusGone, res.StatusCode, "%s", body)
+		assert.Equal(t, identity.StateInactive, getIdentity(t, inv.Get("identity_id").String()).State)
+	})
+
+	t.Run("case=expired invitations can not be accepted", func(t *testing.T) {
+		inv := do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id":  "default",
+			"traits":     map[string]any{"email": "expired@ory.sh"},
+			"expires_in": "1ns",
+		}, http.StatusCreated)
+
+		got := do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection+"/"+inv.Get("id").String(), nil, http.StatusOK)
+		assert.Equal(t, "expired", got.Get("state").String(), "%s", got.Raw)
+
+		res, body := testhelpers.EasyGetJSON(t, publicTS.Client(), inv.Get("invitation_link").String())
+		assert.Equal(t, http.StatusGone, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=lists invitations", func(t *testing.T) {
+		list := do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection, nil, http.StatusOK)
+		assert.GreaterOrEqual(t, len(list.Array()), 3, "%s", list.Raw)
+		assert.False(t, list.Get("0.invitation_link").Exists(), "%s", list.Raw)
+	})
+
+	t.Run("case=rejects invalid invitations", func(t *testing.T) {
+		do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id":  "default",
+			"traits":     map[string]any{"email": "invalid-expiry@ory.sh"},
+			"expires_in": "-1h",
+		}, http.StatusBadRequest)
+
+		do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id": "unverifiable",
+			"traits":    map[string]any{"username": "no-email"},
+		}, http.StatusBadRequest)
+
+		do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id": "default",
+			"traits":    map[string]any{"email": "invited@ory.sh"},
+		}, http.StatusConflict)
+
+		do(t, "POST", adminTS.URL+"/admin"+invitation.RouteCollection, map[string]any{
+			"schema_id":       "default",
+			"traits":          map[string]any{"email": "unknown-organization@ory.sh"},
+			"organization_id": uuid.Must(uuid.NewV4()).String(),
+		}, http.StatusBadRequest)
+	})
+
+	t.Run("case=unknown invitations are not found", func(t *testing.T) {
+		do(t, "GET", adminTS.URL+"/admin"+invitation.RouteCollection+"/00000000-0000-0000-0000-000000000000", nil, http.StatusNotFound)
+		do(t, "DELETE", adminTS.URL+"/admin"+invitation.RouteCollection+"/00000000-0000-0000-0000-000000000000", nil, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package invitation lets administrators invite users by email. Inviting a
+// user creates an inactive identity with preset traits and metadata, which
+// the user activates by accepting the invitation.
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+// State is the state of an invitation.
+//
+// swagger:enum invitationState
+type State string
+
+const (
+	// StatePending is the state of an invitation until it is accepted,
+	// revoked or expires.
+	StatePending State = "pending"
+	// StateAccepted is the state of an invitation after the user accepted it.
+	StateAccepted State = "accepted"
+	// StateRevoked is the state of an invitation after an administrator
+	// revoked it.
+	StateRevoked State = "revoked"
+	// StateExpired is reported for pending invitations whose expiry passed.
+	// It is never stored.
+	StateExpired State = "expired"
+)
+
+type (
+	// Invitation invites a user to activate a pre-provisioned identity.
+	//
+	// swagger:model invitation
+	Invitation struct {
+		// ID is the invitation's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// IdentityID is the ID of the identity which is activated when the
+		// invitation is accepted.
+		//
+		// required: true
+		IdentityID uuid.UUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+		// Address is the email address the invitation was sent to. It is
+		// verified when the invitation is accepted.
+		//
+		// required: true
+		Address string `json:"address" db:"address"`
+
+		// State is the state of the invitation.
+		//
+		// required: true
+		State State `json:"state" faker:"-" db:"state"`
+
+		// ExpiresAt is the time (UTC) until which the invitation can be
+		// accepted.
+		//
+		// required: true
+		ExpiresAt time.Time `json:"expires_at" faker:"-" db:"expires_at"`
+
+		// AcceptedAt is the time (UTC) at which the invitation was accepted.
+		AcceptedAt *sqlxx.NullTime `json:"accepted_at,omitempty" faker:"-" db:"accepted_at"`
+
+		TokenHMAC string `json:"-" faker:"-" db:"token_hmac"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	Persister interface {
+		// CreateInvitation stores the invitation and the HMAC of its token.
+		CreateInvitation(ctx context.Context, i *Invitation, token string) error
+		GetInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+		ListInvitations(ctx context.Context, opts []keysetpagination.Option) ([]Invitation, *keysetpagination.Paginator, error)
+		// RevokeInvitation revokes the invitation if it is pending and
+		// returns sqlcon.ErrNoRows otherwise.
+		RevokeInvitation(ctx context.Context, id uuid.UUID) error
+		// FindPendingInvitation returns the pending, unexpired invitation
+		// with the given token. If there is no such invitation,
+		// sqlcon.ErrNoRows is returned.
+		FindPendingInvitation(ctx context.Context, token string) (*Invitation, error)
+		// AcceptInvitation marks the pending, unexpired invitation as
+		// accepted and returns it. If there is no such invitation,
+		// sqlcon.ErrNoRows is returned.
+		AcceptInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	}
+
+	PersistenceProvider interface {
+		InvitationPersister() Persister
+	}
+)
+
+func (Invitation) TableName() string { return "identity_invitations" }
+
+func (i Invitation) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: i.ID})
+}
+
+func (i Invitation) DefaultPageToken() keysetpagination.PageToken {
+	return Invitation{ID: uuid.Nil}.PageToken()
+}
+
+func (i *Invitation) IsExpired() bool {
+	return i.ExpiresAt.Before(time.Now().UTC())
+}
+
+// withReportedState returns a copy of the invitation which reports pending
+// invitations whose expiry passed as expired.
+func (i Invitation) withReportedState() Invitation {
+	if i.State == StatePending && i.IsExpired() {
+		i.State = StateExpired
+	}
+	return i
+}
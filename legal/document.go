@@ -0,0 +1,163 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/stringsx"
+)
+
+// The lengths of the ip_address and user_agent columns.
+const (
+	ipAddressMaxLength = 64
+	userAgentMaxLength = 512
+)
+
+// Pending returns the documents whose configured version the identity has not
+// accepted yet.
+func Pending(ctx context.Context, p Persister, identityID uuid.UUID, docs []config.LegalDocument) ([]config.LegalDocument, error) {
+	var pending []config.LegalDocument
+	for _, doc := range docs {
+		accepted, err := p.HasAcceptedLegalDocument(ctx, identityID, doc.ID, doc.Version)
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			pending = append(pending, doc)
+		}
+	}
+	return pending, nil
+}
+
+// NewNodes returns a required checkbox for each document.
+func NewNodes(docs []config.LegalDocument, group node.UiNodeGroup) node.Nodes {
+	nodes := make(node.Nodes, 0, len(docs))
+	for _, doc := range docs {
+		nodes = append(nodes, node.NewInputField(fieldName(doc.ID), false, group, node.InputAttributeTypeCheckbox, node.WithRequiredInputAttribute).
+			WithMetaLabel(text.NewInfoNodeLabelLegalDocument(doc.Title, doc.Version, doc.URL)))
+	}
+	return nodes
+}
+
+// Decode returns the documents the user checked. The request body is kept for
+// the self-service strategies.
+func Decode(r *http.Request, docs []config.LegalDocument) ([]config.LegalDocument, error) {
+	properties := make(map[string]any, len(docs))
+	for _, doc := range docs {
+		properties[doc.ID] = map[string]any{"type": "boolean"}
+	}
+	schema, err := json.Marshal(map[string]any{
+		"$id":     "https://schemas.ory.sh/kratos/legal/documents.schema.json",
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+		"properties": map[string]any{
+			node.LegalDocuments: map[string]any{
+				"type":       "object",
+				"properties": properties,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(schema)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var p struct {
+		LegalDocuments map[string]bool `json:"legal_documents"`
+	}
+	if err := decoderx.Decode(r, &p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST"),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var checked []config.LegalDocument
+	for _, doc := range docs {
+		if p.LegalDocuments[doc.ID] {
+			checked = append(checked, doc)
+		}
+	}
+	return checked, nil
+}
+
+// Missing returns the IDs of the documents which have not been accepted.
+// Accepted maps document IDs to their accepted version.
+func Missing(docs []config.LegalDocument, accepted map[string]string) []string {
+	var missing []string
+	for _, doc := range docs {
+		if accepted[doc.ID] != doc.Version {
+			missing = append(missing, doc.ID)
+		}
+	}
+	return missing
+}
+
+// NewMissingError returns a validation error which marks the checkboxes of
+// the documents as required.
+func NewMissingError(missing []string) error {
+	errs := make([]*schema.ValidationError, 0, len(missing))
+	for _, id := range missing {
+		var ve *schema.ValidationError
+		if errors.As(schema.NewRequiredError(FieldPointer(id), id), &ve) {
+			errs = append(errs, ve)
+		}
+	}
+	return schema.NewValidationListError(errs)
+}
+
+// NewAcceptances records that the identity accepted the documents using the
+// client of the request.
+func NewAcceptances(r *http.Request, identityID uuid.UUID, accepted map[string]string) []Acceptance {
+	now := time.Now().UTC()
+	acceptances := make([]Acceptance, 0, len(accepted))
+	for id, version := range accepted {
+		acceptances = append(acceptances, Acceptance{
+			ID:         uuid.Must(uuid.NewV4()),
+			IdentityID: identityID,
+			DocumentID: id,
+			Version:    version,
+			IPAddress:  stringsx.TruncateByteLen(httpx.ClientIP(r), ipAddressMaxLength),
+			UserAgent:  stringsx.TruncateByteLen(r.UserAgent(), userAgentMaxLength),
+			AcceptedAt: now,
+		})
+	}
+	return acceptances
+}
+
+// Versions maps the IDs of the documents to their version.
+func Versions(docs []config.LegalDocument) map[string]string {
+	versions := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		versions[doc.ID] = doc.Version
+	}
+	return versions
+}
+
+// FieldPointer returns the JSON pointer of the document's checkbox.
+func FieldPointer(id string) string {
+	return "#/" + node.LegalDocuments + "/" + id
+}
+
+func fieldName(id string) string {
+	return node.LegalDocuments + "." + id
+}
@@ -0,0 +1,31 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/legal"
+)
+
+func TestNewAcceptances(t *testing.T) {
+	r := httptest.NewRequest("POST", "/self-service/registration", nil)
+	r.Header.Set("User-Agent", strings.Repeat("a", 1024))
+	r.Header.Set("True-Client-IP", strings.Repeat("1", 128))
+
+	identityID := uuid.Must(uuid.NewV4())
+	acceptances := legal.NewAcceptances(r, identityID, map[string]string{"tos": "v2"})
+	require.Len(t, acceptances, 1)
+	assert.Equal(t, identityID, acceptances[0].IdentityID)
+	assert.Equal(t, "tos", acceptances[0].DocumentID)
+	assert.Equal(t, "v2", acceptances[0].Version)
+	assert.Len(t, acceptances[0].UserAgent, 512)
+	assert.Len(t, acceptances[0].IPAddress, 64)
+}
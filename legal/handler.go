@@ -0,0 +1,148 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+const RouteCollection = "/legal-acceptances"
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		PersistenceProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		LegalHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(httprouterx.AdminPrefix + RouteCollection)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+}
+
+// Paginated Legal Document Acceptance List Response
+//
+// swagger:response listLegalDocumentAcceptances
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listLegalDocumentAcceptancesResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of legal document acceptances
+	//
+	// in:body
+	Body []Acceptance
+}
+
+// List Legal Document Acceptances Parameters
+//
+// swagger:parameters listLegalDocumentAcceptances
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listLegalDocumentAcceptances struct {
+	keysetpagination.RequestParameters
+
+	// IdentityID filters the acceptances by the identity which accepted the
+	// documents.
+	//
+	// required: false
+	// in: query
+	IdentityID string `json:"identity_id"`
+
+	// DocumentID filters the acceptances by the accepted document.
+	//
+	// required: false
+	// in: query
+	DocumentID string `json:"document_id"`
+
+	// Version filters the acceptances by the accepted version of the
+	// document.
+	//
+	// required: false
+	// in: query
+	Version string `json:"version"`
+}
+
+// swagger:route GET /admin/legal-acceptances identity listLegalDocumentAcceptances
+//
+// # List Legal Document Acceptances
+//
+// Lists which versions of the legal documents identities accepted, and when and from where they
+// accepted them.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listLegalDocumentAcceptances
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	q := r.URL.Query()
+	params := ListParameters{
+		DocumentID: q.Get("document_id"),
+		Version:    q.Get("version"),
+	}
+	if id := q.Get("identity_id"); id != "" {
+		if params.IdentityID, err = uuid.FromString(id); err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The identity_id parameter must be a UUID.")))
+			return
+		}
+	}
+
+	acceptances, nextPage, err := h.r.LegalPersister().ListLegalAcceptances(r.Context(), params, opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, acceptances)
+}
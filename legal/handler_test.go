@@ -0,0 +1,93 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/configx"
+)
+
+func TestRegistrationAndList(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, true)),
+		configx.WithValues(testhelpers.MethodEnableConfig("legal", true)),
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceRegistrationEnabled:             true,
+			config.ViperKeySelfServiceRegistrationEnableLegacyOneStep: true,
+			config.ViperKeyLegalDocuments: []map[string]any{
+				{"id": "terms_of_service", "version": "v1", "title": "Terms of Service", "url": "https://www.ory.sh/tos"},
+			},
+		}),
+	)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewRegistrationUIFlowEchoServer(t, reg)
+
+	register := func(t *testing.T, email string, accept bool, expectCode int) string {
+		return testhelpers.SubmitRegistrationForm(t, true, new(http.Client), publicTS, func(v url.Values) {
+			v.Set("traits.email", email)
+			v.Set("password", "a-very-secure-password-4711")
+			v.Set("method", "password")
+			if accept {
+				v.Set("legal_documents.terms_of_service", "true")
+			} else {
+				v.Del("legal_documents.terms_of_service")
+			}
+		}, false, expectCode, publicTS.URL)
+	}
+
+	list := func(t *testing.T, query string, expectCode int) gjson.Result {
+		res, body := testhelpers.EasyGetJSON(t, adminTS.Client(), adminTS.URL+"/admin"+legal.RouteCollection+"?"+query)
+		require.Equal(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	t.Run("case=registration requires the documents", func(t *testing.T) {
+		body := register(t, "declined@ory.sh", false, http.StatusBadRequest)
+		assert.EqualValues(t, text.ErrorValidationRequired,
+			gjson.Get(body, `ui.nodes.#(attributes.name=="legal_documents.terms_of_service").messages.0.id`).Int(), body)
+
+		_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypePassword, "declined@ory.sh")
+		assert.Error(t, err)
+	})
+
+	t.Run("case=registration records the acceptance", func(t *testing.T) {
+		body := register(t, "accepted@ory.sh", true, http.StatusOK)
+		identityID := gjson.Get(body, "identity.id").String()
+		require.NotEmpty(t, identityID, body)
+
+		acceptances := list(t, "identity_id="+identityID, http.StatusOK)
+		require.Len(t, acceptances.Array(), 1, acceptances.Raw)
+		assert.Equal(t, "terms_of_service", acceptances.Get("0.document_id").String(), acceptances.Raw)
+		assert.Equal(t, "v1", acceptances.Get("0.version").String(), acceptances.Raw)
+		assert.NotEmpty(t, acceptances.Get("0.ip_address").String(), acceptances.Raw)
+		assert.True(t, acceptances.Get("0.accepted_at").Exists(), acceptances.Raw)
+	})
+
+	t.Run("case=lists acceptances by document and version", func(t *testing.T) {
+		assert.NotEmpty(t, list(t, "document_id=terms_of_service&version=v1", http.StatusOK).Array())
+		assert.Empty(t, list(t, "document_id=terms_of_service&version=v0", http.StatusOK).Array())
+		assert.Empty(t, list(t, "document_id=privacy_policy", http.StatusOK).Array())
+	})
+
+	t.Run("case=rejects invalid identity IDs", func(t *testing.T) {
+		list(t, "identity_id=not-a-uuid", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package legal tracks which versions of the configured legal documents, such
+// as the terms of service, identities accepted. Registration requires
+// accepting all documents, and users who have not accepted the current
+// version of a document are asked to do so after signing in.
+package legal
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+type (
+	// Acceptance records that an identity accepted a version of a legal
+	// document.
+	//
+	// swagger:model legalDocumentAcceptance
+	Acceptance struct {
+		// ID is the acceptance's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// IdentityID is the ID of the identity which accepted the document.
+		//
+		// required: true
+		IdentityID uuid.UUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+		// DocumentID is the ID of the accepted document as configured in
+		// `selfservice.methods.legal.config.documents`.
+		//
+		// required: true
+		DocumentID string `json:"document_id" db:"document_id"`
+
+		// Version is the accepted version of the document.
+		//
+		// required: true
+		Version string `json:"version" db:"version"`
+
+		// IPAddress is the IP address of the client which accepted the
+		// document.
+		IPAddress string `json:"ip_address" db:"ip_address"`
+
+		// UserAgent is the user agent of the client which accepted the
+		// document.
+		UserAgent string `json:"user_agent" db:"user_agent"`
+
+		// AcceptedAt is the time (UTC) at which the document was accepted.
+		//
+		// required: true
+		AcceptedAt time.Time `json:"accepted_at" faker:"-" db:"accepted_at"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	// ListParameters filters the listed acceptances. Empty fields do not
+	// filter.
+	ListParameters struct {
+		IdentityID uuid.UUID
+		DocumentID string
+		Version    string
+	}
+
+	Persister interface {
+		CreateLegalAcceptances(ctx context.Context, acceptances []Acceptance) error
+		ListLegalAcceptances(ctx context.Context, params ListParameters, opts []keysetpagination.Option) ([]Acceptance, *keysetpagination.Paginator, error)
+		// HasAcceptedLegalDocument returns true if the identity accepted the
+		// given version of the document.
+		HasAcceptedLegalDocument(ctx context.Context, identityID uuid.UUID, documentID, version string) (bool, error)
+	}
+
+	PersistenceProvider interface {
+		LegalPersister() Persister
+	}
+)
+
+func (Acceptance) TableName() string { return "identity_legal_acceptances" }
+
+func (a Acceptance) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: a.ID})
+}
+
+func (a Acceptance) DefaultPageToken() keysetpagination.PageToken {
+	return Acceptance{ID: uuid.Nil}.PageToken()
+}
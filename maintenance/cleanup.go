@@ -0,0 +1,55 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"context"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/tenant"
+)
+
+type (
+	cleanupDependencies interface {
+		config.Provider
+		PersistenceProvider
+		tenant.ContextsProvider
+	}
+
+	// CleanupJob purges expired sessions, flows and tokens, like `kratos
+	// cleanup sql` does.
+	CleanupJob struct {
+		d cleanupDependencies
+	}
+)
+
+var _ Job = (*CleanupJob)(nil)
+
+func NewCleanupJob(d cleanupDependencies) *CleanupJob {
+	return &CleanupJob{d: d}
+}
+
+func (j *CleanupJob) Name() string {
+	return "cleanup"
+}
+
+// Run cleans up the default network and, in multi-tenant mode, the network
+// of every tenant.
+func (j *CleanupJob) Run(ctx context.Context) error {
+	ctxs, err := j.d.TenantContexts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ctx := range ctxs {
+		if err := j.d.MaintenancePersister().CleanupDatabase(
+			ctx,
+			j.d.Config().DatabaseCleanupSleepTables(ctx),
+			j.d.Config().DatabaseCleanupOlderThan(ctx),
+			j.d.Config().DatabaseCleanupBatchSize(ctx),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
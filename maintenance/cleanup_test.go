@@ -0,0 +1,28 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/pkg"
+)
+
+func TestCleanupJob(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyDatabaseCleanupSleepTables, "0s")
+
+	require.NoError(t, maintenance.NewCleanupJob(reg).Run(ctx))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, maintenance.NewCleanupJob(reg).Run(canceled))
+}
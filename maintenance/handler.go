@@ -0,0 +1,147 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+)
+
+const (
+	RouteJobs = "/maintenance/jobs"
+	RouteJob  = RouteJobs + "/{name}"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		SchedulerProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		MaintenanceHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		httprouterx.AdminPrefix+RouteJobs,
+		httprouterx.AdminPrefix+RouteJobs+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteJobs, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteJob, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteJobs, h.list)
+	admin.GET(RouteJob, h.get)
+}
+
+// List Maintenance Jobs Response
+//
+// swagger:response listMaintenanceJobs
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listMaintenanceJobsResponse struct {
+	// in: body
+	Body []JobStatus
+}
+
+// swagger:route GET /admin/maintenance/jobs maintenance listMaintenanceJobs
+//
+// # List Maintenance Jobs
+//
+// Lists the background maintenance jobs with the result of their last run.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listMaintenanceJobs
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	status, err := h.r.MaintenanceScheduler().Status(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, status)
+}
+
+// Get Maintenance Job Parameters
+//
+// swagger:parameters getMaintenanceJob
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getMaintenanceJob struct {
+	// Name is the job's name.
+	//
+	// required: true
+	// in: path
+	Name string `json:"name"`
+}
+
+// swagger:route GET /admin/maintenance/jobs/{name} maintenance getMaintenanceJob
+//
+// # Get a Maintenance Job
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: maintenanceJob
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	status, err := h.r.MaintenanceScheduler().Status(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	name := r.PathValue("name")
+	for _, s := range status {
+		if s.Name == name {
+			h.r.Writer().Write(w, r, s)
+			return
+		}
+	}
+	h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReasonf("Unknown maintenance job %q.", name)))
+}
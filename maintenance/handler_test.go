@@ -0,0 +1,62 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	conf.MustSet(ctx, config.ViperKeyMaintenanceEnabled, true)
+	conf.MustSet(ctx, config.ViperKeyDatabaseCleanupSleepTables, "0s")
+
+	get := func(t *testing.T, path string, expectCode int) gjson.Result {
+		t.Helper()
+		res, err := adminTS.Client().Get(adminTS.URL + "/admin/maintenance/jobs" + path)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", body)
+		require.True(t, json.Valid(body))
+		return gjson.ParseBytes(body)
+	}
+
+	t.Run("case=before the first run", func(t *testing.T) {
+		res := get(t, "", http.StatusOK)
+		assert.Equal(t, "cleanup", res.Get("0.name").String())
+		assert.True(t, res.Get("0.enabled").Bool())
+		assert.Equal(t, "1h0m0s", res.Get("0.interval").String())
+		assert.False(t, res.Get("0.last_status").Exists())
+	})
+
+	t.Run("case=after a run", func(t *testing.T) {
+		reg.MaintenanceScheduler().RunDue(ctx)
+
+		res := get(t, "/cleanup", http.StatusOK)
+		assert.Equal(t, maintenance.RunStatusSucceeded, res.Get("last_status").String(), "%s", res.Raw)
+		assert.True(t, res.Get("next_run_at").Exists())
+	})
+
+	t.Run("case=unknown job", func(t *testing.T) {
+		get(t, "/unknown", http.StatusNotFound)
+	})
+}
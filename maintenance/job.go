@@ -0,0 +1,138 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// Job is a periodic maintenance job run by the Scheduler.
+type Job interface {
+	// Name identifies the job in the configuration, the database, the admin
+	// API and metrics.
+	Name() string
+
+	// Run runs the job once. The context is canceled if the replica loses
+	// the job's lease.
+	Run(ctx context.Context) error
+}
+
+const (
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+)
+
+// Maintenance Job State
+//
+// The state of a maintenance job shared by all replicas.
+type JobState struct {
+	// Name is the job's name.
+	Name string `json:"name" db:"name"`
+
+	// LockedBy is the replica currently running the job.
+	LockedBy sqlxx.NullString `json:"locked_by" db:"locked_by"`
+
+	// LockedUntil is when the current run's lease expires.
+	LockedUntil *sqlxx.NullTime `json:"locked_until" db:"locked_until"`
+
+	// LastStartedAt is when the job was last started.
+	LastStartedAt *sqlxx.NullTime `json:"last_started_at" db:"last_started_at"`
+
+	// LastFinishedAt is when the job last finished.
+	LastFinishedAt *sqlxx.NullTime `json:"last_finished_at" db:"last_finished_at"`
+
+	// LastStatus is the result of the last run.
+	LastStatus sqlxx.NullString `json:"last_status" db:"last_status"`
+
+	// LastError is the error of the last run, if it failed.
+	LastError sqlxx.NullString `json:"last_error" db:"last_error"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (JobState) TableName() string {
+	return "maintenance_jobs"
+}
+
+// Running returns whether a replica holds the job's lease at the given time.
+func (s *JobState) Running(now time.Time) bool {
+	return s.LockedBy != "" && s.LockedUntil != nil && time.Time(*s.LockedUntil).After(now)
+}
+
+// Maintenance Job Status
+//
+// swagger:model maintenanceJob
+type JobStatus struct {
+	// Name is the job's name.
+	//
+	// required: true
+	Name string `json:"name"`
+
+	// Enabled is whether the job is enabled in the configuration.
+	//
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// Interval is how long the scheduler waits after a run finished before
+	// running the job again.
+	//
+	// required: true
+	Interval string `json:"interval"`
+
+	// Running is whether a replica is currently running the job.
+	//
+	// required: true
+	Running bool `json:"running"`
+
+	// RunningOn is the replica currently running the job.
+	RunningOn string `json:"running_on,omitempty"`
+
+	// LastStartedAt is when the job was last started.
+	LastStartedAt *time.Time `json:"last_started_at,omitempty"`
+
+	// LastFinishedAt is when the job last finished.
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+
+	// LastStatus is the result of the last run, either `succeeded` or
+	// `failed`.
+	LastStatus string `json:"last_status,omitempty"`
+
+	// LastError is the error of the last run, if it failed.
+	LastError string `json:"last_error,omitempty"`
+
+	// NextRunAt is the earliest time the job will run again.
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+}
+
+type (
+	Persister interface {
+		// AcquireMaintenanceJob takes the job's lease for the given holder if
+		// no other replica holds it and the job last finished before
+		// finishedBefore.
+		AcquireMaintenanceJob(ctx context.Context, name, holder string, finishedBefore time.Time, lease time.Duration) (bool, error)
+
+		// RenewMaintenanceJob extends the lease of the given holder. It
+		// returns false if the holder lost the lease.
+		RenewMaintenanceJob(ctx context.Context, name, holder string, lease time.Duration) (bool, error)
+
+		// ReleaseMaintenanceJob releases the lease of the given holder and
+		// records the run's result.
+		ReleaseMaintenanceJob(ctx context.Context, name, holder string, runErr error) error
+
+		// ListMaintenanceJobs returns the state of all jobs which ran at least
+		// once.
+		ListMaintenanceJobs(ctx context.Context) ([]JobState, error)
+
+		// CleanupDatabase purges expired records of the context's network
+		// which are older than the given duration.
+		CleanupDatabase(ctx context.Context, wait time.Duration, older time.Duration, batchSize int) error
+	}
+	PersistenceProvider interface {
+		MaintenancePersister() Persister
+	}
+)
@@ -0,0 +1,53 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	jobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kratos",
+		Subsystem: "maintenance",
+		Name:      "job_runs_total",
+		Help:      "Number of maintenance job runs on this replica by job and status.",
+	}, []string{"job", "status"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kratos",
+		Subsystem: "maintenance",
+		Name:      "job_duration_seconds",
+		Help:      "Duration of maintenance job runs on this replica.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 10),
+	}, []string{"job"})
+
+	jobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kratos",
+		Subsystem: "maintenance",
+		Name:      "job_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful maintenance job run on this replica.",
+	}, []string{"job"})
+
+	jobRunning = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kratos",
+		Subsystem: "maintenance",
+		Name:      "job_running",
+		Help:      "Whether the maintenance job is running on this replica.",
+	}, []string{"job"})
+)
+
+func observeRun(job string, start time.Time, err error) {
+	status := RunStatusSucceeded
+	if err != nil {
+		status = RunStatusFailed
+	} else {
+		jobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+	jobRuns.WithLabelValues(job, status).Inc()
+	jobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+}
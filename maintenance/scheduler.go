@@ -0,0 +1,195 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/logrusx"
+)
+
+type (
+	schedulerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		PersistenceProvider
+	}
+
+	// Scheduler runs maintenance jobs periodically. Replicas coordinate
+	// through the database, so each job runs on only one replica at a time.
+	Scheduler struct {
+		d      schedulerDependencies
+		holder string
+		jobs   []Job
+	}
+
+	SchedulerProvider interface {
+		MaintenanceScheduler() *Scheduler
+	}
+)
+
+func NewScheduler(d schedulerDependencies, jobs ...Job) *Scheduler {
+	host, _ := os.Hostname()
+	return &Scheduler{
+		d:      d,
+		holder: fmt.Sprintf("%s/%s", host, uuid.Must(uuid.NewV4())),
+		jobs:   jobs,
+	}
+}
+
+// Holder identifies this replica when it holds a job's lease.
+func (s *Scheduler) Holder() string {
+	return s.holder
+}
+
+// Run runs due jobs until the context is canceled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.d.Logger().WithField("holder", s.holder).Info("Starting the maintenance scheduler.")
+	for {
+		s.RunDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.d.Config().MaintenancePollInterval(ctx)):
+		}
+	}
+}
+
+// RunDue runs every enabled job which is due and not running on another
+// replica. Failed runs are logged and recorded, and retried once the job is
+// due again.
+func (s *Scheduler) RunDue(ctx context.Context) {
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.d.Config().MaintenanceJobEnabled(ctx, job.Name()) {
+			continue
+		}
+		if err := s.runIfDue(ctx, job); err != nil {
+			s.d.Logger().WithError(err).WithField("job", job.Name()).Error("Unable to run the maintenance job.")
+		}
+	}
+}
+
+func (s *Scheduler) runIfDue(ctx context.Context, job Job) error {
+	name := job.Name()
+	lease := s.d.Config().MaintenanceLease(ctx)
+	interval := s.d.Config().MaintenanceJobInterval(ctx, name)
+
+	acquired, err := s.d.MaintenancePersister().AcquireMaintenanceJob(ctx, name, s.holder, time.Now().Add(-interval), lease)
+	if err != nil || !acquired {
+		return err
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		s.renew(jobCtx, cancel, name, lease)
+	}()
+
+	l := s.d.Logger().WithField("job", name)
+	l.Info("Running the maintenance job.")
+
+	jobRunning.WithLabelValues(name).Set(1)
+	start := time.Now()
+	runErr := job.Run(jobCtx)
+	jobRunning.WithLabelValues(name).Set(0)
+	observeRun(name, start, runErr)
+
+	cancel()
+	<-renewed
+
+	if runErr != nil {
+		l.WithError(runErr).Error("The maintenance job failed.")
+	} else {
+		l.WithField("duration", time.Since(start)).Info("The maintenance job succeeded.")
+	}
+
+	// Record the result even if the scheduler is shutting down, so that the
+	// job is not run again right away by another replica.
+	return s.d.MaintenancePersister().ReleaseMaintenanceJob(context.WithoutCancel(ctx), name, s.holder, runErr)
+}
+
+// renew extends the job's lease until the context is canceled. If the lease
+// was taken over by another replica, the job is canceled.
+func (s *Scheduler) renew(ctx context.Context, cancel context.CancelFunc, name string, lease time.Duration) {
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ok, err := s.d.MaintenancePersister().RenewMaintenanceJob(ctx, name, s.holder, lease)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.d.Logger().WithError(err).WithField("job", name).Warn("Unable to renew the maintenance job lease.")
+			}
+			continue
+		}
+		if !ok {
+			s.d.Logger().WithField("job", name).Warn("Lost the maintenance job lease to another replica, canceling the job.")
+			cancel()
+			return
+		}
+	}
+}
+
+// Status returns the status of every job known to the scheduler.
+func (s *Scheduler) Status(ctx context.Context) ([]JobStatus, error) {
+	states, err := s.d.MaintenancePersister().ListMaintenanceJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]JobState, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+
+	now := time.Now()
+	status := make([]JobStatus, len(s.jobs))
+	for k, job := range s.jobs {
+		name := job.Name()
+		interval := s.d.Config().MaintenanceJobInterval(ctx, name)
+		status[k] = JobStatus{
+			Name:     name,
+			Enabled:  s.d.Config().MaintenanceEnabled(ctx) && s.d.Config().MaintenanceJobEnabled(ctx, name),
+			Interval: interval.String(),
+		}
+
+		state, ok := byName[name]
+		if !ok {
+			continue
+		}
+		if state.Running(now) {
+			status[k].Running = true
+			status[k].RunningOn = state.LockedBy.String()
+		}
+		if state.LastStartedAt != nil {
+			t := time.Time(*state.LastStartedAt)
+			status[k].LastStartedAt = &t
+		}
+		if state.LastFinishedAt != nil {
+			t := time.Time(*state.LastFinishedAt)
+			next := t.Add(interval)
+			status[k].LastFinishedAt = &t
+			status[k].NextRunAt = &next
+		}
+		status[k].LastStatus = state.LastStatus.String()
+		status[k].LastError = state.LastError.String()
+	}
+	return status, nil
+}
@@ -0,0 +1,147 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/pkg"
+)
+
+type countingJob struct {
+	name string
+	runs int
+	err  error
+}
+
+func (j *countingJob) Name() string { return j.name }
+
+func (j *countingJob) Run(context.Context) error {
+	j.runs++
+	return j.err
+}
+
+func TestPersister(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.MaintenancePersister()
+
+	acquired, err := p.AcquireMaintenanceJob(ctx, "persister", "replica-a", time.Now(), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	t.Run("case=only one replica holds the lease", func(t *testing.T) {
+		acquired, err := p.AcquireMaintenanceJob(ctx, "persister", "replica-b", time.Now(), time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+
+		renewed, err := p.RenewMaintenanceJob(ctx, "persister", "replica-b", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, renewed)
+
+		renewed, err = p.RenewMaintenanceJob(ctx, "persister", "replica-a", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, renewed)
+	})
+
+	t.Run("case=the job is not run again before it is due", func(t *testing.T) {
+		require.NoError(t, p.ReleaseMaintenanceJob(ctx, "persister", "replica-a", errors.New("the job failed")))
+
+		acquired, err := p.AcquireMaintenanceJob(ctx, "persister", "replica-b", time.Now().Add(-time.Hour), time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+
+		acquired, err = p.AcquireMaintenanceJob(ctx, "persister", "replica-b", time.Now().Add(time.Second), time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("case=an expired lease is taken over", func(t *testing.T) {
+		acquired, err := p.AcquireMaintenanceJob(ctx, "expired", "replica-a", time.Now(), -time.Second)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		acquired, err = p.AcquireMaintenanceJob(ctx, "expired", "replica-b", time.Now(), time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("case=lists the job state", func(t *testing.T) {
+		jobs, err := p.ListMaintenanceJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, "expired", jobs[0].Name)
+		assert.Equal(t, "persister", jobs[1].Name)
+		assert.EqualValues(t, "replica-b", jobs[1].LockedBy)
+		assert.EqualValues(t, maintenance.RunStatusFailed, jobs[1].LastStatus)
+		assert.EqualValues(t, "the job failed", jobs[1].LastError)
+
+		// The lease is computed by the database.
+		require.NotNil(t, jobs[1].LockedUntil)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), time.Time(*jobs[1].LockedUntil), 10*time.Second)
+	})
+}
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyMaintenanceEnabled, true)
+
+	succeeding := &countingJob{name: "succeeding"}
+	failing := &countingJob{name: "failing", err: errors.New("something went wrong")}
+	disabled := &countingJob{name: "disabled"}
+	conf.MustSet(ctx, config.ViperKeyMaintenanceJobs+".disabled.enabled", false)
+
+	s := maintenance.NewScheduler(reg, succeeding, failing, disabled)
+	other := maintenance.NewScheduler(reg, succeeding, failing, disabled)
+
+	t.Run("case=runs due jobs once", func(t *testing.T) {
+		s.RunDue(ctx)
+		other.RunDue(ctx)
+		s.RunDue(ctx)
+
+		assert.Equal(t, 1, succeeding.runs)
+		assert.Equal(t, 1, failing.runs)
+		assert.Equal(t, 0, disabled.runs)
+	})
+
+	t.Run("case=runs jobs again once the interval passed", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyMaintenanceJobs+".succeeding.interval", "1ns")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyMaintenanceJobs+".succeeding.interval", "1h") })
+
+		other.RunDue(ctx)
+		assert.Equal(t, 2, succeeding.runs)
+		assert.Equal(t, 1, failing.runs)
+	})
+
+	t.Run("case=reports the status", func(t *testing.T) {
+		status, err := s.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, status, 3)
+
+		assert.Equal(t, "succeeding", status[0].Name)
+		assert.True(t, status[0].Enabled)
+		assert.False(t, status[0].Running)
+		assert.Equal(t, maintenance.RunStatusSucceeded, status[0].LastStatus)
+		assert.Empty(t, status[0].LastError)
+		require.NotNil(t, status[0].NextRunAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *status[0].NextRunAt, time.Minute)
+
+		assert.Equal(t, "failing", status[1].Name)
+		assert.Equal(t, maintenance.RunStatusFailed, status[1].LastStatus)
+		assert.Equal(t, "something went wrong", status[1].LastError)
+
+		assert.Equal(t, "disabled", status[2].Name)
+		assert.False(t, status[2].Enabled)
+		assert.Nil(t, status[2].LastStartedAt)
+	})
+}
@@ -0,0 +1,417 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organization
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCollection = "/organizations"
+	RouteItem       = RouteCollection + "/{id}"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		cipher.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		PersistenceProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		OrganizationHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		RouteCollection,
+		RouteCollection+"/*",
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.PUT(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteCollection, h.create)
+	admin.GET(RouteItem, h.get)
+	admin.PUT(RouteItem, h.update)
+	admin.DELETE(RouteItem, h.delete)
+}
+
+// Organization Request Body
+//
+// swagger:model organizationBody
+type OrganizationBody struct {
+	// Label is a human-readable name of the organization.
+	//
+	// required: true
+	Label string `json:"label"`
+
+	// Domains are the email domains belonging to the organization. Users
+	// signing in with an email address of one of these domains are sent to
+	// the organization's OpenID Connect provider.
+	Domains []string `json:"domains,omitempty"`
+
+	// OIDCProviders are the organization's OpenID Connect providers. They
+	// use the same format as the providers in
+	// `selfservice.methods.oidc.config.providers`. Provider IDs must be unique
+	// across all organizations and the configured providers. On updates,
+	// providers without a `client_secret` keep their current client secret.
+	OIDCProviders []json.RawMessage `json:"oidc_providers,omitempty"`
+}
+
+func (h *Handler) decode(r *http.Request, id uuid.UUID) (*Organization, error) {
+	var body OrganizationBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()))
+	}
+
+	o := &Organization{
+		ID:            id,
+		Label:         body.Label,
+		Domains:       body.Domains,
+		OIDCProviders: make([]sqlxx.JSONRawMessage, len(body.OIDCProviders)),
+	}
+	for k, c := range body.OIDCProviders {
+		o.OIDCProviders[k] = sqlxx.JSONRawMessage(c)
+	}
+
+	var configured []string
+	for _, id := range gjson.GetBytes(h.r.Config().SelfServiceStrategy(r.Context(), identity.CredentialsTypeOIDC.String()).Config, "providers.#.id").Array() {
+		configured = append(configured, id.String())
+	}
+	if err := o.Validate(r.Context(), configured); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (h *Handler) writePersistenceError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, sqlcon.ErrUniqueViolation()) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReason("One of the domains or OpenID Connect provider IDs already belongs to another organization.")))
+		return
+	}
+	h.r.Writer().WriteError(w, r, err)
+}
+
+// Create Organization Parameters
+//
+// swagger:parameters createOrganization
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createOrganization struct {
+	// in: body
+	Body OrganizationBody
+}
+
+// swagger:route POST /admin/organizations organization createOrganization
+//
+// # Create an Organization
+//
+// Creates an organization with its email domains and OpenID Connect
+// providers.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: organization
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	o, err := h.decode(r, uuid.Must(uuid.NewV4()))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	if err := o.EncryptClientSecrets(r.Context(), h.r.Cipher(r.Context()), nil); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.OrganizationPersister().CreateOrganization(r.Context(), o); err != nil {
+		h.writePersistenceError(w, r, err)
+		return
+	}
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(r.Context()), "organizations", o.ID.String()).String(),
+		o.WithoutClientSecrets(),
+	)
+}
+
+// Paginated Organization List Response
+//
+// swagger:response listOrganizations
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listOrganizationsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of organizations
+	//
+	// in:body
+	Body []Organization
+}
+
+// List Organizations Parameters
+//
+// swagger:parameters listOrganizations
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listOrganizations struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/organizations organization listOrganizations
+//
+// # List Organizations
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listOrganizations
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	organizations, nextPage, err := h.r.OrganizationPersister().ListOrganizations(r.Context(), opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for k := range organizations {
+		organizations[k] = organizations[k].WithoutClientSecrets()
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, organizations)
+}
+
+// Get Organization Parameters
+//
+// swagger:parameters getOrganization
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getOrganization struct {
+	// ID is the organization's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/organizations/{id} organization getOrganization
+//
+// # Get an Organization
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: organization
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	o, err := h.r.OrganizationPersister().GetOrganization(r.Context(), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, o.WithoutClientSecrets())
+}
+
+// Update Organization Parameters
+//
+// swagger:parameters updateOrganization
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateOrganization struct {
+	// ID is the organization's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body OrganizationBody
+}
+
+// swagger:route PUT /admin/organizations/{id} organization updateOrganization
+//
+// # Update an Organization
+//
+// Replaces the organization's label, email domains and OpenID Connect
+// providers.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: organization
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) update(w http.ResponseWriter, r *http.Request) {
+	o, err := h.decode(r, x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	existing, err := h.r.OrganizationPersister().GetOrganization(r.Context(), o.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	if err := o.EncryptClientSecrets(r.Context(), h.r.Cipher(r.Context()), existing.OIDCProviders); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.OrganizationPersister().UpdateOrganization(r.Context(), o); err != nil {
+		h.writePersistenceError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, o.WithoutClientSecrets())
+}
+
+// Delete Organization Parameters
+//
+// swagger:parameters deleteOrganization
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteOrganization struct {
+	// ID is the organization's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/organizations/{id} organization deleteOrganization
+//
+// # Delete an Organization
+//
+// Deletes the organization, its email domains and its OpenID Connect
+// providers. Identities of the organization are not deleted, but can no
+// longer sign in using the organization's providers.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.r.OrganizationPersister().DeleteOrganization(r.Context(), x.ParseUUID(r.PathValue("id"))); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organization_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/organization"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".oidc.config.providers", []map[string]any{{
+		"id":            "google",
+		"provider":      "google",
+		"client_id":     "client",
+		"client_secret": "secret",
+		"mapper_url":    "file://./stub/oidc.jsonnet",
+	}})
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	send := func(t *testing.T, method, path string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var reqBody io.Reader
+		if body != nil {
+			b, err := json.Marshal(body)
+			require.NoError(t, err)
+			reqBody = bytes.NewReader(b)
+		}
+		req, err := http.NewRequest(method, adminTS.URL+"/admin/organizations"+path, reqBody)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	created := send(t, "POST", "", organization.OrganizationBody{
+		Label:         "ACME",
+		Domains:       []string{"ACME.com"},
+		OIDCProviders: []json.RawMessage{json.RawMessage(githubProvider)},
+	}, http.StatusCreated)
+	id := created.Get("id").String()
+	assert.Equal(t, "ACME", created.Get("label").String())
+	assert.Equal(t, []any{"acme.com"}, created.Get("domains").Value())
+	assert.Equal(t, "acme-github", created.Get("oidc_providers.0.id").String(), "%s", created.Raw)
+	assert.False(t, created.Get("oidc_providers.0.client_secret").Exists(), "%s", created.Raw)
+
+	clientSecret := func(t *testing.T) string {
+		p, err := reg.OrganizationPersister().FindOrganizationOIDCProvider(ctx, "acme-github")
+		require.NoError(t, err)
+		assert.NotEqual(t, "secret", gjson.GetBytes(p.Config, "client_secret").String(), "the client secret is stored encrypted")
+		config, err := p.DecryptClientSecret(ctx, reg.Cipher(ctx))
+		require.NoError(t, err)
+		return gjson.GetBytes(config, "client_secret").String()
+	}
+
+	t.Run("case=finds the organization by domain and provider", func(t *testing.T) {
+		o, err := reg.OrganizationPersister().FindOrganizationByDomain(ctx, "acme.com")
+		require.NoError(t, err)
+		assert.Equal(t, id, o.ID.String())
+
+		p, err := reg.OrganizationPersister().FindOrganizationOIDCProvider(ctx, "acme-github")
+		require.NoError(t, err)
+		assert.Equal(t, id, p.OrganizationID.String())
+		assert.Equal(t, "secret", clientSecret(t))
+	})
+
+	t.Run("case=does not return client secrets", func(t *testing.T) {
+		assert.False(t, send(t, "GET", "/"+id, nil, http.StatusOK).Get("oidc_providers.0.client_secret").Exists())
+		assert.False(t, send(t, "GET", "", nil, http.StatusOK).Get("0.oidc_providers.0.client_secret").Exists())
+	})
+
+	t.Run("case=rejects conflicts", func(t *testing.T) {
+		send(t, "POST", "", organization.OrganizationBody{Label: "Other", Domains: []string{"acme.com"}}, http.StatusConflict)
+		send(t, "POST", "", organization.OrganizationBody{Label: "Other", OIDCProviders: []json.RawMessage{json.RawMessage(githubProvider)}}, http.StatusConflict)
+
+		res := send(t, "POST", "", organization.OrganizationBody{
+			Label:         "Other",
+			OIDCProviders: []json.RawMessage{json.RawMessage(`{"id":"google","provider":"google","client_id":"client","client_secret":"secret","mapper_url":"file://./stub/oidc.jsonnet"}`)},
+		}, http.StatusConflict)
+		assert.Contains(t, res.Get("error.reason").String(), "configured provider")
+	})
+
+	t.Run("case=rejects invalid organizations", func(t *testing.T) {
+		send(t, "POST", "", organization.OrganizationBody{Label: "Invalid", Domains: []string{"https://invalid.com"}}, http.StatusBadRequest)
+		send(t, "POST", "", organization.OrganizationBody{Label: "Invalid", OIDCProviders: []json.RawMessage{json.RawMessage(`{"id":"invalid"}`)}}, http.StatusBadRequest)
+	})
+
+	t.Run("case=updates organizations", func(t *testing.T) {
+		updated := send(t, "PUT", "/"+id, organization.OrganizationBody{Label: "ACME Inc.", Domains: []string{"acme.com", "acme.org"}}, http.StatusOK)
+		assert.Equal(t, "ACME Inc.", updated.Get("label").String())
+
+		actual := send(t, "GET", "/"+id, nil, http.StatusOK)
+		assert.Equal(t, "ACME Inc.", actual.Get("label").String())
+		assert.Equal(t, []any{"acme.com", "acme.org"}, actual.Get("domains").Value())
+		assert.Empty(t, actual.Get("oidc_providers").Array())
+
+		send(t, "PUT", "/"+id, organization.OrganizationBody{
+			Label:         "ACME",
+			Domains:       []string{"acme.com"},
+			OIDCProviders: []json.RawMessage{json.RawMessage(githubProvider)},
+		}, http.StatusOK)
+		assert.Equal(t, "secret", clientSecret(t))
+
+		updated = send(t, "PUT", "/"+id, organization.OrganizationBody{
+			Label:         "ACME",
+			Domains:       []string{"acme.com"},
+			OIDCProviders: []json.RawMessage{json.RawMessage(`{"id":"acme-github","provider":"github","client_id":"other-client","mapper_url":"file://./stub/oidc.jsonnet"}`)},
+		}, http.StatusOK)
+		assert.Equal(t, "other-client", updated.Get("oidc_providers.0.client_id").String())
+		assert.Equal(t, "secret", clientSecret(t), "the client secret is kept if it is not sent")
+
+		send(t, "PUT", "/"+uuid.Must(uuid.NewV4()).String(), organization.OrganizationBody{Label: "Unknown"}, http.StatusNotFound)
+	})
+
+	t.Run("case=lists organizations", func(t *testing.T) {
+		send(t, "POST", "", organization.OrganizationBody{Label: "Globex", Domains: []string{"globex.com"}}, http.StatusCreated)
+
+		list := send(t, "GET", "", nil, http.StatusOK)
+		assert.ElementsMatch(t, []string{"ACME", "Globex"}, []string{list.Get("0.label").String(), list.Get("1.label").String()})
+	})
+
+	t.Run("case=deletes organizations", func(t *testing.T) {
+		o := send(t, "POST", "", organization.OrganizationBody{Label: "Initech", Domains: []string{"initech.com"}}, http.StatusCreated)
+
+		send(t, "DELETE", "/"+o.Get("id").String(), nil, http.StatusNoContent)
+		send(t, "GET", "/"+o.Get("id").String(), nil, http.StatusNotFound)
+		send(t, "DELETE", "/"+o.Get("id").String(), nil, http.StatusNotFound)
+
+		// The domain can be used again.
+		send(t, "POST", "", organization.OrganizationBody{Label: "Initech", Domains: []string{"initech.com"}}, http.StatusCreated)
+	})
+}
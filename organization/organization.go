@@ -0,0 +1,283 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organization
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/jsonschema/v3"
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/embedx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+var domainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+type (
+	// Organization groups the identities of a customer who brings their own
+	// identity provider.
+	//
+	// Users whose email address belongs to one of the organization's domains
+	// sign in using the organization's OpenID Connect providers.
+	//
+	// swagger:model organization
+	Organization struct {
+		// ID is the organization's ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// Label is a human-readable name of the organization.
+		//
+		// required: true
+		Label string `json:"label" db:"label"`
+
+		// Domains are the email domains belonging to the organization.
+		//
+		// required: true
+		Domains []string `json:"domains" faker:"-" db:"-"`
+
+		// OIDCProviders are the organization's OpenID Connect providers. They
+		// use the same format as the providers in
+		// `selfservice.methods.oidc.config.providers`. Client secrets are
+		// stored encrypted and never returned.
+		//
+		// required: true
+		OIDCProviders []sqlxx.JSONRawMessage `json:"oidc_providers" faker:"-" db:"-"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+		NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+	}
+
+	// Domain maps an email domain to an organization.
+	Domain struct {
+		ID             uuid.UUID `json:"id" db:"id"`
+		OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+		Domain         string    `json:"domain" db:"domain"`
+		CreatedAt      time.Time `json:"-" db:"created_at"`
+		UpdatedAt      time.Time `json:"-" db:"updated_at"`
+		NID            uuid.UUID `json:"-" db:"nid"`
+	}
+
+	// OIDCProvider is an OpenID Connect provider of an organization.
+	OIDCProvider struct {
+		ID             uuid.UUID            `json:"id" db:"id"`
+		OrganizationID uuid.UUID            `json:"organization_id" db:"organization_id"`
+		ProviderID     string               `json:"provider_id" db:"provider_id"`
+		Config         sqlxx.JSONRawMessage `json:"config" db:"config"`
+		CreatedAt      time.Time            `json:"-" db:"created_at"`
+		UpdatedAt      time.Time            `json:"-" db:"updated_at"`
+		NID            uuid.UUID            `json:"-" db:"nid"`
+	}
+
+	Persister interface {
+		// CreateOrganization creates the organization, its domains and its
+		// OpenID Connect providers.
+		CreateOrganization(ctx context.Context, o *Organization) error
+		GetOrganization(ctx context.Context, id uuid.UUID) (*Organization, error)
+		ListOrganizations(ctx context.Context, opts []keysetpagination.Option) ([]Organization, *keysetpagination.Paginator, error)
+		// UpdateOrganization replaces the organization's label, domains and
+		// OpenID Connect providers.
+		UpdateOrganization(ctx context.Context, o *Organization) error
+		DeleteOrganization(ctx context.Context, id uuid.UUID) error
+
+		// FindOrganizationByDomain returns the organization the email domain
+		// belongs to.
+		FindOrganizationByDomain(ctx context.Context, domain string) (*Organization, error)
+		// FindOrganizationOIDCProvider returns the organization provider with
+		// the given provider ID.
+		FindOrganizationOIDCProvider(ctx context.Context, providerID string) (*OIDCProvider, error)
+	}
+
+	PersistenceProvider interface {
+		OrganizationPersister() Persister
+	}
+)
+
+func (Organization) TableName() string { return "organizations" }
+
+func (Domain) TableName() string { return "organization_domains" }
+
+func (OIDCProvider) TableName() string { return "organization_oidc_providers" }
+
+func (o Organization) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: o.ID})
+}
+
+func (o Organization) DefaultPageToken() keysetpagination.PageToken {
+	return Organization{ID: uuid.Nil}.PageToken()
+}
+
+// EmailDomain returns the lower-cased domain of the email address, or an
+// empty string if the identifier is not an email address.
+func EmailDomain(identifier string) string {
+	_, domain, ok := strings.Cut(strings.TrimSpace(identifier), "@")
+	if !ok || strings.Contains(domain, "@") {
+		return ""
+	}
+	domain = strings.ToLower(domain)
+	if !domainRegex.MatchString(domain) {
+		return ""
+	}
+	return domain
+}
+
+// ProviderID returns the ID of the OpenID Connect provider configuration.
+func ProviderID(config []byte) string {
+	return gjson.GetBytes(config, "id").String()
+}
+
+// Validate normalizes the organization's domains and checks its label,
+// domains and OpenID Connect provider configurations. Provider IDs must not
+// be one of the configured provider IDs, because configured providers take
+// precedence over organization providers with the same ID.
+func (o *Organization) Validate(ctx context.Context, configuredProviderIDs []string) error {
+	o.Label = strings.TrimSpace(o.Label)
+	if o.Label == "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The organization label must not be empty."))
+	}
+
+	domains := make([]string, 0, len(o.Domains))
+	for _, d := range o.Domains {
+		domain := strings.ToLower(strings.TrimSpace(d))
+		if !domainRegex.MatchString(domain) {
+			return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The organization domain %q is invalid. Domains must not contain a scheme, port or path.", d))
+		}
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	o.Domains = domains
+
+	if o.OIDCProviders == nil {
+		o.OIDCProviders = []sqlxx.JSONRawMessage{}
+	}
+
+	var ids []string
+	for _, c := range o.OIDCProviders {
+		if err := validateOIDCProvider(ctx, c); err != nil {
+			return err
+		}
+
+		id := ProviderID(c)
+		if slices.Contains(configuredProviderIDs, id) {
+			return errors.WithStack(herodot.ErrConflict().WithReasonf("The OpenID Connect provider ID %q is already used by a configured provider.", id))
+		}
+		if slices.Contains(ids, id) {
+			return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The OpenID Connect provider ID %q is used more than once.", id))
+		}
+		if organization := gjson.GetBytes(c, "organization_id"); organization.Exists() && organization.String() != o.ID.String() {
+			return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The OpenID Connect provider %q must not belong to another organization.", id))
+		}
+		ids = append(ids, id)
+	}
+
+	return nil
+}
+
+// EncryptClientSecrets encrypts the client secrets of the organization's
+// OpenID Connect providers. Providers without a client secret keep the
+// already encrypted secret of the existing provider with the same ID, so that
+// secrets do not need to be sent again on updates.
+func (o *Organization) EncryptClientSecrets(ctx context.Context, c cipher.Cipher, existing []sqlxx.JSONRawMessage) error {
+	for k, config := range o.OIDCProviders {
+		if secret := gjson.GetBytes(config, "client_secret"); secret.Exists() {
+			if _, ok := c.(*cipher.Noop); ok {
+				return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf(
+					`The OpenID Connect provider %q has a client secret, but no cipher is configured to encrypt it. Set "ciphers.algorithm" and "secrets.cipher".`, ProviderID(config)))
+			}
+
+			encrypted, err := c.Encrypt(ctx, []byte(secret.String()))
+			if err != nil {
+				return err
+			}
+			if config, err = sjson.SetBytes(config, "client_secret", encrypted); err != nil {
+				return errors.WithStack(err)
+			}
+			o.OIDCProviders[k] = config
+			continue
+		}
+
+		id := ProviderID(config)
+		for _, e := range existing {
+			if secret := gjson.GetBytes(e, "client_secret"); ProviderID(e) == id && secret.Exists() {
+				config, err := sjson.SetBytes(config, "client_secret", secret.String())
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				o.OIDCProviders[k] = config
+			}
+		}
+	}
+	return nil
+}
+
+// WithoutClientSecrets returns a copy of the organization without the client
+// secrets of its OpenID Connect providers.
+func (o Organization) WithoutClientSecrets() Organization {
+	providers := make([]sqlxx.JSONRawMessage, len(o.OIDCProviders))
+	for k, config := range o.OIDCProviders {
+		if stripped, err := sjson.DeleteBytes(config, "client_secret"); err == nil {
+			providers[k] = stripped
+		}
+	}
+	o.OIDCProviders = providers
+	return o
+}
+
+// DecryptClientSecret returns the provider's configuration with its client
+// secret decrypted.
+func (p *OIDCProvider) DecryptClientSecret(ctx context.Context, c cipher.Cipher) (sqlxx.JSONRawMessage, error) {
+	secret := gjson.GetBytes(p.Config, "client_secret")
+	if !secret.Exists() {
+		return p.Config, nil
+	}
+
+	plaintext, err := c.Decrypt(ctx, secret.String())
+	if err != nil {
+		return nil, err
+	}
+	config, err := sjson.SetBytes(p.Config, "client_secret", string(plaintext))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return config, nil
+}
+
+func validateOIDCProvider(ctx context.Context, config []byte) error {
+	c := jsonschema.NewCompiler()
+	if err := embedx.AddSchemaResources(c, embedx.Config); err != nil {
+		return errors.WithStack(err)
+	}
+	s, err := c.Compile(ctx, embedx.Config.GetSchemaID()+"#/definitions/selfServiceOIDCProvider")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	dec, err := jsonschema.DecodeJSON(bytes.NewReader(config))
+	if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The OpenID Connect provider configuration must be a JSON object: %s", err))
+	}
+	if err := s.ValidateInterface(dec); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The OpenID Connect provider configuration is invalid: %s", err))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/organization"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/x/sqlxx"
+)
+
+const githubProvider = `{"id":"acme-github","provider":"github","client_id":"client","client_secret":"secret","mapper_url":"file://./stub/oidc.jsonnet"}`
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		org  organization.Organization
+		err  string
+	}{
+		{name: "valid", org: organization.Organization{Label: "ACME", Domains: []string{"acme.com"}, OIDCProviders: []sqlxx.JSONRawMessage{[]byte(githubProvider)}}},
+		{name: "no label", org: organization.Organization{Label: " "}, err: "label"},
+		{name: "domain with scheme", org: organization.Organization{Label: "ACME", Domains: []string{"https://acme.com"}}, err: "is invalid"},
+		{name: "domain without tld", org: organization.Organization{Label: "ACME", Domains: []string{"acme"}}, err: "is invalid"},
+		{name: "provider is not an object", org: organization.Organization{Label: "ACME", OIDCProviders: []sqlxx.JSONRawMessage{[]byte(`[]`)}}, err: "is invalid"},
+		{name: "provider without client", org: organization.Organization{Label: "ACME", OIDCProviders: []sqlxx.JSONRawMessage{[]byte(`{"id":"acme","provider":"github","mapper_url":"file://./stub/oidc.jsonnet"}`)}}, err: "is invalid"},
+		{name: "duplicate provider", org: organization.Organization{Label: "ACME", OIDCProviders: []sqlxx.JSONRawMessage{[]byte(githubProvider), []byte(githubProvider)}}, err: "more than once"},
+		{
+			name: "provider of another organization",
+			org: organization.Organization{Label: "ACME", OIDCProviders: []sqlxx.JSONRawMessage{
+				[]byte(`{"id":"acme","provider":"github","client_id":"client","client_secret":"secret","mapper_url":"file://./stub/oidc.jsonnet","organization_id":"` + uuid.Must(uuid.NewV4()).String() + `"}`),
+			}},
+			err: "another organization",
+		},
+		{
+			name: "configured provider",
+			org: organization.Organization{Label: "ACME", OIDCProviders: []sqlxx.JSONRawMessage{
+				[]byte(`{"id":"google","provider":"google","client_id":"client","client_secret":"secret","mapper_url":"file://./stub/oidc.jsonnet"}`),
+			}},
+			err: "configured provider",
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			err := tc.org.Validate(ctx, []string{"google"})
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			var he *herodot.DefaultError
+			require.ErrorAs(t, err, &he)
+			assert.Contains(t, he.Reason(), tc.err)
+		})
+	}
+
+	t.Run("case=domains are normalized", func(t *testing.T) {
+		o := organization.Organization{Label: " ACME ", Domains: []string{"ACME.com", "acme.com", " sub.acme.com"}}
+		require.NoError(t, o.Validate(ctx, nil))
+		assert.Equal(t, "ACME", o.Label)
+		assert.Equal(t, []string{"acme.com", "sub.acme.com"}, o.Domains)
+		assert.Equal(t, []sqlxx.JSONRawMessage{}, o.OIDCProviders)
+	})
+}
+
+func TestEncryptClientSecrets(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	t.Run("case=encrypts client secrets", func(t *testing.T) {
+		o := organization.Organization{OIDCProviders: []sqlxx.JSONRawMessage{[]byte(githubProvider)}}
+		require.NoError(t, o.EncryptClientSecrets(ctx, reg.Cipher(ctx), nil))
+		assert.NotEqual(t, "secret", gjson.GetBytes(o.OIDCProviders[0], "client_secret").String())
+
+		config, err := (&organization.OIDCProvider{Config: o.OIDCProviders[0]}).DecryptClientSecret(ctx, reg.Cipher(ctx))
+		require.NoError(t, err)
+		assert.Equal(t, "secret", gjson.GetBytes(config, "client_secret").String())
+	})
+
+	t.Run("case=requires a cipher", func(t *testing.T) {
+		o := organization.Organization{OIDCProviders: []sqlxx.JSONRawMessage{[]byte(githubProvider)}}
+		err := o.EncryptClientSecrets(ctx, cipher.NewNoop(), nil)
+		require.ErrorIs(t, err, herodot.ErrMisconfiguration())
+		assert.Equal(t, "secret", gjson.GetBytes(o.OIDCProviders[0], "client_secret").String())
+	})
+}
+
+func TestEmailDomain(t *testing.T) {
+	for identifier, expected := range map[string]string{
+		"user@acme.com":       "acme.com",
+		" User@ACME.com ":     "acme.com",
+		"user@sub.acme.co.uk": "sub.acme.co.uk",
+		"user":                "",
+		"user@acme":           "",
+		"user@acme.com@evil":  "",
+		"user@acme.com/path":  "",
+	} {
+		assert.Equal(t, expected, organization.EmailDomain(identifier), identifier)
+	}
+}
@@ -5,7 +5,6 @@ package persistence
 
 import (
 	"context"
-	"time"
 
 	"github.com/ory/kratos/x"
 
@@ -18,18 +17,29 @@ import (
 
 	"github.com/ory/x/popx"
 
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/kratos/bulk"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/invitation"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/kratos/organization"
+	"github.com/ory/kratos/retention"
+	"github.com/ory/kratos/risk"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/device"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/revokesessions"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
 )
 
 type Provider interface {
@@ -41,11 +51,25 @@ type Persister interface {
 	continuity.Persister
 	identity.PrivilegedPool
 	identity.PendingTraitsChangePersister
+	identity.UniqueTraitPersister
 	registration.FlowPersister
 	login.FlowPersister
 	settings.FlowPersister
+	device.Persister
+	revokesessions.Persister
 	courier.Persister
+	tenant.Persister
+	organization.Persister
+	invitation.Persister
+	legal.Persister
+	apikey.Persister
+	maintenance.Persister
+	retention.Persister
+	bulk.Persister
+	risk.Persister
 	session.Persister
+	session.TrustedDevicePersister
+	identity.ProfilePromptPersister
 	sessiontokenexchange.Persister
 	errorx.Persister
 	verification.FlowPersister
@@ -57,7 +81,6 @@ type Persister interface {
 	code.RegistrationCodePersister
 	code.LoginCodePersister
 
-	CleanupDatabase(context.Context, time.Duration, time.Duration, int) error
 	Close(context.Context) error
 	Ping(context.Context) error
 	MigrationStatus(context.Context) (popx.MigrationStatuses, error)
@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -50,6 +51,7 @@ var (
 type dependencies interface {
 	schema.IdentitySchemaProvider
 	identity.ValidationProvider
+	identity.TraitsClassifierProvider
 	logrusx.Provider
 	config.Provider
 	contextx.Provider
@@ -356,6 +358,10 @@ LIMIT 1`, columns,
 		return nil, sqlcon.HandleError(err)
 	}
 
+	if err := p.r.IdentityTraitsClassifier().DecryptTraits(ctx, &id); err != nil {
+		return nil, err
+	}
+
 	return &id, nil
 }
 
@@ -736,6 +742,17 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 		}
 	}
 
+	uniqueTraits, err := p.uniqueTraits(ctx, identities...)
+	if err != nil {
+		return err
+	}
+
+	restoreTraits, err := p.encryptTraits(ctx, identities...)
+	if err != nil {
+		return err
+	}
+	defer restoreTraits()
+
 	var succeededIDs []uuid.UUID
 	var partialErr *identity.CreateIdentitiesError
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
@@ -814,6 +831,25 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 			}
 		}
 
+		duplicateTraitErrs := make(map[uuid.UUID]*herodot.DefaultError)
+		for _, ident := range createdIdentities {
+			if _, ok := failedIdentityIDs[ident.ID]; ok {
+				continue
+			}
+			sync := p.syncUniqueTraits
+			if len(identities) > 1 {
+				sync = p.syncUniqueTraitsInSavepoint
+			}
+			if err := sync(ctx, tx, ident.ID, uniqueTraits[ident]); err != nil {
+				var validationErr *schema.ValidationError
+				if len(identities) == 1 || !errors.As(err, &validationErr) {
+					return err
+				}
+				failedIdentityIDs[ident.ID] = struct{ created bool }{true}
+				duplicateTraitErrs[ident.ID] = sqlcon.ErrUniqueViolation().WithReason(validationErr.Message)
+			}
+		}
+
 		// If any of the batch inserts failed on conflict, let's delete the corresponding
 		// identity and return a list of failed identities in the error.
 		if len(failedIdentityIDs) > 0 {
@@ -822,7 +858,11 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities ...
 
 			for _, ident := range identities {
 				if info, ok := failedIdentityIDs[ident.ID]; ok {
-					partialErr.AddFailedIdentity(ident, sqlcon.ErrUniqueViolation())
+					if err, ok := duplicateTraitErrs[ident.ID]; ok {
+						partialErr.AddFailedIdentity(ident, err)
+					} else {
+						partialErr.AddFailedIdentity(ident, sqlcon.ErrUniqueViolation())
+					}
 					if info.created {
 						idsToBeRemoved = append(idsToBeRemoved, ident.ID)
 					}
@@ -928,6 +968,10 @@ func (p *IdentityPersister) HydrateIdentityAssociations(ctx context.Context, i *
 		return err
 	}
 
+	if err := p.r.IdentityTraitsClassifier().DecryptTraits(ctx, i); err != nil {
+		return err
+	}
+
 	return p.InjectTraitsSchemaURL(ctx, i)
 }
 
@@ -1151,6 +1195,20 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 			args = append(args, params.OrganizationID.String())
 		}
 
+		if params.SchemaID != "" {
+			wheres += `
+				AND identities.schema_id = ?
+			`
+			args = append(args, params.SchemaID)
+		}
+
+		if params.State != "" {
+			wheres += `
+				AND identities.state = ?
+			`
+			args = append(args, params.State)
+		}
+
 		columns := popx.DBColumns[identity.Identity](&popx.AliasQuoter{Alias: "identities", Quoter: con.Dialect})
 
 		query := fmt.Sprintf(`
@@ -1240,6 +1298,10 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 			return nil, nil, err
 		}
 
+		if err := p.r.IdentityTraitsClassifier().DecryptTraits(ctx, i); err != nil {
+			return nil, nil, err
+		}
+
 		is[k] = *i
 	}
 
@@ -1253,9 +1315,29 @@ func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identi
 			attribute.Stringer("network.id", p.NetworkID(ctx))))
 	defer otelx.End(span, &err)
 
+	var uniqueTraits []identity.UniqueTrait
+	syncUniqueTraits := slices.Contains(columns, "traits") || slices.Contains(columns, "organization_id")
+	if syncUniqueTraits {
+		uniqueTraits, err = p.r.IdentityTraitsClassifier().UniqueTraits(ctx, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	restoreTraits, err := p.encryptTraits(ctx, i)
+	if err != nil {
+		return err
+	}
+	defer restoreTraits()
+
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		_, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...)
-		return sqlcon.HandleError(err)
+		if _, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if syncUniqueTraits {
+			return p.syncUniqueTraits(ctx, tx, i.ID, uniqueTraits)
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
@@ -1264,6 +1346,20 @@ func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identi
 	return nil
 }
 
+func (p *IdentityPersister) UpdateIdentityLastAuthenticatedAt(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateIdentityLastAuthenticatedAt",
+		trace.WithAttributes(
+			attribute.Stringer("identity.id", id),
+			attribute.Stringer("network.id", p.NetworkID(ctx))))
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		fmt.Sprintf("UPDATE %s SET last_authenticated_at = ? WHERE id = ? AND nid = ?", new(identity.Identity).TableName(ctx)),
+		at.UTC().Truncate(time.Microsecond), id, p.NetworkID(ctx),
+	).Exec())
+}
+
 func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Identity, mods ...identity.UpdateIdentityModifier) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateIdentity",
 		trace.WithAttributes(
@@ -1275,6 +1371,18 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 		return err
 	}
 
+	i.NID = p.NetworkID(ctx)
+	uniqueTraits, err := p.r.IdentityTraitsClassifier().UniqueTraits(ctx, i)
+	if err != nil {
+		return err
+	}
+
+	restoreTraits, err := p.encryptTraits(ctx, i)
+	if err != nil {
+		return err
+	}
+	defer restoreTraits()
+
 	o := identity.NewUpdateIdentityOptions(mods)
 
 	span.SetAttributes(attribute.Bool("update.minimize_diff", o.FromDatabase() != nil))
@@ -1287,6 +1395,10 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 			return err
 		}
 
+		if err := p.syncUniqueTraits(ctx, tx, i.ID, uniqueTraits); err != nil {
+			return err
+		}
+
 		var identityCreds map[identity.CredentialsType]identity.Credentials
 		p.normalizeAllAddresses(ctx, i)
 		if o.FromDatabase() != nil {
@@ -1639,6 +1751,30 @@ func (p *IdentityPersister) validateIdentity(ctx context.Context, i *identity.Id
 	return nil
 }
 
+// encryptTraits encrypts the traits which the identity schema marks for
+// encryption. The returned function restores the plaintext traits and must be
+// called once the identities have been written to the database.
+func (p *IdentityPersister) encryptTraits(ctx context.Context, identities ...*identity.Identity) (restore func(), err error) {
+	plaintext := make([]identity.Traits, len(identities))
+	restore = func() {
+		for k, ident := range identities {
+			if plaintext[k] != nil {
+				ident.Traits = plaintext[k]
+			}
+		}
+	}
+
+	for k, ident := range identities {
+		plaintext[k] = ident.Traits
+		if err := p.r.IdentityTraitsClassifier().EncryptTraits(ctx, ident); err != nil {
+			restore()
+			return nil, err
+		}
+	}
+
+	return restore, nil
+}
+
 func (p *IdentityPersister) InjectTraitsSchemaURL(ctx context.Context, i *identity.Identity) (err error) {
 	// This trace is more noisy than it's worth in diagnostic power.
 	// ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.InjectTraitsSchemaURL")
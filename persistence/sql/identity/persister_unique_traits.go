@@ -0,0 +1,150 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence/sql/batch"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ identity.UniqueTraitPersister = new(IdentityPersister)
+
+func (p *IdentityPersister) IndexUniqueTraits(ctx context.Context, identityID uuid.UUID, traits []identity.UniqueTrait) (conflicts []identity.UniqueTrait, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.IndexUniqueTraits",
+		trace.WithAttributes(
+			attribute.Stringer("identity.id", identityID),
+			attribute.Stringer("network.id", p.NetworkID(ctx))))
+	defer otelx.End(span, &err)
+
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		conflicts, err = p.indexUniqueTraits(ctx, tx, identityID, traits)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// syncUniqueTraits replaces the unique traits index entries of the identity and
+// returns a validation error if a value is already used by another identity.
+func (p *IdentityPersister) syncUniqueTraits(ctx context.Context, tx *pop.Connection, identityID uuid.UUID, traits []identity.UniqueTrait) error {
+	conflicts, err := p.indexUniqueTraits(ctx, tx, identityID, traits)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return schema.NewDuplicateTraitError(conflicts[0].InstancePtr, conflicts[0].Path)
+	}
+	return nil
+}
+
+// syncUniqueTraitsInSavepoint is like syncUniqueTraits, but rolls back to a
+// savepoint if the traits can not be indexed. Otherwise, a violated unique
+// index would abort the whole transaction on PostgreSQL and CockroachDB, which
+// fails all following identities of a batch.
+func (p *IdentityPersister) syncUniqueTraitsInSavepoint(ctx context.Context, tx *pop.Connection, identityID uuid.UUID, traits []identity.UniqueTrait) error {
+	if err := tx.RawQuery("SAVEPOINT identity_unique_traits").Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if err := p.syncUniqueTraits(ctx, tx, identityID, traits); err != nil {
+		if rollbackErr := tx.RawQuery("ROLLBACK TO SAVEPOINT identity_unique_traits").Exec(); rollbackErr != nil {
+			return sqlcon.HandleError(rollbackErr)
+		}
+		return err
+	}
+	return sqlcon.HandleError(tx.RawQuery("RELEASE SAVEPOINT identity_unique_traits").Exec())
+}
+
+// indexUniqueTraits replaces the unique traits index entries of the identity.
+// Entries conflicting with another identity are not indexed. Instead, the
+// entries of the other identity are returned with InstancePtr pointing to the
+// conflicting value of this identity. If another identity indexes the same
+// value concurrently, a validation error is returned.
+func (p *IdentityPersister) indexUniqueTraits(ctx context.Context, tx *pop.Connection, identityID uuid.UUID, traits []identity.UniqueTrait) ([]identity.UniqueTrait, error) {
+	nid := p.NetworkID(ctx)
+
+	if err := tx.RawQuery("DELETE FROM identity_unique_traits WHERE identity_id = ? AND nid = ?", identityID, nid).Exec(); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	if len(traits) == 0 {
+		return nil, nil
+	}
+
+	// Values indexed before the secrets were rotated are hashed with a
+	// previous secret.
+	hashes := make([]string, 0, len(traits))
+	for _, trait := range traits {
+		hashes = append(append(hashes, trait.ValueHash), trait.ValueHashes...)
+	}
+
+	var existing []identity.UniqueTrait
+	if err := tx.Where("nid = ? AND identity_id <> ? AND value_hash IN (?)", nid, identityID, hashes).All(&existing); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	taken := make(map[string]identity.UniqueTrait, len(existing))
+	for _, trait := range existing {
+		taken[trait.Key()] = trait
+	}
+
+	var conflicts []identity.UniqueTrait
+	insert := make([]*identity.UniqueTrait, 0, len(traits))
+	for k := range traits {
+		trait := traits[k]
+		if other, ok := findUniqueTrait(taken, trait); ok {
+			other.InstancePtr = trait.InstancePtr
+			conflicts = append(conflicts, other)
+			continue
+		}
+
+		trait.ID = uuid.Nil
+		trait.IdentityID = identityID
+		trait.NID = nid
+		insert = append(insert, &trait)
+	}
+
+	// The entries are inserted one by one, so that a value indexed by another
+	// identity after it was checked above is attributed to the correct trait.
+	conn := &batch.TracerConnection{Tracer: p.r.Tracer(ctx), Connection: tx}
+	for _, trait := range insert {
+		if err := batch.Create(ctx, conn, []*identity.UniqueTrait{trait}); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+			return nil, schema.NewDuplicateTraitError(trait.InstancePtr, trait.Path)
+		} else if err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+	}
+	return conflicts, nil
+}
+
+func findUniqueTrait(taken map[string]identity.UniqueTrait, trait identity.UniqueTrait) (identity.UniqueTrait, bool) {
+	for _, key := range trait.Keys() {
+		if other, ok := taken[key]; ok {
+			return other, true
+		}
+	}
+	return identity.UniqueTrait{}, false
+}
+
+func (p *IdentityPersister) uniqueTraits(ctx context.Context, identities ...*identity.Identity) (map[*identity.Identity][]identity.UniqueTrait, error) {
+	result := make(map[*identity.Identity][]identity.UniqueTrait, len(identities))
+	for _, i := range identities {
+		traits, err := p.r.IdentityTraitsClassifier().UniqueTraits(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = traits
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package gomigrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/popx"
+	"github.com/ory/x/sqlxx"
+)
+
+// The types below are copies of the TOTP credentials configuration at the time
+// of the migration, so that later changes to the identity package do not
+// change what this migration does.
+type (
+	totpCredentialsRow struct {
+		ID        uuid.UUID            `db:"id"`
+		Config    sqlxx.JSONRawMessage `db:"config"`
+		CreatedAt time.Time            `db:"created_at"`
+	}
+	totpDevice struct {
+		ID          uuid.UUID  `json:"id"`
+		DisplayName string     `json:"display_name"`
+		TOTPURL     string     `json:"totp_url"`
+		AddedAt     time.Time  `json:"added_at"`
+		LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	}
+	totpConfig struct {
+		TOTPURL string       `json:"totp_url,omitempty"`
+		Devices []totpDevice `json:"devices,omitempty"`
+	}
+)
+
+// rewriteTOTPConfigs applies the rewrite function to the configuration of all
+// TOTP credentials. Credentials for which the function returns nil are left
+// unchanged.
+func rewriteTOTPConfigs(c *pop.Connection, rewrite func(row totpCredentialsRow, conf totpConfig) *totpConfig) error {
+	var after uuid.UUID
+	for {
+		var rows []totpCredentialsRow
+		if err := c.RawQuery(`
+			SELECT ic.id, ic.config, ic.created_at
+			FROM identity_credentials ic
+			JOIN identity_credential_types ict ON ic.identity_credential_type_id = ict.id
+			WHERE ict.name = 'totp' AND ic.id > ?
+			ORDER BY ic.id
+			LIMIT 1000`, after).All(&rows); err != nil {
+			return errors.WithStack(err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		var n int
+		for _, row := range rows {
+			var conf totpConfig
+			if len(row.Config) > 0 {
+				if err := json.Unmarshal(row.Config, &conf); err != nil {
+					return errors.Wrapf(err, "unable to decode TOTP credentials %s", row.ID)
+				}
+			}
+
+			updated := rewrite(row, conf)
+			if updated == nil {
+				continue
+			}
+
+			encoded, err := json.Marshal(updated)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := c.RawQuery("UPDATE identity_credentials SET config = ? WHERE id = ?", sqlxx.JSONRawMessage(encoded), row.ID).Exec(); err != nil {
+				return errors.WithStack(err)
+			}
+			n++
+		}
+		if n > 0 {
+			fmt.Printf("Migrated the configuration of %d TOTP credentials\n", n)
+		}
+
+		after = rows[len(rows)-1].ID
+	}
+}
+
+var totpDevices = []popx.Migration{
+	{
+		Version:    "20261018000003000000",
+		Path:       path(),
+		Name:       "Migrate TOTP credentials to the multi-device format",
+		Direction:  "up",
+		Type:       "go",
+		DBType:     "all",
+		Autocommit: true,
+		Runner: func(m popx.Migration, c *pop.Connection) error {
+			return rewriteTOTPConfigs(c, func(row totpCredentialsRow, conf totpConfig) *totpConfig {
+				if conf.TOTPURL == "" {
+					return nil
+				}
+				// The device uses the credentials' ID and creation time, which is
+				// also what identity.NewCredentialsTOTPConfig assumes for
+				// credentials in the legacy format.
+				return &totpConfig{Devices: append([]totpDevice{{
+					ID:      row.ID,
+					TOTPURL: conf.TOTPURL,
+					AddedAt: row.CreatedAt.UTC(),
+				}}, conf.Devices...)}
+			})
+		},
+	},
+	{
+		Version:    "20261018000003000000",
+		Path:       path(),
+		Name:       "Revert TOTP credentials to the single-URL format",
+		Direction:  "down",
+		Type:       "go",
+		DBType:     "all",
+		Autocommit: true,
+		Runner: func(m popx.Migration, c *pop.Connection) error {
+			// The single-URL format can only hold one device, so only the
+			// first device is kept.
+			return rewriteTOTPConfigs(c, func(row totpCredentialsRow, conf totpConfig) *totpConfig {
+				if len(conf.Devices) == 0 {
+					return nil
+				}
+				return &totpConfig{TOTPURL: conf.Devices[0].TOTPURL}
+			})
+		},
+	},
+}
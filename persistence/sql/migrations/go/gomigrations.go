@@ -14,6 +14,7 @@ import (
 var (
 	All = slices.Concat(
 		backfillIdentityID,
+		totpDevices,
 	)
 
 	//go:embed *.go
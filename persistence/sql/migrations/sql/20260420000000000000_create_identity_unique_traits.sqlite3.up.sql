@@ -0,0 +1,16 @@
+CREATE TABLE identity_unique_traits (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "identity_id" char(36) NOT NULL,
+    "nid" char(36) NOT NULL,
+    "trait_path" VARCHAR(255) NOT NULL,
+    "scope" VARCHAR(64) NOT NULL,
+    "value_hash" VARCHAR(64) NOT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_unique_traits_identities_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+    CONSTRAINT identity_unique_traits_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX identity_unique_traits_nid_trait_path_scope_value_hash_uq_idx ON identity_unique_traits (nid, trait_path, scope, value_hash);
+CREATE INDEX identity_unique_traits_nid_value_hash_idx ON identity_unique_traits (nid, value_hash);
+CREATE INDEX identity_unique_traits_nid_identity_id_idx ON identity_unique_traits (nid, identity_id);
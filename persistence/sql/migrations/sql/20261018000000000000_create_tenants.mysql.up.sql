@@ -0,0 +1,22 @@
+CREATE TABLE tenants (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(63) NOT NULL,
+    config JSON NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT tenants_networks_id_fk FOREIGN KEY (id) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX tenants_name_uq_idx ON tenants (name);
+
+CREATE TABLE tenant_hosts (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    tenant_id CHAR(36) NOT NULL,
+    host VARCHAR(255) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT tenant_hosts_tenants_id_fk FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX tenant_hosts_host_uq_idx ON tenant_hosts (host);
+CREATE INDEX tenant_hosts_tenant_id_idx ON tenant_hosts (tenant_id);
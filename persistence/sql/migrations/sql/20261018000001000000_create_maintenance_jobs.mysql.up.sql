@@ -0,0 +1,11 @@
+CREATE TABLE maintenance_jobs (
+    name VARCHAR(64) NOT NULL PRIMARY KEY,
+    locked_by VARCHAR(255) NULL,
+    locked_until timestamp NULL,
+    last_started_at timestamp NULL,
+    last_finished_at timestamp NULL,
+    last_status VARCHAR(16) NULL,
+    last_error TEXT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB;
@@ -0,0 +1,11 @@
+CREATE TABLE maintenance_jobs (
+    "name" VARCHAR(64) NOT NULL PRIMARY KEY,
+    "locked_by" VARCHAR(255) NULL,
+    "locked_until" DATETIME NULL,
+    "last_started_at" DATETIME NULL,
+    "last_finished_at" DATETIME NULL,
+    "last_status" VARCHAR(16) NULL,
+    "last_error" TEXT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE identities DROP COLUMN IF EXISTS last_authenticated_at;
@@ -0,0 +1 @@
+ALTER TABLE identities ADD COLUMN last_authenticated_at DATETIME NULL;
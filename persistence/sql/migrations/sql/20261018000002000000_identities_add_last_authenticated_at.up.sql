@@ -0,0 +1 @@
+ALTER TABLE identities ADD COLUMN IF NOT EXISTS last_authenticated_at timestamp NULL;
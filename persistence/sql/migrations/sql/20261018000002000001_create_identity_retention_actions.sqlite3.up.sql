@@ -0,0 +1,13 @@
+CREATE TABLE identity_retention_actions (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "identity_id" char(36) NOT NULL,
+    "policy_id" VARCHAR(64) NOT NULL,
+    "action" VARCHAR(16) NOT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_retention_actions_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_retention_actions_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_retention_actions_nid_identity_id_idx ON identity_retention_actions (nid, identity_id, policy_id);
@@ -0,0 +1,17 @@
+CREATE TABLE identity_trusted_devices (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    "token_hmac" VARCHAR(128) NOT NULL,
+    "ip_address" VARCHAR(50) NULL,
+    "user_agent" VARCHAR(512) NULL,
+    "location" VARCHAR(512) NULL,
+    "expires_at" timestamp NOT NULL,
+    "last_used_at" timestamp NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT identity_trusted_devices_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_trusted_devices_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_trusted_devices_nid_identity_id_idx ON identity_trusted_devices (nid, identity_id, expires_at);
@@ -0,0 +1,19 @@
+CREATE TABLE identity_login_attempts (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    identity_id CHAR(36) NULL,
+    succeeded bool NOT NULL DEFAULT FALSE,
+    ip_address VARCHAR(50) NOT NULL,
+    user_agent VARCHAR(512) NULL,
+    country VARCHAR(8) NULL,
+    latitude DOUBLE NULL,
+    longitude DOUBLE NULL,
+    risk_score INT NOT NULL DEFAULT 0,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT identity_login_attempts_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_login_attempts_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX identity_login_attempts_nid_identity_id_idx ON identity_login_attempts (nid, identity_id, created_at);
+CREATE INDEX identity_login_attempts_nid_ip_address_idx ON identity_login_attempts (nid, ip_address, created_at);
@@ -0,0 +1,19 @@
+CREATE TABLE identity_login_attempts (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "identity_id" char(36) NULL,
+    "succeeded" bool NOT NULL DEFAULT FALSE,
+    "ip_address" VARCHAR(50) NOT NULL,
+    "user_agent" VARCHAR(512) NULL,
+    "country" VARCHAR(8) NULL,
+    "latitude" REAL NULL,
+    "longitude" REAL NULL,
+    "risk_score" INTEGER NOT NULL DEFAULT 0,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_login_attempts_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_login_attempts_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_login_attempts_nid_identity_id_idx ON identity_login_attempts (nid, identity_id, created_at);
+CREATE INDEX identity_login_attempts_nid_ip_address_idx ON identity_login_attempts (nid, ip_address, created_at);
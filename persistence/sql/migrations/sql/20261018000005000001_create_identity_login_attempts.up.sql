@@ -0,0 +1,19 @@
+CREATE TABLE identity_login_attempts (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "identity_id" UUID NULL,
+    "succeeded" bool NOT NULL DEFAULT FALSE,
+    "ip_address" VARCHAR(50) NOT NULL,
+    "user_agent" VARCHAR(512) NULL,
+    "country" VARCHAR(8) NULL,
+    "latitude" double precision NULL,
+    "longitude" double precision NULL,
+    "risk_score" INT NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT identity_login_attempts_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_login_attempts_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_login_attempts_nid_identity_id_idx ON identity_login_attempts (nid, identity_id, created_at);
+CREATE INDEX identity_login_attempts_nid_ip_address_idx ON identity_login_attempts (nid, ip_address, created_at);
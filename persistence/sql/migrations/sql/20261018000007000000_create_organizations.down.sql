@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS organization_oidc_providers;
+DROP TABLE IF EXISTS organization_domains;
+DROP TABLE IF EXISTS organizations;
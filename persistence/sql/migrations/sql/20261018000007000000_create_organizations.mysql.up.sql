@@ -0,0 +1,39 @@
+CREATE TABLE organizations (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT organizations_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX organizations_nid_idx ON organizations (nid, id);
+
+CREATE TABLE organization_domains (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    organization_id CHAR(36) NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT organization_domains_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT organization_domains_organizations_id_fk FOREIGN KEY (organization_id) REFERENCES organizations (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX organization_domains_nid_domain_uq_idx ON organization_domains (nid, domain);
+CREATE INDEX organization_domains_organization_id_idx ON organization_domains (organization_id);
+
+CREATE TABLE organization_oidc_providers (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    organization_id CHAR(36) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    config JSON NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT organization_oidc_providers_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT organization_oidc_providers_organizations_id_fk FOREIGN KEY (organization_id) REFERENCES organizations (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX organization_oidc_providers_nid_provider_id_uq_idx ON organization_oidc_providers (nid, provider_id);
+CREATE INDEX organization_oidc_providers_organization_id_idx ON organization_oidc_providers (organization_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS selfservice_device_flows;
+DROP TABLE IF EXISTS selfservice_device_authorizations;
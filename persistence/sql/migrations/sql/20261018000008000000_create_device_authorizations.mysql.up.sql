@@ -0,0 +1,37 @@
+CREATE TABLE selfservice_device_authorizations (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    device_code_hmac VARCHAR(128) NOT NULL,
+    user_code_hmac VARCHAR(128) NOT NULL,
+    state VARCHAR(16) NOT NULL,
+    identity_id CHAR(36) NULL,
+    session_id CHAR(36) NULL,
+    expires_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_polled_at timestamp NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_device_authorizations_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_authorizations_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX selfservice_device_authorizations_device_code_hmac_uq_idx ON selfservice_device_authorizations (nid, device_code_hmac);
+CREATE UNIQUE INDEX selfservice_device_authorizations_user_code_hmac_uq_idx ON selfservice_device_authorizations (nid, user_code_hmac);
+CREATE INDEX selfservice_device_authorizations_nid_expires_at_idx ON selfservice_device_authorizations (nid, expires_at);
+
+CREATE TABLE selfservice_device_flows (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    identity_id CHAR(36) NOT NULL,
+    request_url TEXT NOT NULL,
+    ui JSON NULL,
+    state VARCHAR(255) NOT NULL,
+    csrf_token VARCHAR(255) NOT NULL,
+    expires_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    issued_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_device_flows_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_flows_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX selfservice_device_flows_nid_expires_at_idx ON selfservice_device_flows (nid, expires_at);
@@ -0,0 +1,37 @@
+CREATE TABLE selfservice_device_authorizations (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "device_code_hmac" VARCHAR(128) NOT NULL,
+    "user_code_hmac" VARCHAR(128) NOT NULL,
+    "state" VARCHAR(16) NOT NULL,
+    "identity_id" char(36) NULL,
+    "session_id" char(36) NULL,
+    "expires_at" DATETIME NOT NULL,
+    "last_polled_at" DATETIME NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT selfservice_device_authorizations_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_authorizations_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX selfservice_device_authorizations_device_code_hmac_uq_idx ON selfservice_device_authorizations (nid, device_code_hmac);
+CREATE UNIQUE INDEX selfservice_device_authorizations_user_code_hmac_uq_idx ON selfservice_device_authorizations (nid, user_code_hmac);
+CREATE INDEX selfservice_device_authorizations_nid_expires_at_idx ON selfservice_device_authorizations (nid, expires_at);
+
+CREATE TABLE selfservice_device_flows (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "identity_id" char(36) NOT NULL,
+    "request_url" TEXT NOT NULL,
+    "ui" TEXT NULL,
+    "state" VARCHAR(255) NOT NULL,
+    "csrf_token" VARCHAR(255) NOT NULL,
+    "expires_at" DATETIME NOT NULL,
+    "issued_at" DATETIME NOT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT selfservice_device_flows_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_flows_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX selfservice_device_flows_nid_expires_at_idx ON selfservice_device_flows (nid, expires_at);
@@ -0,0 +1,37 @@
+CREATE TABLE selfservice_device_authorizations (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "device_code_hmac" VARCHAR(128) NOT NULL,
+    "user_code_hmac" VARCHAR(128) NOT NULL,
+    "state" VARCHAR(16) NOT NULL,
+    "identity_id" UUID NULL,
+    "session_id" UUID NULL,
+    "expires_at" timestamp NOT NULL,
+    "last_polled_at" timestamp NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT selfservice_device_authorizations_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_authorizations_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX selfservice_device_authorizations_device_code_hmac_uq_idx ON selfservice_device_authorizations (nid, device_code_hmac);
+CREATE UNIQUE INDEX selfservice_device_authorizations_user_code_hmac_uq_idx ON selfservice_device_authorizations (nid, user_code_hmac);
+CREATE INDEX selfservice_device_authorizations_nid_expires_at_idx ON selfservice_device_authorizations (nid, expires_at);
+
+CREATE TABLE selfservice_device_flows (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    "request_url" TEXT NOT NULL,
+    "ui" jsonb NULL,
+    "state" VARCHAR(255) NOT NULL,
+    "csrf_token" VARCHAR(255) NOT NULL,
+    "expires_at" timestamp NOT NULL,
+    "issued_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT selfservice_device_flows_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_device_flows_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX selfservice_device_flows_nid_expires_at_idx ON selfservice_device_flows (nid, expires_at);
@@ -0,0 +1,17 @@
+CREATE TABLE identity_invitations (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "identity_id" char(36) NOT NULL,
+    "address" VARCHAR(400) NOT NULL,
+    "state" VARCHAR(16) NOT NULL,
+    "token_hmac" VARCHAR(128) NOT NULL,
+    "expires_at" DATETIME NOT NULL,
+    "accepted_at" DATETIME NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_invitations_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_invitations_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX identity_invitations_token_hmac_uq_idx ON identity_invitations (nid, token_hmac);
+CREATE INDEX identity_invitations_identity_id_idx ON identity_invitations (identity_id, nid);
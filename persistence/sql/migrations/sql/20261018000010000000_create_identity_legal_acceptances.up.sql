@@ -0,0 +1,17 @@
+CREATE TABLE identity_legal_acceptances (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    "document_id" VARCHAR(128) NOT NULL,
+    "version" VARCHAR(128) NOT NULL,
+    "ip_address" VARCHAR(64) NOT NULL DEFAULT '',
+    "user_agent" VARCHAR(512) NOT NULL DEFAULT '',
+    "accepted_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT identity_legal_acceptances_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_legal_acceptances_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_legal_acceptances_identity_id_idx ON identity_legal_acceptances (nid, identity_id, document_id);
+CREATE INDEX identity_legal_acceptances_document_id_idx ON identity_legal_acceptances (nid, document_id, version);
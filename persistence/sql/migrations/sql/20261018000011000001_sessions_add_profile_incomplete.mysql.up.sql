@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN profile_incomplete BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS profile_incomplete BOOLEAN NOT NULL DEFAULT FALSE;
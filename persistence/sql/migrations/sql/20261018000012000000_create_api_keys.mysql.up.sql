@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    scopes JSON NOT NULL,
+    organization_id CHAR(36) NULL,
+    secret_hash VARCHAR(255) NOT NULL,
+    expires_at timestamp NULL,
+    last_used_at timestamp NULL,
+    revoked_at timestamp NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT api_keys_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX api_keys_nid_idx ON api_keys (nid, id);
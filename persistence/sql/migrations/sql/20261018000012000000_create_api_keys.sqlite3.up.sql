@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "scopes" TEXT NOT NULL,
+    "organization_id" char(36) NULL,
+    "secret_hash" VARCHAR(255) NOT NULL,
+    "expires_at" DATETIME NULL,
+    "last_used_at" DATETIME NULL,
+    "revoked_at" DATETIME NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT api_keys_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX api_keys_nid_idx ON api_keys (nid, id);
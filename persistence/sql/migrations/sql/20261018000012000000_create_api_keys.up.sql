@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "scopes" jsonb NOT NULL,
+    "organization_id" UUID NULL,
+    "secret_hash" VARCHAR(255) NOT NULL,
+    "expires_at" timestamp NULL,
+    "last_used_at" timestamp NULL,
+    "revoked_at" timestamp NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT api_keys_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX api_keys_nid_idx ON api_keys (nid, id);
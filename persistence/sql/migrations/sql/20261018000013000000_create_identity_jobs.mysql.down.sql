@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS identity_job_failures;
+DROP TABLE IF EXISTS identity_job_items;
+DROP TABLE IF EXISTS identity_jobs;
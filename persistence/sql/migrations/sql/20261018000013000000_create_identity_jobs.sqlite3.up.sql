@@ -0,0 +1,47 @@
+CREATE TABLE identity_jobs (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "action" VARCHAR(32) NOT NULL,
+    "selector" TEXT NOT NULL,
+    "identity_id_list" bool NOT NULL DEFAULT FALSE,
+    "metadata_public" TEXT NULL,
+    "metadata_admin" TEXT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "total" INTEGER NOT NULL DEFAULT 0,
+    "processed" INTEGER NOT NULL DEFAULT 0,
+    "failed" INTEGER NOT NULL DEFAULT 0,
+    "last_identity_id" char(36) NULL,
+    "started_at" DATETIME NULL,
+    "finished_at" DATETIME NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_jobs_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_jobs_nid_idx ON identity_jobs (nid, id);
+CREATE INDEX identity_jobs_nid_status_idx ON identity_jobs (nid, status, created_at);
+
+CREATE TABLE identity_job_items (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "job_id" char(36) NOT NULL,
+    "identity_id" char(36) NOT NULL,
+    CONSTRAINT identity_job_items_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_job_items_job_id_fk FOREIGN KEY (job_id) REFERENCES identity_jobs (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX identity_job_items_job_id_identity_id_uq_idx ON identity_job_items (nid, job_id, identity_id);
+
+CREATE TABLE identity_job_failures (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "job_id" char(36) NOT NULL,
+    "identity_id" char(36) NOT NULL,
+    "error" TEXT NOT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT identity_job_failures_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_job_failures_job_id_fk FOREIGN KEY (job_id) REFERENCES identity_jobs (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_job_failures_job_id_idx ON identity_job_failures (nid, job_id, id);
@@ -0,0 +1,47 @@
+CREATE TABLE identity_jobs (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "action" VARCHAR(32) NOT NULL,
+    "selector" jsonb NOT NULL,
+    "identity_id_list" bool NOT NULL DEFAULT FALSE,
+    "metadata_public" jsonb NULL,
+    "metadata_admin" jsonb NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "total" INT NOT NULL DEFAULT 0,
+    "processed" INT NOT NULL DEFAULT 0,
+    "failed" INT NOT NULL DEFAULT 0,
+    "last_identity_id" UUID NULL,
+    "started_at" timestamp NULL,
+    "finished_at" timestamp NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT identity_jobs_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_jobs_nid_idx ON identity_jobs (nid, id);
+CREATE INDEX identity_jobs_nid_status_idx ON identity_jobs (nid, status, created_at);
+
+CREATE TABLE identity_job_items (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "job_id" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    CONSTRAINT identity_job_items_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_job_items_job_id_fk FOREIGN KEY (job_id) REFERENCES identity_jobs (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX identity_job_items_job_id_identity_id_uq_idx ON identity_job_items (nid, job_id, identity_id);
+
+CREATE TABLE identity_job_failures (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "job_id" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    "error" TEXT NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT identity_job_failures_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT identity_job_failures_job_id_fk FOREIGN KEY (job_id) REFERENCES identity_jobs (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX identity_job_failures_job_id_idx ON identity_job_failures (nid, job_id, id);
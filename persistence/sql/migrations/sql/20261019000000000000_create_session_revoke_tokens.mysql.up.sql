@@ -0,0 +1,12 @@
+CREATE TABLE session_revoke_tokens (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT session_revoke_tokens_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX session_revoke_tokens_nid_token_hash_uq_idx ON session_revoke_tokens (nid, token_hash);
+CREATE INDEX session_revoke_tokens_nid_expires_at_idx ON session_revoke_tokens (nid, expires_at);
@@ -0,0 +1,12 @@
+CREATE TABLE session_revoke_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "token_hash" VARCHAR(64) NOT NULL,
+    "expires_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT session_revoke_tokens_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX session_revoke_tokens_nid_token_hash_uq_idx ON session_revoke_tokens (nid, token_hash);
+CREATE INDEX session_revoke_tokens_nid_expires_at_idx ON session_revoke_tokens (nid, expires_at);
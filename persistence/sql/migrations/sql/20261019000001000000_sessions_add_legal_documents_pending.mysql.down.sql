@@ -0,0 +1 @@
+ALTER TABLE sessions DROP COLUMN legal_documents_pending;
@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN legal_documents_pending BOOLEAN NOT NULL DEFAULT FALSE;
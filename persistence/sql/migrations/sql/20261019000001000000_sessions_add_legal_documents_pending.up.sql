@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS legal_documents_pending BOOLEAN NOT NULL DEFAULT FALSE;
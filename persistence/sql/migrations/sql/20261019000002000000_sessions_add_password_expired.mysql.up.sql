@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN password_expired BOOLEAN NOT NULL DEFAULT FALSE;
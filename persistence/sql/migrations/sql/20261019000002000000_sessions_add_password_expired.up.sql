@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS password_expired BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE identity_jobs DROP COLUMN error;
@@ -0,0 +1 @@
+ALTER TABLE identity_jobs ADD COLUMN error TEXT NULL;
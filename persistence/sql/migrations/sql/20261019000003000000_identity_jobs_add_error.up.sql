@@ -0,0 +1 @@
+ALTER TABLE identity_jobs ADD COLUMN IF NOT EXISTS error TEXT NULL;
@@ -0,0 +1,2 @@
+-- Unique trait values were hashed without a secret. Run "kratos migrate unique-traits" to index them again.
+DELETE FROM identity_unique_traits;
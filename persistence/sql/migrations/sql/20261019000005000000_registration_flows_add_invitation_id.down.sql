@@ -0,0 +1 @@
+ALTER TABLE selfservice_registration_flows DROP COLUMN IF EXISTS invitation_id;
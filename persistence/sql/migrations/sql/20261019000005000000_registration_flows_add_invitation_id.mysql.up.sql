@@ -0,0 +1 @@
+ALTER TABLE selfservice_registration_flows ADD COLUMN invitation_id CHAR(36) NULL;
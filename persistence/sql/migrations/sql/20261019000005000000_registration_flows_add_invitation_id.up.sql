@@ -0,0 +1 @@
+ALTER TABLE selfservice_registration_flows ADD COLUMN IF NOT EXISTS invitation_id UUID NULL;
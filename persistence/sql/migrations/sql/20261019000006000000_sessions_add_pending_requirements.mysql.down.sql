@@ -0,0 +1 @@
+ALTER TABLE sessions DROP COLUMN pending_requirements;
@@ -0,0 +1 @@
+ALTER TABLE sessions ADD COLUMN pending_requirements TEXT NULL;
@@ -0,0 +1,5 @@
+UPDATE sessions SET
+  profile_incomplete = pending_requirements @> '["profile_incomplete"]'::jsonb,
+  legal_documents_pending = pending_requirements @> '["legal_documents_pending"]'::jsonb,
+  password_expired = pending_requirements @> '["password_expired"]'::jsonb
+WHERE pending_requirements IS NOT NULL;
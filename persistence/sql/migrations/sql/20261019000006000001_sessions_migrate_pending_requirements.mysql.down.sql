@@ -0,0 +1,5 @@
+UPDATE sessions SET
+  profile_incomplete = JSON_CONTAINS(pending_requirements, '"profile_incomplete"'),
+  legal_documents_pending = JSON_CONTAINS(pending_requirements, '"legal_documents_pending"'),
+  password_expired = JSON_CONTAINS(pending_requirements, '"password_expired"')
+WHERE pending_requirements IS NOT NULL;
@@ -0,0 +1,6 @@
+UPDATE sessions SET pending_requirements = JSON_MERGE_PRESERVE(
+  IF(profile_incomplete, '["profile_incomplete"]', '[]'),
+  IF(legal_documents_pending, '["legal_documents_pending"]', '[]'),
+  IF(password_expired, '["password_expired"]', '[]')
+)
+WHERE profile_incomplete OR legal_documents_pending OR password_expired;
@@ -0,0 +1,5 @@
+UPDATE sessions SET
+  profile_incomplete = pending_requirements LIKE '%"profile_incomplete"%',
+  legal_documents_pending = pending_requirements LIKE '%"legal_documents_pending"%',
+  password_expired = pending_requirements LIKE '%"password_expired"%'
+WHERE pending_requirements IS NOT NULL;
@@ -0,0 +1,5 @@
+UPDATE sessions SET pending_requirements = '[' || rtrim(
+  (CASE WHEN profile_incomplete THEN '"profile_incomplete",' ELSE '' END) ||
+  (CASE WHEN legal_documents_pending THEN '"legal_documents_pending",' ELSE '' END) ||
+  (CASE WHEN password_expired THEN '"password_expired",' ELSE '' END), ',') || ']'
+WHERE profile_incomplete OR legal_documents_pending OR password_expired;
@@ -0,0 +1,5 @@
+UPDATE sessions SET pending_requirements =
+  (CASE WHEN profile_incomplete THEN '["profile_incomplete"]'::jsonb ELSE '[]'::jsonb END) ||
+  (CASE WHEN legal_documents_pending THEN '["legal_documents_pending"]'::jsonb ELSE '[]'::jsonb END) ||
+  (CASE WHEN password_expired THEN '["password_expired"]'::jsonb ELSE '[]'::jsonb END)
+WHERE profile_incomplete OR legal_documents_pending OR password_expired;
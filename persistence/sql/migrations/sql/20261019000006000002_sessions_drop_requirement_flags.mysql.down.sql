@@ -0,0 +1,3 @@
+ALTER TABLE sessions ADD COLUMN profile_incomplete BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE sessions ADD COLUMN legal_documents_pending BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE sessions ADD COLUMN password_expired BOOLEAN NOT NULL DEFAULT FALSE;
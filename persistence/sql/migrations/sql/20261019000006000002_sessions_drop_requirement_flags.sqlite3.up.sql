@@ -0,0 +1,3 @@
+ALTER TABLE sessions DROP COLUMN profile_incomplete;
+ALTER TABLE sessions DROP COLUMN legal_documents_pending;
+ALTER TABLE sessions DROP COLUMN password_expired;
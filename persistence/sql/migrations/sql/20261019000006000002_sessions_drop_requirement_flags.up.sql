@@ -0,0 +1,3 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS profile_incomplete;
+ALTER TABLE sessions DROP COLUMN IF EXISTS legal_documents_pending;
+ALTER TABLE sessions DROP COLUMN IF EXISTS password_expired;
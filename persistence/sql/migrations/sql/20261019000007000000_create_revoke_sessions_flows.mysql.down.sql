@@ -0,0 +1 @@
+DROP TABLE IF EXISTS selfservice_revoke_sessions_flows;
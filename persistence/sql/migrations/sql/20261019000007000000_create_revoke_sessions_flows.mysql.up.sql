@@ -0,0 +1,18 @@
+CREATE TABLE selfservice_revoke_sessions_flows (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    identity_id CHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    request_url TEXT NOT NULL,
+    ui JSON NULL,
+    state VARCHAR(255) NOT NULL,
+    csrf_token VARCHAR(255) NOT NULL,
+    expires_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    issued_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_revoke_sessions_flows_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_revoke_sessions_flows_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX selfservice_revoke_sessions_flows_nid_expires_at_idx ON selfservice_revoke_sessions_flows (nid, expires_at);
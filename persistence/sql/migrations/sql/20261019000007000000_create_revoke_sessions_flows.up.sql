@@ -0,0 +1,18 @@
+CREATE TABLE selfservice_revoke_sessions_flows (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "identity_id" UUID NOT NULL,
+    "token_hash" VARCHAR(64) NOT NULL,
+    "request_url" TEXT NOT NULL,
+    "ui" jsonb NULL,
+    "state" VARCHAR(255) NOT NULL,
+    "csrf_token" VARCHAR(255) NOT NULL,
+    "expires_at" timestamp NOT NULL,
+    "issued_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT selfservice_revoke_sessions_flows_nid_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    CONSTRAINT selfservice_revoke_sessions_flows_identity_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX selfservice_revoke_sessions_flows_nid_expires_at_idx ON selfservice_revoke_sessions_flows (nid, expires_at);
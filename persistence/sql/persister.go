@@ -44,6 +44,7 @@ type (
 		otelx.Provider
 		schema.IdentitySchemaProvider
 		identity.ValidationProvider
+		identity.TraitsClassifierProvider
 	}
 	Persister struct {
 		nid uuid.UUID
@@ -177,6 +178,19 @@ func (p *Persister) Ping(ctx context.Context) error {
 	return errors.WithStack(p.c.Store.SQLDB().PingContext(ctx))
 }
 
+// sleepContext waits for the given duration unless the context is canceled
+// first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
+
 func (p *Persister) CleanupDatabase(ctx context.Context, wait time.Duration, older time.Duration, batchSize int) error {
 	currentTime := time.Now().Add(-older)
 	p.r.Logger().Printf("Cleaning up records older than %s\n", currentTime)
@@ -185,49 +199,105 @@ func (p *Persister) CleanupDatabase(ctx context.Context, wait time.Duration, old
 	if err := p.DeleteExpiredSessions(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+
+	p.r.Logger().Println("Cleaning up expired revoke sessions flows")
+	if err := p.DeleteExpiredRevokeSessionsFlows(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+
+	p.r.Logger().Println("Cleaning up used revoke sessions tokens")
+	if err := p.DeleteExpiredRevokeSessionsTokens(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired continuity containers")
 	if err := p.DeleteExpiredContinuitySessions(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired login flows")
 	if err := p.DeleteExpiredLoginFlows(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired recovery flows")
 	if err := p.DeleteExpiredRecoveryFlows(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired registration flows")
 	if err := p.DeleteExpiredRegistrationFlows(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired settings flows")
 	if err := p.DeleteExpiredSettingsFlows(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired verification flows")
 	if err := p.DeleteExpiredVerificationFlows(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+
+	p.r.Logger().Println("Cleaning up expired device flows")
+	if err := p.DeleteExpiredDeviceFlows(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+
+	p.r.Logger().Println("Cleaning up expired device authorizations")
+	if err := p.DeleteExpiredDeviceAuthorizations(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Cleaning up expired session token exchangers")
 	if err := p.DeleteExpiredExchangers(ctx, currentTime, batchSize); err != nil {
 		return err
 	}
-	time.Sleep(wait)
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+
+	p.r.Logger().Println("Cleaning up old login attempts")
+	if err := p.DeleteLoginAttemptsBefore(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
 
 	p.r.Logger().Println("Successfully cleaned up the latest batch of the SQL database! " +
 		"This should be re-run periodically, to be sure that all expired data is purged.")
@@ -0,0 +1,95 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/apikey"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ apikey.Persister = new(Persister)
+
+func (p *Persister) CreateAPIKey(ctx context.Context, k *apikey.Key) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateAPIKey")
+	defer otelx.End(span, &err)
+
+	k.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(k))
+}
+
+func (p *Persister) GetAPIKey(ctx context.Context, id uuid.UUID) (_ *apikey.Key, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetAPIKey")
+	defer otelx.End(span, &err)
+
+	var k apikey.Key
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&k); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &k, nil
+}
+
+func (p *Persister) ListAPIKeys(ctx context.Context, opts []keysetpagination.Option) (_ []apikey.Key, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListAPIKeys")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(apikey.Key{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]apikey.Key, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Scope(keysetpagination.Paginate[apikey.Key](paginator)).
+		All(&keys); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	keys, nextPage := keysetpagination.Result(keys, paginator)
+	return keys, nextPage, nil
+}
+
+func (p *Persister) RevokeAPIKey(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeAPIKey")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET revoked_at = ?, updated_at = ? WHERE id = ? AND nid = ? AND revoked_at IS NULL",
+		apikey.Key{}.TableName(),
+	),
+		now, now, id, p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) UpdateAPIKeyLastUsedAt(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateAPIKeyLastUsedAt")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET last_used_at = ? WHERE id = ? AND nid = ?",
+		apikey.Key{}.TableName(),
+	),
+		at, id, p.NetworkID(ctx),
+	).Exec())
+}
@@ -0,0 +1,305 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/bulk"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence/sql/batch"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var _ bulk.Persister = new(Persister)
+
+// identityJobItemsPerInsert bounds the number of rows, and thus placeholders,
+// of a single INSERT statement.
+const identityJobItemsPerInsert = 1000
+
+func (p *Persister) CreateIdentityJob(ctx context.Context, j *bulk.Job, identityIDs []uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateIdentityJob")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	j.NID = nid
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		if err := tx.Create(j); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		items := make([]*bulk.Item, len(identityIDs))
+		for k, id := range identityIDs {
+			items[k] = &bulk.Item{NID: nid, JobID: j.ID, IdentityID: id}
+		}
+		conn := &batch.TracerConnection{Tracer: p.r.Tracer(ctx), Connection: tx}
+		for start := 0; start < len(items); start += identityJobItemsPerInsert {
+			end := min(start+identityJobItemsPerInsert, len(items))
+			if err := batch.Create(ctx, conn, items[start:end]); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *Persister) GetIdentityJob(ctx context.Context, id uuid.UUID) (_ *bulk.Job, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetIdentityJob")
+	defer otelx.End(span, &err)
+
+	var j bulk.Job
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&j); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &j, nil
+}
+
+func (p *Persister) ListIdentityJobs(ctx context.Context, opts []keysetpagination.Option) (_ []bulk.Job, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListIdentityJobs")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(bulk.Job{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobs := make([]bulk.Job, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Scope(keysetpagination.Paginate[bulk.Job](paginator)).
+		All(&jobs); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	jobs, nextPage := keysetpagination.Result(jobs, paginator)
+	return jobs, nextPage, nil
+}
+
+func (p *Persister) ListIdentityJobFailures(ctx context.Context, jobID uuid.UUID, opts []keysetpagination.Option) (_ []bulk.Failure, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListIdentityJobFailures")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(bulk.Failure{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	failures := make([]bulk.Failure, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND job_id = ?", p.NetworkID(ctx), jobID).
+		Scope(keysetpagination.Paginate[bulk.Failure](paginator)).
+		All(&failures); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	failures, nextPage := keysetpagination.Result(failures, paginator)
+	return failures, nextPage, nil
+}
+
+func (p *Persister) ListUnfinishedIdentityJobs(ctx context.Context) (_ []bulk.Job, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListUnfinishedIdentityJobs")
+	defer otelx.End(span, &err)
+
+	var jobs []bulk.Job
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND status IN (?, ?)", p.NetworkID(ctx), bulk.StatusPending, bulk.StatusRunning).
+		Order("created_at ASC, id ASC").
+		All(&jobs); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return jobs, nil
+}
+
+// identityJobTargets selects the identities after the job's cursor which
+// match the job's selector.
+func (p *Persister) identityJobTargets(ctx context.Context, j *bulk.Job) *pop.Query {
+	query := p.GetConnection(ctx).Where("identities.nid = ?", p.NetworkID(ctx))
+	if j.Cursor.Valid {
+		query = query.Where("identities.id > ?", j.Cursor.UUID)
+	}
+	if j.Selector.OrganizationID.Valid {
+		query = query.Where("identities.organization_id = ?", j.Selector.OrganizationID.UUID)
+	}
+	if j.Selector.SchemaID != "" {
+		query = query.Where("identities.schema_id = ?", j.Selector.SchemaID)
+	}
+	if j.Selector.CredentialsIdentifier != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM identity_credentials ic INNER JOIN identity_credential_identifiers ici ON ici.identity_credential_id = ic.id AND ici.nid = ic.nid WHERE ic.identity_id = identities.id AND ic.nid = identities.nid AND ici.identifier LIKE ?)",
+			j.Selector.CredentialsIdentifierLikePattern(),
+		)
+	}
+	return query
+}
+
+func (p *Persister) CountIdentityJobTargets(ctx context.Context, j *bulk.Job) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountIdentityJobTargets")
+	defer otelx.End(span, &err)
+
+	if j.IdentityIDList {
+		count, err := p.GetConnection(ctx).Where("nid = ? AND job_id = ?", p.NetworkID(ctx), j.ID).Count(new(bulk.Item))
+		if err != nil {
+			return 0, sqlcon.HandleError(err)
+		}
+		return count, nil
+	}
+
+	count, err := p.identityJobTargets(ctx, j).Count(new(identity.Identity))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
+
+func (p *Persister) FindIdentityJobTargets(ctx context.Context, j *bulk.Job, limit int) (_ []uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindIdentityJobTargets")
+	defer otelx.End(span, &err)
+
+	if j.IdentityIDList {
+		query := p.GetConnection(ctx).Where("nid = ? AND job_id = ?", p.NetworkID(ctx), j.ID)
+		if j.Cursor.Valid {
+			query = query.Where("identity_id > ?", j.Cursor.UUID)
+		}
+
+		var items []bulk.Item
+		if err := query.Order("identity_id ASC").Limit(limit).All(&items); err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+
+		ids := make([]uuid.UUID, len(items))
+		for k := range items {
+			ids[k] = items[k].IdentityID
+		}
+		return ids, nil
+	}
+
+	var is []identity.Identity
+	if err := p.identityJobTargets(ctx, j).
+		Select("identities.id").
+		Order("identities.id ASC").
+		Limit(limit).
+		All(&is); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	ids := make([]uuid.UUID, len(is))
+	for k := range is {
+		ids[k] = is[k].ID
+	}
+	return ids, nil
+}
+
+func (p *Persister) StartIdentityJob(ctx context.Context, id uuid.UUID, total int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.StartIdentityJob")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET status = ?, total = ?, started_at = ?, updated_at = ? WHERE id = ? AND nid = ? AND status = ?",
+		bulk.Job{}.TableName(),
+	),
+		bulk.StatusRunning, total, now, now, id, p.NetworkID(ctx), bulk.StatusPending,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) RecordIdentityJobBatch(ctx context.Context, id, cursor uuid.UUID, processed int, failures []bulk.Failure) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RecordIdentityJobBatch")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		//#nosec G201 -- TableName is static
+		count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET last_identity_id = ?, processed = processed + ?, failed = failed + ?, updated_at = ? WHERE id = ? AND nid = ? AND status = ?",
+			bulk.Job{}.TableName(),
+		),
+			cursor, processed, len(failures), time.Now().UTC(), id, nid, bulk.StatusRunning,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		} else if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		insert := make([]*bulk.Failure, len(failures))
+		for k := range failures {
+			failures[k].NID = nid
+			failures[k].JobID = id
+			insert[k] = &failures[k]
+		}
+		return batch.Create(ctx, &batch.TracerConnection{Tracer: p.r.Tracer(ctx), Connection: tx}, insert)
+	})
+}
+
+func (p *Persister) FinishIdentityJob(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FinishIdentityJob")
+	defer otelx.End(span, &err)
+
+	return p.finishIdentityJob(ctx, id, bulk.StatusCompleted, "", bulk.StatusRunning)
+}
+
+func (p *Persister) CancelIdentityJob(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CancelIdentityJob")
+	defer otelx.End(span, &err)
+
+	return p.finishIdentityJob(ctx, id, bulk.StatusCanceled, "", bulk.StatusPending, bulk.StatusRunning)
+}
+
+func (p *Persister) FailIdentityJob(ctx context.Context, id uuid.UUID, reason string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FailIdentityJob")
+	defer otelx.End(span, &err)
+
+	return p.finishIdentityJob(ctx, id, bulk.StatusFailed, reason, bulk.StatusPending, bulk.StatusRunning)
+}
+
+// finishIdentityJob sets the status and error of the job if it is in one of
+// the given states, and removes its items which are no longer needed.
+func (p *Persister) finishIdentityJob(ctx context.Context, id uuid.UUID, status, reason string, from ...string) error {
+	nid := p.NetworkID(ctx)
+	now := time.Now().UTC()
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		args := []any{status, sqlxx.NullString(reason), now, now, id, nid}
+		for _, s := range from {
+			args = append(args, s)
+		}
+		//#nosec G201 -- TableName is static
+		count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET status = ?, error = ?, finished_at = ?, updated_at = ? WHERE id = ? AND nid = ? AND status IN (?%s)",
+			bulk.Job{}.TableName(),
+			strings.Repeat(", ?", len(from)-1),
+		), args...).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		} else if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		//#nosec G201 -- TableName is static
+		return sqlcon.HandleError(tx.RawQuery(fmt.Sprintf(
+			"DELETE FROM %s WHERE job_id = ? AND nid = ?",
+			bulk.Item{}.TableName(),
+		), id, nid).Exec())
+	})
+}
@@ -25,6 +25,12 @@ func TestPersister_Cleanup(t *testing.T) {
 		assert.Nil(t, p.CleanupDatabase(ctx, 0, 0, reg.Config().DatabaseCleanupBatchSize(ctx)))
 	})
 
+	t.Run("case=should stop waiting when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.Error(t, p.CleanupDatabase(ctx, time.Hour, 0, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
 	t.Run("case=should throw error on cleanup", func(t *testing.T) {
 		require.NoError(t, p.GetConnection(ctx).Close())
 		assert.Error(t, p.CleanupDatabase(ctx, 0, 0, reg.Config().DatabaseCleanupBatchSize(ctx)))
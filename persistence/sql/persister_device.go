@@ -0,0 +1,171 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/selfservice/flow/device"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ device.Persister = new(Persister)
+
+func (p *Persister) CreateDeviceAuthorization(ctx context.Context, a *device.Authorization, deviceCode, userCode string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateDeviceAuthorization")
+	defer otelx.End(span, &err)
+
+	a.NID = p.NetworkID(ctx)
+	a.DeviceCodeHMAC = p.hmacValue(ctx, deviceCode)
+	a.UserCodeHMAC = p.hmacValue(ctx, device.NormalizeUserCode(userCode))
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(a))
+}
+
+func (p *Persister) GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (_ *device.Authorization, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetDeviceAuthorizationByUserCode")
+	defer otelx.End(span, &err)
+
+	var a device.Authorization
+	if err := p.GetConnection(ctx).
+		Where("user_code_hmac IN (?) AND nid = ?", p.hmacValues(ctx, device.NormalizeUserCode(userCode)), p.NetworkID(ctx)).
+		First(&a); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &a, nil
+}
+
+func (p *Persister) GetDeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (_ *device.Authorization, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetDeviceAuthorizationByDeviceCode")
+	defer otelx.End(span, &err)
+
+	var a device.Authorization
+	if err := p.GetConnection(ctx).
+		Where("device_code_hmac IN (?) AND nid = ?", p.hmacValues(ctx, deviceCode), p.NetworkID(ctx)).
+		First(&a); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &a, nil
+}
+
+func (p *Persister) DecideDeviceAuthorization(ctx context.Context, id, identityID uuid.UUID, state device.AuthorizationState) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DecideDeviceAuthorization")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET state = ?, identity_id = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ? AND expires_at > ?",
+		device.Authorization{}.TableName(),
+	),
+		state, identityID, now, id, p.NetworkID(ctx), device.AuthorizationStatePending, now,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) PollDeviceAuthorization(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.PollDeviceAuthorization")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET last_polled_at = ?, updated_at = ? WHERE id = ? AND nid = ?",
+		device.Authorization{}.TableName(),
+	),
+		at.UTC(), at.UTC(), id, p.NetworkID(ctx),
+	).Exec())
+}
+
+func (p *Persister) ConsumeDeviceAuthorization(ctx context.Context, id, sessionID uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ConsumeDeviceAuthorization")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET state = ?, session_id = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ? AND expires_at > ?",
+		device.Authorization{}.TableName(),
+	),
+		device.AuthorizationStateConsumed, sessionID, now, id, p.NetworkID(ctx), device.AuthorizationStateApproved, now,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) DeleteExpiredDeviceAuthorizations(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredDeviceAuthorizations")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT ?) AS s)",
+		device.Authorization{}.TableName(),
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+		limit,
+	).Exec()
+
+	return sqlcon.HandleError(err)
+}
+
+func (p *Persister) CreateDeviceFlow(ctx context.Context, f *device.Flow) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateDeviceFlow")
+	defer otelx.End(span, &err)
+
+	f.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(f))
+}
+
+func (p *Persister) GetDeviceFlow(ctx context.Context, id uuid.UUID) (_ *device.Flow, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetDeviceFlow")
+	defer otelx.End(span, &err)
+
+	var f device.Flow
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&f); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &f, nil
+}
+
+func (p *Persister) UpdateDeviceFlow(ctx context.Context, f *device.Flow) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateDeviceFlow")
+	defer otelx.End(span, &err)
+
+	cp := *f
+	cp.NID = p.NetworkID(ctx)
+	return update.Generic(ctx, p.GetConnection(ctx), p.r.Tracer(ctx).Tracer(), cp)
+}
+
+func (p *Persister) DeleteExpiredDeviceFlows(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredDeviceFlows")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT ?) AS s)",
+		device.Flow{}.TableName(),
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+		limit,
+	).Exec()
+
+	return sqlcon.HandleError(err)
+}
@@ -14,6 +14,17 @@ func (p *Persister) hmacValue(ctx context.Context, value string) string {
 	return hmacValueWithSecret(value, p.r.Config().SecretsSession(ctx)[0])
 }
 
+// hmacValues returns the HMACs of the value for all session secrets so that
+// values remain valid while the secrets are rotated.
+func (p *Persister) hmacValues(ctx context.Context, value string) []string {
+	secrets := p.r.Config().SecretsSession(ctx)
+	hmacs := make([]string, len(secrets))
+	for k, secret := range secrets {
+		hmacs[k] = hmacValueWithSecret(value, secret)
+	}
+	return hmacs
+}
+
 func hmacValueWithSecret(value string, secret []byte) string {
 	h := hmac.New(sha512.New512_256, secret)
 	_, _ = h.Write([]byte(value))
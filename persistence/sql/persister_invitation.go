@@ -0,0 +1,136 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/invitation"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var _ invitation.Persister = new(Persister)
+
+func (p *Persister) CreateInvitation(ctx context.Context, i *invitation.Invitation, token string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateInvitation")
+	defer otelx.End(span, &err)
+
+	i.NID = p.NetworkID(ctx)
+	i.TokenHMAC = p.hmacValue(ctx, token)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(i))
+}
+
+func (p *Persister) GetInvitation(ctx context.Context, id uuid.UUID) (_ *invitation.Invitation, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetInvitation")
+	defer otelx.End(span, &err)
+
+	var i invitation.Invitation
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&i); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &i, nil
+}
+
+func (p *Persister) ListInvitations(ctx context.Context, opts []keysetpagination.Option) (_ []invitation.Invitation, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListInvitations")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(invitation.Invitation{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invitations := make([]invitation.Invitation, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Scope(keysetpagination.Paginate[invitation.Invitation](paginator)).
+		All(&invitations); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	invitations, nextPage := keysetpagination.Result(invitations, paginator)
+	return invitations, nextPage, nil
+}
+
+func (p *Persister) RevokeInvitation(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeInvitation")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET state = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ?",
+		invitation.Invitation{}.TableName(),
+	),
+		invitation.StateRevoked, time.Now().UTC(), id, p.NetworkID(ctx), invitation.StatePending,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) FindPendingInvitation(ctx context.Context, token string) (_ *invitation.Invitation, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindPendingInvitation")
+	defer otelx.End(span, &err)
+
+	var i invitation.Invitation
+	if err := p.GetConnection(ctx).
+		Where("token_hmac IN (?) AND nid = ? AND state = ? AND expires_at > ?",
+			p.hmacValues(ctx, token), p.NetworkID(ctx), invitation.StatePending, time.Now().UTC()).
+		First(&i); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &i, nil
+}
+
+func (p *Persister) AcceptInvitation(ctx context.Context, id uuid.UUID) (_ *invitation.Invitation, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.AcceptInvitation")
+	defer otelx.End(span, &err)
+
+	var i invitation.Invitation
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		now := time.Now().UTC()
+		if err := tx.
+			Where("id = ? AND nid = ? AND state = ? AND expires_at > ?",
+				id, p.NetworkID(ctx), invitation.StatePending, now).
+			First(&i); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		//#nosec G201 -- TableName is static
+		count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET state = ?, accepted_at = ?, updated_at = ? WHERE id = ? AND nid = ? AND state = ?",
+			invitation.Invitation{}.TableName(),
+		),
+			invitation.StateAccepted, now, now, i.ID, p.NetworkID(ctx), invitation.StatePending,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		} else if count == 0 {
+			// The invitation was accepted or revoked concurrently.
+			return errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		i.State = invitation.StateAccepted
+		i.AcceptedAt = new(sqlxx.NullTime(now))
+		i.UpdatedAt = now
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
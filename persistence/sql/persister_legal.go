@@ -0,0 +1,77 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/legal"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ legal.Persister = new(Persister)
+
+func (p *Persister) CreateLegalAcceptances(ctx context.Context, acceptances []legal.Acceptance) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateLegalAcceptances")
+	defer otelx.End(span, &err)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		for k := range acceptances {
+			acceptances[k].NID = p.NetworkID(ctx)
+			if err := tx.Create(&acceptances[k]); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *Persister) ListLegalAcceptances(ctx context.Context, params legal.ListParameters, opts []keysetpagination.Option) (_ []legal.Acceptance, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListLegalAcceptances")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(legal.Acceptance{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := p.GetConnection(ctx).Where("nid = ?", p.NetworkID(ctx))
+	if params.IdentityID != uuid.Nil {
+		q = q.Where("identity_id = ?", params.IdentityID)
+	}
+	if params.DocumentID != "" {
+		q = q.Where("document_id = ?", params.DocumentID)
+	}
+	if params.Version != "" {
+		q = q.Where("version = ?", params.Version)
+	}
+
+	acceptances := make([]legal.Acceptance, 0, paginator.Size())
+	if err := q.Scope(keysetpagination.Paginate[legal.Acceptance](paginator)).All(&acceptances); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	acceptances, nextPage := keysetpagination.Result(acceptances, paginator)
+	return acceptances, nextPage, nil
+}
+
+func (p *Persister) HasAcceptedLegalDocument(ctx context.Context, identityID uuid.UUID, documentID, version string) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.HasAcceptedLegalDocument")
+	defer otelx.End(span, &err)
+
+	exists, err := p.GetConnection(ctx).
+		Where("nid = ? AND identity_id = ? AND document_id = ? AND version = ?", p.NetworkID(ctx), identityID, documentID, version).
+		Exists(new(legal.Acceptance))
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/risk"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/stringsx"
+)
+
+var _ risk.Persister = new(Persister)
+
+func (p *Persister) CreateLoginAttempt(ctx context.Context, a *risk.LoginAttempt) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateLoginAttempt")
+	defer otelx.End(span, &err)
+
+	a.NID = p.NetworkID(ctx)
+	if a.UserAgent != nil {
+		a.UserAgent = new(stringsx.TruncateByteLen(*a.UserAgent, SessionDeviceUserAgentMaxLength))
+	}
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(a))
+}
+
+func (p *Persister) ListSuccessfulLoginAttempts(ctx context.Context, identityID uuid.UUID, limit int) (_ []risk.LoginAttempt, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSuccessfulLoginAttempts")
+	defer otelx.End(span, &err)
+
+	attempts := make([]risk.LoginAttempt, 0)
+	if err := p.GetConnection(ctx).
+		Where("identity_id = ? AND nid = ? AND succeeded = ?", identityID, p.NetworkID(ctx), true).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		All(&attempts); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return attempts, nil
+}
+
+func (p *Persister) CountFailedLoginAttempts(ctx context.Context, ipAddress string, since time.Time) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountFailedLoginAttempts")
+	defer otelx.End(span, &err)
+
+	count, err := p.GetConnection(ctx).
+		Where("ip_address = ? AND nid = ? AND succeeded = ? AND created_at > ?", ipAddress, p.NetworkID(ctx), false, since.UTC()).
+		Count(new(risk.LoginAttempt))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	return count, nil
+}
+
+func (p *Persister) DeleteLoginAttemptsBefore(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteLoginAttemptsBefore")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE created_at <= ? and nid = ? ORDER BY created_at ASC LIMIT ?) AS s)",
+		risk.LoginAttempt{}.TableName(),
+	),
+		before.UTC(),
+		p.NetworkID(ctx),
+		limit,
+	).Exec()
+
+	return sqlcon.HandleError(err)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/maintenance"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var _ maintenance.Persister = new(Persister)
+
+// leaseClauses returns SQL expressions for the database's current time and for
+// the end of a lease starting now. The end of the lease has one parameter, which
+// is returned as arg. Leases are computed by the database, so that clock skew
+// between replicas cannot lead to two holders at once.
+func leaseClauses(conn *pop.Connection, lease time.Duration) (now, until string, arg any) {
+	switch conn.Dialect.Name() {
+	case "sqlite3":
+		return "strftime('%Y-%m-%d %H:%M:%f', 'now')",
+			"strftime('%Y-%m-%d %H:%M:%f', 'now', ?)",
+			fmt.Sprintf("%+.3f seconds", lease.Seconds())
+	case "mysql":
+		return "UTC_TIMESTAMP(6)",
+			"DATE_ADD(UTC_TIMESTAMP(6), INTERVAL ? MICROSECOND)",
+			lease.Microseconds()
+	default:
+		return "(CURRENT_TIMESTAMP AT TIME ZONE 'UTC')",
+			"((CURRENT_TIMESTAMP AT TIME ZONE 'UTC') + CAST(? AS INTERVAL))",
+			fmt.Sprintf("%d microseconds", lease.Microseconds())
+	}
+}
+
+func (p *Persister) AcquireMaintenanceJob(ctx context.Context, name, holder string, finishedBefore time.Time, lease time.Duration) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.AcquireMaintenanceJob")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	c := p.GetConnection(ctx)
+	if exists, err := c.Where("name = ?", name).Exists(new(maintenance.JobState)); err != nil {
+		return false, sqlcon.HandleError(err)
+	} else if !exists {
+		// Another replica may create the row concurrently, which is fine.
+		if err := sqlcon.HandleError(c.RawQuery(
+			"INSERT INTO maintenance_jobs (name, created_at, updated_at) VALUES (?, ?, ?)",
+			name, now, now,
+		).Exec()); err != nil && !errors.Is(err, sqlcon.ErrUniqueViolation()) {
+			return false, err
+		}
+	}
+
+	dbNow, until, leaseArg := leaseClauses(c, lease)
+	count, err := c.RawQuery(
+		"UPDATE maintenance_jobs SET locked_by = ?, locked_until = "+until+", last_started_at = ?, updated_at = ? "+
+			"WHERE name = ? AND (locked_until IS NULL OR locked_until < "+dbNow+") AND (last_finished_at IS NULL OR last_finished_at <= ?)",
+		holder, leaseArg, now, now,
+		name, finishedBefore.UTC(),
+	).ExecWithCount()
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return count == 1, nil
+}
+
+func (p *Persister) RenewMaintenanceJob(ctx context.Context, name, holder string, lease time.Duration) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RenewMaintenanceJob")
+	defer otelx.End(span, &err)
+
+	c := p.GetConnection(ctx)
+	_, until, leaseArg := leaseClauses(c, lease)
+	count, err := c.RawQuery(
+		"UPDATE maintenance_jobs SET locked_until = "+until+", updated_at = ? WHERE name = ? AND locked_by = ?",
+		leaseArg, time.Now().UTC(), name, holder,
+	).ExecWithCount()
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return count == 1, nil
+}
+
+func (p *Persister) ReleaseMaintenanceJob(ctx context.Context, name, holder string, runErr error) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ReleaseMaintenanceJob")
+	defer otelx.End(span, &err)
+
+	status, lastError := maintenance.RunStatusSucceeded, sqlxx.NullString("")
+	if runErr != nil {
+		status, lastError = maintenance.RunStatusFailed, sqlxx.NullString(runErr.Error())
+	}
+
+	now := time.Now().UTC()
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		"UPDATE maintenance_jobs SET locked_by = NULL, locked_until = NULL, last_finished_at = ?, last_status = ?, last_error = ?, updated_at = ? "+
+			"WHERE name = ? AND locked_by = ?",
+		now, status, lastError, now, name, holder,
+	).Exec())
+}
+
+func (p *Persister) ListMaintenanceJobs(ctx context.Context) (_ []maintenance.JobState, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListMaintenanceJobs")
+	defer otelx.End(span, &err)
+
+	var jobs []maintenance.JobState
+	if err := p.GetConnection(ctx).Order("name ASC").All(&jobs); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,204 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/organization"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var _ organization.Persister = new(Persister)
+
+func (p *Persister) CreateOrganization(ctx context.Context, o *organization.Organization) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateOrganization")
+	defer otelx.End(span, &err)
+
+	o.NID = p.NetworkID(ctx)
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		if err := tx.Create(o); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		return p.createOrganizationRelations(tx, o)
+	})
+}
+
+func (p *Persister) createOrganizationRelations(tx *pop.Connection, o *organization.Organization) error {
+	for _, domain := range o.Domains {
+		if err := tx.Create(&organization.Domain{OrganizationID: o.ID, Domain: domain, NID: o.NID}); err != nil {
+			return sqlcon.HandleError(err)
+		}
+	}
+	for _, c := range o.OIDCProviders {
+		if err := tx.Create(&organization.OIDCProvider{
+			OrganizationID: o.ID,
+			ProviderID:     organization.ProviderID(c),
+			Config:         c,
+			NID:            o.NID,
+		}); err != nil {
+			return sqlcon.HandleError(err)
+		}
+	}
+	return nil
+}
+
+func (p *Persister) GetOrganization(ctx context.Context, id uuid.UUID) (_ *organization.Organization, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetOrganization")
+	defer otelx.End(span, &err)
+
+	return p.findOrganization(ctx, id)
+}
+
+func (p *Persister) FindOrganizationByDomain(ctx context.Context, domain string) (_ *organization.Organization, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindOrganizationByDomain")
+	defer otelx.End(span, &err)
+
+	var d organization.Domain
+	if err := p.GetConnection(ctx).Where("domain = ? AND nid = ?", domain, p.NetworkID(ctx)).First(&d); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return p.findOrganization(ctx, d.OrganizationID)
+}
+
+func (p *Persister) FindOrganizationOIDCProvider(ctx context.Context, providerID string) (_ *organization.OIDCProvider, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindOrganizationOIDCProvider")
+	defer otelx.End(span, &err)
+
+	var op organization.OIDCProvider
+	if err := p.GetConnection(ctx).Where("provider_id = ? AND nid = ?", providerID, p.NetworkID(ctx)).First(&op); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &op, nil
+}
+
+func (p *Persister) findOrganization(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	var o organization.Organization
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&o); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	organizations := []organization.Organization{o}
+	if err := p.loadOrganizationRelations(ctx, organizations); err != nil {
+		return nil, err
+	}
+	return &organizations[0], nil
+}
+
+func (p *Persister) ListOrganizations(ctx context.Context, opts []keysetpagination.Option) (_ []organization.Organization, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListOrganizations")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(organization.Organization{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organizations := make([]organization.Organization, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Scope(keysetpagination.Paginate[organization.Organization](paginator)).
+		All(&organizations); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	organizations, nextPage := keysetpagination.Result(organizations, paginator)
+	if err := p.loadOrganizationRelations(ctx, organizations); err != nil {
+		return nil, nil, err
+	}
+	return organizations, nextPage, nil
+}
+
+func (p *Persister) loadOrganizationRelations(ctx context.Context, organizations []organization.Organization) error {
+	if len(organizations) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(organizations))
+	byID := make(map[uuid.UUID]*organization.Organization, len(organizations))
+	for k := range organizations {
+		ids[k] = organizations[k].ID
+		byID[organizations[k].ID] = &organizations[k]
+		organizations[k].Domains = []string{}
+		organizations[k].OIDCProviders = []sqlxx.JSONRawMessage{}
+	}
+
+	var domains []organization.Domain
+	if err := p.GetConnection(ctx).
+		Where("organization_id IN (?) AND nid = ?", ids, p.NetworkID(ctx)).
+		Order("domain ASC").
+		All(&domains); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	for _, d := range domains {
+		if o, ok := byID[d.OrganizationID]; ok {
+			o.Domains = append(o.Domains, d.Domain)
+		}
+	}
+
+	var providers []organization.OIDCProvider
+	if err := p.GetConnection(ctx).
+		Where("organization_id IN (?) AND nid = ?", ids, p.NetworkID(ctx)).
+		Order("provider_id ASC").
+		All(&providers); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	for _, op := range providers {
+		if o, ok := byID[op.OrganizationID]; ok {
+			o.OIDCProviders = append(o.OIDCProviders, op.Config)
+		}
+	}
+	return nil
+}
+
+func (p *Persister) UpdateOrganization(ctx context.Context, o *organization.Organization) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateOrganization")
+	defer otelx.End(span, &err)
+
+	o.NID = p.NetworkID(ctx)
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var existing organization.Organization
+		if err := tx.Where("id = ? AND nid = ?", o.ID, o.NID).First(&existing); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		o.CreatedAt = existing.CreatedAt
+
+		if err := tx.Update(o); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		for _, table := range []string{organization.Domain{}.TableName(), organization.OIDCProvider{}.TableName()} {
+			if err := tx.RawQuery("DELETE FROM "+table+" WHERE organization_id = ? AND nid = ?", o.ID, o.NID).Exec(); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return p.createOrganizationRelations(tx, o)
+	})
+}
+
+func (p *Persister) DeleteOrganization(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteOrganization")
+	defer otelx.End(span, &err)
+
+	// Deleting the organization cascades to its domains and providers.
+	count, err := p.GetConnection(ctx).RawQuery(
+		"DELETE FROM organizations WHERE id = ? AND nid = ?", id, p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ identity.ProfilePromptPersister = new(Persister)
+
+func (p *Persister) ListProfilePrompts(ctx context.Context, identityID uuid.UUID) (_ []string, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListProfilePrompts")
+	defer otelx.End(span, &err)
+
+	var prompts []identity.ProfilePrompt
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND identity_id = ?", p.NetworkID(ctx), identityID).
+		Order("trait ASC").
+		All(&prompts); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	traits := make([]string, len(prompts))
+	for k := range prompts {
+		traits[k] = prompts[k].Trait
+	}
+	return traits, nil
+}
+
+func (p *Persister) CreateProfilePrompts(ctx context.Context, identityID uuid.UUID, traits []string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateProfilePrompts")
+	defer otelx.End(span, &err)
+
+	if len(traits) == 0 {
+		return nil
+	}
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		existing, err := p.ListProfilePrompts(ctx, identityID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, trait := range traits {
+			if slices.Contains(existing, trait) {
+				continue
+			}
+			existing = append(existing, trait)
+
+			if err := tx.Create(&identity.ProfilePrompt{
+				IdentityID: identityID,
+				Trait:      trait,
+				PromptedAt: now,
+				NID:        p.NetworkID(ctx),
+			}); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return nil
+	})
+}
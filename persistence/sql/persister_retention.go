@@ -0,0 +1,124 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/retention"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ retention.Persister = new(Persister)
+
+const verifiedAddressExists = "EXISTS (SELECT 1 FROM identity_verifiable_addresses a WHERE a.identity_id = identities.id AND a.nid = identities.nid AND a.verified = ?)"
+
+func (p *Persister) retentionCandidates(ctx context.Context, q retention.Query) *pop.Query {
+	query := p.GetConnection(ctx).Where("identities.nid = ?", p.NetworkID(ctx))
+	if !q.InactiveBefore.IsZero() {
+		query = query.Where("COALESCE(identities.last_authenticated_at, identities.created_at) < ?", q.InactiveBefore.UTC())
+	}
+	if !q.CreatedBefore.IsZero() {
+		query = query.Where("identities.created_at < ?", q.CreatedBefore.UTC())
+	}
+	if q.State != "" {
+		query = query.Where("identities.state = ?", q.State)
+	}
+	if q.Verified != nil {
+		if *q.Verified {
+			query = query.Where(verifiedAddressExists, true)
+		} else {
+			query = query.Where("NOT "+verifiedAddressExists, true)
+		}
+	}
+
+	// Each policy is applied at most once per period of inactivity.
+	return query.Where(
+		"NOT EXISTS (SELECT 1 FROM identity_retention_actions r WHERE r.identity_id = identities.id AND r.nid = identities.nid AND r.policy_id = ? AND r.created_at > COALESCE(identities.last_authenticated_at, identities.created_at))",
+		q.PolicyID,
+	)
+}
+
+func (p *Persister) FindRetentionCandidates(ctx context.Context, q retention.Query, limit int) (_ []uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindRetentionCandidates")
+	defer otelx.End(span, &err)
+
+	var is []identity.Identity
+	if err := p.retentionCandidates(ctx, q).
+		Select("identities.id").
+		Order("identities.id ASC").
+		Limit(limit).
+		All(&is); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	ids := make([]uuid.UUID, len(is))
+	for k := range is {
+		ids[k] = is[k].ID
+	}
+	return ids, nil
+}
+
+func (p *Persister) CountRetentionCandidates(ctx context.Context, q retention.Query) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountRetentionCandidates")
+	defer otelx.End(span, &err)
+
+	count, err := p.retentionCandidates(ctx, q).Count(new(identity.Identity))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
+
+func (p *Persister) RecordRetentionAction(ctx context.Context, identityID uuid.UUID, policyID, action string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RecordRetentionAction")
+	defer otelx.End(span, &err)
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(&retention.AppliedAction{
+		NID:        p.NetworkID(ctx),
+		IdentityID: identityID,
+		PolicyID:   policyID,
+		Action:     action,
+	}))
+}
+
+func (p *Persister) AnonymizeIdentity(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.AnonymizeIdentity")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		count, err := tx.RawQuery(
+			"UPDATE identities SET traits = ?, metadata_public = NULL, metadata_admin = NULL, external_id = NULL, state = ?, state_changed_at = ?, updated_at = ? WHERE id = ? AND nid = ?",
+			"{}", identity.StateInactive, now, now, id, nid,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		} else if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		for _, q := range []string{
+			"DELETE FROM identity_credential_identifiers WHERE identity_credential_id IN (SELECT id FROM identity_credentials WHERE identity_id = ? AND nid = ?)",
+			"DELETE FROM identity_credentials WHERE identity_id = ? AND nid = ?",
+			"DELETE FROM identity_verifiable_addresses WHERE identity_id = ? AND nid = ?",
+			"DELETE FROM identity_recovery_addresses WHERE identity_id = ? AND nid = ?",
+			"DELETE FROM identity_unique_traits WHERE identity_id = ? AND nid = ?",
+			"DELETE FROM identity_pending_traits_changes WHERE identity_id = ? AND nid = ?",
+		} {
+			if err := tx.RawQuery(q, id, nid).Exec(); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return nil
+	})
+}
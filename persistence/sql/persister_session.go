@@ -112,6 +112,9 @@ func (p *Persister) ListSessions(ctx context.Context, active *bool, paginatorOpt
 		if s[k].Identity == nil {
 			continue
 		}
+		if err := p.r.IdentityTraitsClassifier().DecryptTraits(ctx, s[k].Identity); err != nil {
+			return nil, nil, err
+		}
 		if err := p.InjectTraitsSchemaURL(ctx, s[k].Identity); err != nil {
 			return nil, nil, err
 		}
@@ -493,6 +496,34 @@ func (p *Persister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID u
 	return count, nil
 }
 
+func (p *Persister) UseRevokeSessionsToken(ctx context.Context, tokenHash string, expiresAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseRevokeSessionsToken")
+	defer otelx.End(span, &err)
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(&session.RevokeSessionsToken{
+		NID:       p.NetworkID(ctx),
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}))
+}
+
+func (p *Persister) DeleteExpiredRevokeSessionsTokens(ctx context.Context, expiresAt time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredRevokeSessionsTokens")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE expires_at <= ? and nid = ? ORDER BY expires_at ASC LIMIT ?) AS s)",
+		session.RevokeSessionsToken{}.TableName(),
+	),
+		expiresAt,
+		p.NetworkID(ctx),
+		limit,
+	).Exec()
+
+	return sqlcon.HandleError(err)
+}
+
 func (p *Persister) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time, limit int) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredSessions")
 	defer otelx.End(span, &err)
@@ -0,0 +1,147 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/networkx"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ tenant.Persister = new(Persister)
+
+func (p *Persister) CreateTenant(ctx context.Context, t *tenant.Tenant) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateTenant")
+	defer otelx.End(span, &err)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		if err := tx.Create(&networkx.Network{ID: t.ID}); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if err := tx.Create(t); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		for _, host := range t.Hosts {
+			if err := tx.Create(&tenant.Host{TenantID: t.ID, Host: host}); err != nil {
+				return sqlcon.HandleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *Persister) GetTenant(ctx context.Context, id uuid.UUID) (_ *tenant.Tenant, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetTenant")
+	defer otelx.End(span, &err)
+
+	return p.findTenant(ctx, "id = ?", id)
+}
+
+func (p *Persister) GetTenantByName(ctx context.Context, name string) (_ *tenant.Tenant, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetTenantByName")
+	defer otelx.End(span, &err)
+
+	return p.findTenant(ctx, "name = ?", name)
+}
+
+func (p *Persister) GetTenantByHost(ctx context.Context, host string) (_ *tenant.Tenant, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetTenantByHost")
+	defer otelx.End(span, &err)
+
+	var h tenant.Host
+	if err := p.GetConnection(ctx).Where("host = ?", host).First(&h); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return p.findTenant(ctx, "id = ?", h.TenantID)
+}
+
+func (p *Persister) findTenant(ctx context.Context, where string, arg any) (*tenant.Tenant, error) {
+	var t tenant.Tenant
+	if err := p.GetConnection(ctx).Where(where, arg).First(&t); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	tenants := []tenant.Tenant{t}
+	if err := p.loadTenantHosts(ctx, tenants); err != nil {
+		return nil, err
+	}
+	return &tenants[0], nil
+}
+
+func (p *Persister) ListTenants(ctx context.Context, opts []keysetpagination.Option) (_ []tenant.Tenant, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListTenants")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(tenant.Tenant{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tenants := make([]tenant.Tenant, 0, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Scope(keysetpagination.Paginate[tenant.Tenant](paginator)).
+		All(&tenants); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	tenants, nextPage := keysetpagination.Result(tenants, paginator)
+	if err := p.loadTenantHosts(ctx, tenants); err != nil {
+		return nil, nil, err
+	}
+	return tenants, nextPage, nil
+}
+
+func (p *Persister) loadTenantHosts(ctx context.Context, tenants []tenant.Tenant) error {
+	if len(tenants) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(tenants))
+	byID := make(map[uuid.UUID]*tenant.Tenant, len(tenants))
+	for k := range tenants {
+		ids[k] = tenants[k].ID
+		byID[tenants[k].ID] = &tenants[k]
+		tenants[k].Hosts = []string{}
+	}
+
+	var hosts []tenant.Host
+	if err := p.GetConnection(ctx).Where("tenant_id IN (?)", ids).Order("host ASC").All(&hosts); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	for _, h := range hosts {
+		if t, ok := byID[h.TenantID]; ok {
+			t.Hosts = append(t.Hosts, h.Host)
+		}
+	}
+	return nil
+}
+
+func (p *Persister) DeleteTenant(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteTenant")
+	defer otelx.End(span, &err)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		// Only networks belonging to a tenant may be deleted, never the default
+		// network.
+		if exists, err := tx.Where("id = ?", id).Exists(new(tenant.Tenant)); err != nil {
+			return sqlcon.HandleError(err)
+		} else if !exists {
+			return errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		// Deleting the network cascades to the tenant, its hosts and all data
+		// scoped to the network.
+		return sqlcon.HandleError(tx.RawQuery("DELETE FROM networks WHERE id = ?", id).Exec())
+	})
+}
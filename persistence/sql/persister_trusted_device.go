@@ -0,0 +1,107 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/stringsx"
+)
+
+var _ session.TrustedDevicePersister = new(Persister)
+
+func (p *Persister) CreateTrustedDevice(ctx context.Context, d *session.TrustedDevice, token string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateTrustedDevice")
+	defer otelx.End(span, &err)
+
+	d.NID = p.NetworkID(ctx)
+	d.TokenHMAC = p.hmacValue(ctx, token)
+	if d.UserAgent != nil {
+		d.UserAgent = new(stringsx.TruncateByteLen(*d.UserAgent, SessionDeviceUserAgentMaxLength))
+	}
+	if d.Location != nil {
+		d.Location = new(stringsx.TruncateByteLen(*d.Location, SessionDeviceLocationMaxLength))
+	}
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(d))
+}
+
+func (p *Persister) UseTrustedDevice(ctx context.Context, identityID, id uuid.UUID, token string) (_ *session.TrustedDevice, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseTrustedDevice")
+	defer otelx.End(span, &err)
+
+	now := time.Now().UTC()
+	var d session.TrustedDevice
+	if err := p.GetConnection(ctx).
+		Where("id = ? AND identity_id = ? AND nid = ? AND expires_at > ?", id, identityID, p.NetworkID(ctx), now).
+		First(&d); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	var matches bool
+	for _, secret := range p.r.Config().SecretsSession(ctx) {
+		if subtle.ConstantTimeCompare([]byte(d.TokenHMAC), []byte(hmacValueWithSecret(token, secret))) == 1 {
+			matches = true
+			break
+		}
+	}
+	if !matches {
+		return nil, errors.WithStack(sqlcon.ErrNoRows())
+	}
+
+	if err := p.GetConnection(ctx).
+		RawQuery("UPDATE identity_trusted_devices SET last_used_at = ?, updated_at = ? WHERE id = ? AND nid = ?", now, now, d.ID, d.NID).
+		Exec(); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &d, nil
+}
+
+func (p *Persister) ListTrustedDevices(ctx context.Context, identityID uuid.UUID) (_ []session.TrustedDevice, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListTrustedDevices")
+	defer otelx.End(span, &err)
+
+	devices := make([]session.TrustedDevice, 0)
+	if err := p.GetConnection(ctx).
+		Where("identity_id = ? AND nid = ? AND expires_at > ?", identityID, p.NetworkID(ctx), time.Now().UTC()).
+		Order("created_at DESC, id DESC").
+		All(&devices); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return devices, nil
+}
+
+func (p *Persister) DeleteTrustedDevice(ctx context.Context, identityID, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteTrustedDevice")
+	defer otelx.End(span, &err)
+
+	count, err := p.GetConnection(ctx).
+		RawQuery("DELETE FROM identity_trusted_devices WHERE id = ? AND identity_id = ? AND nid = ?", id, identityID, p.NetworkID(ctx)).
+		ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) DeleteTrustedDevicesByIdentity(ctx context.Context, identityID uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteTrustedDevicesByIdentity")
+	defer otelx.End(span, &err)
+
+	return sqlcon.HandleError(p.GetConnection(ctx).
+		RawQuery("DELETE FROM identity_trusted_devices WHERE identity_id = ? AND nid = ?", identityID, p.NetworkID(ctx)).
+		Exec())
+}
@@ -0,0 +1,23 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+)
+
+var _ identity.UniqueTraitPersister = new(Persister)
+
+func (p *Persister) IndexUniqueTraits(ctx context.Context, identityID uuid.UUID, traits []identity.UniqueTrait) ([]identity.UniqueTrait, error) {
+	up, ok := p.PrivilegedPool.(identity.UniqueTraitPersister)
+	if !ok {
+		return nil, errors.New("the identity pool does not support unique traits")
+	}
+	return up.IndexUniqueTraits(ctx, identityID, traits)
+}
@@ -0,0 +1,34 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package testhelpers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ory/kratos/driver"
+)
+
+// NewAdminGRPCClient serves the admin gRPC API of the registry in memory and
+// returns a client connection to it.
+func NewAdminGRPCClient(t *testing.T, reg *driver.RegistryDefault) *grpc.ClientConn {
+	l := bufconn.Listen(1024 * 1024)
+	s := reg.AdminGRPCServer()
+	go func() { _ = s.Serve(l) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
@@ -26,6 +26,7 @@ type (
 		EmitAnalyticsEvent *bool             `json:"emit_analytics_event" koanf:"emit_analytics_event"`
 		CanInterrupt       bool              `json:"can_interrupt" koanf:"can_interrupt"`
 		Response           ResponseConfig    `json:"response" koanf:"response"`
+		IncludePII         bool              `json:"include_pii" koanf:"include_pii"`
 
 		auth   AuthStrategy
 		header http.Header
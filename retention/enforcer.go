@@ -0,0 +1,246 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	enforcerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.ClientProvider
+		courier.Provider
+		courier.ConfigProvider
+		identity.PrivilegedPoolProvider
+		session.PersistenceProvider
+		tenant.ContextsProvider
+		PersistenceProvider
+	}
+
+	// Enforcer applies the configured retention policies. It is run as the
+	// "retention" maintenance job.
+	Enforcer struct {
+		d enforcerDependencies
+	}
+	EnforcerProvider interface {
+		RetentionEnforcer() *Enforcer
+	}
+
+	// Retention Policy Report
+	//
+	// swagger:model retentionPolicyReport
+	PolicyReport struct {
+		// PolicyID is the ID of the retention policy.
+		//
+		// required: true
+		PolicyID string `json:"policy_id"`
+
+		// Action is the action the policy applies.
+		//
+		// required: true
+		Action string `json:"action"`
+
+		// Matched is the number of identities the policy currently applies to.
+		//
+		// required: true
+		Matched int `json:"matched"`
+
+		// IdentityIDs lists the first identities the policy currently applies
+		// to, up to the configured batch size.
+		//
+		// required: true
+		IdentityIDs []uuid.UUID `json:"identity_ids"`
+
+		// DryRun is true if the policies are only reported and not applied.
+		//
+		// required: true
+		DryRun bool `json:"dry_run"`
+	}
+)
+
+func NewEnforcer(d enforcerDependencies) *Enforcer {
+	return &Enforcer{d: d}
+}
+
+func (e *Enforcer) Name() string {
+	return "retention"
+}
+
+// Run applies every retention policy to up to the configured batch size of
+// identities, in the default network and, in multi-tenant mode, the network
+// of every tenant. In dry-run mode, the matching identities are only logged.
+func (e *Enforcer) Run(ctx context.Context) error {
+	ctxs, err := e.d.TenantContexts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ctx := range ctxs {
+		if err := e.run(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) run(ctx context.Context) error {
+	policies, err := e.d.Config().IdentityRetentionPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	dryRun := e.d.Config().IdentityRetentionDryRun(ctx)
+	for _, p := range policies {
+		ids, err := e.d.RetentionPersister().FindRetentionCandidates(ctx, NewQuery(p, now), e.d.Config().IdentityRetentionBatchSize(ctx))
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			l := e.d.Logger().
+				WithField("retention_policy", p.ID).
+				WithField("retention_action", p.Action).
+				WithField("identity_id", id)
+			if dryRun {
+				l.Info("Retention policy would be applied to identity.")
+				continue
+			}
+
+			if err := e.apply(ctx, p, id); err != nil {
+				if errors.Is(err, sqlcon.ErrNoRows()) {
+					// The identity was deleted in the meantime.
+					continue
+				}
+				return err
+			}
+			l.Info("Retention policy applied to identity.")
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) apply(ctx context.Context, p config.IdentityRetentionPolicy, id uuid.UUID) error {
+	switch p.Action {
+	case ActionNotify:
+		if err := e.notify(ctx, p, id); err != nil {
+			return err
+		}
+	case ActionDeactivate:
+		i, err := e.d.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandNothing)
+		if err != nil {
+			return err
+		}
+		stateChangedAt := sqlxx.NullTime(time.Now().UTC())
+		i.State = identity.StateInactive
+		i.StateChangedAt = &stateChangedAt
+		if err := e.d.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, i, "state", "state_changed_at"); err != nil {
+			return err
+		}
+		if err := e.d.SessionPersister().DeleteSessionsByIdentity(ctx, id); err != nil {
+			return err
+		}
+	case ActionAnonymize:
+		if err := e.d.RetentionPersister().AnonymizeIdentity(ctx, id); err != nil {
+			return err
+		}
+		if err := e.d.SessionPersister().DeleteSessionsByIdentity(ctx, id); err != nil {
+			return err
+		}
+	case ActionDelete:
+		// Deleting the identity cascades to its sessions and to the recorded
+		// retention actions.
+		return e.d.PrivilegedIdentityPool().DeleteIdentity(ctx, id)
+	default:
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Retention policy %q has unknown action %q.", p.ID, p.Action))
+	}
+
+	return e.d.RetentionPersister().RecordRetentionAction(ctx, id, p.ID, p.Action)
+}
+
+func (e *Enforcer) notify(ctx context.Context, p config.IdentityRetentionPolicy, id uuid.UUID) error {
+	i, err := e.d.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandDefault)
+	if err != nil {
+		return err
+	}
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	inactiveSince := i.CreatedAt
+	if i.LastAuthenticatedAt != nil && !time.Time(*i.LastAuthenticatedAt).IsZero() {
+		inactiveSince = time.Time(*i.LastAuthenticatedAt)
+	}
+
+	c, err := e.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range i.VerifiableAddresses {
+		if address.Via != identity.AddressTypeEmail {
+			continue
+		}
+		if _, err := c.QueueEmail(ctx, email.NewRetentionNotice(e.d, &email.RetentionNoticeModel{
+			To:            address.Value,
+			Identity:      model,
+			PolicyID:      p.ID,
+			InactiveSince: inactiveSince.UTC().Format(time.RFC3339),
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Report returns, for every retention policy, the identities it currently
+// applies to in the network of the context.
+func (e *Enforcer) Report(ctx context.Context) ([]PolicyReport, error) {
+	policies, err := e.d.Config().IdentityRetentionPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	reports := make([]PolicyReport, len(policies))
+	for k, p := range policies {
+		q := NewQuery(p, now)
+		matched, err := e.d.RetentionPersister().CountRetentionCandidates(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		ids, err := e.d.RetentionPersister().FindRetentionCandidates(ctx, q, e.d.Config().IdentityRetentionBatchSize(ctx))
+		if err != nil {
+			return nil, err
+		}
+		reports[k] = PolicyReport{
+			PolicyID:    p.ID,
+			Action:      p.Action,
+			Matched:     matched,
+			IdentityIDs: ids,
+			DryRun:      e.d.Config().IdentityRetentionDryRun(ctx),
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,88 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"net/http"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+)
+
+const RouteReport = "/retention/report"
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		EnforcerProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		RetentionHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(httprouterx.AdminPrefix + RouteReport)
+
+	public.GET(httprouterx.AdminPrefix+RouteReport, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteReport, h.report)
+}
+
+// Get Retention Report Response
+//
+// swagger:response getRetentionReport
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getRetentionReportResponse struct {
+	// in: body
+	Body []PolicyReport
+}
+
+// swagger:route GET /admin/retention/report identity getRetentionReport
+//
+// # Get the Identity Retention Report
+//
+// Reports, for every configured retention policy, the identities the policy
+// currently applies to. Nothing is changed, which makes this endpoint suitable
+// for reviewing policies before disabling `identity.retention.dry_run`.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: getRetentionReport
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) report(w http.ResponseWriter, r *http.Request) {
+	report, err := h.r.RetentionEnforcer().Report(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, report)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+)
+
+const (
+	ActionNotify     = "notify"
+	ActionDeactivate = "deactivate"
+	ActionAnonymize  = "anonymize"
+	ActionDelete     = "delete"
+)
+
+// Query selects the identities a retention policy applies to.
+type Query struct {
+	// PolicyID excludes identities the policy was already applied to since
+	// they last authenticated.
+	PolicyID string
+
+	// InactiveBefore matches identities which last authenticated before this
+	// time or, if they never authenticated, were created before this time.
+	InactiveBefore time.Time
+
+	// CreatedBefore matches identities created before this time.
+	CreatedBefore time.Time
+
+	// State matches identities in this state.
+	State identity.State
+
+	// Verified matches identities with or without a verified address.
+	Verified *bool
+}
+
+// NewQuery returns the query selecting the identities the policy applies to
+// at the given time.
+func NewQuery(p config.IdentityRetentionPolicy, now time.Time) Query {
+	q := Query{
+		PolicyID: p.ID,
+		State:    identity.State(p.State),
+		Verified: p.Verified,
+	}
+	if p.InactiveFor > 0 {
+		q.InactiveBefore = now.Add(-p.InactiveFor)
+	}
+	if p.OlderThan > 0 {
+		q.CreatedBefore = now.Add(-p.OlderThan)
+	}
+	if p.Action == ActionDeactivate && q.State == "" {
+		// Deactivating inactive identities would be a no-op.
+		q.State = identity.StateActive
+	}
+	return q
+}
+
+// AppliedAction records that a retention policy was applied to an identity.
+type AppliedAction struct {
+	ID         uuid.UUID `db:"id"`
+	NID        uuid.UUID `db:"nid"`
+	IdentityID uuid.UUID `db:"identity_id"`
+	PolicyID   string    `db:"policy_id"`
+	Action     string    `db:"action"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (AppliedAction) TableName() string {
+	return "identity_retention_actions"
+}
+
+type (
+	Persister interface {
+		// FindRetentionCandidates returns the IDs of up to limit identities
+		// matching the query.
+		FindRetentionCandidates(ctx context.Context, q Query, limit int) ([]uuid.UUID, error)
+
+		// CountRetentionCandidates returns the number of identities matching
+		// the query.
+		CountRetentionCandidates(ctx context.Context, q Query) (int, error)
+
+		// RecordRetentionAction records that the policy was applied to the
+		// identity.
+		RecordRetentionAction(ctx context.Context, identityID uuid.UUID, policyID, action string) error
+
+		// AnonymizeIdentity removes the identity's traits, metadata, external
+		// ID, addresses and credentials, and deactivates it.
+		AnonymizeIdentity(ctx context.Context, id uuid.UUID) error
+	}
+	PersistenceProvider interface {
+		RetentionPersister() Persister
+	}
+)
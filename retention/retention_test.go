@@ -0,0 +1,252 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/retention"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+func newRegistry(t *testing.T) (*config.Config, *driver.RegistryDefault) {
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	return conf, reg
+}
+
+// createIdentity creates an identity with a password and an active session
+// which last authenticated at the given time. If lastAuthenticatedAt is zero,
+// the identity never authenticated.
+func createIdentity(t *testing.T, reg *driver.RegistryDefault, email string, lastAuthenticatedAt time.Time) *identity.Identity {
+	ctx := context.Background()
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"` + email + `"}`)
+	i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+		Identifiers: []string{email},
+		Config:      []byte(`{"hashed_password":"$2a$04$zvZz1zV"}`),
+	})
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	if !lastAuthenticatedAt.IsZero() {
+		s, err := testhelpers.NewActiveSession(httptest.NewRequest("GET", "/sessions/whoami", nil), reg, i, lastAuthenticatedAt, identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+	}
+	return i
+}
+
+func setCreatedAt(t *testing.T, reg *driver.RegistryDefault, i *identity.Identity, at time.Time) {
+	require.NoError(t, reg.Persister().GetConnection(context.Background()).
+		RawQuery("UPDATE identities SET created_at = ? WHERE id = ?", at.UTC(), i.ID).Exec())
+}
+
+func setPolicies(conf *config.Config, policies ...map[string]interface{}) {
+	conf.MustSet(context.Background(), config.ViperKeyIdentityRetentionPolicies, policies)
+}
+
+func getIdentity(t *testing.T, reg *driver.RegistryDefault, id uuid.UUID) *identity.Identity {
+	i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id)
+	require.NoError(t, err)
+	return i
+}
+
+func countSessions(t *testing.T, reg *driver.RegistryDefault, id uuid.UUID) int64 {
+	_, total, err := reg.SessionPersister().ListSessionsByIdentity(context.Background(), id, nil, 1, 10, uuid.Nil, nil)
+	require.NoError(t, err)
+	return total
+}
+
+func TestLastAuthenticatedAt(t *testing.T) {
+	_, reg := newRegistry(t)
+
+	i := createIdentity(t, reg, "never@ory.sh", time.Time{})
+	assert.Nil(t, getIdentity(t, reg, i.ID).LastAuthenticatedAt)
+
+	at := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	i = createIdentity(t, reg, "once@ory.sh", at)
+	actual := getIdentity(t, reg, i.ID).LastAuthenticatedAt
+	require.NotNil(t, actual)
+	assert.WithinDuration(t, at, time.Time(*actual), time.Second)
+}
+
+func TestEnforcer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=dry run only reports", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeyIdentityRetentionDryRun, true)
+		setPolicies(conf, map[string]interface{}{"id": "dormant", "action": "deactivate", "inactive_for": "1h"})
+
+		dormant := createIdentity(t, reg, "dormant@ory.sh", time.Now().Add(-2*time.Hour))
+		createIdentity(t, reg, "recent@ory.sh", time.Now())
+		createIdentity(t, reg, "new@ory.sh", time.Time{})
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+		assert.Equal(t, identity.StateActive, getIdentity(t, reg, dormant.ID).State)
+
+		report, err := reg.RetentionEnforcer().Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []retention.PolicyReport{{
+			PolicyID:    "dormant",
+			Action:      retention.ActionDeactivate,
+			Matched:     1,
+			IdentityIDs: []uuid.UUID{dormant.ID},
+			DryRun:      true,
+		}}, report)
+	})
+
+	t.Run("case=deactivates inactive identities", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		setPolicies(conf, map[string]interface{}{"id": "dormant", "action": "deactivate", "inactive_for": "1h"})
+
+		dormant := createIdentity(t, reg, "dormant@ory.sh", time.Now().Add(-2*time.Hour))
+		recent := createIdentity(t, reg, "recent@ory.sh", time.Now())
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+
+		actual := getIdentity(t, reg, dormant.ID)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		require.NotNil(t, actual.StateChangedAt)
+		assert.EqualValues(t, 0, countSessions(t, reg, dormant.ID))
+
+		assert.Equal(t, identity.StateActive, getIdentity(t, reg, recent.ID).State)
+		assert.EqualValues(t, 1, countSessions(t, reg, recent.ID))
+
+		report, err := reg.RetentionEnforcer().Report(ctx)
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+		assert.Zero(t, report[0].Matched)
+	})
+
+	t.Run("case=notifies once per period of inactivity", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		setPolicies(conf, map[string]interface{}{"id": "notice", "action": "notify", "inactive_for": "1h"})
+
+		dormant := createIdentity(t, reg, "dormant@ory.sh", time.Now().Add(-2*time.Hour))
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+
+		messages, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{
+			Recipient: "dormant@ory.sh",
+		}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeRetentionNotice, messages[0].TemplateType)
+		assert.Contains(t, messages[0].Body, "has not been used since")
+
+		assert.Equal(t, identity.StateActive, getIdentity(t, reg, dormant.ID).State)
+		assert.EqualValues(t, 1, countSessions(t, reg, dormant.ID))
+	})
+
+	t.Run("case=anonymizes old unverified identities", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		setPolicies(conf, map[string]interface{}{"id": "unverified", "action": "anonymize", "older_than": "1h", "verified": false})
+
+		unverified := createIdentity(t, reg, "unverified@ory.sh", time.Time{})
+		setCreatedAt(t, reg, unverified, time.Now().Add(-2*time.Hour))
+
+		verified := createIdentity(t, reg, "verified@ory.sh", time.Time{})
+		setCreatedAt(t, reg, verified, time.Now().Add(-2*time.Hour))
+		address := verified.VerifiableAddresses[0]
+		address.Verified = true
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, &address, "verified"))
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+
+		actual := getIdentity(t, reg, unverified.ID)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		assert.JSONEq(t, `{}`, string(actual.Traits))
+		assert.Empty(t, actual.Credentials)
+		assert.Empty(t, actual.VerifiableAddresses)
+		assert.Empty(t, actual.RecoveryAddresses)
+
+		actual = getIdentity(t, reg, verified.ID)
+		assert.Equal(t, identity.StateActive, actual.State)
+		assert.Len(t, actual.Credentials, 1)
+	})
+
+	t.Run("case=deletes old inactive identities", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		setPolicies(conf, map[string]interface{}{"id": "purge", "action": "delete", "older_than": "1h", "state": "inactive"})
+
+		inactive := createIdentity(t, reg, "inactive@ory.sh", time.Time{})
+		setCreatedAt(t, reg, inactive, time.Now().Add(-2*time.Hour))
+		inactive.State = identity.StateInactive
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentityColumns(ctx, inactive, "state"))
+
+		active := createIdentity(t, reg, "active@ory.sh", time.Time{})
+		setCreatedAt(t, reg, active, time.Now().Add(-2*time.Hour))
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+
+		_, err := reg.PrivilegedIdentityPool().GetIdentity(ctx, inactive.ID, identity.ExpandNothing)
+		assert.ErrorIs(t, err, sqlcon.ErrNoRows())
+		_, err = reg.PrivilegedIdentityPool().GetIdentity(ctx, active.ID, identity.ExpandNothing)
+		assert.NoError(t, err)
+	})
+
+	t.Run("case=applies up to batch size identities per run", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeyIdentityRetentionBatchSize, 1)
+		setPolicies(conf, map[string]interface{}{"id": "dormant", "action": "deactivate", "inactive_for": "1h"})
+
+		createIdentity(t, reg, "dormant-1@ory.sh", time.Now().Add(-2*time.Hour))
+		createIdentity(t, reg, "dormant-2@ory.sh", time.Now().Add(-2*time.Hour))
+
+		report, err := reg.RetentionEnforcer().Report(ctx)
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+		assert.Equal(t, 2, report[0].Matched)
+		assert.Len(t, report[0].IdentityIDs, 1)
+
+		require.NoError(t, reg.RetentionEnforcer().Run(ctx))
+
+		report, err = reg.RetentionEnforcer().Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report[0].Matched)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	conf, reg := newRegistry(t)
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+	conf.MustSet(context.Background(), config.ViperKeyIdentityRetentionDryRun, true)
+	setPolicies(conf, map[string]interface{}{"id": "dormant", "action": "deactivate", "inactive_for": "1h"})
+
+	dormant := createIdentity(t, reg, "dormant@ory.sh", time.Now().Add(-2*time.Hour))
+
+	res, err := adminTS.Client().Get(adminTS.URL + "/admin" + retention.RouteReport)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+	require.True(t, json.Valid(body))
+
+	assert.Equal(t, "dormant", gjson.GetBytes(body, "0.policy_id").String())
+	assert.EqualValues(t, 1, gjson.GetBytes(body, "0.matched").Int())
+	assert.Equal(t, dormant.ID.String(), gjson.GetBytes(body, "0.identity_ids.0").String())
+	assert.True(t, gjson.GetBytes(body, "0.dry_run").Bool())
+}
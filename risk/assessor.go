@@ -0,0 +1,341 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package risk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+const (
+	// historySize is the number of previous successful sign-ins the sign-in
+	// is compared against.
+	historySize = 100
+
+	// minTravelDistance is the distance in kilometers below which sign-ins
+	// are never considered impossible travel, because geolocation by IP
+	// address is imprecise.
+	minTravelDistance = 100
+)
+
+type (
+	assessorDependencies interface {
+		config.Provider
+		logrusx.Provider
+		otelx.Provider
+		httpx.ClientProvider
+		jsonnetsecure.VMProvider
+		courier.Provider
+		courier.ConfigProvider
+		identity.PrivilegedPoolProvider
+		PersistenceProvider
+	}
+
+	// Assessor scores sign-ins and records them for scoring later sign-ins.
+	Assessor struct {
+		d         assessorDependencies
+		blocklist blocklistFile
+		cache     *ristretto.Cache[[]byte, []byte]
+	}
+	AssessorProvider interface {
+		LoginRiskAssessor() *Assessor
+	}
+)
+
+func NewAssessor(d assessorDependencies) *Assessor {
+	cache, _ := ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+		NumCounters: 1000,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+	})
+	return &Assessor{d: d, cache: cache}
+}
+
+// AssessLogin scores the first factor sign-in of the session and stores the
+// assessment on the session. Depending on the score, the identity is notified
+// and the second factor is required. If the score reaches the block
+// threshold, ErrLoginBlocked is returned.
+func (a *Assessor) AssessLogin(ctx context.Context, r *http.Request, s *session.Session) (err error) {
+	if !a.d.Config().SelfServiceFlowLoginRiskEnabled(ctx) {
+		return nil
+	}
+
+	ctx, span := a.d.Tracer(ctx).Tracer().Start(ctx, "risk.Assessor.AssessLogin")
+	defer otelx.End(span, &err)
+
+	conf, err := a.d.Config().SelfServiceFlowLoginRisk(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	attempt := NewLoginAttempt(r)
+	attempt.IdentityID = uuid.NullUUID{UUID: s.IdentityID, Valid: true}
+
+	signals, err := a.signals(ctx, conf, attempt, now)
+	if err != nil {
+		return err
+	}
+
+	in := &Input{
+		IdentityID:   s.IdentityID,
+		IPAddress:    attempt.IPAddress,
+		UserAgent:    stringOrEmpty(attempt.UserAgent),
+		Country:      stringOrEmpty(attempt.Country),
+		Signals:      signals,
+		BuiltinScore: builtinScore(conf.Weights, signals),
+	}
+
+	score := in.BuiltinScore
+	scorer, err := NewScorer(a.d, conf.Scorer, a.cache)
+	if err != nil {
+		return err
+	}
+	if custom, err := scorer.Score(ctx, in); err != nil {
+		// A failing scorer must not lock everyone out.
+		a.d.Logger().
+			WithRequest(r).
+			WithField("identity_id", s.IdentityID).
+			WithError(err).
+			Warn("Unable to compute the login risk score, falling back to the builtin score.")
+	} else {
+		score = clampScore(custom)
+	}
+
+	action := decide(conf.Thresholds, score)
+	attempt.Succeeded = action != session.RiskActionBlock
+	attempt.RiskScore = score
+	if err := a.d.LoginRiskPersister().CreateLoginAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	s.Risk = &session.RiskAssessment{
+		Score:      score,
+		Action:     action,
+		Signals:    signals.Names(),
+		AssessedAt: now,
+	}
+
+	a.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.IdentityID).
+		WithField("risk_score", score).
+		WithField("risk_action", action).
+		WithField("risk_signals", s.Risk.Signals).
+		Debug("Assessed the login risk.")
+
+	if conf.Thresholds.Notify > 0 && score >= conf.Thresholds.Notify {
+		// Notifications are best effort and must not prevent the sign-in.
+		if err := a.notify(ctx, s, attempt, now); err != nil {
+			a.d.Logger().
+				WithRequest(r).
+				WithField("identity_id", s.IdentityID).
+				WithError(err).
+				Warn("Unable to send the login risk notification.")
+		}
+	}
+
+	if action == session.RiskActionBlock {
+		return errors.WithStack(ErrLoginBlocked())
+	}
+	return nil
+}
+
+// RecordFailedLogin records a failed sign-in from the client of the request.
+// Recording is best effort and errors are only logged.
+func (a *Assessor) RecordFailedLogin(ctx context.Context, r *http.Request) {
+	if !a.d.Config().SelfServiceFlowLoginRiskEnabled(ctx) {
+		return
+	}
+
+	if err := a.d.LoginRiskPersister().CreateLoginAttempt(ctx, NewLoginAttempt(r)); err != nil {
+		a.d.Logger().
+			WithRequest(r).
+			WithError(err).
+			Warn("Unable to record the failed login attempt.")
+	}
+}
+
+func (a *Assessor) signals(ctx context.Context, conf *config.LoginRisk, attempt *LoginAttempt, now time.Time) (signals Signals, err error) {
+	history, err := a.d.LoginRiskPersister().ListSuccessfulLoginAttempts(ctx, attempt.IdentityID.UUID, historySize)
+	if err != nil {
+		return signals, err
+	}
+
+	// Without previous sign-ins, every device and country would be new.
+	if len(history) > 0 {
+		signals.NewDevice = true
+		signals.NewCountry = attempt.Country != nil
+		knownCountries := false
+		for _, previous := range history {
+			if previous.UserAgent != nil && attempt.UserAgent != nil && *previous.UserAgent == *attempt.UserAgent {
+				signals.NewDevice = false
+			}
+			if previous.Country != nil {
+				knownCountries = true
+				if attempt.Country != nil && *previous.Country == *attempt.Country {
+					signals.NewCountry = false
+				}
+			}
+		}
+		// Do not flag the country if it was never known before.
+		signals.NewCountry = signals.NewCountry && knownCountries
+
+		signals.ImpossibleTravel = impossibleTravel(&history[0], attempt, now, conf.ImpossibleTravelSpeed)
+	}
+
+	blocklist, err := parsePrefixes(conf.IPBlocklist)
+	if err != nil {
+		return signals, err
+	}
+	if conf.IPBlocklistFile != "" {
+		fromFile, err := a.blocklist.load(conf.IPBlocklistFile)
+		if err != nil {
+			return signals, err
+		}
+		blocklist = append(blocklist[:len(blocklist):len(blocklist)], fromFile...)
+	}
+	signals.IPReputation = containsAddr(blocklist, attempt.IPAddress)
+
+	signals.RecentFailures, err = a.d.LoginRiskPersister().CountFailedLoginAttempts(ctx, attempt.IPAddress, now.Add(-conf.FailureWindow))
+	if err != nil {
+		return signals, err
+	}
+
+	return signals, nil
+}
+
+func (a *Assessor) notify(ctx context.Context, s *session.Session, attempt *LoginAttempt, now time.Time) error {
+	i, err := a.d.PrivilegedIdentityPool().GetIdentity(ctx, s.IdentityID, identity.ExpandDefault)
+	if err != nil {
+		return err
+	}
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	var location string
+	if len(s.Devices) > 0 {
+		location = stringOrEmpty(s.Devices[len(s.Devices)-1].Location)
+	}
+
+	for _, address := range i.VerifiableAddresses {
+		if address.Via != identity.AddressTypeEmail {
+			continue
+		}
+		if _, err := c.QueueEmail(ctx, email.NewLoginRiskNotice(a.d, &email.LoginRiskNoticeModel{
+			To:         address.Value,
+			Identity:   model,
+			Blocked:    s.Risk.Action == session.RiskActionBlock,
+			Score:      s.Risk.Score,
+			Signals:    s.Risk.Signals,
+			IPAddress:  attempt.IPAddress,
+			UserAgent:  stringOrEmpty(attempt.UserAgent),
+			Location:   location,
+			SignedInAt: now.Format(time.RFC3339),
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func builtinScore(w config.LoginRiskWeights, s Signals) int {
+	var score int
+	if s.NewDevice {
+		score += w.NewDevice
+	}
+	if s.NewCountry {
+		score += w.NewCountry
+	}
+	if s.ImpossibleTravel {
+		score += w.ImpossibleTravel
+	}
+	if s.IPReputation {
+		score += w.IPReputation
+	}
+	score += s.RecentFailures * w.RecentFailure
+	return clampScore(score)
+}
+
+func clampScore(score int) int {
+	return min(max(score, 0), 100)
+}
+
+// decide returns the strongest action whose threshold the score reaches. A
+// threshold of zero disables the action.
+func decide(t config.LoginRiskThresholds, score int) session.RiskAction {
+	switch {
+	case t.Block > 0 && score >= t.Block:
+		return session.RiskActionBlock
+	case t.StepUp > 0 && score >= t.StepUp:
+		return session.RiskActionStepUp
+	case t.Notify > 0 && score >= t.Notify:
+		return session.RiskActionNotify
+	default:
+		return session.RiskActionAllow
+	}
+}
+
+// impossibleTravel returns true if the distance between the previous and the
+// current sign-in can not be travelled at the maximum speed (in km/h) in the
+// time between them.
+func impossibleTravel(previous, current *LoginAttempt, now time.Time, maxSpeed float64) bool {
+	if previous.Latitude == nil || previous.Longitude == nil || current.Latitude == nil || current.Longitude == nil {
+		return false
+	}
+
+	distance := haversine(*previous.Latitude, *previous.Longitude, *current.Latitude, *current.Longitude)
+	if distance < minTravelDistance {
+		return false
+	}
+
+	hours := now.Sub(previous.CreatedAt).Hours()
+	if hours <= 0 {
+		return true
+	}
+	return distance/hours > maxSpeed
+}
+
+// haversine returns the great-circle distance in kilometers.
+func haversine(lat1, long1, lat2, long2 float64) float64 {
+	const earthRadius = 6371
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat, dLong := rad(lat2-lat1), rad(long2-long1)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Pow(math.Sin(dLong/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
@@ -0,0 +1,103 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package risk
+
+import (
+	"bufio"
+	"bytes"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// parsePrefix parses an IP address or CIDR range.
+func parsePrefix(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		p, err := netip.ParsePrefix(entry)
+		return p.Masked(), errors.WithStack(err)
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, errors.WithStack(err)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func parsePrefixes(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		p, err := parsePrefix(entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid IP blocklist entry %q", entry)
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes, nil
+}
+
+// blocklistFile caches the parsed IP blocklist file until it changes.
+type blocklistFile struct {
+	sync.Mutex
+	path     string
+	modTime  time.Time
+	prefixes []netip.Prefix
+}
+
+func (f *blocklistFile) load(path string) ([]netip.Prefix, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.path == path && f.modTime.Equal(info.ModTime()) {
+		return f.prefixes, nil
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 -- The path is configured by the operator.
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		entries = append(entries, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	prefixes, err := parsePrefixes(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	f.path, f.modTime, f.prefixes = path, info.ModTime(), prefixes
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package risk scores sign-ins to require the second factor, notify the user,
+// or block the sign-in depending on how unusual the sign-in is.
+package risk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/httpx"
+)
+
+// The signals which contribute to the risk score.
+const (
+	SignalNewDevice        = "new_device"
+	SignalNewCountry       = "new_country"
+	SignalImpossibleTravel = "impossible_travel"
+	SignalIPReputation     = "ip_reputation"
+	SignalRecentFailures   = "recent_failures"
+)
+
+func ErrLoginBlocked() *herodot.DefaultError {
+	return herodot.ErrForbidden().WithID(text.ErrIDLoginBlocked).WithError("login blocked").WithReason("This sign-in was blocked because it looks unusual.")
+}
+
+// LoginAttempt records a sign-in for comparing later sign-ins against it.
+type LoginAttempt struct {
+	ID  uuid.UUID `db:"id"`
+	NID uuid.UUID `db:"nid"`
+
+	// IdentityID is nil for failed attempts, where the identity might not
+	// be known.
+	IdentityID uuid.NullUUID `db:"identity_id"`
+	Succeeded  bool          `db:"succeeded"`
+	IPAddress  string        `db:"ip_address"`
+	UserAgent  *string       `db:"user_agent"`
+	Country    *string       `db:"country"`
+	Latitude   *float64      `db:"latitude"`
+	Longitude  *float64      `db:"longitude"`
+	RiskScore  int           `db:"risk_score"`
+	CreatedAt  time.Time     `db:"created_at"`
+	UpdatedAt  time.Time     `db:"updated_at"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "identity_login_attempts"
+}
+
+// NewLoginAttempt returns a login attempt with the client information of the
+// request.
+func NewLoginAttempt(r *http.Request) *LoginAttempt {
+	a := &LoginAttempt{IPAddress: httpx.ClientIP(r)}
+	if host, _, err := net.SplitHostPort(a.IPAddress); err == nil {
+		a.IPAddress = host
+	}
+	if agent := r.Header["User-Agent"]; len(agent) > 0 {
+		a.UserAgent = new(strings.Join(agent, " "))
+	}
+	if country := r.Header.Get("Cf-Ipcountry"); country != "" {
+		a.Country = new(strings.ToUpper(country))
+	}
+
+	lat, latErr := strconv.ParseFloat(r.Header.Get("Cf-Iplatitude"), 64)
+	long, longErr := strconv.ParseFloat(r.Header.Get("Cf-Iplongitude"), 64)
+	if latErr == nil && longErr == nil {
+		a.Latitude, a.Longitude = &lat, &long
+	}
+	return a
+}
+
+// Signals are the risk signals of a sign-in.
+type Signals struct {
+	NewDevice        bool `json:"new_device"`
+	NewCountry       bool `json:"new_country"`
+	ImpossibleTravel bool `json:"impossible_travel"`
+	IPReputation     bool `json:"ip_reputation"`
+	RecentFailures   int  `json:"recent_failures"`
+}
+
+// Names returns the names of the detected signals.
+func (s Signals) Names() []string {
+	names := make([]string, 0, 5)
+	if s.NewDevice {
+		names = append(names, SignalNewDevice)
+	}
+	if s.NewCountry {
+		names = append(names, SignalNewCountry)
+	}
+	if s.ImpossibleTravel {
+		names = append(names, SignalImpossibleTravel)
+	}
+	if s.IPReputation {
+		names = append(names, SignalIPReputation)
+	}
+	if s.RecentFailures > 0 {
+		names = append(names, SignalRecentFailures)
+	}
+	return names
+}
+
+// Input is passed to the scorer.
+type Input struct {
+	IdentityID   uuid.UUID `json:"identity_id"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	Country      string    `json:"country"`
+	Signals      Signals   `json:"signals"`
+	BuiltinScore int       `json:"builtin_score"`
+}
+
+type (
+	Persister interface {
+		// CreateLoginAttempt records the login attempt.
+		CreateLoginAttempt(ctx context.Context, a *LoginAttempt) error
+
+		// ListSuccessfulLoginAttempts returns up to limit successful login
+		// attempts of the identity, most recent first.
+		ListSuccessfulLoginAttempts(ctx context.Context, identityID uuid.UUID, limit int) ([]LoginAttempt, error)
+
+		// CountFailedLoginAttempts returns the number of failed login attempts
+		// from the IP address since the given time.
+		CountFailedLoginAttempts(ctx context.Context, ipAddress string, since time.Time) (int, error)
+
+		// DeleteLoginAttemptsBefore removes up to limit login attempts created
+		// before the given time.
+		DeleteLoginAttemptsBefore(ctx context.Context, before time.Time, limit int) error
+	}
+	PersistenceProvider interface {
+		LoginRiskPersister() Persister
+	}
+)
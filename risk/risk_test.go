@@ -0,0 +1,301 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package risk_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/risk"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+type client struct {
+	ip, userAgent, country string
+	lat, long              *float64
+}
+
+var (
+	berlin  = client{ip: "198.51.100.1", userAgent: "Firefox", country: "DE", lat: new(52.52), long: new(13.405)}
+	newYork = client{ip: "198.51.100.2", userAgent: "Firefox", country: "US", lat: new(40.7128), long: new(-74.006)}
+)
+
+func (c client) request() *http.Request {
+	r := httptest.NewRequest("POST", "/self-service/login", nil)
+	r.Header.Set("True-Client-IP", c.ip)
+	r.Header.Set("User-Agent", c.userAgent)
+	r.Header.Set("Cf-Ipcountry", c.country)
+	if c.lat != nil && c.long != nil {
+		r.Header.Set("Cf-Iplatitude", strconv.FormatFloat(*c.lat, 'f', -1, 64))
+		r.Header.Set("Cf-Iplongitude", strconv.FormatFloat(*c.long, 'f', -1, 64))
+	}
+	return r
+}
+
+func newRegistry(t *testing.T) (*config.Config, *driver.RegistryDefault) {
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	ctx := context.Background()
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginRiskEnabled, true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".thresholds.notify", 20)
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".thresholds.step_up", 50)
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".thresholds.block", 90)
+	return conf, reg
+}
+
+func createIdentity(t *testing.T, reg *driver.RegistryDefault, email string) *identity.Identity {
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"` + email + `"}`)
+	require.NoError(t, reg.IdentityManager().Create(context.Background(), i))
+	return i
+}
+
+func assess(t *testing.T, reg *driver.RegistryDefault, i *identity.Identity, c client) (*session.Session, error) {
+	s := session.NewInactiveSession()
+	s.IdentityID = i.ID
+	return s, reg.LoginRiskAssessor().AssessLogin(context.Background(), c.request(), s)
+}
+
+func mustAssess(t *testing.T, reg *driver.RegistryDefault, i *identity.Identity, c client) *session.RiskAssessment {
+	s, err := assess(t, reg, i, c)
+	require.NoError(t, err)
+	require.NotNil(t, s.Risk)
+	return s.Risk
+}
+
+func listNotices(t *testing.T, reg *driver.RegistryDefault, email string) []courier.Message {
+	messages, _, err := reg.CourierPersister().ListMessages(context.Background(), courier.ListCourierMessagesParameters{
+		Recipient: email,
+	}, []keysetpagination.Option{})
+	require.NoError(t, err)
+	return messages
+}
+
+func TestAssessLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("case=does nothing if disabled", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRiskEnabled, false)
+		i := createIdentity(t, reg, "disabled@ory.sh")
+
+		s, err := assess(t, reg, i, berlin)
+		require.NoError(t, err)
+		assert.Nil(t, s.Risk)
+
+		attempts, err := reg.LoginRiskPersister().ListSuccessfulLoginAttempts(ctx, i.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, attempts)
+	})
+
+	t.Run("case=allows the first sign-in", func(t *testing.T) {
+		_, reg := newRegistry(t)
+		i := createIdentity(t, reg, "first@ory.sh")
+
+		actual := mustAssess(t, reg, i, berlin)
+		assert.Equal(t, session.RiskActionAllow, actual.Action)
+		assert.Zero(t, actual.Score)
+		assert.Empty(t, actual.Signals)
+
+		attempts, err := reg.LoginRiskPersister().ListSuccessfulLoginAttempts(ctx, i.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, attempts, 1)
+		assert.Equal(t, berlin.ip, attempts[0].IPAddress)
+		assert.Equal(t, "DE", *attempts[0].Country)
+		assert.Empty(t, listNotices(t, reg, "first@ory.sh"))
+	})
+
+	t.Run("case=notifies about a new device", func(t *testing.T) {
+		_, reg := newRegistry(t)
+		i := createIdentity(t, reg, "device@ory.sh")
+		mustAssess(t, reg, i, berlin)
+
+		c := berlin
+		c.userAgent = "Safari"
+		actual := mustAssess(t, reg, i, c)
+		assert.Equal(t, session.RiskActionNotify, actual.Action)
+		assert.Equal(t, 20, actual.Score)
+		assert.Equal(t, []string{risk.SignalNewDevice}, actual.Signals)
+
+		messages := listNotices(t, reg, "device@ory.sh")
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeLoginRiskNotice, messages[0].TemplateType)
+		assert.Equal(t, "New sign-in to your account", messages[0].Subject)
+		assert.Contains(t, messages[0].Body, "Device: Safari")
+
+		// The device is known now.
+		assert.Equal(t, session.RiskActionAllow, mustAssess(t, reg, i, c).Action)
+	})
+
+	t.Run("case=requires the second factor after impossible travel", func(t *testing.T) {
+		_, reg := newRegistry(t)
+		i := createIdentity(t, reg, "travel@ory.sh")
+		mustAssess(t, reg, i, berlin)
+
+		actual := mustAssess(t, reg, i, newYork)
+		assert.Equal(t, session.RiskActionStepUp, actual.Action)
+		assert.Equal(t, 80, actual.Score)
+		assert.ElementsMatch(t, []string{risk.SignalNewCountry, risk.SignalImpossibleTravel}, actual.Signals)
+		assert.True(t, actual.RequiresStepUp())
+	})
+
+	t.Run("case=blocks sign-ins from blocklisted addresses", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".weights.ip_reputation", 95)
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".ip_blocklist", []string{"203.0.113.0/24"})
+		i := createIdentity(t, reg, "blocked@ory.sh")
+
+		c := berlin
+		c.ip = "203.0.113.7"
+		s, err := assess(t, reg, i, c)
+		require.ErrorIs(t, err, risk.ErrLoginBlocked())
+		var herodotErr *herodot.DefaultError
+		require.ErrorAs(t, err, &herodotErr)
+		assert.Equal(t, text.ErrIDLoginBlocked, herodotErr.IDField)
+		assert.Equal(t, session.RiskActionBlock, s.Risk.Action)
+		assert.Equal(t, []string{risk.SignalIPReputation}, s.Risk.Signals)
+
+		messages := listNotices(t, reg, "blocked@ory.sh")
+		require.Len(t, messages, 1)
+		assert.Equal(t, "A sign-in to your account was blocked", messages[0].Subject)
+
+		// Blocked sign-ins are not part of the history.
+		attempts, err := reg.LoginRiskPersister().ListSuccessfulLoginAttempts(ctx, i.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, attempts)
+	})
+
+	t.Run("case=reads the blocklist file", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		file := filepath.Join(t.TempDir(), "blocklist.txt")
+		require.NoError(t, os.WriteFile(file, []byte("# known bad\n\n2001:db8::/32\n"), 0o600))
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".ip_blocklist_file", file)
+		i := createIdentity(t, reg, "file@ory.sh")
+
+		c := berlin
+		c.ip = "2001:db8::1"
+		actual := mustAssess(t, reg, i, c)
+		assert.Equal(t, session.RiskActionStepUp, actual.Action)
+		assert.Equal(t, []string{risk.SignalIPReputation}, actual.Signals)
+	})
+
+	t.Run("case=counts recent failures from the address", func(t *testing.T) {
+		_, reg := newRegistry(t)
+		i := createIdentity(t, reg, "failures@ory.sh")
+
+		for range 3 {
+			reg.LoginRiskAssessor().RecordFailedLogin(ctx, berlin.request())
+		}
+
+		actual := mustAssess(t, reg, i, berlin)
+		assert.Equal(t, session.RiskActionNotify, actual.Action)
+		assert.Equal(t, 30, actual.Score)
+		assert.Equal(t, []string{risk.SignalRecentFailures}, actual.Signals)
+
+		// Failures from other addresses do not count.
+		c := berlin
+		c.ip = "198.51.100.9"
+		assert.Equal(t, session.RiskActionAllow, mustAssess(t, reg, i, c).Action)
+	})
+
+	t.Run("case=uses the jsonnet scorer", func(t *testing.T) {
+		conf, reg := newRegistry(t)
+		snippet := `function(ctx) { score: if ctx.country == "US" then 95 else ctx.builtin_score }`
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".scorer", map[string]any{
+			"type":   "jsonnet",
+			"config": map[string]any{"url": "base64://" + base64.StdEncoding.EncodeToString([]byte(snippet))},
+		})
+		i := createIdentity(t, reg, "jsonnet@ory.sh")
+
+		assert.Equal(t, session.RiskActionAllow, mustAssess(t, reg, i, berlin).Action)
+
+		c := newYork
+		c.lat, c.long = nil, nil
+		_, err := assess(t, reg, i, c)
+		assert.ErrorIs(t, err, risk.ErrLoginBlocked())
+	})
+
+	t.Run("case=uses the web hook scorer", func(t *testing.T) {
+		var received []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, _ = io.ReadAll(r.Body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"score": 55})
+		}))
+		t.Cleanup(ts.Close)
+
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".scorer", map[string]any{
+			"type":   "web_hook",
+			"config": map[string]any{"url": ts.URL},
+		})
+		i := createIdentity(t, reg, "webhook@ory.sh")
+
+		actual := mustAssess(t, reg, i, berlin)
+		assert.Equal(t, session.RiskActionStepUp, actual.Action)
+		assert.Equal(t, 55, actual.Score)
+		assert.Equal(t, i.ID.String(), gjson.GetBytes(received, "identity_id").String())
+		assert.Equal(t, berlin.ip, gjson.GetBytes(received, "ip_address").String())
+	})
+
+	t.Run("case=falls back to the builtin score if the scorer fails", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(ts.Close)
+
+		conf, reg := newRegistry(t)
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginRisk+".scorer", map[string]any{
+			"type":   "web_hook",
+			"config": map[string]any{"url": ts.URL},
+		})
+		i := createIdentity(t, reg, "fallback@ory.sh")
+		mustAssess(t, reg, i, berlin)
+
+		actual := mustAssess(t, reg, i, newYork)
+		assert.Equal(t, session.RiskActionStepUp, actual.Action)
+		assert.Equal(t, 80, actual.Score)
+	})
+}
+
+func TestSignals(t *testing.T) {
+	assert.Empty(t, risk.Signals{}.Names())
+	assert.Equal(t,
+		[]string{risk.SignalNewDevice, risk.SignalNewCountry, risk.SignalImpossibleTravel, risk.SignalIPReputation, risk.SignalRecentFailures},
+		risk.Signals{NewDevice: true, NewCountry: true, ImpossibleTravel: true, IPReputation: true, RecentFailures: 2}.Names(),
+	)
+}
+
+func TestNewLoginAttempt(t *testing.T) {
+	r := httptest.NewRequest("POST", "/self-service/login", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("Cf-Ipcountry", "de")
+
+	a := risk.NewLoginAttempt(r)
+	assert.Equal(t, "192.0.2.1", a.IPAddress)
+	assert.Equal(t, "DE", *a.Country)
+	assert.Nil(t, a.UserAgent)
+	assert.Nil(t, a.Latitude)
+}
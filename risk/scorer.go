@@ -0,0 +1,181 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/request"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+const (
+	ScorerBuiltin = "builtin"
+	ScorerJsonnet = "jsonnet"
+	ScorerWebHook = "web_hook"
+)
+
+type (
+	// Scorer computes the risk score of a sign-in from its signals.
+	Scorer interface {
+		Score(ctx context.Context, in *Input) (int, error)
+	}
+	scorerDependencies interface {
+		logrusx.Provider
+		otelx.Provider
+		httpx.ClientProvider
+		jsonnetsecure.VMProvider
+	}
+	scorerConfig struct {
+		URL string `json:"url"`
+	}
+)
+
+// NewScorer returns the configured scorer.
+func NewScorer(d scorerDependencies, c config.LoginRiskScorer, cache *ristretto.Cache[[]byte, []byte]) (Scorer, error) {
+	switch c.Type {
+	case "", ScorerBuiltin:
+		return new(builtinScorer), nil
+	case ScorerJsonnet:
+		var conf scorerConfig
+		if err := decodeScorerConfig(c.Config, &conf); err != nil {
+			return nil, err
+		}
+		return &jsonnetScorer{d: d, url: conf.URL, cache: cache}, nil
+	case ScorerWebHook:
+		var conf request.Config
+		if err := decodeScorerConfig(c.Config, &conf); err != nil {
+			return nil, err
+		}
+		if conf.Method == "" {
+			conf.Method = http.MethodPost
+		}
+		return &webHookScorer{d: d, conf: conf, cache: cache}, nil
+	default:
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unknown login risk scorer %q.", c.Type))
+	}
+}
+
+func decodeScorerConfig(raw map[string]any, dest any) error {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := json.Unmarshal(encoded, dest); err != nil {
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the login risk scorer configuration: %s", err))
+	}
+	return nil
+}
+
+// parseScore reads the score from the result of a jsonnet or web hook scorer.
+func parseScore(result []byte) (int, error) {
+	score := gjson.GetBytes(result, "score")
+	if score.Type != gjson.Number {
+		return 0, errors.Errorf("the login risk scorer must return an object with a numeric score but returned: %s", result)
+	}
+	return int(score.Int()), nil
+}
+
+// builtinScorer uses the sum of the weights of the detected signals.
+type builtinScorer struct{}
+
+func (*builtinScorer) Score(_ context.Context, in *Input) (int, error) {
+	return in.BuiltinScore, nil
+}
+
+// jsonnetScorer evaluates a Jsonnet snippet which receives the input as the
+// `ctx` top-level argument.
+type jsonnetScorer struct {
+	d     scorerDependencies
+	url   string
+	cache *ristretto.Cache[[]byte, []byte]
+}
+
+func (s *jsonnetScorer) Score(ctx context.Context, in *Input) (_ int, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "risk.jsonnetScorer.Score")
+	defer otelx.End(span, &err)
+
+	snippet, err := fetcher.NewFetcher(
+		fetcher.WithClient(s.d.HTTPClient(ctx)),
+		fetcher.WithCache(s.cache, 60*time.Minute),
+	).FetchContext(ctx, s.url)
+	if err != nil {
+		return 0, err
+	}
+
+	input, err := json.Marshal(in)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	vm, err := s.d.JsonnetVM(ctx)
+	if err != nil {
+		return 0, err
+	}
+	vm.TLACode("ctx", string(input))
+
+	result, err := vm.EvaluateAnonymousSnippet(s.url, snippet.String())
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return parseScore([]byte(result))
+}
+
+// webHookScorer sends the input to an HTTP endpoint.
+type webHookScorer struct {
+	d     scorerDependencies
+	conf  request.Config
+	cache *ristretto.Cache[[]byte, []byte]
+}
+
+func (s *webHookScorer) Score(ctx context.Context, in *Input) (_ int, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "risk.webHookScorer.Score")
+	defer otelx.End(span, &err)
+
+	conf := s.conf
+	builder, err := request.NewBuilder(ctx, &conf, s.d, request.WithCache(s.cache))
+	if err != nil {
+		return 0, err
+	}
+
+	var req *retryablehttp.Request
+	if conf.TemplateURI != "" {
+		req, err = builder.BuildRequest(ctx, in)
+	} else {
+		req, err = builder.BuildRawRequest(in)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.d.HTTPClient(ctx).Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("the login risk scorer responded with status code %d", res.StatusCode)
+	}
+	return parseScore(body)
+}
@@ -64,6 +64,17 @@ func NewDeviceAuthnVerifierWrongError(instancePtr string) error {
 	})
 }
 
+func NewDuplicateTraitError(instancePtr, property string) error {
+	t := text.NewErrorValidationDuplicateTrait(property)
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
 func NewLookupAlreadyUsed() error {
 	t := text.NewErrorValidationLookupAlreadyUsed()
 	return errors.WithStack(&ValidationError{
@@ -400,3 +411,33 @@ func NewUnknownAddressError() error {
 	},
 	)
 }
+
+func NewCrossDeviceLoginPending() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the sign in has not yet been approved on the other device`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginCrossDevicePending()),
+	})
+}
+
+func NewCrossDeviceLoginDenied() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the sign in was denied on the other device`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginCrossDeviceDenied()),
+	})
+}
+
+func NewCrossDeviceLoginExpired() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the cross-device challenge has expired`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginCrossDeviceExpired()),
+	})
+}
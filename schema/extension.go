@@ -48,6 +48,16 @@ type (
 		Organization struct {
 			Matcher string `json:"matcher"`
 		} `json:"organizations"`
+		Privacy struct {
+			Encrypt bool `json:"encrypt"`
+			PII     bool `json:"pii"`
+		} `json:"privacy"`
+		Unique struct {
+			Scope string `json:"scope"`
+		} `json:"unique"`
+		Profile struct {
+			AskOnce bool `json:"ask_once"`
+		} `json:"profile"`
 		RawSchema map[string]interface{} `json:"-"`
 	}
 
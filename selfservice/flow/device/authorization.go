@@ -0,0 +1,151 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
+)
+
+// AuthorizationState is the state of a device authorization.
+type AuthorizationState string
+
+const (
+	// AuthorizationStatePending is the state of a device authorization until
+	// a user approves or denies it.
+	AuthorizationStatePending AuthorizationState = "pending"
+	// AuthorizationStateApproved is the state of a device authorization
+	// which a user approved but for which the device has not yet received
+	// a session.
+	AuthorizationStateApproved AuthorizationState = "approved"
+	// AuthorizationStateDenied is the state of a device authorization which
+	// a user denied.
+	AuthorizationStateDenied AuthorizationState = "denied"
+	// AuthorizationStateConsumed is the state of a device authorization for
+	// which the device received a session.
+	AuthorizationStateConsumed AuthorizationState = "consumed"
+)
+
+// userCodeAlphabet contains only upper-case consonants so that user codes are
+// easy to type on a TV remote and can not spell words, as recommended by
+// RFC 8628, section 6.1.
+var userCodeAlphabet = []rune("BCDFGHJKLMNPQRSTVWXZ")
+
+const (
+	userCodeLength   = 8
+	deviceCodeLength = 64
+)
+
+// Authorization is a device's request to sign in. The device polls for a
+// session using the device code, while the user approves the request in their
+// browser by entering the user code.
+//
+// Only HMACs of the codes are stored.
+type Authorization struct {
+	ID  uuid.UUID `db:"id"`
+	NID uuid.UUID `db:"nid"`
+
+	DeviceCodeHMAC string `db:"device_code_hmac"`
+	UserCodeHMAC   string `db:"user_code_hmac"`
+
+	State AuthorizationState `db:"state"`
+
+	// IdentityID is the identity which approved or denied the authorization.
+	IdentityID uuid.NullUUID `db:"identity_id"`
+
+	// SessionID is the session which was issued to the device.
+	SessionID uuid.NullUUID `db:"session_id"`
+
+	ExpiresAt    time.Time      `db:"expires_at"`
+	LastPolledAt sqlxx.NullTime `db:"last_polled_at"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (Authorization) TableName() string { return "selfservice_device_authorizations" }
+
+// IsExpired returns true if the device and user codes can no longer be used.
+func (a *Authorization) IsExpired() bool {
+	return a.ExpiresAt.Before(time.Now().UTC())
+}
+
+// NewCodes returns a new device code and a new user code. The user code is
+// formatted as XXXX-XXXX.
+func NewCodes() (deviceCode, userCode string) {
+	userCode = randx.MustString(userCodeLength, userCodeAlphabet)
+	return randx.MustString(deviceCodeLength, randx.AlphaNum), userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
+
+// NormalizeUserCode removes the separator, whitespace and casing users might
+// add when typing the user code.
+func NormalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '-' || r == ' ':
+			return -1
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return r
+	}, strings.TrimSpace(userCode))
+}
+
+type (
+	AuthorizationPersister interface {
+		// CreateDeviceAuthorization stores the authorization with the HMACs of
+		// the device and user codes.
+		CreateDeviceAuthorization(ctx context.Context, a *Authorization, deviceCode, userCode string) error
+
+		// GetDeviceAuthorizationByUserCode returns the authorization for the
+		// user code, ignoring separators and casing.
+		GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*Authorization, error)
+
+		// GetDeviceAuthorizationByDeviceCode returns the authorization for the
+		// device code.
+		GetDeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (*Authorization, error)
+
+		// DecideDeviceAuthorization approves or denies a pending authorization
+		// which has not expired. It returns sqlcon.ErrNoRows if the
+		// authorization is no longer pending.
+		DecideDeviceAuthorization(ctx context.Context, id, identityID uuid.UUID, state AuthorizationState) error
+
+		// PollDeviceAuthorization records the time the device polled for a
+		// session.
+		PollDeviceAuthorization(ctx context.Context, id uuid.UUID, at time.Time) error
+
+		// ConsumeDeviceAuthorization marks an approved authorization as
+		// consumed by the session. It returns sqlcon.ErrNoRows if the
+		// authorization is not approved, which ensures that each authorization
+		// issues at most one session.
+		ConsumeDeviceAuthorization(ctx context.Context, id, sessionID uuid.UUID) error
+
+		DeleteExpiredDeviceAuthorizations(ctx context.Context, expiresAt time.Time, limit int) error
+	}
+
+	FlowPersister interface {
+		CreateDeviceFlow(ctx context.Context, f *Flow) error
+		GetDeviceFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
+		UpdateDeviceFlow(ctx context.Context, f *Flow) error
+		DeleteExpiredDeviceFlows(ctx context.Context, expiresAt time.Time, limit int) error
+	}
+
+	Persister interface {
+		AuthorizationPersister
+		FlowPersister
+	}
+
+	PersistenceProvider interface {
+		DeviceFlowPersister() Persister
+	}
+)
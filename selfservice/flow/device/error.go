@@ -0,0 +1,36 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/text"
+)
+
+func ErrDeviceFlowDisabled() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDSelfServiceFlowDisabled).WithError("device flow disabled").WithReason("Signing in devices is not allowed because it was disabled.")
+}
+
+// The following errors are returned to devices polling for a session and
+// correspond to the error codes of RFC 8628, section 3.5.
+
+func ErrAuthorizationPending() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDDeviceAuthorizationPending).WithError("authorization pending").WithReason("The user has not yet approved the device. Poll again after the interval.")
+}
+
+func ErrSlowDown() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDDeviceSlowDown).WithError("slow down").WithReason("The device is polling too often. Wait for the interval between polling requests.")
+}
+
+func ErrAccessDenied() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDDeviceAccessDenied).WithError("access denied").WithReason("The user denied the sign in of the device.")
+}
+
+func ErrExpiredToken() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDDeviceExpiredToken).WithError("expired token").WithReason("The device code has expired. Initialize a new device authorization.")
+}
+
+func ErrInvalidDeviceCode() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithError("invalid device code").WithReason("The device code is invalid or has already been used.")
+}
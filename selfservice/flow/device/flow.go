@@ -0,0 +1,127 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	// ActionApprove approves the device's sign in.
+	ActionApprove = "approve"
+	// ActionDeny denies the device's sign in.
+	ActionDeny = "deny"
+)
+
+// A Device Flow
+//
+// This flow is used when a signed in user approves the sign in of a device,
+// such as a CLI or a smart TV, by entering the user code shown on the device.
+//
+// swagger:model deviceFlow
+type Flow struct {
+	// ID represents the flow's unique ID. When performing the device flow, this
+	// represents the id in the device ui's query parameter: http://<selfservice.flows.device.ui_url>?flow=<id>
+	//
+	// type: string
+	// format: uuid
+	// required: true
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// ExpiresAt is the time (UTC) when the flow expires. If the user still wishes to approve the device,
+	// a new flow has to be initiated.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// IssuedAt is the time (UTC) when the flow occurred.
+	//
+	// required: true
+	IssuedAt time.Time `json:"issued_at" faker:"time_type" db:"issued_at"`
+
+	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used
+	// to forward information contained in the URL's path or query for example.
+	//
+	// required: true
+	RequestURL string `json:"request_url" db:"request_url"`
+
+	// UI contains data which must be shown in the user interface.
+	//
+	// required: true
+	UI *container.Container `json:"ui" db:"ui"`
+
+	// State represents the state of this flow:
+	//
+	// - choose_method: ask the user to enter the user code and to approve or deny the device
+	// - passed_challenge: the user approved or denied the device
+	//
+	// required: true
+	State flow.State `json:"state" faker:"-" db:"state"`
+
+	// IdentityID is the identity which initiated the flow.
+	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
+
+	// CSRFToken contains the anti-csrf token associated with this flow.
+	CSRFToken string `json:"-" db:"csrf_token"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+}
+
+// NewFlow returns a flow in which the identity enters the user code, which
+// may be pre-filled from the `user_code` query parameter.
+func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Request, identityID uuid.UUID) *Flow {
+	now := time.Now().UTC()
+	id := x.NewUUID()
+
+	f := &Flow{
+		ID:         id,
+		ExpiresAt:  now.Add(exp),
+		IssuedAt:   now,
+		RequestURL: x.RequestURL(r).String(),
+		UI: &container.Container{
+			Method: "POST",
+			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
+		},
+		State:      flow.StateChooseMethod,
+		IdentityID: identityID,
+		CSRFToken:  csrf,
+	}
+
+	f.UI.SetCSRF(csrf)
+	f.UI.Messages.Set(text.NewInfoSelfServiceDevice())
+	f.UI.GetNodes().Append(node.NewInputField(node.DeviceUserCode, r.URL.Query().Get("user_code"), node.DefaultGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoNodeLabelDeviceUserCode()))
+	f.UI.GetNodes().Append(node.NewInputField(node.DeviceAction, ActionApprove, node.DefaultGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelDeviceApprove()))
+	f.UI.GetNodes().Append(node.NewInputField(node.DeviceAction, ActionDeny, node.DefaultGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelDeviceDeny()))
+
+	return f
+}
+
+func (Flow) TableName() string { return "selfservice_device_flows" }
+
+func (f *Flow) AppendTo(src *url.URL) *url.URL {
+	return flow.AppendFlowTo(src, f.ID)
+}
+
+func (f *Flow) IsExpired() bool {
+	return f.ExpiresAt.Before(time.Now().UTC())
+}
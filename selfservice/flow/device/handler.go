@@ -0,0 +1,721 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device
+
+import (
+	_ "embed"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteInitAPIFlow     = "/self-service/device/api"
+	RouteInitBrowserFlow = "/self-service/device/browser"
+	RouteGetFlow         = "/self-service/device/flows"
+	RouteToken           = "/self-service/device/token"
+
+	RouteSubmitFlow = "/self-service/device"
+)
+
+//go:embed .schema/update.schema.json
+var updateSchema []byte
+
+//go:embed .schema/token.schema.json
+var tokenSchema []byte
+
+type (
+	handlerDependencies interface {
+		nosurfx.CSRFProvider
+		nosurfx.CSRFTokenGeneratorProvider
+		httpx.WriterProvider
+		otelx.Provider
+
+		config.Provider
+
+		session.HandlerProvider
+		session.ManagementProvider
+
+		identity.PoolProvider
+
+		login.HookExecutorProvider
+		login.FlowPersistenceProvider
+
+		errorx.ManagementProvider
+
+		PersistenceProvider
+	}
+	HandlerProvider interface {
+		DeviceFlowHandler() *Handler
+	}
+	Handler struct {
+		d handlerDependencies
+	}
+)
+
+func NewHandler(d handlerDependencies) *Handler {
+	return &Handler{d: d}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.d.CSRFHandler().IgnorePath(RouteInitAPIFlow)
+	h.d.CSRFHandler().IgnorePath(RouteToken)
+	h.d.CSRFHandler().IgnorePath(RouteSubmitFlow)
+
+	public.POST(RouteInitAPIFlow, h.onlyIfEnabledAPI(h.createDeviceAuthorization))
+	public.POST(RouteToken, h.onlyIfEnabledAPI(h.exchangeDeviceCode))
+
+	public.GET(RouteInitBrowserFlow, h.onlyIfEnabled(h.d.SessionHandler().IsAuthenticated(h.createBrowserDeviceFlow, h.redirectToLogin)))
+	public.GET(RouteGetFlow, h.onlyIfEnabled(h.d.SessionHandler().IsAuthenticated(h.getDeviceFlow, nil)))
+	public.POST(RouteSubmitFlow, h.onlyIfEnabled(h.d.SessionHandler().IsAuthenticated(h.updateDeviceFlow, nil)))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.POST(RouteInitAPIFlow, redir.RedirectToPublicRoute(h.d))
+	admin.POST(RouteToken, redir.RedirectToPublicRoute(h.d))
+	admin.GET(RouteInitBrowserFlow, redir.RedirectToPublicRoute(h.d))
+	admin.GET(RouteGetFlow, redir.RedirectToPublicRoute(h.d))
+	admin.POST(RouteSubmitFlow, redir.RedirectToPublicRoute(h.d))
+}
+
+func (h *Handler) onlyIfEnabled(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.d.Config().SelfServiceFlowDeviceEnabled(r.Context()) {
+			h.d.SelfServiceErrorManager().Forward(r.Context(), w, r, errors.WithStack(ErrDeviceFlowDisabled()))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// onlyIfEnabledAPI is like onlyIfEnabled but always responds with JSON, as
+// devices do not have a browser to show the error UI.
+func (h *Handler) onlyIfEnabledAPI(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.d.Config().SelfServiceFlowDeviceEnabled(r.Context()) {
+			h.d.Writer().WriteError(w, r, errors.WithStack(ErrDeviceFlowDisabled()))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// redirectToLogin sends users without a session to the login flow and back to
+// the device flow once they signed in.
+func (h *Handler) redirectToLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if x.IsJSONRequest(r) {
+		h.d.Writer().WriteError(w, r, session.NewErrNoActiveSessionFound())
+		return
+	}
+
+	returnTo := urlx.CopyWithQuery(urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), RouteInitBrowserFlow), r.URL.Query())
+	http.Redirect(w, r, urlx.CopyWithQuery(
+		urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), login.RouteInitBrowserFlow),
+		url.Values{"return_to": {returnTo.String()}},
+	).String(), http.StatusSeeOther)
+}
+
+func (h *Handler) decode(r *http.Request, dest any, schema []byte) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(schema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+// Device Authorization
+//
+// swagger:model deviceAuthorization
+type AuthorizationResponse struct {
+	// DeviceCode is used by the device to poll for a session. It must be kept
+	// secret.
+	//
+	// required: true
+	DeviceCode string `json:"device_code"`
+
+	// UserCode is shown to the user, who enters it on the verification page.
+	//
+	// required: true
+	UserCode string `json:"user_code"`
+
+	// VerificationURI is the page the user opens in their browser to approve
+	// the device.
+	//
+	// required: true
+	VerificationURI string `json:"verification_uri"`
+
+	// VerificationURIComplete is the verification page with the user code
+	// already filled in. It can, for example, be shown as a QR code.
+	//
+	// required: true
+	VerificationURIComplete string `json:"verification_uri_complete"`
+
+	// ExpiresIn is the number of seconds until the device and user codes
+	// expire.
+	//
+	// required: true
+	ExpiresIn int64 `json:"expires_in"`
+
+	// Interval is the number of seconds the device must wait between polling
+	// requests.
+	//
+	// required: true
+	Interval int64 `json:"interval"`
+}
+
+// swagger:route POST /self-service/device/api frontend createDeviceAuthorization
+//
+// # Create Device Authorization
+//
+// This endpoint is used by devices without a browser, such as CLIs and smart TVs, to sign in. It returns
+// a device code and a user code. The device shows the user code and the verification URI to the user, who
+// opens the URI in their browser, signs in, and approves the device. In the meantime, the device polls
+// the `/self-service/device/token` endpoint with the device code until it receives a session token.
+//
+// The flow is modeled on the OAuth 2.0 Device Authorization Grant (RFC 8628).
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceAuthorization
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) createDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	lifespan := h.d.Config().SelfServiceFlowDeviceLifespan(ctx)
+
+	deviceCode, userCode := NewCodes()
+	a := &Authorization{
+		State:     AuthorizationStatePending,
+		ExpiresAt: time.Now().UTC().Add(lifespan),
+	}
+	if err := h.d.DeviceFlowPersister().CreateDeviceAuthorization(ctx, a, deviceCode, userCode); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	verificationURI := urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), RouteInitBrowserFlow)
+	h.d.Writer().Write(w, r, &AuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI.String(),
+		VerificationURIComplete: urlx.CopyWithQuery(verificationURI, url.Values{"user_code": {userCode}}).String(),
+		ExpiresIn:               int64(lifespan.Seconds()),
+		Interval:                int64(h.d.Config().SelfServiceFlowDeviceInterval(ctx).Seconds()),
+	})
+}
+
+// Exchange Device Code Parameters
+//
+// swagger:parameters exchangeDeviceCode
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type exchangeDeviceCode struct {
+	// in: body
+	// required: true
+	Body exchangeDeviceCodeBody
+}
+
+// Exchange Device Code Request Body
+//
+// swagger:model exchangeDeviceCodeBody
+type exchangeDeviceCodeBody struct {
+	// DeviceCode is the device code returned when the device authorization
+	// was created.
+	//
+	// required: true
+	DeviceCode string `json:"device_code"`
+}
+
+// swagger:route POST /self-service/device/token frontend exchangeDeviceCode
+//
+// # Exchange Device Code for a Session Token
+//
+// Devices poll this endpoint with their device code until the user approved or denied the device. Until then,
+// this endpoint responds with HTTP 400 and one of the following `error.id` values:
+//
+//   - `authorization_pending`: The user has not yet approved the device. Poll again after the interval.
+//   - `slow_down`: The device polls more often than the interval allows. Wait longer between requests.
+//   - `access_denied`: The user denied the device. Stop polling.
+//   - `expired_token`: The device code expired. Stop polling and create a new device authorization.
+//
+// Once the user approved the device, this endpoint runs the login hooks and responds with a session token
+// like the API login flow. Each device code can be exchanged for a session only once.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: successfulNativeLogin
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-medium
+func (h *Handler) exchangeDeviceCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body exchangeDeviceCodeBody
+	if err := h.decode(r, &body, tokenSchema); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	a, err := h.d.DeviceFlowPersister().GetDeviceAuthorizationByDeviceCode(ctx, body.DeviceCode)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrInvalidDeviceCode()))
+		return
+	} else if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case a.State == AuthorizationStateConsumed:
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrInvalidDeviceCode()))
+		return
+	case a.IsExpired():
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrExpiredToken()))
+		return
+	case a.State == AuthorizationStateDenied:
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrAccessDenied()))
+		return
+	case a.State == AuthorizationStatePending:
+		if !a.LastPolledAt.IsZero() && now.Before(time.Time(a.LastPolledAt).Add(h.d.Config().SelfServiceFlowDeviceInterval(ctx))) {
+			h.d.Writer().WriteError(w, r, errors.WithStack(ErrSlowDown()))
+			return
+		}
+		if err := h.d.DeviceFlowPersister().PollDeviceAuthorization(ctx, a.ID, now); err != nil {
+			h.d.Writer().WriteError(w, r, err)
+			return
+		}
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrAuthorizationPending()))
+		return
+	}
+
+	i, err := h.d.IdentityPool().GetIdentity(ctx, a.IdentityID.UUID, identity.ExpandDefault)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// The session is issued by an API login flow, so that the login hooks run
+	// for devices like for any other sign in.
+	f, err := login.NewFlow(h.d.Config(), h.d.Config().SelfServiceFlowLoginRequestLifespan(ctx), "", r, flow.TypeAPI)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+	f.Active = identity.CredentialsTypeDeviceAuthorization
+	f.State = flow.StatePassedChallenge
+	if err := h.d.LoginFlowPersister().CreateLoginFlow(ctx, f); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s := session.NewInactiveSession()
+	s.ID = x.NewUUID()
+	s.CompletedLoginFor(identity.CredentialsTypeDeviceAuthorization, identity.AuthenticatorAssuranceLevel1)
+
+	// Consuming the authorization before issuing the session ensures that
+	// concurrent polling requests can not obtain more than one session.
+	if err := h.d.DeviceFlowPersister().ConsumeDeviceAuthorization(ctx, a.ID, s.ID); errors.Is(err, sqlcon.ErrNoRows()) {
+		h.d.Writer().WriteError(w, r, errors.WithStack(ErrInvalidDeviceCode()))
+		return
+	} else if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.d.LoginHookExecutor().PostLoginHook(w, r, node.DefaultGroup, f, i, s, ""); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+}
+
+// Create Browser Device Flow Parameters
+//
+// swagger:parameters createBrowserDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createBrowserDeviceFlow struct {
+	// The user code shown on the device. If set, it is filled in for the user.
+	//
+	// in: query
+	UserCode string `json:"user_code"`
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/device/browser frontend createBrowserDeviceFlow
+//
+// # Create Device Flow for Browsers
+//
+// This endpoint initializes a browser-based flow in which a signed in user approves a device by entering
+// the user code shown on the device. Once initialized, the browser will be redirected to
+// `selfservice.flows.device.ui_url` with the flow ID set as the query parameter `?flow=`. If no valid
+// Ory Kratos Session Cookie is included in the request, the browser will be redirected to the login
+// endpoint and returns to this endpoint after signing in.
+//
+// If this endpoint is called via an AJAX request, the response contains the flow without any redirects
+// or a 401 error if no valid session was set.
+//
+// This endpoint is NOT INTENDED for clients that do not have a browser (Chrome, Firefox, ...) as cookies are needed.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-medium
+func (h *Handler) createBrowserDeviceFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	s, err := h.d.SessionManager().FetchFromRequestContext(ctx, r)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f := NewFlow(h.d.Config(), h.d.Config().SelfServiceFlowDeviceLifespan(ctx), h.d.GenerateCSRFToken(r), r, s.IdentityID)
+	if err := h.d.DeviceFlowPersister().CreateDeviceFlow(ctx, f); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowCompletedAsRedirectOrJSON(w, r, h.d.Writer(), f, f.AppendTo(h.d.Config().SelfServiceFlowDeviceUI(ctx)).String())
+}
+
+// fetchFlow returns the flow if it belongs to the identity of the session.
+func (h *Handler) fetchFlow(r *http.Request, id uuid.UUID) (*Flow, *session.Session, error) {
+	ctx := r.Context()
+	f, err := h.d.DeviceFlowPersister().GetDeviceFlow(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := h.d.SessionManager().FetchFromRequestContext(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f.IdentityID != s.IdentityID {
+		return nil, nil, errors.WithStack(herodot.ErrForbidden().
+			WithID(text.ErrIDInitiatedBySomeoneElse).
+			WithReasonf("The request was made for another identity and has been blocked for security reasons."))
+	}
+
+	return f, s, nil
+}
+
+// Get Device Flow Parameters
+//
+// swagger:parameters getDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getDeviceFlow struct {
+	// The Device Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/device?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	ID string `json:"id"`
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/device/flows frontend getDeviceFlow
+//
+// # Get Device Flow
+//
+// This endpoint returns a device flow's context with, for example, error details and other information.
+// The flow can only be fetched by the identity which initiated it.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-high
+func (h *Handler) getDeviceFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, _, err := h.fetchFlow(r, x.ParseUUID(r.URL.Query().Get("id")))
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if f.IsExpired() {
+		h.d.Writer().WriteError(w, r, errors.WithStack(nosurfx.ErrGone().
+			WithReason("The device flow has expired. Redirect the user to the device flow init endpoint to initialize a new device flow.").
+			WithDetail("redirect_to", urlx.AppendPaths(h.d.Config().SelfPublicURL(ctx), RouteInitBrowserFlow).String())))
+		return
+	}
+
+	h.d.Writer().Write(w, r, f)
+}
+
+// Update Device Flow Parameters
+//
+// swagger:parameters updateDeviceFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateDeviceFlow struct {
+	// The Device Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/device?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	// required: true
+	Body updateDeviceFlowBody
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF and session cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// Update Device Flow Request Body
+//
+// swagger:model updateDeviceFlowBody
+type updateDeviceFlowBody struct {
+	// The user code shown on the device.
+	//
+	// required: true
+	UserCode string `json:"user_code"`
+
+	// Action is either `approve` or `deny`.
+	//
+	// required: true
+	Action string `json:"action"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+}
+
+// swagger:route POST /self-service/device frontend updateDeviceFlow
+//
+// # Approve or Deny a Device
+//
+// Use this endpoint to approve or deny the sign in of the device showing the user code. Approving the
+// device allows it to exchange its device code for a session of the signed in identity.
+//
+// Browser flows without HTTP Header `Accept` or with `Accept: text/*` respond with a HTTP 303 redirect
+// to the Device UI URL with the flow ID containing the success or validation messages.
+//
+// Browser flows with HTTP Header `Accept: application/json` respond with
+//
+//   - HTTP 200 and the flow on success;
+//   - HTTP 400 and the flow if the user code is invalid or has expired;
+//   - HTTP 401 when the endpoint is called without a valid session cookie;
+//   - HTTP 403 when the flow was initiated by another identity or a CSRF violation occurred;
+//   - HTTP 410 if the flow expired.
+//
+// A decision can only be made once per user code.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: deviceFlow
+//	  303: emptyResponse
+//	  400: deviceFlow
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) updateDeviceFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := flow.GetFlowID(r)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f, s, err := h.fetchFlow(r, id)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if err := session.ForbidImpersonation(ctx, s, "device"); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if f.IsExpired() {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(flow.NewFlowExpiredError(f.ExpiresAt)))
+		return
+	}
+
+	if f.State == flow.StatePassedChallenge {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest().
+			WithReason("The device flow was already completed. Initialize a new device flow to approve another device.")))
+		return
+	}
+
+	var body updateDeviceFlowBody
+	if err := h.decode(r, &body, updateSchema); err != nil {
+		h.writeFlowError(w, r, f, err)
+		return
+	}
+
+	if err := flow.EnsureCSRF(h.d, r, flow.TypeBrowser, h.d.Config().DisableAPIFlowEnforcement(ctx), h.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f.UI.ResetMessages()
+	f.UI.Nodes.SetValueAttribute(node.DeviceUserCode, body.UserCode)
+
+	decision := AuthorizationStateDenied
+	if body.Action == ActionApprove {
+		decision = AuthorizationStateApproved
+	}
+
+	a, err := h.d.DeviceFlowPersister().GetDeviceAuthorizationByUserCode(ctx, body.UserCode)
+	if err == nil {
+		if a.State != AuthorizationStatePending || a.IsExpired() {
+			err = errors.WithStack(sqlcon.ErrNoRows())
+		} else {
+			err = h.d.DeviceFlowPersister().DecideDeviceAuthorization(ctx, a.ID, s.IdentityID, decision)
+		}
+	}
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		f.UI.AddMessage(node.DefaultGroup, text.NewErrorValidationDeviceUserCodeInvalid(), node.DeviceUserCode)
+		h.writeFlowError(w, r, f, nil)
+		return
+	} else if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f.State = flow.StatePassedChallenge
+	if decision == AuthorizationStateApproved {
+		f.UI.Messages.Set(text.NewInfoSelfServiceDeviceApproved())
+		trace.SpanFromContext(ctx).AddEvent(events.NewDeviceAuthorizationApproved(ctx, f.ID, s.IdentityID))
+	} else {
+		f.UI.Messages.Set(text.NewInfoSelfServiceDeviceDenied())
+		trace.SpanFromContext(ctx).AddEvent(events.NewDeviceAuthorizationDenied(ctx, f.ID, s.IdentityID))
+	}
+
+	if err := h.d.DeviceFlowPersister().UpdateDeviceFlow(ctx, f); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowCompletedAsRedirectOrJSON(w, r, h.d.Writer(), f, f.AppendTo(h.d.Config().SelfServiceFlowDeviceUI(ctx)).String())
+}
+
+// writeFlowError shows the validation error in the flow's UI. Errors which
+// can not be shown in the UI are forwarded to the error handler.
+func (h *Handler) writeFlowError(w http.ResponseWriter, r *http.Request, f *Flow, err error) {
+	ctx := r.Context()
+	if err != nil {
+		if err := f.UI.ParseError(node.DefaultGroup, err); err != nil {
+			h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+			return
+		}
+	}
+
+	if err := h.d.DeviceFlowPersister().UpdateDeviceFlow(ctx, f); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowErrorAsRedirectOrJSON(w, r, h.d.Writer(), f, f.AppendTo(h.d.Config().SelfServiceFlowDeviceUI(ctx)).String())
+}
@@ -0,0 +1,194 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package device_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/device"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/configx"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValue(config.ViperKeySelfServiceDeviceEnabled, true),
+	)
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	i := identity.NewIdentity("")
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+	browser := testhelpers.NewHTTPClientWithIdentitySessionCookie(ctx, t, reg, i)
+
+	postJSON := func(t *testing.T, c *http.Client, url string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	authorize := func(t *testing.T) gjson.Result {
+		return postJSON(t, publicTS.Client(), publicTS.URL+device.RouteInitAPIFlow, struct{}{}, http.StatusOK)
+	}
+
+	poll := func(t *testing.T, deviceCode string, expectCode int) gjson.Result {
+		return postJSON(t, publicTS.Client(), publicTS.URL+device.RouteToken, map[string]string{"device_code": deviceCode}, expectCode)
+	}
+
+	initFlow := func(t *testing.T, c *http.Client, userCode string) gjson.Result {
+		res, body := testhelpers.EasyGetJSON(t, c, publicTS.URL+device.RouteInitBrowserFlow+"?"+url.Values{"user_code": {userCode}}.Encode())
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	submit := func(t *testing.T, f gjson.Result, userCode, action string, expectCode int) gjson.Result {
+		return postJSON(t, browser, f.Get("ui.action").String(), map[string]string{
+			"csrf_token": f.Get("ui.nodes.#(attributes.name==csrf_token).attributes.value").String(),
+			"user_code":  userCode,
+			"action":     action,
+		}, expectCode)
+	}
+
+	t.Run("case=approves a device and issues a session once", func(t *testing.T) {
+		a := authorize(t)
+		deviceCode, userCode := a.Get("device_code").String(), a.Get("user_code").String()
+		assert.Len(t, deviceCode, 64)
+		assert.Regexp(t, "^[A-Z]{4}-[A-Z]{4}$", userCode)
+		assert.Equal(t, publicTS.URL+device.RouteInitBrowserFlow, a.Get("verification_uri").String())
+		assert.Contains(t, a.Get("verification_uri_complete").String(), "user_code="+userCode)
+		assert.EqualValues(t, 900, a.Get("expires_in").Int())
+		assert.EqualValues(t, 5, a.Get("interval").Int())
+
+		assert.Equal(t, text.ErrIDDeviceAuthorizationPending, poll(t, deviceCode, http.StatusBadRequest).Get("error.id").String())
+		assert.Equal(t, text.ErrIDDeviceSlowDown, poll(t, deviceCode, http.StatusBadRequest).Get("error.id").String())
+
+		f := initFlow(t, browser, userCode)
+		assert.Equal(t, userCode, f.Get("ui.nodes.#(attributes.name==user_code).attributes.value").String(), "%s", f.Raw)
+
+		res := submit(t, f, "BBBB-BBBB", device.ActionApprove, http.StatusBadRequest)
+		assert.EqualValues(t, text.ErrorValidationDeviceUserCodeInvalid, res.Get("ui.nodes.#(attributes.name==user_code).messages.0.id").Int(), "%s", res.Raw)
+
+		res = submit(t, f, strings.ToLower(userCode), device.ActionApprove, http.StatusOK)
+		assert.Equal(t, "passed_challenge", res.Get("state").String(), "%s", res.Raw)
+		assert.EqualValues(t, text.InfoSelfServiceDeviceApproved, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+
+		s := poll(t, deviceCode, http.StatusOK)
+		assert.NotEmpty(t, s.Get("session_token").String(), "%s", s.Raw)
+		assert.Equal(t, i.ID.String(), s.Get("session.identity.id").String(), "%s", s.Raw)
+		assert.Equal(t, identity.CredentialsTypeDeviceAuthorization.String(), s.Get("session.authentication_methods.0.method").String(), "%s", s.Raw)
+
+		assert.Empty(t, poll(t, deviceCode, http.StatusBadRequest).Get("session_token").String())
+	})
+
+	t.Run("case=runs the login hooks when issuing the session", func(t *testing.T) {
+		var calls atomic.Int32
+		hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(hookTS.Close)
+
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginAfter+".hooks", []map[string]any{
+			{"hook": "web_hook", "config": map[string]any{"url": hookTS.URL, "method": "POST", "body": "base64://" + base64.StdEncoding.EncodeToString([]byte(`function(ctx) ctx`))}},
+		})
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceLoginAfter+".hooks", nil) })
+
+		a := authorize(t)
+		submit(t, initFlow(t, browser, ""), a.Get("user_code").String(), device.ActionApprove, http.StatusOK)
+
+		s := poll(t, a.Get("device_code").String(), http.StatusOK)
+		assert.NotEmpty(t, s.Get("session_token").String(), "%s", s.Raw)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("case=denies a device", func(t *testing.T) {
+		a := authorize(t)
+		f := initFlow(t, browser, "")
+
+		res := submit(t, f, a.Get("user_code").String(), device.ActionDeny, http.StatusOK)
+		assert.EqualValues(t, text.InfoSelfServiceDeviceDenied, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+
+		assert.Equal(t, text.ErrIDDeviceAccessDenied, poll(t, a.Get("device_code").String(), http.StatusBadRequest).Get("error.id").String())
+
+		t.Run("case=a completed flow can not be submitted again", func(t *testing.T) {
+			submit(t, f, authorize(t).Get("user_code").String(), device.ActionApprove, http.StatusBadRequest)
+		})
+	})
+
+	t.Run("case=rejects unknown device codes", func(t *testing.T) {
+		poll(t, "not-a-device-code", http.StatusBadRequest)
+	})
+
+	t.Run("case=flows can only be fetched by the identity which initiated them", func(t *testing.T) {
+		f := initFlow(t, browser, "")
+
+		res, body := testhelpers.EasyGetJSON(t, browser, publicTS.URL+device.RouteGetFlow+"?id="+f.Get("id").String())
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+
+		other := testhelpers.NewHTTPClientWithArbitrarySessionCookie(ctx, t, reg)
+		res, body = testhelpers.EasyGetJSON(t, other, publicTS.URL+device.RouteGetFlow+"?id="+f.Get("id").String())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=redirects to login without a session", func(t *testing.T) {
+		res, _ := testhelpers.EasyGet(t, testhelpers.NewNoRedirectClientWithCookies(t), publicTS.URL+device.RouteInitBrowserFlow+"?user_code=BBBB-BBBB")
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		loc, err := url.Parse(res.Header.Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, login.RouteInitBrowserFlow, loc.Path)
+		assert.Contains(t, loc.Query().Get("return_to"), device.RouteInitBrowserFlow+"?user_code=BBBB-BBBB")
+	})
+
+	t.Run("case=fails if the flow is disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceDeviceEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeySelfServiceDeviceEnabled, true) })
+
+		res := postJSON(t, publicTS.Client(), publicTS.URL+device.RouteInitAPIFlow, struct{}{}, http.StatusBadRequest)
+		assert.Equal(t, text.ErrIDSelfServiceFlowDisabled, res.Get("error.id").String(), "%s", res.Raw)
+	})
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	for in, expected := range map[string]string{
+		"BCDF-GHJK":   "BCDFGHJK",
+		"bcdf-ghjk":   "BCDFGHJK",
+		" bcdf ghjk ": "BCDFGHJK",
+		"BCDFGHJK":    "BCDFGHJK",
+	} {
+		assert.Equal(t, expected, device.NormalizeUserCode(in), in)
+	}
+
+	_, userCode := device.NewCodes()
+	assert.Equal(t, strings.ReplaceAll(userCode, "-", ""), device.NormalizeUserCode(userCode))
+}
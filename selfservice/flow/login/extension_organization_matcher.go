@@ -0,0 +1,44 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package login
+
+import (
+	"context"
+
+	"github.com/ory/x/jsonschemax"
+
+	"github.com/ory/kratos/schema"
+)
+
+// OrganizationMatcherEmailDomain matches identifiers to organizations by the
+// domain of their email address.
+const OrganizationMatcherEmailDomain = "email_domain"
+
+// SchemaMatchesOrganizationsByEmailDomain returns true if a trait of the
+// identity schema is annotated with the `email_domain` organization matcher.
+func SchemaMatchesOrganizationsByEmailDomain(ctx context.Context, schemaURL string, disallowRefs bool) (bool, error) {
+	runner, err := schema.NewExtensionRunner(ctx)
+	if err != nil {
+		return false, err
+	}
+	c, err := schema.NewCompilerWithURL(ctx, schemaURL, disallowRefs)
+	if err != nil {
+		return false, err
+	}
+	c.ExtractAnnotations = true
+	runner.Register(c)
+
+	paths, err := jsonschemax.ListPaths(ctx, schemaURL, c)
+	if err != nil {
+		return false, err
+	}
+
+	for _, path := range paths {
+		if config, ok := path.CustomProperties[schema.ExtensionName].(*schema.ExtensionConfig); ok &&
+			config.Organization.Matcher == OrganizationMatcherEmailDomain {
+			return true, nil
+		}
+	}
+	return false, nil
+}
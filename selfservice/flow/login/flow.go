@@ -148,6 +148,14 @@ type Flow struct {
 	// ReturnToVerification contains the redirect URL for the verification flow.
 	ReturnToVerification string `json:"-" db:"-"`
 
+	// ReturnToSettings contains the redirect URL for a settings flow the user
+	// is required to complete, for example because their password expired.
+	ReturnToSettings string `json:"-" db:"-"`
+
+	// RememberDevice is set if the user asked to trust the browser after
+	// completing the second factor.
+	RememberDevice bool `json:"-" db:"-"`
+
 	isAccountLinkingFlow bool `db:"-"`
 
 	// IdentitySchema optionally holds the ID of the identity schema that is used
@@ -216,6 +224,7 @@ func (Flow) GetFlowName() flow.FlowName                       { return flow.Logi
 func (Flow) TableName() string                                { return "selfservice_login_flows" }
 func (f *Flow) ContinueWith() []flow.ContinueWith             { return f.ContinueWithItems }
 func (f *Flow) SetReturnToVerification(to string)             { f.ReturnToVerification = to }
+func (f *Flow) SetReturnToSettings(to string)                 { f.ReturnToSettings = to }
 func (f *Flow) GetOAuth2LoginChallenge() sqlxx.NullString     { return f.OAuth2LoginChallenge }
 func (f *Flow) AppendTo(src *url.URL) *url.URL                { return flow.AppendFlowTo(src, f.ID) }
 func (f *Flow) SetState(state flow.State)                     { f.State = state }
@@ -18,6 +18,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/risk"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow"
@@ -66,6 +67,7 @@ type (
 		ErrorHandlerProvider
 		sessiontokenexchange.PersistenceProvider
 		logrusx.Provider
+		risk.AssessorProvider
 	}
 	HandlerProvider interface {
 		LoginHandler() *Handler
@@ -275,6 +277,10 @@ preLoginHook:
 		f.UI.Messages.Add(text.NewInfoLoginMFA())
 	}
 
+	if h.offersRememberDevice(r, f) {
+		f.UI.Nodes.Append(newRememberDeviceNode())
+	}
+
 	if err := sortNodes(r.Context(), f.UI.Nodes); err != nil {
 		return nil, nil, err
 	}
@@ -921,6 +927,14 @@ continueLogin:
 		return
 	}
 
+	if h.offersRememberDevice(r, f) {
+		f.RememberDevice, err = decodeRememberDevice(r)
+		if err != nil {
+			h.d.LoginFlowErrorHandler().WriteFlowError(w, r, f, "", node.DefaultGroup, err)
+			return
+		}
+	}
+
 	var ct identity.CredentialsType
 	var i *identity.Identity
 	var group node.UiNodeGroup
@@ -932,6 +946,9 @@ continueLogin:
 		} else if errors.Is(err, flow.ErrCompletedByStrategy) {
 			return
 		} else if err != nil {
+			if isCredentialsError(err) {
+				h.d.LoginRiskAssessor().RecordFailedLogin(ctx, r)
+			}
 			h.d.LoginFlowErrorHandler().WriteFlowError(w, r, f, ss.ID(), group, err)
 			return
 		}
@@ -964,3 +981,21 @@ continueLogin:
 		return
 	}
 }
+
+// isCredentialsError returns true if the error reports wrong credentials, which
+// count as failed login attempts for the risk assessment.
+func isCredentialsError(err error) bool {
+	var validationErr *schema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	for _, m := range validationErr.Messages {
+		switch m.ID {
+		case text.ErrorValidationInvalidCredentials,
+			text.ErrorValidationTOTPVerifierWrong,
+			text.ErrorValidationLookupInvalid:
+			return true
+		}
+	}
+	return false
+}
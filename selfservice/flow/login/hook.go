@@ -19,6 +19,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/risk"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
@@ -53,6 +54,7 @@ type (
 		hydra.Provider
 		identity.PrivilegedPoolProvider
 		identity.ManagementProvider
+		identity.SchemaMigratorProvider
 		session.ManagementProvider
 		session.PersistenceProvider
 		nosurfx.CSRFTokenGeneratorProvider
@@ -60,6 +62,7 @@ type (
 		logrusx.Provider
 		otelx.Provider
 		sessiontokenexchange.PersistenceProvider
+		risk.AssessorProvider
 		HandlerProvider
 
 		FlowPersistenceProvider
@@ -87,7 +90,9 @@ func NewHookExecutor(d executorDependencies) *HookExecutor {
 }
 
 func (e *HookExecutor) checkAAL(ctx context.Context, s *session.Session, a *Flow) error {
-	err := e.d.SessionManager().DoesSessionSatisfy(ctx, s, e.d.Config().SessionWhoAmIAAL(ctx))
+	// Pending requirements are taken care of in the settings flow the user is
+	// sent to after the login.
+	err := e.d.SessionManager().DoesSessionSatisfy(ctx, s, e.d.Config().SessionWhoAmIAAL(ctx), session.AllowPendingRequirements)
 	if err == nil {
 		return nil
 	}
@@ -143,6 +148,15 @@ func (e *HookExecutor) PostLoginHook(
 	s.IdentityID = i.ID
 	s.Identity = i
 
+	// Lazy identity schema migrations must not prevent the user from signing in.
+	if err := e.d.IdentitySchemaMigrator().MigrateOnLogin(ctx, i); err != nil {
+		e.d.Logger().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			WithError(err).
+			Warn("Unable to migrate the identity to a new identity schema on login.")
+	}
+
 	if err := e.maybeLinkCredentials(ctx, s, i, f); err != nil {
 		return err
 	}
@@ -151,6 +165,26 @@ func (e *HookExecutor) PostLoginHook(
 		return err
 	}
 
+	// Only the first factor is assessed. The assessment is kept on the session
+	// when the second factor completes it.
+	if f.RequestedAAL == identity.AuthenticatorAssuranceLevel1 {
+		if err := e.d.LoginRiskAssessor().AssessLogin(ctx, r, s); err != nil {
+			return err
+		}
+	}
+
+	if f.Type == flow.TypeBrowser {
+		if err := e.d.SessionManager().MaybeCompleteWithTrustedDevice(ctx, r, s); err != nil {
+			return err
+		}
+
+		if f.RememberDevice && s.AuthenticatorAssuranceLevel == identity.AuthenticatorAssuranceLevel2 {
+			if err := e.d.SessionManager().TrustDevice(ctx, w, r, s); err != nil {
+				return err
+			}
+		}
+	}
+
 	c := e.d.Config()
 	// Verify the redirect URL before we do any other processing.
 	returnTo, err := redir.SecureRedirectTo(r,
@@ -381,6 +415,9 @@ func (e *HookExecutor) PostLoginHook(
 		}
 		finalReturnTo = rt
 		span.SetAttributes(attribute.String("return_to", rt), attribute.String("redirect_reason", "oauth2 login challenge"))
+	} else if f.ReturnToSettings != "" {
+		finalReturnTo = f.ReturnToSettings
+		span.SetAttributes(attribute.String("redirect_reason", "settings required"))
 	} else if f.ReturnToVerification != "" {
 		finalReturnTo = f.ReturnToVerification
 		span.SetAttributes(attribute.String("redirect_reason", "verification requested"))
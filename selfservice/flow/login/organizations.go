@@ -40,3 +40,15 @@ func PrepareOrganizations(r *http.Request, f *Flow, sess *session.Session) []Str
 	// AAL1.
 	return organizationFilter
 }
+
+// OrganizationDiscoverer is implemented by strategies which can send a user
+// straight to the identity provider of the organization their identifier
+// belongs to.
+type OrganizationDiscoverer interface {
+	// LoginWithOrganization starts the login at the identity provider of the
+	// organization the identifier belongs to. It returns
+	// flow.ErrCompletedByStrategy if it did so and
+	// flow.ErrStrategyNotResponsible if the identifier belongs to no
+	// organization.
+	LoginWithOrganization(w http.ResponseWriter, r *http.Request, f *Flow, identifier string) error
+}
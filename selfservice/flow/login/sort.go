@@ -20,6 +20,7 @@ func sortNodes(ctx context.Context, n node.Nodes) error {
 			node.PasswordGroup,
 			node.TOTPGroup,
 			node.LookupGroup,
+			node.CrossDeviceGroup,
 		}),
 		node.SortUseOrder([]string{
 			"csrf_token",
@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package login
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/decoderx"
+)
+
+//go:embed .schema/remember_device.schema.json
+var rememberDeviceSchema []byte
+
+// offersRememberDevice returns true if the user can choose to trust the
+// browser when completing the flow.
+func (h *Handler) offersRememberDevice(r *http.Request, f *Flow) bool {
+	return f.Type == flow.TypeBrowser &&
+		f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 &&
+		h.d.Config().SelfServiceTrustedDeviceEnabled(r.Context())
+}
+
+// decodeRememberDevice reads whether the user asked to trust the browser. The
+// request body is kept for the login strategies.
+func decodeRememberDevice(r *http.Request) (bool, error) {
+	var p struct {
+		RememberDevice bool `json:"remember_device" form:"remember_device"`
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(rememberDeviceSchema)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if err := decoderx.Decode(r, &p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST"),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return p.RememberDevice, nil
+}
+
+func newRememberDeviceNode() *node.Node {
+	return node.NewInputField(node.RememberDevice, false, node.DefaultGroup, node.InputAttributeTypeCheckbox).
+		WithMetaLabel(text.NewInfoNodeLabelRememberDevice())
+}
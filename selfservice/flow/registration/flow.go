@@ -98,6 +98,11 @@ type Flow struct {
 	NID            uuid.UUID     `json:"-" faker:"-" db:"nid"`
 	OrganizationID uuid.NullUUID `json:"organization_id,omitempty"  faker:"-" db:"organization_id"`
 
+	// InvitationID is the ID of the invitation the flow was created for.
+	// Completing the flow accepts the invitation instead of creating a new
+	// identity.
+	InvitationID uuid.NullUUID `json:"-" faker:"-" db:"invitation_id"`
+
 	// TransientPayload is used to pass data from the registration to a webhook
 	//
 	// required: false
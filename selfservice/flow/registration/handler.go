@@ -119,10 +119,6 @@ func WithFlowOAuth2LoginChallenge(loginChallenge string) FlowOption {
 }
 
 func (h *Handler) NewRegistrationFlow(w http.ResponseWriter, r *http.Request, ft flow.Type, opts ...FlowOption) (*Flow, error) {
-	if !h.d.Config().SelfServiceFlowRegistrationEnabled(r.Context()) {
-		return nil, errors.WithStack(ErrRegistrationDisabled())
-	}
-
 	f, err := NewFlow(h.d.Config(), h.d.Config().SelfServiceFlowRegistrationRequestLifespan(r.Context()), h.d.GenerateCSRFToken(r), r, ft)
 	if err != nil {
 		return nil, err
@@ -131,6 +127,11 @@ func (h *Handler) NewRegistrationFlow(w http.ResponseWriter, r *http.Request, ft
 		o(f)
 	}
 
+	// Invited users can register even if the registration is disabled.
+	if !h.d.Config().SelfServiceFlowRegistrationEnabled(r.Context()) && !f.InvitationID.Valid {
+		return nil, errors.WithStack(ErrRegistrationDisabled())
+	}
+
 	if ft == flow.TypeAPI && r.URL.Query().Get("return_session_token_exchange_code") == "true" {
 		e, err := h.d.SessionTokenExchangePersister().CreateSessionTokenExchanger(r.Context(), f.ID)
 		if err != nil {
@@ -160,6 +161,10 @@ func (h *Handler) NewRegistrationFlow(w http.ResponseWriter, r *http.Request, ft
 		}
 	}
 
+	if err := h.addLegalDocumentNodes(r, f); err != nil {
+		return nil, err
+	}
+
 	ds, err := f.IdentitySchema.URL(r.Context(), h.d.Config())
 	if err != nil {
 		return nil, err
@@ -523,12 +528,11 @@ type getRegistrationFlow struct {
 //	Extensions:
 //	  x-ory-ratelimit-bucket: kratos-public-high
 func (h *Handler) getRegistrationFlow(w http.ResponseWriter, r *http.Request) {
-	if !h.d.Config().SelfServiceFlowRegistrationEnabled(r.Context()) {
+	ar, err := h.d.RegistrationFlowPersister().GetRegistrationFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("id")))
+	if !h.d.Config().SelfServiceFlowRegistrationEnabled(r.Context()) && (err != nil || !ar.InvitationID.Valid) {
 		h.d.SelfServiceErrorManager().Forward(r.Context(), w, r, errors.WithStack(ErrRegistrationDisabled()))
 		return
 	}
-
-	ar, err := h.d.RegistrationFlowPersister().GetRegistrationFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("id")))
 	if err != nil {
 		h.d.Writer().WriteError(w, r, err)
 		return
@@ -697,6 +701,11 @@ func (h *Handler) updateRegistrationFlow(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := h.decodeLegalDocuments(r, f); err != nil {
+		h.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, f, "", node.DefaultGroup, err)
+		return
+	}
+
 	i := identity.NewIdentity(f.IdentitySchema.ID(ctx, h.d.Config()))
 	var s Strategy
 	for _, ss := range h.d.AllRegistrationStrategies() {
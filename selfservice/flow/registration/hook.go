@@ -18,12 +18,15 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/events"
+	"github.com/ory/pop/v6"
 	"github.com/ory/x/httpx"
 	"github.com/ory/x/logrusx"
 	"github.com/ory/x/otelx"
@@ -92,6 +95,9 @@ type (
 		httpx.WriterProvider
 		otelx.Provider
 		sessiontokenexchange.PersistenceProvider
+		legal.PersistenceProvider
+		x.TransactionPersistenceProvider
+		InvitationAcceptorProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -168,9 +174,35 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 	if err := e.d.IdentityValidator().Validate(ctx, i); err != nil {
 		return err
 	}
+
+	legalDocuments, err := e.requireLegalDocuments(r, registrationFlow)
+	if err != nil {
+		return err
+	}
+
+	// Invited identities were already approved by the administrator who invited them.
+	if e.d.Config().SelfServiceFlowRegistrationRequireApproval(ctx) && !registrationFlow.InvitationID.Valid {
+		i.State = identity.StatePendingApproval
+	}
 	// We're now creating the identity because any of the hooks could trigger a "redirect" or a "session" which
-	// would imply that the identity has to exist already.
-	if err := e.d.IdentityManager().Create(ctx, i); err != nil {
+	// would imply that the identity has to exist already. If the flow was created for an invitation, the invited
+	// identity is activated instead.
+	//
+	// The acceptances of the legal documents are created in the same transaction so that an identity never exists
+	// without them.
+	if err := e.d.TransactionalPersisterProvider().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+		if registrationFlow.InvitationID.Valid {
+			if err := e.d.RegistrationInvitationAcceptor().AcceptRegistrationInvitation(ctx, registrationFlow, i); err != nil {
+				return err
+			}
+		} else if err := e.d.IdentityManager().Create(ctx, i); err != nil {
+			return err
+		}
+		if len(legalDocuments) == 0 {
+			return nil
+		}
+		return e.d.LegalPersister().CreateLegalAcceptances(ctx, legal.NewAcceptances(r, i.ID, legalDocuments))
+	}); err != nil {
 		if errors.Is(err, sqlcon.ErrUniqueViolation()) {
 			strategy, err := e.d.AllLoginStrategies().Strategy(ct)
 			if err != nil {
@@ -201,6 +233,10 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 	ctx = context.WithoutCancel(ctx)
 	r = r.WithContext(ctx)
 
+	for id, version := range legalDocuments {
+		span.AddEvent(events.NewLegalDocumentAccepted(ctx, i.ID, id, version))
+	}
+
 	// Verify the redirect URL before we do any other processing.
 	c := e.d.Config()
 	returnTo, err := redir.SecureRedirectTo(r, c.SelfServiceBrowserDefaultReturnTo(ctx),
@@ -231,6 +267,12 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 
 	span.AddEvent(events.NewRegistrationSucceeded(ctx, registrationFlow.ID, i.ID, string(registrationFlow.Type), ct.String(), authMethod.Provider))
 
+	if i.State == identity.StatePendingApproval {
+		span.AddEvent(events.NewRegistrationPendingApproval(ctx, registrationFlow.ID, i.ID))
+		span.SetAttributes(attribute.String("redirect_reason", "pending approval"))
+		return e.awaitApproval(w, r, registrationFlow, i)
+	}
+
 	s := session.NewInactiveSession()
 	s.CompletedLoginForMethod(authMethod)
 	if err := e.d.SessionManager().ActivateSession(r, s, i, time.Now().UTC()); err != nil {
@@ -348,6 +390,25 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 	return nil
 }
 
+// awaitApproval completes a registration whose identity can not sign in
+// until an administrator approves it. No session is issued and the post
+// persist hooks do not run.
+func (e *HookExecutor) awaitApproval(w http.ResponseWriter, r *http.Request, f *Flow, i *identity.Identity) error {
+	f.State = flow.StatePassedChallenge
+	f.UI.Messages.Set(text.NewInfoSelfServiceRegistrationPendingApproval())
+	if err := e.d.RegistrationFlowPersister().UpdateRegistrationFlow(r.Context(), f); err != nil {
+		return err
+	}
+
+	if f.Type == flow.TypeAPI || x.IsJSONRequest(r) {
+		e.d.Writer().Write(w, r, &APIFlowResponse{Identity: i})
+		return nil
+	}
+
+	http.Redirect(w, r, f.AppendTo(e.d.Config().SelfServiceFlowRegistrationUI(r.Context())).String(), http.StatusSeeOther)
+	return nil
+}
+
 func (e *HookExecutor) getDuplicateIdentifier(ctx context.Context, i *identity.Identity) (string, error) {
 	_, id, _, err := e.d.IdentityManager().ConflictingIdentity(ctx, i)
 	if err != nil {
@@ -0,0 +1,36 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package registration
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+)
+
+type (
+	// InvitationAcceptor accepts the invitation a registration flow was
+	// created for.
+	InvitationAcceptor interface {
+		// AcceptRegistrationInvitation accepts the invitation of the flow and
+		// stores the credentials of i on the invited identity. Afterwards, i
+		// is the activated invited identity.
+		AcceptRegistrationInvitation(ctx context.Context, f *Flow, i *identity.Identity) error
+	}
+	InvitationAcceptorProvider interface {
+		RegistrationInvitationAcceptor() InvitationAcceptor
+	}
+)
+
+// WithFlowInvitation binds the flow to the invitation. The flow uses the
+// identity schema of the invited identity.
+func WithFlowInvitation(invitationID uuid.UUID, schemaID string) FlowOption {
+	return func(f *Flow) {
+		f.InvitationID = uuid.NullUUID{UUID: invitationID, Valid: true}
+		f.IdentitySchema = flow.IdentitySchema(schemaID)
+	}
+}
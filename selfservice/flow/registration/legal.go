@@ -0,0 +1,88 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package registration
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/ui/node"
+)
+
+const internalContextKeyLegalDocuments = "legal_documents"
+
+// addLegalDocumentNodes asks the user to accept the configured legal
+// documents.
+func (h *Handler) addLegalDocumentNodes(r *http.Request, f *Flow) error {
+	docs, err := h.d.Config().SelfServiceLegalDocuments(r.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, n := range legal.NewNodes(docs, node.DefaultGroup) {
+		f.UI.Nodes.Append(n)
+	}
+	return nil
+}
+
+// decodeLegalDocuments remembers which legal documents the user accepted. They
+// are stored in the flow because strategies such as OIDC complete the
+// registration in a later request.
+func (h *Handler) decodeLegalDocuments(r *http.Request, f *Flow) error {
+	ctx := r.Context()
+	docs, err := h.d.Config().SelfServiceLegalDocuments(ctx)
+	if err != nil || len(docs) == 0 {
+		return err
+	}
+
+	checked, err := legal.Decode(r, docs)
+	if err != nil || len(checked) == 0 {
+		return err
+	}
+
+	accepted := acceptedLegalDocuments(f)
+	for _, doc := range checked {
+		accepted[doc.ID] = doc.Version
+		f.UI.Nodes.SetValueAttribute(node.LegalDocuments+"."+doc.ID, true)
+	}
+
+	f.EnsureInternalContext()
+	raw, err := sjson.SetBytes(f.InternalContext, internalContextKeyLegalDocuments, accepted)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	f.InternalContext = raw
+
+	return h.d.RegistrationFlowPersister().UpdateRegistrationFlow(ctx, f)
+}
+
+// acceptedLegalDocuments maps the IDs of the legal documents the user accepted
+// during the flow to their version.
+func acceptedLegalDocuments(f *Flow) map[string]string {
+	accepted := make(map[string]string)
+	if raw := gjson.GetBytes(f.InternalContext, internalContextKeyLegalDocuments).Raw; raw != "" {
+		_ = json.Unmarshal([]byte(raw), &accepted)
+	}
+	return accepted
+}
+
+// requireLegalDocuments returns a validation error unless the user accepted
+// the current version of all legal documents.
+func (e *HookExecutor) requireLegalDocuments(r *http.Request, f *Flow) (map[string]string, error) {
+	docs, err := e.d.Config().SelfServiceLegalDocuments(r.Context())
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+
+	accepted := acceptedLegalDocuments(f)
+	if missing := legal.Missing(docs, accepted); len(missing) > 0 {
+		return nil, legal.NewMissingError(missing)
+	}
+	return legal.Versions(docs), nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package revokesessions
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/urlx"
+)
+
+// ActionRevoke confirms revoking all sessions.
+const ActionRevoke = "revoke"
+
+// A Revoke Sessions Flow
+//
+// This flow is started by the link in a security notification. In it, the
+// user confirms signing out everywhere if they did not make the change they
+// were notified about.
+//
+// swagger:model revokeSessionsFlow
+type Flow struct {
+	// ID represents the flow's unique ID. When performing the revoke sessions flow, this
+	// represents the id in the revoke sessions ui's query parameter: http://<selfservice.flows.revoke_sessions.ui_url>?flow=<id>
+	//
+	// type: string
+	// format: uuid
+	// required: true
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// ExpiresAt is the time (UTC) when the flow expires. It expires together with the link
+	// in the security notification.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// IssuedAt is the time (UTC) when the flow occurred.
+	//
+	// required: true
+	IssuedAt time.Time `json:"issued_at" faker:"time_type" db:"issued_at"`
+
+	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used
+	// to forward information contained in the URL's path or query for example.
+	//
+	// required: true
+	RequestURL string `json:"request_url" db:"request_url"`
+
+	// UI contains data which must be shown in the user interface.
+	//
+	// required: true
+	UI *container.Container `json:"ui" db:"ui"`
+
+	// State represents the state of this flow:
+	//
+	// - choose_method: ask the user to confirm signing out everywhere
+	// - passed_challenge: all sessions were revoked
+	//
+	// required: true
+	State flow.State `json:"state" faker:"-" db:"state"`
+
+	// IdentityID is the identity whose sessions are revoked.
+	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
+
+	// TokenHash is the hash of the link's token, which is recorded as used
+	// once the sessions were revoked.
+	TokenHash string `json:"-" faker:"-" db:"token_hash"`
+
+	// CSRFToken contains the anti-csrf token associated with this flow.
+	CSRFToken string `json:"-" db:"csrf_token"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+}
+
+// NewFlow returns a flow in which the user confirms revoking all sessions of
+// the identity the link's token was issued for.
+func NewFlow(conf *config.Config, csrf string, r *http.Request, identityID uuid.UUID, tokenHash string, expiresAt time.Time) *Flow {
+	now := time.Now().UTC()
+	id := x.NewUUID()
+
+	f := &Flow{
+		ID:         id,
+		ExpiresAt:  expiresAt.UTC(),
+		IssuedAt:   now,
+		RequestURL: x.RequestURL(r).String(),
+		UI: &container.Container{
+			Method: "POST",
+			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r.Context()), RouteSubmitFlow), id).String(),
+		},
+		State:      flow.StateChooseMethod,
+		IdentityID: identityID,
+		TokenHash:  tokenHash,
+		CSRFToken:  csrf,
+	}
+
+	f.UI.SetCSRF(csrf)
+	f.UI.Messages.Set(text.NewInfoSelfServiceRevokeSessions())
+	f.UI.GetNodes().Append(node.NewInputField(node.RevokeSessionsAction, ActionRevoke, node.DefaultGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelRevokeSessions()))
+
+	return f
+}
+
+func (Flow) TableName() string { return "selfservice_revoke_sessions_flows" }
+
+func (f *Flow) AppendTo(src *url.URL) *url.URL {
+	return flow.AppendFlowTo(src, f.ID)
+}
+
+func (f *Flow) IsExpired() bool {
+	return f.ExpiresAt.Before(time.Now().UTC())
+}
+
+type (
+	Persister interface {
+		CreateRevokeSessionsFlow(ctx context.Context, f *Flow) error
+		GetRevokeSessionsFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
+		UpdateRevokeSessionsFlow(ctx context.Context, f *Flow) error
+		DeleteExpiredRevokeSessionsFlows(ctx context.Context, expiresAt time.Time, limit int) error
+
+		// NetworkID returns the network the links are bound to.
+		NetworkID(ctx context.Context) uuid.UUID
+	}
+
+	PersistenceProvider interface {
+		RevokeSessionsFlowPersister() Persister
+	}
+)
@@ -0,0 +1,373 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package revokesessions
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteInitBrowserFlow = "/self-service/revoke-sessions/browser"
+	RouteGetFlow         = "/self-service/revoke-sessions/flows"
+
+	RouteSubmitFlow = "/self-service/revoke-sessions"
+)
+
+//go:embed .schema/update.schema.json
+var updateSchema []byte
+
+type (
+	handlerDependencies interface {
+		nosurfx.CSRFProvider
+		nosurfx.CSRFTokenGeneratorProvider
+		httpx.WriterProvider
+		logrusx.Provider
+
+		config.Provider
+
+		session.PersistenceProvider
+		session.TrustedDevicePersistenceProvider
+
+		errorx.ManagementProvider
+
+		PersistenceProvider
+	}
+	HandlerProvider interface {
+		RevokeSessionsFlowHandler() *Handler
+	}
+	Handler struct {
+		d handlerDependencies
+	}
+)
+
+func NewHandler(d handlerDependencies) *Handler {
+	return &Handler{d: d}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.d.CSRFHandler().IgnorePath(RouteSubmitFlow)
+
+	public.GET(RouteInitBrowserFlow, h.createBrowserRevokeSessionsFlow)
+	public.GET(RouteGetFlow, h.getRevokeSessionsFlow)
+	public.POST(RouteSubmitFlow, h.updateRevokeSessionsFlow)
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteInitBrowserFlow, redir.RedirectToPublicRoute(h.d))
+	admin.GET(RouteGetFlow, redir.RedirectToPublicRoute(h.d))
+	admin.POST(RouteSubmitFlow, redir.RedirectToPublicRoute(h.d))
+}
+
+// NewLink returns the link which starts the revoke sessions flow for the
+// identity, for example to include in security notifications. The link
+// expires after `session.revoke_link.lifespan`.
+func NewLink(ctx context.Context, c *config.Config, i *identity.Identity) *url.URL {
+	token := session.NewRevokeSessionsToken(c.SecretsSession(ctx)[0], i.NID, i.ID, time.Now().Add(c.SessionRevokeLinkLifespan(ctx)))
+	return urlx.CopyWithQuery(urlx.AppendPaths(c.SelfPublicURL(ctx), RouteInitBrowserFlow), url.Values{"token": {token}})
+}
+
+// Create Browser Revoke Sessions Flow Parameters
+//
+// swagger:parameters createBrowserRevokeSessionsFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createBrowserRevokeSessionsFlow struct {
+	// The token of the link in the security notification.
+	//
+	// required: true
+	// in: query
+	Token string `json:"token"`
+}
+
+// swagger:route GET /self-service/revoke-sessions/browser frontend createBrowserRevokeSessionsFlow
+//
+// # Create Revoke Sessions Flow for Browsers
+//
+// Security notifications, for example about a changed email address, contain a link to this endpoint. It
+// initializes a browser-based flow in which the user confirms revoking all sessions and trusted devices of
+// the identity. Once initialized, the browser will be redirected to `selfservice.flows.revoke_sessions.ui_url`
+// with the flow ID set as the query parameter `?flow=`. Opening the link does not revoke any sessions.
+//
+// If this endpoint is called via an AJAX request, the response contains the flow without any redirects.
+//
+// This endpoint is NOT INTENDED for clients that do not have a browser (Chrome, Firefox, ...) as cookies are needed.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: revokeSessionsFlow
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) createBrowserRevokeSessionsFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := r.URL.Query().Get("token")
+	identityID, expiresAt, err := session.ParseRevokeSessionsToken(
+		h.d.Config().SecretsSession(ctx), h.d.RevokeSessionsFlowPersister().NetworkID(ctx), token, time.Now())
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f := NewFlow(h.d.Config(), h.d.GenerateCSRFToken(r), r, identityID, session.HashRevokeSessionsToken(token), expiresAt)
+	if err := h.d.RevokeSessionsFlowPersister().CreateRevokeSessionsFlow(ctx, f); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowCompletedAsRedirectOrJSON(w, r, h.d.Writer(), f, f.AppendTo(h.d.Config().SelfServiceFlowRevokeSessionsUI(ctx)).String())
+}
+
+// Get Revoke Sessions Flow Parameters
+//
+// swagger:parameters getRevokeSessionsFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getRevokeSessionsFlow struct {
+	// The Revoke Sessions Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/revoke-sessions?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	ID string `json:"id"`
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/revoke-sessions/flows frontend getRevokeSessionsFlow
+//
+// # Get Revoke Sessions Flow
+//
+// This endpoint returns a revoke sessions flow's context with, for example, error details and other information.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: revokeSessionsFlow
+//	  404: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-high
+func (h *Handler) getRevokeSessionsFlow(w http.ResponseWriter, r *http.Request) {
+	f, err := h.d.RevokeSessionsFlowPersister().GetRevokeSessionsFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("id")))
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if f.IsExpired() {
+		h.d.Writer().WriteError(w, r, errors.WithStack(nosurfx.ErrGone().
+			WithReason("The revoke sessions flow has expired together with the link in the security notification.")))
+		return
+	}
+
+	h.d.Writer().Write(w, r, f)
+}
+
+// Update Revoke Sessions Flow Parameters
+//
+// swagger:parameters updateRevokeSessionsFlow
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateRevokeSessionsFlow struct {
+	// The Revoke Sessions Flow ID
+	//
+	// The value for this parameter comes from `flow` URL Query parameter sent to your
+	// application (e.g. `/revoke-sessions?flow=abcde`).
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	// required: true
+	Body updateRevokeSessionsFlowBody
+
+	// HTTP Cookies
+	//
+	// When using the SDK in a browser app, on the server side you must include the HTTP Cookie Header
+	// sent by the client to your server here. This ensures that CSRF cookies are respected.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// Update Revoke Sessions Flow Request Body
+//
+// swagger:model updateRevokeSessionsFlowBody
+type updateRevokeSessionsFlowBody struct {
+	// Action must be `revoke`.
+	Action string `json:"action"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+}
+
+// swagger:route POST /self-service/revoke-sessions frontend updateRevokeSessionsFlow
+//
+// # Revoke All Sessions
+//
+// Use this endpoint to revoke all sessions and trusted devices of the identity the link in the security
+// notification was issued for. The link can only be used once.
+//
+// Browser flows without HTTP Header `Accept` or with `Accept: text/*` respond with a HTTP 303 redirect
+// to the Revoke Sessions UI URL with the flow ID containing the success message.
+//
+// Browser flows with HTTP Header `Accept: application/json` respond with
+//
+//   - HTTP 200 and the flow on success;
+//   - HTTP 400 if the link was already used;
+//   - HTTP 403 when a CSRF violation occurred;
+//   - HTTP 410 if the flow expired.
+//
+// Sessions are only revoked once per link.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: revokeSessionsFlow
+//	  303: emptyResponse
+//	  400: errorGeneric
+//	  403: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) updateRevokeSessionsFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := flow.GetFlowID(r)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	f, err := h.d.RevokeSessionsFlowPersister().GetRevokeSessionsFlow(ctx, id)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if f.IsExpired() {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(flow.NewFlowExpiredError(f.ExpiresAt)))
+		return
+	}
+
+	if f.State == flow.StatePassedChallenge {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest().
+			WithReason("All sessions were already revoked using this link.")))
+		return
+	}
+
+	var body updateRevokeSessionsFlowBody
+	if err := h.decode(r, &body); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if err := flow.EnsureCSRF(h.d, r, flow.TypeBrowser, h.d.Config().DisableAPIFlowEnforcement(ctx), h.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if err := h.d.SessionPersister().UseRevokeSessionsToken(ctx, f.TokenHash, f.ExpiresAt); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(session.ErrRevokeSessionsLinkInvalid()))
+		return
+	} else if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	revoked, err := h.d.SessionPersister().RevokeSessionsIdentityExcept(ctx, f.IdentityID, uuid.Nil)
+	if err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if err := h.d.TrustedDevicePersister().DeleteTrustedDevicesByIdentity(ctx, f.IdentityID); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	h.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", f.IdentityID).
+		WithField("revoked_sessions", revoked).
+		Info("Revoked all sessions using the link in a security notification.")
+
+	f.State = flow.StatePassedChallenge
+	f.UI.Nodes.Remove(node.RevokeSessionsAction)
+	f.UI.Messages.Set(text.NewInfoSelfServiceRevokeSessionsSucceeded())
+	if err := h.d.RevokeSessionsFlowPersister().UpdateRevokeSessionsFlow(ctx, f); err != nil {
+		h.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	x.SendFlowCompletedAsRedirectOrJSON(w, r, h.d.Writer(), f, f.AppendTo(h.d.Config().SelfServiceFlowRevokeSessionsUI(ctx)).String())
+}
+
+func (h *Handler) decode(r *http.Request, dest any) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(updateSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
@@ -0,0 +1,136 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package revokesessions_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/revokesessions"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/configx"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+	)
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+	conf.MustSet(ctx, config.ViperKeySelfServiceRevokeSessionsUI, "https://www.ory.sh/revoke-sessions")
+
+	i := identity.NewIdentity("")
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+	s := &session.Session{Identity: i, Active: true, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	isActive := func(t *testing.T) bool {
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		return actual.IsActive()
+	}
+
+	link := func(t *testing.T) string {
+		u := revokesessions.NewLink(ctx, conf, i)
+		assert.Equal(t, revokesessions.RouteInitBrowserFlow, u.Path)
+		return publicTS.URL + revokesessions.RouteInitBrowserFlow + "?" + u.RawQuery
+	}
+
+	initFlow := func(t *testing.T, c *http.Client, link string) gjson.Result {
+		res, body := testhelpers.EasyGetJSON(t, c, link)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	submit := func(t *testing.T, c *http.Client, f gjson.Result, csrfToken string, expectCode int) gjson.Result {
+		payload, err := json.Marshal(map[string]string{"csrf_token": csrfToken, "action": revokesessions.ActionRevoke})
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", f.Get("ui.action").String(), bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	csrfTokenOf := func(f gjson.Result) string {
+		return f.Get("ui.nodes.#(attributes.name==csrf_token).attributes.value").String()
+	}
+
+	t.Run("case=rejects invalid tokens", func(t *testing.T) {
+		res, body := testhelpers.EasyGetJSON(t, publicTS.Client(), publicTS.URL+revokesessions.RouteInitBrowserFlow+"?token=invalid")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.True(t, isActive(t))
+	})
+
+	t.Run("case=rejects tokens issued for another network", func(t *testing.T) {
+		token := session.NewRevokeSessionsToken(conf.SecretsSession(ctx)[0], uuid.Must(uuid.NewV4()), i.ID, time.Now().Add(time.Hour))
+		res, body := testhelpers.EasyGetJSON(t, publicTS.Client(), publicTS.URL+revokesessions.RouteInitBrowserFlow+"?"+url.Values{"token": {token}}.Encode())
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.True(t, isActive(t))
+	})
+
+	t.Run("case=redirects browsers to the UI", func(t *testing.T) {
+		c := testhelpers.NewClientWithCookies(t)
+		c.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+		res, err := c.Get(link(t))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Location"), "https://www.ory.sh/revoke-sessions?flow=")
+		assert.True(t, isActive(t))
+	})
+
+	t.Run("case=revokes all sessions once confirmed", func(t *testing.T) {
+		c := testhelpers.NewClientWithCookies(t)
+		l := link(t)
+
+		f := initFlow(t, c, l)
+		assert.Equal(t, "choose_method", f.Get("state").String())
+		assert.EqualValues(t, text.InfoSelfServiceRevokeSessions, f.Get("ui.messages.0.id").Int())
+		assert.True(t, isActive(t), "opening the link must not revoke sessions")
+
+		res, body := testhelpers.EasyGetJSON(t, c, publicTS.URL+revokesessions.RouteGetFlow+"?id="+f.Get("id").String())
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, f.Get("id").String(), gjson.GetBytes(body, "id").String())
+
+		submit(t, c, f, "invalid", http.StatusForbidden)
+		assert.True(t, isActive(t))
+
+		actual := submit(t, c, f, csrfTokenOf(f), http.StatusOK)
+		assert.Equal(t, "passed_challenge", actual.Get("state").String())
+		assert.EqualValues(t, text.InfoSelfServiceRevokeSessionsSucceeded, actual.Get("ui.messages.0.id").Int())
+		assert.False(t, isActive(t))
+
+		t.Run("case=flow can not be submitted again", func(t *testing.T) {
+			submit(t, c, f, csrfTokenOf(f), http.StatusBadRequest)
+		})
+
+		t.Run("case=link can only be used once", func(t *testing.T) {
+			other := initFlow(t, c, l)
+			submit(t, c, other, csrfTokenOf(other), http.StatusBadRequest)
+		})
+	})
+}
@@ -130,6 +130,10 @@ func (h *Handler) NewFlow(ctx context.Context, w http.ResponseWriter, r *http.Re
 	ctx, span := h.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.flow.settings.Handler.NewFlow")
 	defer otelx.End(span, &err)
 
+	if err := session.ForbidImpersonation(ctx, s, "settings"); err != nil {
+		return nil, err
+	}
+
 	f, err := NewFlow(h.d.Config(), h.d.Config().SelfServiceFlowSettingsFlowLifespan(r.Context()), r, i, ft)
 	if err != nil {
 		return nil, err
@@ -233,7 +237,7 @@ func (h *Handler) createNativeSettingsFlow(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, s, h.d.Config().SelfServiceSettingsRequiredAAL(ctx)); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, s, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.AllowPendingRequirements); err != nil {
 		h.d.Writer().WriteError(w, r, err)
 		return
 	}
@@ -320,7 +324,7 @@ func (h *Handler) createBrowserSettingsFlow(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var managerOptions []session.ManagerOptions
+	managerOptions := []session.ManagerOptions{session.AllowPendingRequirements}
 	requestURL := x.RequestURL(r)
 	if requestURL.Query().Get("return_to") != "" {
 		managerOptions = append(managerOptions, session.WithRequestURL(requestURL.String()))
@@ -440,7 +444,7 @@ func (h *Handler) getSettingsFlow(w http.ResponseWriter, r *http.Request) {
 	// to a page displaying raw JSON to the client (browser), which is not what we want.
 	// Let's rather carry over the flow ID as a query parameter and redirect to the settings UI URL.
 	requestURL := urlx.CopyWithQuery(h.d.Config().SelfServiceFlowSettingsUI(ctx), url.Values{"flow": {rid.String()}})
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL.String())); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, sess, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL.String()), session.AllowPendingRequirements); err != nil {
 		h.d.Writer().WriteError(w, r, err)
 		return
 	}
@@ -610,7 +614,7 @@ func (h *Handler) updateSettingsFlow(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestURL := x.RequestURL(r).String()
-	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, ss, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL)); err != nil {
+	if err := h.d.SessionManager().DoesSessionSatisfy(ctx, ss, h.d.Config().SelfServiceSettingsRequiredAAL(ctx), session.WithRequestURL(requestURL), session.AllowPendingRequirements); err != nil {
 		h.d.SettingsFlowErrorHandler().WriteFlowError(ctx, w, r, node.DefaultGroup, f, nil, nil, err)
 		return
 	}
@@ -620,6 +624,11 @@ func (h *Handler) updateSettingsFlow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := session.ForbidImpersonation(ctx, ss, "settings"); err != nil {
+		h.d.SettingsFlowErrorHandler().WriteFlowError(ctx, w, r, node.DefaultGroup, f, ss.Identity, ss, err)
+		return
+	}
+
 	var s string
 	var updateContext *UpdateContext
 	for _, strat := range h.d.AllSettingsStrategies() {
@@ -21,6 +21,7 @@ func sortNodes(ctx context.Context, n node.Nodes, schemaRef string) error {
 			node.WebAuthnGroup,
 			node.PasskeyGroup,
 			node.TOTPGroup,
+			node.TrustedDeviceGroup,
 		}),
 		node.SortUseOrderAppend([]string{
 			// Lookup
@@ -39,10 +40,15 @@ func sortNodes(ctx context.Context, n node.Nodes, schemaRef string) error {
 			node.PasskeyRegister,
 
 			// TOTP
+			node.TOTPRemove,
+			node.TOTPUnlink,
 			node.TOTPQR,
 			node.TOTPSecretKey,
-			node.TOTPUnlink,
+			node.TOTPDeviceName,
 			node.TOTPCode,
+
+			// Trusted devices
+			node.TrustedDeviceRevoke,
 		}),
 	)
 }
@@ -11,4 +11,5 @@ const (
 	KeyVerificationUI         = "show_verification_ui"
 	KeyVerifier               = "verification"
 	KeyVerifyNewAddress       = "verify_new_address"
+	KeySecurityNotifier       = "security_notification"
 )
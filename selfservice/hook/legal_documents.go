@@ -0,0 +1,81 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"net/http"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+var _ login.PostHookExecutor = new(LegalDocuments)
+
+type (
+	legalDocumentsDependencies interface {
+		config.Provider
+		legal.PersistenceProvider
+		settings.HandlerProvider
+		settings.FlowPersistenceProvider
+		logrusx.Provider
+		otelx.Provider
+	}
+
+	LegalDocumentsProvider interface {
+		HookLegalDocuments() *LegalDocuments
+	}
+
+	// LegalDocuments is a post login hook which requires users who have not
+	// accepted the current version of all legal documents to accept them in
+	// a settings flow.
+	LegalDocuments struct {
+		d legalDocumentsDependencies
+	}
+)
+
+func NewLegalDocuments(d legalDocumentsDependencies) *LegalDocuments {
+	return &LegalDocuments{d: d}
+}
+
+// ExecuteLoginPostHook creates a settings flow if a legal document changed
+// since the user last accepted it. The flow is added to the `continue_with`
+// items and browser clients are redirected to it once the login completes.
+//
+// The legal documents are a pending requirement of the session which limits it
+// until the documents were accepted.
+func (e *LegalDocuments) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, f *login.Flow, s *session.Session) (err error) {
+	ctx, span := e.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.hook.LegalDocuments.ExecuteLoginPostHook")
+	r = r.WithContext(ctx)
+	defer otelx.End(span, &err)
+
+	docs, err := e.d.Config().SelfServiceLegalDocuments(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending, err := legal.Pending(ctx, e.d.LegalPersister(), s.Identity.ID, docs)
+	if err != nil {
+		return err
+	}
+	s.SetRequirement(session.RequirementLegalDocumentsPending, len(pending) > 0)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.Identity.ID).
+		Debug("The identity has not accepted the current legal documents, requiring a settings flow.")
+
+	return requireSettingsFlow(e.d, w, r, f, s, func(sf *settings.Flow) {
+		sf.UI.Messages.Set(text.NewInfoSelfServiceSettingsLegalDocumentsRequired())
+	})
+}
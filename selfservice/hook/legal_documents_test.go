@@ -0,0 +1,101 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+)
+
+func TestLegalDocuments(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "http://localhost/")
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsURL, "http://localhost/settings")
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".legal.enabled", true)
+	conf.MustSet(ctx, config.ViperKeyLegalDocuments, []map[string]any{
+		{"id": "terms_of_service", "version": "v1", "title": "Terms of Service", "url": "https://www.ory.sh/tos"},
+	})
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/stub.schema.json")
+
+	h := hook.NewLegalDocuments(reg)
+
+	createIdentity := func(t *testing.T, accepted ...string) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		for _, version := range accepted {
+			require.NoError(t, reg.LegalPersister().CreateLegalAcceptances(ctx, legal.NewAcceptances(
+				httptest.NewRequest("POST", "/", nil), i.ID, map[string]string{"terms_of_service": version})))
+		}
+		return i
+	}
+
+	execute := func(t *testing.T, i *identity.Identity) (*login.Flow, *session.Session) {
+		r := httptest.NewRequest("POST", "/self-service/login?flow=1", nil)
+		f := &login.Flow{Type: flow.TypeBrowser, RequestURL: "http://localhost/self-service/login/browser?return_to=https://www.ory.sh/"}
+		s := &session.Session{Identity: i}
+		require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), r, node.PasswordGroup, f, s))
+		return f, s
+	}
+
+	t.Run("case=current version was accepted", func(t *testing.T) {
+		f, s := execute(t, createIdentity(t, "v1"))
+		assert.Empty(t, f.ContinueWith())
+		assert.Empty(t, f.ReturnToSettings)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementLegalDocumentsPending))
+	})
+
+	t.Run("case=only an older version was accepted", func(t *testing.T) {
+		i := createIdentity(t, "v0")
+		f, s := execute(t, i)
+		assert.True(t, s.PendingRequirements.Has(session.RequirementLegalDocumentsPending))
+
+		require.Len(t, f.ContinueWith(), 1)
+		cw, ok := f.ContinueWith()[0].(*flow.ContinueWithSettingsUI)
+		require.True(t, ok, "%T", f.ContinueWith()[0])
+		assert.Equal(t, "http://localhost/settings?flow="+cw.Flow.ID.String(), f.ReturnToSettings)
+
+		sf, err := reg.SettingsFlowPersister().GetSettingsFlow(ctx, cw.Flow.ID)
+		require.NoError(t, err)
+		assert.Equal(t, i.ID, sf.IdentityID)
+		assert.Equal(t, "https://www.ory.sh/", sf.ReturnTo)
+		require.Len(t, sf.UI.Messages, 1)
+		assert.Equal(t, text.InfoSelfServiceSettingsLegalDocumentsRequired, sf.UI.Messages[0].ID)
+	})
+
+	t.Run("case=nothing was accepted", func(t *testing.T) {
+		f, s := execute(t, createIdentity(t))
+		assert.Len(t, f.ContinueWith(), 1)
+		assert.True(t, s.PendingRequirements.Has(session.RequirementLegalDocumentsPending))
+	})
+
+	t.Run("case=method is disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".legal.enabled", false)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".legal.enabled", true)
+		})
+
+		f, s := execute(t, createIdentity(t))
+		assert.Empty(t, f.ContinueWith())
+		assert.False(t, s.PendingRequirements.Has(session.RequirementLegalDocumentsPending))
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+var _ login.PostHookExecutor = new(PasswordExpiry)
+
+type (
+	passwordExpiryDependencies interface {
+		config.Provider
+		identity.PrivilegedPoolProvider
+		settings.HandlerProvider
+		settings.FlowPersistenceProvider
+		logrusx.Provider
+		otelx.Provider
+	}
+
+	PasswordExpiryProvider interface {
+		HookPasswordExpiry() *PasswordExpiry
+	}
+
+	// PasswordExpiry is a post login hook which requires users whose password
+	// is older than the maximum password age of the password policy to choose
+	// a new password in a settings flow.
+	PasswordExpiry struct {
+		d passwordExpiryDependencies
+	}
+)
+
+func NewPasswordExpiry(d passwordExpiryDependencies) *PasswordExpiry {
+	return &PasswordExpiry{d: d}
+}
+
+// ExecuteLoginPostHook creates a settings flow if the user signed in with an
+// expired password. The flow is added to the `continue_with` items and browser
+// clients are redirected to it once the login completes.
+//
+// The expired password is a pending requirement of the session which limits it
+// until the password was changed.
+func (e *PasswordExpiry) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, f *login.Flow, s *session.Session) (err error) {
+	ctx, span := e.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.hook.PasswordExpiry.ExecuteLoginPostHook")
+	r = r.WithContext(ctx)
+	defer otelx.End(span, &err)
+
+	if f.Active != identity.CredentialsTypePassword {
+		return nil
+	}
+
+	policy, err := e.d.Config().PasswordPolicyRules(ctx, s.Identity.SchemaID)
+	if err != nil {
+		return err
+	} else if policy.MaxAge <= 0 {
+		return nil
+	}
+
+	i, err := e.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, s.Identity.ID)
+	if err != nil {
+		return err
+	}
+
+	expired, err := i.PasswordExpired(policy.MaxAge, time.Now())
+	if err != nil {
+		return err
+	}
+	s.SetRequirement(session.RequirementPasswordExpired, expired)
+	if !expired {
+		return nil
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		Debug("The password of the identity expired, requiring a settings flow.")
+
+	return requireSettingsFlow(e.d, w, r, f, s, func(sf *settings.Flow) {
+		sf.UI.Messages.Set(text.NewInfoSelfServiceSettingsPasswordExpired())
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/randx"
+)
+
+func TestPasswordExpiry(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "http://localhost/")
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsURL, "http://localhost/settings")
+	conf.MustSet(ctx, config.ViperKeyPasswordPolicyRules+".max_age", "720h")
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/stub.schema.json")
+
+	h := hook.NewPasswordExpiry(reg)
+
+	createIdentity := func(t *testing.T, changedAt time.Time) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{}`)
+		require.NoError(t, i.SetCredentialsWithConfig(identity.CredentialsTypePassword,
+			identity.Credentials{Identifiers: []string{randx.MustString(16, randx.AlphaLowerNum)}},
+			identity.CredentialsPassword{HashedPassword: "$2a$04$zvZz1zV", ChangedAt: &changedAt}))
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	execute := func(t *testing.T, i *identity.Identity, active identity.CredentialsType) (*login.Flow, *session.Session) {
+		r := httptest.NewRequest("POST", "/self-service/login?flow=1", nil)
+		f := &login.Flow{Type: flow.TypeBrowser, Active: active, RequestURL: "http://localhost/self-service/login/browser?return_to=https://www.ory.sh/"}
+		s := &session.Session{Identity: i}
+		require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), r, node.PasswordGroup, f, s))
+		return f, s
+	}
+
+	t.Run("case=password did not expire", func(t *testing.T) {
+		f, s := execute(t, createIdentity(t, time.Now().Add(-time.Hour)), identity.CredentialsTypePassword)
+		assert.Empty(t, f.ContinueWith())
+		assert.Empty(t, f.ReturnToSettings)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementPasswordExpired))
+	})
+
+	t.Run("case=other method was used", func(t *testing.T) {
+		f, s := execute(t, createIdentity(t, time.Now().Add(-1000*time.Hour)), identity.CredentialsTypeCodeAuth)
+		assert.Empty(t, f.ContinueWith())
+		assert.Empty(t, f.ReturnToSettings)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementPasswordExpired))
+	})
+
+	t.Run("case=password expired", func(t *testing.T) {
+		i := createIdentity(t, time.Now().Add(-1000*time.Hour))
+		f, s := execute(t, i, identity.CredentialsTypePassword)
+		assert.True(t, s.PendingRequirements.Has(session.RequirementPasswordExpired))
+
+		require.Len(t, f.ContinueWith(), 1)
+		cw, ok := f.ContinueWith()[0].(*flow.ContinueWithSettingsUI)
+		require.True(t, ok, "%T", f.ContinueWith()[0])
+		assert.Equal(t, "http://localhost/settings?flow="+cw.Flow.ID.String(), f.ReturnToSettings)
+
+		sf, err := reg.SettingsFlowPersister().GetSettingsFlow(ctx, cw.Flow.ID)
+		require.NoError(t, err)
+		assert.Equal(t, i.ID, sf.IdentityID)
+		assert.Equal(t, "https://www.ory.sh/", sf.ReturnTo)
+		require.Len(t, sf.UI.Messages, 1)
+		assert.Equal(t, text.InfoSelfServiceSettingsPasswordExpired, sf.UI.Messages[0].ID)
+	})
+
+	t.Run("case=passwords without change date are expired", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{}`)
+		require.NoError(t, i.SetCredentialsWithConfig(identity.CredentialsTypePassword,
+			identity.Credentials{Identifiers: []string{randx.MustString(16, randx.AlphaLowerNum)}},
+			json.RawMessage(`{"hashed_password":"$2a$04$zvZz1zV"}`)))
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		f, s := execute(t, i, identity.CredentialsTypePassword)
+		assert.Len(t, f.ContinueWith(), 1)
+		assert.True(t, s.PendingRequirements.Has(session.RequirementPasswordExpired))
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+var _ login.PostHookExecutor = new(ProfileCompletion)
+
+type (
+	profileCompletionDependencies interface {
+		config.Provider
+		identity.ValidationProvider
+		identity.TraitsClassifierProvider
+		identity.ProfilePromptPersistenceProvider
+		settings.HandlerProvider
+		settings.FlowPersistenceProvider
+		logrusx.Provider
+		otelx.Provider
+	}
+
+	ProfileCompletionProvider interface {
+		HookProfileCompletion() *ProfileCompletion
+	}
+
+	// ProfileCompletion is a post login hook which asks users whose identity
+	// does not satisfy its current identity schema for the missing traits in
+	// a settings flow. Optional traits marked as "ask once" in the identity
+	// schema are asked for in the same flow, but only once per identity.
+	ProfileCompletion struct {
+		d profileCompletionDependencies
+	}
+)
+
+func NewProfileCompletion(d profileCompletionDependencies) *ProfileCompletion {
+	return &ProfileCompletion{d: d}
+}
+
+// ExecuteLoginPostHook creates a settings flow which only asks for the missing
+// traits if the identity is incomplete. The flow is added to the
+// `continue_with` items and browser clients are redirected to it once the
+// login completes.
+//
+// If required traits are missing, the incomplete profile is a pending
+// requirement of the session which limits it until the traits were provided.
+func (e *ProfileCompletion) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, f *login.Flow, s *session.Session) (err error) {
+	ctx, span := e.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.hook.ProfileCompletion.ExecuteLoginPostHook")
+	r = r.WithContext(ctx)
+	defer otelx.End(span, &err)
+
+	missing, err := e.d.IdentityValidator().MissingTraits(ctx, s.Identity)
+	if err != nil {
+		return err
+	}
+	s.SetRequirement(session.RequirementProfileIncomplete, len(missing) > 0)
+
+	classification, err := e.d.IdentityTraitsClassifier().Classify(ctx, s.Identity.SchemaID)
+	if err != nil {
+		return err
+	}
+
+	var askOnce []string
+	if unset := classification.UnsetAskOnce(s.Identity.Traits); len(unset) > 0 {
+		prompted, err := e.d.ProfilePromptPersister().ListProfilePrompts(ctx, s.Identity.ID)
+		if err != nil {
+			return err
+		}
+		for _, trait := range unset {
+			if !slices.Contains(prompted, trait) && !slices.Contains(missing, trait) {
+				askOnce = append(askOnce, trait)
+			}
+		}
+	}
+
+	asked := slices.Concat(missing, askOnce)
+	if len(asked) == 0 {
+		return nil
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.Identity.ID).
+		WithField("traits", asked).
+		Debug("The identity profile is incomplete, requiring a settings flow.")
+
+	if err := requireSettingsFlow(e.d, w, r, f, s, func(sf *settings.Flow) {
+		sf.UI.Nodes = restrictProfileNodes(sf.UI.Nodes, asked)
+		sf.UI.Messages.Set(text.NewInfoSelfServiceSettingsProfileIncomplete(asked))
+	}); err != nil {
+		return err
+	}
+
+	return e.d.ProfilePromptPersister().CreateProfilePrompts(ctx, s.Identity.ID, askOnce)
+}
+
+// restrictProfileNodes removes all nodes except the ones of the profile method
+// and hides the traits which are not asked for. The hidden traits are still
+// submitted, so that updating the profile does not remove them.
+func restrictProfileNodes(nodes node.Nodes, asked []string) node.Nodes {
+	isAsked := func(name string) bool {
+		return slices.ContainsFunc(asked, func(trait string) bool {
+			return name == trait || strings.HasPrefix(name, trait+".")
+		})
+	}
+
+	result := make(node.Nodes, 0, len(nodes))
+	for _, n := range nodes {
+		switch n.Group {
+		case node.DefaultGroup:
+		case node.ProfileGroup:
+			if a, ok := n.Attributes.(*node.InputAttributes); ok && strings.HasPrefix(a.Name, "traits.") && !isAsked(a.Name) {
+				a.Type = node.InputAttributeTypeHidden
+				n.Meta = &node.Meta{}
+			}
+		default:
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
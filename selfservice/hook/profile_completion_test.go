@@ -0,0 +1,122 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+)
+
+func TestProfileCompletion(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "http://localhost/")
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsURL, "http://localhost/settings")
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginProfileCompletionEnabled, true)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/profile_completion.schema.json")
+
+	h := hook.NewProfileCompletion(reg)
+
+	createIdentity := func(t *testing.T, traits string) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(traits)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	execute := func(t *testing.T, s *session.Session) *login.Flow {
+		r := httptest.NewRequest("POST", "/self-service/login?flow=1", nil)
+		f := &login.Flow{Type: flow.TypeBrowser, RequestURL: "http://localhost/self-service/login/browser?return_to=https://www.ory.sh/"}
+		require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), r, node.PasswordGroup, f, s))
+		return f
+	}
+
+	settingsFlow := func(t *testing.T, f *login.Flow) *settings.Flow {
+		require.Len(t, f.ContinueWith(), 1)
+		cw, ok := f.ContinueWith()[0].(*flow.ContinueWithSettingsUI)
+		require.True(t, ok, "%T", f.ContinueWith()[0])
+		assert.Equal(t, "http://localhost/settings?flow="+cw.Flow.ID.String(), f.ReturnToSettings)
+
+		sf, err := reg.SettingsFlowPersister().GetSettingsFlow(ctx, cw.Flow.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://www.ory.sh/", sf.ReturnTo)
+		require.Len(t, sf.UI.Messages, 1)
+		assert.Equal(t, text.InfoSelfServiceSettingsProfileIncomplete, sf.UI.Messages[0].ID)
+		for _, n := range sf.UI.Nodes {
+			assert.Contains(t, []node.UiNodeGroup{node.DefaultGroup, node.ProfileGroup}, n.Group, n.ID())
+		}
+		return sf
+	}
+
+	inputType := func(t *testing.T, sf *settings.Flow, name string) node.UiNodeInputAttributeType {
+		n := sf.UI.Nodes.Find(name)
+		require.NotNil(t, n, name)
+		return n.Attributes.(*node.InputAttributes).Type
+	}
+
+	t.Run("case=profile is complete", func(t *testing.T) {
+		s := &session.Session{Identity: createIdentity(t, `{"email":"foo@ory.sh","name":{"first":"Foo"},"website":"https://www.ory.sh"}`)}
+		f := execute(t, s)
+		assert.Empty(t, f.ContinueWith())
+		assert.Empty(t, f.ReturnToSettings)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementProfileIncomplete))
+	})
+
+	t.Run("case=required traits are missing", func(t *testing.T) {
+		s := &session.Session{Identity: createIdentity(t, `{"email":"foo@ory.sh","website":"https://www.ory.sh"}`)}
+		f := execute(t, s)
+		assert.True(t, s.PendingRequirements.Has(session.RequirementProfileIncomplete))
+
+		sf := settingsFlow(t, f)
+		assert.JSONEq(t, `{"traits":["traits.name"]}`, string(sf.UI.Messages[0].Context))
+		assert.NotEqual(t, node.InputAttributeTypeHidden, inputType(t, sf, "traits.name.first"))
+		assert.Equal(t, node.InputAttributeTypeHidden, inputType(t, sf, "traits.email"))
+		assert.Equal(t, node.InputAttributeTypeHidden, inputType(t, sf, "traits.website"))
+	})
+
+	t.Run("case=ask once traits are only asked once", func(t *testing.T) {
+		s := &session.Session{Identity: createIdentity(t, `{"email":"foo@ory.sh","name":{"first":"Foo"}}`)}
+		f := execute(t, s)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementProfileIncomplete))
+
+		sf := settingsFlow(t, f)
+		assert.NotEqual(t, node.InputAttributeTypeHidden, inputType(t, sf, "traits.website"))
+		assert.Equal(t, node.InputAttributeTypeHidden, inputType(t, sf, "traits.name.first"))
+
+		prompted, err := reg.ProfilePromptPersister().ListProfilePrompts(ctx, s.Identity.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"traits.website"}, prompted)
+
+		f = execute(t, s)
+		assert.Empty(t, f.ContinueWith())
+	})
+
+	t.Run("case=completed profile clears the limitation", func(t *testing.T) {
+		s := &session.Session{Identity: createIdentity(t, `{"email":"foo@ory.sh","website":"https://www.ory.sh"}`)}
+		execute(t, s)
+		require.True(t, s.PendingRequirements.Has(session.RequirementProfileIncomplete))
+
+		s.Identity.Traits = identity.Traits(`{"email":"foo@ory.sh","name":{"first":"Foo"},"website":"https://www.ory.sh"}`)
+		f := execute(t, s)
+		assert.False(t, s.PendingRequirements.Has(session.RequirementProfileIncomplete))
+		assert.Empty(t, f.ContinueWith())
+	})
+}
@@ -0,0 +1,297 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/selfservice/flow/revokesessions"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+// newDeviceHistorySize is the number of previous sessions a sign-in is
+// compared against to decide whether the device is new.
+const newDeviceHistorySize = 100
+
+var (
+	_ login.PostHookExecutor               = new(SecurityNotifier)
+	_ recovery.PostHookExecutor            = new(SecurityNotifier)
+	_ settings.PostHookPostPersistExecutor = new(SecurityNotifier)
+)
+
+type (
+	securityNotifierDependencies interface {
+		config.Provider
+		courier.Provider
+		courier.ConfigProvider
+		httpx.ClientProvider
+		identity.PrivilegedPoolProvider
+		session.PersistenceProvider
+		logrusx.Provider
+	}
+
+	SecurityNotifierProvider interface {
+		HookSecurityNotifier() *SecurityNotifier
+	}
+
+	// SecurityNotifier emails the identity about security relevant changes of
+	// their account: a changed password, added or removed second factors, a
+	// used recovery flow, a sign-in from a new device, and a changed email
+	// address. Notifications are best effort and never fail the flow.
+	//
+	// Email changes which the verify_new_address hook defers until the new
+	// address is verified are not reported.
+	SecurityNotifier struct {
+		r securityNotifierDependencies
+	}
+)
+
+func NewSecurityNotifier(r securityNotifierDependencies) *SecurityNotifier {
+	return &SecurityNotifier{r: r}
+}
+
+func (e *SecurityNotifier) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, _ *login.Flow, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.SecurityNotifier.ExecuteLoginPostHook", func(ctx context.Context) error {
+		newDevice, err := e.isNewDevice(ctx, s)
+		if err != nil {
+			e.warn(r, s.IdentityID, err)
+			return nil
+		}
+		if !newDevice {
+			return nil
+		}
+
+		e.notifyIdentity(ctx, r, s.IdentityID, template.TypeSecurityNewDeviceLogin, email.SecurityNotificationModel{})
+		return nil
+	})
+}
+
+func (e *SecurityNotifier) ExecutePostRecoveryHook(_ http.ResponseWriter, r *http.Request, _ *recovery.Flow, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.SecurityNotifier.ExecutePostRecoveryHook", func(ctx context.Context) error {
+		e.notifyIdentity(ctx, r, s.IdentityID, template.TypeSecurityRecoveryUsed, email.SecurityNotificationModel{})
+		return nil
+	})
+}
+
+func (e *SecurityNotifier) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, r *http.Request, _ *settings.Flow, i *identity.Identity, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.SecurityNotifier.ExecuteSettingsPostPersistHook", func(ctx context.Context) error {
+		// The session still holds the identity as it was before the update.
+		original := s.Identity
+		if original == nil {
+			return nil
+		}
+		recipients := emailAddresses(i)
+
+		if passwordChanged(original, i) {
+			e.notify(ctx, r, i, template.TypeSecurityPasswordChanged, email.SecurityNotificationModel{}, recipients)
+		}
+
+		for _, ct := range []identity.CredentialsType{
+			identity.CredentialsTypeTOTP,
+			identity.CredentialsTypeWebAuthn,
+			identity.CredentialsTypePasskey,
+			identity.CredentialsTypeLookup,
+		} {
+			before, after := secondFactors(original, ct), secondFactors(i, ct)
+			switch {
+			case after > before:
+				e.notify(ctx, r, i, template.TypeSecurityMFAEnrolled, email.SecurityNotificationModel{Method: string(ct)}, recipients)
+			case after < before:
+				e.notify(ctx, r, i, template.TypeSecurityMFARemoved, email.SecurityNotificationModel{Method: string(ct)}, recipients)
+			}
+		}
+
+		// The old address is notified because whoever changed it now controls
+		// the new one.
+		var newAddress string
+		if added := subtract(recipients, emailAddresses(original)); len(added) > 0 {
+			newAddress = added[0]
+		}
+		if removed := subtract(emailAddresses(original), recipients); len(removed) > 0 {
+			e.notify(ctx, r, i, template.TypeSecurityEmailChanged, email.SecurityNotificationModel{
+				NewAddress:        newAddress,
+				RevokeSessionsURL: revokesessions.NewLink(ctx, e.r.Config(), i).String(),
+			}, removed)
+		}
+
+		return nil
+	})
+}
+
+// isNewDevice returns true if none of the identity's previous sessions was
+// created with the user agent of the session. The first session of an
+// identity is not considered to be from a new device.
+func (e *SecurityNotifier) isNewDevice(ctx context.Context, s *session.Session) (bool, error) {
+	if len(s.Devices) == 0 || s.Devices[len(s.Devices)-1].UserAgent == nil {
+		return false, nil
+	}
+	userAgent := *s.Devices[len(s.Devices)-1].UserAgent
+
+	previous, _, err := e.r.SessionPersister().ListSessionsByIdentity(ctx, s.IdentityID, nil, 1, newDeviceHistorySize, s.ID, session.Expandables{session.ExpandSessionDevices})
+	if err != nil {
+		return false, err
+	}
+	if len(previous) == 0 {
+		return false, nil
+	}
+
+	for _, p := range previous {
+		for _, d := range p.Devices {
+			if d.UserAgent != nil && *d.UserAgent == userAgent {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func (e *SecurityNotifier) notifyIdentity(ctx context.Context, r *http.Request, identityID uuid.UUID, t template.TemplateType, m email.SecurityNotificationModel) {
+	i, err := e.r.PrivilegedIdentityPool().GetIdentity(ctx, identityID, identity.ExpandDefault)
+	if err != nil {
+		e.warn(r, identityID, err)
+		return
+	}
+	e.notify(ctx, r, i, t, m, emailAddresses(i))
+}
+
+func (e *SecurityNotifier) notify(ctx context.Context, r *http.Request, i *identity.Identity, t template.TemplateType, m email.SecurityNotificationModel, recipients []string) {
+	if err := e.queue(ctx, r, i, t, m, recipients); err != nil {
+		e.warn(r, i.ID, err)
+	}
+}
+
+func (e *SecurityNotifier) queue(ctx context.Context, r *http.Request, i *identity.Identity, t template.TemplateType, m email.SecurityNotificationModel, recipients []string) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	model, err := x.StructToMap(i.CopyWithoutCredentials())
+	if err != nil {
+		return err
+	}
+
+	c, err := e.r.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Describe the device the same way sessions do.
+	var probe session.Session
+	probe.SetSessionDeviceInformation(r)
+	device := probe.Devices[0]
+
+	m.Identity = model
+	m.IPAddress = stringOrEmpty(device.IPAddress)
+	m.UserAgent = stringOrEmpty(device.UserAgent)
+	m.Location = stringOrEmpty(device.Location)
+	m.OccurredAt = time.Now().UTC().Format(time.RFC3339)
+
+	for _, to := range recipients {
+		m.To = to
+		tpl, err := email.NewSecurityNotification(e.r, t, &m)
+		if err != nil {
+			return err
+		}
+		if _, err := c.QueueEmail(ctx, tpl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *SecurityNotifier) warn(r *http.Request, identityID uuid.UUID, err error) {
+	e.r.Logger().
+		WithRequest(r).
+		WithField("identity_id", identityID).
+		WithError(err).
+		Warn("Unable to send the security notification.")
+}
+
+// emailAddresses returns the identity's verifiable email addresses.
+func emailAddresses(i *identity.Identity) []string {
+	var addresses []string
+	for _, a := range i.VerifiableAddresses {
+		if a.Via == identity.AddressTypeEmail {
+			addresses = append(addresses, a.Value)
+		}
+	}
+	return addresses
+}
+
+// subtract returns the values of a which are not in b.
+func subtract(a, b []string) []string {
+	var result []string
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// passwordChanged returns true if the updated identity has a password whose
+// hash differs from the original one.
+func passwordChanged(original, updated *identity.Identity) bool {
+	after := passwordHash(updated)
+	return after != "" && after != passwordHash(original)
+}
+
+func passwordHash(i *identity.Identity) string {
+	c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+	if !ok {
+		return ""
+	}
+	return gjson.GetBytes(c.Config, "hashed_password").String()
+}
+
+// secondFactors returns the number of authenticators the identity has set up
+// for the credentials type.
+func secondFactors(i *identity.Identity, ct identity.CredentialsType) int {
+	c, ok := i.GetCredentials(ct)
+	if !ok {
+		return 0
+	}
+
+	switch ct {
+	case identity.CredentialsTypeTOTP:
+		conf, err := identity.NewCredentialsTOTPConfig(*c)
+		if err != nil {
+			return 0
+		}
+		return len(conf.Devices)
+	case identity.CredentialsTypeLookup:
+		if gjson.GetBytes(c.Config, "recovery_codes.#").Int() > 0 {
+			return 1
+		}
+		return 0
+	default:
+		return int(gjson.GetBytes(c.Config, "credentials.#").Int())
+	}
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
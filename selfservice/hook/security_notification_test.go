@@ -0,0 +1,170 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func TestSecurityNotifier(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/verify_single_email.schema.json")
+
+	h := hook.NewSecurityNotifier(reg)
+
+	newRequest := func(userAgent string) *http.Request {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.Header.Set("User-Agent", userAgent)
+		return r
+	}
+
+	messagesTo := func(t *testing.T, recipient string) []courier.Message {
+		messages, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{
+			Recipient: recipient,
+		}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		return messages
+	}
+
+	withEmail := func(address string) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.ID = uuid.Must(uuid.NewV4())
+		i.Traits = identity.Traits(`{"email":"` + address + `"}`)
+		i.VerifiableAddresses = []identity.VerifiableAddress{{Value: address, Via: identity.AddressTypeEmail}}
+		return i
+	}
+
+	t.Run("method=ExecuteSettingsPostPersistHook", func(t *testing.T) {
+		t.Run("case=password changed", func(t *testing.T) {
+			address := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+			original := withEmail(address)
+			original.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{Config: []byte(`{"hashed_password":"old"}`)})
+			updated := *original
+			updated.Credentials = nil
+			updated.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{Config: []byte(`{"hashed_password":"new"}`)})
+
+			require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest("agent"), nil, &updated, &session.Session{Identity: original}))
+
+			messages := messagesTo(t, address)
+			require.Len(t, messages, 1)
+			assert.Equal(t, template.TypeSecurityPasswordChanged, messages[0].TemplateType)
+		})
+
+		t.Run("case=unchanged password is not reported", func(t *testing.T) {
+			address := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+			original := withEmail(address)
+			original.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{Config: []byte(`{"hashed_password":"same"}`)})
+
+			require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest("agent"), nil, original, &session.Session{Identity: original}))
+
+			assert.Empty(t, messagesTo(t, address))
+		})
+
+		t.Run("case=second factor enrolled and removed", func(t *testing.T) {
+			address := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+			without := withEmail(address)
+			with := *without
+			with.Credentials = nil
+			totp, err := json.Marshal(identity.CredentialsTOTPConfig{Devices: []identity.CredentialTOTPDevice{{ID: uuid.Must(uuid.NewV4()), TOTPURL: "otpauth://totp/ory", AddedAt: time.Now()}}})
+			require.NoError(t, err)
+			with.SetCredentials(identity.CredentialsTypeTOTP, identity.Credentials{Config: totp})
+
+			require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest("agent"), nil, &with, &session.Session{Identity: without}))
+			require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest("agent"), nil, without, &session.Session{Identity: &with}))
+
+			messages := messagesTo(t, address)
+			require.Len(t, messages, 2)
+			types := []template.TemplateType{messages[0].TemplateType, messages[1].TemplateType}
+			assert.ElementsMatch(t, []template.TemplateType{template.TypeSecurityMFAEnrolled, template.TypeSecurityMFARemoved}, types)
+			assert.Contains(t, messages[0].Body, "totp")
+		})
+
+		t.Run("case=email changed notifies the old address", func(t *testing.T) {
+			oldAddress := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+			newAddress := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+			original := withEmail(oldAddress)
+			updated := withEmail(newAddress)
+			updated.ID = original.ID
+
+			require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest("agent"), nil, updated, &session.Session{Identity: original}))
+
+			messages := messagesTo(t, oldAddress)
+			require.Len(t, messages, 1)
+			assert.Equal(t, template.TypeSecurityEmailChanged, messages[0].TemplateType)
+			assert.Contains(t, messages[0].Body, newAddress)
+			assert.Contains(t, messages[0].Body, "https://www.ory.sh/self-service/revoke-sessions/browser?token=")
+			assert.Empty(t, messagesTo(t, newAddress))
+		})
+	})
+
+	createIdentity := func(t *testing.T) (*identity.Identity, string) {
+		address := uuid.Must(uuid.NewV4()).String() + "@ory.sh"
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + address + `"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		return i, address
+	}
+
+	createSession := func(t *testing.T, reg *driver.RegistryDefault, i *identity.Identity, userAgent string) *session.Session {
+		s, err := testhelpers.NewActiveSession(newRequest(userAgent), reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	t.Run("method=ExecuteLoginPostHook", func(t *testing.T) {
+		i, address := createIdentity(t)
+
+		login := func(t *testing.T, userAgent string) {
+			s := createSession(t, reg, i, userAgent)
+			require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), newRequest(userAgent), node.PasswordGroup, nil, s))
+		}
+
+		login(t, "first-agent")
+		assert.Empty(t, messagesTo(t, address), "the first sign-in is not from a new device")
+
+		login(t, "first-agent")
+		assert.Empty(t, messagesTo(t, address), "the device is known")
+
+		login(t, "second-agent")
+		messages := messagesTo(t, address)
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeSecurityNewDeviceLogin, messages[0].TemplateType)
+		assert.Contains(t, messages[0].Body, "second-agent")
+	})
+
+	t.Run("method=ExecutePostRecoveryHook", func(t *testing.T) {
+		i, address := createIdentity(t)
+		s := createSession(t, reg, i, "agent")
+
+		require.NoError(t, h.ExecutePostRecoveryHook(httptest.NewRecorder(), newRequest("agent"), nil, s))
+
+		messages := messagesTo(t, address)
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeSecurityRecoveryUsed, messages[0].TemplateType)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"net/http"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/redir"
+)
+
+type settingsRequirementDependencies interface {
+	config.Provider
+	settings.HandlerProvider
+	settings.FlowPersistenceProvider
+}
+
+// requireSettingsFlow creates the settings flow in which the user takes care
+// of what a post login hook asks for. The flow is added to the `continue_with`
+// items and browser clients are redirected to it once the login completes.
+//
+// The update function prepares the flow before it is stored, for example by
+// setting the message which explains why the flow is shown.
+func requireSettingsFlow(d settingsRequirementDependencies, w http.ResponseWriter, r *http.Request, f *login.Flow, s *session.Session, update func(*settings.Flow)) error {
+	ctx := r.Context()
+
+	sf, err := d.SettingsHandler().NewFlow(ctx, w, r, s.Identity, s, f.Type)
+	if err != nil {
+		return err
+	}
+
+	sf.RequestURL, err = redir.TakeOverReturnToParameter(f.RequestURL, sf.RequestURL, f.ReturnTo)
+	if err != nil {
+		return err
+	}
+	update(sf)
+	if err := d.SettingsFlowPersister().UpdateSettingsFlow(ctx, sf); err != nil {
+		return err
+	}
+
+	redirectTo := sf.AppendTo(d.Config().SelfServiceFlowSettingsUI(ctx)).String()
+	f.AddContinueWith(flow.NewContinueWithSettingsUI(sf, redirectTo))
+	if x.IsBrowserRequest(r) {
+		f.SetReturnToSettings(redirectTo)
+	}
+
+	return nil
+}
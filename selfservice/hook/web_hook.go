@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.11.0"
@@ -78,6 +79,7 @@ type (
 		otelx.Provider
 		jsonnetsecure.VMProvider
 		config.Provider
+		identity.TraitsClassifierProvider
 	}
 
 	templateContext struct {
@@ -368,7 +370,12 @@ func (e *WebHook) execute(ctx context.Context, data *templateContext) error {
 
 		data.RequestHeaders = RemoveDisallowedHeaders(data.RequestHeaders, e.deps.Config().WebhookHeaderAllowlist(ctx))
 
-		req, err := builder.BuildRequest(ctx, data)
+		payload, err := e.redactPayload(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		req, err := builder.BuildRequest(ctx, payload)
 		if errors.Is(err, request.ErrCancel) {
 			span.SetAttributes(attribute.Bool("webhook.jsonnet.canceled", true))
 			return nil
@@ -439,6 +446,74 @@ func (e *WebHook) execute(ctx context.Context, data *templateContext) error {
 	return nil
 }
 
+// redactPayload returns a copy of the template context in which all identity
+// traits classified as PII are redacted, unless the web hook is configured to
+// include them.
+func (e *WebHook) redactPayload(ctx context.Context, data *templateContext) (*templateContext, error) {
+	if e.conf.IncludePII {
+		return data, nil
+	}
+
+	payload := *data
+	redacted, err := e.deps.IdentityTraitsClassifier().WithRedactedTraits(ctx, data.Identity)
+	if err != nil {
+		return nil, err
+	}
+	payload.Identity = redacted
+
+	if data.Session != nil && data.Session.Identity != nil {
+		s := *data.Session
+		s.Identity, err = e.deps.IdentityTraitsClassifier().WithRedactedTraits(ctx, data.Session.Identity)
+		if err != nil {
+			return nil, err
+		}
+		payload.Session = &s
+	}
+
+	if data.Flow != nil {
+		schemaID := e.deps.Config().DefaultIdentityTraitsSchemaID(ctx)
+		if data.Identity != nil && data.Identity.SchemaID != "" {
+			schemaID = data.Identity.SchemaID
+		} else if data.Session != nil && data.Session.Identity != nil && data.Session.Identity.SchemaID != "" {
+			schemaID = data.Session.Identity.SchemaID
+		}
+
+		classification, err := e.deps.IdentityTraitsClassifier().Classify(ctx, schemaID)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := json.Marshal(data.Flow)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for k, n := range gjson.GetBytes(raw, "ui.nodes").Array() {
+			value := n.Get("attributes.value")
+			if !value.Exists() || value.Type == gjson.Null || !classification.IsPII(n.Get("attributes.name").String()) {
+				continue
+			}
+			raw, err = sjson.SetBytes(raw, fmt.Sprintf("ui.nodes.%d.attributes.value", k), identity.RedactedTraitValue)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		payload.Flow = &redactedFlow{Flow: data.Flow, raw: raw}
+	}
+
+	return &payload, nil
+}
+
+// redactedFlow serializes to the flow with the values of all UI nodes of PII
+// traits redacted.
+type redactedFlow struct {
+	flow.Flow
+	raw json.RawMessage
+}
+
+func (f *redactedFlow) MarshalJSON() ([]byte, error) {
+	return f.raw, nil
+}
+
 // RemoveDisallowedHeaders removes all headers from httpHeaders that are not in
 // headerAllowlist.
 func RemoveDisallowedHeaders(httpHeaders http.Header, headerAllowlist []string) http.Header {
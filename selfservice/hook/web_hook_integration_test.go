@@ -33,6 +33,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/request"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -44,6 +45,7 @@ import (
 	"github.com/ory/kratos/selfservice/hook"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/events"
@@ -65,6 +67,7 @@ type webHookDeps struct {
 	x.BasicRegistry
 	jsonnetsecure.VMProvider
 	config.Provider
+	identity.TraitsClassifierProvider
 }
 
 func newWebHookDeps(t *testing.T, logger *logrusx.Logger, reg *driver.RegistryDefault) *webHookDeps {
@@ -73,6 +76,8 @@ func newWebHookDeps(t *testing.T, logger *logrusx.Logger, reg *driver.RegistryDe
 		BasicRegistry: x.BasicRegistry{L: logger, C: reg.HTTPClient(t.Context()), T: otelx.NewNoop()},
 		VMProvider:    reg,
 		Provider:      reg,
+
+		TraitsClassifierProvider: reg,
 	}
 }
 
@@ -1431,3 +1436,52 @@ func TestRemoveDisallowedHeaders(t *testing.T) {
 		require.Equal(t, []string{"text/html"}, h)
 	})
 }
+
+func TestWebHookRedactsPII(t *testing.T) {
+	logger := logrusx.New("kratos", "test")
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/pii.schema.json")
+
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(ts.Close)
+
+	execute := func(t *testing.T, includePII bool) []byte {
+		req := &http.Request{
+			Header: map[string][]string{"Some-Header": {"Some-Value"}},
+			Host:   "www.ory.com",
+			TLS:    new(tls.ConnectionState),
+			URL:    &url.URL{Path: "/some_end_point"},
+			Method: http.MethodPost,
+		}
+		f := &registration.Flow{ID: x.NewUUID(), UI: container.New("")}
+		f.UI.Nodes.Append(node.NewInputField("traits.email", "foo@ory.sh", node.DefaultGroup, node.InputAttributeTypeEmail))
+		f.UI.Nodes.Append(node.NewInputField("traits.nickname", "foo", node.DefaultGroup, node.InputAttributeTypeText))
+		i := &identity.Identity{ID: x.NewUUID(), SchemaID: config.DefaultIdentityTraitsSchemaID, Traits: identity.Traits(`{"email":"foo@ory.sh","nickname":"foo"}`)}
+
+		wh := hook.NewWebHook(newWebHookDeps(t, logger, reg), &request.Config{
+			Method:      "POST",
+			URL:         ts.URL,
+			TemplateURI: "file://./stub/pii_body.jsonnet",
+			IncludePII:  includePII,
+		})
+		require.NoError(t, wh.ExecutePostRegistrationPrePersistHook(nil, req, f, i))
+		return body
+	}
+
+	t.Run("case=redacts pii traits and ui node values", func(t *testing.T) {
+		body := execute(t, false)
+		assert.Equal(t, identity.RedactedTraitValue, gjson.GetBytes(body, "traits.email").String(), "%s", body)
+		assert.Equal(t, "foo", gjson.GetBytes(body, "traits.nickname").String(), "%s", body)
+		assert.Equal(t, identity.RedactedTraitValue, gjson.GetBytes(body, `nodes.#(attributes.name=="traits.email").attributes.value`).String(), "%s", body)
+		assert.Equal(t, "foo", gjson.GetBytes(body, `nodes.#(attributes.name=="traits.nickname").attributes.value`).String(), "%s", body)
+	})
+
+	t.Run("case=includes pii if configured", func(t *testing.T) {
+		body := execute(t, true)
+		assert.Equal(t, "foo@ory.sh", gjson.GetBytes(body, "traits.email").String(), "%s", body)
+		assert.Equal(t, "foo@ory.sh", gjson.GetBytes(body, `nodes.#(attributes.name=="traits.email").attributes.value`).String(), "%s", body)
+	})
+}
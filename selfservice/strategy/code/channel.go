@@ -0,0 +1,226 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package code
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+)
+
+// Delivery describes where a code was sent to.
+type Delivery struct {
+	Address
+
+	// Fallback is true if the code was sent over a fallback channel because
+	// dispatching it over the requested channel failed.
+	Fallback bool
+}
+
+func findChannel(channels []config.CodeChannel, id string) (config.CodeChannel, bool) {
+	k := slices.IndexFunc(channels, func(c config.CodeChannel) bool { return c.ID == id })
+	if k < 0 {
+		return config.CodeChannel{}, false
+	}
+	return channels[k], true
+}
+
+// resolveChannel returns the channel with the given ID, which must deliver to
+// addresses of the given type. If the ID is empty, the first channel for the
+// address type is returned.
+func resolveChannel(channels []config.CodeChannel, via identity.CodeChannel, id string) (config.CodeChannel, error) {
+	if id == "" {
+		id = string(via)
+		if k := slices.IndexFunc(channels, func(c config.CodeChannel) bool { return c.Via == string(via) }); k >= 0 {
+			return channels[k], nil
+		}
+	}
+
+	c, ok := findChannel(channels, id)
+	if !ok || c.Via != string(via) {
+		return config.CodeChannel{}, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The code delivery channel %q is not available for %s addresses.", id, via))
+	}
+	return c, nil
+}
+
+// deliver sends the template built for the address over the address' channel
+// and, if dispatching fails, over the channel's fallbacks in order. A fallback
+// channel with a different address type delivers to the first of the
+// alternative addresses with that type and is skipped if there is none.
+//
+// All but the last attempt are dispatched right away to detect failures. The
+// last attempt is queued for the courier worker, which retries it. build is
+// called right before every attempt is queued.
+func (s *Sender) deliver(ctx context.Context, to Address, alternatives []Address, build func(Address) (courier.Template, error)) (Delivery, error) {
+	channels, err := s.deps.Config().SelfServiceCodeMethodChannels(ctx)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	primary, err := resolveChannel(channels, to.Via, to.Channel)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	attempts := []Delivery{{Address: Address{To: to.To, Via: to.Via, Channel: primary.ID}}}
+	for _, id := range primary.Fallback {
+		c, ok := findChannel(channels, id)
+		if !ok {
+			s.deps.Logger().
+				WithField("channel", primary.ID).
+				WithField("fallback", id).
+				Warn("Skipping unknown fallback code delivery channel.")
+			continue
+		}
+
+		attempt := Delivery{Address: Address{To: to.To, Via: identity.CodeChannel(c.Via), Channel: c.ID}, Fallback: true}
+		if attempt.Via != to.Via {
+			k := slices.IndexFunc(alternatives, func(a Address) bool { return a.Via == attempt.Via })
+			if k < 0 {
+				continue
+			}
+			attempt.To = alternatives[k].To
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	c, err := s.deps.Courier(ctx)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	for k, attempt := range attempts {
+		t, err := build(attempt.Address)
+		if err != nil {
+			return Delivery{}, err
+		}
+
+		if k == len(attempts)-1 {
+			if _, err := s.queue(ctx, c, attempt.Address, t); err != nil {
+				return Delivery{}, err
+			}
+			return attempt, nil
+		}
+
+		id, err := s.queue(ctx, c, attempt.Address, t, courier.WithoutWorker())
+		if err != nil {
+			return Delivery{}, err
+		}
+		if err := c.DispatchNow(ctx, id); err != nil {
+			s.deps.Logger().
+				WithError(err).
+				WithField("channel", attempt.Channel).
+				WithField("message_id", id).
+				Warn("Unable to dispatch code, trying the next fallback channel.")
+			continue
+		}
+		return attempt, nil
+	}
+
+	// Unreachable because there is at least one attempt.
+	return Delivery{}, errors.WithStack(herodot.ErrInternalServerError().WithReason("No code delivery channel was attempted."))
+}
+
+func (s *Sender) queue(ctx context.Context, c courier.Courier, to Address, t courier.Template, opts ...courier.QueueOption) (uuid.UUID, error) {
+	opts = append(opts, courier.WithChannel(to.Channel))
+	switch to.Via {
+	case identity.CodeChannelEmail:
+		t, ok := t.(courier.EmailTemplate)
+		if !ok {
+			return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email template but got %T", t))
+		}
+		return c.QueueEmail(ctx, t, opts...)
+	case identity.CodeChannelSMS:
+		t, ok := t.(courier.SMSTemplate)
+		if !ok {
+			return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected sms template but got %T", t))
+		}
+		return c.QueueSMS(ctx, t, opts...)
+	default:
+		return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", to.Via))
+	}
+}
+
+// applyChannel sets the delivery channel of the addresses the channel
+// delivers to.
+func (s *Strategy) applyChannel(ctx context.Context, addresses []Address, channel string) ([]Address, error) {
+	if channel == "" {
+		return addresses, nil
+	}
+
+	channels, err := s.deps.Config().SelfServiceCodeMethodChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := findChannel(channels, channel)
+	if !ok || !slices.ContainsFunc(addresses, func(a Address) bool { return string(a.Via) == c.Via }) {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The code delivery channel %q is not available for your addresses.", channel))
+	}
+
+	result := make([]Address, len(addresses))
+	for k, a := range addresses {
+		if string(a.Via) == c.Via {
+			a.Channel = c.ID
+		}
+		result[k] = a
+	}
+	return result, nil
+}
+
+// addressType returns the type of an address the user entered. If the user
+// picked a delivery channel, it is the channel's address type. Otherwise, it is
+// inferred from the address format.
+func (s *Strategy) addressType(ctx context.Context, address, channel string) (identity.CodeChannel, error) {
+	if channel == "" {
+		return inferAddressType(address), nil
+	}
+
+	channels, err := s.deps.Config().SelfServiceCodeMethodChannels(ctx)
+	if err != nil {
+		return "", err
+	}
+	c, ok := findChannel(channels, channel)
+	if !ok {
+		return "", errors.WithStack(herodot.ErrBadRequest().WithReasonf("The code delivery channel %q does not exist.", channel))
+	}
+	return identity.CodeChannel(c.Via), nil
+}
+
+// populateDeliveries tells the user where codes sent over a fallback channel
+// went. If offerChannels is true, it also adds a button for every other
+// channel the code can be resent over.
+func (s *Strategy) populateDeliveries(ctx context.Context, f flow.Flow, deliveries []Delivery, offerChannels bool) error {
+	for _, d := range deliveries {
+		if d.Fallback {
+			f.GetUI().Messages.Add(text.NewInfoSelfServiceCodeSentViaFallback(MaskAddress(d.To), d.Channel))
+		}
+	}
+	if !offerChannels {
+		return nil
+	}
+
+	channels, err := s.deps.Config().SelfServiceCodeMethodChannels(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range channels {
+		if !slices.ContainsFunc(deliveries, func(d Delivery) bool { return string(d.Via) == c.Via }) ||
+			slices.ContainsFunc(deliveries, func(d Delivery) bool { return d.Channel == c.ID }) {
+			continue
+		}
+		f.GetUI().Nodes.Append(node.NewInputField("channel", c.ID, node.CodeGroup, node.InputAttributeTypeSubmit).
+			WithMetaLabel(text.NewInfoNodeLabelSendCodeVia(c.ID)))
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/gofrs/uuid"
@@ -58,6 +59,10 @@ type (
 	Address struct {
 		To  string
 		Via identity.CodeChannel
+
+		// Channel is the ID of the courier channel to deliver the code over.
+		// If empty, the first code delivery channel for Via is used.
+		Channel string
 	}
 )
 
@@ -69,136 +74,163 @@ func NewSender(deps senderDependencies) *Sender {
 	return &Sender{deps: deps}
 }
 
-func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identity, header http.Header, addresses ...Address) error {
+func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identity, header http.Header, addresses ...Address) ([]Delivery, error) {
 	s.deps.Logger().
 		WithSensitiveField("address", addresses).
 		Debugf("Preparing %s code", f.GetFlowName())
 
 	transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
+	}
+
+	// Fallback channels with another address type deliver to another address
+	// of the identity.
+	alternatives := addresses
+	if f.GetFlowName() == flow.LoginFlow {
+		candidates, _, err := FindCodeAddressCandidates(id, s.deps.Config().SelfServiceCodeMethodMissingCredentialFallbackEnabled(ctx))
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(slices.Clone(addresses), candidates...)
 	}
 
 	// send to all addresses
+	deliveries := make([]Delivery, 0, len(addresses))
 	for _, address := range addresses {
 		// We have to generate a unique code per address, or otherwise it is not possible to link which
-		// address was used to verify the code.
+		// address was used to verify the code. Fallback channels may deliver to another address, so every
+		// delivery attempt gets its own code, which is persisted before the message is queued.
 		//
 		// See also [this discussion](https://github.com/ory/kratos/pull/3456#discussion_r1307560988).
-		rawCode := GenerateCode()
+		var rawCode string
 
 		switch f.GetFlowName() {
 		case flow.RegistrationFlow:
-			code, err := s.deps.
-				RegistrationCodePersister().
-				CreateRegistrationCode(ctx, &CreateRegistrationCodeParams{
-					AddressType: address.Via,
-					RawCode:     rawCode,
-					ExpiresIn:   s.deps.Config().SelfServiceCodeMethodLifespan(ctx),
-					FlowID:      f.GetID(),
-					Address:     address.To,
-				})
+			model, err := x.StructToMap(id.Traits)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			model, err := x.StructToMap(id.Traits)
+
+			var code *RegistrationCode
+			delivery, err := s.deliver(ctx, address, alternatives, func(to Address) (courier.Template, error) {
+				var err error
+				rawCode = GenerateCode()
+				code, err = s.deps.
+					RegistrationCodePersister().
+					CreateRegistrationCode(ctx, &CreateRegistrationCodeParams{
+						AddressType: to.Via,
+						RawCode:     rawCode,
+						ExpiresIn:   s.deps.Config().SelfServiceCodeMethodLifespan(ctx),
+						FlowID:      f.GetID(),
+						Address:     to.To,
+					})
+				if err != nil {
+					return nil, err
+				}
+
+				switch to.Via {
+				case identity.ChannelTypeEmail:
+					return email.NewRegistrationCodeValid(s.deps, &email.RegistrationCodeValidModel{
+						To:                 to.To,
+						RegistrationCode:   rawCode,
+						Traits:             model,
+						RequestURL:         f.GetRequestURL(),
+						TransientPayload:   transientPayload,
+						ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					}), nil
+				case identity.ChannelTypeSMS:
+					return sms.NewRegistrationCodeValid(s.deps, &sms.RegistrationCodeValidModel{
+						To:                 to.To,
+						RegistrationCode:   rawCode,
+						Identity:           model,
+						RequestURL:         f.GetRequestURL(),
+						TransientPayload:   transientPayload,
+						ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					}), nil
+				}
+				return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", to.Via))
+			})
 			if err != nil {
-				return err
+				return nil, errors.WithStack(err)
 			}
 
 			s.deps.Logger().
 				WithField("registration_flow_id", code.FlowID).
 				WithField("registration_code_id", code.ID).
+				WithField("channel", delivery.Channel).
 				WithSensitiveField("registration_code", rawCode).
-				Info("Sending out registration email with code.")
-
-			var t courier.Template
-			switch address.Via {
-			case identity.ChannelTypeEmail:
-				t = email.NewRegistrationCodeValid(s.deps, &email.RegistrationCodeValidModel{
-					To:                 address.To,
-					RegistrationCode:   rawCode,
-					Traits:             model,
-					RequestURL:         f.GetRequestURL(),
-					TransientPayload:   transientPayload,
-					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-				})
-			case identity.ChannelTypeSMS:
-				t = sms.NewRegistrationCodeValid(s.deps, &sms.RegistrationCodeValidModel{
-					To:                 address.To,
-					RegistrationCode:   rawCode,
-					Identity:           model,
-					RequestURL:         f.GetRequestURL(),
-					TransientPayload:   transientPayload,
-					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-				})
-			}
-
-			if err := s.send(ctx, string(address.Via), t); err != nil {
-				return errors.WithStack(err)
-			}
+				Info("Sent out registration code.")
+			deliveries = append(deliveries, delivery)
 
 		case flow.LoginFlow:
-			code, err := s.deps.
-				LoginCodePersister().
-				CreateLoginCode(ctx, &CreateLoginCodeParams{
-					AddressType: address.Via,
-					Address:     address.To,
-					RawCode:     rawCode,
-					ExpiresIn:   s.deps.Config().SelfServiceCodeMethodLifespan(ctx),
-					FlowID:      f.GetID(),
-					IdentityID:  id.ID,
-				})
+			model, err := x.StructToMap(id)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
-			model, err := x.StructToMap(id)
+			var code *LoginCode
+			delivery, err := s.deliver(ctx, address, alternatives, func(to Address) (courier.Template, error) {
+				var err error
+				rawCode = GenerateCode()
+				code, err = s.deps.
+					LoginCodePersister().
+					CreateLoginCode(ctx, &CreateLoginCodeParams{
+						AddressType: to.Via,
+						Address:     to.To,
+						RawCode:     rawCode,
+						ExpiresIn:   s.deps.Config().SelfServiceCodeMethodLifespan(ctx),
+						FlowID:      f.GetID(),
+						IdentityID:  id.ID,
+					})
+				if err != nil {
+					return nil, err
+				}
+
+				switch to.Via {
+				case identity.ChannelTypeEmail:
+					return email.NewLoginCodeValid(s.deps, &email.LoginCodeValidModel{
+						To:                 to.To,
+						LoginCode:          rawCode,
+						Identity:           model,
+						RequestURL:         f.GetRequestURL(),
+						TransientPayload:   transientPayload,
+						ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					}), nil
+				case identity.ChannelTypeSMS:
+					return sms.NewLoginCodeValid(s.deps, &sms.LoginCodeValidModel{
+						To:                 to.To,
+						LoginCode:          rawCode,
+						Identity:           model,
+						RequestURL:         f.GetRequestURL(),
+						TransientPayload:   transientPayload,
+						ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+						UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					}), nil
+				}
+				return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", to.Via))
+			})
 			if err != nil {
-				return err
+				return nil, errors.WithStack(err)
 			}
+
 			s.deps.Logger().
 				WithField("login_flow_id", code.FlowID).
 				WithField("login_code_id", code.ID).
+				WithField("channel", delivery.Channel).
 				WithSensitiveField("login_code", rawCode).
-				Info("Sending out login email with code.")
-
-			var t courier.Template
-			switch address.Via {
-			case identity.ChannelTypeEmail:
-				t = email.NewLoginCodeValid(s.deps, &email.LoginCodeValidModel{
-					To:                 address.To,
-					LoginCode:          rawCode,
-					Identity:           model,
-					RequestURL:         f.GetRequestURL(),
-					TransientPayload:   transientPayload,
-					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-				})
-			case identity.ChannelTypeSMS:
-				t = sms.NewLoginCodeValid(s.deps, &sms.LoginCodeValidModel{
-					To:                 address.To,
-					LoginCode:          rawCode,
-					Identity:           model,
-					RequestURL:         f.GetRequestURL(),
-					TransientPayload:   transientPayload,
-					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-				})
-			}
-
-			if err := s.send(ctx, string(address.Via), t); err != nil {
-				return errors.WithStack(err)
-			}
+				Info("Sent out login code.")
+			deliveries = append(deliveries, delivery)
 
 		default:
-			return errors.WithStack(errors.New("received unknown flow type"))
+			return nil, errors.WithStack(errors.New("received unknown flow type"))
 
 		}
 	}
-	return nil
+	return deliveries, nil
 }
 
 // SendRecoveryCode sends a recovery code to the specified address
@@ -206,25 +238,27 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 // If the address does not exist in the store and dispatching invalid emails is enabled (CourierEnableInvalidDispatch is
 // true), an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
 // ErrUnknownAddress error.
-func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via, to string, requestHeader http.Header) error {
+func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, to Address, requestHeader http.Header) (Delivery, error) {
+	via := string(to.Via)
 	s.deps.Logger().
 		WithField("via", via).
-		WithSensitiveField("address", to).
+		WithField("channel", to.Channel).
+		WithSensitiveField("address", to.To).
 		Debug("Preparing recovery code.")
 
-	address, err := s.deps.IdentityPool().FindRecoveryAddressByValue(ctx, via, to)
+	address, err := s.deps.IdentityPool().FindRecoveryAddressByValue(ctx, via, to.To)
 	if errors.Is(err, sqlcon.ErrNoRows()) {
 		notifyUnknownRecipients := s.deps.Config().SelfServiceFlowRecoveryNotifyUnknownRecipients(ctx)
 		s.deps.Logger().
 			WithField("via", via).
-			WithSensitiveField("address", to).
+			WithSensitiveField("address", to.To).
 			WithField("strategy", "code").
 			WithField("was_notified", notifyUnknownRecipients).
 			Info("Account recovery was requested for an unknown address.")
 
 		transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 		if err != nil {
-			return errors.WithStack(err)
+			return Delivery{}, errors.WithStack(err)
 		}
 
 		// We only send a notification if the configuration allows it *and* the channel is email.
@@ -234,22 +268,22 @@ func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via, to
 		if !shouldNotifyOfUnkownRecipient {
 			// do nothing
 		} else if err := s.send(ctx, via, email.NewRecoveryCodeInvalid(s.deps, &email.RecoveryCodeInvalidModel{
-			To:               to,
+			To:               to.To,
 			RequestURL:       f.RequestURL,
 			TransientPayload: transientPayload,
 		})); err != nil {
-			return err
+			return Delivery{}, err
 		}
-		return errors.WithStack(ErrUnknownAddress())
+		return Delivery{}, errors.WithStack(ErrUnknownAddress())
 	} else if err != nil {
 		// DB error
-		return err
+		return Delivery{}, err
 	}
 
 	// Get the identity associated with the recovery address
 	i, err := s.deps.IdentityPool().GetIdentity(ctx, address.IdentityID, identity.ExpandDefault)
 	if err != nil {
-		return err
+		return Delivery{}, err
 	}
 
 	rawCode := GenerateCode()
@@ -265,15 +299,18 @@ func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via, to
 			FlowID:          f.ID,
 			IdentityID:      i.ID,
 		}); err != nil {
-		return err
+		return Delivery{}, err
 	}
 
-	return s.SendRecoveryCodeTo(ctx, i, rawCode, code, f, requestHeader)
+	return s.SendRecoveryCodeTo(ctx, i, rawCode, code, f, requestHeader, to.Channel)
 }
 
-func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, codeString string, code *RecoveryCode, f *recovery.Flow, requestHeader http.Header) error {
+// SendRecoveryCodeTo sends the recovery code over the given courier channel,
+// or the default channel for the recovery address if channel is empty.
+func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, codeString string, code *RecoveryCode, f *recovery.Flow, requestHeader http.Header, channel string) (Delivery, error) {
 	s.deps.Logger().
 		WithField("via", code.RecoveryAddress.Via).
+		WithField("channel", channel).
 		WithField("identity_id", code.RecoveryAddress.IdentityID).
 		WithField("recovery_code_id", code.ID).
 		WithSensitiveField("address", code.RecoveryAddress.Value).
@@ -282,48 +319,52 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 
 	model, err := x.StructToMap(i)
 	if err != nil {
-		return err
+		return Delivery{}, err
 	}
 
 	transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 	if err != nil {
-		return errors.WithStack(err)
+		return Delivery{}, errors.WithStack(err)
 	}
 
-	var t courier.Template
-
-	switch code.RecoveryAddress.Via {
-	case identity.AddressTypeEmail:
-		t = email.NewRecoveryCodeValid(s.deps, &email.RecoveryCodeValidModel{
-			To:                 code.RecoveryAddress.Value,
-			RecoveryCode:       codeString,
-			Identity:           model,
-			RequestURL:         f.GetRequestURL(),
-			TransientPayload:   transientPayload,
-			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-			UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-		})
-	case identity.AddressTypeSMS:
-		u, err := url.Parse(f.GetRequestURL())
-		if err != nil {
-			return err
-		}
-
-		t = sms.NewRecoveryCodeValid(s.deps, &sms.RecoveryCodeValidModel{
-			To:                 code.RecoveryAddress.Value,
-			RecoveryCode:       codeString,
-			Identity:           model,
-			RequestURL:         f.GetRequestURL(),
-			RequestURLDomain:   u.Hostname(),
-			TransientPayload:   transientPayload,
-			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-			UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
-		})
-	default:
-		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", code.RecoveryAddress.Via))
-	}
+	// The recovery code is bound to the recovery address, so fallback
+	// channels for other address types are skipped.
+	return s.deliver(ctx, Address{
+		To:      code.RecoveryAddress.Value,
+		Via:     identity.CodeChannel(code.RecoveryAddress.Via),
+		Channel: channel,
+	}, nil, func(to Address) (courier.Template, error) {
+		switch to.Via {
+		case identity.AddressTypeEmail:
+			return email.NewRecoveryCodeValid(s.deps, &email.RecoveryCodeValidModel{
+				To:                 to.To,
+				RecoveryCode:       codeString,
+				Identity:           model,
+				RequestURL:         f.GetRequestURL(),
+				TransientPayload:   transientPayload,
+				ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+				UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+			}), nil
+		case identity.AddressTypeSMS:
+			u, err := url.Parse(f.GetRequestURL())
+			if err != nil {
+				return nil, err
+			}
 
-	return s.send(ctx, code.RecoveryAddress.Via, t)
+			return sms.NewRecoveryCodeValid(s.deps, &sms.RecoveryCodeValidModel{
+				To:                 to.To,
+				RecoveryCode:       codeString,
+				Identity:           model,
+				RequestURL:         f.GetRequestURL(),
+				RequestURLDomain:   u.Hostname(),
+				TransientPayload:   transientPayload,
+				ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+				UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+			}), nil
+		default:
+			return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", to.Via))
+		}
+	})
 }
 
 // SendVerificationCode sends a verification code & link to the specified address
@@ -331,40 +372,42 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 // If the address does not exist in the store and dispatching invalid emails is enabled (CourierEnableInvalidDispatch is
 // true), an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
 // ErrUnknownAddress error.
-func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow, via string, to string) error {
+func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow, to Address) (Delivery, error) {
+	via := string(to.Via)
 	s.deps.Logger().
 		WithField("via", via).
-		WithSensitiveField("address", to).
+		WithField("channel", to.Channel).
+		WithSensitiveField("address", to.To).
 		Debug("Preparing verification code.")
 
-	address, err := s.deps.IdentityPool().FindVerifiableAddressByValue(ctx, via, to)
+	address, err := s.deps.IdentityPool().FindVerifiableAddressByValue(ctx, via, to.To)
 	if errors.Is(err, sqlcon.ErrNoRows()) {
 		notifyUnknownRecipients := s.deps.Config().SelfServiceFlowVerificationNotifyUnknownRecipients(ctx)
 		s.deps.Logger().
 			WithField("via", via).
 			WithField("strategy", "code").
-			WithSensitiveField("email_address", to).
+			WithSensitiveField("email_address", to.To).
 			WithField("was_notified", notifyUnknownRecipients).
 			Info("Address verification was requested for an unknown address.")
 
 		transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 		if err != nil {
-			return errors.WithStack(err)
+			return Delivery{}, errors.WithStack(err)
 		}
 		if !notifyUnknownRecipients || via != identity.AddressTypeEmail {
 			// Only send unknown-address notifications via email. SMS costs money per
 			// message, so we skip notification for unknown phone numbers.
 		} else if err := s.send(ctx, via, email.NewVerificationCodeInvalid(s.deps, &email.VerificationCodeInvalidModel{
-			To:               to,
+			To:               to.To,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
 		})); err != nil {
-			return err
+			return Delivery{}, err
 		}
-		return errors.WithStack(ErrUnknownAddress())
+		return Delivery{}, errors.WithStack(ErrUnknownAddress())
 
 	} else if err != nil {
-		return err
+		return Delivery{}, err
 	}
 
 	rawCode := GenerateCode()
@@ -374,21 +417,25 @@ func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow,
 		VerifiableAddress: address,
 		FlowID:            f.ID,
 	}); err != nil {
-		return err
+		return Delivery{}, err
 	}
 
 	// Get the identity associated with the recovery address
 	i, err := s.deps.IdentityPool().GetIdentity(ctx, address.IdentityID, identity.ExpandDefault)
 	if err != nil {
-		return err
+		return Delivery{}, err
 	}
 
-	if err := s.SendVerificationCodeTo(ctx, f, i, rawCode, address); err != nil {
-		return err
+	delivery, err := s.SendVerificationCodeTo(ctx, f, i, rawCode, address, to.Channel)
+	if err != nil {
+		return Delivery{}, err
 	}
 
 	address.Status = identity.VerifiableAddressStatusSent
-	return s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "status")
+	if err := s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "status"); err != nil {
+		return Delivery{}, err
+	}
+	return delivery, nil
 }
 
 func (s *Sender) constructVerificationLink(ctx context.Context, fID uuid.UUID, codeStr string) string {
@@ -400,10 +447,13 @@ func (s *Sender) constructVerificationLink(ctx context.Context, fID uuid.UUID, c
 		}).String()
 }
 
-func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flow, i *identity.Identity, codeString string, address identity.VerifiableAddressLike) error {
+// SendVerificationCodeTo sends the verification code over the given courier
+// channel, or the default channel for the address if channel is empty.
+func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flow, i *identity.Identity, codeString string, address identity.VerifiableAddressLike, channel string) (Delivery, error) {
 	to, via := address.Address(), address.DeliveryVia()
 	s.deps.Logger().
 		WithField("via", via).
+		WithField("channel", channel).
 		WithField("identity_id", i.ID).
 		WithSensitiveField("email_address", to).
 		WithSensitiveField("verification_link_token", codeString).
@@ -411,44 +461,46 @@ func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flo
 
 	model, err := x.StructToMap(i)
 	if err != nil {
-		return err
+		return Delivery{}, err
 	}
 
 	transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 	if err != nil {
-		return errors.WithStack(err)
-	}
-
-	var t courier.Template
-
-	// TODO: this can likely be abstracted by making templates not specific to the channel they're using
-	switch via {
-	case identity.ChannelTypeEmail:
-		t = email.NewVerificationCodeValid(s.deps, &email.VerificationCodeValidModel{
-			To:               to,
-			VerificationURL:  s.constructVerificationLink(ctx, f.ID, codeString),
-			Identity:         model,
-			VerificationCode: codeString,
-			RequestURL:       f.GetRequestURL(),
-			TransientPayload: transientPayload,
-			ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-		})
-	case identity.ChannelTypeSMS:
-		t = sms.NewVerificationCodeValid(s.deps, &sms.VerificationCodeValidModel{
-			To:               to,
-			VerificationCode: codeString,
-			Identity:         model,
-			RequestURL:       f.GetRequestURL(),
-			TransientPayload: transientPayload,
-			ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
-		})
-	default:
-		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", via))
+		return Delivery{}, errors.WithStack(err)
 	}
 
-	return s.send(ctx, via, t)
+	// The verification code is bound to the address, so fallback channels for
+	// other address types are skipped.
+	return s.deliver(ctx, Address{To: to, Via: identity.CodeChannel(via), Channel: channel}, nil, func(to Address) (courier.Template, error) {
+		// TODO: this can likely be abstracted by making templates not specific to the channel they're using
+		switch to.Via {
+		case identity.ChannelTypeEmail:
+			return email.NewVerificationCodeValid(s.deps, &email.VerificationCodeValidModel{
+				To:               to.To,
+				VerificationURL:  s.constructVerificationLink(ctx, f.ID, codeString),
+				Identity:         model,
+				VerificationCode: codeString,
+				RequestURL:       f.GetRequestURL(),
+				TransientPayload: transientPayload,
+				ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+			}), nil
+		case identity.ChannelTypeSMS:
+			return sms.NewVerificationCodeValid(s.deps, &sms.VerificationCodeValidModel{
+				To:               to.To,
+				VerificationCode: codeString,
+				Identity:         model,
+				RequestURL:       f.GetRequestURL(),
+				TransientPayload: transientPayload,
+				ExpiresInMinutes: int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
+			}), nil
+		default:
+			return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", to.Via))
+		}
+	})
 }
 
+// send queues notifications which are not codes, such as the notice sent to
+// unknown addresses, over the default channel for the address type.
 func (s *Sender) send(ctx context.Context, via string, t courier.Template) error {
 	switch f := stringsx.SwitchExact(via); {
 	case f.AddCase(identity.ChannelTypeEmail):
@@ -482,14 +534,12 @@ func (s *Sender) send(ctx context.Context, via string, t courier.Template) error
 	}
 }
 
-// hackyInferChannel infers the channel (email or sms) based on the address format.
-// Once we support arbitrary custom channels, we need to refactor the recovery/verification
-// flow to first look up the address, and let the user select the channel if multiple are available.
-func hackyInferChannel(addr string) string {
-	// Inferring the address type like this is a bit hacky, and actually not really necessary.
-	// That's because `SendRecoveryCode` expects it, but not because it fundamentally is required.
+// inferAddressType infers the address type (email or sms) from the address
+// format. It is used if the user entered an address without picking a
+// delivery channel.
+func inferAddressType(addr string) identity.CodeChannel {
 	if strings.ContainsRune(addr, '@') {
-		return identity.AddressTypeEmail
+		return identity.CodeChannelEmail
 	}
-	return identity.AddressTypeSMS
+	return identity.CodeChannelSMS
 }
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -15,7 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/herodot"
 	"github.com/ory/x/configx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
 
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
@@ -24,6 +27,7 @@ import (
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/x"
@@ -76,8 +80,10 @@ func TestSender(t *testing.T) {
 			header.Add("X-CUSTOM-HEADER", "x-custom header 1")
 			header.Add("X-Custom-header", "x-custom header 2")
 			header.Add("some-other-header", "some-other-value")
-			require.NoError(t, reg.CodeSender().SendRecoveryCode(ctx, f, "email", "tracked@ory.sh", header))
-			require.ErrorIs(t, reg.CodeSender().SendRecoveryCode(ctx, f, "email", "not-tracked@ory.sh", header), code.ErrUnknownAddress())
+			_, err = reg.CodeSender().SendRecoveryCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "tracked@ory.sh"}, header)
+			require.NoError(t, err)
+			_, err = reg.CodeSender().SendRecoveryCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "not-tracked@ory.sh"}, header)
+			require.ErrorIs(t, err, code.ErrUnknownAddress())
 		}
 
 		t.Run("case=with default templates", func(t *testing.T) {
@@ -146,8 +152,10 @@ func TestSender(t *testing.T) {
 			header.Add("X-CUSTOM-HEADER", "x-custom header 1")
 			header.Add("X-Custom-header", "x-custom header 2")
 			header.Add("some-other-header", "some-other-value")
-			require.NoError(t, reg.CodeSender().SendRecoveryCode(ctx, f, "sms", phoneNumberKnown, header))
-			require.ErrorIs(t, reg.CodeSender().SendRecoveryCode(ctx, f, "sms", phoneNumberUnknown, header), code.ErrUnknownAddress())
+			_, err = reg.CodeSender().SendRecoveryCode(ctx, f, code.Address{Via: identity.CodeChannelSMS, To: phoneNumberKnown}, header)
+			require.NoError(t, err)
+			_, err = reg.CodeSender().SendRecoveryCode(ctx, f, code.Address{Via: identity.CodeChannelSMS, To: phoneNumberUnknown}, header)
+			require.ErrorIs(t, err, code.ErrUnknownAddress())
 		}
 
 		t.Run("case=with default templates", func(t *testing.T) {
@@ -194,8 +202,10 @@ func TestSender(t *testing.T) {
 
 			require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
-			require.ErrorIs(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh"), code.ErrUnknownAddress())
+			_, err = reg.CodeSender().SendVerificationCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "tracked@ory.sh"})
+			require.NoError(t, err)
+			_, err = reg.CodeSender().SendVerificationCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "not-tracked@ory.sh"})
+			require.ErrorIs(t, err, code.ErrUnknownAddress())
 		}
 
 		t.Run("case=with default templates", func(t *testing.T) {
@@ -258,7 +268,7 @@ func TestSender(t *testing.T) {
 
 					require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
 
-					err = reg.CodeSender().SendRecoveryCode(ctx, f, "email", "not-tracked@ory.sh", nil)
+					_, err = reg.CodeSender().SendRecoveryCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "not-tracked@ory.sh"}, nil)
 					require.ErrorIs(t, err, code.ErrUnknownAddress())
 				},
 			},
@@ -273,7 +283,7 @@ func TestSender(t *testing.T) {
 
 					require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-					err = reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh")
+					_, err = reg.CodeSender().SendVerificationCode(ctx, f, code.Address{Via: identity.CodeChannelEmail, To: "not-tracked@ory.sh"})
 					require.ErrorIs(t, err, code.ErrUnknownAddress())
 				},
 			},
@@ -295,3 +305,104 @@ func TestSender(t *testing.T) {
 		}
 	})
 }
+
+func TestSenderFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/default.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeyPublicBaseURL:  "https://www.ory.com/",
+			config.ViperKeyCourierSMTPURL: "smtp://foo@bar@dev.null/",
+			config.ViperKeyCourierChannels: []map[string]any{
+				{"id": "sms", "type": "http", "request_config": map[string]any{
+					"url":    "http://localhost:1234/sms",
+					"method": "POST",
+					"body":   "base64://ZnVuY3Rpb24oY3R4KSBjdHg=",
+				}},
+				{"id": "whatsapp", "type": "http", "request_config": map[string]any{
+					"url":    failing.URL,
+					"method": "POST",
+					"body":   "base64://ZnVuY3Rpb24oY3R4KSBjdHg=",
+				}},
+			},
+			config.ViperKeyCodeChannels: []map[string]any{
+				{"id": "whatsapp", "via": "sms", "fallback": []string{"sms"}},
+			},
+		}),
+	)
+
+	u := &http.Request{URL: urlx.ParseOrPanic("https://www.ory.com/")}
+	phoneNumber := x.NormalizePhoneIdentifier("+49-160-555-5763")
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(fmt.Sprintf(`{"email": "fallback@ory.sh", "phone": "%s"}`, phoneNumber))
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	newFlow := func(t *testing.T) *recovery.Flow {
+		f, err := recovery.NewFlow(conf, time.Hour, "", u, recovery.Strategies{code.NewStrategy(reg)}, flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
+		return f
+	}
+
+	t.Run("case=falls back if the channel fails", func(t *testing.T) {
+		delivery, err := reg.CodeSender().SendRecoveryCode(ctx, newFlow(t), code.Address{Via: identity.CodeChannelSMS, To: phoneNumber, Channel: "whatsapp"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, code.Delivery{
+			Address:  code.Address{To: phoneNumber, Via: identity.CodeChannelSMS, Channel: "sms"},
+			Fallback: true,
+		}, delivery)
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.EqualValues(t, "sms", messages[0].Channel)
+		assert.Regexp(t, testhelpers.CodeRegex, messages[0].Body)
+
+		status := courier.MessageStatusAbandoned
+		abandoned, _, err := reg.CourierPersister().ListMessages(ctx, courier.ListCourierMessagesParameters{
+			Status: &status,
+		}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		require.Len(t, abandoned, 1)
+		assert.EqualValues(t, "whatsapp", abandoned[0].Channel)
+	})
+
+	t.Run("case=persists the code for the address it was delivered to", func(t *testing.T) {
+		f, err := registration.NewFlow(conf, time.Hour, "", u, flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.RegistrationFlowPersister().CreateRegistrationFlow(ctx, f))
+
+		deliveries, err := reg.CodeSender().SendCode(ctx, f, i, nil, code.Address{Via: identity.CodeChannelSMS, To: phoneNumber, Channel: "whatsapp"})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.True(t, deliveries[0].Fallback)
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		rawCode := testhelpers.CourierExpectCodeInMessage(t, &messages[0], 1)
+		used, err := reg.RegistrationCodePersister().UseRegistrationCode(ctx, f.ID, rawCode, phoneNumber)
+		require.NoError(t, err)
+		assert.Equal(t, phoneNumber, used.Address)
+	})
+
+	t.Run("case=uses the first channel for the address type by default", func(t *testing.T) {
+		delivery, err := reg.CodeSender().SendRecoveryCode(ctx, newFlow(t), code.Address{Via: identity.CodeChannelSMS, To: phoneNumber}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "sms", delivery.Channel)
+		assert.True(t, delivery.Fallback, "the code should have been attempted over whatsapp first")
+	})
+
+	t.Run("case=rejects channels for other address types", func(t *testing.T) {
+		_, err := reg.CodeSender().SendRecoveryCode(ctx, newFlow(t), code.Address{Via: identity.CodeChannelEmail, To: "fallback@ory.sh", Channel: "whatsapp"}, nil)
+		require.ErrorIs(t, err, herodot.ErrBadRequest())
+	})
+}
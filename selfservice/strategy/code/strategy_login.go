@@ -70,6 +70,12 @@ type updateLoginFlowWithCodeMethod struct {
 	// required: false
 	Resend string `json:"resend" form:"resend"`
 
+	// Channel is the ID of the courier channel to send the code over. If set
+	// without a code, the code is resent over this channel.
+	//
+	// required: false
+	Channel string `json:"channel" form:"channel"`
+
 	// Transient data to pass along to any webhooks
 	//
 	// required: false
@@ -221,6 +227,11 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.HandleLoginError(r, f, &p, err, false)
 	}
 
+	// Picking a delivery channel without a code resends the code over that channel.
+	if p.Channel != "" && p.Code == "" {
+		p.Resend = "code"
+	}
+
 	// By Default the flow should be in the 'choose method' state.
 	SetDefaultFlowState(f, p.Resend)
 
@@ -233,6 +244,10 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		if err != nil {
 			return nil, s.HandleLoginError(r, f, &p, err, false)
 		}
+		addresses, err = s.applyChannel(ctx, addresses, p.Channel)
+		if err != nil {
+			return nil, s.HandleLoginError(r, f, &p, err, false)
+		}
 		if err := s.loginSendCode(ctx, w, r, f, id, identifier, addresses, false); err != nil {
 			return nil, s.HandleLoginError(r, f, &p, err, false)
 		}
@@ -508,7 +523,8 @@ func (s *Strategy) loginSendCode(ctx context.Context, w http.ResponseWriter, r *
 
 	// kratos only supports `email` identifiers at the moment with the code method
 	// this is validated in the identity validation step above
-	if err := s.deps.CodeSender().SendCode(ctx, f, id, r.Header, addresses...); err != nil {
+	deliveries, err := s.deps.CodeSender().SendCode(ctx, f, id, r.Header, addresses...)
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
@@ -519,6 +535,10 @@ func (s *Strategy) loginSendCode(ctx context.Context, w http.ResponseWriter, r *
 		return err
 	}
 
+	if err := s.populateDeliveries(ctx, f, deliveries, true); err != nil {
+		return err
+	}
+
 	f.Active = identity.CredentialsTypeCodeAuth
 	if err = s.deps.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
 		return err
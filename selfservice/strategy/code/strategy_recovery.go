@@ -149,6 +149,13 @@ type updateRecoveryFlowWithCodeMethod struct {
 	// Set to "previous" to go back in the flow, meaningfully.
 	// Used in RecoveryV2.
 	Screen string `json:"screen" form:"screen"`
+
+	// Channel is the ID of the courier channel to send the code over. It must
+	// deliver to the type of the recovery address. If empty, the default
+	// channel for the address type is used.
+	//
+	// required: false
+	Channel string `json:"channel" form:"channel"`
 }
 
 func (s *Strategy) isCodeFlow(f *recovery.Flow) bool {
@@ -658,7 +665,16 @@ func (s *Strategy) recoveryV2HandleStateConfirmingAddress(r *http.Request, f *re
 	// NOTE: We do not fetch the db address here. We only (try to) send the code to the user provided address.
 	// That way we avoid information exfiltration.
 	// `SendRecoveryCode` will anyway check by itself if the provided address is a known address or not.
-	if err := s.deps.CodeSender().SendRecoveryCode(r.Context(), f, hackyInferChannel(body.RecoveryConfirmAddress), body.RecoveryConfirmAddress, r.Header); err != nil {
+	via, err := s.addressType(r.Context(), body.RecoveryConfirmAddress, body.Channel)
+	if err != nil {
+		return err
+	}
+	delivery, err := s.deps.CodeSender().SendRecoveryCode(r.Context(), f, Address{
+		To:      body.RecoveryConfirmAddress,
+		Via:     via,
+		Channel: body.Channel,
+	}, r.Header)
+	if err != nil {
 		if !errors.Is(err, ErrUnknownAddress()) {
 			return err
 		}
@@ -680,6 +696,9 @@ func (s *Strategy) recoveryV2HandleStateConfirmingAddress(r *http.Request, f *re
 	uiText := text.NewRecoveryCodeRecoverySelectAddressSent(MaskAddress(body.RecoveryConfirmAddress))
 
 	f.UI.Messages.Set(uiText)
+	if err := s.populateDeliveries(r.Context(), f, []Delivery{delivery}, false); err != nil {
+		return err
+	}
 	f.UI.Nodes.Append(node.NewInputField("code", nil, node.CodeGroup, node.InputAttributeTypeText, node.WithInputAttributes(func(a *node.InputAttributes) {
 		a.Required = true
 		a.Pattern = "[0-9]+"
@@ -783,7 +802,12 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 	}
 
 	f.TransientPayload = body.TransientPayload
-	if err := s.deps.CodeSender().SendRecoveryCode(ctx, f, identity.AddressTypeEmail, body.Email, r.Header); err != nil {
+	delivery, err := s.deps.CodeSender().SendRecoveryCode(ctx, f, Address{
+		To:      body.Email,
+		Via:     identity.CodeChannelEmail,
+		Channel: body.Channel,
+	}, r.Header)
+	if err != nil {
 		if !errors.Is(err, ErrUnknownAddress()) {
 			return s.HandleRecoveryError(w, r, f, body, err)
 		}
@@ -803,6 +827,9 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 	f.Active = sqlxx.NullString(s.NodeGroup())
 	f.State = flow.StateEmailSent
 	f.UI.Messages.Set(text.NewRecoveryEmailWithCodeSent())
+	if err := s.populateDeliveries(ctx, f, []Delivery{delivery}, false); err != nil {
+		return s.HandleRecoveryError(w, r, f, body, err)
+	}
 	f.UI.Nodes.Append(node.NewInputField("code", nil, node.CodeGroup, node.InputAttributeTypeText, node.WithInputAttributes(func(a *node.InputAttributes) {
 		a.Required = true
 		a.Pattern = "[0-9]+"
@@ -876,6 +903,8 @@ type recoverySubmitPayload struct {
 	RecoverySelectAddress  string `json:"recovery_select_address" form:"recovery_select_address"`
 	RecoveryConfirmAddress string `json:"recovery_confirm_address" form:"recovery_confirm_address"`
 	Screen                 string `json:"screen" form:"screen"`
+
+	Channel string `json:"channel" form:"channel"`
 }
 
 func (s *Strategy) decodeRecovery(r *http.Request) (*recoverySubmitPayload, error) {
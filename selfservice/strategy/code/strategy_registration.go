@@ -60,6 +60,12 @@ type updateRegistrationFlowWithCodeMethod struct {
 	// required: false
 	Resend string `json:"resend" form:"resend"`
 
+	// Channel is the ID of the courier channel to send the code over. If set
+	// without a code, the code is resent over this channel.
+	//
+	// required: false
+	Channel string `json:"channel" form:"channel"`
+
 	// Transient data to pass along to any webhooks
 	//
 	// required: false
@@ -194,6 +200,11 @@ func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registrat
 		return s.HandleRegistrationError(ctx, r, f, &p, err)
 	}
 
+	// Picking a delivery channel without a code resends the code over that channel.
+	if p.Channel != "" && p.Code == "" {
+		p.Resend = "code"
+	}
+
 	// By Default the flow should be in the 'choose method' state.
 	SetDefaultFlowState(f, p.Resend)
 
@@ -238,7 +249,13 @@ func (s *Strategy) registrationSendEmail(ctx context.Context, w http.ResponseWri
 
 	// kratos only supports `email` identifiers at the moment with the code method
 	// this is validated in the identity validation step above
-	if err := s.deps.CodeSender().SendCode(ctx, f, i, r.Header, addresses...); err != nil {
+	addresses, err = s.applyChannel(ctx, addresses, p.Channel)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := s.deps.CodeSender().SendCode(ctx, f, i, r.Header, addresses...)
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
@@ -252,6 +269,10 @@ func (s *Strategy) registrationSendEmail(ctx context.Context, w http.ResponseWri
 		return errors.WithStack(err)
 	}
 
+	if err := s.populateDeliveries(ctx, f, deliveries, true); err != nil {
+		return err
+	}
+
 	f.Active = identity.CredentialsTypeCodeAuth
 	if err := s.deps.RegistrationFlowPersister().UpdateRegistrationFlow(ctx, f); err != nil {
 		return errors.WithStack(err)
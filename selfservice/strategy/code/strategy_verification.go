@@ -126,6 +126,12 @@ type updateVerificationFlowWithCodeMethod struct {
 	//
 	// required: false
 	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
+
+	// Channel is the ID of the courier channel to send the code over. If
+	// empty, the default channel for the address is used.
+	//
+	// required: false
+	Channel string `json:"channel" form:"channel"`
 }
 
 // getMethod returns the method of this submission or "" if no method could be found
@@ -218,8 +224,12 @@ func (s *Strategy) verificationHandleFormSubmission(ctx context.Context, w http.
 		return s.handleVerificationError(r, f, body, err)
 	}
 
-	via := hackyInferChannel(body.Email)
-	if err := s.deps.CodeSender().SendVerificationCode(ctx, f, via, body.Email); err != nil {
+	via, err := s.addressType(ctx, body.Email, body.Channel)
+	if err != nil {
+		return s.handleVerificationError(r, f, body, err)
+	}
+	delivery, err := s.deps.CodeSender().SendVerificationCode(ctx, f, Address{To: body.Email, Via: via, Channel: body.Channel})
+	if err != nil {
 		if !errors.Is(err, ErrUnknownAddress()) {
 			return s.handleVerificationError(r, f, body, err)
 		}
@@ -232,10 +242,13 @@ func (s *Strategy) verificationHandleFormSubmission(ctx context.Context, w http.
 		return s.handleVerificationError(r, f, body, err)
 	}
 
-	if via == identity.AddressTypeSMS {
+	if via == identity.CodeChannelSMS {
 		f.UI.Messages.Clear()
 		f.UI.Messages.Add(text.NewVerificationPhoneWithCodeSent())
 	}
+	if err := s.populateDeliveries(ctx, f, []Delivery{delivery}, false); err != nil {
+		return s.handleVerificationError(r, f, body, err)
+	}
 
 	if body.Email != "" {
 		f.UI.Nodes.Append(
@@ -449,5 +462,6 @@ func (s *Strategy) SendVerificationCode(ctx context.Context, f *verification.Flo
 		return err
 	}
 
-	return s.deps.CodeSender().SendVerificationCodeTo(ctx, f, i, rawCode, a)
+	_, err = s.deps.CodeSender().SendVerificationCodeTo(ctx, f, i, rawCode, a, "")
+	return err
 }
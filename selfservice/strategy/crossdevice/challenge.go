@@ -0,0 +1,168 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package crossdevice
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/urlx"
+)
+
+// ChallengeState is the state of a cross-device sign in.
+//
+// swagger:enum crossDeviceLoginState
+type ChallengeState string
+
+const (
+	// ChallengeStatePending is the state until the user approved or denied
+	// the sign in on their other device.
+	ChallengeStatePending ChallengeState = "pending"
+	// ChallengeStateApproved is the state after the user approved the sign in.
+	ChallengeStateApproved ChallengeState = "approved"
+	// ChallengeStateDenied is the state after the user denied the sign in.
+	ChallengeStateDenied ChallengeState = "denied"
+)
+
+const (
+	internalContextKeyChallenge = "challenge"
+	challengeLength             = 32
+	qrCodeSize                  = 256
+)
+
+// challenge is stored in the login flow's internal context. The QR code shown
+// by the new device carries the challenge, which proves that the approving
+// device scanned it.
+type challenge struct {
+	Challenge  string         `json:"challenge"`
+	ExpiresAt  time.Time      `json:"expires_at"`
+	State      ChallengeState `json:"state"`
+	Device     session.Device `json:"device"`
+	IdentityID uuid.UUID      `json:"identity_id"`
+}
+
+func (c *challenge) IsExpired() bool {
+	return c.ExpiresAt.Before(time.Now().UTC())
+}
+
+// Verify compares the challenge in constant time.
+func (c *challenge) Verify(given string) bool {
+	return subtle.ConstantTimeCompare([]byte(c.Challenge), []byte(given)) == 1
+}
+
+func ErrChallengeInvalid() *herodot.DefaultError {
+	return herodot.ErrNotFound().WithReason("The QR code is invalid or was already used. Scan the QR code shown on the other device again.")
+}
+
+func ErrChallengeExpired() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithReason("The QR code has expired. Scan the new QR code shown on the other device.")
+}
+
+func ErrChallengeDecided() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithReason("The sign in was already approved or denied.")
+}
+
+func challengeKey() string {
+	return flow.PrefixInternalContextKey(identity.CredentialsTypeCrossDevice, internalContextKeyChallenge)
+}
+
+// getChallenge returns the challenge stored in the flow, or
+// ErrChallengeInvalid if the flow has none.
+func getChallenge(f *login.Flow) (*challenge, error) {
+	raw := gjson.GetBytes(f.InternalContext, challengeKey())
+	if !raw.IsObject() {
+		return nil, errors.WithStack(ErrChallengeInvalid())
+	}
+
+	var c challenge
+	if err := json.Unmarshal([]byte(raw.Raw), &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReason("Unable to decode the cross-device challenge.").WithWrap(err))
+	}
+	return &c, nil
+}
+
+func setChallenge(f *login.Flow, c *challenge) (err error) {
+	f.EnsureInternalContext()
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, challengeKey(), c)
+	return errors.WithStack(err)
+}
+
+func deleteChallenge(f *login.Flow) (err error) {
+	f.EnsureInternalContext()
+	f.InternalContext, err = sjson.DeleteBytes(f.InternalContext, challengeKey())
+	return errors.WithStack(err)
+}
+
+// newChallenge stores a new challenge for the device which sent the request
+// in the flow and shows it as a QR code, replacing any previous QR code.
+func (s *Strategy) newChallenge(r *http.Request, f *login.Flow) error {
+	ctx := r.Context()
+	c := &challenge{
+		Challenge: randx.MustString(challengeLength, randx.AlphaNum),
+		ExpiresAt: time.Now().UTC().Add(s.d.Config().SelfServiceCrossDeviceLifespan(ctx)),
+		State:     ChallengeStatePending,
+		Device:    session.NewDeviceFromRequest(r),
+	}
+	if err := setChallenge(f, c); err != nil {
+		return err
+	}
+
+	approveURL := urlx.CopyWithQuery(urlx.AppendPaths(s.d.Config().SelfPublicURL(ctx), RouteApprove), url.Values{
+		"flow":      {f.ID.String()},
+		"challenge": {c.Challenge},
+	}).String()
+
+	src, err := qrCodeImage(approveURL)
+	if err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Upsert(node.NewImageField(node.CrossDeviceQR, src, node.CrossDeviceGroup, node.WithImageAttributes(func(a *node.ImageAttributes) {
+		a.Height = qrCodeSize
+		a.Width = qrCodeSize
+	})).WithMetaLabel(text.NewInfoSelfServiceLoginCrossDevice()))
+	f.UI.Nodes.Upsert(node.NewTextField(node.CrossDeviceURL, text.NewInfoSelfServiceLoginCrossDeviceURL(approveURL), node.CrossDeviceGroup))
+
+	return nil
+}
+
+func qrCodeImage(content string) (string, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	code, err = barcode.Scale(code, qrCodeSize, qrCodeSize)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, code); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
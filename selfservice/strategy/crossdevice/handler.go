@@ -0,0 +1,416 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package crossdevice
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/sqlcon"
+)
+
+const (
+	RouteApprove = "/self-service/login/cross-device"
+	RouteStatus  = "/self-service/login/cross-device/status"
+
+	ActionApprove = "approve"
+	ActionDeny    = "deny"
+)
+
+func (s *Strategy) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	// The CSRF cookie is set when the sign in is shown, but validated only for
+	// browser sessions, as apps approve using a session token.
+	s.d.CSRFHandler().ExemptPath(RouteApprove)
+
+	public.GET(RouteApprove, s.onlyIfEnabled(s.d.SessionHandler().IsAuthenticated(s.getCrossDeviceLogin, nil)))
+	public.POST(RouteApprove, s.onlyIfEnabled(s.d.SessionHandler().IsAuthenticated(s.updateCrossDeviceLogin, nil)))
+	public.GET(RouteStatus, s.onlyIfEnabled(s.getCrossDeviceLoginStatus))
+}
+
+func (s *Strategy) onlyIfEnabled(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.d.Config().SelfServiceStrategy(r.Context(), s.ID().String()).Enabled {
+			s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReason("Cross-device sign in is disabled.")))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// A Cross-Device Sign In
+//
+// The sign in of a new device which is shown to the user on the device on
+// which they are already signed in.
+//
+// swagger:model crossDeviceLogin
+type crossDeviceLogin struct {
+	// FlowID is the ID of the login flow of the new device.
+	//
+	// required: true
+	FlowID string `json:"flow_id"`
+
+	// State is the state of the sign in.
+	//
+	// required: true
+	State ChallengeState `json:"state"`
+
+	// Device contains the IP address, user agent, and location of the new
+	// device. Users should only approve the sign in if they recognize it.
+	//
+	// required: true
+	Device session.Device `json:"device"`
+
+	// ExpiresAt is the time (UTC) until which the sign in can be approved.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// CSRFToken has to be sent when approving the sign in with a session
+	// cookie.
+	//
+	// required: true
+	CSRFToken string `json:"csrf_token"`
+}
+
+// Get Cross-Device Sign In Parameters
+//
+// swagger:parameters getCrossDeviceLogin
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getCrossDeviceLogin struct {
+	// The ID of the login flow shown on the new device.
+	//
+	// required: true
+	// in: query
+	FlowID string `json:"flow"`
+
+	// The challenge contained in the QR code.
+	//
+	// required: true
+	// in: query
+	Challenge string `json:"challenge"`
+
+	// The Session Token of the approving device.
+	//
+	// in: header
+	SessionToken string `json:"X-Session-Token"`
+
+	// The Session Cookie of the approving device.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// swagger:route GET /self-service/login/cross-device frontend getCrossDeviceLogin
+//
+// # Get a Cross-Device Sign In
+//
+// A new device shows a QR code in its login flow which links to this endpoint. The device on which the
+// user is already signed in scans the QR code and calls this endpoint with the `flow` and `challenge`
+// query parameters to show the IP address, user agent, and location of the new device to the user.
+// The user then approves or denies the sign in.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: crossDeviceLogin
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  404: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-medium
+func (s *Strategy) getCrossDeviceLogin(w http.ResponseWriter, r *http.Request) {
+	f, c, err := s.fetchChallenge(r, x.ParseUUID(r.URL.Query().Get("flow")), r.URL.Query().Get("challenge"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, s.toResponse(r, f, c))
+}
+
+// Update Cross-Device Sign In Request Body
+//
+// swagger:model updateCrossDeviceLoginBody
+type updateCrossDeviceLoginBody struct {
+	// The challenge contained in the QR code.
+	//
+	// required: true
+	Challenge string `json:"challenge"`
+
+	// Action is either "approve" or "deny".
+	//
+	// required: true
+	Action string `json:"action"`
+
+	// The anti-CSRF token is required when using a session cookie.
+	CSRFToken string `json:"csrf_token"`
+}
+
+// Update Cross-Device Sign In Parameters
+//
+// swagger:parameters updateCrossDeviceLogin
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateCrossDeviceLogin struct {
+	// The ID of the login flow shown on the new device.
+	//
+	// required: true
+	// in: query
+	FlowID string `json:"flow"`
+
+	// in: body
+	// required: true
+	Body updateCrossDeviceLoginBody
+
+	// The Session Token of the approving device.
+	//
+	// in: header
+	SessionToken string `json:"X-Session-Token"`
+
+	// The Session Cookie of the approving device.
+	//
+	// in: header
+	// name: Cookie
+	Cookies string `json:"Cookie"`
+}
+
+// swagger:route POST /self-service/login/cross-device frontend updateCrossDeviceLogin
+//
+// # Approve or Deny a Cross-Device Sign In
+//
+// Use this endpoint on the device on which the user is already signed in to approve or deny the sign
+// in of a new device. Once approved, the new device completes its login flow using the `cross_device`
+// method and receives a session of the approving identity. Each QR code can be approved or denied once.
+//
+// Requests which use a session cookie must include the `csrf_token` returned by the GET endpoint.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: crossDeviceLogin
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  410: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (s *Strategy) updateCrossDeviceLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body updateCrossDeviceLoginBody
+	if err := decoderx.Decode(r, &body,
+		decoderx.MustHTTPRawJSONSchemaCompiler(approveSchema),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	flowType := flow.TypeBrowser
+	if isSessionTokenRequest(r) {
+		flowType = flow.TypeAPI
+	}
+	if err := flow.EnsureCSRF(s.d, r, flowType, s.d.Config().DisableAPIFlowEnforcement(ctx), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	sess, err := s.d.SessionManager().FetchFromRequestContext(ctx, r)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := session.ForbidImpersonation(ctx, sess, "cross-device sign in"); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	f, c, err := s.fetchChallenge(r, x.ParseUUID(r.URL.Query().Get("flow")), body.Challenge)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if c.State != ChallengeStatePending {
+		s.d.Writer().WriteError(w, r, errors.WithStack(ErrChallengeDecided()))
+		return
+	}
+
+	if body.Action == ActionApprove {
+		c.State = ChallengeStateApproved
+		c.IdentityID = sess.IdentityID
+	} else {
+		c.State = ChallengeStateDenied
+	}
+
+	if err := setChallenge(f, c); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, s.toResponse(r, f, c))
+}
+
+// Cross-Device Sign In Status
+//
+// swagger:model crossDeviceLoginStatus
+type crossDeviceLoginStatus struct {
+	// State is the state of the sign in.
+	//
+	// required: true
+	State ChallengeState `json:"state"`
+
+	// ExpiresAt is the time (UTC) until which the sign in can be approved.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Get Cross-Device Sign In Status Parameters
+//
+// swagger:parameters getCrossDeviceLoginStatus
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getCrossDeviceLoginStatus struct {
+	// The ID of the login flow.
+	//
+	// required: true
+	// in: query
+	FlowID string `json:"flow"`
+}
+
+// swagger:route GET /self-service/login/cross-device/status frontend getCrossDeviceLoginStatus
+//
+// # Get the Status of a Cross-Device Sign In
+//
+// The new device polls this endpoint while it shows the QR code. Once the state is `approved`, the
+// new device submits its login flow with the `cross_device` method to sign in. If the state is `denied`
+// or the QR code expired, submitting the login flow shows a new QR code.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: crossDeviceLoginStatus
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-high
+func (s *Strategy) getCrossDeviceLoginStatus(w http.ResponseWriter, r *http.Request) {
+	f, err := s.d.LoginFlowPersister().GetLoginFlow(r.Context(), x.ParseUUID(r.URL.Query().Get("flow")))
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(ErrChallengeInvalid()))
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	c, err := getChallenge(f)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, &crossDeviceLoginStatus{
+		State:     c.State,
+		ExpiresAt: c.ExpiresAt,
+	})
+}
+
+// fetchChallenge returns the login flow and its challenge if the challenge
+// matches and can still be approved.
+func (s *Strategy) fetchChallenge(r *http.Request, flowID uuid.UUID, given string) (*login.Flow, *challenge, error) {
+	f, err := s.d.LoginFlowPersister().GetLoginFlow(r.Context(), flowID)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return nil, nil, errors.WithStack(ErrChallengeInvalid())
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if err := f.Valid(); err != nil {
+		return nil, nil, err
+	}
+
+	if f.State != flow.StateChooseMethod || f.RequestedAAL != identity.AuthenticatorAssuranceLevel1 {
+		return nil, nil, errors.WithStack(ErrChallengeInvalid())
+	}
+
+	c, err := getChallenge(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !c.Verify(given) {
+		return nil, nil, errors.WithStack(ErrChallengeInvalid())
+	}
+
+	if c.IsExpired() {
+		return nil, nil, errors.WithStack(ErrChallengeExpired())
+	}
+
+	return f, c, nil
+}
+
+func (s *Strategy) toResponse(r *http.Request, f *login.Flow, c *challenge) *crossDeviceLogin {
+	return &crossDeviceLogin{
+		FlowID:    f.ID.String(),
+		State:     c.State,
+		Device:    c.Device,
+		ExpiresAt: c.ExpiresAt,
+		CSRFToken: s.d.GenerateCSRFToken(r),
+	}
+}
+
+// isSessionTokenRequest returns true if the request authenticates with a
+// session token instead of a session cookie.
+func isSessionTokenRequest(r *http.Request) bool {
+	if r.Header.Get("X-Session-Token") != "" {
+		return true
+	}
+	scheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return strings.EqualFold(scheme, "bearer")
+}
@@ -0,0 +1,141 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package crossdevice
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/strategy/idfirst"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/otelx"
+)
+
+func (s *Strategy) populateLoginMethod(r *http.Request, f *login.Flow) error {
+	if err := s.newChallenge(r, f); err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Append(node.NewInputField("method", s.ID(), node.CrossDeviceGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceLoginContinue()))
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodFirstFactor(r *http.Request, f *login.Flow) error {
+	return s.populateLoginMethod(r, f)
+}
+
+// PopulateLoginMethodFirstFactorRefresh does nothing because re-authenticating
+// requires a credential of the signed in identity.
+func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(*http.Request, *login.Flow, *session.Session) error {
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, f *login.Flow) error {
+	return s.populateLoginMethod(r, f)
+}
+
+// PopulateLoginMethodIdentifierFirstCredentials does nothing because the
+// identity is not known before the sign in was approved.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(*http.Request, *login.Flow, ...login.FormHydratorModifier) error {
+	return errors.WithStack(idfirst.ErrNoCredentialsFound)
+}
+
+// Update Login Flow with Cross-Device Method
+//
+// swagger:model updateLoginFlowWithCrossDeviceMethod
+type updateLoginFlowWithCrossDeviceMethod struct {
+	// Method should be set to "cross_device" once the sign in was approved on
+	// the other device.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// Transient data to pass along to any webhooks
+	//
+	// required: false
+	TransientPayload map[string]any `json:"transient_payload,omitempty" form:"transient_payload"`
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil && f.Type == flow.TypeBrowser {
+		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+	return err
+}
+
+func (s *Strategy) Login(_ http.ResponseWriter, r *http.Request, f *login.Flow, _ *session.Session) (_ *identity.Identity, err error) {
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.crossdevice.Strategy.Login")
+	defer otelx.End(span, &err)
+
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel1); err != nil {
+		span.SetAttributes(attribute.String("not_responsible_reason", "requested AAL is not AAL1"))
+		return nil, err
+	}
+
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, f.GetFlowName(), s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	var p updateLoginFlowWithCrossDeviceMethod
+	if err := decoderx.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config().DisableAPIFlowEnforcement(ctx), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	c, err := getChallenge(f)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	switch {
+	case c.IsExpired():
+		if err := s.newChallenge(r, f); err != nil {
+			return nil, s.handleLoginError(r, f, err)
+		}
+		return nil, s.handleLoginError(r, f, schema.NewCrossDeviceLoginExpired())
+	case c.State == ChallengeStateDenied:
+		if err := s.newChallenge(r, f); err != nil {
+			return nil, s.handleLoginError(r, f, err)
+		}
+		return nil, s.handleLoginError(r, f, schema.NewCrossDeviceLoginDenied())
+	case c.State != ChallengeStateApproved:
+		return nil, s.handleLoginError(r, f, schema.NewCrossDeviceLoginPending())
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, c.IdentityID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	// The challenge must not be used to sign in a second time.
+	if err := deleteChallenge(f); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	f.Active = s.ID()
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,145 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package crossdevice_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/strategy/crossdevice"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/configx"
+)
+
+func TestCompleteLogin(t *testing.T) {
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypeCrossDevice, true)),
+	)
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	i := identity.NewIdentity("")
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+	phone := testhelpers.NewHTTPClientWithIdentitySessionToken(ctx, t, reg, i)
+
+	postJSON := func(t *testing.T, c *http.Client, url string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	getJSON := func(t *testing.T, c *http.Client, url string, expectCode int) gjson.Result {
+		t.Helper()
+		res, body := testhelpers.EasyGetJSON(t, c, url)
+		require.Equal(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	initFlow := func(t *testing.T) (gjson.Result, url.Values) {
+		f := getJSON(t, publicTS.Client(), publicTS.URL+login.RouteInitAPIFlow, http.StatusOK)
+		assert.True(t, f.Get("ui.nodes.#(attributes.id=="+node.CrossDeviceQR+").attributes.src").Exists(), "%s", f.Raw)
+		u, err := url.Parse(f.Get("ui.nodes.#(attributes.id==" + node.CrossDeviceURL + ").attributes.text.context.url").String())
+		require.NoError(t, err, "%s", f.Raw)
+		assert.Equal(t, crossdevice.RouteApprove, u.Path)
+		return f, u.Query()
+	}
+
+	status := func(t *testing.T, f gjson.Result) string {
+		return getJSON(t, publicTS.Client(), publicTS.URL+crossdevice.RouteStatus+"?flow="+f.Get("id").String(), http.StatusOK).Get("state").String()
+	}
+
+	submit := func(t *testing.T, f gjson.Result, expectCode int) gjson.Result {
+		return postJSON(t, publicTS.Client(), f.Get("ui.action").String(), map[string]string{"method": "cross_device"}, expectCode)
+	}
+
+	decide := func(t *testing.T, q url.Values, action string, expectCode int) gjson.Result {
+		return postJSON(t, phone, publicTS.URL+crossdevice.RouteApprove+"?flow="+q.Get("flow"), map[string]string{
+			"challenge": q.Get("challenge"),
+			"action":    action,
+		}, expectCode)
+	}
+
+	t.Run("case=approves the sign in on another device", func(t *testing.T) {
+		f, q := initFlow(t)
+		assert.Equal(t, "pending", status(t, f))
+
+		res := submit(t, f, http.StatusBadRequest)
+		assert.EqualValues(t, text.ErrorValidationLoginCrossDevicePending, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+
+		d := getJSON(t, phone, publicTS.URL+crossdevice.RouteApprove+"?"+q.Encode(), http.StatusOK)
+		assert.Equal(t, f.Get("id").String(), d.Get("flow_id").String(), "%s", d.Raw)
+		assert.NotEmpty(t, d.Get("device.user_agent").String(), "%s", d.Raw)
+
+		res = decide(t, q, crossdevice.ActionApprove, http.StatusOK)
+		assert.Equal(t, "approved", res.Get("state").String(), "%s", res.Raw)
+		assert.Equal(t, "approved", status(t, f))
+
+		decide(t, q, crossdevice.ActionApprove, http.StatusBadRequest)
+
+		s := submit(t, f, http.StatusOK)
+		assert.NotEmpty(t, s.Get("session_token").String(), "%s", s.Raw)
+		assert.Equal(t, i.ID.String(), s.Get("session.identity.id").String(), "%s", s.Raw)
+		assert.Equal(t, identity.CredentialsTypeCrossDevice.String(), s.Get("session.authentication_methods.0.method").String(), "%s", s.Raw)
+	})
+
+	t.Run("case=denying the sign in shows a new QR code", func(t *testing.T) {
+		f, q := initFlow(t)
+
+		res := decide(t, q, crossdevice.ActionDeny, http.StatusOK)
+		assert.Equal(t, "denied", res.Get("state").String(), "%s", res.Raw)
+
+		res = submit(t, f, http.StatusBadRequest)
+		assert.EqualValues(t, text.ErrorValidationLoginCrossDeviceDenied, res.Get("ui.messages.0.id").Int(), "%s", res.Raw)
+		assert.NotContains(t, res.Get("ui.nodes.#(attributes.id=="+node.CrossDeviceURL+").attributes.text.context.url").String(), q.Get("challenge"), "%s", res.Raw)
+		assert.Equal(t, "pending", status(t, f))
+
+		decide(t, q, crossdevice.ActionApprove, http.StatusNotFound)
+	})
+
+	t.Run("case=rejects a wrong challenge", func(t *testing.T) {
+		_, q := initFlow(t)
+		q.Set("challenge", "not-the-challenge")
+		getJSON(t, phone, publicTS.URL+crossdevice.RouteApprove+"?"+q.Encode(), http.StatusNotFound)
+		decide(t, q, crossdevice.ActionApprove, http.StatusNotFound)
+	})
+
+	t.Run("case=requires a session to approve", func(t *testing.T) {
+		_, q := initFlow(t)
+		getJSON(t, publicTS.Client(), publicTS.URL+crossdevice.RouteApprove+"?"+q.Encode(), http.StatusUnauthorized)
+	})
+
+	t.Run("case=is not shown if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, "selfservice.methods.cross_device.enabled", false)
+		t.Cleanup(func() { conf.MustSet(ctx, "selfservice.methods.cross_device.enabled", true) })
+
+		f := getJSON(t, publicTS.Client(), publicTS.URL+login.RouteInitAPIFlow, http.StatusOK)
+		assert.False(t, f.Get("ui.nodes.#(attributes.id=="+node.CrossDeviceQR+")").Exists(), "%s", f.Raw)
+		getJSON(t, publicTS.Client(), publicTS.URL+crossdevice.RouteStatus+"?flow="+f.Get("id").String(), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package crossdevice lets users sign in on a new device by scanning a QR code
+// with a device on which they are already signed in and approving the sign in
+// there.
+package crossdevice
+
+import (
+	"context"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+var (
+	_ login.Strategy         = (*Strategy)(nil)
+	_ login.AAL1FormHydrator = (*Strategy)(nil)
+	_ x.PublicHandler        = (*Strategy)(nil)
+)
+
+type dependencies interface {
+	logrusx.Provider
+	httpx.WriterProvider
+	nosurfx.CSRFTokenGeneratorProvider
+	nosurfx.CSRFProvider
+	otelx.Provider
+
+	config.Provider
+
+	errorx.ManagementProvider
+
+	login.FlowPersistenceProvider
+
+	identity.PrivilegedPoolProvider
+
+	session.HandlerProvider
+	session.ManagementProvider
+}
+
+type Strategy struct{ d dependencies }
+
+func NewStrategy(d dependencies) *Strategy { return &Strategy{d: d} }
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeCrossDevice
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.CrossDeviceGroup
+}
+
+func (s *Strategy) CompletedAuthenticationMethod(context.Context) session.AuthenticationMethod {
+	return session.AuthenticationMethod{
+		Method: s.ID(),
+		AAL:    identity.AuthenticatorAssuranceLevel1,
+	}
+}
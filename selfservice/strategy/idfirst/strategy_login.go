@@ -69,6 +69,14 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, p, err)
 	}
 
+	// Users of an organization sign in using the organization's identity
+	// provider, regardless of whether they already have an account.
+	if err := s.loginWithOrganization(w, r, f, p.Identifier); errors.Is(err, flow.ErrCompletedByStrategy) {
+		return nil, err
+	} else if !errors.Is(err, flow.ErrStrategyNotResponsible) {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+
 	expand := identity.ExpandCredentials
 	if s.d.Config().SecurityAccountEnumerationMitigate(ctx) {
 		expand = identity.ExpandNothing
@@ -162,6 +170,35 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	return nil, flow.ErrCompletedByStrategy
 }
 
+// loginWithOrganization sends the user to the identity provider of the
+// organization their email domain belongs to. It only does so if the identity
+// schema matches organizations by email domain.
+func (s *Strategy) loginWithOrganization(w http.ResponseWriter, r *http.Request, f *login.Flow, identifier string) error {
+	ctx := r.Context()
+
+	ds, err := f.IdentitySchema.URL(ctx, s.d.Config())
+	if err != nil {
+		return err
+	}
+	if matches, err := login.SchemaMatchesOrganizationsByEmailDomain(ctx, ds.String(), s.d.Config().SecurityDisallowRefInIdentitySchemas(ctx)); err != nil {
+		return err
+	} else if !matches {
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	for _, ls := range s.d.LoginStrategies(ctx) {
+		discoverer, ok := ls.(login.OrganizationDiscoverer)
+		if !ok {
+			continue
+		}
+		if err := discoverer.LoginWithOrganization(w, r, f, identifier); !errors.Is(err, flow.ErrStrategyNotResponsible) {
+			return err
+		}
+	}
+
+	return errors.WithStack(flow.ErrStrategyNotResponsible)
+}
+
 func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(r *http.Request, sr *login.Flow, _ *session.Session) error {
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/organization"
 	"github.com/ory/kratos/pkg"
 	kratos "github.com/ory/kratos/pkg/httpclient"
 	"github.com/ory/kratos/pkg/testhelpers"
@@ -40,6 +41,7 @@ import (
 	"github.com/ory/x/contextx"
 	"github.com/ory/x/ioutilx"
 	"github.com/ory/x/snapshotx"
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 )
 
@@ -708,3 +710,53 @@ func TestFormHydration(t *testing.T) {
 		toSnapshot(t, f)
 	})
 }
+
+func TestCompleteLoginWithOrganization(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceStrategyConfig + "." + string(identity.CredentialsTypePassword) + ".enabled": true,
+			config.ViperKeySelfServiceStrategyConfig + "." + string(identity.CredentialsTypeOIDC) + ".enabled":     true,
+			config.ViperKeySelfServiceLoginFlowStyle: "identifier_first",
+		}),
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/organization.schema.json")),
+	)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	org := &organization.Organization{
+		ID:      uuid.Must(uuid.NewV4()),
+		Label:   "ACME",
+		Domains: []string{"acme.com"},
+		OIDCProviders: []sqlxx.JSONRawMessage{
+			[]byte(`{"id":"acme-github","provider":"github","client_id":"acme-client","client_secret":"secret","mapper_url":"file://./stub/oidc.jsonnet"}`),
+		},
+	}
+	require.NoError(t, org.EncryptClientSecrets(ctx, reg.Cipher(ctx), nil))
+	require.NoError(t, reg.OrganizationPersister().CreateOrganization(ctx, org))
+
+	apiClient := testhelpers.NewDebugClient(t)
+	submit := func(t *testing.T, identifier string) (string, *http.Response) {
+		f := testhelpers.InitializeLoginFlowViaAPICtx(ctx, t, apiClient, publicTS, false)
+		return testhelpers.LoginMakeRequestCtx(ctx, t, true, false, f, apiClient, fmt.Sprintf(`{"method":"identifier_first","identifier":%q}`, identifier))
+	}
+
+	t.Run("case=sends users of the organization to its provider", func(t *testing.T) {
+		body, res := submit(t, "user@ACME.com")
+		require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, "%s", body)
+
+		redirect, err := url.Parse(gjson.Get(body, "redirect_browser_to").String())
+		require.NoError(t, err)
+		assert.Equal(t, "github.com", redirect.Host, "%s", body)
+		assert.Equal(t, "acme-client", redirect.Query().Get("client_id"))
+		assert.Equal(t, "user@ACME.com", redirect.Query().Get("login_hint"))
+		assert.Equal(t, publicTS.URL+oidc.RouteBase+"/organization/"+org.ID.String()+"/callback/acme-github", redirect.Query().Get("redirect_uri"))
+	})
+
+	t.Run("case=continues the login for other users", func(t *testing.T) {
+		body, res := submit(t, "user@example.com")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.False(t, gjson.Get(body, "redirect_browser_to").Exists(), "%s", body)
+	})
+}
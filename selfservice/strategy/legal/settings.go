@@ -0,0 +1,205 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/otelx"
+)
+
+// Update Settings Flow with Legal Method
+//
+// swagger:model updateSettingsFlowWithLegalMethod
+type updateSettingsFlowWithLegalMethod struct {
+	// LegalDocuments maps the IDs of the accepted legal documents to true.
+	// All documents pending acceptance must be accepted.
+	//
+	// required: true
+	LegalDocuments map[string]bool `json:"legal_documents"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "legal" when accepting legal documents.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+
+	// Transient data to pass along to any webhooks
+	//
+	// required: false
+	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
+}
+
+func (p *updateSettingsFlowWithLegalMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *updateSettingsFlowWithLegalMethod) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(ctx context.Context, w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (_ *settings.UpdateContext, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.legal.Strategy.Settings")
+	defer otelx.End(span, &err)
+
+	var p updateSettingsFlowWithLegalMethod
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, s.continueSettingsFlow(ctx, r, ctxUpdate, p))
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, f.GetFlowName(), s.SettingsStrategyID(), s.d); err != nil {
+		return ctxUpdate, err
+	}
+
+	docs, err := s.d.Config().SelfServiceLegalDocuments(ctx)
+	if err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	checked, err := legal.Decode(r, docs)
+	if err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	p.LegalDocuments = make(map[string]bool, len(checked))
+	for _, doc := range checked {
+		p.LegalDocuments[doc.ID] = true
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	return ctxUpdate, s.handleSettingsError(r, ctxUpdate, s.continueSettingsFlow(ctx, r, ctxUpdate, p))
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithLegalMethod) error {
+	if err := flow.MethodEnabledAndAllowed(ctx, flow.SettingsFlow, s.SettingsStrategyID(), s.SettingsStrategyID(), s.d); err != nil {
+		return err
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, ctxUpdate.Flow.Type, s.d.Config().DisableAPIFlowEnforcement(ctx), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return err
+	}
+
+	docs, err := s.d.Config().SelfServiceLegalDocuments(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Accepting legal documents does not change the identity, which is why
+	// the session does not need to be privileged.
+	pending, err := legal.Pending(ctx, s.d.LegalPersister(), ctxUpdate.Session.IdentityID, docs)
+	if err != nil {
+		return err
+	}
+
+	accepted := make([]config.LegalDocument, 0, len(pending))
+	for _, doc := range pending {
+		if p.LegalDocuments[doc.ID] {
+			accepted = append(accepted, doc)
+		}
+	}
+	if missing := legal.Missing(pending, legal.Versions(accepted)); len(missing) > 0 {
+		return legal.NewMissingError(missing)
+	}
+
+	if len(accepted) > 0 {
+		if err := s.d.LegalPersister().CreateLegalAcceptances(ctx, legal.NewAcceptances(r, ctxUpdate.Session.IdentityID, legal.Versions(accepted))); err != nil {
+			return err
+		}
+		for _, doc := range accepted {
+			trace.SpanFromContext(ctx).AddEvent(events.NewLegalDocumentAccepted(ctx, ctxUpdate.Session.IdentityID, doc.ID, doc.Version))
+		}
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	ctxUpdate.UpdateIdentity(i)
+	return nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request, id *identity.Identity, f *settings.Flow) (err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.legal.Strategy.PopulateSettingsMethod")
+	defer otelx.End(span, &err)
+
+	docs, err := s.d.Config().SelfServiceLegalDocuments(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending, err := legal.Pending(ctx, s.d.LegalPersister(), id.ID, docs)
+	if err != nil {
+		return err
+	} else if len(pending) == 0 {
+		return nil
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, n := range legal.NewNodes(pending, node.LegalGroup) {
+		f.UI.Nodes.Append(n)
+	}
+	f.UI.Nodes.Append(node.NewInputField("method", s.SettingsStrategyID(), node.LegalGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelContinue()))
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(r *http.Request, ctxUpdate *settings.UpdateContext, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctxUpdate != nil && ctxUpdate.Flow != nil && !errors.Is(err, flow.ErrStrategyAsksToReturnToUI) {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
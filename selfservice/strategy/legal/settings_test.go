@@ -0,0 +1,140 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package legal_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/configx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, false)),
+		configx.WithValues(testhelpers.MethodEnableConfig("profile", false)),
+		configx.WithValues(testhelpers.MethodEnableConfig("legal", true)),
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/settings.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceSettingsRequiredAAL: "aal1",
+		}),
+	)
+
+	setDocuments := func(t *testing.T, version string) {
+		conf.MustSet(ctx, config.ViperKeyLegalDocuments, []map[string]any{
+			{"id": "terms_of_service", "version": version, "title": "Terms of Service", "url": "https://www.ory.sh/tos"},
+			{"id": "privacy_policy", "version": "v1", "title": "Privacy Policy", "url": "https://www.ory.sh/privacy"},
+		})
+	}
+	setDocuments(t, "v1")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	createIdentity := func(t *testing.T) *identity.Identity {
+		i := &identity.Identity{Traits: identity.Traits(`{}`), SchemaID: config.DefaultIdentityTraitsSchemaID}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	listAcceptances := func(t *testing.T, i *identity.Identity) []legal.Acceptance {
+		acceptances, _, err := reg.LegalPersister().ListLegalAcceptances(ctx, legal.ListParameters{IdentityID: i.ID}, []keysetpagination.Option{})
+		require.NoError(t, err)
+		return acceptances
+	}
+
+	doSPAFlow := func(t *testing.T, v func(url.Values), id *identity.Identity) (string, int) {
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(ctx, t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", "legal")
+		v(values)
+		body, res := testhelpers.SettingsMakeRequest(t, false, true, f, browserClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		return body, res.StatusCode
+	}
+
+	t.Run("case=shows the pending documents", func(t *testing.T) {
+		id := createIdentity(t)
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(ctx, t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+
+		var names []string
+		for _, n := range f.Ui.Nodes {
+			if n.Group == string(node.LegalGroup) {
+				names = append(names, n.Attributes.UiNodeInputAttributes.Name)
+			}
+		}
+		assert.ElementsMatch(t, []string{"legal_documents.terms_of_service", "legal_documents.privacy_policy", "method"}, names)
+	})
+
+	t.Run("case=requires all pending documents", func(t *testing.T) {
+		id := createIdentity(t)
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set("legal_documents.terms_of_service", "true")
+		}, id)
+		assert.Equal(t, 400, code, body)
+		assert.EqualValues(t, text.ErrorValidationRequired, gjson.Get(body, `ui.nodes.#(attributes.name=="legal_documents.privacy_policy").messages.0.id`).Int(), body)
+		assert.Empty(t, listAcceptances(t, id))
+	})
+
+	t.Run("case=accepts the documents and asks again after a new version", func(t *testing.T) {
+		id := createIdentity(t)
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set("legal_documents.terms_of_service", "true")
+			v.Set("legal_documents.privacy_policy", "true")
+		}, id)
+		require.Equal(t, 200, code, body)
+		assert.EqualValues(t, "success", gjson.Get(body, "state").String(), body)
+		assert.False(t, gjson.Get(body, `ui.nodes.#(group=="legal")`).Exists(), body)
+
+		acceptances := listAcceptances(t, id)
+		require.Len(t, acceptances, 2)
+		for _, a := range acceptances {
+			assert.Equal(t, "v1", a.Version)
+			assert.NotEmpty(t, a.IPAddress)
+			assert.False(t, a.AcceptedAt.IsZero())
+		}
+
+		setDocuments(t, "v2")
+		t.Cleanup(func() { setDocuments(t, "v1") })
+
+		body, code = doSPAFlow(t, func(v url.Values) {
+			v.Set("legal_documents.terms_of_service", "true")
+		}, id)
+		require.Equal(t, 200, code, body)
+
+		acceptances = listAcceptances(t, id)
+		require.Len(t, acceptances, 3)
+		accepted, err := reg.LegalPersister().HasAcceptedLegalDocument(ctx, id.ID, "terms_of_service", "v2")
+		require.NoError(t, err)
+		assert.True(t, accepted)
+	})
+
+	t.Run("case=is not responsible for other methods", func(t *testing.T) {
+		id := createIdentity(t)
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set("method", "profile")
+			v.Set("legal_documents.terms_of_service", "true")
+			v.Set("legal_documents.privacy_policy", "true")
+		}, id)
+		assert.NotEqual(t, 200, code, body)
+		assert.Empty(t, listAcceptances(t, id))
+	})
+}
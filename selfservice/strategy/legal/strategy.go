@@ -0,0 +1,69 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+// Package legal lets users accept the current version of the configured
+// legal documents through the settings flow.
+package legal
+
+import (
+	_ "embed"
+
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+//go:embed .schema/settings.schema.json
+var settingsSchema []byte
+
+const StrategyID = "legal"
+
+var _ settings.Strategy = (*Strategy)(nil)
+
+type dependencies interface {
+	logrusx.Provider
+	httpx.WriterProvider
+	nosurfx.CSRFTokenGeneratorProvider
+	nosurfx.CSRFProvider
+	otelx.Provider
+
+	config.Provider
+
+	continuity.ManagementProvider
+
+	x.CookieProvider
+
+	errorx.ManagementProvider
+
+	identity.PrivilegedPoolProvider
+
+	settings.FlowPersistenceProvider
+	settings.HookExecutorProvider
+	settings.ErrorHandlerProvider
+
+	session.ManagementProvider
+
+	legal.PersistenceProvider
+}
+
+type Strategy struct{ d dependencies }
+
+func NewStrategy(d dependencies) *Strategy { return &Strategy{d: d} }
+
+func (s *Strategy) SettingsStrategyID() string {
+	return StrategyID
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.LegalGroup
+}
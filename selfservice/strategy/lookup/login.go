@@ -83,6 +83,12 @@ type updateLoginFlowWithLookupSecretMethod struct {
 	//
 	// required: true
 	Code string `json:"lookup_secret"`
+
+	// Trust this browser to skip the second factor on future sign-ins. Only
+	// used if trusted devices are enabled.
+	//
+	// required: false
+	RememberDevice bool `json:"remember_device"`
 }
 
 func (s *Strategy) Login(_ http.ResponseWriter, r *http.Request, f *login.Flow, sess *session.Session) (i *identity.Identity, err error) {
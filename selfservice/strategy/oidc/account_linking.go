@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ory/kratos/identity"
+)
+
+// AutomaticAccountLinkingPolicy is the ConflictingIdentityPolicy used for
+// providers with the "automatic" account linking mode if no other policy is
+// set. It merges the new credentials into the existing identity only if
+//
+//   - the provider is trusted to verify email addresses,
+//   - the claims assert that the email address is verified, and
+//   - the existing identity has verified the same email address.
+//
+// The last check prevents pre-hijacking: someone who registered the victim's
+// address without verifying it must not gain access to the victim's provider
+// account. All other conflicts continue with the explicit account linking step.
+//
+// The existing identity must have its verifiable addresses hydrated.
+func AutomaticAccountLinkingPolicy(_ context.Context, existingIdentity, _ *identity.Identity, provider Provider, claims *Claims) ConflictingIdentityVerdict {
+	c := provider.Config()
+	if c.AccountLinkingMode != AccountLinkingModeAutomatic || !c.TrustsEmailVerified() {
+		return ConflictingIdentityVerdictReject
+	}
+
+	if claims == nil || claims.Email == "" || !bool(claims.EmailVerified) {
+		return ConflictingIdentityVerdictReject
+	}
+
+	// Identities are only linked to providers of the same organization. An
+	// organization controls its provider and could otherwise assert any
+	// verified email address to take over identities outside of it.
+	var existingOrganization string
+	if existingIdentity.OrganizationID.Valid {
+		existingOrganization = existingIdentity.OrganizationID.UUID.String()
+	}
+	if existingOrganization != c.OrganizationID {
+		return ConflictingIdentityVerdictReject
+	}
+
+	for _, a := range existingIdentity.VerifiableAddresses {
+		if a.Via == identity.AddressTypeEmail && a.Verified && strings.EqualFold(a.Value, claims.Email) {
+			return ConflictingIdentityVerdictMerge
+		}
+	}
+
+	return ConflictingIdentityVerdictReject
+}
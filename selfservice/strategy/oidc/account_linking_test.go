@@ -0,0 +1,139 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+type configProvider struct{ c oidc.Configuration }
+
+func (p *configProvider) Config() *oidc.Configuration { return &p.c }
+
+func TestAutomaticAccountLinkingPolicy(t *testing.T) {
+	t.Parallel()
+
+	const address = "user@ory.sh"
+	orgID := uuid.Must(uuid.NewV4())
+
+	existing := func(verified bool) *identity.Identity {
+		i := identity.NewIdentity("")
+		i.VerifiableAddresses = []identity.VerifiableAddress{{Via: identity.AddressTypeEmail, Value: address, Verified: verified}}
+		return i
+	}
+	automatic := oidc.Configuration{ID: "google", Provider: "google", AccountLinkingMode: oidc.AccountLinkingModeAutomatic}
+	verifiedClaims := &oidc.Claims{Subject: "sub", Email: address, EmailVerified: true}
+
+	for _, tc := range []struct {
+		name     string
+		config   oidc.Configuration
+		existing *identity.Identity
+		claims   *oidc.Claims
+		expected oidc.ConflictingIdentityVerdict
+	}{
+		{
+			name:     "merges if all checks pass",
+			config:   automatic,
+			existing: existing(true),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictMerge,
+		},
+		{
+			name:     "compares email addresses case-insensitively",
+			config:   automatic,
+			existing: existing(true),
+			claims:   &oidc.Claims{Subject: "sub", Email: "User@Ory.sh", EmailVerified: true},
+			expected: oidc.ConflictingIdentityVerdictMerge,
+		},
+		{
+			name:     "rejects if the mode is not automatic",
+			config:   oidc.Configuration{ID: "google", Provider: "google"},
+			existing: existing(true),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name:     "rejects untrusted providers",
+			config:   oidc.Configuration{ID: "microsoft", Provider: "microsoft", AccountLinkingMode: oidc.AccountLinkingModeAutomatic},
+			existing: existing(true),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name:     "merges for explicitly trusted providers",
+			config:   oidc.Configuration{ID: "corp", Provider: "generic", AccountLinkingMode: oidc.AccountLinkingModeAutomatic, TrustEmailVerified: true},
+			existing: existing(true),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictMerge,
+		},
+		{
+			name:     "rejects unverified email claims",
+			config:   automatic,
+			existing: existing(true),
+			claims:   &oidc.Claims{Subject: "sub", Email: address},
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name:     "rejects other email addresses",
+			config:   automatic,
+			existing: existing(true),
+			claims:   &oidc.Claims{Subject: "sub", Email: "other@ory.sh", EmailVerified: true},
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name:     "rejects if the existing address is not verified",
+			config:   automatic,
+			existing: existing(false),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name:   "rejects identities of another organization",
+			config: automatic,
+			existing: func() *identity.Identity {
+				i := existing(true)
+				i.OrganizationID = uuid.NullUUID{UUID: orgID, Valid: true}
+				return i
+			}(),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name: "rejects identities without organization for organization providers",
+			config: oidc.Configuration{
+				ID: "org", Provider: "generic", AccountLinkingMode: oidc.AccountLinkingModeAutomatic,
+				TrustEmailVerified: true, OrganizationID: orgID.String(),
+			},
+			existing: existing(true),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictReject,
+		},
+		{
+			name: "merges identities of the provider's organization",
+			config: oidc.Configuration{
+				ID: "org", Provider: "generic", AccountLinkingMode: oidc.AccountLinkingModeAutomatic,
+				TrustEmailVerified: true, OrganizationID: orgID.String(),
+			},
+			existing: func() *identity.Identity {
+				i := existing(true)
+				i.OrganizationID = uuid.NullUUID{UUID: orgID, Valid: true}
+				return i
+			}(),
+			claims:   verifiedClaims,
+			expected: oidc.ConflictingIdentityVerdictMerge,
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			actual := oidc.AutomaticAccountLinkingPolicy(context.Background(), tc.existing, identity.NewIdentity(""), &configProvider{c: tc.config}, tc.claims)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
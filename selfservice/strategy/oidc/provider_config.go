@@ -130,7 +130,7 @@ type Configuration struct {
 	RequestedClaims json.RawMessage `json:"requested_claims"`
 
 	// An optional organization ID that this provider belongs to.
-	// This parameter is only effective in the Ory Network.
+	// Providers of organizations are usually managed using the organizations admin API.
 	OrganizationID string `json:"organization_id"`
 
 	// AdditionalIDTokenAudiences is a list of additional audiences allowed in the ID Token.
@@ -163,9 +163,16 @@ type Configuration struct {
 
 	// AccountLinkingMode controls how account conflicts are resolved for this provider.
 	// Possible values: "confirm_with_existing_credential" (default), "automatic".
-	// This is only effective in the Ory Network.
+	//
+	// In "automatic" mode, the provider is linked to the existing identity without
+	// confirmation if TrustsEmailVerified returns true, the claims assert a verified
+	// email address, and the existing identity has verified that address.
 	AccountLinkingMode string `json:"account_linking_mode,omitempty"`
 
+	// TrustEmailVerified declares that the provider only asserts `email_verified`
+	// for email addresses it has verified itself. See TrustsEmailVerified.
+	TrustEmailVerified bool `json:"trust_email_verified,omitempty"`
+
 	// UpdateIdentityOnLogin controls whether the identity is updated from
 	// OIDC claims on each login.
 	//
@@ -212,6 +219,23 @@ func (c *Configuration) AALForClaims(claims *Claims) identity.AuthenticatorAssur
 	return identity.AuthenticatorAssuranceLevel1
 }
 
+// emailVerifiedTrustedProviders are the provider types which only assert
+// verified email addresses they own or have verified themselves.
+var emailVerifiedTrustedProviders = []string{
+	ProviderTypeApple,
+	ProviderTypeGoogle,
+	"github",
+	"github-app",
+	"gitlab",
+}
+
+// TrustsEmailVerified returns true if the provider's `email_verified` claim can
+// be relied upon to link accounts. Generic and multi-tenant providers, such as
+// Microsoft, are only trusted if TrustEmailVerified is set.
+func (c *Configuration) TrustsEmailVerified() bool {
+	return c.TrustEmailVerified || slices.Contains(emailVerifiedTrustedProviders, c.Provider)
+}
+
 func (p Configuration) Redir(public *url.URL) string {
 	if p.PKCE == "force" {
 		return urlx.AppendPaths(public, RouteCallbackGeneric).String()
@@ -30,6 +30,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	oidcv1 "github.com/ory/kratos/gen/oidc/v1"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/organization"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow"
@@ -52,6 +53,7 @@ import (
 	"github.com/ory/x/otelx"
 	"github.com/ory/x/otelx/semconv"
 	"github.com/ory/x/reqlog"
+	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 )
@@ -84,8 +86,11 @@ type Dependencies interface {
 	otelx.Provider
 
 	identity.ValidationProvider
+	identity.TraitsClassifierProvider
 	identity.PrivilegedPoolProvider
 	identity.ActiveCredentialsCounterStrategyProvider
+
+	organization.PersistenceProvider
 	identity.ManagementProvider
 
 	session.ManagementProvider
@@ -205,6 +210,7 @@ func (s *Strategy) RegisterPublicRoutes(r *httprouterx.RouterPublic) {
 	wrappedHandleCallback := strategy.IsDisabled(s.d, s.ID().String(), s.HandleCallback)
 	r.GET(RouteCallback, wrappedHandleCallback)
 	r.GET(RouteCallbackGeneric, wrappedHandleCallback)
+	r.GET(RouteOrganizationCallback, wrappedHandleCallback)
 
 	// Apple can use the POST request method when calling the callback
 	// Apple is the only (known) provider that sometimes does a form POST to the callback URL.
@@ -212,11 +218,13 @@ func (s *Strategy) RegisterPublicRoutes(r *httprouterx.RouterPublic) {
 	// But since the URL contains the `id` of the provider, we just allow all OIDC provider callbacks to bypass CSRF.
 	// This is fine, because all other providers seem to use GET, which is CSRF safe.
 	s.d.CSRFHandler().IgnoreGlob(RouteBase + "/callback/*")
+	s.d.CSRFHandler().IgnoreGlob(RouteBase + "/organization/*/callback/*")
 
 	// When handler is called using POST method, the cookies are not attached to the request
 	// by the browser. So here we just redirect the request to the same location rewriting the
 	// form fields to query params. This second GET request should have the cookies attached.
 	r.POST(RouteCallback, s.redirectToGET)
+	r.POST(RouteOrganizationCallback, s.redirectToGET)
 }
 
 func (s *Strategy) RegisterAdminRoutes(*httprouterx.RouterAdmin) {}
@@ -498,6 +506,11 @@ func (s *Strategy) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if organizationFromURL := r.PathValue("organization"); organizationFromURL != "" && organizationFromURL != provider.Config().OrganizationID {
+		s.forwardError(ctx, w, r, req, s.HandleError(ctx, w, r, req, state.ProviderId, nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf(`Unable to complete OpenID Connect flow: organization mismatch between provider and URL.`))))
+		return
+	}
+
 	var claims *Claims
 	var et *identity.CredentialsOIDCEncryptedTokens
 	switch p := provider.(type) {
@@ -646,11 +659,42 @@ func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error)
 func (s *Strategy) Provider(ctx context.Context, id string) (Provider, error) {
 	if c, err := s.Config(ctx); err != nil {
 		return nil, err
-	} else if provider, err := c.Provider(id, s.d); err != nil {
-		return nil, s.handleUnknownProviderError(err)
-	} else {
+	} else if provider, err := c.Provider(id, s.d); err == nil {
 		return provider, nil
+	} else if provider, orgErr := s.organizationProvider(ctx, id); orgErr == nil {
+		return provider, nil
+	} else if !errors.Is(orgErr, sqlcon.ErrNoRows()) {
+		return nil, orgErr
+	} else {
+		return nil, s.handleUnknownProviderError(err)
+	}
+}
+
+// organizationProvider returns the provider of an organization, which are
+// managed using the organizations admin API instead of the configuration.
+func (s *Strategy) organizationProvider(ctx context.Context, id string) (Provider, error) {
+	if s.ID() != identity.CredentialsTypeOIDC {
+		return nil, errors.WithStack(sqlcon.ErrNoRows())
 	}
+
+	op, err := s.d.OrganizationPersister().FindOrganizationOIDCProvider(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := op.DecryptClientSecret(ctx, s.d.Cipher(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var c Configuration
+	if err := json.Unmarshal(conf, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the OpenID Connect Provider configuration of the organization: %s", err))
+	}
+	c.ID = op.ProviderID
+	c.OrganizationID = op.OrganizationID.String()
+
+	return ConfigurationCollection{Providers: []Configuration{c}}.Provider(c.ID, s.d)
 }
 
 func (s *Strategy) forwardError(ctx context.Context, w http.ResponseWriter, r *http.Request, f flow.Flow, err error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/continuity"
@@ -31,6 +32,7 @@ import (
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
 	"github.com/ory/x/otelx"
 	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlxx"
@@ -101,7 +103,8 @@ type UpdateLoginFlowWithOidcMethod struct {
 }
 
 func (s *Strategy) handleConflictingIdentity(ctx context.Context, loginFlow *login.Flow, token *identity.CredentialsOIDCEncryptedTokens, claims *Claims, provider Provider, container *AuthCodeContainer) (verdict ConflictingIdentityVerdict, id *identity.Identity, credentials *identity.Credentials, err error) {
-	if s.conflictingIdentityPolicy == nil {
+	policy := s.conflictingIdentityPolicyFor(provider)
+	if policy == nil {
 		return ConflictingIdentityVerdictReject, nil, nil, nil
 	}
 
@@ -141,23 +144,63 @@ func (s *Strategy) handleConflictingIdentity(ctx context.Context, loginFlow *log
 
 	newIdentity.SetCredentials(s.ID(), *creds)
 
+	verdict, existingIdentity, err := s.linkConflictingIdentity(ctx, policy, newIdentity, creds, provider, claims)
+	if err != nil {
+		return ConflictingIdentityVerdictUnknown, nil, nil, err
+	}
+
+	return verdict, existingIdentity, creds, nil
+}
+
+// conflictingIdentityPolicyFor returns the policy which resolves conflicts with
+// existing identities for the provider, or nil if conflicts always require the
+// explicit account linking step.
+func (s *Strategy) conflictingIdentityPolicyFor(provider Provider) ConflictingIdentityPolicy {
+	if s.conflictingIdentityPolicy != nil {
+		return s.conflictingIdentityPolicy
+	}
+	if provider.Config().AccountLinkingMode == AccountLinkingModeAutomatic {
+		return AutomaticAccountLinkingPolicy
+	}
+	return nil
+}
+
+// linkConflictingIdentity looks up the identity which conflicts with the new
+// identity and, if the policy says so, merges the new credentials into it. If
+// there is no conflicting identity, the verdict is to reject.
+func (s *Strategy) linkConflictingIdentity(ctx context.Context, policy ConflictingIdentityPolicy, newIdentity *identity.Identity, creds *identity.Credentials, provider Provider, claims *Claims) (ConflictingIdentityVerdict, *identity.Identity, error) {
 	existingIdentity, _, _, err := s.d.IdentityManager().ConflictingIdentity(ctx, newIdentity)
 	if err != nil {
-		return ConflictingIdentityVerdictReject, nil, nil, nil
+		return ConflictingIdentityVerdictReject, nil, nil
 	}
 
-	verdict = s.conflictingIdentityPolicy(ctx, existingIdentity, newIdentity, provider, claims)
-	if verdict == ConflictingIdentityVerdictMerge {
-		if err = existingIdentity.MergeOIDCCredentials(s.ID(), *creds); err != nil {
-			return ConflictingIdentityVerdictUnknown, nil, nil, err
-		}
+	// The policy may depend on the verification state of the existing addresses.
+	if err := s.d.PrivilegedIdentityPool().HydrateIdentityAssociations(ctx, existingIdentity, identity.Expandables{identity.ExpandFieldVerifiableAddresses}); err != nil {
+		return ConflictingIdentityVerdictUnknown, nil, err
+	}
 
-		if err = s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, existingIdentity); err != nil {
-			return ConflictingIdentityVerdictUnknown, nil, nil, err
-		}
+	verdict := policy(ctx, existingIdentity, newIdentity, provider, claims)
+	if verdict != ConflictingIdentityVerdictMerge {
+		return verdict, existingIdentity, nil
 	}
 
-	return verdict, existingIdentity, creds, nil
+	if err := existingIdentity.MergeOIDCCredentials(s.ID(), *creds); err != nil {
+		return ConflictingIdentityVerdictUnknown, nil, err
+	}
+
+	if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, existingIdentity); err != nil {
+		return ConflictingIdentityVerdictUnknown, nil, err
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewIdentityCredentialsLinked(ctx, existingIdentity.ID, s.ID().String(), provider.Config().ID, events.CredentialsLinkSourceAutomaticAccountLinking))
+	s.d.Logger().
+		WithField("audience", "audit").
+		WithField("identity_id", existingIdentity.ID).
+		WithField("provider", provider.Config().ID).
+		WithField("subject", claims.Subject).
+		Info("Linked the provider to the conflicting identity without asking the user to confirm an existing credential.")
+
+	return verdict, existingIdentity, nil
 }
 
 func verifiableAddressHash(i *identity.Identity) [sha256.Size]byte {
@@ -505,9 +548,22 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, errors.WithStack(flow.ErrCompletedByStrategy)
 	}
 
+	var up map[string]string
+	if err := json.NewDecoder(bytes.NewBuffer(p.UpstreamParameters)).Decode(&up); err != nil {
+		return nil, err
+	}
+
+	return nil, s.redirectToProvider(ctx, w, r, f, provider, p.Traits, up)
+}
+
+// redirectToProvider pauses the login flow and sends the user to the provider
+// to sign in.
+func (s *Strategy) redirectToProvider(ctx context.Context, w http.ResponseWriter, r *http.Request, f *login.Flow, provider Provider, traits json.RawMessage, up map[string]string) error {
+	pid := provider.Config().ID
+
 	state, pkce, err := s.GenerateState(ctx, provider, f)
 	if err != nil {
-		return nil, s.HandleError(ctx, w, r, f, pid, nil, err)
+		return s.HandleError(ctx, w, r, f, pid, nil, err)
 	}
 	var refStore continuity.ContainerReferenceStore
 	if f.Type == flow.TypeAPI {
@@ -520,28 +576,23 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		continuity.WithPayload(&AuthCodeContainer{
 			State:            state,
 			FlowID:           f.ID.String(),
-			Traits:           p.Traits,
+			Traits:           traits,
 			TransientPayload: f.TransientPayload,
 			IdentitySchema:   f.IdentitySchema,
 		}),
 		continuity.WithLifespan(time.Minute*30),
 	); err != nil {
-		return nil, s.HandleError(ctx, w, r, f, pid, nil, err)
+		return s.HandleError(ctx, w, r, f, pid, nil, err)
 	}
 
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
-		return nil, s.HandleError(ctx, w, r, f, pid, nil, errors.WithStack(herodot.ErrInternalServerError().WithReason("Could not update flow").WithWrap(err)))
-	}
-
-	var up map[string]string
-	if err := json.NewDecoder(bytes.NewBuffer(p.UpstreamParameters)).Decode(&up); err != nil {
-		return nil, err
+		return s.HandleError(ctx, w, r, f, pid, nil, errors.WithStack(herodot.ErrInternalServerError().WithReason("Could not update flow").WithWrap(err)))
 	}
 
 	codeURL, err := getAuthRedirectURL(ctx, provider, f, state, up, pkce)
 	if err != nil {
-		return nil, s.HandleError(ctx, w, r, f, pid, nil, err)
+		return s.HandleError(ctx, w, r, f, pid, nil, err)
 	}
 
 	if x.IsJSONRequest(r) {
@@ -550,7 +601,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		http.Redirect(w, r, codeURL, http.StatusSeeOther)
 	}
 
-	return nil, errors.WithStack(flow.ErrCompletedByStrategy)
+	return errors.WithStack(flow.ErrCompletedByStrategy)
 }
 
 func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(r *http.Request, lf *login.Flow, _ *session.Session) error {
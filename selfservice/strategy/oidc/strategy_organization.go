@@ -0,0 +1,57 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/organization"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ login.OrganizationDiscoverer = new(Strategy)
+
+// LoginWithOrganization sends the user to the first OpenID Connect provider of
+// the organization their email domain belongs to.
+func (s *Strategy) LoginWithOrganization(w http.ResponseWriter, r *http.Request, f *login.Flow, identifier string) (err error) {
+	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.oidc.Strategy.LoginWithOrganization")
+	defer otelx.End(span, &err)
+
+	if s.ID() != identity.CredentialsTypeOIDC {
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	domain := organization.EmailDomain(identifier)
+	if domain == "" {
+		span.SetAttributes(attribute.String("not_responsible_reason", "identifier is not an email address"))
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	org, err := s.d.OrganizationPersister().FindOrganizationByDomain(ctx, domain)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		span.SetAttributes(attribute.String("not_responsible_reason", "email domain belongs to no organization"))
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	} else if err != nil {
+		return err
+	}
+	if len(org.OIDCProviders) == 0 {
+		span.SetAttributes(attribute.String("not_responsible_reason", "organization has no providers"))
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+	span.SetAttributes(attribute.String("organization", org.ID.String()))
+
+	provider, err := s.Provider(ctx, organization.ProviderID(org.OIDCProviders[0]))
+	if err != nil {
+		return err
+	}
+
+	return s.redirectToProvider(ctx, w, r, f, provider, nil, map[string]string{"login_hint": identifier})
+}
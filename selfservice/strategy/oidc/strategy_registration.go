@@ -380,6 +380,29 @@ func (s *Strategy) processRegistration(ctx context.Context, w http.ResponseWrite
 	}
 
 	i.SetCredentials(s.ID(), *creds)
+
+	// With automatic account linking, the credentials are added to a matching
+	// existing identity and the user is signed in to it instead.
+	if provider.Config().AccountLinkingMode == AccountLinkingModeAutomatic {
+		verdict, _, err := s.linkConflictingIdentity(ctx, s.conflictingIdentityPolicyFor(provider), i, creds, provider, claims)
+		if err != nil {
+			return nil, s.HandleError(ctx, w, r, rf, provider.Config().ID, i.Traits, err)
+		}
+
+		if verdict == ConflictingIdentityVerdictMerge {
+			lf, err := s.registrationToLogin(ctx, w, r, rf)
+			if err != nil {
+				return nil, s.HandleError(ctx, w, r, rf, provider.Config().ID, nil, err)
+			}
+
+			if _, err := s.ProcessLogin(ctx, w, r, lf, token, claims, provider, container); err != nil {
+				return lf, s.HandleError(ctx, w, r, rf, provider.Config().ID, nil, err)
+			}
+
+			return nil, nil
+		}
+	}
+
 	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, rf, i, session.AuthenticationMethod{
 		Method:       s.ID(),
 		AAL:          provider.Config().AALForClaims(claims),
@@ -462,7 +485,7 @@ func (s *Strategy) newIdentityFromClaims(ctx context.Context, claims *Claims, pr
 	}
 
 	i := identity.NewIdentity(schema.ID(ctx, s.d.Config()))
-	if err = s.setTraits(provider, container, evaluated, i); err != nil {
+	if err = s.setTraits(ctx, provider, container, evaluated, i); err != nil {
 		return nil, nil, err
 	}
 
@@ -492,7 +515,7 @@ func (s *Strategy) newIdentityFromClaims(ctx context.Context, claims *Claims, pr
 	return i, va, nil
 }
 
-func (s *Strategy) setTraits(provider Provider, container *AuthCodeContainer, evaluated string, i *identity.Identity) error {
+func (s *Strategy) setTraits(ctx context.Context, provider Provider, container *AuthCodeContainer, evaluated string, i *identity.Identity) error {
 	jsonTraits := gjson.Get(evaluated, "identity.traits")
 	if !jsonTraits.IsObject() {
 		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("OpenID Connect Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!"))
@@ -508,9 +531,14 @@ func (s *Strategy) setTraits(provider Provider, container *AuthCodeContainer, ev
 	} else {
 		i.Traits = identity.Traits(jsonTraits.Raw)
 	}
+
+	redacted, err := s.d.IdentityTraitsClassifier().WithRedactedTraits(ctx, i)
+	if err != nil {
+		return err
+	}
 	s.d.Logger().
 		WithField("oidc_provider", provider.Config().ID).
-		WithSensitiveField("identity_traits", i.Traits).
+		WithSensitiveField("identity_traits", redacted.Traits).
 		WithSensitiveField("mapper_jsonnet_output", evaluated).
 		WithField("mapper_jsonnet_url", provider.Config().Mapper).
 		Debug("Merged form values and OpenID Connect Jsonnet output.")
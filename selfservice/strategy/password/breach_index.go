@@ -0,0 +1,226 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// The breach index is a compact, offline representation of a breached password
+// corpus such as the Have I Been Pwned SHA-1 range files. It is built using
+// `kratos hashers breach-index build` and queried in-process by the validator.
+//
+// The file starts with a header followed by the sorted entries:
+//
+//	magic          [4]byte  "KBIX"
+//	version        uint32   1
+//	entries        uint64   number of entries
+//	fanout         [65536]uint64 number of entries whose fingerprint starts
+//	               with a two byte prefix less than or equal to the index
+//	entries        [entries]{fingerprint uint64; count uint32}
+//
+// The fingerprint is the first eight bytes of the password's SHA-1 hash and all
+// integers are big endian. Lookups read the fanout boundaries and binary search
+// the remaining range on disk, so the index is never loaded into memory.
+const (
+	breachIndexVersion    = 1
+	breachIndexFanout     = 1 << 16
+	breachIndexHeaderSize = 4 + 4 + 8 + breachIndexFanout*8
+	breachIndexEntrySize  = 8 + 4
+)
+
+var breachIndexMagic = []byte("KBIX")
+
+var (
+	ErrBreachIndexInvalid   = errors.New("the file is not a valid breach index")
+	ErrBreachIndexUnordered = errors.New("breach index entries must be added in ascending order of their hash")
+)
+
+type (
+	// BreachIndex is an opened breach index.
+	BreachIndex struct {
+		f       *os.File
+		entries uint64
+	}
+
+	// BreachIndexBuilder writes a breach index.
+	BreachIndexBuilder struct {
+		out      breachIndexWriter
+		w        *bufio.Writer
+		fanout   [breachIndexFanout]uint64
+		entries  uint64
+		minCount int64
+
+		last      uint64
+		lastCount int64
+		hasLast   bool
+	}
+
+	breachIndexWriter interface {
+		io.Writer
+		io.WriterAt
+		io.Seeker
+	}
+)
+
+// OpenBreachIndex opens the breach index at path.
+func OpenBreachIndex(path string) (*BreachIndex, error) {
+	f, err := os.Open(path) //#nosec G304 -- the path is set by the operator
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	header := make([]byte, 16)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		_ = f.Close()
+		return nil, errors.Wrap(ErrBreachIndexInvalid, err.Error())
+	}
+	if !bytes.Equal(header[:4], breachIndexMagic) || binary.BigEndian.Uint32(header[4:8]) != breachIndexVersion {
+		_ = f.Close()
+		return nil, errors.WithStack(ErrBreachIndexInvalid)
+	}
+
+	return &BreachIndex{f: f, entries: binary.BigEndian.Uint64(header[8:16])}, nil
+}
+
+// Close closes the underlying file.
+func (b *BreachIndex) Close() error {
+	return b.f.Close()
+}
+
+// Count returns how often the password with the given SHA-1 hash was found in
+// breaches, or zero if it is not part of the index.
+func (b *BreachIndex) Count(sha1sum []byte) (int64, error) {
+	if len(sha1sum) < 8 {
+		return 0, errors.New("expected a SHA-1 hash")
+	}
+	fingerprint := binary.BigEndian.Uint64(sha1sum)
+	prefix := fingerprint >> 48
+
+	var lo uint64
+	bounds := make([]byte, 16)
+	if prefix == 0 {
+		if _, err := b.f.ReadAt(bounds[8:], 16); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	} else if _, err := b.f.ReadAt(bounds, int64(16+(prefix-1)*8)); err != nil { //#nosec G115 -- prefix is at most 65535
+		return 0, errors.WithStack(err)
+	} else {
+		lo = binary.BigEndian.Uint64(bounds[:8])
+	}
+	hi := binary.BigEndian.Uint64(bounds[8:])
+	if hi > b.entries || lo > hi {
+		return 0, errors.WithStack(ErrBreachIndexInvalid)
+	}
+
+	entry := make([]byte, breachIndexEntrySize)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if _, err := b.f.ReadAt(entry, int64(breachIndexHeaderSize+mid*breachIndexEntrySize)); err != nil { //#nosec G115 -- bounded by the file size
+			return 0, errors.WithStack(err)
+		}
+
+		switch current := binary.BigEndian.Uint64(entry[:8]); {
+		case current == fingerprint:
+			return int64(binary.BigEndian.Uint32(entry[8:])), nil
+		case current < fingerprint:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, nil
+}
+
+// NewBreachIndexBuilder returns a builder writing the index to out. Entries
+// found in fewer than minCount breaches are omitted to reduce the index size.
+func NewBreachIndexBuilder(out breachIndexWriter, minCount int64) (*BreachIndexBuilder, error) {
+	if _, err := out.Seek(breachIndexHeaderSize, io.SeekStart); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &BreachIndexBuilder{out: out, w: bufio.NewWriterSize(out, 1<<20), minCount: minCount}, nil
+}
+
+// Add adds the SHA-1 hash of a breached password and its breach count. Hashes
+// must be added in ascending order, which is the order of the Have I Been Pwned
+// range files.
+func (b *BreachIndexBuilder) Add(sha1sum []byte, count int64) error {
+	if len(sha1sum) != 20 {
+		return errors.New("expected a SHA-1 hash")
+	}
+
+	fingerprint := binary.BigEndian.Uint64(sha1sum)
+	if b.hasLast {
+		if fingerprint < b.last {
+			return errors.WithStack(ErrBreachIndexUnordered)
+		}
+		if fingerprint == b.last {
+			// Different hashes sharing a fingerprint are merged.
+			b.lastCount = max(b.lastCount, count)
+			return nil
+		}
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+
+	b.last, b.lastCount, b.hasLast = fingerprint, count, true
+	return nil
+}
+
+// Entries returns the number of entries written so far.
+func (b *BreachIndexBuilder) Entries() uint64 {
+	return b.entries
+}
+
+// Finish writes the remaining entries and the header.
+func (b *BreachIndexBuilder) Finish() error {
+	if b.hasLast {
+		if err := b.flush(); err != nil {
+			return err
+		}
+		b.hasLast = false
+	}
+	if err := b.w.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	header := make([]byte, breachIndexHeaderSize)
+	copy(header, breachIndexMagic)
+	binary.BigEndian.PutUint32(header[4:8], breachIndexVersion)
+	binary.BigEndian.PutUint64(header[8:16], b.entries)
+
+	var total uint64
+	for k, n := range b.fanout {
+		total += n
+		binary.BigEndian.PutUint64(header[16+k*8:], total)
+	}
+
+	_, err := b.out.WriteAt(header, 0)
+	return errors.WithStack(err)
+}
+
+func (b *BreachIndexBuilder) flush() error {
+	if b.lastCount < b.minCount {
+		return nil
+	}
+
+	entry := make([]byte, breachIndexEntrySize)
+	binary.BigEndian.PutUint64(entry[:8], b.last)
+	binary.BigEndian.PutUint32(entry[8:], uint32(min(b.lastCount, math.MaxUint32))) //#nosec G115 -- the count is capped
+	if _, err := b.w.Write(entry); err != nil {
+		return errors.WithStack(err)
+	}
+
+	b.fanout[b.last>>48]++
+	b.entries++
+	return nil
+}
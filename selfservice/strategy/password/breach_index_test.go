@@ -0,0 +1,153 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password_test
+
+import (
+	"context"
+	"crypto/sha1" //#nosec G505 -- sha1 is used by the breach corpus
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/contextx"
+)
+
+func sha1Sum(pw string) []byte {
+	h := sha1.Sum([]byte(pw)) //#nosec G401 -- sha1 is used by the breach corpus
+	return h[:]
+}
+
+func buildBreachIndex(t *testing.T, minCount int64, breaches map[string]int64) string {
+	t.Helper()
+
+	sums := make([][]byte, 0, len(breaches))
+	counts := make(map[string]int64, len(breaches))
+	for pw, count := range breaches {
+		sum := sha1Sum(pw)
+		sums = append(sums, sum)
+		counts[string(sum)] = count
+	}
+	slices.SortFunc(sums, func(a, b []byte) int { return strings.Compare(string(a), string(b)) })
+
+	path := filepath.Join(t.TempDir(), "breaches.idx")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	b, err := password.NewBreachIndexBuilder(out, minCount)
+	require.NoError(t, err)
+	for _, sum := range sums {
+		require.NoError(t, b.Add(sum, counts[string(sum)]))
+	}
+	require.NoError(t, b.Finish())
+	require.NoError(t, out.Close())
+	return path
+}
+
+func TestBreachIndex(t *testing.T) {
+	t.Run("case=lookup", func(t *testing.T) {
+		path := buildBreachIndex(t, 2, map[string]int64{
+			"password": 10434004,
+			"123456":   37359195,
+			"hunter2":  33084,
+			"rare":     1,
+		})
+
+		index, err := password.OpenBreachIndex(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = index.Close() })
+
+		for pw, expected := range map[string]int64{
+			"password":         10434004,
+			"123456":           37359195,
+			"hunter2":          33084,
+			"rare":             0,
+			"not-breached-yet": 0,
+		} {
+			count, err := index.Count(sha1Sum(pw))
+			require.NoError(t, err)
+			assert.Equal(t, expected, count, pw)
+		}
+	})
+
+	t.Run("case=entries must be ordered", func(t *testing.T) {
+		out, err := os.Create(filepath.Join(t.TempDir(), "breaches.idx"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = out.Close() })
+
+		b, err := password.NewBreachIndexBuilder(out, 1)
+		require.NoError(t, err)
+
+		first, second := sha1Sum("password"), sha1Sum("123456")
+		if strings.Compare(string(first), string(second)) < 0 {
+			first, second = second, first
+		}
+		require.NoError(t, b.Add(first, 1))
+		assert.ErrorIs(t, b.Add(second, 1), password.ErrBreachIndexUnordered)
+	})
+
+	t.Run("case=invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "breaches.idx")
+		require.NoError(t, os.WriteFile(path, []byte("not an index file"), 0o600))
+
+		_, err := password.OpenBreachIndex(path)
+		assert.ErrorIs(t, err, password.ErrBreachIndexInvalid)
+	})
+}
+
+func TestDefaultPasswordValidatorBreachCorpus(t *testing.T) {
+	_, reg := pkg.NewVeryFastRegistryWithoutDB(t)
+	v, err := password.NewDefaultPasswordValidatorStrategy(reg)
+	require.NoError(t, err)
+
+	rangeFiles := t.TempDir()
+	breached := strings.ToUpper(hex.EncodeToString(sha1Sum("correct horse battery staple")))
+	require.NoError(t, os.WriteFile(filepath.Join(rangeFiles, breached[:5]+".txt"), []byte(breached[5:]+":25\r\n"), 0o600))
+
+	index := buildBreachIndex(t, 1, map[string]int64{"correct horse battery staple": 25})
+
+	for _, tc := range []struct {
+		format string
+		path   string
+	}{
+		{format: config.PasswordBreachCorpusFormatIndex, path: index},
+		{format: config.PasswordBreachCorpusFormatRangeFiles, path: rangeFiles},
+	} {
+		t.Run("format="+tc.format, func(t *testing.T) {
+			ctx := contextx.WithConfigValues(context.Background(), map[string]any{
+				config.ViperKeyPasswordHaveIBeenPwnedHost: "invalid.example.com",
+				config.ViperKeyPasswordBreachCorpusFormat: tc.format,
+				config.ViperKeyPasswordBreachCorpusPath:   tc.path,
+				config.ViperKeyPasswordMaxBreaches:        10,
+			})
+
+			err := v.Validate(ctx, "", "correct horse battery staple")
+			var message *text.Message
+			require.ErrorAs(t, err, &message)
+			assert.Equal(t, text.ErrorValidationPasswordTooManyBreaches, message.ID)
+
+			ctx = contextx.WithConfigValue(ctx, config.ViperKeyPasswordMaxBreaches, 30)
+			require.NoError(t, v.Validate(ctx, "", "correct horse battery staple"))
+		})
+	}
+
+	t.Run("case=lookup errors are not ignored", func(t *testing.T) {
+		ctx := contextx.WithConfigValues(context.Background(), map[string]any{
+			config.ViperKeyIgnoreNetworkErrors:      true,
+			config.ViperKeyPasswordBreachCorpusPath: filepath.Join(t.TempDir(), "missing.idx"),
+		})
+
+		var herodotErr *herodot.DefaultError
+		require.ErrorAs(t, v.Validate(ctx, "", "correct horse battery staple"), &herodotErr)
+	})
+}
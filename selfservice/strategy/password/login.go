@@ -134,10 +134,6 @@ func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID
 	if err != nil {
 		return err
 	}
-	co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(hpw)})
-	if err != nil {
-		return errors.Wrap(err, "unable to encode password configuration to JSON")
-	}
 
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identifier)
 	if err != nil {
@@ -149,6 +145,21 @@ func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID
 		return errors.New("expected to find password credential but could not")
 	}
 
+	// Keep the password history and change date, only the hash changes.
+	var conf identity.CredentialsPassword
+	if len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &conf); err != nil {
+			return errors.Wrap(err, "unable to decode password configuration from JSON")
+		}
+	}
+	conf.HashedPassword = string(hpw)
+	conf.UsePasswordMigrationHook = false
+
+	co, err := json.Marshal(&conf)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode password configuration to JSON")
+	}
+
 	c.Config = co
 	i.SetCredentials(s.ID(), *c)
 
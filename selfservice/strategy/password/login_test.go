@@ -1037,6 +1037,7 @@ func TestCompleteLogin(t *testing.T) {
 		require.NoError(t, json.NewDecoder(bytes.NewBuffer(c.Config)).Decode(&o))
 		assert.True(t, reg.Hasher(t.Context()).Understands([]byte(o.HashedPassword)), "%s", o.HashedPassword)
 		assert.True(t, hash.IsBcryptHash([]byte(o.HashedPassword)), "%s", o.HashedPassword)
+		assert.Nil(t, o.ChangedAt, "rehashing does not change the password")
 
 		// retry after upgraded
 		body = testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
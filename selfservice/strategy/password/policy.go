@@ -0,0 +1,207 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/hash"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/otelx"
+)
+
+type (
+	// PolicyRule is a rule of the password policy. Rules are checked whenever a
+	// user sets a new password, in addition to the checks of the Validator.
+	//
+	// Additional rules can be registered using driver.WithPasswordPolicyRules.
+	PolicyRule interface {
+		// CheckPassword returns nil if the password satisfies the rule. If it
+		// does not, a *text.Message describing the violation is returned. Any
+		// other error aborts the flow.
+		CheckPassword(ctx context.Context, c *PolicyCheck) error
+	}
+
+	// PolicyCheck is the input of a PolicyRule.
+	PolicyCheck struct {
+		// Identity is the identity the password is set for.
+		Identity *identity.Identity
+
+		// Password is the new password in clear text.
+		Password string
+
+		// Previous are the credentials of the password which is being replaced
+		// or nil if the identity has no password yet.
+		Previous *identity.CredentialsPassword
+
+		// Rules are the password policy rules of the identity's schema.
+		Rules *config.PasswordPolicyRules
+	}
+
+	// PolicyEngine checks passwords against the password policy configured for
+	// the identity schema.
+	PolicyEngine struct {
+		d     policyEngineDependencies
+		rules []PolicyRule
+	}
+
+	policyEngineDependencies interface {
+		config.Provider
+		httpx.ClientProvider
+	}
+
+	PolicyEngineProvider interface {
+		PasswordPolicyEngine() *PolicyEngine
+	}
+
+	// CompositionRule enforces the minimum number of lowercase letters,
+	// uppercase letters, digits, and symbols.
+	CompositionRule struct{}
+
+	// BannedWordsRule rejects passwords containing a banned word.
+	BannedWordsRule struct {
+		d policyEngineDependencies
+	}
+
+	// HistoryRule rejects passwords which were used recently.
+	HistoryRule struct{}
+)
+
+var (
+	_ PolicyRule = CompositionRule{}
+	_ PolicyRule = (*BannedWordsRule)(nil)
+	_ PolicyRule = HistoryRule{}
+)
+
+var bannedWordsCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     50 << 20, // 50MB
+	NumCounters: 10_000,
+	BufferItems: 64,
+})
+
+// NewPolicyEngine returns a policy engine which checks the built-in rules
+// followed by the given additional rules.
+func NewPolicyEngine(d policyEngineDependencies, rules ...PolicyRule) *PolicyEngine {
+	return &PolicyEngine{
+		d:     d,
+		rules: append([]PolicyRule{CompositionRule{}, NewBannedWordsRule(d), HistoryRule{}}, rules...),
+	}
+}
+
+// Check checks the new password of the identity against all rules. previous
+// are the credentials of the password being replaced and may be nil.
+func (e *PolicyEngine) Check(ctx context.Context, i *identity.Identity, password string, previous *identity.CredentialsPassword) error {
+	return otelx.WithSpan(ctx, "password.PolicyEngine.Check", func(ctx context.Context) error {
+		rules, err := e.d.Config().PasswordPolicyRules(ctx, i.SchemaID)
+		if err != nil {
+			return err
+		}
+
+		c := &PolicyCheck{Identity: i, Password: password, Previous: previous, Rules: rules}
+		for _, rule := range e.rules {
+			if err := rule.CheckPassword(ctx, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (CompositionRule) CheckPassword(_ context.Context, c *PolicyCheck) error {
+	var lower, upper, digits, symbols int
+	for _, r := range c.Password {
+		switch {
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsDigit(r):
+			digits++
+		case !unicode.IsLetter(r):
+			symbols++
+		}
+	}
+
+	//nolint:gosec // disable G115
+	switch {
+	case lower < int(c.Rules.MinLowercase):
+		return text.NewErrorValidationPasswordMinLowercase(int(c.Rules.MinLowercase), lower)
+	case upper < int(c.Rules.MinUppercase):
+		return text.NewErrorValidationPasswordMinUppercase(int(c.Rules.MinUppercase), upper)
+	case digits < int(c.Rules.MinDigits):
+		return text.NewErrorValidationPasswordMinDigits(int(c.Rules.MinDigits), digits)
+	case symbols < int(c.Rules.MinSymbols):
+		return text.NewErrorValidationPasswordMinSymbols(int(c.Rules.MinSymbols), symbols)
+	}
+	return nil
+}
+
+func NewBannedWordsRule(d policyEngineDependencies) *BannedWordsRule {
+	return &BannedWordsRule{d: d}
+}
+
+func (r *BannedWordsRule) CheckPassword(ctx context.Context, c *PolicyCheck) error {
+	password := strings.ToLower(c.Password)
+	for _, word := range c.Rules.BannedWords {
+		if word != "" && strings.Contains(password, strings.ToLower(word)) {
+			return text.NewErrorValidationPasswordBannedWord()
+		}
+	}
+
+	if c.Rules.BannedWordsURL == "" {
+		return nil
+	}
+
+	dictionary, err := fetcher.NewFetcher(
+		fetcher.WithClient(r.d.HTTPClient(ctx)),
+		fetcher.WithCache(bannedWordsCache, 60*time.Minute),
+	).FetchContext(ctx, c.Rules.BannedWordsURL)
+	if err != nil {
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to fetch the banned words dictionary from %s: %s", c.Rules.BannedWordsURL, err))
+	}
+
+	sc := bufio.NewScanner(dictionary)
+	for sc.Scan() {
+		word := strings.ToLower(strings.TrimSpace(sc.Text()))
+		if word != "" && strings.Contains(password, word) {
+			return text.NewErrorValidationPasswordBannedWord()
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to read the banned words dictionary from %s: %s", c.Rules.BannedWordsURL, err))
+	}
+	return nil
+}
+
+// CheckPassword compares the password with the previous passwords. The current
+// password is not compared because the settings strategy already rejects it.
+func (HistoryRule) CheckPassword(ctx context.Context, c *PolicyCheck) error {
+	if c.Previous == nil || c.Rules.HistorySize <= 1 {
+		return nil
+	}
+
+	previous := c.Previous.PreviousHashedPasswords
+	if uint(len(previous)) > c.Rules.HistorySize-1 {
+		previous = previous[:c.Rules.HistorySize-1]
+	}
+	for _, hashed := range previous {
+		if hash.Compare(ctx, []byte(c.Password), []byte(hashed)) == nil {
+			//nolint:gosec // disable G115
+			return text.NewErrorValidationPasswordReused(int(c.Rules.HistorySize))
+		}
+	}
+	return nil
+}
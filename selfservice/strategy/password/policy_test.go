@@ -0,0 +1,123 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package password_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/contextx"
+)
+
+type rejectAllRule struct{}
+
+func (rejectAllRule) CheckPassword(context.Context, *password.PolicyCheck) error {
+	return text.NewErrorValidationPasswordPolicyViolationGeneric("it is rejected by a custom rule")
+}
+
+func TestPolicyEngine(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewVeryFastRegistryWithoutDB(t)
+	i := &identity.Identity{SchemaID: "default"}
+
+	assertViolation := func(t *testing.T, err error, id text.ID) {
+		t.Helper()
+		var message *text.Message
+		require.True(t, errors.As(err, &message), "%+v", err)
+		assert.Equal(t, id, message.ID)
+	}
+
+	t.Run("case=composition", func(t *testing.T) {
+		ctx := contextx.WithConfigValue(ctx, config.ViperKeyPasswordPolicyRules, map[string]any{
+			"min_lowercase": 2,
+			"min_uppercase": 1,
+			"min_digits":    1,
+			"min_symbols":   1,
+		})
+
+		e := password.NewPolicyEngine(reg)
+		for _, tc := range []struct {
+			password string
+			expected text.ID
+		}{
+			{password: "A1!bcdef", expected: 0},
+			{password: "A1!b", expected: text.ErrorValidationPasswordMinLowercase},
+			{password: "a1!bcdef", expected: text.ErrorValidationPasswordMinUppercase},
+			{password: "Aa!bcdef", expected: text.ErrorValidationPasswordMinDigits},
+			{password: "A1bcdefg", expected: text.ErrorValidationPasswordMinSymbols},
+		} {
+			t.Run("password="+tc.password, func(t *testing.T) {
+				err := e.Check(ctx, i, tc.password, nil)
+				if tc.expected == 0 {
+					require.NoError(t, err)
+					return
+				}
+				assertViolation(t, err, tc.expected)
+			})
+		}
+	})
+
+	t.Run("case=banned words", func(t *testing.T) {
+		ctx := contextx.WithConfigValue(ctx, config.ViperKeyPasswordPolicyRules, map[string]any{
+			"banned_words":     []string{"Acme"},
+			"banned_words_url": "base64://" + base64.StdEncoding.EncodeToString([]byte("\nkratos\n  hunter  \n")),
+		})
+
+		e := password.NewPolicyEngine(reg)
+		require.NoError(t, e.Check(ctx, i, "correct horse battery staple", nil))
+		assertViolation(t, e.Check(ctx, i, "my-acme-password", nil), text.ErrorValidationPasswordBannedWord)
+		assertViolation(t, e.Check(ctx, i, "KRATOS2026", nil), text.ErrorValidationPasswordBannedWord)
+		assertViolation(t, e.Check(ctx, i, "hunter2", nil), text.ErrorValidationPasswordBannedWord)
+	})
+
+	t.Run("case=history", func(t *testing.T) {
+		ctx := contextx.WithConfigValue(ctx, config.ViperKeyPasswordPolicyRules, map[string]any{"history_size": 3})
+
+		hash := func(pw string) string {
+			h, err := reg.Hasher(ctx).Generate(ctx, []byte(pw))
+			require.NoError(t, err)
+			return string(h)
+		}
+		previous := &identity.CredentialsPassword{
+			HashedPassword:          hash("current"),
+			PreviousHashedPasswords: []string{hash("previous-1"), hash("previous-2"), hash("previous-3")},
+		}
+
+		e := password.NewPolicyEngine(reg)
+		require.NoError(t, e.Check(ctx, i, "something-new", previous))
+		require.NoError(t, e.Check(ctx, i, "previous-3", previous), "only the last three passwords are remembered")
+		require.NoError(t, e.Check(ctx, i, "previous-1", nil))
+		assertViolation(t, e.Check(ctx, i, "previous-1", previous), text.ErrorValidationPasswordReused)
+		assertViolation(t, e.Check(ctx, i, "previous-2", previous), text.ErrorValidationPasswordReused)
+	})
+
+	t.Run("case=schema policy replaces the default policy", func(t *testing.T) {
+		ctx := contextx.WithConfigValues(ctx, map[string]any{
+			config.ViperKeyPasswordPolicyRules: map[string]any{"min_digits": 3},
+			config.ViperKeyPasswordSchemaPolicies: []map[string]any{
+				{"schema_id": "employees", "policy": map[string]any{"min_symbols": 1}},
+			},
+		})
+
+		e := password.NewPolicyEngine(reg)
+		assertViolation(t, e.Check(ctx, i, "no-digits", nil), text.ErrorValidationPasswordMinDigits)
+		require.NoError(t, e.Check(ctx, &identity.Identity{SchemaID: "employees"}, "no-digits", nil))
+		assertViolation(t, e.Check(ctx, &identity.Identity{SchemaID: "employees"}, "nosymbols", nil), text.ErrorValidationPasswordMinSymbols)
+	})
+
+	t.Run("case=custom rules", func(t *testing.T) {
+		e := password.NewPolicyEngine(reg, rejectAllRule{})
+		assertViolation(t, e.Check(ctx, i, "anything", nil), text.ErrorValidationPasswordPolicyViolationGeneric)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/ory/x/otelx/semconv"
 
@@ -143,7 +144,7 @@ func (s *Strategy) Register(_ http.ResponseWriter, r *http.Request, f *registrat
 		return s.handleRegistrationError(r, f, p, err)
 	}
 
-	if err := s.validateCredentials(ctx, i, p.Password); err != nil {
+	if err := s.validateCredentials(ctx, i, p.Password, nil); err != nil {
 		return s.handleRegistrationError(r, f, p, err)
 	}
 
@@ -151,7 +152,7 @@ func (s *Strategy) Register(_ http.ResponseWriter, r *http.Request, f *registrat
 	case err := <-errC:
 		return s.handleRegistrationError(r, f, p, err)
 	case h := <-hpw:
-		co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(h)})
+		co, err := json.Marshal(new(identity.CredentialsPassword).WithNewHashedPassword(string(h), 0, time.Now()))
 		if err != nil {
 			return s.handleRegistrationError(r, f, p, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode password options to JSON: %s", err)))
 		}
@@ -161,7 +162,10 @@ func (s *Strategy) Register(_ http.ResponseWriter, r *http.Request, f *registrat
 	return nil
 }
 
-func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity, pw string) (err error) {
+// validateCredentials validates the identity and checks the new password
+// against the password validator and policy. previous are the credentials of
+// the password being replaced and may be nil.
+func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity, pw string, previous *identity.CredentialsPassword) (err error) {
 	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.password.Strategy.validateCredentials")
 	defer otelx.End(span, &err)
 
@@ -179,19 +183,27 @@ func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity
 
 	for _, id := range c.Identifiers {
 		if err := s.d.PasswordValidator().Validate(ctx, id, pw); err != nil {
-			if herodotErr := new(herodot.DefaultError); errors.As(err, &herodotErr) {
-				return err
-			}
-			if message := new(text.Message); errors.As(err, &message) {
-				return schema.NewPasswordPolicyViolationError("#/password", message)
-			}
-			return schema.NewPasswordPolicyViolationError("#/password", text.NewErrorValidationPasswordPolicyViolationGeneric(err.Error()))
+			return passwordPolicyViolation(err)
 		}
 	}
 
+	if err := s.d.PasswordPolicyEngine().Check(ctx, i, pw, previous); err != nil {
+		return passwordPolicyViolation(err)
+	}
+
 	return nil
 }
 
+func passwordPolicyViolation(err error) error {
+	if herodotErr := new(herodot.DefaultError); errors.As(err, &herodotErr) {
+		return err
+	}
+	if message := new(text.Message); errors.As(err, &message) {
+		return schema.NewPasswordPolicyViolationError("#/password", message)
+	}
+	return schema.NewPasswordPolicyViolationError("#/password", text.NewErrorValidationPasswordPolicyViolationGeneric(err.Error()))
+}
+
 func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) (err error) {
 	ctx, span := s.d.Tracer(r.Context()).Tracer().Start(r.Context(), "selfservice.strategy.password.Strategy.PopulateRegistrationMethod")
 	defer otelx.End(span, &err)
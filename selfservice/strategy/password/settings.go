@@ -120,20 +120,24 @@ func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
 
 // Try to find a password hash in the credentials. Returns it if found, otherwise return an empty string.
 func getPasswordHashFromCredential(creds map[identity.CredentialsType]identity.Credentials) string {
-	if creds == nil {
-		return ""
+	if c := getPasswordCredentialsConfig(creds); c != nil {
+		return c.HashedPassword
 	}
+	return ""
+}
 
+// Try to find the password credentials config. Returns nil if none was found.
+func getPasswordCredentialsConfig(creds map[identity.CredentialsType]identity.Credentials) *identity.CredentialsPassword {
 	cred, ok := creds[identity.CredentialsTypePassword]
 	if !ok {
-		return ""
+		return nil
 	}
 
-	var hashedPassword identity.CredentialsPassword
-	if err := json.Unmarshal(cred.Config, &hashedPassword); err != nil {
-		return ""
+	var c identity.CredentialsPassword
+	if err := json.Unmarshal(cred.Config, &c); err != nil {
+		return nil
 	}
-	return hashedPassword.HashedPassword
+	return &c
 }
 
 // Detect whether the new password is the same as the old password.
@@ -171,9 +175,15 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		return err
 	}
 
+	policy, err := s.d.Config().PasswordPolicyRules(ctx, i.SchemaID)
+	if err != nil {
+		return err
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 	var newPasswordHash []byte
-	// Extract an immutable value to avoid data races between goroutines.
+	// Extract immutable values to avoid data races between goroutines.
+	oldPassword := getPasswordCredentialsConfig(i.Credentials)
 	oldHashedPassword := getPasswordHashFromCredential(i.Credentials)
 
 	// Do in parallel due to limitations of the `bcrypt` library and for performance:
@@ -198,13 +208,13 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		// The credentials could have been modified in many ways possible. To keep it simple, we reset, and the validators
 		// will populate it correctly.
 		i.UpsertCredentialsConfig(s.ID(), []byte("{}"), 0)
-		return s.validateCredentials(ctx, i, p.Password)
+		return s.validateCredentials(ctx, i, p.Password, oldPassword)
 	})
 	if err := g.Wait(); err != nil {
 		return err
 	}
 
-	co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(newPasswordHash)})
+	co, err := json.Marshal(oldPassword.WithNewHashedPassword(string(newPasswordHash), policy.HistorySize, time.Now()))
 	if err != nil {
 		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode password options to JSON: %s", err))
 	}
@@ -51,6 +51,7 @@ type dependencies interface {
 
 	errorx.ManagementProvider
 	ValidationProvider
+	PolicyEngineProvider
 	hash.HashProvider
 
 	registration.HandlerProvider
@@ -72,6 +73,7 @@ type dependencies interface {
 
 	identity.PrivilegedPoolProvider
 	identity.ValidationProvider
+	identity.TraitsClassifierProvider
 	identity.ManagementProvider
 
 	session.HandlerProvider
@@ -8,7 +8,10 @@ import (
 	"context"
 	"crypto/sha1" //#nosec G505 -- sha1 is used for k-anonymity
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -130,9 +133,25 @@ func (s *DefaultPasswordValidator) fetch(ctx context.Context, hpw []byte, apiDNS
 		return 0, errors.Wrapf(ErrUnexpectedStatusCode, "%d", res.StatusCode)
 	}
 
-	var thisCount int64
+	counts, err := scanRange(prefix, res.Body)
+	if err != nil {
+		return 0, err
+	}
+	for h, count := range counts {
+		s.hashes.SetWithTTL(h, count, 1, hashCacheItemTTL)
+	}
+
+	thisCount := counts[b20(hpw)]
+	s.hashes.SetWithTTL(b20(hpw), thisCount, 1, hashCacheItemTTL)
+	return thisCount, nil
+}
+
+// scanRange parses the response of the HIBP range API or a HIBP range file and
+// returns the breach counts keyed by the full hash.
+func scanRange(prefix string, body io.Reader) (map[string]int64, error) {
+	counts := make(map[string]int64)
 
-	sc := bufio.NewScanner(res.Body)
+	sc := bufio.NewScanner(body)
 	for sc.Scan() {
 		row := sc.Text()
 		result := strings.Split(strings.TrimSpace(row), ":")
@@ -143,24 +162,52 @@ func (s *DefaultPasswordValidator) fetch(ctx context.Context, hpw []byte, apiDNS
 		// See https://github.com/ory/kratos/issues/2145
 		count := int64(1)
 		if len(result) == 2 {
+			var err error
 			count, err = strconv.ParseInt(strings.ReplaceAll(result[1], ",", ""), 10, 64)
 			if err != nil {
-				return 0, errors.WithStack(herodot.ErrUpstreamError().WithReasonf("Expected password hash to contain a count formatted as int but got: %s", result[1]))
+				return nil, errors.WithStack(herodot.ErrUpstreamError().WithReasonf("Expected password hash to contain a count formatted as int but got: %s", result[1]))
 			}
 		}
 
-		s.hashes.SetWithTTL(prefix+result[0], count, 1, hashCacheItemTTL)
-		if prefix+result[0] == b20(hpw) {
-			thisCount = count
-		}
+		counts[prefix+strings.ToUpper(result[0])] = count
 	}
 
 	if err := sc.Err(); err != nil {
-		return 0, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to initialize string scanner: %s", err))
+		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to initialize string scanner: %s", err))
 	}
 
-	s.hashes.SetWithTTL(b20(hpw), thisCount, 1, hashCacheItemTTL)
-	return thisCount, nil
+	return counts, nil
+}
+
+// lookupBreachCorpus returns how often the password hash was found in the
+// offline breach corpus.
+func lookupBreachCorpus(conf *config.PasswordPolicy, hpw []byte) (int64, error) {
+	if conf.BreachCorpusFormat == config.PasswordBreachCorpusFormatRangeFiles {
+		prefix := b20(hpw)[0:5]
+		f, err := os.Open(filepath.Join(conf.BreachCorpusPath, prefix+".txt")) //#nosec G304 -- the path is set by the operator
+		if err != nil {
+			return 0, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to open the password breach corpus: %s", err))
+		}
+		defer func() { _ = f.Close() }()
+
+		counts, err := scanRange(prefix, f)
+		if err != nil {
+			return 0, err
+		}
+		return counts[b20(hpw)], nil
+	}
+
+	index, err := OpenBreachIndex(conf.BreachCorpusPath)
+	if err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to open the password breach corpus: %s", err))
+	}
+	defer func() { _ = index.Close() }()
+
+	count, err := index.Count(hpw)
+	if err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to read the password breach corpus: %s", err))
+	}
+	return count, nil
 }
 
 func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, password string) error {
@@ -187,7 +234,7 @@ func (s *DefaultPasswordValidator) validate(ctx context.Context, identifier, pas
 		}
 	}
 
-	if !passwordPolicyConfig.HaveIBeenPwnedEnabled {
+	if !passwordPolicyConfig.HaveIBeenPwnedEnabled && passwordPolicyConfig.BreachCorpusPath == "" {
 		return nil
 	}
 
@@ -198,8 +245,18 @@ func (s *DefaultPasswordValidator) validate(ctx context.Context, identifier, pas
 	}
 	hpw := h.Sum(nil)
 
-	c, ok := s.hashes.Get(b20(hpw))
-	if !ok {
+	var c int64
+	if passwordPolicyConfig.BreachCorpusPath != "" {
+		// The offline corpus takes precedence over the API. Lookup errors are
+		// never ignored because they are not caused by the network.
+		var err error
+		c, err = lookupBreachCorpus(passwordPolicyConfig, hpw)
+		if err != nil {
+			return err
+		}
+	} else if cached, ok := s.hashes.Get(b20(hpw)); ok {
+		c = cached
+	} else {
 		var err error
 		c, err = s.fetch(ctx, hpw, passwordPolicyConfig.HaveIBeenPwnedHost)
 		if (errors.Is(err, ErrNetworkFailure) || errors.Is(err, ErrUnexpectedStatusCode)) && passwordPolicyConfig.IgnoreNetworkErrors {
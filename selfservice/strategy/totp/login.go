@@ -6,6 +6,7 @@ package totp
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
@@ -83,6 +84,12 @@ type updateLoginFlowWithTotpMethod struct {
 	// required: true
 	TOTPCode string `json:"totp_code"`
 
+	// Trust this browser to skip the second factor on future sign-ins. Only
+	// used if trusted devices are enabled.
+	//
+	// required: false
+	RememberDevice bool `json:"remember_device"`
+
 	// Transient data to pass along to any webhooks
 	//
 	// required: false
@@ -120,20 +127,54 @@ func (s *Strategy) Login(_ http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoTOTPDeviceRegistered()))
 	}
 
-	var o identity.CredentialsTOTPConfig
-	if err := json.Unmarshal(c.Config, &o); err != nil {
+	o, err := identity.NewCredentialsTOTPConfig(*c)
+	if err != nil {
 		return nil, x.WrapWithIdentityIDError(errors.WithStack(herodot.ErrInternalServerError().WithReason("The TOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err)), i.ID)
 	}
 
-	key, err := otp.NewKeyFromURL(o.TOTPURL)
-	if err != nil {
-		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(errors.WithStack(err), i.ID))
+	// The code may come from any of the identity's devices. Devices with an
+	// invalid TOTP URL are not counted as active and are skipped.
+	device := -1
+	for k, d := range o.Devices {
+		key, err := otp.NewKeyFromURL(d.TOTPURL)
+		if err != nil {
+			continue
+		}
+
+		if totp.Validate(p.TOTPCode, key.Secret()) {
+			device = k
+			break
+		}
 	}
 
-	if !totp.Validate(p.TOTPCode, key.Secret()) {
+	if device < 0 {
 		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(errors.WithStack(schema.NewTOTPVerifierWrongError("#/")), i.ID))
 	}
 
+	lastUsedAt := time.Now().UTC().Round(time.Second)
+	o.Devices[device].LastUsedAt = &lastUsedAt
+
+	// We can't use a transaction here because HydrateIdentityAssociations (used by update) does not support transactions.
+	toUpdate, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, sess.IdentityID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(err, i.ID))
+	}
+
+	encoded, err := json.Marshal(o)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(errors.WithStack(herodot.ErrInternalServerError().WithReason("Unable to encode updated TOTP devices.").WithDebug(err.Error())), i.ID))
+	}
+
+	c.Config = encoded
+	toUpdate.SetCredentials(s.ID(), *c)
+
+	if err := s.d.IdentityManager().Update(ctx, toUpdate,
+		// We need to allow write protected traits because we are updating the TOTP devices.
+		identity.ManagerAllowWriteProtectedTraits,
+	); err != nil {
+		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(errors.WithStack(herodot.ErrInternalServerError().WithReason("Unable to update identity.").WithDebug(err.Error())), i.ID))
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
 		return nil, s.handleLoginError(r, f, x.WrapWithIdentityIDError(errors.WithStack(herodot.ErrInternalServerError().WithReason("Could not update flow").WithDebug(err.Error())), i.ID))
@@ -397,6 +397,36 @@ func TestCompleteLogin(t *testing.T) {
 		assert.Equal(t, text.NewErrorValidationTOTPVerifierWrong().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
 	})
 
+	t.Run("case=should pass with a code of any TOTP device", func(t *testing.T) {
+		id, _, first := createIdentity(t.Context(), t, reg)
+		second, err := totp.NewKey(t.Context(), "foo", reg)
+		require.NoError(t, err)
+
+		firstID, secondID := x.NewUUID(), x.NewUUID()
+		cred := id.Credentials[identity.CredentialsTypeTOTP]
+		cred.Config = sqlxx.JSONRawMessage(fmt.Sprintf(`{"devices":[{"id":"%s","totp_url":"%s"},{"id":"%s","totp_url":"%s"}]}`, firstID, first.URL(), secondID, second.URL()))
+		id.SetCredentials(identity.CredentialsTypeTOTP, cred)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(t.Context(), id))
+
+		code, err := stdtotp.GenerateCode(second.Secret(), time.Now())
+		require.NoError(t, err)
+
+		body, res := doAPIFlow(t, func(v url.Values) {
+			v.Set("totp_code", code)
+		}, id)
+		assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.authenticator_assurance_level").String(), "%s", body)
+
+		_, actual, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(t.Context(), identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		conf, err := identity.NewCredentialsTOTPConfig(*actual)
+		require.NoError(t, err)
+		require.Len(t, conf.Devices, 2)
+		assert.Nil(t, conf.Devices[0].LastUsedAt)
+		require.NotNil(t, conf.Devices[1].LastUsedAt)
+		assert.WithinDuration(t, time.Now(), *conf.Devices[1].LastUsedAt, time.Minute)
+	})
+
 	t.Run("case=should fail if CSRF token is invalid", func(t *testing.T) {
 		id, _, _ := createIdentity(t.Context(), t, reg)
 		t.Run("type=browser", func(t *testing.T) {
@@ -4,16 +4,19 @@
 package totp
 
 import (
+	"cmp"
+
 	"github.com/pquerna/otp"
 
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
 )
 
-func NewVerifyTOTPNode() *node.Node {
+func NewVerifyTOTPNode(opts ...node.InputAttributesModifier) *node.Node {
 	return node.NewInputField(node.TOTPCode, nil, node.TOTPGroup,
 		node.InputAttributeTypeText,
-		node.WithRequiredInputAttribute).
+		append([]node.InputAttributesModifier{node.WithRequiredInputAttribute}, opts...)...).
 		WithMetaLabel(text.NewInfoNodeLabelVerifyOTP())
 }
 
@@ -41,3 +44,15 @@ func NewUnlinkTOTPNode() *node.Node {
 		node.WithRequiredInputAttribute).
 		WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateUnlinkTOTP())
 }
+
+func NewTOTPDeviceNameNode() *node.Node {
+	return node.NewInputField(node.TOTPDeviceName, "", node.TOTPGroup,
+		node.InputAttributeTypeText).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsTOTPDeviceName())
+}
+
+func NewRemoveTOTPNode(d *identity.CredentialTOTPDevice) *node.Node {
+	return node.NewInputField(node.TOTPRemove, d.ID.String(), node.TOTPGroup,
+		node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceRemoveTOTP(cmp.Or(d.DisplayName, "unnamed"), d.AddedAt, d.LastUsedAt))
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/ory/x/otelx"
 
@@ -48,11 +50,17 @@ type updateSettingsFlowWithTotpMethod struct {
 	// ValidationTOTP must contain a valid TOTP based on the
 	ValidationTOTP string `json:"totp_code"`
 
-	// UnlinkTOTP if true will remove the TOTP pairing,
+	// UnlinkTOTP if true will remove all TOTP pairings,
 	// effectively removing the credential. This can be used
 	// to set up a new TOTP device.
 	UnlinkTOTP bool `json:"totp_unlink"`
 
+	// RemoveTOTP removes the TOTP device with the given ID.
+	RemoveTOTP string `json:"totp_remove"`
+
+	// DeviceName is the name of the TOTP device which is being set up.
+	DeviceName string `json:"totp_device_name"`
+
 	// CSRFToken is the anti-CSRF token
 	CSRFToken string `json:"csrf_token"`
 
@@ -98,7 +106,7 @@ func (s *Strategy) Settings(ctx context.Context, w http.ResponseWriter, r *http.
 		return ctxUpdate, s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
 	}
 
-	if p.UnlinkTOTP {
+	if p.UnlinkTOTP || len(p.RemoveTOTP) > 0 {
 		// This is a submit so we need to manually set the type to TOTP
 		p.Method = s.SettingsStrategyID()
 		if err := flow.MethodEnabledAndAllowed(ctx, f.GetFlowName(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
@@ -144,19 +152,19 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		return errors.WithStack(settings.NewFlowNeedsReAuth())
 	}
 
-	hasTOTP, err := s.identityHasTOTP(ctx, ctxUpdate.Session.Identity)
-	if err != nil {
-		return err
-	}
-
-	// We have now two cases:
+	// We have now three cases:
 	//
-	// 1. TOTP should be removed -> we have it already
-	// 2. TOTP should be added -> we do not have it yet
+	// 1. All TOTP devices should be removed
+	// 2. A single TOTP device should be removed
+	// 3. A TOTP device should be added
 	var i *identity.Identity
-	if hasTOTP {
+	var err error
+	switch {
+	case p.UnlinkTOTP:
+		i, err = s.continueSettingsFlowUnlinkTOTP(ctx, ctxUpdate)
+	case len(p.RemoveTOTP) > 0:
 		i, err = s.continueSettingsFlowRemoveTOTP(ctx, ctxUpdate, p)
-	} else {
+	default:
 		i, err = s.continueSettingsFlowAddTOTP(ctx, ctxUpdate, p)
 	}
 
@@ -191,20 +199,27 @@ func (s *Strategy) continueSettingsFlowAddTOTP(ctx context.Context, ctxUpdate *s
 		return nil, schema.NewTOTPVerifierWrongError("#/totp_code")
 	}
 
-	co, err := json.Marshal(&identity.CredentialsTOTPConfig{TOTPURL: key.URL()})
-	if err != nil {
-		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode totp options to JSON: %s", err))
-	}
-
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// We do not really need the identifier, so we add the identity's ID
-	c := &identity.Credentials{Type: s.ID(), Identifiers: []string{i.ID.String()}, Config: co}
-	c.Config = co
-	i.SetCredentials(s.ID(), *c)
+	conf := new(identity.CredentialsTOTPConfig)
+	if c, ok := i.GetCredentials(s.ID()); ok {
+		if conf, err = identity.NewCredentialsTOTPConfig(*c); err != nil {
+			return nil, err
+		}
+	}
+
+	conf.Devices = append(conf.Devices, identity.CredentialTOTPDevice{
+		ID:          x.NewUUID(),
+		DisplayName: p.DeviceName,
+		TOTPURL:     key.URL(),
+		AddedAt:     time.Now().UTC().Round(time.Second),
+	})
+	if err := s.setDevices(i, conf); err != nil {
+		return nil, err
+	}
 
 	// Remove the TOTP URL from the internal context now that it is set!
 	ctxUpdate.Flow.InternalContext, err = sjson.DeleteBytes(ctxUpdate.Flow.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyURL))
@@ -227,20 +242,61 @@ func (s *Strategy) continueSettingsFlowAddTOTP(ctx context.Context, ctxUpdate *s
 	return i, nil
 }
 
-func (s *Strategy) continueSettingsFlowRemoveTOTP(ctx context.Context, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithTotpMethod) (*identity.Identity, error) {
-	if !p.UnlinkTOTP {
-		return ctxUpdate.Session.Identity, nil
+func (s *Strategy) continueSettingsFlowUnlinkTOTP(ctx context.Context, ctxUpdate *settings.UpdateContext) (*identity.Identity, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return nil, err
 	}
 
+	i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+	return i, nil
+}
+
+func (s *Strategy) continueSettingsFlowRemoveTOTP(ctx context.Context, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithTotpMethod) (*identity.Identity, error) {
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, ctxUpdate.Session.Identity.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+	c, ok := i.GetCredentials(s.ID())
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("You tried to remove a TOTP device but you have no TOTP device set up."))
+	}
+
+	conf, err := identity.NewCredentialsTOTPConfig(*c)
+	if err != nil {
+		return nil, err
+	}
+
+	id := x.ParseUUID(p.RemoveTOTP)
+	count := len(conf.Devices)
+	conf.Devices = slices.DeleteFunc(conf.Devices, func(d identity.CredentialTOTPDevice) bool { return d.ID == id })
+	if len(conf.Devices) == count {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("You tried to remove a TOTP device which does not exist."))
+	}
+
+	if len(conf.Devices) == 0 {
+		i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+		return i, nil
+	}
+
+	if err := s.setDevices(i, conf); err != nil {
+		return nil, err
+	}
 	return i, nil
 }
 
+func (s *Strategy) setDevices(i *identity.Identity, conf *identity.CredentialsTOTPConfig) error {
+	co, err := json.Marshal(conf)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode totp options to JSON: %s", err))
+	}
+
+	// We do not really need the identifier, so we add the identity's ID
+	i.SetCredentials(s.ID(), identity.Credentials{Type: s.ID(), Identifiers: []string{i.ID.String()}, Config: co})
+	return nil
+}
+
 func (s *Strategy) identityHasTOTP(ctx context.Context, id *identity.Identity) (bool, error) {
 	if len(id.Credentials) == 0 {
 		if err := s.d.PrivilegedIdentityPool().HydrateIdentityAssociations(ctx, id, identity.ExpandCredentials); err != nil {
@@ -267,35 +323,47 @@ func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request,
 		return err
 	}
 
-	// OTP already set up, just add an unlink option
+	// TOTP already set up, add options to remove single devices or all of them.
 	if hasTOTP {
-		f.UI.Nodes.Upsert(NewUnlinkTOTPNode())
-	} else {
-		e := NewSchemaExtension(id.ID.String())
-		_ = s.d.IdentityValidator().ValidateWithRunner(ctx, id, e)
-
-		// No TOTP set up yet, add nodes allowing us to add it.
-		key, err := NewKey(ctx, e.AccountName, s.d)
+		c, _ := id.GetCredentials(s.ID())
+		conf, err := identity.NewCredentialsTOTPConfig(*c)
 		if err != nil {
 			return err
 		}
 
-		f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyURL), key.URL())
-		if err != nil {
-			return err
+		for k := range conf.Devices {
+			f.UI.Nodes.Append(NewRemoveTOTPNode(&conf.Devices[k]))
 		}
+		f.UI.Nodes.Upsert(NewUnlinkTOTPNode())
+	}
 
-		qr, err := NewTOTPImageQRNode(key)
-		if err != nil {
-			return err
-		}
+	// Add nodes allowing us to add another device.
+	e := NewSchemaExtension(id.ID.String())
+	_ = s.d.IdentityValidator().ValidateWithRunner(ctx, id, e)
+
+	key, err := NewKey(ctx, e.AccountName, s.d)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyURL), key.URL())
+	if err != nil {
+		return err
+	}
 
-		f.UI.Nodes.Upsert(NewTOTPSourceURLNode(key))
-		f.UI.Nodes.Upsert(qr)
-		f.UI.Nodes.Upsert(NewVerifyTOTPNode())
-		f.UI.Nodes.Append(node.NewInputField("method", "totp", node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSave()))
+	qr, err := NewTOTPImageQRNode(key)
+	if err != nil {
+		return err
 	}
 
+	f.UI.Nodes.Upsert(NewTOTPSourceURLNode(key))
+	f.UI.Nodes.Upsert(qr)
+	f.UI.Nodes.Upsert(NewTOTPDeviceNameNode())
+	// The code is only required if the identity has no device yet, because
+	// removing a device does not need a code.
+	f.UI.Nodes.Upsert(NewVerifyTOTPNode(func(a *node.InputAttributes) { a.Required = !hasTOTP }))
+	f.UI.Nodes.Append(node.NewInputField("method", "totp", node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSave()))
+
 	return nil
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/totp"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/configx"
@@ -64,6 +65,11 @@ func TestCompleteSettings(t *testing.T) {
 		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
 		testhelpers.SnapshotTExcept(t, f.Ui.Nodes, []string{
 			"0.attributes.value",
+			"1.attributes.value",
+			"1.meta.label.context",
+			"3.attributes.src",
+			"4.attributes.text.context.secret",
+			"4.attributes.text.text",
 		})
 	})
 
@@ -305,12 +311,14 @@ func TestCompleteSettings(t *testing.T) {
 		checkIdentity := func(t *testing.T, id *identity.Identity, key string) {
 			i, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(t.Context(), identity.CredentialsTypeTOTP, id.ID.String())
 			require.NoError(t, err)
-			var c identity.CredentialsTOTPConfig
-			require.NoError(t, json.Unmarshal(cred.Config, &c))
-			actual, err := otp.NewKeyFromURL(c.TOTPURL)
+			c, err := identity.NewCredentialsTOTPConfig(*cred)
+			require.NoError(t, err)
+			require.Len(t, c.Devices, 1)
+			actual, err := otp.NewKeyFromURL(c.Devices[0].TOTPURL)
 			require.NoError(t, err)
 			assert.Equal(t, key, actual.Secret())
-			assert.Contains(t, c.TOTPURL, gjson.GetBytes(i.Traits, "subject").String())
+			assert.Contains(t, c.Devices[0].TOTPURL, gjson.GetBytes(i.Traits, "subject").String())
+			assert.Equal(t, "My phone", c.Devices[0].DisplayName)
 		}
 
 		run := func(t *testing.T, isAPI, isSPA bool, id *identity.Identity, hc *http.Client, f *kratos.SettingsFlow) {
@@ -326,6 +334,7 @@ func TestCompleteSettings(t *testing.T) {
 			require.NoError(t, err)
 			values.Set("method", "totp")
 			values.Set(node.TOTPCode, code)
+			values.Set(node.TOTPDeviceName, "My phone")
 
 			actual, res := testhelpers.SettingsMakeRequest(t, isAPI, isSPA, f, hc, testhelpers.EncodeFormAsJSON(t, isAPI || isSPA, values))
 			require.NotEmpty(t, key)
@@ -381,4 +390,88 @@ func TestCompleteSettings(t *testing.T) {
 			run(t, false, false, id, user, f)
 		})
 	})
+
+	getDevices := func(t *testing.T, id *identity.Identity) []identity.CredentialTOTPDevice {
+		_, cred, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(t.Context(), identity.CredentialsTypeTOTP, id.ID.String())
+		require.NoError(t, err)
+		c, err := identity.NewCredentialsTOTPConfig(*cred)
+		require.NoError(t, err)
+		return c.Devices
+	}
+
+	t.Run("type=add a second TOTP device", func(t *testing.T) {
+		id, _, key := createIdentity(t.Context(), t, reg)
+
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t.Context(), t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+
+		nodes, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+		secret := gjson.GetBytes(nodes, "#(attributes.id==totp_secret_key).attributes.text.context.secret").String()
+		require.NotEmpty(t, secret, "%s", nodes)
+
+		code, err := stdtotp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Del(node.TOTPUnlink)
+		values.Del(node.TOTPRemove)
+		values.Set("method", "totp")
+		values.Set(node.TOTPCode, code)
+		values.Set(node.TOTPDeviceName, "Tablet")
+
+		actual, res := testhelpers.SettingsMakeRequest(t, true, false, f, apiClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, flow.StateSuccess, gjson.Get(actual, "state").String(), actual)
+
+		devices := getDevices(t, id)
+		require.Len(t, devices, 2)
+		assert.Equal(t, key.URL(), devices[0].TOTPURL)
+		assert.Empty(t, devices[0].DisplayName)
+		assert.Contains(t, devices[1].TOTPURL, secret)
+		assert.Equal(t, "Tablet", devices[1].DisplayName)
+		assert.NotEqual(t, devices[0].ID, devices[1].ID)
+
+		assert.Len(t, gjson.Get(actual, `ui.nodes.#(attributes.name=="totp_remove")#`).Array(), 2, "%s", actual)
+	})
+
+	t.Run("type=remove a single TOTP device", func(t *testing.T) {
+		id, _, key := createIdentity(t.Context(), t, reg)
+		second, err := totp.NewKey(t.Context(), "foo", reg)
+		require.NoError(t, err)
+
+		cred, ok := id.GetCredentials(identity.CredentialsTypeTOTP)
+		require.True(t, ok)
+		removed, kept := x.NewUUID(), x.NewUUID()
+		cred.Config, err = json.Marshal(identity.CredentialsTOTPConfig{Devices: []identity.CredentialTOTPDevice{
+			{ID: removed, DisplayName: "Phone", TOTPURL: key.URL(), AddedAt: time.Now().UTC()},
+			{ID: kept, DisplayName: "Tablet", TOTPURL: second.URL(), AddedAt: time.Now().UTC()},
+		}})
+		require.NoError(t, err)
+		id.SetCredentials(identity.CredentialsTypeTOTP, *cred)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(t.Context(), id))
+
+		t.Run("case=unknown device", func(t *testing.T) {
+			actual, res := doAPIFlow(t, func(v url.Values) {
+				v.Del(node.TOTPUnlink)
+				v.Set(node.TOTPRemove, x.NewUUID().String())
+			}, id)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+			assert.Len(t, getDevices(t, id), 2)
+		})
+
+		t.Run("case=known device", func(t *testing.T) {
+			actual, res := doAPIFlow(t, func(v url.Values) {
+				v.Del(node.TOTPUnlink)
+				v.Set(node.TOTPRemove, removed.String())
+			}, id)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			assert.EqualValues(t, flow.StateSuccess, gjson.Get(actual, "state").String(), actual)
+
+			devices := getDevices(t, id)
+			require.Len(t, devices, 1)
+			assert.Equal(t, kept, devices[0].ID)
+			assert.Equal(t, "Tablet", devices[0].DisplayName)
+		})
+	})
 }
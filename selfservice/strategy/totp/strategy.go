@@ -5,12 +5,10 @@ package totp
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/ory/kratos/x/nosurfx"
 
-	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
 
 	"github.com/ory/kratos/continuity"
@@ -70,6 +68,7 @@ type dependencies interface {
 	settings.ErrorHandlerProvider
 
 	identity.PrivilegedPoolProvider
+	identity.ManagementProvider
 	identity.ValidationProvider
 
 	session.HandlerProvider
@@ -88,14 +87,15 @@ func (s *Strategy) CountActiveFirstFactorCredentials(_ context.Context, _ map[id
 func (s *Strategy) CountActiveMultiFactorCredentials(_ context.Context, cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
 	for _, c := range cc {
 		if c.Type == s.ID() && len(c.Config) > 0 {
-			var conf identity.CredentialsTOTPConfig
-			if err = json.Unmarshal(c.Config, &conf); err != nil {
-				return 0, errors.WithStack(err)
+			conf, err := identity.NewCredentialsTOTPConfig(c)
+			if err != nil {
+				return 0, err
 			}
 
-			_, err := otp.NewKeyFromURL(conf.TOTPURL)
-			if len(conf.TOTPURL) > 0 && err == nil {
-				count++
+			for _, d := range conf.Devices {
+				if _, err := otp.NewKeyFromURL(d.TOTPURL); len(d.TOTPURL) > 0 && err == nil {
+					count++
+				}
 			}
 		}
 	}
@@ -62,6 +62,22 @@ func TestCountActiveCredentials(t *testing.T) {
 				}},
 				expected: 0,
 			},
+			{
+				in: map[identity.CredentialsType]identity.Credentials{strategy.ID(): {
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"devices": [{"totp_url": "` + key.URL() + `"}, {"totp_url": "` + key.URL() + `"}]}`),
+				}},
+				expected: 2,
+			},
+			{
+				in: map[identity.CredentialsType]identity.Credentials{strategy.ID(): {
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"devices": [{"totp_url": ""}, {"totp_url": "` + key.URL() + `"}]}`),
+				}},
+				expected: 1,
+			},
 			{
 				in:       nil,
 				expected: 0,
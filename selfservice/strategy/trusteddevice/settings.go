@@ -0,0 +1,193 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+// Update Settings Flow with Trusted Device Method
+//
+// swagger:model updateSettingsFlowWithTrustedDeviceMethod
+type updateSettingsFlowWithTrustedDeviceMethod struct {
+	// Revoke the trusted device with this ID.
+	RevokeTrustedDevice string `json:"trusted_device_revoke"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "trusted_device" when trying to revoke a trusted device.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+
+	// Transient data to pass along to any webhooks
+	//
+	// required: false
+	TransientPayload json.RawMessage `json:"transient_payload,omitempty" form:"transient_payload"`
+}
+
+func (p *updateSettingsFlowWithTrustedDeviceMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *updateSettingsFlowWithTrustedDeviceMethod) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(ctx context.Context, w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (_ *settings.UpdateContext, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.trusteddevice.Strategy.Settings")
+	defer otelx.End(span, &err)
+
+	var p updateSettingsFlowWithTrustedDeviceMethod
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, s.continueSettingsFlow(ctx, r, ctxUpdate, p))
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	if p.RevokeTrustedDevice == "" {
+		span.SetAttributes(attribute.String("not_responsible_reason", "trusted_device_revoke was not set"))
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	// This method has only one kind of submit button.
+	p.Method = s.SettingsStrategyID()
+	if err := flow.MethodEnabledAndAllowed(ctx, f.GetFlowName(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return nil, s.handleSettingsError(r, ctxUpdate, err)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	return ctxUpdate, s.handleSettingsError(r, ctxUpdate, s.continueSettingsFlow(ctx, r, ctxUpdate, p))
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ctxUpdate *settings.UpdateContext, p updateSettingsFlowWithTrustedDeviceMethod) error {
+	if err := flow.MethodEnabledAndAllowed(ctx, flow.SettingsFlow, s.SettingsStrategyID(), s.SettingsStrategyID(), s.d); err != nil {
+		return err
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, ctxUpdate.Flow.Type, s.d.Config().DisableAPIFlowEnforcement(ctx), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return err
+	}
+
+	// Revoking a trusted device only makes signing in harder, which is why the
+	// session does not need to be privileged.
+	id, err := uuid.FromString(p.RevokeTrustedDevice)
+	if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The trusted device ID is invalid.").WithDebug(err.Error()))
+	}
+
+	if err := s.d.TrustedDevicePersister().DeleteTrustedDevice(ctx, ctxUpdate.Session.IdentityID, id); errors.Is(err, sqlcon.ErrNoRows()) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("You tried to revoke a trusted device which does not exist."))
+	} else if err != nil {
+		return err
+	}
+
+	ctxUpdate.Flow.UI.Nodes.Remove(node.TrustedDeviceRevoke)
+	if err := s.populateDevices(ctx, ctxUpdate.Session.IdentityID, ctxUpdate.Flow); err != nil {
+		return err
+	}
+
+	ctxUpdate.Flow.UI.Messages.Set(text.NewInfoSelfServiceSettingsUpdateSuccess())
+	if err := s.d.SettingsFlowPersister().UpdateSettingsFlow(ctx, ctxUpdate.Flow); err != nil {
+		return err
+	}
+
+	return flow.ErrStrategyAsksToReturnToUI
+}
+
+func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request, id *identity.Identity, f *settings.Flow) (err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.trusteddevice.Strategy.PopulateSettingsMethod")
+	defer otelx.End(span, &err)
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	return s.populateDevices(ctx, id.ID, f)
+}
+
+func (s *Strategy) populateDevices(ctx context.Context, identityID uuid.UUID, f *settings.Flow) error {
+	devices, err := s.d.TrustedDevicePersister().ListTrustedDevices(ctx, identityID)
+	if err != nil {
+		return err
+	}
+
+	for k := range devices {
+		f.UI.Nodes.Append(NewRevokeTrustedDeviceNode(&devices[k]))
+	}
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(r *http.Request, ctxUpdate *settings.UpdateContext, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctxUpdate != nil && ctxUpdate.Flow != nil && !errors.Is(err, flow.ErrStrategyAsksToReturnToUI) {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
+
+// NewRevokeTrustedDeviceNode returns the button which revokes the trusted
+// device.
+func NewRevokeTrustedDeviceNode(d *session.TrustedDevice) *node.Node {
+	var userAgent string
+	if d.UserAgent != nil {
+		userAgent = *d.UserAgent
+	}
+
+	var lastUsedAt *time.Time
+	if d.LastUsedAt != nil {
+		t := time.Time(*d.LastUsedAt)
+		lastUsedAt = &t
+	}
+
+	return node.NewInputField(node.TrustedDeviceRevoke, d.ID.String(), node.TrustedDeviceGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceRevokeTrustedDevice(userAgent, d.CreatedAt, lastUsedAt))
+}
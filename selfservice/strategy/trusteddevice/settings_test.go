@@ -0,0 +1,143 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/randx"
+)
+
+func createTrustedDevice(t *testing.T, reg driver.Registry, i *identity.Identity) *session.TrustedDevice {
+	d := &session.TrustedDevice{
+		IdentityID: i.ID,
+		UserAgent:  new("Mozilla/5.0"),
+		ExpiresAt:  time.Now().Add(time.Hour).UTC(),
+	}
+	require.NoError(t, reg.TrustedDevicePersister().CreateTrustedDevice(context.Background(), d, randx.MustString(32, randx.AlphaNum)))
+	return d
+}
+
+func TestCompleteSettings(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, false)),
+		configx.WithValues(testhelpers.MethodEnableConfig("profile", false)),
+		configx.WithValues(testhelpers.MethodEnableConfig("trusted_device", true)),
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/settings.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceSettingsRequiredAAL: "aal1",
+		}),
+	)
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	createIdentity := func(t *testing.T) *identity.Identity {
+		i := &identity.Identity{Traits: identity.Traits(`{}`), SchemaID: config.DefaultIdentityTraitsSchemaID}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	doSPAFlow := func(t *testing.T, v func(url.Values), id *identity.Identity) (string, int) {
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(ctx, t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		body, res := testhelpers.SettingsMakeRequest(t, false, true, f, browserClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		return body, res.StatusCode
+	}
+
+	t.Run("case=lists the trusted devices", func(t *testing.T) {
+		id := createIdentity(t)
+		first := createTrustedDevice(t, reg, id)
+		second := createTrustedDevice(t, reg, id)
+
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(ctx, t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+
+		var values []string
+		for _, n := range f.Ui.Nodes {
+			if n.Group == string(node.TrustedDeviceGroup) && n.Attributes.UiNodeInputAttributes.Name == node.TrustedDeviceRevoke {
+				values = append(values, n.Attributes.UiNodeInputAttributes.Value.(string))
+			}
+		}
+		assert.ElementsMatch(t, []string{first.ID.String(), second.ID.String()}, values)
+	})
+
+	t.Run("case=revokes a trusted device", func(t *testing.T) {
+		id := createIdentity(t)
+		revoked := createTrustedDevice(t, reg, id)
+		kept := createTrustedDevice(t, reg, id)
+
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set(node.TrustedDeviceRevoke, revoked.ID.String())
+		}, id)
+		require.Equal(t, 200, code, body)
+		assert.EqualValues(t, text.InfoSelfServiceSettingsUpdateSuccess, gjson.Get(body, "ui.messages.0.id").Int(), body)
+		assert.False(t, gjson.Get(body, `ui.nodes.#(attributes.value=="`+revoked.ID.String()+`")`).Exists(), body)
+		assert.True(t, gjson.Get(body, `ui.nodes.#(attributes.value=="`+kept.ID.String()+`")`).Exists(), body)
+
+		actual, err := reg.TrustedDevicePersister().ListTrustedDevices(ctx, id.ID)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, kept.ID, actual[0].ID)
+	})
+
+	t.Run("case=can not revoke the trusted device of another identity", func(t *testing.T) {
+		id := createIdentity(t)
+		other := createTrustedDevice(t, reg, createIdentity(t))
+
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set(node.TrustedDeviceRevoke, other.ID.String())
+		}, id)
+		assert.Equal(t, 400, code, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "does not exist", body)
+
+		actual, err := reg.TrustedDevicePersister().ListTrustedDevices(ctx, other.IdentityID)
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("case=rejects invalid IDs", func(t *testing.T) {
+		id := createIdentity(t)
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Set(node.TrustedDeviceRevoke, "not-a-uuid")
+		}, id)
+		assert.Equal(t, 400, code, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "invalid", body)
+	})
+
+	t.Run("case=is not responsible without a device", func(t *testing.T) {
+		id := createIdentity(t)
+		createTrustedDevice(t, reg, id)
+
+		body, code := doSPAFlow(t, func(v url.Values) {
+			v.Del(node.TrustedDeviceRevoke)
+		}, id)
+		assert.NotEqual(t, 200, code, body)
+
+		actual, err := reg.TrustedDevicePersister().ListTrustedDevices(ctx, id.ID)
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+}
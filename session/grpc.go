@@ -0,0 +1,179 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ory/herodot"
+	sessionv1 "github.com/ory/kratos/gen/session/v1"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/pagination/keysetpagination"
+)
+
+var (
+	_ sessionv1.SessionServiceServer = (*GRPCServer)(nil)
+
+	aalsToProto = map[identity.AuthenticatorAssuranceLevel]sessionv1.AuthenticatorAssuranceLevel{
+		identity.NoAuthenticatorAssuranceLevel: sessionv1.AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL0,
+		identity.AuthenticatorAssuranceLevel1:  sessionv1.AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL1,
+		identity.AuthenticatorAssuranceLevel2:  sessionv1.AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL2,
+	}
+)
+
+type (
+	// GRPCServer serves the session gRPC API. It shares the request handling
+	// of the session admin API.
+	GRPCServer struct {
+		sessionv1.UnimplementedSessionServiceServer
+		h *Handler
+	}
+
+	GRPCServerProvider interface {
+		SessionGRPCServer() *GRPCServer
+	}
+)
+
+func NewGRPCServer(h *Handler) *GRPCServer { return &GRPCServer{h: h} }
+
+// WhoAmI checks the session token or cookie like the whoami endpoint of the
+// public API. Unlike the public API, it does not refresh the session cookie.
+func (s *GRPCServer) WhoAmI(ctx context.Context, req *sessionv1.WhoAmIRequest) (*sessionv1.WhoAmIResponse, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if req.GetSessionToken() != "" {
+		r.Header.Set("X-Session-Token", req.GetSessionToken())
+	}
+	if req.GetCookie() != "" {
+		r.Header.Set("Cookie", req.GetCookie())
+	}
+
+	c := s.h.r.Config()
+	sess, err := s.h.r.SessionManager().FetchFromRequest(ctx, r)
+	if err != nil {
+		s.h.r.Logger().WithError(err).Info("No valid session found.")
+		return nil, errors.WithStack(ErrNoSessionFound().WithWrap(err))
+	}
+
+	var aalErr *ErrAALNotSatisfied
+	var requirementErr *ErrRequirementPending
+	if err := s.h.r.SessionManager().DoesSessionSatisfy(ctx, sess, c.SessionWhoAmIAAL(ctx), UpsertAAL); errors.As(err, &aalErr) || errors.As(err, &requirementErr) {
+		return nil, err
+	} else if err != nil {
+		return nil, errors.WithStack(herodot.ErrUnauthorized().WithWrap(err).WithReasonf("Unable to determine AAL."))
+	}
+
+	sess.Identity = sess.Identity.CopyWithoutCredentials()
+
+	ps, err := ToProto(sess)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionv1.WhoAmIResponse{Session: ps}, nil
+}
+
+func (s *GRPCServer) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRequest) (*sessionv1.ListSessionsResponse, error) {
+	query := url.Values{}
+	if req.GetPageSize() > 0 {
+		query.Set("page_size", strconv.Itoa(int(req.GetPageSize())))
+	}
+	if req.GetPageToken() != "" {
+		query.Set("page_token", req.GetPageToken())
+	}
+	opts, err := keysetpagination.Parse(query, keysetpagination.NewMapPageToken)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithError("could not parse parameter page_size"))
+	}
+
+	var expandables Expandables
+	for _, e := range req.GetExpand() {
+		expand, ok := ParseExpandable(e)
+		if !ok {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Could not parse expand option: %s", e))
+		}
+		expandables = append(expandables, expand)
+	}
+
+	sess, nextPage, err := s.h.r.SessionPersister().ListSessions(ctx, req.Active, opts, expandables)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &sessionv1.ListSessionsResponse{Sessions: make([]*sessionv1.Session, len(sess))}
+	for k := range sess {
+		if res.Sessions[k], err = ToProto(&sess[k]); err != nil {
+			return nil, err
+		}
+	}
+	if nextPage != nil && !nextPage.IsLast() {
+		res.NextPageToken = nextPage.Token().Encode()
+	}
+
+	return res, nil
+}
+
+func (s *GRPCServer) RevokeSession(ctx context.Context, req *sessionv1.RevokeSessionRequest) (*sessionv1.RevokeSessionResponse, error) {
+	id, err := uuid.FromString(req.GetId())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+	}
+
+	if err := s.h.r.SessionPersister().RevokeSessionById(ctx, id); err != nil {
+		return nil, err
+	}
+	return &sessionv1.RevokeSessionResponse{}, nil
+}
+
+// ToProto converts the session to its gRPC representation.
+func ToProto(s *Session) (*sessionv1.Session, error) {
+	ps := &sessionv1.Session{
+		Id:                          s.ID.String(),
+		Active:                      s.Active,
+		ExpiresAt:                   timestamppb.New(s.ExpiresAt),
+		AuthenticatedAt:             timestamppb.New(s.AuthenticatedAt),
+		IssuedAt:                    timestamppb.New(s.IssuedAt),
+		AuthenticatorAssuranceLevel: aalsToProto[s.AuthenticatorAssuranceLevel],
+	}
+
+	for _, m := range s.AMR {
+		ps.AuthenticationMethods = append(ps.AuthenticationMethods, &sessionv1.AuthenticationMethod{
+			Method:       string(m.Method),
+			Aal:          aalsToProto[m.AAL],
+			CompletedAt:  timestamppb.New(m.CompletedAt),
+			Provider:     m.Provider,
+			Organization: m.Organization,
+		})
+	}
+	for _, d := range s.Devices {
+		pd := &sessionv1.Device{Id: d.ID.String()}
+		if d.IPAddress != nil {
+			pd.IpAddress = *d.IPAddress
+		}
+		if d.UserAgent != nil {
+			pd.UserAgent = *d.UserAgent
+		}
+		if d.Location != nil {
+			pd.Location = *d.Location
+		}
+		ps.Devices = append(ps.Devices, pd)
+	}
+
+	if s.Identity != nil {
+		var err error
+		if ps.Identity, err = identity.ToProto(s.Identity); err != nil {
+			return nil, err
+		}
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ory/kratos/driver/config"
+	sessionv1 "github.com/ory/kratos/gen/session/v1"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/urlx"
+)
+
+func TestGRPCServer(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")))
+	client := sessionv1.NewSessionServiceClient(testhelpers.NewAdminGRPCClient(t, reg))
+	ctx := context.Background()
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := &http.Request{URL: urlx.ParseOrPanic("/")}
+	s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	t.Run("method=WhoAmI", func(t *testing.T) {
+		res, err := client.WhoAmI(ctx, &sessionv1.WhoAmIRequest{SessionToken: s.Token})
+		require.NoError(t, err)
+		assert.Equal(t, s.ID.String(), res.GetSession().GetId())
+		assert.True(t, res.GetSession().GetActive())
+		assert.Equal(t, i.ID.String(), res.GetSession().GetIdentity().GetId())
+		assert.Empty(t, res.GetSession().GetIdentity().GetCredentials())
+		assert.Equal(t, sessionv1.AuthenticatorAssuranceLevel_AUTHENTICATOR_ASSURANCE_LEVEL_AAL1, res.GetSession().GetAuthenticatorAssuranceLevel())
+
+		_, err = client.WhoAmI(ctx, &sessionv1.WhoAmIRequest{SessionToken: "not-a-token"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("method=ListSessions", func(t *testing.T) {
+		res, err := client.ListSessions(ctx, &sessionv1.ListSessionsRequest{Expand: []string{"identity"}})
+		require.NoError(t, err)
+		require.Len(t, res.GetSessions(), 1)
+		assert.Equal(t, s.ID.String(), res.GetSessions()[0].GetId())
+		assert.Equal(t, i.ID.String(), res.GetSessions()[0].GetIdentity().GetId())
+
+		_, err = client.ListSessions(ctx, &sessionv1.ListSessionsRequest{Expand: []string{"unknown"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("method=RevokeSession", func(t *testing.T) {
+		_, err := client.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{Id: s.ID.String()})
+		require.NoError(t, err)
+
+		active := true
+		res, err := client.ListSessions(ctx, &sessionv1.ListSessionsRequest{Active: &active})
+		require.NoError(t, err)
+		assert.Empty(t, res.GetSessions())
+
+		_, err = client.WhoAmI(ctx, &sessionv1.WhoAmIRequest{SessionToken: s.Token})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
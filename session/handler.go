@@ -32,6 +32,7 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
 )
 
@@ -50,10 +51,14 @@ type (
 	handlerDependencies interface {
 		ManagementProvider
 		PersistenceProvider
+		TrustedDevicePersistenceProvider
+		identity.PoolProvider
+		identity.PrivilegedPoolProvider
 		httpx.WriterProvider
 		otelx.Provider
 		logrusx.Provider
 		nosurfx.CSRFProvider
+		nosurfx.CSRFTokenGeneratorProvider
 		config.Provider
 		sessiontokenexchange.PersistenceProvider
 		FlowForTokenExchangeProvider
@@ -89,6 +94,12 @@ func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
 	admin.DELETE(AdminRouteIdentitiesSessions, h.deleteIdentitySessions)
 	admin.PATCH(AdminRouteSessionExtendId, h.adminSessionExtend)
 
+	admin.GET(AdminRouteIdentitiesTrustedDevices, h.listIdentityTrustedDevices)
+	admin.DELETE(AdminRouteIdentitiesTrustedDevices, h.deleteIdentityTrustedDevices)
+	admin.DELETE(AdminRouteIdentitiesTrustedDevice, h.deleteIdentityTrustedDevice)
+
+	admin.POST(AdminRouteIdentitiesImpersonate, h.createImpersonationSession)
+
 	admin.DELETE(RouteCollection, redir.RedirectToPublicRoute(h.r))
 }
 
@@ -100,6 +111,10 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*")
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*/extend")
 	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/sessions")
+	h.r.CSRFHandler().IgnoreGlob(RouteTrustedDevices + "/*")
+	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/trusted-devices")
+	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/trusted-devices/*")
+	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/impersonate")
 
 	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodConnect, http.MethodOptions, http.MethodTrace} {
 		public.Handler(m, RouteWhoami, http.HandlerFunc(h.whoami))
@@ -111,7 +126,13 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 
 	public.GET(RouteExchangeCodeForSessionToken, h.exchangeCode)
 
+	public.GET(RouteTrustedDevices, h.listMyTrustedDevices)
+	public.DELETE(RouteTrustedDevice, h.revokeMyTrustedDevice)
+
 	public.DELETE(AdminRouteIdentitiesSessions, redir.RedirectToAdminRoute(h.r))
+	public.GET(AdminRouteIdentitiesTrustedDevices, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(AdminRouteIdentitiesTrustedDevices, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(AdminRouteIdentitiesTrustedDevice, redir.RedirectToAdminRoute(h.r))
 }
 
 // Check Session Request Parameters
@@ -188,6 +209,9 @@ type toSession struct {
 // credentials (which would result in AAL2) but the session has only AAL1. If this error occurs, ask the user
 // to sign in with the second factor or change the configuration.
 //
+// If profile completion is enabled, this endpoint also returns a 403 status code for sessions of identities which
+// were missing required traits when signing in, until the traits were provided using the settings flow.
+//
 // This endpoint is useful for:
 //
 // - AJAX calls. Remember to send credentials and set up CORS correctly!
@@ -206,6 +230,7 @@ type toSession struct {
 //
 // - `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 // - `session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+// - `session_profile_incomplete`: An active session was found but the identity is missing required traits, implying that the user must complete the profile using the settings flow.
 //
 //	Produces:
 //	- application/json
@@ -238,6 +263,7 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var aalErr *ErrAALNotSatisfied
+	var requirementErr *ErrRequirementPending
 	if err := h.r.SessionManager().DoesSessionSatisfy(ctx, s, c.SessionWhoAmIAAL(ctx),
 		// For the time being we want to update the AAL in the database if it is unset.
 		UpsertAAL,
@@ -245,6 +271,10 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request) {
 		h.r.Logger().WithRequest(r).WithError(err).Info("Session was found but AAL is not satisfied for calling this endpoint.")
 		h.r.Writer().WriteError(w, r, err)
 		return
+	} else if errors.As(err, &requirementErr) {
+		h.r.Logger().WithRequest(r).WithError(err).Info("Session was found but it has pending requirements.")
+		h.r.Writer().WriteError(w, r, err)
+		return
 	} else if err != nil {
 		h.r.Logger().WithRequest(r).WithError(err).Info("No valid session cookie found.")
 		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized().WithWrap(err).WithReasonf("Unable to determine AAL."))
@@ -701,15 +731,14 @@ type disableMyOtherSessions struct {
 //	  200: deleteMySessionsCount
 //	  400: errorGeneric
 //	  401: errorGeneric
+//	  403: errorGeneric
 //	  default: errorGeneric
 //
 //	Extensions:
 //	  x-ory-ratelimit-bucket: kratos-public-low
 func (h *Handler) deleteMySessions(w http.ResponseWriter, r *http.Request) {
-	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
-	if err != nil {
-		h.r.Logger().WithRequest(r).WithError(err).Info("No valid session cookie found.")
-		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized().WithWrap(err).WithReasonf("No valid session cookie found."))
+	s, ok := h.fetchSelfServiceSession(w, r, "revoke sessions")
+	if !ok {
 		return
 	}
 
@@ -763,6 +792,7 @@ type disableMySession struct {
 //	  204: emptyResponse
 //	  400: errorGeneric
 //	  401: errorGeneric
+//	  403: errorGeneric
 //	  default: errorGeneric
 //
 //	Extensions:
@@ -775,10 +805,8 @@ func (h *Handler) deleteMySession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
-	if err != nil {
-		h.r.Logger().WithRequest(r).WithError(err).Info("No valid session cookie found.")
-		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized().WithWrap(err).WithReasonf("No valid session cookie found."))
+	s, ok := h.fetchSelfServiceSession(w, r, "revoke session")
+	if !ok {
 		return
 	}
 
@@ -850,40 +878,73 @@ type listMySessionsResponse struct {
 //	  200: listMySessions
 //	  400: errorGeneric
 //	  401: errorGeneric
+//	  403: errorGeneric
 //	  default: errorGeneric
 //
 //	Extensions:
 //	  x-ory-ratelimit-bucket: kratos-public-medium
 func (h *Handler) listMySessions(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.fetchSatisfiedSession(w, r, "list sessions")
+	if !ok {
+		return
+	}
+
+	page, perPage := x.ParsePagination(r)
+	sess, total, err := h.r.SessionPersister().ListSessionsByIdentity(r.Context(), s.IdentityID, new(true), page, perPage, s.ID, ExpandEverything)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, *r.URL, total, page, perPage)
+	h.r.Writer().Write(w, r, sess)
+}
+
+// fetchSelfServiceSession returns the session of the request if it may
+// manage the identity's sessions and devices. Impersonated sessions are
+// rejected, the action names what the impersonator tried to do.
+func (h *Handler) fetchSelfServiceSession(w http.ResponseWriter, r *http.Request, action string) (*Session, bool) {
 	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
 	if err != nil {
 		h.r.Logger().WithRequest(r).WithError(err).Info("No valid session cookie found.")
 		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrUnauthorized().WithWrap(err).WithReasonf("No valid session cookie found.")))
-		return
+		return nil, false
 	}
 
-	c := h.r.Config()
+	if err := ForbidImpersonation(r.Context(), s, action); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return nil, false
+	}
+
+	return s, true
+}
+
+// fetchSatisfiedSession is like fetchSelfServiceSession, but additionally
+// requires the session to satisfy the AAL required for calling the whoami
+// endpoint and to have no pending requirements.
+func (h *Handler) fetchSatisfiedSession(w http.ResponseWriter, r *http.Request, action string) (*Session, bool) {
+	s, ok := h.fetchSelfServiceSession(w, r, action)
+	if !ok {
+		return nil, false
+	}
 
 	var aalErr *ErrAALNotSatisfied
-	if err := h.r.SessionManager().DoesSessionSatisfy(r.Context(), s, c.SessionWhoAmIAAL(r.Context())); errors.As(err, &aalErr) {
+	var requirementErr *ErrRequirementPending
+	if err := h.r.SessionManager().DoesSessionSatisfy(r.Context(), s, h.r.Config().SessionWhoAmIAAL(r.Context())); errors.As(err, &aalErr) {
 		h.r.Logger().WithRequest(r).WithError(err).Info("Session was found but AAL is not satisfied for calling this endpoint.")
 		h.r.Writer().WriteError(w, r, err)
-		return
+		return nil, false
+	} else if errors.As(err, &requirementErr) {
+		h.r.Logger().WithRequest(r).WithError(err).Info("Session was found but it has pending requirements.")
+		h.r.Writer().WriteError(w, r, err)
+		return nil, false
 	} else if err != nil {
 		h.r.Logger().WithRequest(r).WithError(err).Info("No valid session cookie found.")
 		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized().WithWrap(err).WithReasonf("Unable to determine AAL."))
-		return
-	}
-
-	page, perPage := x.ParsePagination(r)
-	sess, total, err := h.r.SessionPersister().ListSessionsByIdentity(r.Context(), s.IdentityID, new(true), page, perPage, s.ID, ExpandEverything)
-	if err != nil {
-		h.r.Writer().WriteError(w, r, err)
-		return
+		return nil, false
 	}
 
-	x.PaginationHeader(w, *r.URL, total, page, perPage)
-	h.r.Writer().Write(w, r, sess)
+	return s, true
 }
 
 type sessionInContext int
@@ -0,0 +1,173 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/jsonx"
+)
+
+const AdminRouteIdentitiesImpersonate = AdminRouteIdentity + "/{id}/impersonate"
+
+// Create Impersonation Session Parameters
+//
+// swagger:parameters createImpersonationSession
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createImpersonationSession struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	// required: true
+	Body createImpersonationSessionBody
+}
+
+// Create Impersonation Session Request Body
+//
+// swagger:model createImpersonationSessionBody
+type createImpersonationSessionBody struct {
+	// Actor identifies the administrator who impersonates the identity, for
+	// example their email address. It is recorded in the session's
+	// authentication methods.
+	//
+	// required: true
+	Actor string `json:"actor"`
+
+	// Reason is the reason for the impersonation, for example a support ticket.
+	Reason string `json:"reason"`
+
+	// Lifespan of the session. Defaults to and must not exceed the
+	// configuration value of `session.impersonation.max_lifespan`.
+	//
+	// pattern: ^([0-9]+(ns|us|ms|s|m|h))*$
+	// example:
+	//	- 15m
+	//	- 1h
+	Lifespan string `json:"lifespan"`
+}
+
+// Impersonation Session
+//
+// The session an administrator created to impersonate an identity.
+//
+// swagger:model impersonationSession
+type ImpersonationSession struct {
+	// The Session Token
+	//
+	// Send the token in the `X-Session-Token` header to act as the identity.
+	//
+	// required: true
+	Token string `json:"session_token"`
+
+	// The Session
+	//
+	// required: true
+	Session *Session `json:"session"`
+}
+
+// swagger:route POST /admin/identities/{id}/impersonate identity createImpersonationSession
+//
+// # Impersonate an Identity
+//
+// This endpoint issues a session for the identity which lets an administrator see what the identity sees.
+// The session is marked as impersonated, records the administrator in its authentication methods, expires
+// after the requested lifespan, and can not be extended. Impersonated sessions can not complete settings
+// flows and can therefore not change the identity's traits or credentials.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: impersonationSession
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) createImpersonationSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	iID, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	var body createImpersonationSessionBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	body.Actor = strings.TrimSpace(body.Actor)
+	if body.Actor == "" {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason(`The "actor" must identify the administrator who impersonates the identity.`)))
+		return
+	}
+
+	maxLifespan := h.r.Config().SessionImpersonationMaxLifespan(ctx)
+	lifespan := maxLifespan
+	if len(body.Lifespan) > 0 {
+		lifespan, err = time.ParseDuration(body.Lifespan)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().
+				WithReasonf(`Unable to parse "lifespan" whose format should match "[0-9]+(ns|us|ms|s|m|h)" but did not: %s`, body.Lifespan)))
+			return
+		}
+	}
+	if lifespan <= 0 || lifespan > maxLifespan {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().
+			WithReasonf(`The "lifespan" must be positive and must not exceed %s.`, maxLifespan)))
+		return
+	}
+
+	i, err := h.r.IdentityPool().GetIdentity(ctx, iID, identity.ExpandDefault)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s, err := NewImpersonatedSession(r, i, Impersonation{Actor: body.Actor, Reason: body.Reason}, lifespan)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionPersister().UpsertSession(ctx, s); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewImpersonationStarted(ctx, s.ID, s.IdentityID, body.Actor, body.Reason, s.ExpiresAt))
+	h.r.Logger().
+		WithField("identity_id", s.IdentityID).
+		WithField("session_id", s.ID).
+		WithField("impersonation_actor", body.Actor).
+		Info("An administrator started to impersonate an identity.")
+
+	h.r.Writer().WriteCode(w, r, http.StatusCreated, &ImpersonationSession{Token: s.Token, Session: s})
+}
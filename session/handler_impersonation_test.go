@@ -0,0 +1,161 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/ioutilx"
+)
+
+func TestHandlerImpersonation(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValue(config.ViperKeySessionImpersonationMaxLifespan, "1h"),
+	)
+	publicServer, adminServer, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	conf.MustSet(t.Context(), config.ViperKeySelfServiceBrowserDefaultReturnTo, publicServer.URL+"/default-return-to")
+
+	i := identity.NewIdentity("")
+	require.NoError(t, reg.IdentityManager().Create(t.Context(), i))
+
+	impersonate := func(t *testing.T, id string, body any) (*http.Response, []byte) {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		res, err := adminServer.Client().Post(adminServer.URL+"/admin/identities/"+id+"/impersonate", "application/json", bytes.NewReader(payload))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	withToken := func(t *testing.T, method, url, token string) (*http.Response, []byte) {
+		req, err := http.NewRequest(method, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-Token", token)
+		res, err := publicServer.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	t.Run("case=should issue an impersonated session", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), map[string]string{"actor": "admin@example.org", "reason": "ticket 42", "lifespan": "15m"})
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+
+		token := gjson.GetBytes(body, "session_token").String()
+		require.NotEmpty(t, token)
+		assert.Equal(t, i.ID.String(), gjson.GetBytes(body, "session.identity.id").String())
+		expiresAt := gjson.GetBytes(body, "session.expires_at").Time()
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+
+		res, body = withToken(t, "GET", publicServer.URL+"/sessions/whoami", token)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "admin@example.org", gjson.GetBytes(body, "impersonation.actor").String(), "%s", body)
+		assert.Equal(t, "ticket 42", gjson.GetBytes(body, "impersonation.reason").String(), "%s", body)
+		assert.Equal(t, string(identity.CredentialsTypeImpersonation), gjson.GetBytes(body, "authentication_methods.0.method").String(), "%s", body)
+		assert.Equal(t, "admin@example.org", gjson.GetBytes(body, "authentication_methods.0.actor").String(), "%s", body)
+
+		t.Run("case=settings are forbidden", func(t *testing.T) {
+			res, body := withToken(t, "GET", publicServer.URL+"/self-service/settings/api", token)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+			assert.Equal(t, text.ErrIDSessionImpersonated, gjson.GetBytes(body, "error.id").String(), "%s", body)
+		})
+
+		for _, tc := range []struct{ method, path string }{
+			{"GET", "/sessions"},
+			{"DELETE", "/sessions"},
+			{"DELETE", "/sessions/" + uuid.Must(uuid.NewV4()).String()},
+			{"GET", "/sessions/trusted-devices"},
+			{"DELETE", "/sessions/trusted-devices/" + uuid.Must(uuid.NewV4()).String()},
+		} {
+			t.Run("case=forbids "+tc.method+" "+tc.path, func(t *testing.T) {
+				res, body := withToken(t, tc.method, publicServer.URL+tc.path, token)
+				assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+				assert.Equal(t, text.ErrIDSessionImpersonated, gjson.GetBytes(body, "error.id").String(), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=should not satisfy the highest available AAL", func(t *testing.T) {
+		withTOTP := identity.NewIdentity("")
+		withTOTP.SetCredentials(identity.CredentialsTypeTOTP, identity.Credentials{
+			Type:   identity.CredentialsTypeTOTP,
+			Config: []byte(`{"totp_url": "otpauth://totp/..."}`),
+		})
+		require.NoError(t, reg.IdentityManager().Create(t.Context(), withTOTP))
+
+		res, body := impersonate(t, withTOTP.ID.String(), map[string]string{"actor": "admin@example.org"})
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		token := gjson.GetBytes(body, "session_token").String()
+
+		prev := conf.SessionWhoAmIAAL(t.Context())
+		conf.MustSet(t.Context(), config.ViperKeySessionWhoAmIAAL, config.HighestAvailableAAL)
+		t.Cleanup(func() { conf.MustSet(context.Background(), config.ViperKeySessionWhoAmIAAL, prev) })
+
+		res, body = withToken(t, "GET", publicServer.URL+"/sessions/whoami", token)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+		assert.Equal(t, text.ErrIDHigherAALRequired, gjson.GetBytes(body, "error.id").String(), "%s", body)
+	})
+
+	t.Run("case=should default to the maximum lifespan", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), map[string]string{"actor": "admin@example.org"})
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), gjson.GetBytes(body, "session.expires_at").Time(), time.Minute)
+	})
+
+	t.Run("case=should return 400 without an actor", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), map[string]string{"actor": " "})
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 400 if the lifespan exceeds the maximum", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), map[string]string{"actor": "admin@example.org", "lifespan": "2h"})
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 400 for an invalid lifespan", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), map[string]string{"actor": "admin@example.org", "lifespan": "soon"})
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 400 for an inactive identity", func(t *testing.T) {
+		inactive := identity.NewIdentity("")
+		inactive.State = identity.StateInactive
+		require.NoError(t, reg.IdentityManager().Create(t.Context(), inactive))
+
+		res, body := impersonate(t, inactive.ID.String(), map[string]string{"actor": "admin@example.org"})
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		assert.Equal(t, text.ErrIDIdentityDisabled, gjson.GetBytes(body, "error.id").String(), "%s", body)
+	})
+
+	t.Run("case=should return 404 for an unknown identity", func(t *testing.T) {
+		res, body := impersonate(t, uuid.Must(uuid.NewV4()).String(), map[string]string{"actor": "admin@example.org"})
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 404 on the public server", func(t *testing.T) {
+		res, err := publicServer.Client().Post(publicServer.URL+"/admin/identities/"+i.ID.String()+"/impersonate", "application/json", bytes.NewBufferString(`{"actor":"admin@example.org"}`))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/ory/kratos/corpx"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
 	"github.com/ory/kratos/pkg"
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/selfservice/flow"
@@ -350,6 +351,149 @@ func TestSessionWhoAmI(t *testing.T) {
 	*/
 }
 
+func TestSessionWhoAmIProfileIncomplete(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/profile.schema.json")))
+	ts, _ := testhelpers.NewKratosServer(t, reg)
+	ctx := context.Background()
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"foo@ory.sh"}`)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := &http.Request{URL: urlx.ParseOrPanic("/")}
+	s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	s.SetRequirement(RequirementProfileIncomplete, true)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	whoami := func(t *testing.T) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", ts.URL+RouteWhoami, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-Token", s.Token)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	res, body := whoami(t)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+	assert.Equal(t, "session_profile_incomplete", gjson.GetBytes(body, "error.id").String(), "%s", body)
+	assert.Equal(t, ts.URL+"/self-service/settings/browser", gjson.GetBytes(body, "redirect_browser_to").String(), "%s", body)
+
+	i.Traits = identity.Traits(`{"email":"foo@ory.sh","name":"Foo"}`)
+	require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, i))
+
+	res, body = whoami(t)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+	assert.False(t, gjson.GetBytes(body, "pending_requirements").Exists(), "%s", body)
+
+	actual, err := reg.SessionPersister().GetSession(ctx, s.ID, ExpandNothing)
+	require.NoError(t, err)
+	assert.Empty(t, actual.PendingRequirements)
+}
+
+func TestSessionWhoAmILegalDocumentsPending(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")))
+	ts, _ := testhelpers.NewKratosServer(t, reg)
+	ctx := context.Background()
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".legal.enabled", true)
+	conf.MustSet(ctx, config.ViperKeyLegalDocuments, []map[string]any{
+		{"id": "terms_of_service", "version": "v2", "title": "Terms of Service", "url": "https://www.ory.sh/tos"},
+	})
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := &http.Request{URL: urlx.ParseOrPanic("/")}
+	s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	s.SetRequirement(RequirementLegalDocumentsPending, true)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	whoami := func(t *testing.T) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", ts.URL+RouteWhoami, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-Token", s.Token)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	res, body := whoami(t)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+	assert.Equal(t, "session_legal_documents_pending", gjson.GetBytes(body, "error.id").String(), "%s", body)
+	assert.Equal(t, ts.URL+"/self-service/settings/browser", gjson.GetBytes(body, "redirect_browser_to").String(), "%s", body)
+
+	require.NoError(t, reg.LegalPersister().CreateLegalAcceptances(ctx, legal.NewAcceptances(
+		httptest.NewRequest("POST", "/", nil), i.ID, map[string]string{"terms_of_service": "v2"})))
+
+	res, body = whoami(t)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+	assert.False(t, gjson.GetBytes(body, "pending_requirements").Exists(), "%s", body)
+
+	actual, err := reg.SessionPersister().GetSession(ctx, s.ID, ExpandNothing)
+	require.NoError(t, err)
+	assert.Empty(t, actual.PendingRequirements)
+}
+
+func TestSessionWhoAmIPasswordExpired(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")))
+	ts, _ := testhelpers.NewKratosServer(t, reg)
+	ctx := context.Background()
+	conf.MustSet(ctx, config.ViperKeyPasswordPolicyRules+".max_age", "720h")
+
+	changedAt := time.Now().Add(-1000 * time.Hour)
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"email":"expired@ory.sh"}`)
+	require.NoError(t, i.SetCredentialsWithConfig(identity.CredentialsTypePassword,
+		identity.Credentials{Identifiers: []string{"expired@ory.sh"}},
+		identity.CredentialsPassword{HashedPassword: "$2a$04$zvZz1zV", ChangedAt: &changedAt}))
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := &http.Request{URL: urlx.ParseOrPanic("/")}
+	s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	s.SetRequirement(RequirementPasswordExpired, true)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	whoami := func(t *testing.T) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", ts.URL+RouteWhoami, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-Token", s.Token)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	res, body := whoami(t)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+	assert.Equal(t, "session_password_expired", gjson.GetBytes(body, "error.id").String(), "%s", body)
+	assert.Equal(t, ts.URL+"/self-service/settings/browser", gjson.GetBytes(body, "redirect_browser_to").String(), "%s", body)
+
+	changedAt = time.Now()
+	require.NoError(t, i.SetCredentialsWithConfig(identity.CredentialsTypePassword,
+		identity.Credentials{Identifiers: []string{"expired@ory.sh"}},
+		identity.CredentialsPassword{HashedPassword: "$2a$04$zvZz1zV", ChangedAt: &changedAt}))
+	require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, i))
+
+	res, body = whoami(t)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+	assert.False(t, gjson.GetBytes(body, "pending_requirements").Exists(), "%s", body)
+
+	actual, err := reg.SessionPersister().GetSession(ctx, s.ID, ExpandNothing)
+	require.NoError(t, err)
+	assert.Empty(t, actual.PendingRequirements)
+}
+
 func TestIsNotAuthenticatedSecurecookie(t *testing.T) {
 	t.Parallel()
 
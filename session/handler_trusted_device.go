@@ -0,0 +1,301 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/herodot"
+)
+
+const (
+	RouteTrustedDevices                = RouteCollection + "/trusted-devices"
+	RouteTrustedDevice                 = RouteTrustedDevices + "/{id}"
+	AdminRouteIdentitiesTrustedDevices = AdminRouteIdentity + "/{id}/trusted-devices"
+	AdminRouteIdentitiesTrustedDevice  = AdminRouteIdentitiesTrustedDevices + "/{device_id}"
+)
+
+// List My Trusted Devices Parameters
+//
+// swagger:parameters listMyTrustedDevices
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listMyTrustedDevicesParameters struct {
+	// Set the Session Token when calling from non-browser clients. A session token has a format of `MP2YWEMeM8MxjkGKpH4dqOQ4Q4DlSPaj`.
+	//
+	// in: header
+	SessionToken string `json:"X-Session-Token"`
+
+	// Set the Cookie Header. This is especially useful when calling this endpoint from a server-side application. In that
+	// scenario you must include the HTTP Cookie Header which originally was included in the request to your server.
+	//
+	// in: header
+	Cookie string `json:"Cookie"`
+}
+
+// List Trusted Devices Response
+//
+// swagger:response listTrustedDevices
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listTrustedDevicesResponse struct {
+	// in: body
+	Body []TrustedDevice
+}
+
+// swagger:route GET /sessions/trusted-devices frontend listMyTrustedDevices
+//
+// # Get My Trusted Devices
+//
+// This endpoint returns the browsers in which the logged-in user chose to skip the second factor
+// on later sign-ins.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: listTrustedDevices
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-medium
+func (h *Handler) listMyTrustedDevices(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.fetchSatisfiedSession(w, r, "list trusted devices")
+	if !ok {
+		return
+	}
+
+	devices, err := h.r.TrustedDevicePersister().ListTrustedDevices(r.Context(), s.IdentityID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, devices)
+}
+
+// Revoke My Trusted Device Parameters
+//
+// swagger:parameters revokeMyTrustedDevice
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeMyTrustedDevice struct {
+	// ID is the trusted device's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Set the Session Token when calling from non-browser clients. A session token has a format of `MP2YWEMeM8MxjkGKpH4dqOQ4Q4DlSPaj`.
+	//
+	// in: header
+	SessionToken string `json:"X-Session-Token"`
+
+	// Set the Cookie Header. This is especially useful when calling this endpoint from a server-side application. In that
+	// scenario you must include the HTTP Cookie Header which originally was included in the request to your server.
+	//
+	// in: header
+	Cookie string `json:"Cookie"`
+}
+
+// swagger:route DELETE /sessions/trusted-devices/{id} frontend revokeMyTrustedDevice
+//
+// # Revoke One of My Trusted Devices
+//
+// Calling this endpoint revokes the trust in the specified device. The next sign-in from that
+// browser asks for the second factor again.
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  401: errorGeneric
+//	  403: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) revokeMyTrustedDevice(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.fetchSatisfiedSession(w, r, "revoke trusted device")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	if err := h.r.TrustedDevicePersister().DeleteTrustedDevice(r.Context(), s.IdentityID, id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().WriteCode(w, r, http.StatusNoContent, nil)
+}
+
+// List Identity Trusted Devices Parameters
+//
+// swagger:parameters listIdentityTrustedDevices
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityTrustedDevices struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/identities/{id}/trusted-devices identity listIdentityTrustedDevices
+//
+// # List an Identity's Trusted Devices
+//
+// This endpoint returns the browsers in which the identity chose to skip the second factor on later sign-ins.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listTrustedDevices
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) listIdentityTrustedDevices(w http.ResponseWriter, r *http.Request) {
+	iID, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	devices, err := h.r.TrustedDevicePersister().ListTrustedDevices(r.Context(), iID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, devices)
+}
+
+// Delete Identity Trusted Devices Parameters
+//
+// swagger:parameters deleteIdentityTrustedDevices
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteIdentityTrustedDevices struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/trusted-devices identity deleteIdentityTrustedDevices
+//
+// # Revoke All Trusted Devices of an Identity
+//
+// Calling this endpoint revokes the trust in all devices of the given identity. The next sign-in
+// from any browser asks for the second factor again.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) deleteIdentityTrustedDevices(w http.ResponseWriter, r *http.Request) {
+	iID, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	if err := h.r.TrustedDevicePersister().DeleteTrustedDevicesByIdentity(r.Context(), iID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete Identity Trusted Device Parameters
+//
+// swagger:parameters deleteIdentityTrustedDevice
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteIdentityTrustedDevice struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// DeviceID is the trusted device's ID.
+	//
+	// required: true
+	// in: path
+	DeviceID string `json:"device_id"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/trusted-devices/{device_id} identity deleteIdentityTrustedDevice
+//
+// # Revoke a Trusted Device of an Identity
+//
+// Calling this endpoint revokes the trust in one device of the given identity.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) deleteIdentityTrustedDevice(w http.ResponseWriter, r *http.Request) {
+	iID, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	id, err := uuid.FromString(r.PathValue("device_id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	if err := h.r.TrustedDevicePersister().DeleteTrustedDevice(r.Context(), iID, id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
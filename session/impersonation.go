@@ -0,0 +1,106 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x/events"
+)
+
+func ErrSessionImpersonated() *herodot.DefaultError {
+	return herodot.ErrForbidden().WithID(text.ErrIDSessionImpersonated).WithError("session is impersonated").WithReason("This action is not available while an administrator impersonates the account.")
+}
+
+// Impersonation
+//
+// Describes who impersonates the identity of a session.
+//
+// swagger:model sessionImpersonation
+type Impersonation struct {
+	// Actor identifies the administrator who impersonates the identity.
+	//
+	// required: true
+	Actor string `json:"actor"`
+
+	// Reason is the reason the administrator gave for the impersonation.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Scan implements the Scanner interface.
+func (i *Impersonation) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), i))
+}
+
+// Value implements the driver Valuer interface.
+func (i Impersonation) Value() (driver.Value, error) {
+	value, err := json.Marshal(i)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
+
+// IsImpersonated returns true if an administrator issued the session to
+// impersonate the identity.
+func (s *Session) IsImpersonated() bool {
+	return s != nil && s.Impersonation != nil
+}
+
+// ForbidImpersonation returns ErrSessionImpersonated if the session is
+// impersonated. The action names what the impersonator tried to do.
+func ForbidImpersonation(ctx context.Context, s *Session, action string) error {
+	if !s.IsImpersonated() {
+		return nil
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(events.NewImpersonationBlocked(ctx, s.ID, s.IdentityID, s.Impersonation.Actor, action))
+	return errors.WithStack(ErrSessionImpersonated())
+}
+
+// NewImpersonatedSession returns an active session for the identity which
+// expires after the lifespan. The authentication methods record the actor
+// instead of a credential of the identity.
+func NewImpersonatedSession(r *http.Request, i *identity.Identity, impersonation Impersonation, lifespan time.Duration) (*Session, error) {
+	if !i.IsActive() {
+		return nil, errors.WithStack(errIdentityNotActive(i))
+	}
+
+	now := time.Now().UTC()
+	s := NewInactiveSession()
+	s.Identity = i
+	s.IdentityID = i.ID
+	s.Active = true
+	s.IssuedAt = now
+	s.AuthenticatedAt = now
+	s.ExpiresAt = now.Add(lifespan)
+	s.Impersonation = &impersonation
+	s.AMR = AuthenticationMethods{{
+		Method:      identity.CredentialsTypeImpersonation,
+		AAL:         identity.AuthenticatorAssuranceLevel1,
+		CompletedAt: now,
+		Actor:       impersonation.Actor,
+	}}
+	s.SetSessionDeviceInformation(r)
+	s.SetAuthenticatorAssuranceLevel()
+	return s, nil
+}
@@ -149,6 +149,9 @@ type Manager interface {
 	// This method is implemented in such a way, that if a second factor is found for the user, it is always assumed
 	// that the user is able to authenticate with it. This means that if a user has a second factor, the user is always
 	// asked to authenticate with it if `highest_available` is set and the session's AAL is `aal1`.
+	//
+	// Once the AAL is satisfied, ErrRequirementPending is returned if the session has pending requirements
+	// which were not yet taken care of, unless AllowPendingRequirements is passed.
 	DoesSessionSatisfy(ctx context.Context, sess *Session, matcher string, opts ...ManagerOptions) error
 
 	// SessionAddAuthenticationMethods adds one or more authentication method to the session.
@@ -165,6 +168,14 @@ type Manager interface {
 	// the session in the database or on the client device.
 	ActivateSession(r *http.Request, session *Session, i *identity.Identity, authenticatedAt time.Time) error
 
+	// TrustDevice trusts the browser for the session's identity, so that signing in from it later satisfies the
+	// second factor.
+	TrustDevice(ctx context.Context, w http.ResponseWriter, r *http.Request, session *Session) error
+
+	// MaybeCompleteWithTrustedDevice completes the second factor of the session if the request comes from a
+	// trusted device of the session's identity.
+	MaybeCompleteWithTrustedDevice(ctx context.Context, r *http.Request, session *Session) error
+
 	// IsPrivileged checks if a session can be considered privileged.
 	// https://ory.com/docs/kratos/session-management/session-lifespan#privileged-sessions
 	IsPrivileged(ctx context.Context, session *Session) bool
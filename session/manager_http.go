@@ -41,6 +41,7 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
 	"github.com/ory/kratos/x"
 )
 
@@ -54,12 +55,15 @@ type (
 		identity.PoolProvider
 		identity.PrivilegedPoolProvider
 		identity.ManagementProvider
+		identity.ValidationProvider
+		legal.PersistenceProvider
 		x.CookieProvider
 		logrusx.Provider
 		nosurfx.CSRFProvider
 		otelx.Provider
 		x.TransactionPersistenceProvider
 		PersistenceProvider
+		TrustedDevicePersistenceProvider
 		sessiontokenexchange.PersistenceProvider
 	}
 	ManagerHTTP struct {
@@ -78,8 +82,9 @@ func NewManagerHTTP(r managerHTTPDependencies) *ManagerHTTP {
 }
 
 type options struct {
-	requestURL string
-	upsertAAL  bool
+	requestURL               string
+	upsertAAL                bool
+	allowPendingRequirements bool
 }
 
 type ManagerOptions func(*options)
@@ -97,6 +102,12 @@ func UpsertAAL(opts *options) {
 	opts.upsertAAL = true
 }
 
+// AllowPendingRequirements accepts sessions with pending requirements. This is used by the flows in which
+// the requirements are taken care of.
+func AllowPendingRequirements(opts *options) {
+	opts.allowPendingRequirements = true
+}
+
 func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, ss *Session) (err error) {
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.UpsertAndIssueCookie")
 	defer otelx.End(span, &err)
@@ -147,19 +158,7 @@ func (s *ManagerHTTP) IssueCookie(ctx context.Context, w http.ResponseWriter, r
 		return errors.WithStack(err)
 	}
 
-	if s.r.Config().SessionPath(ctx) != "" {
-		cookie.Options.Path = s.r.Config().SessionPath(ctx)
-	}
-
-	if domain := s.r.Config().SessionDomain(ctx); domain != "" {
-		cookie.Options.Domain = domain
-	}
-
-	if alias := s.r.Config().SelfPublicURL(ctx); s.r.Config().SelfPublicURL(ctx).String() != alias.String() {
-		// If a domain alias is detected use that instead.
-		cookie.Options.Domain = alias.Hostname()
-		cookie.Options.Path = alias.Path
-	}
+	s.setCookieOptions(ctx, cookie)
 
 	old, err := s.FetchFromRequest(ctx, r)
 	if err != nil {
@@ -170,10 +169,6 @@ func (s *ManagerHTTP) IssueCookie(ctx context.Context, w http.ResponseWriter, r
 		_ = s.r.CSRFHandler().RegenerateToken(w, r)
 	}
 
-	if s.r.Config().SessionSameSiteMode(ctx) != 0 {
-		cookie.Options.SameSite = s.r.Config().SessionSameSiteMode(ctx)
-	}
-
 	cookie.Options.MaxAge = 0
 	if s.r.Config().SessionPersistentCookie(ctx) {
 		if session.ExpiresAt.IsZero() {
@@ -193,6 +188,28 @@ func (s *ManagerHTTP) IssueCookie(ctx context.Context, w http.ResponseWriter, r
 	return nil
 }
 
+// setCookieOptions applies the configured session cookie path, domain, and
+// same site mode to the cookie.
+func (s *ManagerHTTP) setCookieOptions(ctx context.Context, cookie *sessions.Session) {
+	if s.r.Config().SessionPath(ctx) != "" {
+		cookie.Options.Path = s.r.Config().SessionPath(ctx)
+	}
+
+	if domain := s.r.Config().SessionDomain(ctx); domain != "" {
+		cookie.Options.Domain = domain
+	}
+
+	if alias := s.r.Config().SelfPublicURL(ctx); s.r.Config().SelfPublicURL(ctx).String() != alias.String() {
+		// If a domain alias is detected use that instead.
+		cookie.Options.Domain = alias.Hostname()
+		cookie.Options.Path = alias.Path
+	}
+
+	if s.r.Config().SessionSameSiteMode(ctx) != 0 {
+		cookie.Options.SameSite = s.r.Config().SessionSameSiteMode(ctx)
+	}
+}
+
 func getCookieExpiry(s *sessions.Session) *time.Time {
 	expiresAt, ok := s.Values["expires_at"].(string)
 	if !ok {
@@ -307,6 +324,22 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.DoesSessionSatisfy")
 	defer otelx.End(span, &err)
 
+	managerOpts := &options{}
+	for _, o := range opts {
+		o(managerOpts)
+	}
+
+	if err := s.doesSessionSatisfyAAL(ctx, sess, requestedAAL, managerOpts); err != nil {
+		return err
+	}
+
+	if managerOpts.allowPendingRequirements {
+		return nil
+	}
+	return s.checkPendingRequirements(ctx, sess)
+}
+
+func (s *ManagerHTTP) doesSessionSatisfyAAL(ctx context.Context, sess *Session, requestedAAL string, managerOpts *options) (err error) {
 	sess.SetAuthenticatorAssuranceLevel()
 
 	// If we already have AAL2 there is no need to check further because it is the highest AAL.
@@ -314,9 +347,10 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 		return nil
 	}
 
-	managerOpts := &options{}
-	for _, o := range opts {
-		o(managerOpts)
+	// A risky sign-in must be completed with the second factor if the identity
+	// has one.
+	if sess.Risk.RequiresStepUp() && requestedAAL == string(identity.AuthenticatorAssuranceLevel1) {
+		requestedAAL = config.HighestAvailableAAL
 	}
 
 	loginURL := urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/login/browser")
@@ -466,7 +500,7 @@ func (s *ManagerHTTP) ActivateSession(r *http.Request, session *Session, i *iden
 	}
 
 	if !i.IsActive() {
-		return errors.WithStack(ErrIdentityDisabled().WithDetail("identity_id", i.ID))
+		return errors.WithStack(errIdentityNotActive(i))
 	}
 
 	if err := s.r.IdentityManager().RefreshAvailableAAL(ctx, i); err != nil {
@@ -488,6 +522,12 @@ func (s *ManagerHTTP) ActivateSession(r *http.Request, session *Session, i *iden
 		attribute.String("identity.available_aal", session.Identity.InternalAvailableAAL.String),
 	)
 
+	// Recording the authentication time is best effort and must not prevent
+	// the identity from signing in.
+	if err := s.r.PrivilegedIdentityPool().UpdateIdentityLastAuthenticatedAt(ctx, i.ID, authenticatedAt); err != nil {
+		s.r.Logger().WithError(err).WithField("identity_id", i.ID).Warn("Unable to record when the identity last authenticated.")
+	}
+
 	return nil
 }
 
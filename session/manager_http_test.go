@@ -18,6 +18,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
 	"github.com/ory/kratos/pkg"
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/session"
@@ -525,6 +526,102 @@ func TestManagerHTTP(t *testing.T) {
 	})
 }
 
+func TestManagerHTTPTrustedDevice(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/fake-session.schema.json")),
+		configx.WithValue(config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", true),
+	)
+
+	newSession := func(t *testing.T, i *identity.Identity) *session.Session {
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		return s
+	}
+
+	trust := func(t *testing.T, s *session.Session) *http.Cookie {
+		rec := httptest.NewRecorder()
+		req := testhelpers.NewTestHTTPRequest(t, "POST", "/self-service/login", nil)
+		require.NoError(t, reg.SessionManager().TrustDevice(t.Context(), rec, req, s))
+		require.Len(t, rec.Result().Cookies(), 1)
+		cookie := rec.Result().Cookies()[0]
+		assert.Equal(t, session.TrustedDeviceCookieName, cookie.Name)
+		return cookie
+	}
+
+	complete := func(t *testing.T, s *session.Session, cookies ...*http.Cookie) {
+		req := testhelpers.NewTestHTTPRequest(t, "POST", "/self-service/login", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		require.NoError(t, reg.SessionManager().MaybeCompleteWithTrustedDevice(t.Context(), req, s))
+	}
+
+	i := newAAL2Identity()
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+	i.InternalAvailableAAL = identity.NewNullableAuthenticatorAssuranceLevel(identity.AuthenticatorAssuranceLevel2)
+	cookie := trust(t, newSession(t, i))
+
+	t.Run("case=completes the second factor", func(t *testing.T) {
+		s := newSession(t, i)
+		complete(t, s, cookie)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, s.AuthenticatorAssuranceLevel)
+		assert.EqualValues(t, identity.CredentialsTypeTrustedDevice, s.AMR[len(s.AMR)-1].Method)
+	})
+
+	t.Run("case=ignores requests without the cookie", func(t *testing.T) {
+		s := newSession(t, i)
+		complete(t, s)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+
+	t.Run("case=ignores tampered cookies", func(t *testing.T) {
+		s := newSession(t, i)
+		complete(t, s, &http.Cookie{Name: cookie.Name, Value: cookie.Value + "x"})
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+
+	t.Run("case=ignores cookies of other identities", func(t *testing.T) {
+		other := newAAL2Identity()
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), other))
+		other.InternalAvailableAAL = identity.NewNullableAuthenticatorAssuranceLevel(identity.AuthenticatorAssuranceLevel2)
+
+		s := newSession(t, other)
+		complete(t, s, cookie)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+
+	t.Run("case=ignores the cookie when disabled", func(t *testing.T) {
+		ctx := contextx.WithConfigValue(t.Context(), config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", false)
+		s := newSession(t, i)
+		req := testhelpers.NewTestHTTPRequest(t, "POST", "/self-service/login", nil)
+		req.AddCookie(cookie)
+		require.NoError(t, reg.SessionManager().MaybeCompleteWithTrustedDevice(ctx, req, s))
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+
+	t.Run("case=ignores identities without a second factor", func(t *testing.T) {
+		aal1 := newAAL1Identity()
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), aal1))
+		aal1.InternalAvailableAAL = identity.NewNullableAuthenticatorAssuranceLevel(identity.AuthenticatorAssuranceLevel1)
+
+		s := newSession(t, aal1)
+		aal1Cookie := trust(t, s)
+		complete(t, s, aal1Cookie)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+
+	t.Run("case=ignores revoked devices", func(t *testing.T) {
+		require.NoError(t, reg.TrustedDevicePersister().DeleteTrustedDevicesByIdentity(t.Context(), i.ID))
+
+		s := newSession(t, i)
+		complete(t, s, cookie)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+	})
+}
+
 func TestDoesSessionSatisfy(t *testing.T) {
 	t.Parallel()
 
@@ -656,6 +753,7 @@ func TestDoesSessionSatisfy(t *testing.T) {
 		matcher               identity.AuthenticatorAssuranceLevel
 		creds                 []identity.Credentials
 		withAMR               session.AuthenticationMethods
+		withRisk              *session.RiskAssessment
 		sessionManagerOptions []session.ManagerOptions
 		expectedFunc          func(t *testing.T, err error, tcError error)
 	}{
@@ -908,6 +1006,30 @@ func TestDoesSessionSatisfy(t *testing.T) {
 			withAMR: session.AuthenticationMethods{amrs[identity.CredentialsTypePassword], {Method: identity.CredentialsTypeWebAuthn, AAL: identity.AuthenticatorAssuranceLevel2}},
 		},
 
+		// risk
+		{
+			desc:     "has=aal1, requested=aal1, available=aal2, credential=password+totp, risk=step_up",
+			matcher:  identity.AuthenticatorAssuranceLevel1,
+			creds:    []identity.Credentials{password(), totp()},
+			withAMR:  session.AuthenticationMethods{amrs[identity.CredentialsTypePassword]},
+			withRisk: &session.RiskAssessment{Score: 60, Action: session.RiskActionStepUp},
+			errAs:    new(session.ErrAALNotSatisfied),
+		},
+		{
+			desc:     "has=aal1, requested=aal1, available=aal2, credential=password+totp, risk=notify",
+			matcher:  identity.AuthenticatorAssuranceLevel1,
+			creds:    []identity.Credentials{password(), totp()},
+			withAMR:  session.AuthenticationMethods{amrs[identity.CredentialsTypePassword]},
+			withRisk: &session.RiskAssessment{Score: 30, Action: session.RiskActionNotify},
+		},
+		{
+			desc:     "has=aal1, requested=aal1, available=aal1, credential=password, risk=step_up",
+			matcher:  identity.AuthenticatorAssuranceLevel1,
+			creds:    []identity.Credentials{password()},
+			withAMR:  session.AuthenticationMethods{amrs[identity.CredentialsTypePassword]},
+			withRisk: &session.RiskAssessment{Score: 60, Action: session.RiskActionStepUp},
+		},
+
 		// oidc
 		{
 			desc:    "has=aal1, requested=highest, available=aal1, credential=oidc_and_empties",
@@ -965,6 +1087,7 @@ func TestDoesSessionSatisfy(t *testing.T) {
 				s.CompletedLoginFor(m.Method, m.AAL)
 			}
 			require.NoError(t, reg.SessionManager().ActivateSession(req, s, id, time.Now().UTC()))
+			s.Risk = tc.withRisk
 
 			err := reg.SessionManager().DoesSessionSatisfy(ctx, s, string(tc.matcher), tc.sessionManagerOptions...)
 			if tc.errAs != nil || tc.errIs != nil {
@@ -1019,3 +1142,41 @@ func TestDoesSessionSatisfy(t *testing.T) {
 		})
 	}
 }
+
+func TestDoesSessionSatisfyPendingRequirements(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+	)
+	ctx := context.Background()
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".legal.enabled", true)
+	conf.MustSet(ctx, config.ViperKeyLegalDocuments, []map[string]any{
+		{"id": "terms_of_service", "version": "v2", "title": "Terms of Service", "url": "https://www.ory.sh/tos"},
+	})
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+	s, err := testhelpers.NewActiveSession(req, reg, i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+	s.SetRequirement(session.RequirementLegalDocumentsPending, true)
+	require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+
+	var requirementErr *session.ErrRequirementPending
+	err = reg.SessionManager().DoesSessionSatisfy(ctx, s, string(identity.AuthenticatorAssuranceLevel1))
+	require.ErrorAs(t, err, &requirementErr)
+	assert.Equal(t, "session_legal_documents_pending", requirementErr.ID())
+
+	require.NoError(t, reg.SessionManager().DoesSessionSatisfy(ctx, s, string(identity.AuthenticatorAssuranceLevel1), session.AllowPendingRequirements))
+
+	require.NoError(t, reg.LegalPersister().CreateLegalAcceptances(ctx, legal.NewAcceptances(
+		httptest.NewRequest("POST", "/", nil), i.ID, map[string]string{"terms_of_service": "v2"})))
+	require.NoError(t, reg.SessionManager().DoesSessionSatisfy(ctx, s, string(identity.AuthenticatorAssuranceLevel1)))
+	assert.Empty(t, s.PendingRequirements)
+
+	actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+	require.NoError(t, err)
+	assert.Empty(t, actual.PendingRequirements)
+}
@@ -70,6 +70,15 @@ type Persister interface {
 
 	// RevokeSessionsIdentityExcept marks all except the given session of an identity inactive. It returns the number of sessions that were revoked.
 	RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error)
+
+	// UseRevokeSessionsToken records the use of the revoke sessions token with
+	// the given hash. It returns sqlcon.ErrUniqueViolation if the token was
+	// used before.
+	UseRevokeSessionsToken(ctx context.Context, tokenHash string, expiresAt time.Time) error
+
+	// DeleteExpiredRevokeSessionsTokens deletes the recorded uses of revoke
+	// sessions tokens that expired before the given time.
+	DeleteExpiredRevokeSessionsTokens(context.Context, time.Time, int) error
 }
 
 type DevicePersister interface {
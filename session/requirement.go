@@ -0,0 +1,192 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/legal"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/urlx"
+)
+
+// Requirement is something the identity has to take care of using the
+// settings flow before its session can be used.
+//
+// swagger:enum Requirement
+type Requirement string
+
+const (
+	// RequirementProfileIncomplete is pending while the identity is missing
+	// traits which its identity schema requires.
+	RequirementProfileIncomplete Requirement = "profile_incomplete"
+
+	// RequirementLegalDocumentsPending is pending while the identity has not
+	// accepted the current version of all legal documents.
+	RequirementLegalDocumentsPending Requirement = "legal_documents_pending"
+
+	// RequirementPasswordExpired is pending while the password of the identity
+	// is older than the maximum password age of the password policy.
+	RequirementPasswordExpired Requirement = "password_expired"
+)
+
+// Requirements is the set of requirements which are pending for a session.
+type Requirements []Requirement
+
+// Has returns true if the requirement is pending.
+func (r Requirements) Has(requirement Requirement) bool {
+	return slices.Contains(r, requirement)
+}
+
+// Scan implements the Scanner interface.
+func (r *Requirements) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), r))
+}
+
+// Value implements the driver Valuer interface.
+func (r Requirements) Value() (driver.Value, error) {
+	if r == nil {
+		r = Requirements{}
+	}
+	value, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
+
+// SetRequirement marks the requirement as pending or resolved.
+func (s *Session) SetRequirement(requirement Requirement, pending bool) {
+	if pending == s.PendingRequirements.Has(requirement) {
+		return
+	}
+	if pending {
+		s.PendingRequirements = append(s.PendingRequirements, requirement)
+		return
+	}
+	s.PendingRequirements = slices.DeleteFunc(slices.Clone(s.PendingRequirements), func(r Requirement) bool {
+		return r == requirement
+	})
+}
+
+// ErrRequirementPending is returned when an active session was found but the
+// identity has to take care of a pending requirement using the settings flow.
+type ErrRequirementPending struct {
+	*herodot.DefaultError `json:"error"`
+	RedirectTo            string `json:"redirect_browser_to"`
+}
+
+func (e *ErrRequirementPending) EnhanceJSONError() interface{} {
+	return e
+}
+
+// NewErrRequirementPending creates a new ErrRequirementPending.
+func NewErrRequirementPending(requirement Requirement, redirectTo string) *ErrRequirementPending {
+	e := &herodot.DefaultError{
+		StatusField: http.StatusText(http.StatusForbidden),
+		CodeField:   http.StatusForbidden,
+		DetailsField: map[string]interface{}{
+			"redirect_browser_to": redirectTo,
+			"requirement":         requirement,
+		},
+	}
+
+	switch requirement {
+	case RequirementProfileIncomplete:
+		e.IDField = text.ErrIDSessionProfileIncomplete
+		e.ErrorField = "Session belongs to an incomplete profile"
+		e.ReasonField = "An active session was found but the identity is missing required traits. Please complete your profile using the settings flow to resolve this issue."
+	case RequirementLegalDocumentsPending:
+		e.IDField = text.ErrIDSessionLegalDocumentsPending
+		e.ErrorField = "Session requires accepting the legal documents"
+		e.ReasonField = "An active session was found but the identity has not accepted the current version of all legal documents. Please accept them using the settings flow to resolve this issue."
+	case RequirementPasswordExpired:
+		e.IDField = text.ErrIDSessionPasswordExpired
+		e.ErrorField = "Session requires changing the expired password"
+		e.ReasonField = "An active session was found but the password of the identity expired. Please choose a new password using the settings flow to resolve this issue."
+	}
+
+	return &ErrRequirementPending{RedirectTo: redirectTo, DefaultError: e}
+}
+
+// checkPendingRequirements returns ErrRequirementPending for the first
+// requirement of the session which is still pending. Requirements which were
+// taken care of in the meantime are removed from the session.
+func (s *ManagerHTTP) checkPendingRequirements(ctx context.Context, sess *Session) error {
+	if len(sess.PendingRequirements) == 0 {
+		return nil
+	}
+
+	for _, requirement := range sess.PendingRequirements {
+		pending, err := s.isRequirementPending(ctx, sess, requirement)
+		if err != nil {
+			return err
+		} else if pending {
+			return errors.WithStack(NewErrRequirementPending(requirement,
+				urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/settings/browser").String()))
+		}
+	}
+
+	sess.PendingRequirements = nil
+	return s.r.SessionPersister().UpsertSession(ctx, sess)
+}
+
+// isRequirementPending checks whether the identity of the session still has
+// to take care of the requirement.
+func (s *ManagerHTTP) isRequirementPending(ctx context.Context, sess *Session, requirement Requirement) (bool, error) {
+	switch requirement {
+	case RequirementProfileIncomplete:
+		i := sess.Identity
+		if i == nil {
+			var err error
+			if i, err = s.r.IdentityPool().GetIdentity(ctx, sess.IdentityID, identity.ExpandNothing); err != nil {
+				return false, err
+			}
+		}
+		missing, err := s.r.IdentityValidator().MissingTraits(ctx, i)
+		if err != nil {
+			return false, err
+		}
+		return len(missing) > 0, nil
+	case RequirementLegalDocumentsPending:
+		docs, err := s.r.Config().SelfServiceLegalDocuments(ctx)
+		if err != nil {
+			return false, err
+		}
+		pending, err := legal.Pending(ctx, s.r.LegalPersister(), sess.IdentityID, docs)
+		if err != nil {
+			return false, err
+		}
+		return len(pending) > 0, nil
+	case RequirementPasswordExpired:
+		i, err := s.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, sess.IdentityID)
+		if err != nil {
+			return false, err
+		}
+		policy, err := s.r.Config().PasswordPolicyRules(ctx, i.SchemaID)
+		if err != nil {
+			return false, err
+		}
+		return i.PasswordExpired(policy.MaxAge, time.Now())
+	}
+
+	return false, errors.Errorf("unknown session requirement: %s", requirement)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+// revokeSessionsTokenPurpose separates the keyed hashes of revoke sessions
+// tokens from other values keyed with the session secrets.
+const revokeSessionsTokenPurpose = "revoke_sessions"
+
+func ErrRevokeSessionsLinkInvalid() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithError("revoke sessions link is invalid").WithReason("The link is invalid or has expired.")
+}
+
+// RevokeSessionsToken records the use of a revoke sessions token, which can
+// only be used once. Only the token's hash is stored.
+type RevokeSessionsToken struct {
+	ID        uuid.UUID `db:"id"`
+	NID       uuid.UUID `db:"nid"`
+	TokenHash string    `db:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (RevokeSessionsToken) TableName() string { return "session_revoke_tokens" }
+
+// NewRevokeSessionsToken returns a token which revokes all sessions of the
+// identity once before it expires. The token is signed with the secret and
+// bound to the network of the identity. It is only stored once it was used.
+func NewRevokeSessionsToken(secret []byte, nid, identityID uuid.UUID, expiresAt time.Time) string {
+	payload := identityID.String() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(signRevokeSessionsPayload(secret, nid, payload))
+}
+
+// ParseRevokeSessionsToken returns the identity ID and expiry of the token if
+// one of the secrets signed it for the network and it has not expired. The
+// first secret is used for signing, the others are accepted so that rotating
+// the secrets does not invalidate links which were already sent.
+func ParseRevokeSessionsToken(secrets [][]byte, nid uuid.UUID, token string, now time.Time) (uuid.UUID, time.Time, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+
+	var valid bool
+	for _, secret := range secrets {
+		if hmac.Equal(signature, signRevokeSessionsPayload(secret, nid, string(payload))) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+
+	rawID, rawExpiresAt, ok := strings.Cut(string(payload), ".")
+	if !ok {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+	expiresAt, err := strconv.ParseInt(rawExpiresAt, 10, 64)
+	if err != nil || now.After(time.Unix(expiresAt, 0)) {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+	identityID, err := uuid.FromString(rawID)
+	if err != nil {
+		return uuid.Nil, time.Time{}, errors.WithStack(ErrRevokeSessionsLinkInvalid())
+	}
+	return identityID, time.Unix(expiresAt, 0).UTC(), nil
+}
+
+// HashRevokeSessionsToken returns the hash under which the use of the token is
+// recorded.
+func HashRevokeSessionsToken(token string) string {
+	h := sha512.Sum512_256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func signRevokeSessionsPayload(secret []byte, nid uuid.UUID, payload string) []byte {
+	h := hmac.New(sha512.New512_256, secret)
+	_, _ = h.Write([]byte(revokeSessionsTokenPurpose + ":" + nid.String() + ":" + payload))
+	return h.Sum(nil)
+}
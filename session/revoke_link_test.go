@@ -0,0 +1,64 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/ory/kratos/session"
+)
+
+func TestRevokeSessionsToken(t *testing.T) {
+	t.Parallel()
+
+	nid := uuid.Must(uuid.NewV4())
+	identityID := uuid.Must(uuid.NewV4())
+	secret := []byte("a-very-secret-value-which-is-long")
+	now := time.Now()
+
+	t.Run("case=parses a valid token", func(t *testing.T) {
+		token := NewRevokeSessionsToken(secret, nid, identityID, now.Add(time.Hour))
+		actual, expiresAt, err := ParseRevokeSessionsToken([][]byte{secret}, nid, token, now)
+		require.NoError(t, err)
+		assert.Equal(t, identityID, actual)
+		assert.Equal(t, now.Add(time.Hour).Unix(), expiresAt.Unix())
+	})
+
+	t.Run("case=parses a token signed with a rotated secret", func(t *testing.T) {
+		token := NewRevokeSessionsToken(secret, nid, identityID, now.Add(time.Hour))
+		actual, _, err := ParseRevokeSessionsToken([][]byte{[]byte("a-new-secret-value-which-is-long"), secret}, nid, token, now)
+		require.NoError(t, err)
+		assert.Equal(t, identityID, actual)
+	})
+
+	t.Run("case=rejects an expired token", func(t *testing.T) {
+		token := NewRevokeSessionsToken(secret, nid, identityID, now.Add(-time.Minute))
+		_, _, err := ParseRevokeSessionsToken([][]byte{secret}, nid, token, now)
+		assert.ErrorIs(t, err, ErrRevokeSessionsLinkInvalid())
+	})
+
+	t.Run("case=rejects a token signed with another secret", func(t *testing.T) {
+		token := NewRevokeSessionsToken([]byte("another-secret-value-which-is-long"), nid, identityID, now.Add(time.Hour))
+		_, _, err := ParseRevokeSessionsToken([][]byte{secret}, nid, token, now)
+		assert.ErrorIs(t, err, ErrRevokeSessionsLinkInvalid())
+	})
+
+	t.Run("case=rejects a token issued for another network", func(t *testing.T) {
+		token := NewRevokeSessionsToken(secret, uuid.Must(uuid.NewV4()), identityID, now.Add(time.Hour))
+		_, _, err := ParseRevokeSessionsToken([][]byte{secret}, nid, token, now)
+		assert.ErrorIs(t, err, ErrRevokeSessionsLinkInvalid())
+	})
+
+	t.Run("case=rejects malformed tokens", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", NewRevokeSessionsToken(secret, nid, identityID, now.Add(time.Hour)) + "x"} {
+			_, _, err := ParseRevokeSessionsToken([][]byte{secret}, nid, token, now)
+			assert.ErrorIs(t, err, ErrRevokeSessionsLinkInvalid(), "%s", token)
+		}
+	})
+}
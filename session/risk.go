@@ -0,0 +1,80 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RiskAction is the action taken because of the risk score of a sign-in.
+//
+// swagger:enum RiskAction
+type RiskAction string
+
+const (
+	RiskActionAllow  RiskAction = "allow"
+	RiskActionNotify RiskAction = "notify"
+	RiskActionStepUp RiskAction = "step_up"
+	RiskActionBlock  RiskAction = "block"
+)
+
+// Risk Assessment
+//
+// The risk assessment of the sign-in which issued the session.
+//
+// swagger:model sessionRiskAssessment
+type RiskAssessment struct {
+	// Score ranges from 0 (no risk) to 100 (highest risk).
+	//
+	// required: true
+	Score int `json:"score"`
+
+	// Action is the strongest action taken because of the score.
+	//
+	// required: true
+	Action RiskAction `json:"action"`
+
+	// Signals lists the risk signals which were detected, for example
+	// `new_device` or `impossible_travel`.
+	//
+	// required: true
+	Signals []string `json:"signals"`
+
+	// AssessedAt is the time of the assessment.
+	//
+	// required: true
+	AssessedAt time.Time `json:"assessed_at"`
+}
+
+// RequiresStepUp returns true if the sign-in must be completed with the
+// second factor, if the identity has one.
+func (r *RiskAssessment) RequiresStepUp() bool {
+	return r != nil && r.Action == RiskActionStepUp
+}
+
+// Scan implements the Scanner interface.
+func (r *RiskAssessment) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), r))
+}
+
+// Value implements the driver Valuer interface.
+func (r RiskAssessment) Value() (driver.Value, error) {
+	value, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
@@ -28,6 +28,19 @@ func ErrIdentityDisabled() *herodot.DefaultError {
 	return herodot.ErrBadRequest().WithID(text.ErrIDIdentityDisabled).WithError("identity is disabled").WithReason("This account was disabled.")
 }
 
+func ErrIdentityPendingApproval() *herodot.DefaultError {
+	return herodot.ErrBadRequest().WithID(text.ErrIDIdentityPendingApproval).WithError("identity is pending approval").WithReason("This account is awaiting approval.")
+}
+
+// errIdentityNotActive returns the error explaining why the identity can not
+// be used to sign in.
+func errIdentityNotActive(i *identity.Identity) *herodot.DefaultError {
+	if i.State == identity.StatePendingApproval {
+		return ErrIdentityPendingApproval().WithDetail("identity_id", i.ID)
+	}
+	return ErrIdentityDisabled().WithDetail("identity_id", i.ID)
+}
+
 type lifespanProvider interface {
 	SessionLifespan(ctx context.Context) time.Duration
 }
@@ -108,6 +121,25 @@ type Session struct {
 	// A list of authentication methods (e.g. password, oidc, ...) used to issue this session.
 	AMR AuthenticationMethods `db:"authentication_methods" json:"authentication_methods"`
 
+	// The Risk Assessment
+	//
+	// The risk assessment of the sign-in which issued this session. Only set if risk-based
+	// authentication is enabled.
+	Risk *RiskAssessment `json:"risk,omitempty" faker:"-" db:"risk"`
+
+	// The Impersonation
+	//
+	// Set if an administrator issued this session to impersonate the identity. Impersonated
+	// sessions can not complete settings flows and can not be extended.
+	Impersonation *Impersonation `json:"impersonation,omitempty" faker:"-" db:"impersonation"`
+
+	// Pending Requirements
+	//
+	// Lists what the identity had to take care of when this session was issued, for
+	// example accepting the current legal documents. Such sessions are rejected by
+	// `/sessions/whoami` until the requirements were taken care of using the settings flow.
+	PendingRequirements Requirements `json:"pending_requirements,omitempty" faker:"-" db:"pending_requirements"`
+
 	// The Session Issuance Timestamp
 	//
 	// When this session was issued at. Usually equal or close to `authenticated_at`.
@@ -282,10 +314,17 @@ func NewInactiveSession() *Session {
 }
 
 func (s *Session) SetSessionDeviceInformation(r *http.Request) {
+	device := NewDeviceFromRequest(r)
+	device.SessionID = s.ID
+	device.IdentityID = new(s.IdentityID)
+	s.Devices = append(s.Devices, device)
+}
+
+// NewDeviceFromRequest returns the IP address, user agent, and location of the
+// client which sent the request.
+func NewDeviceFromRequest(r *http.Request) Device {
 	device := Device{
-		SessionID:  s.ID,
-		IdentityID: new(s.IdentityID),
-		IPAddress:  new(httpx.ClientIP(r)),
+		IPAddress: new(httpx.ClientIP(r)),
 	}
 
 	agent := r.Header["User-Agent"]
@@ -303,7 +342,7 @@ func (s *Session) SetSessionDeviceInformation(r *http.Request) {
 	loc := strings.Join(clientGeoLocation, ", ")
 	device.Location = &loc
 
-	s.Devices = append(s.Devices, device)
+	return device
 }
 
 func (s Session) Declassified() *Session {
@@ -328,6 +367,10 @@ func (s *Session) MarshalJSON() ([]byte, error) {
 }
 
 func (s *Session) CanBeRefreshed(ctx context.Context, c refreshWindowProvider) bool {
+	if s.IsImpersonated() {
+		// Impersonated sessions keep the lifespan the administrator chose.
+		return false
+	}
 	return s.ExpiresAt.Add(-c.SessionRefreshMinTimeLeft(ctx)).Before(time.Now())
 }
 
@@ -368,6 +411,10 @@ type AuthenticationMethod struct {
 	// provider, if any. Populated only for OIDC login methods when the
 	// upstream ID token contained an `amr` claim.
 	UpstreamAMR []string `json:"upstream_amr,omitempty"`
+
+	// Actor is the administrator who impersonates the identity. Only set for
+	// the `impersonation` method.
+	Actor string `json:"actor,omitempty"`
 }
 
 // Scan implements the Scanner interface.
@@ -667,5 +667,73 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			assert.LessOrEqual(t, expectedExpiry.Sub(actual.ExpiresAt).Abs(), 10*time.Second)
 			assert.True(t, foundExpectedCockroachError.Load(), "We expect to find a not found error caused by ... FOR UPDATE SKIP LOCKED")
 		})
+
+		t.Run("case=trusted devices", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			newDevice := func(t *testing.T, expiresAt time.Time) (*session.TrustedDevice, string) {
+				token := randx.MustString(32, randx.AlphaNum)
+				d := &session.TrustedDevice{
+					IdentityID: i.ID,
+					UserAgent:  new("Mozilla/5.0"),
+					ExpiresAt:  expiresAt,
+				}
+				require.NoError(t, p.CreateTrustedDevice(ctx, d, token))
+				assert.NotEqual(t, token, d.TokenHMAC)
+				return d, token
+			}
+
+			active, token := newDevice(t, time.Now().Add(time.Hour).UTC())
+			expired, expiredToken := newDevice(t, time.Now().Add(-time.Hour).UTC())
+
+			t.Run("method=use", func(t *testing.T) {
+				actual, err := p.UseTrustedDevice(ctx, i.ID, active.ID, token)
+				require.NoError(t, err)
+				assert.Equal(t, active.ID, actual.ID)
+
+				_, err = p.UseTrustedDevice(ctx, i.ID, active.ID, "wrong-token")
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+
+				_, err = p.UseTrustedDevice(ctx, x.NewUUID(), active.ID, token)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+
+				_, err = p.UseTrustedDevice(ctx, i.ID, expired.ID, expiredToken)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			})
+
+			t.Run("method=list", func(t *testing.T) {
+				actual, err := p.ListTrustedDevices(ctx, i.ID)
+				require.NoError(t, err)
+				require.Len(t, actual, 1)
+				assert.Equal(t, active.ID, actual[0].ID)
+				require.NotNil(t, actual[0].LastUsedAt)
+			})
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+
+				actual, err := other.ListTrustedDevices(ctx, i.ID)
+				require.NoError(t, err)
+				assert.Empty(t, actual)
+
+				_, err = other.UseTrustedDevice(ctx, i.ID, active.ID, token)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+				require.ErrorIs(t, other.DeleteTrustedDevice(ctx, i.ID, active.ID), sqlcon.ErrNoRows())
+			})
+
+			t.Run("method=delete", func(t *testing.T) {
+				require.ErrorIs(t, p.DeleteTrustedDevice(ctx, x.NewUUID(), active.ID), sqlcon.ErrNoRows())
+				require.NoError(t, p.DeleteTrustedDevice(ctx, i.ID, active.ID))
+				require.ErrorIs(t, p.DeleteTrustedDevice(ctx, i.ID, active.ID), sqlcon.ErrNoRows())
+
+				newDevice(t, time.Now().Add(time.Hour).UTC())
+				require.NoError(t, p.DeleteTrustedDevicesByIdentity(ctx, i.ID))
+				actual, err := p.ListTrustedDevices(ctx, i.ID)
+				require.NoError(t, err)
+				assert.Empty(t, actual)
+			})
+		})
 	}
 }
@@ -72,6 +72,14 @@ func SetSubjectClaim(claims jwt.MapClaims, session *Session, subjectSource strin
 	return nil
 }
 
+// SetActorClaim sets the `act` claim (RFC 8693) to the administrator who
+// impersonates the identity of the session.
+func SetActorClaim(claims jwt.MapClaims, session *Session) {
+	if session.IsImpersonated() {
+		claims["act"] = map[string]any{"sub": session.Impersonation.Actor}
+	}
+}
+
 func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, session *Session) (err error) {
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.TokenizeSession")
 	defer otelx.End(span, &err)
@@ -120,6 +128,7 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 	if err = SetSubjectClaim(claims, session, tpl.SubjectSource); err != nil {
 		return err
 	}
+	SetActorClaim(claims, session)
 
 	if mapper := tpl.ClaimsMapperURL; len(mapper) > 0 {
 		sessionRaw, err := json.Marshal(session)
@@ -163,6 +172,8 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 	if err = SetSubjectClaim(claims, session, tpl.SubjectSource); err != nil {
 		return err
 	}
+	// The claims mapper must not hide the impersonation.
+	SetActorClaim(claims, session)
 
 	var privateKey interface{}
 	if err := key.Raw(&privateKey); err != nil {
@@ -119,6 +119,20 @@ func TestTokenizer(t *testing.T) {
 		snapshotx.SnapshotT(t, token.Claims, snapshotx.ExceptPaths("jti"))
 	})
 
+	t.Run("case=es256-impersonated", func(t *testing.T) {
+		tid := "es256-no-template"
+		ctx := setTokenizeConfig(t.Context(), tid, "jwk.es256.json", "")
+
+		impersonated := *s
+		impersonated.Impersonation = &session.Impersonation{Actor: "admin@example.org"}
+		require.NoError(t, tkn.TokenizeSession(ctx, tid, &impersonated))
+		token := validateTokenized(t, impersonated.Tokenized, es256Key)
+
+		resultClaims := token.Claims.(jwt.MapClaims)
+		assert.Equal(t, i.ID.String(), resultClaims["sub"])
+		assert.Equal(t, map[string]any{"sub": "admin@example.org"}, resultClaims["act"])
+	})
+
 	t.Run("case=es512-without-jsonnet", func(t *testing.T) {
 		tid := "es512-no-template"
 		ctx := setTokenizeConfig(t.Context(), tid, "jwk.es512.json", "")
@@ -0,0 +1,189 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+// TrustedDeviceCookieName is the name of the cookie which marks a browser as
+// trusted.
+const TrustedDeviceCookieName = "ory_kratos_trusted_device"
+
+// A Trusted Device
+//
+// A trusted device is a browser in which the identity signed in with a second
+// factor and chose to not be asked for it again. Signing in from a trusted
+// device satisfies the second factor until the device expires.
+//
+// swagger:model trustedDevice
+type TrustedDevice struct {
+	// Trusted device ID
+	//
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	// IdentityID is the ID of the identity which trusts the device.
+	//
+	// required: true
+	IdentityID uuid.UUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+	// IPAddress of the client when the device was trusted
+	IPAddress *string `json:"ip_address" faker:"ptr_ipv4" db:"ip_address"`
+
+	// UserAgent of the client when the device was trusted
+	UserAgent *string `json:"user_agent" faker:"-" db:"user_agent"`
+
+	// Geo Location corresponding to the IP Address
+	Location *string `json:"location" faker:"ptr_geo_location" db:"location"`
+
+	// ExpiresAt is the time until which the device satisfies the second factor.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// LastUsedAt is the time the device last satisfied the second factor.
+	LastUsedAt *sqlxx.NullTime `json:"last_used_at,omitempty" faker:"-" db:"last_used_at"`
+
+	// CreatedAt is the time the device was trusted.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+
+	// TokenHMAC is the keyed hash of the token stored in the device cookie.
+	TokenHMAC string `json:"-" faker:"-" db:"token_hmac"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+}
+
+func (TrustedDevice) TableName() string { return "identity_trusted_devices" }
+
+type (
+	TrustedDevicePersister interface {
+		// CreateTrustedDevice stores the trusted device. Only a keyed hash of
+		// the token is stored.
+		CreateTrustedDevice(ctx context.Context, d *TrustedDevice, token string) error
+
+		// UseTrustedDevice returns the identity's trusted device with the given
+		// ID if it has not expired and the token matches, and records that it
+		// was used. It returns sqlcon.ErrNoRows otherwise.
+		UseTrustedDevice(ctx context.Context, identityID, id uuid.UUID, token string) (*TrustedDevice, error)
+
+		// ListTrustedDevices returns the identity's trusted devices which have
+		// not expired, most recently trusted first.
+		ListTrustedDevices(ctx context.Context, identityID uuid.UUID) ([]TrustedDevice, error)
+
+		// DeleteTrustedDevice removes the identity's trusted device with the
+		// given ID.
+		DeleteTrustedDevice(ctx context.Context, identityID, id uuid.UUID) error
+
+		// DeleteTrustedDevicesByIdentity removes all trusted devices of the
+		// identity.
+		DeleteTrustedDevicesByIdentity(ctx context.Context, identityID uuid.UUID) error
+	}
+	TrustedDevicePersistenceProvider interface {
+		TrustedDevicePersister() TrustedDevicePersister
+	}
+)
+
+// TrustDevice trusts the browser for the session's identity. It stores a
+// trusted device and issues a signed cookie referring to it.
+func (s *ManagerHTTP) TrustDevice(ctx context.Context, w http.ResponseWriter, r *http.Request, session *Session) (err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.TrustDevice")
+	defer otelx.End(span, &err)
+
+	lifespan := s.r.Config().SelfServiceTrustedDeviceLifespan(ctx)
+	device := TrustedDevice{
+		IdentityID: session.IdentityID,
+		ExpiresAt:  time.Now().UTC().Add(lifespan),
+	}
+	if len(session.Devices) > 0 {
+		current := session.Devices[len(session.Devices)-1]
+		device.IPAddress = current.IPAddress
+		device.UserAgent = current.UserAgent
+		device.Location = current.Location
+	}
+
+	token := randx.MustString(32, randx.AlphaNum)
+	if err := s.r.TrustedDevicePersister().CreateTrustedDevice(ctx, &device, token); err != nil {
+		return err
+	}
+
+	cookie, err := s.r.CookieManager(ctx).Get(r, TrustedDeviceCookieName)
+	// Fix for https://github.com/ory/kratos/issues/1695
+	if err != nil && cookie == nil {
+		return errors.WithStack(err)
+	}
+
+	s.setCookieOptions(ctx, cookie)
+	cookie.Options.MaxAge = int(lifespan.Seconds())
+	cookie.Values["device_id"] = device.ID.String()
+	cookie.Values["identity_id"] = device.IdentityID.String()
+	cookie.Values["token"] = token
+
+	return errors.WithStack(cookie.Save(r, w))
+}
+
+// MaybeCompleteWithTrustedDevice completes the second factor of the session
+// if the request carries the cookie of a trusted device of the session's
+// identity. Nothing happens if trusted devices are disabled, the session
+// already has the highest AAL, or the identity has no second factor.
+func (s *ManagerHTTP) MaybeCompleteWithTrustedDevice(ctx context.Context, r *http.Request, session *Session) (err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.MaybeCompleteWithTrustedDevice")
+	defer otelx.End(span, &err)
+
+	if !s.r.Config().SelfServiceTrustedDeviceEnabled(ctx) ||
+		session.AuthenticatorAssuranceLevel >= identity.AuthenticatorAssuranceLevel2 ||
+		session.Identity == nil ||
+		session.Identity.InternalAvailableAAL.String != string(identity.AuthenticatorAssuranceLevel2) {
+		return nil
+	}
+
+	if _, err := r.Cookie(TrustedDeviceCookieName); errors.Is(err, http.ErrNoCookie) {
+		return nil
+	}
+
+	cookie, err := s.r.CookieManager(ctx).Get(r, TrustedDeviceCookieName)
+	if err != nil {
+		// The cookie was not signed by us or with a rotated secret.
+		s.r.Logger().WithRequest(r).WithError(err).Debug("Ignoring invalid trusted device cookie.")
+		return nil
+	}
+
+	deviceID, _ := cookie.Values["device_id"].(string)
+	identityID, _ := cookie.Values["identity_id"].(string)
+	token, _ := cookie.Values["token"].(string)
+	if identityID != session.IdentityID.String() || token == "" {
+		return nil
+	}
+
+	id, err := uuid.FromString(deviceID)
+	if err != nil {
+		return nil
+	}
+
+	if _, err := s.r.TrustedDevicePersister().UseTrustedDevice(ctx, session.IdentityID, id, token); errors.Is(err, sqlcon.ErrNoRows()) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	session.CompletedLoginFor(identity.CredentialsTypeTrustedDevice, identity.AuthenticatorAssuranceLevel2)
+	session.SetAuthenticatorAssuranceLevel()
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/contextx"
+	"github.com/ory/x/logrusx"
+)
+
+// Contextualizer serves contexts carrying a tenant with the tenant's network
+// and configuration. All other contexts are served by the wrapped
+// contextualizer.
+type Contextualizer struct {
+	base contextx.Contextualizer
+	l    *logrusx.Logger
+
+	mu      sync.Mutex
+	configs map[uuid.UUID]cachedConfig
+}
+
+type cachedConfig struct {
+	p         *configx.Provider
+	expiresAt time.Time
+}
+
+var _ contextx.Contextualizer = (*Contextualizer)(nil)
+
+func NewContextualizer(base contextx.Contextualizer, l *logrusx.Logger) *Contextualizer {
+	return &Contextualizer{base: base, l: l, configs: make(map[uuid.UUID]cachedConfig)}
+}
+
+func (c *Contextualizer) Network(ctx context.Context, network uuid.UUID) uuid.UUID {
+	if t := FromContext(ctx); t != nil {
+		return t.ID
+	}
+	return c.base.Network(ctx, network)
+}
+
+func (c *Contextualizer) Config(ctx context.Context, base *configx.Provider) *configx.Provider {
+	base = c.base.Config(ctx, base)
+	t := FromContext(ctx)
+	if t == nil {
+		return base
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.configs[t.ID]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.p
+	}
+
+	p, err := t.ConfigProvider(ctx, base)
+	if err != nil {
+		// The overlay was valid when the tenant was created, but no longer is
+		// after the base configuration changed.
+		c.l.WithError(err).WithField("tenant_id", t.ID).Error("Unable to overlay the tenant configuration, using the base configuration instead.")
+		return base
+	}
+
+	if ttl := base.DurationF(config.ViperKeyMultiTenancyCacheTTL, time.Minute); ttl > 0 {
+		c.mu.Lock()
+		c.configs[t.ID] = cachedConfig{p: p, expiresAt: now.Add(ttl)}
+		c.mu.Unlock()
+	}
+	return p
+}
@@ -0,0 +1,326 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteCollection = "/tenants"
+	RouteItem       = RouteCollection + "/{id}"
+)
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		PersistenceProvider
+		ResolverProvider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		TenantHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(
+		RouteCollection,
+		RouteCollection+"/*",
+		httprouterx.AdminPrefix+RouteCollection,
+		httprouterx.AdminPrefix+RouteCollection+"/*",
+	)
+
+	public.GET(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+RouteCollection, redir.RedirectToAdminRoute(h.r))
+	public.GET(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+RouteItem, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+	admin.POST(RouteCollection, h.create)
+	admin.GET(RouteItem, h.get)
+	admin.DELETE(RouteItem, h.delete)
+}
+
+// Create Tenant Request Body
+//
+// swagger:model createTenantBody
+type CreateTenantBody struct {
+	// Name is a unique, human-readable identifier of the tenant consisting of
+	// lower-case letters, digits and dashes.
+	//
+	// required: true
+	Name string `json:"name"`
+
+	// Hosts are the request hosts served as this tenant, without scheme or
+	// port.
+	//
+	// required: true
+	Hosts []string `json:"hosts"`
+
+	// Config overlays the identity, selfservice, courier, session and cookies
+	// configuration for this tenant.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// Create Tenant Parameters
+//
+// swagger:parameters createTenant
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createTenant struct {
+	// in: body
+	Body CreateTenantBody
+}
+
+// swagger:route POST /admin/tenants tenant createTenant
+//
+// # Create a Tenant
+//
+// Creates a tenant and its network. The tenant's configuration overlay is
+// validated against the current base configuration.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: tenant
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var body CreateTenantBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	// Tenants are managed in the default network, regardless of the tenant
+	// the request was resolved to.
+	ctx := WithTenant(r.Context(), nil)
+
+	t := &Tenant{
+		ID:     uuid.Must(uuid.NewV4()),
+		Name:   body.Name,
+		Hosts:  body.Hosts,
+		Config: []byte(body.Config),
+	}
+	if err := t.Validate(); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	if _, err := t.ConfigProvider(ctx, h.r.Config().GetProvider(ctx)); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.TenantPersister().CreateTenant(ctx, t); err != nil {
+		if errors.Is(err, sqlcon.ErrUniqueViolation()) {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReason("A tenant with this name or one of these hosts already exists.")))
+		} else {
+			h.r.Writer().WriteError(w, r, err)
+		}
+		return
+	}
+	h.r.TenantResolver().Forget()
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "tenants", t.ID.String()).String(),
+		t,
+	)
+}
+
+// Paginated Tenant List Response
+//
+// swagger:response listTenants
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listTenantsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of tenants
+	//
+	// in:body
+	Body []Tenant
+}
+
+// List Tenants Parameters
+//
+// swagger:parameters listTenants
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listTenants struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/tenants tenant listTenants
+//
+// # List Tenants
+//
+// Lists all tenants served by this deployment.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listTenants
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	ctx := WithTenant(r.Context(), nil)
+
+	keys := h.r.Config().SecretsPagination(ctx)
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	tenants, nextPage, err := h.r.TenantPersister().ListTenants(ctx, opts)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, tenants)
+}
+
+// Get Tenant Parameters
+//
+// swagger:parameters getTenant
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getTenant struct {
+	// ID is the tenant's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/tenants/{id} tenant getTenant
+//
+// # Get a Tenant
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: tenant
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	t, err := h.r.TenantPersister().GetTenant(WithTenant(r.Context(), nil), x.ParseUUID(r.PathValue("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, t)
+}
+
+// Delete Tenant Parameters
+//
+// swagger:parameters deleteTenant
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteTenant struct {
+	// ID is the tenant's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/tenants/{id} tenant deleteTenant
+//
+// # Delete a Tenant
+//
+// Irrecoverably deletes the tenant and all of its identities, sessions,
+// flows and courier messages. This action can not be undone.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.r.TenantPersister().DeleteTenant(WithTenant(r.Context(), nil), x.ParseUUID(r.PathValue("id"))); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	h.r.TenantResolver().Forget()
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,223 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/tenant"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	_, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	send := func(t *testing.T, method, path string, body any, expectCode int) gjson.Result {
+		t.Helper()
+		var reqBody io.Reader
+		if body != nil {
+			b, err := json.Marshal(body)
+			require.NoError(t, err)
+			reqBody = bytes.NewReader(b)
+		}
+		req, err := http.NewRequest(method, adminTS.URL+"/admin/tenants"+path, reqBody)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	created := send(t, "POST", "", tenant.CreateTenantBody{
+		Name:   "acme",
+		Hosts:  []string{"Auth.Acme.com"},
+		Config: json.RawMessage(`{"selfservice":{"flows":{"login":{"ui_url":"https://auth.acme.com/login"}}}}`),
+	}, http.StatusCreated)
+	id := created.Get("id").String()
+	assert.Equal(t, "acme", created.Get("name").String())
+	assert.Equal(t, []any{"auth.acme.com"}, created.Get("hosts").Value())
+
+	t.Run("case=creates the tenant's network", func(t *testing.T) {
+		tn, err := reg.TenantPersister().GetTenantByHost(ctx, "auth.acme.com")
+		require.NoError(t, err)
+		assert.Equal(t, id, tn.ID.String())
+
+		// Identities created in the tenant's network are invisible to the
+		// default network.
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		_, err = reg.PrivilegedIdentityPool().GetIdentity(ctx, i.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+
+		p := reg.Persister().WithNetworkID(tn.ID)
+		_, err = p.GetIdentity(ctx, i.ID, identity.ExpandNothing)
+		require.ErrorIs(t, err, sqlcon.ErrNoRows())
+	})
+
+	t.Run("case=rejects conflicts", func(t *testing.T) {
+		send(t, "POST", "", tenant.CreateTenantBody{Name: "acme", Hosts: []string{"other.acme.com"}}, http.StatusConflict)
+		send(t, "POST", "", tenant.CreateTenantBody{Name: "other", Hosts: []string{"auth.acme.com"}}, http.StatusConflict)
+	})
+
+	t.Run("case=rejects invalid overlays", func(t *testing.T) {
+		res := send(t, "POST", "", tenant.CreateTenantBody{
+			Name:   "invalid",
+			Hosts:  []string{"invalid.acme.com"},
+			Config: json.RawMessage(`{"secrets":{"default":["a-secret-of-thirty-two-characters"]}}`),
+		}, http.StatusBadRequest)
+		assert.Contains(t, res.Get("error.reason").String(), `key "secrets"`)
+
+		send(t, "POST", "", tenant.CreateTenantBody{
+			Name:   "invalid",
+			Hosts:  []string{"invalid.acme.com"},
+			Config: json.RawMessage(`{"selfservice":{"flows":{"login":{"lifespan":"forever"}}}}`),
+		}, http.StatusBadRequest)
+	})
+
+	t.Run("case=lists and gets tenants", func(t *testing.T) {
+		send(t, "POST", "", tenant.CreateTenantBody{Name: "globex", Hosts: []string{"login.globex.com", "sso.globex.com"}}, http.StatusCreated)
+
+		list := send(t, "GET", "", nil, http.StatusOK)
+		assert.ElementsMatch(t, []string{"acme", "globex"}, []string{list.Get("0.name").String(), list.Get("1.name").String()})
+
+		assert.Equal(t, "acme", send(t, "GET", "/"+id, nil, http.StatusOK).Get("name").String())
+		send(t, "GET", "/"+reg.Persister().NetworkID(ctx).String(), nil, http.StatusNotFound)
+	})
+
+	t.Run("case=deletes tenants", func(t *testing.T) {
+		tn := send(t, "POST", "", tenant.CreateTenantBody{Name: "initech", Hosts: []string{"auth.initech.com"}}, http.StatusCreated)
+
+		send(t, "DELETE", "/"+tn.Get("id").String(), nil, http.StatusNoContent)
+		send(t, "GET", "/"+tn.Get("id").String(), nil, http.StatusNotFound)
+		send(t, "DELETE", "/"+tn.Get("id").String(), nil, http.StatusNotFound)
+
+		// The default network can not be deleted.
+		send(t, "DELETE", "/"+reg.Persister().NetworkID(ctx).String(), nil, http.StatusNotFound)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyMultiTenancyEnabled, true)
+	conf.MustSet(ctx, config.ViperKeyMultiTenancyCacheTTL, "0s")
+
+	acme := &tenant.Tenant{Name: "acme", Hosts: []string{"auth.acme.com"}}
+	acme.ID = reg.Persister().NetworkID(ctx)
+	acme.ID[0] ^= 0xff
+	require.NoError(t, reg.TenantPersister().CreateTenant(ctx, acme))
+
+	resolve := func(t *testing.T, admin bool, host string, header http.Header) (*tenant.Tenant, int) {
+		t.Helper()
+		req := httptest.NewRequest("GET", "http://"+host+"/self-service/login/browser", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+
+		var resolved *tenant.Tenant
+		tenant.NewMiddleware(reg, reg.TenantResolver(), admin).ServeHTTP(w, req, func(_ http.ResponseWriter, r *http.Request) {
+			resolved = tenant.FromContext(r.Context())
+		})
+		return resolved, w.Code
+	}
+
+	t.Run("case=resolves the host", func(t *testing.T) {
+		tn, code := resolve(t, false, "Auth.Acme.com:4433", nil)
+		assert.Equal(t, http.StatusOK, code)
+		require.NotNil(t, tn)
+		assert.Equal(t, acme.ID, tn.ID)
+	})
+
+	t.Run("case=unknown hosts are served as the default network", func(t *testing.T) {
+		tn, code := resolve(t, false, "auth.example.com", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, tn)
+	})
+
+	t.Run("case=resolves the header", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyMultiTenancyHeader, "X-Kratos-Tenant")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyMultiTenancyHeader, "") })
+
+		for _, ref := range []string{"acme", acme.ID.String()} {
+			tn, code := resolve(t, true, "admin.example.com", http.Header{"X-Kratos-Tenant": {ref}})
+			assert.Equal(t, http.StatusOK, code)
+			require.NotNil(t, tn)
+			assert.Equal(t, acme.ID, tn.ID)
+		}
+
+		_, code := resolve(t, true, "admin.example.com", http.Header{"X-Kratos-Tenant": {"globex"}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("case=ignores the header of public requests", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyMultiTenancyHeader, "X-Kratos-Tenant")
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyMultiTenancyHeader, "") })
+
+		tn, code := resolve(t, false, "auth.example.com", http.Header{"X-Kratos-Tenant": {"acme"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, tn, "public clients must not switch the tenant")
+
+		other := &tenant.Tenant{Name: "globex", Hosts: []string{"auth.globex.com"}}
+		other.ID = reg.Persister().NetworkID(ctx)
+		other.ID[1] ^= 0xff
+		require.NoError(t, reg.TenantPersister().CreateTenant(ctx, other))
+		t.Cleanup(func() { assert.NoError(t, reg.TenantPersister().DeleteTenant(ctx, other.ID)) })
+
+		tn, code = resolve(t, false, "auth.globex.com", http.Header{"X-Kratos-Tenant": {"acme"}})
+		assert.Equal(t, http.StatusOK, code)
+		require.NotNil(t, tn)
+		assert.Equal(t, other.ID, tn.ID)
+	})
+
+	t.Run("case=requires a tenant", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyMultiTenancyRequireTenant, true)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyMultiTenancyRequireTenant, false) })
+
+		_, code := resolve(t, false, "auth.example.com", nil)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		tn, code := resolve(t, true, "auth.example.com", nil)
+		assert.Equal(t, http.StatusOK, code, "admin requests are never rejected")
+		assert.Nil(t, tn)
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyMultiTenancyEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyMultiTenancyEnabled, true) })
+
+		tn, code := resolve(t, false, "auth.acme.com", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, tn)
+	})
+
+	t.Run("case=contexts include every tenant", func(t *testing.T) {
+		ctxs, err := tenant.Contexts(ctx, reg)
+		require.NoError(t, err)
+		require.Len(t, ctxs, 2)
+		assert.Nil(t, tenant.FromContext(ctxs[0]))
+		assert.Equal(t, acme.ID, tenant.FromContext(ctxs[1]).ID)
+	})
+}
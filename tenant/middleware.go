@@ -0,0 +1,154 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/sqlcon"
+)
+
+// maxCachedLookups bounds the resolver cache, which also remembers lookups
+// of unknown hosts.
+const maxCachedLookups = 10000
+
+type (
+	resolverDependencies interface {
+		config.Provider
+		httpx.WriterProvider
+		PersistenceProvider
+	}
+
+	// Resolver determines the tenant of a request.
+	Resolver struct {
+		d resolverDependencies
+
+		mu      sync.Mutex
+		lookups map[string]cachedLookup
+	}
+
+	ResolverProvider interface {
+		TenantResolver() *Resolver
+	}
+
+	cachedLookup struct {
+		t         *Tenant
+		expiresAt time.Time
+	}
+
+	// Middleware serves requests as the tenant resolved from the request.
+	Middleware struct {
+		d     resolverDependencies
+		r     *Resolver
+		admin bool
+	}
+)
+
+func NewResolver(d resolverDependencies) *Resolver {
+	return &Resolver{d: d, lookups: make(map[string]cachedLookup)}
+}
+
+// Resolve returns the tenant owning the request host or, for admin requests,
+// the tenant named in the tenant header. Public requests are never resolved
+// using the header, as any client could set it. It returns nil if the request
+// does not belong to a tenant.
+func (r *Resolver) Resolve(req *http.Request, admin bool) (*Tenant, error) {
+	ctx := req.Context()
+
+	if header := r.d.Config().MultiTenancyHeader(ctx); admin && header != "" {
+		if ref := strings.TrimSpace(req.Header.Get(header)); ref != "" {
+			t, err := r.lookup(ctx, "ref:"+ref, func(ctx context.Context) (*Tenant, error) {
+				if id, err := uuid.FromString(ref); err == nil {
+					return r.d.TenantPersister().GetTenant(ctx, id)
+				}
+				return r.d.TenantPersister().GetTenantByName(ctx, ref)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if t == nil {
+				return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf("Tenant %q does not exist.", ref))
+			}
+			return t, nil
+		}
+	}
+
+	host := NormalizeHost(req.Host)
+	return r.lookup(ctx, "host:"+host, func(ctx context.Context) (*Tenant, error) {
+		return r.d.TenantPersister().GetTenantByHost(ctx, host)
+	})
+}
+
+func (r *Resolver) lookup(ctx context.Context, key string, find func(context.Context) (*Tenant, error)) (*Tenant, error) {
+	now := time.Now()
+	r.mu.Lock()
+	cached, ok := r.lookups[key]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.t, nil
+	}
+
+	t, err := find(ctx)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		t, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := r.d.Config().MultiTenancyCacheTTL(ctx); ttl > 0 {
+		r.mu.Lock()
+		if len(r.lookups) >= maxCachedLookups {
+			r.lookups = make(map[string]cachedLookup)
+		}
+		r.lookups[key] = cachedLookup{t: t, expiresAt: now.Add(ttl)}
+		r.mu.Unlock()
+	}
+	return t, nil
+}
+
+// Forget removes all cached lookups, e.g. after a tenant was created or
+// deleted.
+func (r *Resolver) Forget() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups = make(map[string]cachedLookup)
+}
+
+// NewMiddleware returns a middleware serving requests as their tenant. Public
+// requests which do not belong to a tenant are rejected if
+// `multitenancy.require_tenant` is set, admin requests never are.
+func NewMiddleware(d resolverDependencies, r *Resolver, admin bool) *Middleware {
+	return &Middleware{d: d, r: r, admin: admin}
+}
+
+func (m *Middleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	ctx := r.Context()
+	if !m.d.Config().MultiTenancyEnabled(ctx) {
+		next(w, r)
+		return
+	}
+
+	t, err := m.r.Resolve(r, m.admin)
+	if err != nil {
+		m.d.Writer().WriteError(w, r, err)
+		return
+	}
+	if t == nil && !m.admin && m.d.Config().MultiTenancyRequireTenant(ctx) {
+		m.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReasonf("No tenant serves host %q.", NormalizeHost(r.Host))))
+		return
+	}
+
+	next(w, r.WithContext(WithTenant(ctx, t)))
+}
@@ -0,0 +1,240 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/embedx"
+	"github.com/ory/x/configx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+// OverlayKeys are the top-level configuration keys a tenant may overlay.
+// Everything else, e.g. the DSN, the secrets or the serve configuration, is
+// shared by all tenants.
+var OverlayKeys = []string{"identity", "selfservice", "courier", "session", "cookies"}
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+type (
+	// Tenant is served by this Ory Kratos deployment alongside other tenants.
+	//
+	// Every tenant has its own network, so identities, sessions, flows and
+	// courier messages of one tenant are invisible to all others.
+	//
+	// swagger:model tenant
+	Tenant struct {
+		// ID is the tenant's network ID.
+		//
+		// required: true
+		ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+		// Name is a unique, human-readable identifier of the tenant. It can be
+		// used instead of the ID in the tenant header.
+		//
+		// required: true
+		Name string `json:"name" db:"name"`
+
+		// Hosts are the request hosts served as this tenant.
+		//
+		// required: true
+		Hosts []string `json:"hosts" faker:"-" db:"-"`
+
+		// Config overlays the identity, selfservice, courier, session and cookies
+		// configuration for this tenant.
+		Config sqlxx.JSONRawMessage `json:"config" faker:"-" db:"config"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+	}
+
+	// Host maps a request host to a tenant.
+	Host struct {
+		ID        uuid.UUID `json:"id" db:"id"`
+		TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+		Host      string    `json:"host" db:"host"`
+		CreatedAt time.Time `json:"-" db:"created_at"`
+		UpdatedAt time.Time `json:"-" db:"updated_at"`
+	}
+
+	Persister interface {
+		// CreateTenant creates the tenant, its network and its hosts.
+		CreateTenant(ctx context.Context, t *Tenant) error
+		GetTenant(ctx context.Context, id uuid.UUID) (*Tenant, error)
+		GetTenantByName(ctx context.Context, name string) (*Tenant, error)
+		GetTenantByHost(ctx context.Context, host string) (*Tenant, error)
+		ListTenants(ctx context.Context, opts []keysetpagination.Option) ([]Tenant, *keysetpagination.Paginator, error)
+		// DeleteTenant deletes the tenant's network, which deletes all data
+		// belonging to the tenant.
+		DeleteTenant(ctx context.Context, id uuid.UUID) error
+	}
+
+	PersistenceProvider interface {
+		TenantPersister() Persister
+	}
+
+	contextKey int
+)
+
+const tenantContextKey contextKey = iota + 1
+
+func (Tenant) TableName() string { return "tenants" }
+
+func (Host) TableName() string { return "tenant_hosts" }
+
+func (t Tenant) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{Name: "id", Value: t.ID})
+}
+
+func (t Tenant) DefaultPageToken() keysetpagination.PageToken {
+	return Tenant{ID: uuid.Nil}.PageToken()
+}
+
+// WithTenant returns a context which is served as the given tenant. Passing
+// nil returns a context which is served as the default network.
+func WithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey, t)
+}
+
+// FromContext returns the tenant the context is served as, or nil.
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(tenantContextKey).(*Tenant)
+	return t
+}
+
+// NormalizeHost lower-cases the host and removes the port, if any.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
+
+// Validate normalizes the tenant's hosts and checks its name, hosts and
+// configuration overlay.
+func (t *Tenant) Validate() error {
+	if !nameRegex.MatchString(t.Name) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The tenant name must consist of up to 63 lower-case letters, digits and dashes and must not start with a dash."))
+	}
+	if len(t.Hosts) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The tenant must serve at least one host."))
+	}
+
+	hosts := make([]string, 0, len(t.Hosts))
+	for _, h := range t.Hosts {
+		host := NormalizeHost(h)
+		if host == "" || strings.ContainsAny(h, "/@?#") {
+			return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The tenant host %q is invalid. Hosts must not contain a scheme or path.", h))
+		}
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	t.Hosts = hosts
+
+	_, err := t.overlay()
+	return err
+}
+
+func (t *Tenant) overlay() (map[string]any, error) {
+	if len(t.Config) == 0 || string(t.Config) == "null" {
+		return nil, nil
+	}
+
+	var overlay map[string]any
+	if err := json.Unmarshal(t.Config, &overlay); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The tenant configuration must be a JSON object: %s", err))
+	}
+	for key := range overlay {
+		if !slices.Contains(OverlayKeys, key) {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The tenant configuration must not contain key %q. Only %s can be overlaid.", key, strings.Join(OverlayKeys, ", ")))
+		}
+	}
+	return overlay, nil
+}
+
+// ConfigProvider returns the base configuration overlaid with the tenant's
+// configuration. It returns an error if the result is not a valid
+// configuration.
+func (t *Tenant) ConfigProvider(ctx context.Context, base *configx.Provider) (*configx.Provider, error) {
+	overlay, err := t.overlay()
+	if err != nil {
+		return nil, err
+	}
+	if len(overlay) == 0 {
+		return base, nil
+	}
+
+	p, err := configx.New(ctx, embedx.ConfigSchema,
+		configx.WithUserProviders(mapProvider(base.Raw()), mapProvider(overlay)),
+		configx.DisableEnvLoading(),
+	)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The tenant configuration is invalid: %s", err))
+	}
+	return p, nil
+}
+
+// mapProvider loads a nested configuration map.
+type mapProvider map[string]any
+
+func (m mapProvider) ReadBytes() ([]byte, error) {
+	return nil, errors.New("the map provider does not support reading bytes")
+}
+
+func (m mapProvider) Read() (map[string]any, error) {
+	return m, nil
+}
+
+type contextsDependencies interface {
+	config.Provider
+	PersistenceProvider
+}
+
+// ContextsProvider returns the contexts background workers process.
+type ContextsProvider interface {
+	TenantContexts(ctx context.Context) ([]context.Context, error)
+}
+
+// Contexts returns a context served as the default network followed by, if
+// multi-tenancy is enabled, a context served as each tenant. Background
+// workers use it to process the data of all tenants.
+func Contexts(ctx context.Context, d contextsDependencies) ([]context.Context, error) {
+	ctxs := []context.Context{WithTenant(ctx, nil)}
+	if !d.Config().MultiTenancyEnabled(ctx) {
+		return ctxs, nil
+	}
+
+	var opts []keysetpagination.Option
+	for {
+		tenants, next, err := d.TenantPersister().ListTenants(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for k := range tenants {
+			ctxs = append(ctxs, WithTenant(ctx, &tenants[k]))
+		}
+		if next.IsLast() {
+			return ctxs, nil
+		}
+		opts = next.ToOptions()
+	}
+}
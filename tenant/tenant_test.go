@@ -0,0 +1,94 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/tenant"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		tenant tenant.Tenant
+		err    string
+	}{
+		{name: "valid", tenant: tenant.Tenant{Name: "acme", Hosts: []string{"auth.acme.com"}}},
+		{name: "invalid name", tenant: tenant.Tenant{Name: "-Acme", Hosts: []string{"auth.acme.com"}}, err: "tenant name"},
+		{name: "no hosts", tenant: tenant.Tenant{Name: "acme"}, err: "at least one host"},
+		{name: "host with scheme", tenant: tenant.Tenant{Name: "acme", Hosts: []string{"https://auth.acme.com"}}, err: "is invalid"},
+		{name: "overlay is not an object", tenant: tenant.Tenant{Name: "acme", Hosts: []string{"auth.acme.com"}, Config: []byte(`[]`)}, err: "JSON object"},
+		{name: "overlay of shared key", tenant: tenant.Tenant{Name: "acme", Hosts: []string{"auth.acme.com"}, Config: []byte(`{"dsn":"memory"}`)}, err: `key "dsn"`},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			err := tc.tenant.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			var he *herodot.DefaultError
+			require.ErrorAs(t, err, &he)
+			assert.Contains(t, he.Reason(), tc.err)
+		})
+	}
+
+	t.Run("case=hosts are normalized", func(t *testing.T) {
+		tn := tenant.Tenant{Name: "acme", Hosts: []string{"Auth.Acme.com:443", "auth.acme.com", "[::1]:4433"}}
+		require.NoError(t, tn.Validate())
+		assert.Equal(t, []string{"auth.acme.com", "::1"}, tn.Hosts)
+	})
+}
+
+func TestContextualizer(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginUI, "https://www.ory.sh/login")
+
+	ctxer := tenant.NewContextualizer(reg.Contextualizer(), reg.Logger())
+	base := conf.GetProvider(ctx)
+	nid := reg.Persister().NetworkID(ctx)
+
+	t.Run("case=without tenant", func(t *testing.T) {
+		assert.Equal(t, nid, ctxer.Network(ctx, nid))
+		assert.Equal(t, "https://www.ory.sh/login", ctxer.Config(ctx, base).String(config.ViperKeySelfServiceLoginUI))
+	})
+
+	t.Run("case=with tenant", func(t *testing.T) {
+		tn := &tenant.Tenant{
+			ID:     uuid.Must(uuid.NewV4()),
+			Config: []byte(`{"selfservice":{"flows":{"login":{"ui_url":"https://auth.acme.com/login"}}}}`),
+		}
+		ctx := tenant.WithTenant(ctx, tn)
+
+		assert.Equal(t, tn.ID, ctxer.Network(ctx, nid))
+		p := ctxer.Config(ctx, base)
+		assert.Equal(t, "https://auth.acme.com/login", p.String(config.ViperKeySelfServiceLoginUI))
+		assert.Equal(t, base.String(config.ViperKeySelfServiceBrowserDefaultReturnTo), p.String(config.ViperKeySelfServiceBrowserDefaultReturnTo), "keys which are not overlaid are inherited")
+		assert.Same(t, p, ctxer.Config(ctx, base), "the overlay is cached")
+	})
+
+	t.Run("case=with tenant without overlay", func(t *testing.T) {
+		ctx := tenant.WithTenant(ctx, &tenant.Tenant{ID: uuid.Must(uuid.NewV4())})
+		assert.Same(t, base, ctxer.Config(ctx, base))
+	})
+
+	t.Run("case=invalid overlay falls back to the base configuration", func(t *testing.T) {
+		ctx := tenant.WithTenant(ctx, &tenant.Tenant{
+			ID:     uuid.Must(uuid.NewV4()),
+			Config: []byte(`{"selfservice":{"flows":{"login":{"lifespan":"forever"}}}}`),
+		})
+		assert.Same(t, base, ctxer.Config(ctx, base))
+	})
+}
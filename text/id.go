@@ -34,6 +34,8 @@ const (
 	InfoSelfServiceLoginPassword                                 // 1010022
 	InfoSelfServiceLoginAAL2CodeAddress                          // 1010023
 	InfoSelfServiceLoginDeviceAuthn                              // 1010024
+	InfoSelfServiceLoginCrossDevice                              // 1010025
+	InfoSelfServiceLoginCrossDeviceURL                           // 1010026
 )
 
 const (
@@ -55,6 +57,7 @@ const (
 	InfoSelfServiceRegistrationRegisterPasskey                       // 1040007
 	InfoSelfServiceRegistrationBack                                  // 1040008
 	InfoSelfServiceRegistrationChooseCredentials                     // 1040009
+	InfoSelfServiceRegistrationPendingApproval                       // 1040010
 )
 
 const (
@@ -81,6 +84,12 @@ const (
 	InfoSelfServiceSettingsRemovePasskey
 	InfoSelfServiceSettingsRemoveDeviceAuthnKey
 	InfoSelfServiceSettingsDeviceAuthnNonce
+	InfoSelfServiceSettingsPasswordExpired
+	InfoSelfServiceSettingsRemoveTOTP
+	InfoSelfServiceSettingsTOTPDeviceName
+	InfoSelfServiceSettingsRevokeTrustedDevice
+	InfoSelfServiceSettingsLegalDocumentsRequired
+	InfoSelfServiceSettingsProfileIncomplete
 )
 
 const (
@@ -114,6 +123,13 @@ const (
 	InfoNodeLabelRecoveryAddress                            // 1070016
 	InfoNodeLabelPhoneNumber                                // 1070017
 	InfoNodeLabelEmailOrPhone                               // 1070018
+	InfoNodeLabelSendCodeVia                                // 1070019
+	InfoNodeLabelRememberDevice                             // 1070020
+	InfoNodeLabelDeviceUserCode                             // 1070021
+	InfoNodeLabelDeviceApprove                              // 1070022
+	InfoNodeLabelDeviceDeny                                 // 1070023
+	InfoNodeLabelLegalDocument                              // 1070024
+	InfoNodeLabelRevokeSessions                             // 1070025
 )
 
 const (
@@ -125,6 +141,22 @@ const (
 	InfoSelfServiceVerificationPhoneSuccessful                       // 1080005
 )
 
+const (
+	InfoSelfServiceCode                ID = 1090000 + iota // 1090000
+	InfoSelfServiceCodeSentViaFallback                     // 1090001
+)
+
+const (
+	InfoSelfServiceDevice         ID = 1100000 + iota // 1100000
+	InfoSelfServiceDeviceApproved                     // 1100001
+	InfoSelfServiceDeviceDenied                       // 1100002
+)
+
+const (
+	InfoSelfServiceRevokeSessions          ID = 1110000 + iota // 1110000
+	InfoSelfServiceRevokeSessionsSucceeded                     // 1110001
+)
+
 const (
 	ErrorValidation ID = 4000000 + iota
 	ErrorValidationGeneric
@@ -171,6 +203,13 @@ const (
 	ErrorValidationNoDeviceAuthnDevice
 	ErrorValidationWebAuthnVerifierWrong
 	ErrorValidationDeviceAuthnVerifierWrong
+	ErrorValidationDuplicateTrait
+	ErrorValidationPasswordMinLowercase
+	ErrorValidationPasswordMinUppercase
+	ErrorValidationPasswordMinDigits
+	ErrorValidationPasswordMinSymbols
+	ErrorValidationPasswordBannedWord
+	ErrorValidationPasswordReused
 )
 
 const (
@@ -186,6 +225,11 @@ const (
 	ErrorValidationLoginLinkedCredentialsDoNotMatch                     // 4010009
 	ErrorValidationLoginAddressUnknown                                  // 4010010
 	ErrorValidationIdentityDisabled                                     // 4010011
+	ErrorValidationLoginBlocked                                         // 4010012
+	ErrorValidationLoginCrossDevicePending                              // 4010013
+	ErrorValidationLoginCrossDeviceDenied                               // 4010014
+	ErrorValidationLoginCrossDeviceExpired                              // 4010015
+	ErrorValidationIdentityPendingApproval                              // 4010016
 )
 
 const (
@@ -221,6 +265,11 @@ const (
 	ErrorValidationVerificationCodeInvalidOrAlreadyUsed                      // 4070006
 )
 
+const (
+	ErrorValidationDevice                ID = 4080000 + iota // 4080000
+	ErrorValidationDeviceUserCodeInvalid                     // 4080001
+)
+
 const (
 	ErrorSystem                                    ID = 5000000 + iota // 5000000
 	ErrorSystemGeneric                                                 // 5000001
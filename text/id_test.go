@@ -78,9 +78,32 @@ func TestIDs(t *testing.T) {
 	assert.Equal(t, 1080004, int(InfoSelfServiceVerificationPhoneWithCodeSent))
 	assert.Equal(t, 1080005, int(InfoSelfServiceVerificationPhoneSuccessful))
 
+	assert.Equal(t, 1070019, int(InfoNodeLabelSendCodeVia))
+	assert.Equal(t, 1090001, int(InfoSelfServiceCodeSentViaFallback))
+
 	assert.Equal(t, 1070015, int(InfoNodeLabelCaptcha))
 	assert.Equal(t, 4000038, int(ErrorValidationCaptchaError))
 
 	assert.Equal(t, 4000040, int(ErrorValidationEmail))
 	assert.Equal(t, 4000041, int(ErrorValidationPhone))
+	assert.Equal(t, 4000045, int(ErrorValidationDuplicateTrait))
+	assert.Equal(t, 4000051, int(ErrorValidationPasswordReused))
+
+	assert.Equal(t, 1050023, int(InfoSelfServiceSettingsPasswordExpired))
+	assert.Equal(t, 1050024, int(InfoSelfServiceSettingsRemoveTOTP))
+	assert.Equal(t, 1050025, int(InfoSelfServiceSettingsTOTPDeviceName))
+	assert.Equal(t, 1050026, int(InfoSelfServiceSettingsRevokeTrustedDevice))
+	assert.Equal(t, 1050027, int(InfoSelfServiceSettingsLegalDocumentsRequired))
+	assert.Equal(t, 1050028, int(InfoSelfServiceSettingsProfileIncomplete))
+	assert.Equal(t, 1070020, int(InfoNodeLabelRememberDevice))
+	assert.Equal(t, 1070023, int(InfoNodeLabelDeviceDeny))
+	assert.Equal(t, 1070024, int(InfoNodeLabelLegalDocument))
+	assert.Equal(t, 1100002, int(InfoSelfServiceDeviceDenied))
+	assert.Equal(t, 4080001, int(ErrorValidationDeviceUserCodeInvalid))
+	assert.Equal(t, 4010012, int(ErrorValidationLoginBlocked))
+	assert.Equal(t, 1010026, int(InfoSelfServiceLoginCrossDeviceURL))
+	assert.Equal(t, 4010015, int(ErrorValidationLoginCrossDeviceExpired))
+	assert.Equal(t, 4010016, int(ErrorValidationIdentityPendingApproval))
+	assert.Equal(t, 1070025, int(InfoNodeLabelRevokeSessions))
+	assert.Equal(t, 1110001, int(InfoSelfServiceRevokeSessionsSucceeded))
 }
@@ -0,0 +1,18 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package text
+
+import "fmt"
+
+func NewInfoSelfServiceCodeSentViaFallback(maskedAddress, channel string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceCodeSentViaFallback,
+		Type: Info,
+		Text: fmt.Sprintf("We could not deliver the code as requested. It has been sent to %s via %s instead.", maskedAddress, channel),
+		Context: context(map[string]any{
+			"masked_address": maskedAddress,
+			"channel":        channel,
+		}),
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package text
+
+func NewInfoSelfServiceDevice() *Message {
+	return &Message{
+		ID:   InfoSelfServiceDevice,
+		Text: "Enter the code shown on your device to sign it in.",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceDeviceApproved() *Message {
+	return &Message{
+		ID:   InfoSelfServiceDeviceApproved,
+		Text: "You successfully signed in your device. You can return to it now.",
+		Type: Success,
+	}
+}
+
+func NewInfoSelfServiceDeviceDenied() *Message {
+	return &Message{
+		ID:   InfoSelfServiceDeviceDenied,
+		Text: "You denied the sign in of your device.",
+		Type: Info,
+	}
+}
+
+func NewErrorValidationDeviceUserCodeInvalid() *Message {
+	return &Message{
+		ID:   ErrorValidationDeviceUserCodeInvalid,
+		Text: "The device code is invalid, has expired, or has already been used.",
+		Type: Error,
+	}
+}
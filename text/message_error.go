@@ -12,16 +12,27 @@ const (
 	ErrIDSelfServiceBrowserLocationChangeRequiredError = "browser_location_change_required"
 	ErrIDSelfServiceFlowReplaced                       = "self_service_flow_replaced"
 
-	ErrIDAlreadyLoggedIn             = "session_already_available"
-	ErrIDAddressNotVerified          = "session_verified_address_required"
-	ErrIDSessionHasAALAlready        = "session_aal_already_fulfilled"
-	ErrIDSessionRequiredForHigherAAL = "session_aal1_required"
-	ErrIDHigherAALRequired           = "session_aal2_required"
-	ErrIDNoActiveSession             = "session_inactive"
-	ErrIDRedirectURLNotAllowed       = "self_service_flow_return_to_forbidden"
-	ErrIDInitiatedBySomeoneElse      = "security_identity_mismatch"
+	ErrIDAlreadyLoggedIn              = "session_already_available"
+	ErrIDAddressNotVerified           = "session_verified_address_required"
+	ErrIDSessionHasAALAlready         = "session_aal_already_fulfilled"
+	ErrIDSessionRequiredForHigherAAL  = "session_aal1_required"
+	ErrIDHigherAALRequired            = "session_aal2_required"
+	ErrIDNoActiveSession              = "session_inactive"
+	ErrIDRedirectURLNotAllowed        = "self_service_flow_return_to_forbidden"
+	ErrIDInitiatedBySomeoneElse       = "security_identity_mismatch"
+	ErrIDSessionImpersonated          = "session_impersonated"
+	ErrIDSessionProfileIncomplete     = "session_profile_incomplete"
+	ErrIDSessionLegalDocumentsPending = "session_legal_documents_pending"
+	ErrIDSessionPasswordExpired       = "session_password_expired"
 
-	ErrIDIdentityDisabled = "identity_disabled"
+	ErrIDIdentityDisabled        = "identity_disabled"
+	ErrIDIdentityPendingApproval = "identity_pending_approval"
+	ErrIDLoginBlocked            = "security_login_blocked"
 
 	ErrIDCSRF = "security_csrf_violation"
+
+	ErrIDDeviceAuthorizationPending = "authorization_pending"
+	ErrIDDeviceSlowDown             = "slow_down"
+	ErrIDDeviceAccessDenied         = "access_denied"
+	ErrIDDeviceExpiredToken         = "expired_token"
 )
@@ -208,6 +208,25 @@ func NewInfoSelfServiceLoginDeviceAuthn() *Message {
 	}
 }
 
+func NewInfoSelfServiceLoginCrossDevice() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginCrossDevice,
+		Text: "Scan the QR code with a device on which you are signed in",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceLoginCrossDeviceURL(url string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginCrossDeviceURL,
+		Text: url,
+		Type: Info,
+		Context: context(map[string]any{
+			"url": url,
+		}),
+	}
+}
+
 func NewInfoSelfServiceLoginPasskey() *Message {
 	return &Message{
 		ID:   InfoSelfServiceLoginPasskey,
@@ -307,3 +326,43 @@ func NewErrorValidationIdentityDisabled() *Message {
 		Type: Error,
 	}
 }
+
+func NewErrorValidationIdentityPendingApproval() *Message {
+	return &Message{
+		ID:   ErrorValidationIdentityPendingApproval,
+		Text: "This account is awaiting approval. You will receive an email once it has been reviewed.",
+		Type: Error,
+	}
+}
+
+func NewErrorValidationLoginBlocked() *Message {
+	return &Message{
+		ID:   ErrorValidationLoginBlocked,
+		Text: "This sign-in was blocked because it looks unusual. Please try again later or contact support for assistance.",
+		Type: Error,
+	}
+}
+
+func NewErrorValidationLoginCrossDevicePending() *Message {
+	return &Message{
+		ID:   ErrorValidationLoginCrossDevicePending,
+		Text: "Approve the sign in on your other device to continue.",
+		Type: Error,
+	}
+}
+
+func NewErrorValidationLoginCrossDeviceDenied() *Message {
+	return &Message{
+		ID:   ErrorValidationLoginCrossDeviceDenied,
+		Text: "The sign in was denied on your other device. Scan the new QR code to try again.",
+		Type: Error,
+	}
+}
+
+func NewErrorValidationLoginCrossDeviceExpired() *Message {
+	return &Message{
+		ID:   ErrorValidationLoginCrossDeviceExpired,
+		Text: "The QR code has expired. Scan the new QR code to continue.",
+		Type: Error,
+	}
+}
@@ -3,6 +3,8 @@
 
 package text
 
+import "fmt"
+
 func NewInfoNodeLabelVerifyOTP() *Message {
 	return &Message{
 		ID:   InfoNodeLabelVerifyOTP,
@@ -134,3 +136,67 @@ func NewInfoNodeLoginAndLinkCredential() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoNodeLabelRememberDevice() *Message {
+	return &Message{
+		ID:   InfoNodeLabelRememberDevice,
+		Text: "Remember this device",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelDeviceUserCode() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceUserCode,
+		Text: "Device code",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelDeviceApprove() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceApprove,
+		Text: "Allow",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelDeviceDeny() *Message {
+	return &Message{
+		ID:   InfoNodeLabelDeviceDeny,
+		Text: "Deny",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelRevokeSessions() *Message {
+	return &Message{
+		ID:   InfoNodeLabelRevokeSessions,
+		Text: "Sign out everywhere",
+		Type: Info,
+	}
+}
+
+func NewInfoNodeLabelLegalDocument(title, version, url string) *Message {
+	return &Message{
+		ID:   InfoNodeLabelLegalDocument,
+		Text: fmt.Sprintf("I accept the %s (version %s)", title, version),
+		Type: Info,
+		Context: context(map[string]any{
+			"title":   title,
+			"version": version,
+			"url":     url,
+		}),
+	}
+}
+
+func NewInfoNodeLabelSendCodeVia(channel string) *Message {
+	return &Message{
+		ID:   InfoNodeLabelSendCodeVia,
+		Text: fmt.Sprintf("Send code via %s", channel),
+		Type: Info,
+		Context: context(map[string]any{
+			"channel": channel,
+		}),
+	}
+}
@@ -111,3 +111,11 @@ func NewInfoSelfServiceRegistrationRegisterCode() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoSelfServiceRegistrationPendingApproval() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRegistrationPendingApproval,
+		Text: "Your registration was received and is awaiting approval. You will receive an email once it has been reviewed.",
+		Type: Info,
+	}
+}
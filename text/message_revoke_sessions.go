@@ -0,0 +1,20 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package text
+
+func NewInfoSelfServiceRevokeSessions() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRevokeSessions,
+		Text: "If you did not make the change you were notified about, sign out of all devices and sign in again to secure your account.",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceRevokeSessionsSucceeded() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRevokeSessionsSucceeded,
+		Text: "You were signed out of all devices. Sign in again to continue.",
+		Type: Success,
+	}
+}
@@ -71,6 +71,52 @@ func NewInfoSelfServiceSettingsUpdateUnlinkTOTP() *Message {
 	}
 }
 
+func NewInfoSelfServiceSettingsTOTPDeviceName() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsTOTPDeviceName,
+		Text: "Name of the authenticator app",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceRemoveTOTP(name string, addedAt time.Time, lastUsedAt *time.Time) *Message {
+	ctx := map[string]any{
+		"display_name":  name,
+		"added_at":      addedAt,
+		"added_at_unix": addedAt.Unix(),
+	}
+	if lastUsedAt != nil {
+		ctx["last_used_at"] = *lastUsedAt
+		ctx["last_used_at_unix"] = lastUsedAt.Unix()
+	}
+
+	return &Message{
+		ID:      InfoSelfServiceSettingsRemoveTOTP,
+		Text:    fmt.Sprintf("Remove authenticator app \"%s\"", name),
+		Type:    Info,
+		Context: context(ctx),
+	}
+}
+
+func NewInfoSelfServiceRevokeTrustedDevice(userAgent string, createdAt time.Time, lastUsedAt *time.Time) *Message {
+	ctx := map[string]any{
+		"user_agent":      userAgent,
+		"created_at":      createdAt,
+		"created_at_unix": createdAt.Unix(),
+	}
+	if lastUsedAt != nil {
+		ctx["last_used_at"] = *lastUsedAt
+		ctx["last_used_at_unix"] = lastUsedAt.Unix()
+	}
+
+	return &Message{
+		ID:      InfoSelfServiceSettingsRevokeTrustedDevice,
+		Text:    fmt.Sprintf("Revoke trusted device \"%s\"", userAgent),
+		Type:    Info,
+		Context: context(ctx),
+	}
+}
+
 func NewInfoSelfServiceSettingsRevealLookup() *Message {
 	return &Message{
 		ID:   InfoSelfServiceSettingsRevealLookup,
@@ -215,3 +261,30 @@ func NewInfoSelfServiceRemovePasskey(name string, createdAt time.Time) *Message
 		}),
 	}
 }
+
+func NewInfoSelfServiceSettingsPasswordExpired() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsPasswordExpired,
+		Text: "Your password has expired. Please choose a new password.",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsLegalDocumentsRequired() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsLegalDocumentsRequired,
+		Text: "Our legal documents have changed. Please review and accept them to continue.",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsProfileIncomplete(traits []string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsProfileIncomplete,
+		Text: "Please complete your profile to continue.",
+		Type: Info,
+		Context: context(map[string]any{
+			"traits": traits,
+		}),
+	}
+}
@@ -281,6 +281,73 @@ func NewErrorValidationPasswordTooManyBreaches(breaches int64) *Message {
 	}
 }
 
+func NewErrorValidationPasswordMinLowercase(minCount, actualCount int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinLowercase,
+		Text: fmt.Sprintf("The password must contain at least %d lowercase letters, but got %d.", minCount, actualCount),
+		Type: Error,
+		Context: context(map[string]any{
+			"min_count":    minCount,
+			"actual_count": actualCount,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordMinUppercase(minCount, actualCount int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinUppercase,
+		Text: fmt.Sprintf("The password must contain at least %d uppercase letters, but got %d.", minCount, actualCount),
+		Type: Error,
+		Context: context(map[string]any{
+			"min_count":    minCount,
+			"actual_count": actualCount,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordMinDigits(minCount, actualCount int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinDigits,
+		Text: fmt.Sprintf("The password must contain at least %d digits, but got %d.", minCount, actualCount),
+		Type: Error,
+		Context: context(map[string]any{
+			"min_count":    minCount,
+			"actual_count": actualCount,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordMinSymbols(minCount, actualCount int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinSymbols,
+		Text: fmt.Sprintf("The password must contain at least %d symbols, but got %d.", minCount, actualCount),
+		Type: Error,
+		Context: context(map[string]any{
+			"min_count":    minCount,
+			"actual_count": actualCount,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordBannedWord() *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordBannedWord,
+		Text: "The password can not be used because it contains a word which is not allowed.",
+		Type: Error,
+	}
+}
+
+func NewErrorValidationPasswordReused(historySize int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordReused,
+		Text: fmt.Sprintf("The new password must be different from the last %d passwords.", historySize),
+		Type: Error,
+		Context: context(map[string]any{
+			"history_size": historySize,
+		}),
+	}
+}
+
 func NewErrorValidationInvalidCredentials() *Message {
 	return &Message{
 		ID:   ErrorValidationInvalidCredentials,
@@ -398,6 +465,17 @@ func NewErrorValidationDeviceAuthnVerifierWrong() *Message {
 	}
 }
 
+func NewErrorValidationDuplicateTrait(property string) *Message {
+	return &Message{
+		ID:   ErrorValidationDuplicateTrait,
+		Text: fmt.Sprintf("The value of %s is already in use by another account.", property),
+		Type: Error,
+		Context: context(map[string]any{
+			"property": property,
+		}),
+	}
+}
+
 func NewErrorValidationLookupAlreadyUsed() *Message {
 	return &Message{
 		ID:   ErrorValidationLookupAlreadyUsed,
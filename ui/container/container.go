@@ -183,6 +183,12 @@ func (c *Container) ParseError(group node.UiNodeGroup, err error) error {
 		case text.ErrIDIdentityDisabled:
 			c.AddMessage(group, text.NewErrorValidationIdentityDisabled())
 			return nil
+		case text.ErrIDIdentityPendingApproval:
+			c.AddMessage(group, text.NewErrorValidationIdentityPendingApproval())
+			return nil
+		case text.ErrIDLoginBlocked:
+			c.AddMessage(group, text.NewErrorValidationLoginBlocked())
+			return nil
 		default:
 			if e.StatusCode() == http.StatusBadRequest {
 				c.AddMessage(group, text.NewValidationErrorGeneric(e.Reason()))
@@ -4,10 +4,12 @@
 package node
 
 const (
-	TOTPCode      = "totp_code"
-	TOTPSecretKey = "totp_secret_key"
-	TOTPQR        = "totp_qr"
-	TOTPUnlink    = "totp_unlink"
+	TOTPCode       = "totp_code"
+	TOTPSecretKey  = "totp_secret_key"
+	TOTPQR         = "totp_qr"
+	TOTPUnlink     = "totp_unlink"
+	TOTPRemove     = "totp_remove"
+	TOTPDeviceName = "totp_device_name"
 )
 
 const (
@@ -33,6 +35,29 @@ const (
 	WebAuthnScript              = "webauthn_script"
 )
 
+const (
+	RememberDevice      = "remember_device"
+	TrustedDeviceRevoke = "trusted_device_revoke"
+)
+
+const (
+	LegalDocuments = "legal_documents"
+)
+
+const (
+	CrossDeviceQR  = "cross_device_qr"
+	CrossDeviceURL = "cross_device_url"
+)
+
+const (
+	DeviceUserCode = "user_code"
+	DeviceAction   = "action"
+)
+
+const (
+	RevokeSessionsAction = "action"
+)
+
 const (
 	DeviceAuthnRemove = "deviceauthn_remove"
 	DeviceAuthnNonce  = "deviceauthn_nonce"
@@ -54,6 +54,9 @@ const (
 	CaptchaGroup         UiNodeGroup = "captcha" // Available in OEL
 	SAMLGroup            UiNodeGroup = "saml"    // Available in OEL
 	DeviceAuthnGroup     UiNodeGroup = "deviceauthn"
+	TrustedDeviceGroup   UiNodeGroup = "trusted_device"
+	CrossDeviceGroup     UiNodeGroup = "cross_device"
+	LegalGroup           UiNodeGroup = "legal"
 )
 
 func (g UiNodeGroup) String() string {
@@ -379,6 +382,7 @@ func (n *Nodes) ClearTransientNodes() {
 		PasskeyGroup,
 		IdentifierFirstGroup,
 		SAMLGroup,
+		CrossDeviceGroup,
 	)
 }
 
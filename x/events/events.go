@@ -21,43 +21,70 @@ import (
 )
 
 const (
-	IdentityCreated          semconv.Event = "IdentityCreated"
-	IdentityDeleted          semconv.Event = "IdentityDeleted"
-	IdentityUpdated          semconv.Event = "IdentityUpdated"
-	JsonnetMappingFailed     semconv.Event = "JsonnetMappingFailed"
-	LoginFailed              semconv.Event = "LoginFailed"
-	LoginInitiated           semconv.Event = "LoginInitiated"
-	LoginSucceeded           semconv.Event = "LoginSucceeded"
-	RecoveryFailed           semconv.Event = "RecoveryFailed"
-	RecoveryInitiatedByAdmin semconv.Event = "RecoveryInitiatedByAdmin"
-	RecoverySucceeded        semconv.Event = "RecoverySucceeded"
-	RegistrationFailed       semconv.Event = "RegistrationFailed"
-	RegistrationInitiated    semconv.Event = "RegistrationInitiated"
-	RegistrationSucceeded    semconv.Event = "RegistrationSucceeded"
-	SessionChanged           semconv.Event = "SessionChanged"
-	SessionChecked           semconv.Event = "SessionChecked"
-	SessionIssued            semconv.Event = "SessionIssued"
-	SessionLifespanExtended  semconv.Event = "SessionLifespanExtended"
-	SessionRevoked           semconv.Event = "SessionRevoked"
-	SessionTokenizedAsJWT    semconv.Event = "SessionTokenizedAsJWT"
-	SettingsFailed           semconv.Event = "SettingsFailed"
-	SettingsSucceeded        semconv.Event = "SettingsSucceeded"
-	VerificationFailed       semconv.Event = "VerificationFailed"
-	VerificationSucceeded    semconv.Event = "VerificationSucceeded"
-	WebhookDelivered         semconv.Event = "WebhookDelivered"
-	WebhookFailed            semconv.Event = "WebhookFailed"
-	WebhookSucceeded         semconv.Event = "WebhookSucceeded"
-	CourierMessageAbandoned  semconv.Event = "CourierMessageAbandoned"
-	CourierMessageDispatched semconv.Event = "CourierMessageDispatched"
+	APIKeyCreated               semconv.Event = "APIKeyCreated"
+	APIKeyRevoked               semconv.Event = "APIKeyRevoked"
+	DeviceAuthorizationApproved semconv.Event = "DeviceAuthorizationApproved"
+	DeviceAuthorizationDenied   semconv.Event = "DeviceAuthorizationDenied"
+	IdentityCreated             semconv.Event = "IdentityCreated"
+	IdentityCredentialsLinked   semconv.Event = "IdentityCredentialsLinked"
+	IdentityCredentialsUnlinked semconv.Event = "IdentityCredentialsUnlinked"
+	IdentityDeleted             semconv.Event = "IdentityDeleted"
+	IdentityJobCanceled         semconv.Event = "IdentityJobCanceled"
+	IdentityJobCreated          semconv.Event = "IdentityJobCreated"
+	IdentityUpdated             semconv.Event = "IdentityUpdated"
+	ImpersonationBlocked        semconv.Event = "ImpersonationBlocked"
+	ImpersonationStarted        semconv.Event = "ImpersonationStarted"
+	InvitationAccepted          semconv.Event = "InvitationAccepted"
+	InvitationCreated           semconv.Event = "InvitationCreated"
+	InvitationRevoked           semconv.Event = "InvitationRevoked"
+	JsonnetMappingFailed        semconv.Event = "JsonnetMappingFailed"
+	LegalDocumentAccepted       semconv.Event = "LegalDocumentAccepted"
+	LoginFailed                 semconv.Event = "LoginFailed"
+	LoginInitiated              semconv.Event = "LoginInitiated"
+	LoginSucceeded              semconv.Event = "LoginSucceeded"
+	RecoveryFailed              semconv.Event = "RecoveryFailed"
+	RecoveryInitiatedByAdmin    semconv.Event = "RecoveryInitiatedByAdmin"
+	RecoverySucceeded           semconv.Event = "RecoverySucceeded"
+	RegistrationApproved        semconv.Event = "RegistrationApproved"
+	RegistrationFailed          semconv.Event = "RegistrationFailed"
+	RegistrationInitiated       semconv.Event = "RegistrationInitiated"
+	RegistrationPendingApproval semconv.Event = "RegistrationPendingApproval"
+	RegistrationRejected        semconv.Event = "RegistrationRejected"
+	RegistrationSucceeded       semconv.Event = "RegistrationSucceeded"
+	SessionChanged              semconv.Event = "SessionChanged"
+	SessionChecked              semconv.Event = "SessionChecked"
+	SessionIssued               semconv.Event = "SessionIssued"
+	SessionLifespanExtended     semconv.Event = "SessionLifespanExtended"
+	SessionRevoked              semconv.Event = "SessionRevoked"
+	SessionTokenizedAsJWT       semconv.Event = "SessionTokenizedAsJWT"
+	SettingsFailed              semconv.Event = "SettingsFailed"
+	SettingsSucceeded           semconv.Event = "SettingsSucceeded"
+	VerificationFailed          semconv.Event = "VerificationFailed"
+	VerificationSucceeded       semconv.Event = "VerificationSucceeded"
+	WebhookDelivered            semconv.Event = "WebhookDelivered"
+	WebhookFailed               semconv.Event = "WebhookFailed"
+	WebhookSucceeded            semconv.Event = "WebhookSucceeded"
+	CourierMessageAbandoned     semconv.Event = "CourierMessageAbandoned"
+	CourierMessageDispatched    semconv.Event = "CourierMessageDispatched"
 )
 
 const (
+	AttributeKeyAPIKeyID                        semconv.AttributeKey = "APIKeyID"
+	AttributeKeyCredentialsLinkSource           semconv.AttributeKey = "CredentialsLinkSource"
 	AttributeKeyErrorReason                     semconv.AttributeKey = "ErrorReason"
 	AttributeKeyFlowID                          semconv.AttributeKey = "FlowID"
 	AttributeKeyFlowRefresh                     semconv.AttributeKey = "FlowRefresh"
 	AttributeKeyFlowRequestedAAL                semconv.AttributeKey = "FlowRequestedAAL"
+	AttributeKeyIdentityJobAction               semconv.AttributeKey = "IdentityJobAction"
+	AttributeKeyIdentityJobID                   semconv.AttributeKey = "IdentityJobID"
+	AttributeKeyImpersonationAction             semconv.AttributeKey = "ImpersonationAction"
+	AttributeKeyImpersonationActor              semconv.AttributeKey = "ImpersonationActor"
+	AttributeKeyImpersonationReason             semconv.AttributeKey = "ImpersonationReason"
+	AttributeKeyInvitationID                    semconv.AttributeKey = "InvitationID"
 	AttributeKeyJsonnetInput                    semconv.AttributeKey = "JsonnetInput"
 	AttributeKeyJsonnetOutput                   semconv.AttributeKey = "JsonnetOutput"
+	AttributeKeyLegalDocumentID                 semconv.AttributeKey = "LegalDocumentID"
+	AttributeKeyLegalDocumentVersion            semconv.AttributeKey = "LegalDocumentVersion"
 	AttributeKeyLoginRequestedAAL               semconv.AttributeKey = "LoginRequestedAAL"
 	AttributeKeyLoginRequestedPrivilegedSession semconv.AttributeKey = "LoginRequestedPrivilegedSession"
 	AttributeKeyOrganizationID                  semconv.AttributeKey = "OrganizationID"
@@ -94,6 +121,28 @@ func attrSessionID(val uuid.UUID) otelattr.KeyValue {
 	return otelattr.String(AttributeKeySessionID.String(), val.String())
 }
 
+// Sources of linked or unlinked OIDC and SAML credentials.
+const (
+	CredentialsLinkSourceAutomaticAccountLinking = "automatic_account_linking"
+	CredentialsLinkSourceAdminAPI                = "admin_api"
+)
+
+func attrCredentialsLinkSource(val string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyCredentialsLinkSource.String(), val)
+}
+
+func attrImpersonationAction(val string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyImpersonationAction.String(), val)
+}
+
+func attrImpersonationActor(val string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyImpersonationActor.String(), val)
+}
+
+func attrImpersonationReason(val string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyImpersonationReason.String(), val)
+}
+
 func attrTokenizedSessionTTL(ttl time.Duration) otelattr.KeyValue {
 	return otelattr.String(AttributeKeyTokenizedSessionTTL.String(), ttl.String())
 }
@@ -415,6 +464,32 @@ func NewIdentityUpdated(ctx context.Context, identityID uuid.UUID) (string, trac
 		)
 }
 
+func NewIdentityCredentialsLinked(ctx context.Context, identityID uuid.UUID, method, provider, source string) (string, trace.EventOption) {
+	return IdentityCredentialsLinked.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSelfServiceMethodUsed(method),
+				attrSelfServiceSSOProviderUsed(provider),
+				attrCredentialsLinkSource(source),
+			)...,
+		)
+}
+
+func NewIdentityCredentialsUnlinked(ctx context.Context, identityID uuid.UUID, method, provider, source string) (string, trace.EventOption) {
+	return IdentityCredentialsUnlinked.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSelfServiceMethodUsed(method),
+				attrSelfServiceSSOProviderUsed(provider),
+				attrCredentialsLinkSource(source),
+			)...,
+		)
+}
+
 func NewLoginFailed(ctx context.Context, flowID uuid.UUID, flowType, method, requestedAAL string, isRefresh bool, err error) (string, trace.EventOption) {
 	attrs := append(
 		semconv.AttributesFromContext(ctx),
@@ -446,6 +521,186 @@ func NewSessionRevoked(ctx context.Context, sessionID, identityID uuid.UUID) (st
 		)
 }
 
+func NewImpersonationStarted(ctx context.Context, sessionID, identityID uuid.UUID, actor, reason string, expiresAt time.Time) (string, trace.EventOption) {
+	return ImpersonationStarted.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+				attrImpersonationActor(actor),
+				attrImpersonationReason(reason),
+				attSessionExpiresAt(expiresAt),
+			)...,
+		)
+}
+
+func NewImpersonationBlocked(ctx context.Context, sessionID, identityID uuid.UUID, actor, action string) (string, trace.EventOption) {
+	return ImpersonationBlocked.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+				attrImpersonationActor(actor),
+				attrImpersonationAction(action),
+			)...,
+		)
+}
+
+func NewDeviceAuthorizationApproved(ctx context.Context, flowID, identityID uuid.UUID) (string, trace.EventOption) {
+	return DeviceAuthorizationApproved.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrFlowID(flowID),
+			)...,
+		)
+}
+
+func NewDeviceAuthorizationDenied(ctx context.Context, flowID, identityID uuid.UUID) (string, trace.EventOption) {
+	return DeviceAuthorizationDenied.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrFlowID(flowID),
+			)...,
+		)
+}
+
+func attrInvitationID(id uuid.UUID) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyInvitationID.String(), id.String())
+}
+
+func NewInvitationCreated(ctx context.Context, invitationID, identityID uuid.UUID) (string, trace.EventOption) {
+	return InvitationCreated.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrInvitationID(invitationID),
+			)...,
+		)
+}
+
+func NewInvitationAccepted(ctx context.Context, invitationID, identityID uuid.UUID) (string, trace.EventOption) {
+	return InvitationAccepted.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrInvitationID(invitationID),
+			)...,
+		)
+}
+
+func NewInvitationRevoked(ctx context.Context, invitationID, identityID uuid.UUID) (string, trace.EventOption) {
+	return InvitationRevoked.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrInvitationID(invitationID),
+			)...,
+		)
+}
+
+func attrAPIKeyID(id uuid.UUID) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyAPIKeyID.String(), id.String())
+}
+
+func NewAPIKeyCreated(ctx context.Context, keyID uuid.UUID) (string, trace.EventOption) {
+	return APIKeyCreated.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				attrAPIKeyID(keyID),
+			)...,
+		)
+}
+
+func NewAPIKeyRevoked(ctx context.Context, keyID uuid.UUID) (string, trace.EventOption) {
+	return APIKeyRevoked.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				attrAPIKeyID(keyID),
+			)...,
+		)
+}
+
+func attrIdentityJob(id uuid.UUID, action string) []otelattr.KeyValue {
+	return []otelattr.KeyValue{
+		otelattr.String(AttributeKeyIdentityJobID.String(), id.String()),
+		otelattr.String(AttributeKeyIdentityJobAction.String(), action),
+	}
+}
+
+func NewIdentityJobCreated(ctx context.Context, jobID uuid.UUID, action string) (string, trace.EventOption) {
+	return IdentityJobCreated.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				attrIdentityJob(jobID, action)...,
+			)...,
+		)
+}
+
+func NewIdentityJobCanceled(ctx context.Context, jobID uuid.UUID, action string) (string, trace.EventOption) {
+	return IdentityJobCanceled.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				attrIdentityJob(jobID, action)...,
+			)...,
+		)
+}
+
+func NewLegalDocumentAccepted(ctx context.Context, identityID uuid.UUID, documentID, version string) (string, trace.EventOption) {
+	return LegalDocumentAccepted.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				otelattr.String(AttributeKeyLegalDocumentID.String(), documentID),
+				otelattr.String(AttributeKeyLegalDocumentVersion.String(), version),
+			)...,
+		)
+}
+
+func NewRegistrationPendingApproval(ctx context.Context, flowID, identityID uuid.UUID) (string, trace.EventOption) {
+	return RegistrationPendingApproval.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrFlowID(flowID),
+			)...,
+		)
+}
+
+func NewRegistrationApproved(ctx context.Context, identityID uuid.UUID) (string, trace.EventOption) {
+	return RegistrationApproved.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+			)...,
+		)
+}
+
+func NewRegistrationRejected(ctx context.Context, identityID uuid.UUID) (string, trace.EventOption) {
+	return RegistrationRejected.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+			)...,
+		)
+}
+
 func NewSessionChecked(ctx context.Context, sessionID, identityID uuid.UUID) (string, trace.EventOption) {
 	return SessionChecked.String(),
 		trace.WithAttributes(
@@ -6,6 +6,7 @@ package events_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
@@ -229,3 +230,57 @@ func TestNewVerificationFailed(t *testing.T) {
 		assert.Contains(t, attrs, attribute.String("SelfServiceMethodUsed", "link"))
 	})
 }
+
+func TestNewImpersonation(t *testing.T) {
+	ctx := t.Context()
+	sessionID := uuid.Must(uuid.NewV4())
+	identityID := uuid.Must(uuid.NewV4())
+
+	t.Run("case=started", func(t *testing.T) {
+		eventName, opts := events.NewImpersonationStarted(ctx, sessionID, identityID, "admin@example.org", "ticket 42", time.Now().Add(time.Hour))
+
+		assert.Equal(t, events.ImpersonationStarted.String(), eventName)
+
+		eventConfig := trace.NewEventConfig(opts)
+		attrs := eventConfig.Attributes()
+		assert.Contains(t, attrs, attribute.String("SessionID", sessionID.String()))
+		assert.Contains(t, attrs, attribute.String("IdentityID", identityID.String()))
+		assert.Contains(t, attrs, attribute.String("ImpersonationActor", "admin@example.org"))
+		assert.Contains(t, attrs, attribute.String("ImpersonationReason", "ticket 42"))
+	})
+
+	t.Run("case=blocked", func(t *testing.T) {
+		eventName, opts := events.NewImpersonationBlocked(ctx, sessionID, identityID, "admin@example.org", "settings")
+
+		assert.Equal(t, events.ImpersonationBlocked.String(), eventName)
+
+		eventConfig := trace.NewEventConfig(opts)
+		attrs := eventConfig.Attributes()
+		assert.Contains(t, attrs, attribute.String("SessionID", sessionID.String()))
+		assert.Contains(t, attrs, attribute.String("ImpersonationActor", "admin@example.org"))
+		assert.Contains(t, attrs, attribute.String("ImpersonationAction", "settings"))
+	})
+}
+
+func TestNewIdentityCredentialsLinked(t *testing.T) {
+	ctx := t.Context()
+	identityID := uuid.Must(uuid.NewV4())
+
+	eventName, opts := events.NewIdentityCredentialsLinked(ctx, identityID, "oidc", "google", events.CredentialsLinkSourceAutomaticAccountLinking)
+	assert.Equal(t, events.IdentityCredentialsLinked.String(), eventName)
+
+	eventConfig := trace.NewEventConfig(opts)
+	attrs := eventConfig.Attributes()
+	assert.Contains(t, attrs, attribute.String("IdentityID", identityID.String()))
+	assert.Contains(t, attrs, attribute.String("SelfServiceMethodUsed", "oidc"))
+	assert.Contains(t, attrs, attribute.String("SelfServiceSSOProviderUsed", "google"))
+	assert.Contains(t, attrs, attribute.String("CredentialsLinkSource", "automatic_account_linking"))
+
+	eventName, opts = events.NewIdentityCredentialsUnlinked(ctx, identityID, "saml", "okta", events.CredentialsLinkSourceAdminAPI)
+	assert.Equal(t, events.IdentityCredentialsUnlinked.String(), eventName)
+
+	eventConfig = trace.NewEventConfig(opts)
+	attrs = eventConfig.Attributes()
+	assert.Contains(t, attrs, attribute.String("SelfServiceMethodUsed", "saml"))
+	assert.Contains(t, attrs, attribute.String("CredentialsLinkSource", "admin_api"))
+}